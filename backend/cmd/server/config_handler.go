@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/recreate-run/nova-simulators/internal/config"
+	"github.com/recreate-run/nova-simulators/internal/middleware"
 )
 
 // ConfigHandler serves the configuration API endpoints
@@ -23,16 +24,22 @@ func NewConfigHandler(configManager *config.Manager) *ConfigHandler {
 
 // ConfigRequest represents the request body for updating config
 type ConfigRequest struct {
-	Timeout   config.TimeoutConfig   `json:"timeout"`
-	RateLimit config.RateLimitConfig `json:"rate_limit"`
+	Timeout    config.TimeoutConfig     `json:"timeout"`
+	RateLimit  config.RateLimitConfig   `json:"rate_limit"`
+	Validation *config.ValidationConfig `json:"validation,omitempty"`
+	Faults     *config.FaultsConfig     `json:"faults,omitempty"`
+	Headers    *config.HeadersConfig    `json:"headers,omitempty"`
 }
 
 // ConfigResponse represents the response body for config requests
 type ConfigResponse struct {
 	SessionID  string                 `json:"session_id"`
 	Simulator  string                 `json:"simulator"`
-	Timeout    config.TimeoutConfig   `json:"timeout"`
-	RateLimit  config.RateLimitConfig `json:"rate_limit"`
+	Timeout    config.TimeoutConfig    `json:"timeout"`
+	RateLimit  config.RateLimitConfig  `json:"rate_limit"`
+	Validation config.ValidationConfig `json:"validation"`
+	Faults     config.FaultsConfig     `json:"faults"`
+	Headers    config.HeadersConfig    `json:"headers"`
 }
 
 // ServeHTTP implements http.Handler interface
@@ -77,21 +84,25 @@ func (h *ConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "Invalid URL format", http.StatusBadRequest)
 }
 
-func (h *ConfigHandler) handleGetConfig(w http.ResponseWriter, _ *http.Request, sessionID, simulator string) {
-	ctx := context.Background()
+func (h *ConfigHandler) handleGetConfig(w http.ResponseWriter, r *http.Request, sessionID, simulator string) {
+	response := effectiveConfig(r.Context(), h.configManager, sessionID, simulator)
 
-	timeout := h.configManager.GetTimeoutConfig(ctx, sessionID, simulator)
-	rateLimit := h.configManager.GetRateLimitConfig(ctx, sessionID, simulator)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
 
-	response := ConfigResponse{
+// effectiveConfig merges a session's overrides for a simulator over the defaults.
+// An empty session ID yields the defaults alone.
+func effectiveConfig(ctx context.Context, configManager *config.Manager, sessionID, simulator string) ConfigResponse {
+	return ConfigResponse{
 		SessionID:  sessionID,
 		Simulator:  simulator,
-		Timeout:    *timeout,
-		RateLimit:  *rateLimit,
+		Timeout:    *configManager.GetTimeoutConfig(ctx, sessionID, simulator),
+		RateLimit:  *configManager.GetRateLimitConfig(ctx, sessionID, simulator),
+		Validation: *configManager.GetValidationConfig(ctx, sessionID, simulator),
+		Faults:     *configManager.GetFaultsConfig(ctx, sessionID, simulator),
+		Headers:    *configManager.GetHeadersConfig(ctx, sessionID, simulator),
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
 }
 
 func (h *ConfigHandler) handleSetConfig(w http.ResponseWriter, r *http.Request, sessionID, simulator string) {
@@ -100,6 +111,16 @@ func (h *ConfigHandler) handleSetConfig(w http.ResponseWriter, r *http.Request,
 		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if req.Faults != nil && (req.Faults.TruncateRate < 0 || req.Faults.TruncateRate > 1) {
+		http.Error(w, "Invalid faults config: truncate rate must be between 0 and 1", http.StatusBadRequest)
+		return
+	}
+	if req.Headers != nil {
+		if msg := validateHeaderRules(req.Headers.Rules); msg != "" {
+			http.Error(w, "Invalid headers config: "+msg, http.StatusBadRequest)
+			return
+		}
+	}
 
 	ctx := context.Background()
 	if err := h.configManager.SetSessionConfig(ctx, sessionID, simulator, &req.Timeout, &req.RateLimit); err != nil {
@@ -107,11 +128,38 @@ func (h *ConfigHandler) handleSetConfig(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	// Validation is optional in the request; omitting it keeps the current setting
+	if req.Validation != nil {
+		if err := h.configManager.SetValidationConfig(ctx, sessionID, simulator, req.Validation); err != nil {
+			http.Error(w, "Failed to set config: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Faults are optional too and stay off unless requested
+	if req.Faults != nil {
+		if err := h.configManager.SetFaultsConfig(ctx, sessionID, simulator, req.Faults); err != nil {
+			http.Error(w, "Failed to set config: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Injected response headers are optional as well
+	if req.Headers != nil {
+		if err := h.configManager.SetHeadersConfig(ctx, sessionID, simulator, req.Headers); err != nil {
+			http.Error(w, "Failed to set config: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	response := ConfigResponse{
 		SessionID:  sessionID,
 		Simulator:  simulator,
 		Timeout:    req.Timeout,
 		RateLimit:  req.RateLimit,
+		Validation: *h.configManager.GetValidationConfig(ctx, sessionID, simulator),
+		Faults:     *h.configManager.GetFaultsConfig(ctx, sessionID, simulator),
+		Headers:    *h.configManager.GetHeadersConfig(ctx, sessionID, simulator),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -141,6 +189,8 @@ func (h *ConfigHandler) handleListConfigs(w http.ResponseWriter, _ *http.Request
 	// Convert database configs to response format
 	responses := make([]ConfigResponse, 0, len(configs))
 	for _, cfg := range configs {
+		// Rows without header rules fall back to the simulator default
+		headers := h.configManager.GetHeadersConfig(ctx, cfg.SessionID, cfg.SimulatorName)
 		responses = append(responses, ConfigResponse{
 			SessionID: cfg.SessionID,
 			Simulator: cfg.SimulatorName,
@@ -152,6 +202,15 @@ func (h *ConfigHandler) handleListConfigs(w http.ResponseWriter, _ *http.Request
 				PerMinute: int(cfg.RateLimitPerMinute),
 				PerDay:    int(cfg.RateLimitPerDay),
 			},
+			Validation: config.ValidationConfig{
+				Enabled:           cfg.ValidationEnabled != 0,
+				StrictContentType: cfg.ValidationStrictContentType != 0,
+			},
+			Faults: config.FaultsConfig{
+				TruncateRate:         cfg.FaultTruncateRate,
+				SignatureSkewSeconds: int(cfg.FaultSignatureSkewSeconds),
+			},
+			Headers: *headers,
 		})
 	}
 
@@ -160,3 +219,22 @@ func (h *ConfigHandler) handleListConfigs(w http.ResponseWriter, _ *http.Request
 		"configs": responses,
 	})
 }
+
+// validateHeaderRules returns a description of the first invalid header rule, or "" if all are valid
+func validateHeaderRules(rules []config.HeaderRule) string {
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Status != 0 && (rule.Status < 100 || rule.Status > 599) {
+			return "status must be between 100 and 599"
+		}
+		if rule.Path != "" && !middleware.ValidOverridePattern(rule.Path) {
+			return "path must be a pattern starting with /"
+		}
+		for name := range rule.Headers {
+			if name == "" {
+				return "header names must not be empty"
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/config"
+)
+
+// EffectiveConfigHandler reports the configuration a simulator actually runs with
+type EffectiveConfigHandler struct {
+	configManager *config.Manager
+	simulators    []Simulator
+}
+
+// NewEffectiveConfigHandler creates an effective config handler for the given simulators
+func NewEffectiveConfigHandler(configManager *config.Manager, simulators []Simulator) *EffectiveConfigHandler {
+	return &EffectiveConfigHandler{
+		configManager: configManager,
+		simulators:    simulators,
+	}
+}
+
+// ServeHTTP handles GET /api/simulators/{simulator}/config?session=. Without a session the
+// defaults are returned; with one, that session's overrides are merged over them.
+func (h *EffectiveConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Parse URL: /api/simulators/{simulator}/config
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/simulators/"), "/"), "/")
+	if len(parts) != 2 || parts[1] != "config" {
+		http.Error(w, "Invalid URL format", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	simulator := parts[0]
+	if !h.knownSimulator(simulator) {
+		http.Error(w, "Unknown simulator", http.StatusNotFound)
+		return
+	}
+	sessionID := r.URL.Query().Get("session")
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(effectiveConfig(r.Context(), h.configManager, sessionID, simulator))
+	log.Printf("[config] ✓ Returned effective config for %s session %q", simulator, sessionID)
+}
+
+func (h *EffectiveConfigHandler) knownSimulator(id string) bool {
+	for _, sim := range h.simulators {
+		if sim.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+)
+
+// harVersion is the HAR spec version emitted by GET /api/logs/har
+const harVersion = "1.2"
+
+// harTimeFormat is the ISO 8601 layout HAR uses for startedDateTime
+const harTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// HAR is the top-level document of an HTTP Archive
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog holds the recorded entries of an HTTP Archive
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that produced the archive
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single request/response pair
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARNameValue is a header or query string parameter
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARRequest describes the captured request
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARPostData is the body sent with a request
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARResponse describes the captured response
+type HARResponse struct {
+	Status      int64          `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARContent is the body returned with a response
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings breaks down the time spent on an entry; only the handler time is known
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// buildHAR converts captured request logs into an HTTP Archive. baseURL is the scheme and host
+// the simulators were reached on, since the store only keeps paths.
+func buildHAR(baseURL string, rows []database.RequestLog) HAR {
+	entries := make([]HAREntry, 0, len(rows))
+	for i := range rows {
+		entries = append(entries, harEntry(baseURL, &rows[i]))
+	}
+	return HAR{
+		Log: HARLog{
+			Version: harVersion,
+			Creator: HARCreator{Name: "nova-simulators", Version: "dev"},
+			Entries: entries,
+		},
+	}
+}
+
+func harEntry(baseURL string, row *database.RequestLog) HAREntry {
+	target := baseURL + simulatorPrefix(row.Simulator) + row.Path
+	if row.RawQuery != "" {
+		target += "?" + row.RawQuery
+	}
+
+	var header http.Header
+	_ = json.Unmarshal([]byte(row.Headers), &header)
+
+	request := HARRequest{
+		Method:      row.Method,
+		URL:         target,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(header),
+		QueryString: harQueryString(row.RawQuery),
+		HeadersSize: -1,
+		BodySize:    len(row.RequestBody),
+	}
+	if len(row.RequestBody) > 0 {
+		request.PostData = &HARPostData{
+			MimeType: header.Get("Content-Type"),
+			Text:     string(row.RequestBody),
+		}
+	}
+
+	// Response headers aren't captured, so the MIME type is inferred from the body
+	mimeType := "text/plain"
+	if json.Valid(row.ResponseBody) {
+		mimeType = "application/json"
+	}
+
+	elapsed := float64(row.DurationUs) / 1000
+	return HAREntry{
+		StartedDateTime: time.Unix(row.CreatedAt, 0).UTC().Format(harTimeFormat),
+		Time:            elapsed,
+		Request:         request,
+		Response: HARResponse{
+			Status:      row.StatusCode,
+			StatusText:  http.StatusText(int(row.StatusCode)),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			Content: HARContent{
+				Size:     len(row.ResponseBody),
+				MimeType: mimeType,
+				Text:     string(row.ResponseBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(row.ResponseBody),
+		},
+		Timings: HARTimings{Wait: elapsed},
+	}
+}
+
+// harHeaders flattens headers into name/value pairs in a stable order
+func harHeaders(header http.Header) []HARNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := []HARNameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			pairs = append(pairs, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// harQueryString splits a raw query into name/value pairs, keeping the original order
+func harQueryString(rawQuery string) []HARNameValue {
+	pairs := []HARNameValue{}
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		pairs = append(pairs, HARNameValue{Name: name, Value: value})
+	}
+	return pairs
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/config"
+)
+
+// LimitsHandler reports live rate limiter state per simulator and session
+type LimitsHandler struct {
+	configManager *config.Manager
+}
+
+// NewLimitsHandler creates a limits handler backed by the given configuration manager
+func NewLimitsHandler(configManager *config.Manager) *LimitsHandler {
+	return &LimitsHandler{
+		configManager: configManager,
+	}
+}
+
+// LimitsStateResponse is the response of GET /api/simulators/{simulator}/limits/state
+type LimitsStateResponse struct {
+	Simulator string                   `json:"simulator"`
+	SessionID string                   `json:"session_id"`
+	Buckets   []config.RateLimitBucket `json:"buckets"`
+}
+
+// ServeHTTP handles GET /api/simulators/{simulator}/limits/state?session=
+func (h *LimitsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Parse URL: /api/simulators/{simulator}/limits/state
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/simulators/"), "/"), "/")
+	if len(parts) != 3 || parts[1] != "limits" || parts[2] != "state" {
+		http.Error(w, "Invalid URL format", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	simulator := parts[0]
+	sessionID := r.URL.Query().Get("session")
+	if sessionID == "" {
+		http.Error(w, "session is required", http.StatusBadRequest)
+		return
+	}
+
+	buckets, ok := h.configManager.RateLimitState(r.Context(), sessionID, simulator)
+	if !ok {
+		http.Error(w, "Unknown simulator", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(LimitsStateResponse{
+		Simulator: simulator,
+		SessionID: sessionID,
+		Buckets:   buckets,
+	})
+	log.Printf("[limits] ✓ Returned rate limit state for %s session %s", simulator, sessionID)
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/middleware"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// defaultLogLimit caps how many entries GET /api/logs returns
+const defaultLogLimit = 100
+
+// LogsHandler serves the request-log store and replays captured requests
+type LogsHandler struct {
+	queries *database.Queries
+	target  http.Handler
+}
+
+// NewLogsHandler creates a logs handler that replays requests through target
+func NewLogsHandler(queries *database.Queries, target http.Handler) *LogsHandler {
+	return &LogsHandler{
+		queries: queries,
+		target:  target,
+	}
+}
+
+// LogEntry is a captured request as returned by the logs API
+type LogEntry struct {
+	ID           int64           `json:"id"`
+	SessionID    string          `json:"session_id"`
+	Simulator    string          `json:"simulator"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Query        string          `json:"query,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body"`
+	StatusCode   int64           `json:"status_code"`
+	ResponseBody json.RawMessage `json:"response_body"`
+	CreatedAt    int64           `json:"created_at"`
+}
+
+// ReplayResult is a single captured response
+type ReplayResult struct {
+	StatusCode int64           `json:"status_code"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// ReplayResponse pairs the original response with the one produced by re-executing the request
+type ReplayResponse struct {
+	LogID     int64        `json:"log_id"`
+	Simulator string       `json:"simulator"`
+	Method    string       `json:"method"`
+	Path      string       `json:"path"`
+	Original  ReplayResult `json:"original"`
+	Replay    ReplayResult `json:"replay"`
+	Identical bool         `json:"identical"`
+}
+
+// ServeHTTP implements http.Handler interface
+func (h *LogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Parse URL: /api/logs, /api/logs/har or /api/logs/{id}/replay
+	path := strings.TrimPrefix(r.URL.Path, "/api/logs")
+	path = strings.Trim(path, "/")
+
+	if path == "" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleListLogs(w, r)
+		return
+	}
+
+	if path == "har" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleExportHAR(w, r)
+		return
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[1] != "replay" {
+		http.Error(w, "Invalid URL format", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid log ID", http.StatusBadRequest)
+		return
+	}
+	h.handleReplay(w, r, id)
+}
+
+func (h *LogsHandler) handleListLogs(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session_id")
+	if sessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := int64(defaultLogLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	rows, err := h.queries.ListRequestLogs(context.Background(), database.ListRequestLogsParams{
+		SessionID: sessionID,
+		Limit:     limit,
+	})
+	if err != nil {
+		log.Printf("[logs] ✗ Failed to list request logs: %v", err)
+		http.Error(w, "Failed to list request logs", http.StatusInternalServerError)
+		return
+	}
+
+	entries := make([]LogEntry, 0, len(rows))
+	for i := range rows {
+		entries = append(entries, LogEntry{
+			ID:           rows[i].ID,
+			SessionID:    rows[i].SessionID,
+			Simulator:    rows[i].Simulator,
+			Method:       rows[i].Method,
+			Path:         rows[i].Path,
+			Query:        rows[i].RawQuery,
+			RequestBody:  jsonBody(rows[i].RequestBody),
+			StatusCode:   rows[i].StatusCode,
+			ResponseBody: jsonBody(rows[i].ResponseBody),
+			CreatedAt:    rows[i].CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"logs": entries,
+	})
+}
+
+func (h *LogsHandler) handleExportHAR(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session")
+	if sessionID == "" {
+		http.Error(w, "session is required", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.queries.ListSessionRequestLogs(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("[logs] ✗ Failed to list request logs: %v", err)
+		http.Error(w, "Failed to list request logs", http.StatusInternalServerError)
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", sessionID+".har"))
+	_ = json.NewEncoder(w).Encode(buildHAR(scheme+"://"+r.Host, rows))
+	log.Printf("[logs] ✓ Exported %d request logs as HAR for session %s", len(rows), sessionID)
+}
+
+func (h *LogsHandler) handleReplay(w http.ResponseWriter, r *http.Request, id int64) {
+	log.Printf("[logs] → Replaying request log %d", id)
+
+	entry, err := h.queries.GetRequestLog(context.Background(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Request log not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("[logs] ✗ Failed to get request log: %v", err)
+		http.Error(w, "Failed to get request log", http.StatusInternalServerError)
+		return
+	}
+
+	target := simulatorPrefix(entry.Simulator) + entry.Path
+	if entry.RawQuery != "" {
+		target += "?" + entry.RawQuery
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), entry.Method, target, bytes.NewReader(entry.RequestBody))
+	if err != nil {
+		log.Printf("[logs] ✗ Failed to rebuild request: %v", err)
+		http.Error(w, "Failed to rebuild request", http.StatusInternalServerError)
+		return
+	}
+	_ = json.Unmarshal([]byte(entry.Headers), &req.Header)
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	req.Header.Set(session.SessionHeaderName, entry.SessionID)
+	// A replay must run the handler again rather than return the stored idempotent response
+	req.Header.Del(middleware.IdempotencyKeyHeader)
+
+	rec := httptest.NewRecorder()
+	h.target.ServeHTTP(rec, req)
+
+	response := ReplayResponse{
+		LogID:     entry.ID,
+		Simulator: entry.Simulator,
+		Method:    entry.Method,
+		Path:      entry.Path,
+		Original: ReplayResult{
+			StatusCode: entry.StatusCode,
+			Body:       jsonBody(entry.ResponseBody),
+		},
+		Replay: ReplayResult{
+			StatusCode: int64(rec.Code),
+			Body:       jsonBody(rec.Body.Bytes()),
+		},
+		Identical: int64(rec.Code) == entry.StatusCode && bytes.Equal(rec.Body.Bytes(), entry.ResponseBody),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[logs] ✓ Replayed request log %d: %d → %d", id, entry.StatusCode, rec.Code)
+}
+
+// jsonBody embeds a captured body as JSON, falling back to a string for non-JSON payloads
+func jsonBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return json.RawMessage("null")
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	encoded, _ := json.Marshal(string(body))
+	return encoded
+}
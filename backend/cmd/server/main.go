@@ -1,18 +1,25 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
 	"github.com/recreate-run/nova-simulators/internal/config"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/logging"
 	"github.com/recreate-run/nova-simulators/internal/middleware"
+	"github.com/recreate-run/nova-simulators/internal/routes"
 	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/recreate-run/nova-simulators/internal/webhook"
 	"github.com/recreate-run/nova-simulators/simulators/datadog"
 	"github.com/recreate-run/nova-simulators/simulators/gdocs"
 	githubsim "github.com/recreate-run/nova-simulators/simulators/github"
@@ -55,7 +62,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 		}
 		w.Header().Set("Access-Control-Allow-Origin", origin)
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Session-ID, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Session-ID, Authorization, Idempotency-Key, X-Nova-Fail-Next")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		// Handle preflight requests
@@ -70,19 +77,43 @@ func corsMiddleware(next http.Handler) http.Handler {
 
 // setupDatabase initializes and returns the database connection and queries
 func setupDatabase() *database.Queries {
-	if err := database.InitDB("file:simulators.db"); err != nil {
+	dsn, err := storageDSN()
+	if err == nil {
+		err = database.InitDB(dsn)
+	}
+	if err != nil {
 		logging.CloseLogger()
 		log.Panicf("Failed to initialize database: %v", err)
 	}
+	if database.IsMemory(dsn) {
+		log.Println("Storage: in-memory, all data is discarded on exit")
+	}
 	return database.GetQueries()
 }
 
+// storageDSN picks the database from STORAGE: "file" (the default) keeps simulators.db on
+// disk, "memory" keeps everything in memory for ephemeral runs such as CI
+func storageDSN() (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE"))); mode {
+	case "", "file":
+		return "file:simulators.db", nil
+	case "memory":
+		return database.MemoryDSN, nil
+	default:
+		return "", fmt.Errorf("unknown STORAGE %q, expected file or memory", mode)
+	}
+}
+
 // setupEmbeddedPostgres starts embedded PostgreSQL and returns handler
 func setupEmbeddedPostgres(queries *database.Queries) (*embeddedpostgres.EmbeddedPostgres, *postgressim.Handler) {
-	// Only start embedded Postgres if POSTGRES_SIMULATOR_ENABLED is not "false"
+	// Only start embedded Postgres if POSTGRES_SIMULATOR_ENABLED is not "false". Memory storage
+	// never starts it, since it keeps its data directory on disk.
 	if os.Getenv("POSTGRES_SIMULATOR_ENABLED") == "false" {
 		return nil, nil
 	}
+	if dsn, err := storageDSN(); err == nil && database.IsMemory(dsn) {
+		return nil, nil
+	}
 
 	embeddedPG := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
 		Port(5433).
@@ -107,116 +138,275 @@ func setupEmbeddedPostgres(queries *database.Queries) (*embeddedpostgres.Embedde
 	return embeddedPG, postgresHandler
 }
 
+// simulatorPrefix returns the mount prefix for a simulator, overridable via SIMULATOR_PREFIX_<ID>
+func simulatorPrefix(id string) string {
+	prefix := strings.TrimSpace(os.Getenv("SIMULATOR_PREFIX_" + strings.ToUpper(id)))
+	if prefix == "" {
+		return "/" + id
+	}
+	return "/" + strings.Trim(prefix, "/")
+}
+
+// configureListCap applies MAX_LIST_ITEMS, the global cap on items per list response
+func configureListCap() {
+	raw := strings.TrimSpace(os.Getenv("MAX_LIST_ITEMS"))
+	if raw == "" {
+		return
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 0 {
+		log.Printf("Warning: ignoring invalid MAX_LIST_ITEMS %q", raw)
+		return
+	}
+	listcap.SetMax(limit)
+	log.Printf("List responses capped at %d items", limit)
+}
+
+// mountSimulator registers a simulator handler under its (possibly overridden) prefix.
+// Panics are recovered per simulator so one failing handler cannot bring the server down, and
+// X-Nova-Fail-Next is honoured before any simulator middleware runs.
+func mountSimulator(mux *http.ServeMux, id string, handler http.Handler) {
+	prefix := simulatorPrefix(id)
+	if prefix != "/"+id {
+		log.Printf("Mounting %s simulator at custom prefix %s", id, prefix)
+	}
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, middleware.Recovery(id)(middleware.FailNext(id)(handler))))
+}
+
+// registerCounters lets /sessions/{id}/stats fan out to every database-backed simulator
+func registerCounters(sessionManager *session.Manager) {
+	sessionManager.RegisterCounter("slack", slack.CountObjects)
+	sessionManager.RegisterCounter("gmail", gmail.CountObjects)
+	sessionManager.RegisterCounter("gdocs", gdocs.CountObjects)
+	sessionManager.RegisterCounter("gsheets", gsheets.CountObjects)
+	sessionManager.RegisterCounter("datadog", datadog.CountObjects)
+	sessionManager.RegisterCounter("resend", resend.CountObjects)
+	sessionManager.RegisterCounter("linear", linear.CountObjects)
+	sessionManager.RegisterCounter("github", githubsim.CountObjects)
+	sessionManager.RegisterCounter("outlook", outlook.CountObjects)
+	sessionManager.RegisterCounter("pagerduty", pagerduty.CountObjects)
+	sessionManager.RegisterCounter("hubspot", hubspot.CountObjects)
+	sessionManager.RegisterCounter("jira", jira.CountObjects)
+	sessionManager.RegisterCounter("whatsapp", whatsapp.CountObjects)
+}
+
+// registerWebhooks sets how each simulator with outbound webhooks builds and signs them, with
+// signature timestamps skewed by the session's faults config
+func registerWebhooks(configManager *config.Manager) {
+	webhook.Register("github", githubsim.WebhookProvider())
+	webhook.Register("resend", resend.WebhookProvider())
+	webhook.SetSkew(func(ctx context.Context, sessionID, simulator string) int {
+		return configManager.GetFaultsConfig(ctx, sessionID, simulator).SignatureSkewSeconds
+	})
+}
+
+// registerTickers wires simulators with scheduled work into the /api/tick fan-out
+func registerTickers(sessionManager *session.Manager) {
+	sessionManager.RegisterTicker("slack", slack.FireScheduledMessages)
+	sessionManager.RegisterTicker("gmail", gmail.ExpireWatches)
+	sessionManager.RegisterTicker("webhooks", webhook.FireRetries)
+}
+
 // registerSimulators registers all simulator handlers with the mux
 func registerSimulators(mux *http.ServeMux, queries *database.Queries, configManager *config.Manager, postgresHandler *postgressim.Handler) {
-	// Register Slack simulator with session + logging + rate limit + timeout middleware
+	// Register Slack simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	slackHandler := session.Middleware(
-		logging.Middleware("slack")(
-			middleware.RateLimit(configManager, "slack")(
-				middleware.Timeout(configManager, "slack")(
-					slack.NewHandler(queries)))))
-	mux.Handle("/slack/", http.StripPrefix("/slack", slackHandler))
-
-	// Register Gmail simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "slack")(
+			logging.Middleware("slack")(
+				middleware.ResponseHeaders(configManager, "slack")(
+					middleware.Overrides(queries, "slack")(
+						middleware.Idempotency(queries, "slack")(
+							middleware.RateLimit(configManager, "slack")(
+								middleware.Timeout(configManager, "slack")(
+									middleware.ContentType(configManager, "slack")(
+										middleware.Validation(configManager, "slack")(
+											slack.NewHandler(queries)))))))))))
+	mountSimulator(mux, "slack", slackHandler)
+
+	// Register Gmail simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	gmailHandler := session.Middleware(
-		logging.Middleware("gmail")(
-			middleware.RateLimit(configManager, "gmail")(
-				middleware.Timeout(configManager, "gmail")(
-					gmail.NewHandler(queries)))))
-	mux.Handle("/gmail/", http.StripPrefix("/gmail", gmailHandler))
-
-	// Register Google Docs simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "gmail")(
+			logging.Middleware("gmail")(
+				middleware.ResponseHeaders(configManager, "gmail")(
+					middleware.Overrides(queries, "gmail")(
+						middleware.Idempotency(queries, "gmail")(
+							middleware.RateLimit(configManager, "gmail")(
+								middleware.Timeout(configManager, "gmail")(
+									middleware.ContentType(configManager, "gmail")(
+										middleware.Validation(configManager, "gmail")(
+											gmail.NewHandler(queries)))))))))))
+	mountSimulator(mux, "gmail", gmailHandler)
+
+	// Register Google Docs simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	gdocsHandler := session.Middleware(
-		logging.Middleware("gdocs")(
-			middleware.RateLimit(configManager, "gdocs")(
-				middleware.Timeout(configManager, "gdocs")(
-					gdocs.NewHandler(queries)))))
-	mux.Handle("/gdocs/", http.StripPrefix("/gdocs", gdocsHandler))
-
-	// Register Google Sheets simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "gdocs")(
+			logging.Middleware("gdocs")(
+				middleware.ResponseHeaders(configManager, "gdocs")(
+					middleware.Overrides(queries, "gdocs")(
+						middleware.Idempotency(queries, "gdocs")(
+							middleware.RateLimit(configManager, "gdocs")(
+								middleware.Timeout(configManager, "gdocs")(
+									middleware.ContentType(configManager, "gdocs")(
+										middleware.Validation(configManager, "gdocs")(
+											gdocs.NewHandler(queries)))))))))))
+	mountSimulator(mux, "gdocs", gdocsHandler)
+
+	// Register Google Sheets simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	gsheetsHandler := session.Middleware(
-		logging.Middleware("gsheets")(
-			middleware.RateLimit(configManager, "gsheets")(
-				middleware.Timeout(configManager, "gsheets")(
-					gsheets.NewHandler(queries)))))
-	mux.Handle("/gsheets/", http.StripPrefix("/gsheets", gsheetsHandler))
-
-	// Register Datadog simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "gsheets")(
+			logging.Middleware("gsheets")(
+				middleware.ResponseHeaders(configManager, "gsheets")(
+					middleware.Overrides(queries, "gsheets")(
+						middleware.Idempotency(queries, "gsheets")(
+							middleware.RateLimit(configManager, "gsheets")(
+								middleware.Timeout(configManager, "gsheets")(
+									middleware.ContentType(configManager, "gsheets")(
+										middleware.Validation(configManager, "gsheets")(
+											gsheets.NewHandler(queries)))))))))))
+	mountSimulator(mux, "gsheets", gsheetsHandler)
+
+	// Register Datadog simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	datadogHandler := session.Middleware(
-		logging.Middleware("datadog")(
-			middleware.RateLimit(configManager, "datadog")(
-				middleware.Timeout(configManager, "datadog")(
-					datadog.NewHandler(queries)))))
-	mux.Handle("/datadog/", http.StripPrefix("/datadog", datadogHandler))
-
-	// Register Resend simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "datadog")(
+			logging.Middleware("datadog")(
+				middleware.ResponseHeaders(configManager, "datadog")(
+					middleware.Overrides(queries, "datadog")(
+						middleware.Idempotency(queries, "datadog")(
+							middleware.RateLimit(configManager, "datadog")(
+								middleware.Timeout(configManager, "datadog")(
+									middleware.ContentType(configManager, "datadog")(
+										middleware.Validation(configManager, "datadog")(
+											datadog.NewHandler(queries)))))))))))
+	mountSimulator(mux, "datadog", datadogHandler)
+
+	// Register Resend simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	resendHandler := session.Middleware(
-		logging.Middleware("resend")(
-			middleware.RateLimit(configManager, "resend")(
-				middleware.Timeout(configManager, "resend")(
-					resend.NewHandler(queries)))))
-	mux.Handle("/resend/", http.StripPrefix("/resend", resendHandler))
-
-	// Register Linear simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "resend")(
+			logging.Middleware("resend")(
+				middleware.ResponseHeaders(configManager, "resend")(
+					middleware.Overrides(queries, "resend")(
+						middleware.Idempotency(queries, "resend")(
+							middleware.RateLimit(configManager, "resend")(
+								middleware.Timeout(configManager, "resend")(
+									middleware.ContentType(configManager, "resend")(
+										middleware.Validation(configManager, "resend")(
+											resend.NewHandler(queries)))))))))))
+	mountSimulator(mux, "resend", resendHandler)
+
+	// Register Linear simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	linearHandler := session.Middleware(
-		logging.Middleware("linear")(
-			middleware.RateLimit(configManager, "linear")(
-				middleware.Timeout(configManager, "linear")(
-					linear.NewHandler(queries)))))
-	mux.Handle("/linear/", http.StripPrefix("/linear", linearHandler))
-
-	// Register GitHub simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "linear")(
+			logging.Middleware("linear")(
+				middleware.ResponseHeaders(configManager, "linear")(
+					middleware.Overrides(queries, "linear")(
+						middleware.Idempotency(queries, "linear")(
+							middleware.RateLimit(configManager, "linear")(
+								middleware.Timeout(configManager, "linear")(
+									middleware.ContentType(configManager, "linear")(
+										middleware.Validation(configManager, "linear")(
+											linear.NewHandler(queries)))))))))))
+	mountSimulator(mux, "linear", linearHandler)
+
+	// Register GitHub simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	githubHandler := session.Middleware(
-		logging.Middleware("github")(
-			middleware.RateLimit(configManager, "github")(
-				middleware.Timeout(configManager, "github")(
-					githubsim.NewHandler(queries)))))
-	mux.Handle("/github/", http.StripPrefix("/github", githubHandler))
-
-	// Register Outlook simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "github")(
+			logging.Middleware("github")(
+				middleware.ResponseHeaders(configManager, "github")(
+					middleware.Overrides(queries, "github")(
+						middleware.Idempotency(queries, "github")(
+							middleware.RateLimit(configManager, "github")(
+								middleware.Timeout(configManager, "github")(
+									middleware.ContentType(configManager, "github")(
+										middleware.Validation(configManager, "github")(
+											githubsim.NewHandler(queries)))))))))))
+	mountSimulator(mux, "github", githubHandler)
+
+	// Register Outlook simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	outlookHandler := session.Middleware(
-		logging.Middleware("outlook")(
-			middleware.RateLimit(configManager, "outlook")(
-				middleware.Timeout(configManager, "outlook")(
-					outlook.NewHandler(queries)))))
-	mux.Handle("/outlook/", http.StripPrefix("/outlook", outlookHandler))
-
-	// Register PagerDuty simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "outlook")(
+			logging.Middleware("outlook")(
+				middleware.ResponseHeaders(configManager, "outlook")(
+					middleware.Overrides(queries, "outlook")(
+						middleware.Idempotency(queries, "outlook")(
+							middleware.RateLimit(configManager, "outlook")(
+								middleware.Timeout(configManager, "outlook")(
+									middleware.ContentType(configManager, "outlook")(
+										middleware.Validation(configManager, "outlook")(
+											outlook.NewHandler(queries)))))))))))
+	mountSimulator(mux, "outlook", outlookHandler)
+
+	// Register PagerDuty simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	pagerdutyHandler := session.Middleware(
-		logging.Middleware("pagerduty")(
-			middleware.RateLimit(configManager, "pagerduty")(
-				middleware.Timeout(configManager, "pagerduty")(
-					pagerduty.NewHandler(queries)))))
-	mux.Handle("/pagerduty/", http.StripPrefix("/pagerduty", pagerdutyHandler))
-
-	// Register HubSpot simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "pagerduty")(
+			logging.Middleware("pagerduty")(
+				middleware.ResponseHeaders(configManager, "pagerduty")(
+					middleware.Overrides(queries, "pagerduty")(
+						middleware.Idempotency(queries, "pagerduty")(
+							middleware.RateLimit(configManager, "pagerduty")(
+								middleware.Timeout(configManager, "pagerduty")(
+									middleware.ContentType(configManager, "pagerduty")(
+										middleware.Validation(configManager, "pagerduty")(
+											pagerduty.NewHandler(queries)))))))))))
+	mountSimulator(mux, "pagerduty", pagerdutyHandler)
+
+	// Register HubSpot simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	hubspotHandler := session.Middleware(
-		logging.Middleware("hubspot")(
-			middleware.RateLimit(configManager, "hubspot")(
-				middleware.Timeout(configManager, "hubspot")(
-					hubspot.NewHandler(queries)))))
-	mux.Handle("/hubspot/", http.StripPrefix("/hubspot", hubspotHandler))
-
-	// Register Jira simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "hubspot")(
+			logging.Middleware("hubspot")(
+				middleware.ResponseHeaders(configManager, "hubspot")(
+					middleware.Overrides(queries, "hubspot")(
+						middleware.Idempotency(queries, "hubspot")(
+							middleware.RateLimit(configManager, "hubspot")(
+								middleware.Timeout(configManager, "hubspot")(
+									middleware.ContentType(configManager, "hubspot")(
+										middleware.Validation(configManager, "hubspot")(
+											hubspot.NewHandler(queries)))))))))))
+	mountSimulator(mux, "hubspot", hubspotHandler)
+
+	// Register Jira simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	jiraHandler := session.Middleware(
-		logging.Middleware("jira")(
-			middleware.RateLimit(configManager, "jira")(
-				middleware.Timeout(configManager, "jira")(
-					jira.NewHandler(queries)))))
-	mux.Handle("/jira/", http.StripPrefix("/jira", jiraHandler))
-
-	// Register WhatsApp simulator with session + logging + rate limit + timeout middleware
+		middleware.Faults(configManager, "jira")(
+			logging.Middleware("jira")(
+				middleware.ResponseHeaders(configManager, "jira")(
+					middleware.Overrides(queries, "jira")(
+						middleware.Idempotency(queries, "jira")(
+							middleware.RateLimit(configManager, "jira")(
+								middleware.Timeout(configManager, "jira")(
+									middleware.ContentType(configManager, "jira")(
+										middleware.Validation(configManager, "jira")(
+											jira.NewHandler(queries)))))))))))
+	mountSimulator(mux, "jira", jiraHandler)
+
+	// Register WhatsApp simulator with session + faults + logging + response headers + overrides + idempotency + rate limit + timeout + content type + validation middleware
 	whatsappHandler := session.Middleware(
-		logging.Middleware("whatsapp")(
-			middleware.RateLimit(configManager, "whatsapp")(
-				middleware.Timeout(configManager, "whatsapp")(
-					whatsapp.NewHandler(queries)))))
-	mux.Handle("/whatsapp/", http.StripPrefix("/whatsapp", whatsappHandler))
+		middleware.Faults(configManager, "whatsapp")(
+			logging.Middleware("whatsapp")(
+				middleware.ResponseHeaders(configManager, "whatsapp")(
+					middleware.Overrides(queries, "whatsapp")(
+						middleware.Idempotency(queries, "whatsapp")(
+							middleware.RateLimit(configManager, "whatsapp")(
+								middleware.Timeout(configManager, "whatsapp")(
+									middleware.ContentType(configManager, "whatsapp")(
+										middleware.Validation(configManager, "whatsapp")(
+											whatsapp.NewHandler(queries)))))))))))
+	mountSimulator(mux, "whatsapp", whatsappHandler)
 
 	// Register Postgres simulator with session + logging middleware (if enabled)
 	if postgresHandler != nil {
 		pgHandler := session.Middleware(logging.Middleware("postgres")(postgresHandler))
-		mux.Handle("/postgres/", http.StripPrefix("/postgres", pgHandler))
+		mountSimulator(mux, "postgres", pgHandler)
+	}
+}
+
+// newServer returns the HTTP server for handler with the simulator's timeouts. Handlers that
+// hold a response open longer, like /sessions/{id}/wait, extend their own write deadline.
+func newServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         ":9000",
+		Handler:      handler,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
 	}
 }
 
@@ -236,6 +426,12 @@ func main() {
 		}
 	}()
 
+	// Capture requests so they can be replayed from /api/logs
+	logging.InitStore(queries)
+
+	// Deliver simulator webhooks to receivers registered through /api/webhooks
+	webhook.Init(queries)
+
 	// Load simulator configuration
 	cfg, err := config.Load("../config/simulators.yaml")
 	if err != nil {
@@ -243,8 +439,12 @@ func main() {
 		cfg = config.Default()
 	}
 
+	// Cap list response sizes when MAX_LIST_ITEMS is set
+	configureListCap()
+
 	// Create configuration manager for session-specific config overrides
 	configManager := config.NewManager(cfg, queries)
+	registerWebhooks(configManager)
 
 	// Initialize SSE hub for real-time events
 	sseHub := InitSSEHub()
@@ -274,6 +474,9 @@ func main() {
 	sessionManager := session.NewManager(queries)
 	mux.Handle("/sessions", sessionManager)
 	mux.Handle("/sessions/", sessionManager)
+	registerCounters(sessionManager)
+	registerTickers(sessionManager)
+	mux.HandleFunc("/api/tick", sessionManager.HandleTick)
 
 	// Register all simulators
 	registerSimulators(mux, queries, configManager, postgresHandler)
@@ -299,12 +502,31 @@ func main() {
 	// Register API routes
 	apiHandler := NewUIHandler(queries, availableSimulators)
 	configHandler := NewConfigHandler(configManager)
+	profileHandler := NewProfileHandler(configManager)
+	logsHandler := NewLogsHandler(queries, mux)
+	statsHandler := NewStatsHandler(queries)
+	overridesHandler := NewOverridesHandler(queries, availableSimulators)
+	limitsHandler := NewLimitsHandler(configManager)
+	effectiveConfigHandler := NewEffectiveConfigHandler(configManager, availableSimulators)
+	webhooksHandler := NewWebhooksHandler(queries)
 
 	// Order matters: more specific patterns should be registered first
 	mux.Handle("/api/sessions/", configHandler)  // Handles /api/sessions/{sessionID}/config/...
 	mux.Handle("/api/sessions", apiHandler)      // Handles exact /api/sessions
 	mux.Handle("/api/simulators", apiHandler)
 	mux.Handle("/api/simulators/", apiHandler)
+	mux.Handle("/api/simulators/{simulator}/overrides", overridesHandler)
+	mux.Handle("/api/simulators/{simulator}/overrides/{overrideID}", overridesHandler)
+	mux.Handle("/api/simulators/{simulator}/limits/state", limitsHandler)
+	mux.Handle("/api/simulators/{simulator}/config", effectiveConfigHandler)
+	mux.Handle("/api/routes", routes.NewHandler(simulatorPrefix))
+	mux.Handle("/api/logs", logsHandler)
+	mux.Handle("/api/logs/", logsHandler)
+	mux.Handle("/api/stats/latency", statsHandler)
+	mux.Handle("/api/webhooks/", webhooksHandler)
+	mux.Handle("/api/config/profiles", profileHandler)
+	mux.Handle("/api/config/profiles/", profileHandler)
+	mux.Handle("/version", NewVersionHandler())
 
 	if postgresHandler != nil {
 		log.Println("Postgres: http://localhost:9000/postgres (DB: localhost:5433)")
@@ -313,13 +535,7 @@ func main() {
 	log.Println("Logging to: simulator.log")
 
 	// Create server with timeouts and CORS middleware
-	server := &http.Server{
-		Addr:         ":9000",
-		Handler:      corsMiddleware(mux),
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	server := newServer(corsMiddleware(mux))
 
 	log.Fatal(server.ListenAndServe()) //nolint:gocritic // exitAfterDefer is acceptable here - server.ListenAndServe only returns on fatal error
 }
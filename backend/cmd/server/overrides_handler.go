@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/middleware"
+)
+
+// OverridesHandler manages canned responses registered per simulator and session
+type OverridesHandler struct {
+	queries    *database.Queries
+	simulators []Simulator
+}
+
+// NewOverridesHandler creates an overrides handler for the given simulators
+func NewOverridesHandler(queries *database.Queries, simulators []Simulator) *OverridesHandler {
+	return &OverridesHandler{
+		queries:    queries,
+		simulators: simulators,
+	}
+}
+
+// OverrideRequest registers a canned response. Body is returned verbatim; a JSON string
+// body with a non-JSON content type is sent unquoted.
+type OverrideRequest struct {
+	SessionID   string          `json:"session_id"`
+	Method      string          `json:"method"`
+	Path        string          `json:"path"`
+	Status      int             `json:"status"`
+	Body        json.RawMessage `json:"body"`
+	ContentType string          `json:"content_type"`
+}
+
+// Override is a registered canned response as returned by the overrides API
+type Override struct {
+	ID          int64           `json:"id"`
+	SessionID   string          `json:"session_id"`
+	Simulator   string          `json:"simulator"`
+	Method      string          `json:"method"`
+	Path        string          `json:"path"`
+	Status      int64           `json:"status"`
+	Body        json.RawMessage `json:"body"`
+	ContentType string          `json:"content_type"`
+	CreatedAt   int64           `json:"created_at"`
+}
+
+// ServeHTTP implements http.Handler interface
+func (h *OverridesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Parse URL: /api/simulators/{simulator}/overrides or /api/simulators/{simulator}/overrides/{id}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/simulators/"), "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[1] != "overrides" {
+		http.Error(w, "Invalid URL format", http.StatusBadRequest)
+		return
+	}
+
+	simulator := parts[0]
+	if !h.knownSimulator(simulator) {
+		http.Error(w, "Unknown simulator", http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 3 {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		id, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid override ID", http.StatusBadRequest)
+			return
+		}
+		h.handleDeleteOverride(w, simulator, id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleListOverrides(w, r, simulator)
+	case http.MethodPost:
+		h.handleCreateOverride(w, r, simulator)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *OverridesHandler) handleCreateOverride(w http.ResponseWriter, r *http.Request, simulator string) {
+	log.Printf("[overrides] → Registering override for %s", simulator)
+
+	var req OverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.SessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+	if !middleware.ValidOverridePattern(req.Path) {
+		http.Error(w, "path must be an absolute path pattern", http.StatusBadRequest)
+		return
+	}
+	if req.Status < 100 || req.Status > 599 {
+		http.Error(w, "status must be between 100 and 599", http.StatusBadRequest)
+		return
+	}
+
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "*"
+	}
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	body := string(req.Body)
+	var text string
+	if !strings.Contains(contentType, "json") && json.Unmarshal(req.Body, &text) == nil {
+		body = text
+	}
+
+	created, err := h.queries.CreateResponseOverride(context.Background(), database.CreateResponseOverrideParams{
+		SessionID:   req.SessionID,
+		Simulator:   simulator,
+		Method:      method,
+		PathPattern: req.Path,
+		StatusCode:  int64(req.Status),
+		Body:        body,
+		ContentType: contentType,
+	})
+	if err != nil {
+		log.Printf("[overrides] ✗ Failed to store override: %v", err)
+		http.Error(w, "Failed to store override", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toOverride(&created))
+	log.Printf("[overrides] ✓ Registered override %d: %s %s → %d", created.ID, method, req.Path, req.Status)
+}
+
+func (h *OverridesHandler) handleListOverrides(w http.ResponseWriter, r *http.Request, simulator string) {
+	sessionID := r.URL.Query().Get("session_id")
+	if sessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.queries.ListResponseOverrides(context.Background(), database.ListResponseOverridesParams{
+		SessionID: sessionID,
+		Simulator: simulator,
+	})
+	if err != nil {
+		log.Printf("[overrides] ✗ Failed to list overrides: %v", err)
+		http.Error(w, "Failed to list overrides", http.StatusInternalServerError)
+		return
+	}
+
+	overrides := make([]Override, 0, len(rows))
+	for i := range rows {
+		overrides = append(overrides, toOverride(&rows[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"overrides": overrides,
+	})
+}
+
+func (h *OverridesHandler) handleDeleteOverride(w http.ResponseWriter, simulator string, id int64) {
+	deleted, err := h.queries.DeleteResponseOverride(context.Background(), database.DeleteResponseOverrideParams{
+		ID:        id,
+		Simulator: simulator,
+	})
+	if err != nil {
+		log.Printf("[overrides] ✗ Failed to delete override: %v", err)
+		http.Error(w, "Failed to delete override", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Override not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("[overrides] ✓ Deleted override %d", id)
+}
+
+func (h *OverridesHandler) knownSimulator(id string) bool {
+	for _, sim := range h.simulators {
+		if sim.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func toOverride(row *database.ResponseOverride) Override {
+	return Override{
+		ID:          row.ID,
+		SessionID:   row.SessionID,
+		Simulator:   row.Simulator,
+		Method:      row.Method,
+		Path:        row.PathPattern,
+		Status:      row.StatusCode,
+		Body:        jsonBody([]byte(row.Body)),
+		ContentType: row.ContentType,
+		CreatedAt:   row.CreatedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/config"
+)
+
+// ProfileHandler serves the named config profile endpoints
+type ProfileHandler struct {
+	configManager *config.Manager
+}
+
+// NewProfileHandler creates a new profile handler
+func NewProfileHandler(configManager *config.Manager) *ProfileHandler {
+	return &ProfileHandler{
+		configManager: configManager,
+	}
+}
+
+// ProfileRequest identifies the session a profile is captured from or applied to
+type ProfileRequest struct {
+	SessionID string `json:"session_id"`
+}
+
+// ServeHTTP implements http.Handler interface
+func (h *ProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Parse URL: /api/config/profiles[/{name}[/apply]]
+	path := strings.TrimPrefix(r.URL.Path, "/api/config/profiles")
+	path = strings.Trim(path, "/")
+
+	if path == "" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleListProfiles(w)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(path, "/")
+	switch {
+	case len(parts) == 1:
+		h.handleSaveProfile(w, r, parts[0])
+	case len(parts) == 2 && parts[1] == "apply":
+		h.handleApplyProfile(w, r, parts[0])
+	default:
+		http.Error(w, "Invalid URL format", http.StatusBadRequest)
+	}
+}
+
+func (h *ProfileHandler) handleListProfiles(w http.ResponseWriter) {
+	profiles, err := h.configManager.ListProfiles(context.Background())
+	if err != nil {
+		http.Error(w, "Failed to list profiles: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"profiles": profiles,
+	})
+}
+
+func (h *ProfileHandler) handleSaveProfile(w http.ResponseWriter, r *http.Request, name string) {
+	sessionID, ok := decodeProfileRequest(w, r)
+	if !ok {
+		return
+	}
+
+	profile, err := h.configManager.SaveProfile(context.Background(), name, sessionID)
+	if err != nil {
+		http.Error(w, "Failed to save profile: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(profile)
+}
+
+func (h *ProfileHandler) handleApplyProfile(w http.ResponseWriter, r *http.Request, name string) {
+	sessionID, ok := decodeProfileRequest(w, r)
+	if !ok {
+		return
+	}
+
+	profile, err := h.configManager.ApplyProfile(context.Background(), name, sessionID)
+	if errors.Is(err, config.ErrProfileNotFound) {
+		http.Error(w, "Profile not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to apply profile: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(profile)
+}
+
+// decodeProfileRequest reads the target session ID, writing a 400 response if it is missing
+func decodeProfileRequest(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+	if req.SessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return "", false
+	}
+	return req.SessionID, true
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/routes"
+)
+
+// defaultLatencyWindow is how far back GET /api/stats/latency looks when no window is given
+const defaultLatencyWindow = time.Hour
+
+// StatsHandler serves reports computed from the request-log store
+type StatsHandler struct {
+	queries *database.Queries
+}
+
+// NewStatsHandler creates a stats handler
+func NewStatsHandler(queries *database.Queries) *StatsHandler {
+	return &StatsHandler{
+		queries: queries,
+	}
+}
+
+// LatencyStats summarizes handler latency in milliseconds
+type LatencyStats struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+}
+
+// EndpointLatency is the latency of one route
+type EndpointLatency struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	LatencyStats
+}
+
+// LatencyReport is the response of GET /api/stats/latency
+type LatencyReport struct {
+	Simulator     string `json:"simulator"`
+	WindowSeconds int64  `json:"window_seconds"`
+	LatencyStats
+	Endpoints []EndpointLatency `json:"endpoints"`
+}
+
+// ServeHTTP handles GET /api/stats/latency?simulator=&window=
+func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	simulator := r.URL.Query().Get("simulator")
+	if simulator == "" {
+		http.Error(w, "simulator is required", http.StatusBadRequest)
+		return
+	}
+
+	window := defaultLatencyWindow
+	if raw := r.URL.Query().Get("window"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid window", http.StatusBadRequest)
+			return
+		}
+		window = parsed
+	}
+
+	rows, err := h.queries.ListRequestLogLatencies(context.Background(), database.ListRequestLogLatenciesParams{
+		Simulator: simulator,
+		CreatedAt: time.Now().Add(-window).Unix(),
+	})
+	if err != nil {
+		log.Printf("[stats] ✗ Failed to list request latencies: %v", err)
+		http.Error(w, "Failed to list request latencies", http.StatusInternalServerError)
+		return
+	}
+
+	// Group by route so /repos/a/b/issues/1 and /repos/c/d/issues/2 count as one endpoint
+	all := make([]int64, 0, len(rows))
+	byEndpoint := make(map[routes.Route][]int64)
+	for i := range rows {
+		all = append(all, rows[i].DurationUs)
+		endpoint, ok := routes.Match(simulator, rows[i].Method, rows[i].Path)
+		if !ok {
+			endpoint = routes.Route{Method: rows[i].Method, Path: rows[i].Path}
+		}
+		endpoint.Path = simulatorPrefix(simulator) + endpoint.Path
+		byEndpoint[endpoint] = append(byEndpoint[endpoint], rows[i].DurationUs)
+	}
+
+	report := LatencyReport{
+		Simulator:     simulator,
+		WindowSeconds: int64(window.Seconds()),
+		LatencyStats:  latencyStats(all),
+		Endpoints:     make([]EndpointLatency, 0, len(byEndpoint)),
+	}
+	for endpoint, durations := range byEndpoint {
+		report.Endpoints = append(report.Endpoints, EndpointLatency{
+			Method:       endpoint.Method,
+			Path:         endpoint.Path,
+			LatencyStats: latencyStats(durations),
+		})
+	}
+	// Busiest endpoints first
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		a, b := report.Endpoints[i], report.Endpoints[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// latencyStats computes nearest-rank percentiles of durations given in microseconds
+func latencyStats(durations []int64) LatencyStats {
+	stats := LatencyStats{Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sorted := append([]int64(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return float64(sorted[rank-1]) / 1000
+	}
+
+	stats.P50 = percentile(50)
+	stats.P90 = percentile(90)
+	stats.P99 = percentile(99)
+	return stats
+}
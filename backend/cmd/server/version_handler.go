@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/recreate-run/nova-simulators/internal/routes"
+)
+
+// Build metadata, set at link time with
+// -ldflags "-X main.version=v1.2.3 -X main.commit=abc1234"
+var (
+	version = "dev"
+	commit  = ""
+)
+
+// apiLevels names the upstream API version each simulator emulates
+var apiLevels = map[string]string{
+	"slack":     "web",
+	"gmail":     "v1",
+	"gdocs":     "v1",
+	"gsheets":   "v4",
+	"datadog":   "v2",
+	"resend":    "v1",
+	"linear":    "graphql",
+	"github":    "v3",
+	"outlook":   "v1.0",
+	"pagerduty": "v2",
+	"hubspot":   "v3",
+	"jira":      "2",
+	"whatsapp":  "v21.0",
+	"postgres":  "v1",
+}
+
+// VersionResponse is the response of GET /version
+type VersionResponse struct {
+	Version    string                      `json:"version"`
+	Commit     string                      `json:"commit"`
+	GoVersion  string                      `json:"go_version"`
+	Simulators map[string]SimulatorVersion `json:"simulators"`
+}
+
+// SimulatorVersion describes what one simulator supports
+type SimulatorVersion struct {
+	APILevel  string `json:"api_level"`
+	Endpoints int    `json:"endpoints"`
+}
+
+// VersionHandler reports the build and what each simulator supports, so clients can check
+// they run against a compatible build
+type VersionHandler struct {
+	response VersionResponse
+}
+
+// NewVersionHandler creates a version handler for the running binary
+func NewVersionHandler() *VersionHandler {
+	simulators := make(map[string]SimulatorVersion)
+	for simulator, endpoints := range routes.Counts() {
+		simulators[simulator] = SimulatorVersion{
+			APILevel:  apiLevels[simulator],
+			Endpoints: endpoints,
+		}
+	}
+
+	return &VersionHandler{
+		response: VersionResponse{
+			Version:    version,
+			Commit:     buildCommit(),
+			GoVersion:  runtime.Version(),
+			Simulators: simulators,
+		},
+	}
+}
+
+// ServeHTTP handles GET /version
+func (h *VersionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.response)
+}
+
+// buildCommit returns the commit set at link time, falling back to the VCS revision Go
+// stamps into binaries built from a checkout
+func buildCommit() string {
+	if commit != "" {
+		return commit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+)
+
+// WebhooksHandler manages webhook receivers and serves the delivery log
+type WebhooksHandler struct {
+	queries *database.Queries
+}
+
+// NewWebhooksHandler creates a webhooks handler
+func NewWebhooksHandler(queries *database.Queries) *WebhooksHandler {
+	return &WebhooksHandler{
+		queries: queries,
+	}
+}
+
+// CreateReceiverRequest registers a URL to receive a simulator's webhooks in a session
+type CreateReceiverRequest struct {
+	SessionID string `json:"session_id"`
+	Simulator string `json:"simulator"`
+	URL       string `json:"url"`
+	Secret    string `json:"secret,omitempty"`
+}
+
+// Receiver is a registered webhook receiver as returned by the webhooks API
+type Receiver struct {
+	ID        int64  `json:"id"`
+	SessionID string `json:"session_id"`
+	Simulator string `json:"simulator"`
+	URL       string `json:"url"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// DeliveryAttempt is one attempt to deliver a webhook
+type DeliveryAttempt struct {
+	ID         int64           `json:"id"`
+	DeliveryID string          `json:"delivery_id"`
+	Simulator  string          `json:"simulator"`
+	Event      string          `json:"event"`
+	URL        string          `json:"url"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempt    int64           `json:"attempt"`
+	StatusCode int64           `json:"status_code"`
+	Error      string          `json:"error,omitempty"`
+	CreatedAt  int64           `json:"created_at"`
+}
+
+// ServeHTTP implements http.Handler interface
+func (h *WebhooksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Parse URL: /api/webhooks/receivers[/{id}] or /api/webhooks/deliveries
+	path := strings.TrimPrefix(r.URL.Path, "/api/webhooks")
+	path = strings.Trim(path, "/")
+
+	switch {
+	case path == "receivers" && r.Method == http.MethodGet:
+		h.handleListReceivers(w, r)
+	case path == "receivers" && r.Method == http.MethodPost:
+		h.handleCreateReceiver(w, r)
+	case strings.HasPrefix(path, "receivers/") && r.Method == http.MethodDelete:
+		id, err := strconv.ParseInt(strings.TrimPrefix(path, "receivers/"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid receiver ID", http.StatusBadRequest)
+			return
+		}
+		h.handleDeleteReceiver(w, r, id)
+	case path == "deliveries" && r.Method == http.MethodGet:
+		h.handleListDeliveries(w, r)
+	case path == "receivers" || path == "deliveries" || strings.HasPrefix(path, "receivers/"):
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *WebhooksHandler) handleCreateReceiver(w http.ResponseWriter, r *http.Request) {
+	var req CreateReceiverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.SessionID == "" || req.Simulator == "" {
+		http.Error(w, "session_id and simulator are required", http.StatusBadRequest)
+		return
+	}
+	if parsed, err := url.Parse(req.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		http.Error(w, "url must be an absolute http(s) URL", http.StatusBadRequest)
+		return
+	}
+
+	receiver, err := h.queries.CreateWebhookReceiver(context.Background(), database.CreateWebhookReceiverParams{
+		SessionID: req.SessionID,
+		Simulator: req.Simulator,
+		Url:       req.URL,
+		Secret:    req.Secret,
+	})
+	if err != nil {
+		log.Printf("[webhooks] ✗ Failed to create receiver: %v", err)
+		http.Error(w, "Failed to create receiver", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toReceiver(&receiver))
+	log.Printf("[webhooks] ✓ Registered %s receiver %s for session %s", req.Simulator, req.URL, req.SessionID)
+}
+
+func (h *WebhooksHandler) handleListReceivers(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session_id")
+	if sessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.queries.ListWebhookReceivers(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("[webhooks] ✗ Failed to list receivers: %v", err)
+		http.Error(w, "Failed to list receivers", http.StatusInternalServerError)
+		return
+	}
+
+	receivers := make([]Receiver, 0, len(rows))
+	for i := range rows {
+		receivers = append(receivers, toReceiver(&rows[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"receivers": receivers,
+	})
+}
+
+func (h *WebhooksHandler) handleDeleteReceiver(w http.ResponseWriter, r *http.Request, id int64) {
+	sessionID := r.URL.Query().Get("session_id")
+	if sessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := h.queries.DeleteWebhookReceiver(context.Background(), database.DeleteWebhookReceiverParams{
+		ID:        id,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[webhooks] ✗ Failed to delete receiver: %v", err)
+		http.Error(w, "Failed to delete receiver", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Receiver not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhooksHandler) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session_id")
+	if sessionID == "" {
+		http.Error(w, "session_id is required", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := h.queries.ListWebhookDeliveries(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("[webhooks] ✗ Failed to list deliveries: %v", err)
+		http.Error(w, "Failed to list deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	attempts := make([]DeliveryAttempt, 0, len(rows))
+	for i := range rows {
+		attempts = append(attempts, DeliveryAttempt{
+			ID:         rows[i].ID,
+			DeliveryID: rows[i].DeliveryID,
+			Simulator:  rows[i].Simulator,
+			Event:      rows[i].Event,
+			URL:        rows[i].Url,
+			Payload:    jsonBody(rows[i].Payload),
+			Attempt:    rows[i].Attempt,
+			StatusCode: rows[i].StatusCode,
+			Error:      rows[i].Error,
+			CreatedAt:  rows[i].CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"deliveries": attempts,
+	})
+}
+
+// toReceiver converts a stored receiver for the API, leaving out its secret
+func toReceiver(receiver *database.WebhookReceiver) Receiver {
+	return Receiver{
+		ID:        receiver.ID,
+		SessionID: receiver.SessionID,
+		Simulator: receiver.Simulator,
+		URL:       receiver.Url,
+		CreatedAt: receiver.CreatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+// Package apierror writes error responses in the envelope each provider's SDK expects,
+// so clients parse simulator failures the same way they parse real API failures.
+package apierror
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// Provider names an upstream API family that shares an error envelope
+type Provider string
+
+const (
+	GitHub  Provider = "github"
+	Google  Provider = "google"
+	Jira    Provider = "jira"
+	HubSpot Provider = "hubspot"
+	Datadog Provider = "datadog"
+	Slack   Provider = "slack"
+)
+
+// Writer writes an error response with the given status and message. Random parts of an
+// envelope are drawn from the session's random source.
+type Writer func(w http.ResponseWriter, sessionID string, status int, message string)
+
+var (
+	mu      sync.RWMutex
+	writers = map[Provider]Writer{
+		GitHub:  writeGitHub,
+		Google:  writeGoogle,
+		Jira:    writeJira,
+		HubSpot: writeHubSpot,
+		Datadog: writeDatadog,
+		Slack:   writeSlack,
+	}
+)
+
+// simulatorProviders maps simulator names to the provider whose envelope they use
+var simulatorProviders = map[string]Provider{
+	"datadog": Datadog,
+	"gdocs":   Google,
+	"github":  GitHub,
+	"gmail":   Google,
+	"gsheets": Google,
+	"hubspot": HubSpot,
+	"jira":    Jira,
+	"slack":   Slack,
+}
+
+// Register installs or replaces the writer for a provider
+func Register(provider Provider, writer Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	writers[provider] = writer
+}
+
+// Write writes an error in the provider's envelope, falling back to plain text for unknown providers
+func Write(w http.ResponseWriter, provider Provider, status int, message string) {
+	WriteForSession(w, "", provider, status, message)
+}
+
+// WriteForSession is Write for a response served in a session, so the envelope is reproducible
+// in a seeded session
+func WriteForSession(w http.ResponseWriter, sessionID string, provider Provider, status int, message string) {
+	mu.RLock()
+	writer, ok := writers[provider]
+	mu.RUnlock()
+	if !ok {
+		http.Error(w, message, status)
+		return
+	}
+	writer(w, sessionID, status, message)
+}
+
+// ForSimulator returns the provider whose envelope a simulator uses, and false if it has none
+func ForSimulator(simulatorName string) (Provider, bool) {
+	provider, ok := simulatorProviders[simulatorName]
+	return provider, ok
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeGitHub matches the REST API's {message, documentation_url} body
+func writeGitHub(w http.ResponseWriter, _ string, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+		"status":            strconv.Itoa(status),
+	})
+}
+
+// googleStatus holds the canonical status and legacy reason for an HTTP status
+type googleStatus struct {
+	status string
+	reason string
+}
+
+var googleStatuses = map[int]googleStatus{
+	http.StatusBadRequest:           {"INVALID_ARGUMENT", "badRequest"},
+	http.StatusUnauthorized:         {"UNAUTHENTICATED", "authError"},
+	http.StatusForbidden:            {"PERMISSION_DENIED", "forbidden"},
+	http.StatusNotFound:             {"NOT_FOUND", "notFound"},
+	http.StatusMethodNotAllowed:     {"INVALID_ARGUMENT", "httpMethodNotAllowed"},
+	http.StatusConflict:             {"ALREADY_EXISTS", "conflict"},
+	http.StatusPreconditionFailed:   {"FAILED_PRECONDITION", "conditionNotMet"},
+	http.StatusUnsupportedMediaType: {"INVALID_ARGUMENT", "unsupportedMediaType"},
+	http.StatusTooManyRequests:      {"RESOURCE_EXHAUSTED", "rateLimitExceeded"},
+	http.StatusInternalServerError:  {"INTERNAL", "backendError"},
+	http.StatusNotImplemented:       {"UNIMPLEMENTED", "notImplemented"},
+	http.StatusServiceUnavailable:   {"UNAVAILABLE", "backendError"},
+	http.StatusGatewayTimeout:       {"DEADLINE_EXCEEDED", "backendError"},
+}
+
+// writeGoogle matches the {error: {code, message, status, errors}} body of Google APIs
+func writeGoogle(w http.ResponseWriter, _ string, status int, message string) {
+	canonical, ok := googleStatuses[status]
+	if !ok {
+		canonical = googleStatus{"UNKNOWN", "unknown"}
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"message": message,
+			"status":  canonical.status,
+			"errors": []map[string]interface{}{
+				{
+					"message": message,
+					"domain":  "global",
+					"reason":  canonical.reason,
+				},
+			},
+		},
+	})
+}
+
+// writeJira matches Jira's {errorMessages, errors} body
+func writeJira(w http.ResponseWriter, _ string, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errorMessages": []string{message},
+		"errors":        map[string]string{},
+	})
+}
+
+var hubspotCategories = map[int]string{
+	http.StatusBadRequest:           "VALIDATION_ERROR",
+	http.StatusUnauthorized:         "INVALID_AUTHENTICATION",
+	http.StatusForbidden:            "MISSING_SCOPES",
+	http.StatusNotFound:             "OBJECT_NOT_FOUND",
+	http.StatusMethodNotAllowed:     "VALIDATION_ERROR",
+	http.StatusConflict:             "CONFLICT",
+	http.StatusUnsupportedMediaType: "VALIDATION_ERROR",
+	http.StatusTooManyRequests:      "RATE_LIMITS",
+}
+
+// writeHubSpot matches HubSpot's {status, message, correlationId, category} body
+func writeHubSpot(w http.ResponseWriter, sessionID string, status int, message string) {
+	category, ok := hubspotCategories[status]
+	if !ok {
+		category = "INTERNAL_ERROR"
+	}
+	correlationID := make([]byte, 16)
+	session.RandomBytes(sessionID, correlationID)
+	writeJSON(w, status, map[string]interface{}{
+		"status":        "error",
+		"message":       message,
+		"correlationId": hex.EncodeToString(correlationID),
+		"category":      category,
+	})
+}
+
+// writeDatadog matches Datadog's {errors: [...]} body
+func writeDatadog(w http.ResponseWriter, _ string, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []string{message},
+	})
+}
+
+// writeSlack matches Slack's {ok: false, error} body. Slack reports most failures with HTTP 200,
+// but the status is kept so callers decide whether that applies.
+func writeSlack(w http.ResponseWriter, _ string, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"ok":    false,
+		"error": message,
+	})
+}
@@ -0,0 +1,122 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeError(t *testing.T, provider apierror.Provider, status int, message string) map[string]interface{} {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	apierror.Write(rec, provider, status, message)
+	require.Equal(t, status, rec.Code, "Status should be preserved")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Envelope should be JSON")
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), "Body should be valid JSON")
+	return body
+}
+
+func TestWriteEnvelopes(t *testing.T) {
+	t.Run("GitHub", func(t *testing.T) {
+		body := writeError(t, apierror.GitHub, http.StatusNotFound, "Not Found")
+		assert.Equal(t, "Not Found", body["message"], "Message should be top-level")
+		assert.Equal(t, "404", body["status"], "Status should be a string")
+		assert.NotEmpty(t, body["documentation_url"], "Documentation URL should be set")
+	})
+
+	t.Run("Google", func(t *testing.T) {
+		body := writeError(t, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		errObj, ok := body["error"].(map[string]interface{})
+		require.True(t, ok, "Error should be an object")
+		assert.InDelta(t, 404, errObj["code"], 0, "Code should match the status")
+		assert.Equal(t, "NOT_FOUND", errObj["status"], "Canonical status should be set")
+		errs, ok := errObj["errors"].([]interface{})
+		require.True(t, ok, "Errors should be a list")
+		require.Len(t, errs, 1, "Should have one error detail")
+		detail, ok := errs[0].(map[string]interface{})
+		require.True(t, ok, "Error detail should be an object")
+		assert.Equal(t, "notFound", detail["reason"], "Reason should be set")
+	})
+
+	t.Run("Jira", func(t *testing.T) {
+		body := writeError(t, apierror.Jira, http.StatusBadRequest, "Invalid request body")
+		assert.Equal(t, []interface{}{"Invalid request body"}, body["errorMessages"], "Message should be in errorMessages")
+		assert.Empty(t, body["errors"], "Field errors should be empty")
+	})
+
+	t.Run("HubSpot", func(t *testing.T) {
+		body := writeError(t, apierror.HubSpot, http.StatusNotFound, "Object not found")
+		assert.Equal(t, "error", body["status"], "Status should be error")
+		assert.Equal(t, "OBJECT_NOT_FOUND", body["category"], "Category should match the status")
+		assert.NotEmpty(t, body["correlationId"], "Correlation ID should be set")
+	})
+
+	t.Run("Datadog", func(t *testing.T) {
+		body := writeError(t, apierror.Datadog, http.StatusForbidden, "Forbidden")
+		assert.Equal(t, []interface{}{"Forbidden"}, body["errors"], "Message should be in errors")
+	})
+
+	t.Run("Slack", func(t *testing.T) {
+		body := writeError(t, apierror.Slack, http.StatusOK, "channel_not_found")
+		okField, isBool := body["ok"].(bool)
+		require.True(t, isBool, "ok should be a boolean")
+		assert.False(t, okField, "ok should be false")
+		assert.Equal(t, "channel_not_found", body["error"], "Error code should be set")
+	})
+}
+
+func TestHubSpotCorrelationIDFollowsSessionSeed(t *testing.T) {
+	correlationID := func() interface{} {
+		rec := httptest.NewRecorder()
+		apierror.WriteForSession(rec, "apierror-seeded", apierror.HubSpot, http.StatusNotFound, "Object not found")
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), "Body should be valid JSON")
+		return body["correlationId"]
+	}
+
+	session.SetSeed("apierror-seeded", 42)
+	first := correlationID()
+	session.SetSeed("apierror-seeded", 42)
+	second := correlationID()
+	session.ClearSeed("apierror-seeded")
+
+	assert.NotEmpty(t, first, "Correlation ID should be set")
+	assert.Equal(t, first, second, "A seeded session should replay the same correlation ID")
+}
+
+func TestWriteUnknownProvider(t *testing.T) {
+	rec := httptest.NewRecorder()
+	apierror.Write(rec, apierror.Provider("unknown"), http.StatusTeapot, "short and stout")
+	assert.Equal(t, http.StatusTeapot, rec.Code, "Status should be preserved")
+	assert.Equal(t, "short and stout\n", rec.Body.String(), "Unknown providers should fall back to plain text")
+}
+
+func TestRegister(t *testing.T) {
+	custom := apierror.Provider("custom")
+	apierror.Register(custom, func(w http.ResponseWriter, _ string, status int, message string) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("custom:" + message))
+	})
+
+	rec := httptest.NewRecorder()
+	apierror.Write(rec, custom, http.StatusConflict, "boom")
+	assert.Equal(t, http.StatusConflict, rec.Code, "Status should be preserved")
+	assert.Equal(t, "custom:boom", rec.Body.String(), "Registered writer should be used")
+}
+
+func TestForSimulator(t *testing.T) {
+	provider, ok := apierror.ForSimulator("gmail")
+	require.True(t, ok, "gmail should have a provider")
+	assert.Equal(t, apierror.Google, provider, "gmail should use Google envelopes")
+
+	_, ok = apierror.ForSimulator("outlook")
+	assert.False(t, ok, "outlook has no registered envelope")
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -25,86 +27,128 @@ type Config struct {
 
 // SimulatorConfig is a generic configuration for simulators
 type SimulatorConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // GmailConfig contains Gmail simulator settings
 type GmailConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // SlackConfig contains Slack simulator settings
 type SlackConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // DatadogConfig contains Datadog simulator settings
 type DatadogConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // ResendConfig contains Resend simulator settings
 type ResendConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // LinearConfig contains Linear simulator settings
 type LinearConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // GitHubConfig contains GitHub simulator settings
 type GitHubConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // OutlookConfig contains Outlook simulator settings
 type OutlookConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // PagerDutyConfig contains PagerDuty simulator settings
 type PagerDutyConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // HubSpotConfig contains HubSpot simulator settings
 type HubSpotConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // JiraConfig contains Jira simulator settings
 type JiraConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // WhatsAppConfig contains WhatsApp simulator settings
 type WhatsAppConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // GoogleDocsConfig contains Google Docs simulator settings
 type GoogleDocsConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // GoogleSheetsConfig contains Google Sheets simulator settings
 type GoogleSheetsConfig struct {
-	Timeout   TimeoutConfig   `yaml:"timeout"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Timeout    TimeoutConfig    `yaml:"timeout"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Validation ValidationConfig `yaml:"validation"`
+	Faults     FaultsConfig     `yaml:"faults"`
+	Headers    HeadersConfig    `yaml:"headers"`
 }
 
 // TimeoutConfig defines artificial delay ranges
@@ -119,6 +163,45 @@ type RateLimitConfig struct {
 	PerDay    int `yaml:"per_day"`
 }
 
+// RateLimitBucket is the current state of one rate limit window for a session
+type RateLimitBucket struct {
+	Name      string    `json:"name"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// RateLimitStateFunc reports a session's rate limit buckets for one simulator
+type RateLimitStateFunc func(ctx context.Context, sessionID string) []RateLimitBucket
+
+// ValidationConfig toggles request body validation against endpoint schemas
+type ValidationConfig struct {
+	Enabled           bool `yaml:"enabled"`
+	StrictContentType bool `yaml:"strict_content_type"` // Reject bodies whose Content-Type the endpoint doesn't accept
+}
+
+// FaultsConfig enables deliberate response faults for testing client resilience.
+// All faults are off by default.
+type FaultsConfig struct {
+	TruncateRate         float64 `yaml:"truncate_rate"`          // Fraction (0-1) of responses cut off mid-body
+	SignatureSkewSeconds int     `yaml:"signature_skew_seconds"` // Shift applied to signed webhook timestamps, + or -
+}
+
+// HeadersConfig injects extra response headers, for testing clients that branch on
+// headers such as Retry-After or ETag. No headers are injected by default.
+type HeadersConfig struct {
+	Rules []HeaderRule `yaml:"rules"`
+}
+
+// HeaderRule adds headers to responses matching a method, path pattern and status.
+// Every matching rule applies, in order, so later rules overwrite earlier ones.
+type HeaderRule struct {
+	Method  string            `yaml:"method"`  // Empty or "*" matches any method
+	Path    string            `yaml:"path"`    // path.Match pattern against the simulator-relative path; empty matches any
+	Status  int               `yaml:"status"`  // Response status to match; 0 matches any
+	Headers map[string]string `yaml:"headers"` // Header name to value
+}
+
 // Load reads and parses the YAML configuration file
 func Load(path string) (*Config, error) {
 	//nolint:gosec // G304: Reading config file path is intentional
@@ -1,8 +1,12 @@
 package config_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +17,7 @@ import (
 	"github.com/recreate-run/nova-simulators/internal/database"
 	"github.com/recreate-run/nova-simulators/internal/middleware"
 	"github.com/recreate-run/nova-simulators/internal/session"
+	simulatorGmail "github.com/recreate-run/nova-simulators/simulators/gmail"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	_ "modernc.org/sqlite"
@@ -338,3 +343,364 @@ func TestMiddlewareWithSessionConfig(t *testing.T) {
 		assert.Less(t, duration.Milliseconds(), int64(50), "Should use default (no delay)")
 	})
 }
+
+func TestValidationMiddleware(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create default config and manager
+	defaultCfg := config.Default()
+	configManager := config.NewManager(defaultCfg, queries)
+
+	// Setup: Gmail simulator with validation middleware
+	handler := session.Middleware(
+		middleware.Validation(configManager, "gmail")(simulatorGmail.NewHandler(queries)))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	sendWithoutRaw := func(t *testing.T, sessionID string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			server.URL+"/gmail/v1/users/me/messages/send", bytes.NewReader([]byte(`{"threadId": "abc"}`)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Session-ID", sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("ValidationDisabledByDefault", func(t *testing.T) {
+		sessionID := "test-session-validation-off"
+		setupTestSession(t, queries, sessionID)
+
+		cfg := configManager.GetValidationConfig(context.Background(), sessionID, "gmail")
+		assert.False(t, cfg.Enabled, "Validation should be off by default")
+
+		resp := sendWithoutRaw(t, sessionID)
+		defer resp.Body.Close()
+
+		// The lenient handler accepts the body as an empty message
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Should not return a validation error")
+	})
+
+	t.Run("GmailSendWithoutRaw", func(t *testing.T) {
+		ctx := context.Background()
+		sessionID := "test-session-validation-on"
+		setupTestSession(t, queries, sessionID)
+
+		err := configManager.SetValidationConfig(ctx, sessionID, "gmail", &config.ValidationConfig{Enabled: true})
+		require.NoError(t, err, "SetValidationConfig should succeed")
+
+		// Other settings keep their defaults
+		rateLimit := configManager.GetRateLimitConfig(ctx, sessionID, "gmail")
+		assert.Equal(t, defaultCfg.Gmail.RateLimit.PerMinute, rateLimit.PerMinute)
+
+		resp := sendWithoutRaw(t, sessionID)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should reject missing raw")
+
+		var body struct {
+			Error struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+				Status  string `json:"status"`
+				Field   string `json:"field"`
+			} `json:"error"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		require.NoError(t, err, "Should return a JSON error")
+		assert.Equal(t, "raw", body.Error.Field, "Should name the offending field")
+		assert.Equal(t, "INVALID_ARGUMENT", body.Error.Status)
+		assert.Contains(t, body.Error.Message, "raw")
+	})
+
+	t.Run("HeaderlessMessageInStrictMode", func(t *testing.T) {
+		ctx := context.Background()
+		raw := base64.URLEncoding.EncodeToString([]byte("Just a body with no headers at all"))
+		sendRaw := func(t *testing.T, sessionID string) *http.Response {
+			t.Helper()
+			body, err := json.Marshal(map[string]string{"raw": raw})
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+				server.URL+"/gmail/v1/users/me/messages/send", bytes.NewReader(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Session-ID", sessionID)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			return resp
+		}
+
+		// Lenient sessions still accept the message
+		lenientSession := "test-session-mime-lenient"
+		setupTestSession(t, queries, lenientSession)
+		resp := sendRaw(t, lenientSession)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Lenient mode should accept a header-less message")
+
+		// Strict sessions reject it with the Google error envelope
+		strictSession := "test-session-mime-strict"
+		setupTestSession(t, queries, strictSession)
+		err := configManager.SetValidationConfig(ctx, strictSession, "gmail", &config.ValidationConfig{Enabled: true})
+		require.NoError(t, err, "SetValidationConfig should succeed")
+
+		resp = sendRaw(t, strictSession)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Strict mode should reject a header-less message")
+
+		var envelope struct {
+			Error struct {
+				Code   int    `json:"code"`
+				Field  string `json:"field"`
+				Errors []struct {
+					Reason string `json:"reason"`
+				} `json:"errors"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&envelope), "Should return a JSON error")
+		assert.Equal(t, 400, envelope.Error.Code)
+		assert.Equal(t, "raw", envelope.Error.Field, "Should name the raw field")
+		require.Len(t, envelope.Error.Errors, 1, "Should include an errors entry")
+		assert.Equal(t, "invalidArgument", envelope.Error.Errors[0].Reason)
+	})
+
+	t.Run("JiraEnvelope", func(t *testing.T) {
+		ctx := context.Background()
+		sessionID := "test-session-validation-jira"
+		setupTestSession(t, queries, sessionID)
+		err := configManager.SetValidationConfig(ctx, sessionID, "jira", &config.ValidationConfig{Enabled: true})
+		require.NoError(t, err, "SetValidationConfig should succeed")
+
+		jiraServer := httptest.NewServer(session.Middleware(middleware.Validation(configManager, "jira")(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			}))))
+		defer jiraServer.Close()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			jiraServer.URL+"/rest/api/2/issue", bytes.NewReader([]byte(`{"fields": {}}`)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Session-ID", sessionID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should reject a missing project")
+
+		var body struct {
+			ErrorMessages []string `json:"errorMessages"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body), "Should return a JSON error")
+		require.Len(t, body.ErrorMessages, 1, "Should use the Jira envelope")
+		assert.Contains(t, body.ErrorMessages[0], "fields.project")
+	})
+
+	t.Run("MissingFromAndBrokenMIME", func(t *testing.T) {
+		rule := middleware.FieldRule{Path: "raw", Type: "string", Required: true, Format: "rfc822"}
+		schema := &middleware.RequestSchema{Fields: []middleware.FieldRule{rule}}
+		encode := func(msg string) []byte {
+			body, err := json.Marshal(map[string]string{"raw": base64.URLEncoding.EncodeToString([]byte(msg))})
+			require.NoError(t, err)
+			return body
+		}
+
+		assert.NotNil(t, middleware.ValidateBody(schema, encode("To: bob@example.com\r\nSubject: Hi\r\n\r\nBody")),
+			"Should reject a message without From")
+		assert.NotNil(t, middleware.ValidateBody(schema, encode(
+			"From: alice@example.com\r\nContent-Type: multipart/mixed; boundary=b1\r\n\r\nno parts here")),
+			"Should reject a multipart message with no parts")
+		assert.Nil(t, middleware.ValidateBody(schema, encode(
+			"From: alice@example.com\r\nContent-Type: multipart/mixed; boundary=b1\r\n\r\n--b1\r\nContent-Type: text/plain\r\n\r\nHi\r\n--b1--\r\n")),
+			"Should accept a well-formed multipart message")
+	})
+
+	t.Run("WrongFieldType", func(t *testing.T) {
+		schemaErr := middleware.ValidateBody(&middleware.RequestSchema{
+			Fields: []middleware.FieldRule{{Path: "message.subject", Type: "string", Required: true}},
+		}, []byte(`{"message": {"subject": 42}}`))
+		require.NotNil(t, schemaErr, "Should reject wrong type")
+		assert.Equal(t, "message.subject", schemaErr.Field)
+	})
+}
+
+func TestOverrideKeepsOtherDefaults(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: YAML defaults with validation and faults turned on for Slack
+	defaultCfg := config.Default()
+	defaultCfg.Slack.Validation = config.ValidationConfig{Enabled: true, StrictContentType: true}
+	defaultCfg.Slack.Faults = config.FaultsConfig{TruncateRate: 0.5, SignatureSkewSeconds: 30}
+	configManager := config.NewManager(defaultCfg, queries)
+
+	ctx := context.Background()
+	sessionID := "test-session-override-defaults"
+	setupTestSession(t, queries, sessionID)
+
+	// Override only the timeout
+	err := configManager.SetSessionConfig(ctx, sessionID, "slack",
+		&config.TimeoutConfig{MinMs: 100, MaxMs: 200},
+		&defaultCfg.Slack.RateLimit)
+	require.NoError(t, err, "SetSessionConfig should succeed")
+
+	assert.Equal(t, &config.TimeoutConfig{MinMs: 100, MaxMs: 200}, configManager.GetTimeoutConfig(ctx, sessionID, "slack"))
+	assert.Equal(t, &defaultCfg.Slack.Validation, configManager.GetValidationConfig(ctx, sessionID, "slack"),
+		"A timeout override should leave validation at its default")
+	assert.Equal(t, &defaultCfg.Slack.Faults, configManager.GetFaultsConfig(ctx, sessionID, "slack"),
+		"A timeout override should leave faults at their default")
+
+	// Overriding faults afterwards keeps the timeout override
+	err = configManager.SetFaultsConfig(ctx, sessionID, "slack", &config.FaultsConfig{TruncateRate: 1})
+	require.NoError(t, err, "SetFaultsConfig should succeed")
+	assert.Equal(t, &config.TimeoutConfig{MinMs: 100, MaxMs: 200}, configManager.GetTimeoutConfig(ctx, sessionID, "slack"))
+	assert.Equal(t, &defaultCfg.Slack.Validation, configManager.GetValidationConfig(ctx, sessionID, "slack"))
+}
+
+func TestConfigProfiles(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create default config and manager
+	defaultCfg := config.Default()
+	configManager := config.NewManager(defaultCfg, queries)
+
+	// Setup: Create test session
+	sessionID := "test-session-profiles"
+	setupTestSession(t, queries, sessionID)
+
+	t.Run("SaveChangeAndReapply", func(t *testing.T) {
+		ctx := context.Background()
+
+		// Inject a fault: slow, heavily rate-limited Slack
+		err := configManager.SetSessionConfig(ctx, sessionID, "slack",
+			&config.TimeoutConfig{MinMs: 500, MaxMs: 900},
+			&config.RateLimitConfig{PerMinute: 1, PerDay: 10})
+		require.NoError(t, err)
+
+		// Save it as a profile
+		profile, err := configManager.SaveProfile(ctx, "slow-slack", sessionID)
+		require.NoError(t, err, "SaveProfile should succeed")
+		assert.Equal(t, "slow-slack", profile.Name)
+		require.Len(t, profile.Overrides, 1, "Profile should capture one override")
+
+		// Change the fault and add an unrelated override
+		err = configManager.SetSessionConfig(ctx, sessionID, "slack",
+			&config.TimeoutConfig{MinMs: 0, MaxMs: 0},
+			&config.RateLimitConfig{PerMinute: 1000, PerDay: 10000})
+		require.NoError(t, err)
+		err = configManager.SetSessionConfig(ctx, sessionID, "gmail",
+			&config.TimeoutConfig{MinMs: 50, MaxMs: 50},
+			&config.RateLimitConfig{PerMinute: 5, PerDay: 50})
+		require.NoError(t, err)
+
+		// Re-apply the profile
+		_, err = configManager.ApplyProfile(ctx, "slow-slack", sessionID)
+		require.NoError(t, err, "ApplyProfile should succeed")
+
+		// Verify the saved fault is back
+		timeout := configManager.GetTimeoutConfig(ctx, sessionID, "slack")
+		rateLimit := configManager.GetRateLimitConfig(ctx, sessionID, "slack")
+		assert.Equal(t, 500, timeout.MinMs)
+		assert.Equal(t, 900, timeout.MaxMs)
+		assert.Equal(t, 1, rateLimit.PerMinute)
+		assert.Equal(t, 10, rateLimit.PerDay)
+
+		// Verify overrides added after saving were dropped
+		configs, err := configManager.ListSessionConfigs(ctx, sessionID)
+		require.NoError(t, err)
+		require.Len(t, configs, 1, "Only the profile's overrides should remain")
+		assert.Equal(t, "slack", configs[0].SimulatorName)
+	})
+
+	t.Run("ListProfiles", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := configManager.SaveProfile(ctx, "another", sessionID)
+		require.NoError(t, err)
+
+		profiles, err := configManager.ListProfiles(ctx)
+		require.NoError(t, err, "ListProfiles should succeed")
+		require.Len(t, profiles, 2, "Should list both profiles")
+		assert.Equal(t, "another", profiles[0].Name, "Profiles should be ordered by name")
+		assert.Equal(t, "slow-slack", profiles[1].Name)
+	})
+
+	t.Run("SurvivesRestart", func(t *testing.T) {
+		ctx := context.Background()
+
+		// A new manager over the same database stands in for a restarted server
+		restarted := config.NewManager(defaultCfg, queries)
+		profiles, err := restarted.ListProfiles(ctx)
+		require.NoError(t, err, "ListProfiles should succeed")
+		require.Len(t, profiles, 2, "Saved profiles should outlive the manager")
+
+		profile, err := restarted.ApplyProfile(ctx, "slow-slack", sessionID)
+		require.NoError(t, err, "ApplyProfile should succeed")
+		require.Len(t, profile.Overrides, 1, "Overrides should round-trip through the database")
+		assert.Equal(t, config.TimeoutConfig{MinMs: 500, MaxMs: 900}, profile.Overrides[0].Timeout)
+	})
+
+	t.Run("ApplyUnknownProfile", func(t *testing.T) {
+		_, err := configManager.ApplyProfile(context.Background(), "missing", sessionID)
+		assert.ErrorIs(t, err, config.ErrProfileNotFound)
+	})
+}
+
+func TestFaultsMiddleware(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create default config and manager
+	configManager := config.NewManager(config.Default(), queries)
+
+	// Setup: Gmail simulator with faults middleware
+	handler := session.Middleware(
+		middleware.Faults(configManager, "gmail")(simulatorGmail.NewHandler(queries)))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	listMessages := func(t *testing.T, sessionID string) (*http.Response, []byte, error) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			server.URL+"/gmail/v1/users/me/messages", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-ID", sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Headers should arrive intact")
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return resp, body, err
+	}
+
+	t.Run("FaultsDisabledByDefault", func(t *testing.T) {
+		sessionID := "test-session-faults-off"
+		setupTestSession(t, queries, sessionID)
+
+		cfg := configManager.GetFaultsConfig(context.Background(), sessionID, "gmail")
+		assert.Zero(t, cfg.TruncateRate, "Truncation should be off by default")
+
+		resp, body, err := listMessages(t, sessionID)
+		require.NoError(t, err, "Body should be read completely")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.True(t, json.Valid(body), "Body should be valid JSON")
+	})
+
+	t.Run("TruncateEveryResponse", func(t *testing.T) {
+		ctx := context.Background()
+		sessionID := "test-session-faults-truncate"
+		setupTestSession(t, queries, sessionID)
+
+		err := configManager.SetFaultsConfig(ctx, sessionID, "gmail", &config.FaultsConfig{TruncateRate: 1.0})
+		require.NoError(t, err, "SetFaultsConfig should succeed")
+
+		resp, body, err := listMessages(t, sessionID)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF, "Body should end before its Content-Length")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be preserved")
+		assert.Less(t, int64(len(body)), resp.ContentLength, "Only part of the body should arrive")
+		assert.False(t, json.Valid(body), "Truncated body should not be valid JSON")
+	})
+}
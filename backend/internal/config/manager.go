@@ -2,6 +2,8 @@ package config
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"sync"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
@@ -12,6 +14,11 @@ type Manager struct {
 	defaultConfig *Config
 	queries       *database.Queries
 	mu            sync.RWMutex
+
+	// rateLimiters reports live limiter state; it has its own lock because the limiters read
+	// config through the manager while reporting
+	rateLimiters   map[string]RateLimitStateFunc
+	rateLimitersMu sync.RWMutex
 }
 
 // NewManager creates a new configuration manager
@@ -19,6 +26,7 @@ func NewManager(defaultConfig *Config, queries *database.Queries) *Manager {
 	return &Manager{
 		defaultConfig: defaultConfig,
 		queries:       queries,
+		rateLimiters:  make(map[string]RateLimitStateFunc),
 	}
 }
 
@@ -68,21 +76,167 @@ func (m *Manager) GetRateLimitConfig(ctx context.Context, sessionID, simulator s
 	return m.getDefaultRateLimitConfig(simulator)
 }
 
+// GetValidationConfig returns validation config for a session/simulator (override or default)
+func (m *Manager) GetValidationConfig(ctx context.Context, sessionID, simulator string) *ValidationConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Try to get session-specific override from database
+	if sessionID != "" && m.queries != nil {
+		cfg, err := m.queries.GetSessionConfig(ctx, database.GetSessionConfigParams{
+			SessionID:     sessionID,
+			SimulatorName: simulator,
+		})
+		if err == nil {
+			return &ValidationConfig{
+				Enabled:           cfg.ValidationEnabled != 0,
+				StrictContentType: cfg.ValidationStrictContentType != 0,
+			}
+		}
+	}
+
+	// Fall back to YAML default
+	return m.getDefaultValidationConfig(simulator)
+}
+
+// GetFaultsConfig returns fault injection config for a session/simulator (override or default)
+func (m *Manager) GetFaultsConfig(ctx context.Context, sessionID, simulator string) *FaultsConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Try to get session-specific override from database
+	if sessionID != "" && m.queries != nil {
+		cfg, err := m.queries.GetSessionConfig(ctx, database.GetSessionConfigParams{
+			SessionID:     sessionID,
+			SimulatorName: simulator,
+		})
+		if err == nil {
+			return &FaultsConfig{
+				TruncateRate:         cfg.FaultTruncateRate,
+				SignatureSkewSeconds: int(cfg.FaultSignatureSkewSeconds),
+			}
+		}
+	}
+
+	// Fall back to YAML default
+	return m.getDefaultFaultsConfig(simulator)
+}
+
+// GetHeadersConfig returns response header injection config for a session/simulator (override or default)
+func (m *Manager) GetHeadersConfig(ctx context.Context, sessionID, simulator string) *HeadersConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Try to get session-specific override from database; rows without header rules use the default
+	if sessionID != "" && m.queries != nil {
+		cfg, err := m.queries.GetSessionConfig(ctx, database.GetSessionConfigParams{
+			SessionID:     sessionID,
+			SimulatorName: simulator,
+		})
+		if err == nil && cfg.ResponseHeaders.Valid {
+			var headers HeadersConfig
+			if err := json.Unmarshal([]byte(cfg.ResponseHeaders.String), &headers); err == nil {
+				return &headers
+			}
+		}
+	}
+
+	// Fall back to YAML default
+	return m.getDefaultHeadersConfig(simulator)
+}
+
 // SetSessionConfig saves session-specific config override to database
 func (m *Manager) SetSessionConfig(ctx context.Context, sessionID, simulator string, timeout *TimeoutConfig, rateLimit *RateLimitConfig) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	return m.queries.UpsertSessionConfig(ctx, database.UpsertSessionConfigParams{
-		SessionID:           sessionID,
-		SimulatorName:       simulator,
-		TimeoutMinMs:        int64(timeout.MinMs),
-		TimeoutMaxMs:        int64(timeout.MaxMs),
-		RateLimitPerMinute:  int64(rateLimit.PerMinute),
-		RateLimitPerDay:     int64(rateLimit.PerDay),
+	row := m.newOverrideRow(sessionID, simulator)
+	row.TimeoutMinMs = int64(timeout.MinMs)
+	row.TimeoutMaxMs = int64(timeout.MaxMs)
+	row.RateLimitPerMinute = int64(rateLimit.PerMinute)
+	row.RateLimitPerDay = int64(rateLimit.PerDay)
+	return m.queries.UpsertSessionConfig(ctx, row)
+}
+
+// SetValidationConfig saves a session-specific validation override, keeping other settings intact
+func (m *Manager) SetValidationConfig(ctx context.Context, sessionID, simulator string, validation *ValidationConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	row := m.newOverrideRow(sessionID, simulator)
+	row.ValidationEnabled = boolToInt(validation.Enabled)
+	row.ValidationStrictContentType = boolToInt(validation.StrictContentType)
+	return m.queries.UpsertSessionValidationConfig(ctx, database.UpsertSessionValidationConfigParams(row))
+}
+
+// SetFaultsConfig saves a session-specific fault injection override, keeping other settings intact
+func (m *Manager) SetFaultsConfig(ctx context.Context, sessionID, simulator string, faults *FaultsConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	row := m.newOverrideRow(sessionID, simulator)
+	row.FaultTruncateRate = faults.TruncateRate
+	row.FaultSignatureSkewSeconds = int64(faults.SignatureSkewSeconds)
+	return m.queries.UpsertSessionFaultsConfig(ctx, database.UpsertSessionFaultsConfigParams(row))
+}
+
+// SetHeadersConfig saves a session-specific response header override, keeping other settings intact
+func (m *Manager) SetHeadersConfig(ctx context.Context, sessionID, simulator string, headers *HeadersConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+
+	row := m.newOverrideRow(sessionID, simulator)
+	return m.queries.UpsertSessionHeadersConfig(ctx, database.UpsertSessionHeadersConfigParams{
+		SessionID:                   row.SessionID,
+		SimulatorName:               row.SimulatorName,
+		TimeoutMinMs:                row.TimeoutMinMs,
+		TimeoutMaxMs:                row.TimeoutMaxMs,
+		RateLimitPerMinute:          row.RateLimitPerMinute,
+		RateLimitPerDay:             row.RateLimitPerDay,
+		ValidationEnabled:           row.ValidationEnabled,
+		ValidationStrictContentType: row.ValidationStrictContentType,
+		FaultTruncateRate:           row.FaultTruncateRate,
+		FaultSignatureSkewSeconds:   row.FaultSignatureSkewSeconds,
+		ResponseHeaders:             sql.NullString{String: string(data), Valid: true},
 	})
 }
 
+// newOverrideRow returns the YAML defaults of a simulator as a session_configs row. Setters only
+// overwrite their own columns of an existing row, but a row they create starts from these, so
+// overriding one setting leaves the others reading as their defaults.
+func (m *Manager) newOverrideRow(sessionID, simulator string) database.UpsertSessionConfigParams {
+	timeout := m.getDefaultTimeoutConfig(simulator)
+	rateLimit := m.getDefaultRateLimitConfig(simulator)
+	validation := m.getDefaultValidationConfig(simulator)
+	faults := m.getDefaultFaultsConfig(simulator)
+
+	return database.UpsertSessionConfigParams{
+		SessionID:                   sessionID,
+		SimulatorName:               simulator,
+		TimeoutMinMs:                int64(timeout.MinMs),
+		TimeoutMaxMs:                int64(timeout.MaxMs),
+		RateLimitPerMinute:          int64(rateLimit.PerMinute),
+		RateLimitPerDay:             int64(rateLimit.PerDay),
+		ValidationEnabled:           boolToInt(validation.Enabled),
+		ValidationStrictContentType: boolToInt(validation.StrictContentType),
+		FaultTruncateRate:           faults.TruncateRate,
+		FaultSignatureSkewSeconds:   int64(faults.SignatureSkewSeconds),
+	}
+}
+
+// boolToInt converts a bool to the 0/1 integer stored in SQLite
+func boolToInt(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
 // DeleteSessionConfig removes session-specific config override
 func (m *Manager) DeleteSessionConfig(ctx context.Context, sessionID, simulator string) error {
 	m.mu.Lock()
@@ -112,6 +266,26 @@ func (m *Manager) ListSessionConfigs(ctx context.Context, sessionID string) ([]d
 	return configs, nil
 }
 
+// RegisterRateLimiter makes a simulator's rate limiter state available through RateLimitState
+func (m *Manager) RegisterRateLimiter(simulator string, state RateLimitStateFunc) {
+	m.rateLimitersMu.Lock()
+	defer m.rateLimitersMu.Unlock()
+
+	m.rateLimiters[simulator] = state
+}
+
+// RateLimitState returns a session's rate limit buckets for a simulator. ok is false when the
+// simulator has no registered rate limiter.
+func (m *Manager) RateLimitState(ctx context.Context, sessionID, simulator string) (buckets []RateLimitBucket, ok bool) {
+	m.rateLimitersMu.RLock()
+	state, ok := m.rateLimiters[simulator]
+	m.rateLimitersMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return state(ctx, sessionID), true
+}
+
 // getDefaultTimeoutConfig returns default timeout config for a simulator
 func (m *Manager) getDefaultTimeoutConfig(simulator string) *TimeoutConfig {
 	switch simulator {
@@ -179,3 +353,105 @@ func (m *Manager) getDefaultRateLimitConfig(simulator string) *RateLimitConfig {
 		return &RateLimitConfig{PerMinute: 60, PerDay: 1000}
 	}
 }
+
+// getDefaultValidationConfig returns default validation config for a simulator
+func (m *Manager) getDefaultValidationConfig(simulator string) *ValidationConfig {
+	switch simulator {
+	case "slack":
+		return &m.defaultConfig.Slack.Validation
+	case "gmail":
+		return &m.defaultConfig.Gmail.Validation
+	case "gdocs":
+		return &m.defaultConfig.GoogleDocs.Validation
+	case "gsheets":
+		return &m.defaultConfig.GoogleSheets.Validation
+	case "datadog":
+		return &m.defaultConfig.Datadog.Validation
+	case "resend":
+		return &m.defaultConfig.Resend.Validation
+	case "linear":
+		return &m.defaultConfig.Linear.Validation
+	case "github":
+		return &m.defaultConfig.GitHub.Validation
+	case "outlook":
+		return &m.defaultConfig.Outlook.Validation
+	case "pagerduty":
+		return &m.defaultConfig.PagerDuty.Validation
+	case "hubspot":
+		return &m.defaultConfig.HubSpot.Validation
+	case "jira":
+		return &m.defaultConfig.Jira.Validation
+	case "whatsapp":
+		return &m.defaultConfig.WhatsApp.Validation
+	default:
+		return &ValidationConfig{Enabled: false}
+	}
+}
+
+// getDefaultFaultsConfig returns default fault injection config for a simulator
+func (m *Manager) getDefaultFaultsConfig(simulator string) *FaultsConfig {
+	switch simulator {
+	case "slack":
+		return &m.defaultConfig.Slack.Faults
+	case "gmail":
+		return &m.defaultConfig.Gmail.Faults
+	case "gdocs":
+		return &m.defaultConfig.GoogleDocs.Faults
+	case "gsheets":
+		return &m.defaultConfig.GoogleSheets.Faults
+	case "datadog":
+		return &m.defaultConfig.Datadog.Faults
+	case "resend":
+		return &m.defaultConfig.Resend.Faults
+	case "linear":
+		return &m.defaultConfig.Linear.Faults
+	case "github":
+		return &m.defaultConfig.GitHub.Faults
+	case "outlook":
+		return &m.defaultConfig.Outlook.Faults
+	case "pagerduty":
+		return &m.defaultConfig.PagerDuty.Faults
+	case "hubspot":
+		return &m.defaultConfig.HubSpot.Faults
+	case "jira":
+		return &m.defaultConfig.Jira.Faults
+	case "whatsapp":
+		return &m.defaultConfig.WhatsApp.Faults
+	default:
+		return &FaultsConfig{TruncateRate: 0}
+	}
+}
+
+// getDefaultHeadersConfig returns default response header injection config for a simulator
+func (m *Manager) getDefaultHeadersConfig(simulator string) *HeadersConfig {
+	switch simulator {
+	case "slack":
+		return &m.defaultConfig.Slack.Headers
+	case "gmail":
+		return &m.defaultConfig.Gmail.Headers
+	case "gdocs":
+		return &m.defaultConfig.GoogleDocs.Headers
+	case "gsheets":
+		return &m.defaultConfig.GoogleSheets.Headers
+	case "datadog":
+		return &m.defaultConfig.Datadog.Headers
+	case "resend":
+		return &m.defaultConfig.Resend.Headers
+	case "linear":
+		return &m.defaultConfig.Linear.Headers
+	case "github":
+		return &m.defaultConfig.GitHub.Headers
+	case "outlook":
+		return &m.defaultConfig.Outlook.Headers
+	case "pagerduty":
+		return &m.defaultConfig.PagerDuty.Headers
+	case "hubspot":
+		return &m.defaultConfig.HubSpot.Headers
+	case "jira":
+		return &m.defaultConfig.Jira.Headers
+	case "whatsapp":
+		return &m.defaultConfig.WhatsApp.Headers
+	default:
+		return &HeadersConfig{}
+	}
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// ErrProfileNotFound is returned when a named profile has not been saved
+var ErrProfileNotFound = errors.New("config profile not found")
+
+// SimulatorOverride is one simulator's config override captured in a profile
+type SimulatorOverride struct {
+	Simulator  string           `json:"simulator"`
+	Timeout    TimeoutConfig    `json:"timeout"`
+	RateLimit  RateLimitConfig  `json:"rate_limit"`
+	Validation ValidationConfig `json:"validation"`
+	Faults     FaultsConfig     `json:"faults"`
+	Headers    *HeadersConfig   `json:"headers,omitempty"`
+}
+
+// Profile is a named snapshot of a session's config overrides, stored in the config_profiles table
+type Profile struct {
+	Name      string              `json:"name"`
+	Overrides []SimulatorOverride `json:"overrides"`
+	SavedAt   time.Time           `json:"saved_at"`
+}
+
+// SaveProfile captures the current overrides of a session under a name, replacing any profile with that name
+func (m *Manager) SaveProfile(ctx context.Context, name, sessionID string) (*Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	configs, err := m.queries.ListSessionConfigs(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		Name:      name,
+		Overrides: make([]SimulatorOverride, 0, len(configs)),
+		SavedAt:   time.UnixMilli(session.Now(sessionID).UnixMilli()),
+	}
+	for _, cfg := range configs {
+		var headers *HeadersConfig
+		if cfg.ResponseHeaders.Valid {
+			headers = &HeadersConfig{}
+			if err := json.Unmarshal([]byte(cfg.ResponseHeaders.String), headers); err != nil {
+				return nil, err
+			}
+		}
+		profile.Overrides = append(profile.Overrides, SimulatorOverride{
+			Simulator: cfg.SimulatorName,
+			Timeout: TimeoutConfig{
+				MinMs: int(cfg.TimeoutMinMs),
+				MaxMs: int(cfg.TimeoutMaxMs),
+			},
+			RateLimit: RateLimitConfig{
+				PerMinute: int(cfg.RateLimitPerMinute),
+				PerDay:    int(cfg.RateLimitPerDay),
+			},
+			Validation: ValidationConfig{
+				Enabled:           cfg.ValidationEnabled != 0,
+				StrictContentType: cfg.ValidationStrictContentType != 0,
+			},
+			Faults: FaultsConfig{
+				TruncateRate:         cfg.FaultTruncateRate,
+				SignatureSkewSeconds: int(cfg.FaultSignatureSkewSeconds),
+			},
+			Headers: headers,
+		})
+	}
+
+	overrides, err := json.Marshal(profile.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.queries.UpsertConfigProfile(ctx, database.UpsertConfigProfileParams{
+		Name:      name,
+		Overrides: string(overrides),
+		SavedAt:   profile.SavedAt.UnixMilli(),
+	}); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// ApplyProfile replaces a session's overrides with those captured in a profile
+func (m *Manager) ApplyProfile(ctx context.Context, name, sessionID string) (*Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	row, err := m.queries.GetConfigProfile(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrProfileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	profile, err := toProfile(&row)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.queries.ExecTx(ctx, func(q *database.Queries) error {
+		// Drop overrides the profile doesn't know about so the session matches it exactly
+		existing, err := q.ListSessionConfigs(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		for _, cfg := range existing {
+			if err := q.DeleteSessionConfig(ctx, database.DeleteSessionConfigParams{
+				SessionID:     sessionID,
+				SimulatorName: cfg.SimulatorName,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, override := range profile.Overrides {
+			// The profile captured every column, so the row is restored as saved
+			row := database.UpsertSessionConfigParams{
+				SessionID:                   sessionID,
+				SimulatorName:               override.Simulator,
+				TimeoutMinMs:                int64(override.Timeout.MinMs),
+				TimeoutMaxMs:                int64(override.Timeout.MaxMs),
+				RateLimitPerMinute:          int64(override.RateLimit.PerMinute),
+				RateLimitPerDay:             int64(override.RateLimit.PerDay),
+				ValidationEnabled:           boolToInt(override.Validation.Enabled),
+				ValidationStrictContentType: boolToInt(override.Validation.StrictContentType),
+				FaultTruncateRate:           override.Faults.TruncateRate,
+				FaultSignatureSkewSeconds:   int64(override.Faults.SignatureSkewSeconds),
+			}
+			if override.Headers == nil {
+				if err := q.UpsertSessionConfig(ctx, row); err != nil {
+					return err
+				}
+				continue
+			}
+
+			data, err := json.Marshal(override.Headers)
+			if err != nil {
+				return err
+			}
+			if err := q.UpsertSessionHeadersConfig(ctx, database.UpsertSessionHeadersConfigParams{
+				SessionID:                   row.SessionID,
+				SimulatorName:               row.SimulatorName,
+				TimeoutMinMs:                row.TimeoutMinMs,
+				TimeoutMaxMs:                row.TimeoutMaxMs,
+				RateLimitPerMinute:          row.RateLimitPerMinute,
+				RateLimitPerDay:             row.RateLimitPerDay,
+				ValidationEnabled:           row.ValidationEnabled,
+				ValidationStrictContentType: row.ValidationStrictContentType,
+				FaultTruncateRate:           row.FaultTruncateRate,
+				FaultSignatureSkewSeconds:   row.FaultSignatureSkewSeconds,
+				ResponseHeaders:             sql.NullString{String: string(data), Valid: true},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// ListProfiles returns all saved profiles ordered by name
+func (m *Manager) ListProfiles(ctx context.Context) ([]Profile, error) {
+	rows, err := m.queries.ListConfigProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]Profile, 0, len(rows))
+	for i := range rows {
+		profile, err := toProfile(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+	return profiles, nil
+}
+
+// toProfile decodes a stored profile
+func toProfile(row *database.ConfigProfile) (*Profile, error) {
+	profile := &Profile{
+		Name:    row.Name,
+		SavedAt: time.UnixMilli(row.SavedAt),
+	}
+	if err := json.Unmarshal([]byte(row.Overrides), &profile.Overrides); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
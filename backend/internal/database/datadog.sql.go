@@ -10,6 +10,33 @@ import (
 	"database/sql"
 )
 
+const countDatadogSessionObjects = `-- name: CountDatadogSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM datadog_metrics WHERE session_id = ?1) AS metrics,
+    (SELECT COUNT(*) FROM datadog_events WHERE session_id = ?1) AS events,
+    (SELECT COUNT(*) FROM datadog_monitors WHERE session_id = ?1) AS monitors,
+    (SELECT COUNT(*) FROM datadog_incidents WHERE session_id = ?1) AS incidents
+`
+
+type CountDatadogSessionObjectsRow struct {
+	Metrics   int64 `json:"metrics"`
+	Events    int64 `json:"events"`
+	Monitors  int64 `json:"monitors"`
+	Incidents int64 `json:"incidents"`
+}
+
+func (q *Queries) CountDatadogSessionObjects(ctx context.Context, sessionID string) (CountDatadogSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countDatadogSessionObjects, sessionID)
+	var i CountDatadogSessionObjectsRow
+	err := row.Scan(
+		&i.Metrics,
+		&i.Events,
+		&i.Monitors,
+		&i.Incidents,
+	)
+	return i, err
+}
+
 const createDatadogEvent = `-- name: CreateDatadogEvent :one
 
 INSERT INTO datadog_events (title, text, tags, session_id, created_at)
@@ -53,10 +80,20 @@ func (q *Queries) CreateDatadogEvent(ctx context.Context, arg CreateDatadogEvent
 	return i, err
 }
 
-const createDatadogIncident = `-- name: CreateDatadogIncident :exec
+const createDatadogIncident = `-- name: CreateDatadogIncident :one
 
-INSERT INTO datadog_incidents (id, title, customer_impacted, severity, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO datadog_incidents (id, title, customer_impacted, severity, session_id, created_at, updated_at, public_id)
+VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5,
+    ?6,
+    ?7,
+    (SELECT COALESCE(MAX(public_id), 0) + 1 FROM datadog_incidents WHERE session_id IN (?5, ?8))
+)
+RETURNING public_id
 `
 
 type CreateDatadogIncidentParams struct {
@@ -67,11 +104,12 @@ type CreateDatadogIncidentParams struct {
 	SessionID        string         `json:"session_id"`
 	CreatedAt        int64          `json:"created_at"`
 	UpdatedAt        int64          `json:"updated_at"`
+	ParentSessionID  string         `json:"parent_session_id"`
 }
 
 // Incidents (v2 API)
-func (q *Queries) CreateDatadogIncident(ctx context.Context, arg CreateDatadogIncidentParams) error {
-	_, err := q.db.ExecContext(ctx, createDatadogIncident,
+func (q *Queries) CreateDatadogIncident(ctx context.Context, arg CreateDatadogIncidentParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createDatadogIncident,
 		arg.ID,
 		arg.Title,
 		arg.CustomerImpacted,
@@ -79,6 +117,71 @@ func (q *Queries) CreateDatadogIncident(ctx context.Context, arg CreateDatadogIn
 		arg.SessionID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ParentSessionID,
+	)
+	var public_id int64
+	err := row.Scan(&public_id)
+	return public_id, err
+}
+
+const createDatadogIncidentAttachment = `-- name: CreateDatadogIncidentAttachment :exec
+INSERT INTO datadog_incident_attachments (id, incident_id, attachment_type, document_url, title, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateDatadogIncidentAttachmentParams struct {
+	ID             string `json:"id"`
+	IncidentID     string `json:"incident_id"`
+	AttachmentType string `json:"attachment_type"`
+	DocumentUrl    string `json:"document_url"`
+	Title          string `json:"title"`
+	SessionID      string `json:"session_id"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+func (q *Queries) CreateDatadogIncidentAttachment(ctx context.Context, arg CreateDatadogIncidentAttachmentParams) error {
+	_, err := q.db.ExecContext(ctx, createDatadogIncidentAttachment,
+		arg.ID,
+		arg.IncidentID,
+		arg.AttachmentType,
+		arg.DocumentUrl,
+		arg.Title,
+		arg.SessionID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createDatadogIncidentTodo = `-- name: CreateDatadogIncidentTodo :exec
+INSERT INTO datadog_incident_todos (id, incident_id, content, assignees, due_date, completed, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateDatadogIncidentTodoParams struct {
+	ID         string         `json:"id"`
+	IncidentID string         `json:"incident_id"`
+	Content    string         `json:"content"`
+	Assignees  string         `json:"assignees"`
+	DueDate    sql.NullString `json:"due_date"`
+	Completed  sql.NullString `json:"completed"`
+	SessionID  string         `json:"session_id"`
+	CreatedAt  int64          `json:"created_at"`
+	UpdatedAt  int64          `json:"updated_at"`
+}
+
+func (q *Queries) CreateDatadogIncidentTodo(ctx context.Context, arg CreateDatadogIncidentTodoParams) error {
+	_, err := q.db.ExecContext(ctx, createDatadogIncidentTodo,
+		arg.ID,
+		arg.IncidentID,
+		arg.Content,
+		arg.Assignees,
+		arg.DueDate,
+		arg.Completed,
+		arg.SessionID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
 	)
 	return err
 }
@@ -113,9 +216,9 @@ func (q *Queries) CreateDatadogMetric(ctx context.Context, arg CreateDatadogMetr
 
 const createDatadogMonitor = `-- name: CreateDatadogMonitor :one
 
-INSERT INTO datadog_monitors (name, type, query, message, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, name, type, query, message, created_at, updated_at
+INSERT INTO datadog_monitors (name, type, query, message, tags, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, type, query, message, tags, created_at, updated_at
 `
 
 type CreateDatadogMonitorParams struct {
@@ -123,6 +226,7 @@ type CreateDatadogMonitorParams struct {
 	Type      string         `json:"type"`
 	Query     string         `json:"query"`
 	Message   sql.NullString `json:"message"`
+	Tags      sql.NullString `json:"tags"`
 	SessionID string         `json:"session_id"`
 	CreatedAt int64          `json:"created_at"`
 	UpdatedAt int64          `json:"updated_at"`
@@ -134,6 +238,7 @@ type CreateDatadogMonitorRow struct {
 	Type      string         `json:"type"`
 	Query     string         `json:"query"`
 	Message   sql.NullString `json:"message"`
+	Tags      sql.NullString `json:"tags"`
 	CreatedAt int64          `json:"created_at"`
 	UpdatedAt int64          `json:"updated_at"`
 }
@@ -145,6 +250,7 @@ func (q *Queries) CreateDatadogMonitor(ctx context.Context, arg CreateDatadogMon
 		arg.Type,
 		arg.Query,
 		arg.Message,
+		arg.Tags,
 		arg.SessionID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -156,6 +262,7 @@ func (q *Queries) CreateDatadogMonitor(ctx context.Context, arg CreateDatadogMon
 		&i.Type,
 		&i.Query,
 		&i.Message,
+		&i.Tags,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -221,7 +328,7 @@ func (q *Queries) GetDatadogEventByID(ctx context.Context, arg GetDatadogEventBy
 }
 
 const getDatadogIncidentByID = `-- name: GetDatadogIncidentByID :one
-SELECT id, title, customer_impacted, severity, session_id, created_at, updated_at
+SELECT id, title, customer_impacted, severity, session_id, created_at, updated_at, public_id
 FROM datadog_incidents
 WHERE id = ? AND session_id = ?
 `
@@ -242,12 +349,47 @@ func (q *Queries) GetDatadogIncidentByID(ctx context.Context, arg GetDatadogInci
 		&i.SessionID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublicID,
+	)
+	return i, err
+}
+
+const getDatadogMetricMetadata = `-- name: GetDatadogMetricMetadata :one
+
+SELECT metric_name, type, description, unit, updated_at
+FROM datadog_metric_metadata
+WHERE metric_name = ? AND session_id = ?
+`
+
+type GetDatadogMetricMetadataParams struct {
+	MetricName string `json:"metric_name"`
+	SessionID  string `json:"session_id"`
+}
+
+type GetDatadogMetricMetadataRow struct {
+	MetricName  string         `json:"metric_name"`
+	Type        sql.NullString `json:"type"`
+	Description sql.NullString `json:"description"`
+	Unit        sql.NullString `json:"unit"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+// Metric metadata (v1 API)
+func (q *Queries) GetDatadogMetricMetadata(ctx context.Context, arg GetDatadogMetricMetadataParams) (GetDatadogMetricMetadataRow, error) {
+	row := q.db.QueryRowContext(ctx, getDatadogMetricMetadata, arg.MetricName, arg.SessionID)
+	var i GetDatadogMetricMetadataRow
+	err := row.Scan(
+		&i.MetricName,
+		&i.Type,
+		&i.Description,
+		&i.Unit,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getDatadogMonitorByID = `-- name: GetDatadogMonitorByID :one
-SELECT id, name, type, query, message, session_id, created_at, updated_at
+SELECT id, name, type, query, message, session_id, created_at, updated_at, tags
 FROM datadog_monitors
 WHERE id = ? AND session_id = ?
 `
@@ -269,6 +411,7 @@ func (q *Queries) GetDatadogMonitorByID(ctx context.Context, arg GetDatadogMonit
 		&i.SessionID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -323,11 +466,100 @@ func (q *Queries) ListDatadogEvents(ctx context.Context, arg ListDatadogEventsPa
 	return items, nil
 }
 
+const listDatadogIncidentAttachments = `-- name: ListDatadogIncidentAttachments :many
+SELECT id, incident_id, attachment_type, document_url, title, session_id, created_at, updated_at
+FROM datadog_incident_attachments
+WHERE incident_id = ? AND session_id = ?
+ORDER BY created_at ASC, rowid ASC
+`
+
+type ListDatadogIncidentAttachmentsParams struct {
+	IncidentID string `json:"incident_id"`
+	SessionID  string `json:"session_id"`
+}
+
+func (q *Queries) ListDatadogIncidentAttachments(ctx context.Context, arg ListDatadogIncidentAttachmentsParams) ([]DatadogIncidentAttachment, error) {
+	rows, err := q.db.QueryContext(ctx, listDatadogIncidentAttachments, arg.IncidentID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DatadogIncidentAttachment{}
+	for rows.Next() {
+		var i DatadogIncidentAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.IncidentID,
+			&i.AttachmentType,
+			&i.DocumentUrl,
+			&i.Title,
+			&i.SessionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDatadogIncidentTodos = `-- name: ListDatadogIncidentTodos :many
+SELECT id, incident_id, content, assignees, due_date, completed, session_id, created_at, updated_at
+FROM datadog_incident_todos
+WHERE incident_id = ? AND session_id = ?
+ORDER BY created_at ASC, rowid ASC
+`
+
+type ListDatadogIncidentTodosParams struct {
+	IncidentID string `json:"incident_id"`
+	SessionID  string `json:"session_id"`
+}
+
+func (q *Queries) ListDatadogIncidentTodos(ctx context.Context, arg ListDatadogIncidentTodosParams) ([]DatadogIncidentTodo, error) {
+	rows, err := q.db.QueryContext(ctx, listDatadogIncidentTodos, arg.IncidentID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DatadogIncidentTodo{}
+	for rows.Next() {
+		var i DatadogIncidentTodo
+		if err := rows.Scan(
+			&i.ID,
+			&i.IncidentID,
+			&i.Content,
+			&i.Assignees,
+			&i.DueDate,
+			&i.Completed,
+			&i.SessionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listDatadogIncidents = `-- name: ListDatadogIncidents :many
-SELECT id, title, customer_impacted, severity, created_at, updated_at
+SELECT id, title, customer_impacted, severity, created_at, updated_at, public_id
 FROM datadog_incidents
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, public_id DESC
 LIMIT ?
 `
 
@@ -343,6 +575,7 @@ type ListDatadogIncidentsRow struct {
 	Severity         sql.NullString `json:"severity"`
 	CreatedAt        int64          `json:"created_at"`
 	UpdatedAt        int64          `json:"updated_at"`
+	PublicID         int64          `json:"public_id"`
 }
 
 func (q *Queries) ListDatadogIncidents(ctx context.Context, arg ListDatadogIncidentsParams) ([]ListDatadogIncidentsRow, error) {
@@ -361,6 +594,7 @@ func (q *Queries) ListDatadogIncidents(ctx context.Context, arg ListDatadogIncid
 			&i.Severity,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublicID,
 		); err != nil {
 			return nil, err
 		}
@@ -375,6 +609,40 @@ func (q *Queries) ListDatadogIncidents(ctx context.Context, arg ListDatadogIncid
 	return items, nil
 }
 
+const listDatadogMetricTags = `-- name: ListDatadogMetricTags :many
+SELECT DISTINCT tags
+FROM datadog_metrics
+WHERE session_id = ? AND metric_name = ?
+`
+
+type ListDatadogMetricTagsParams struct {
+	SessionID  string `json:"session_id"`
+	MetricName string `json:"metric_name"`
+}
+
+func (q *Queries) ListDatadogMetricTags(ctx context.Context, arg ListDatadogMetricTagsParams) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, listDatadogMetricTags, arg.SessionID, arg.MetricName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullString
+	for rows.Next() {
+		var tags sql.NullString
+		if err := rows.Scan(&tags); err != nil {
+			return nil, err
+		}
+		items = append(items, tags)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listDatadogMetrics = `-- name: ListDatadogMetrics :many
 SELECT id, metric_name, value, tags, timestamp, created_at
 FROM datadog_metrics
@@ -480,8 +748,56 @@ func (q *Queries) ListDatadogMetricsBySession(ctx context.Context, arg ListDatad
 	return items, nil
 }
 
+const listDatadogMetricsInRange = `-- name: ListDatadogMetricsInRange :many
+SELECT value, tags, timestamp
+FROM datadog_metrics
+WHERE session_id = ? AND metric_name = ? AND timestamp >= ? AND timestamp <= ?
+ORDER BY timestamp ASC, id ASC
+`
+
+type ListDatadogMetricsInRangeParams struct {
+	SessionID   string `json:"session_id"`
+	MetricName  string `json:"metric_name"`
+	Timestamp   int64  `json:"timestamp"`
+	Timestamp_2 int64  `json:"timestamp_2"`
+}
+
+type ListDatadogMetricsInRangeRow struct {
+	Value     float64        `json:"value"`
+	Tags      sql.NullString `json:"tags"`
+	Timestamp int64          `json:"timestamp"`
+}
+
+func (q *Queries) ListDatadogMetricsInRange(ctx context.Context, arg ListDatadogMetricsInRangeParams) ([]ListDatadogMetricsInRangeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDatadogMetricsInRange,
+		arg.SessionID,
+		arg.MetricName,
+		arg.Timestamp,
+		arg.Timestamp_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDatadogMetricsInRangeRow{}
+	for rows.Next() {
+		var i ListDatadogMetricsInRangeRow
+		if err := rows.Scan(&i.Value, &i.Tags, &i.Timestamp); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listDatadogMonitors = `-- name: ListDatadogMonitors :many
-SELECT id, name, type, query, message, created_at, updated_at
+SELECT id, name, type, query, message, tags, created_at, updated_at
 FROM datadog_monitors
 WHERE session_id = ?
 ORDER BY created_at DESC
@@ -493,6 +809,7 @@ type ListDatadogMonitorsRow struct {
 	Type      string         `json:"type"`
 	Query     string         `json:"query"`
 	Message   sql.NullString `json:"message"`
+	Tags      sql.NullString `json:"tags"`
 	CreatedAt int64          `json:"created_at"`
 	UpdatedAt int64          `json:"updated_at"`
 }
@@ -512,6 +829,7 @@ func (q *Queries) ListDatadogMonitors(ctx context.Context, sessionID string) ([]
 			&i.Type,
 			&i.Query,
 			&i.Message,
+			&i.Tags,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -587,3 +905,34 @@ func (q *Queries) UpdateDatadogMonitor(ctx context.Context, arg UpdateDatadogMon
 	)
 	return err
 }
+
+const upsertDatadogMetricMetadata = `-- name: UpsertDatadogMetricMetadata :exec
+INSERT INTO datadog_metric_metadata (metric_name, type, description, unit, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (metric_name, session_id) DO UPDATE SET
+    type = COALESCE(excluded.type, datadog_metric_metadata.type),
+    description = COALESCE(excluded.description, datadog_metric_metadata.description),
+    unit = COALESCE(excluded.unit, datadog_metric_metadata.unit),
+    updated_at = excluded.updated_at
+`
+
+type UpsertDatadogMetricMetadataParams struct {
+	MetricName  string         `json:"metric_name"`
+	Type        sql.NullString `json:"type"`
+	Description sql.NullString `json:"description"`
+	Unit        sql.NullString `json:"unit"`
+	SessionID   string         `json:"session_id"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+func (q *Queries) UpsertDatadogMetricMetadata(ctx context.Context, arg UpsertDatadogMetricMetadataParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDatadogMetricMetadata,
+		arg.MetricName,
+		arg.Type,
+		arg.Description,
+		arg.Unit,
+		arg.SessionID,
+		arg.UpdatedAt,
+	)
+	return err
+}
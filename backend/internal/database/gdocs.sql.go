@@ -9,6 +9,17 @@ import (
 	"context"
 )
 
+const countGdocsSessionObjects = `-- name: CountGdocsSessionObjects :one
+SELECT COUNT(*) FROM gdocs_documents WHERE session_id = ?
+`
+
+func (q *Queries) CountGdocsSessionObjects(ctx context.Context, sessionID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countGdocsSessionObjects, sessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createGdocsContent = `-- name: CreateGdocsContent :exec
 INSERT INTO gdocs_content (document_id, content_json, end_index, session_id)
 VALUES (?, ?, ?, ?)
@@ -10,6 +10,86 @@ import (
 	"database/sql"
 )
 
+const addGithubIssueLabel = `-- name: AddGithubIssueLabel :exec
+
+INSERT OR IGNORE INTO github_issue_labels (repo_owner, repo_name, issue_number, name, session_id)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type AddGithubIssueLabelParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	Name        string `json:"name"`
+	SessionID   string `json:"session_id"`
+}
+
+// Issue label queries
+func (q *Queries) AddGithubIssueLabel(ctx context.Context, arg AddGithubIssueLabelParams) error {
+	_, err := q.db.ExecContext(ctx, addGithubIssueLabel,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.IssueNumber,
+		arg.Name,
+		arg.SessionID,
+	)
+	return err
+}
+
+const countGithubCommits = `-- name: CountGithubCommits :one
+SELECT COUNT(*) FROM github_commits
+WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
+`
+
+type CountGithubCommitsParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) CountGithubCommits(ctx context.Context, arg CountGithubCommitsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countGithubCommits, arg.RepoOwner, arg.RepoName, arg.SessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countGithubSessionObjects = `-- name: CountGithubSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM github_repositories WHERE session_id = ?1) AS repositories,
+    (SELECT COUNT(*) FROM github_issues WHERE session_id = ?1) AS issues,
+    (SELECT COUNT(*) FROM github_pull_requests WHERE session_id = ?1) AS pulls,
+    (SELECT COUNT(*) FROM github_issue_comments WHERE session_id = ?1) AS comments,
+    (SELECT COUNT(*) FROM github_branches WHERE session_id = ?1) AS branches,
+    (SELECT COUNT(*) FROM github_files WHERE session_id = ?1) AS files,
+    (SELECT COUNT(*) FROM github_workflow_runs WHERE session_id = ?1) AS workflow_runs
+`
+
+type CountGithubSessionObjectsRow struct {
+	Repositories int64 `json:"repositories"`
+	Issues       int64 `json:"issues"`
+	Pulls        int64 `json:"pulls"`
+	Comments     int64 `json:"comments"`
+	Branches     int64 `json:"branches"`
+	Files        int64 `json:"files"`
+	WorkflowRuns int64 `json:"workflow_runs"`
+}
+
+func (q *Queries) CountGithubSessionObjects(ctx context.Context, sessionID string) (CountGithubSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countGithubSessionObjects, sessionID)
+	var i CountGithubSessionObjectsRow
+	err := row.Scan(
+		&i.Repositories,
+		&i.Issues,
+		&i.Pulls,
+		&i.Comments,
+		&i.Branches,
+		&i.Files,
+		&i.WorkflowRuns,
+	)
+	return i, err
+}
+
 const createGithubBranch = `-- name: CreateGithubBranch :exec
 
 INSERT INTO github_branches (repo_owner, repo_name, name, sha, session_id)
@@ -36,11 +116,177 @@ func (q *Queries) CreateGithubBranch(ctx context.Context, arg CreateGithubBranch
 	return err
 }
 
+const createGithubCheckRun = `-- name: CreateGithubCheckRun :one
+INSERT INTO github_check_runs (repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id, created_at, updated_at
+`
+
+type CreateGithubCheckRunParams struct {
+	RepoOwner     string         `json:"repo_owner"`
+	RepoName      string         `json:"repo_name"`
+	CheckRunID    int64          `json:"check_run_id"`
+	Name          string         `json:"name"`
+	HeadSha       string         `json:"head_sha"`
+	Status        string         `json:"status"`
+	Conclusion    sql.NullString `json:"conclusion"`
+	ExternalID    sql.NullString `json:"external_id"`
+	DetailsUrl    sql.NullString `json:"details_url"`
+	OutputTitle   sql.NullString `json:"output_title"`
+	OutputSummary sql.NullString `json:"output_summary"`
+	StartedAt     sql.NullInt64  `json:"started_at"`
+	CompletedAt   sql.NullInt64  `json:"completed_at"`
+	SessionID     string         `json:"session_id"`
+}
+
+func (q *Queries) CreateGithubCheckRun(ctx context.Context, arg CreateGithubCheckRunParams) (GithubCheckRun, error) {
+	row := q.db.QueryRowContext(ctx, createGithubCheckRun,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.CheckRunID,
+		arg.Name,
+		arg.HeadSha,
+		arg.Status,
+		arg.Conclusion,
+		arg.ExternalID,
+		arg.DetailsUrl,
+		arg.OutputTitle,
+		arg.OutputSummary,
+		arg.StartedAt,
+		arg.CompletedAt,
+		arg.SessionID,
+	)
+	var i GithubCheckRun
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.CheckRunID,
+		&i.Name,
+		&i.HeadSha,
+		&i.Status,
+		&i.Conclusion,
+		&i.ExternalID,
+		&i.DetailsUrl,
+		&i.OutputTitle,
+		&i.OutputSummary,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.SessionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createGithubCommit = `-- name: CreateGithubCommit :exec
+INSERT INTO github_commits (repo_owner, repo_name, sha, tree_sha, parent_sha, message, author_name, author_email, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateGithubCommitParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	Sha         string `json:"sha"`
+	TreeSha     string `json:"tree_sha"`
+	ParentSha   string `json:"parent_sha"`
+	Message     string `json:"message"`
+	AuthorName  string `json:"author_name"`
+	AuthorEmail string `json:"author_email"`
+	SessionID   string `json:"session_id"`
+}
+
+func (q *Queries) CreateGithubCommit(ctx context.Context, arg CreateGithubCommitParams) error {
+	_, err := q.db.ExecContext(ctx, createGithubCommit,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Sha,
+		arg.TreeSha,
+		arg.ParentSha,
+		arg.Message,
+		arg.AuthorName,
+		arg.AuthorEmail,
+		arg.SessionID,
+	)
+	return err
+}
+
+const createGithubGist = `-- name: CreateGithubGist :exec
+INSERT INTO github_gists (id, description, public, files, owner_login, session_id)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateGithubGistParams struct {
+	ID          string         `json:"id"`
+	Description sql.NullString `json:"description"`
+	Public      int64          `json:"public"`
+	Files       string         `json:"files"`
+	OwnerLogin  string         `json:"owner_login"`
+	SessionID   string         `json:"session_id"`
+}
+
+func (q *Queries) CreateGithubGist(ctx context.Context, arg CreateGithubGistParams) error {
+	_, err := q.db.ExecContext(ctx, createGithubGist,
+		arg.ID,
+		arg.Description,
+		arg.Public,
+		arg.Files,
+		arg.OwnerLogin,
+		arg.SessionID,
+	)
+	return err
+}
+
+const createGithubHook = `-- name: CreateGithubHook :one
+INSERT INTO github_hooks (repo_owner, repo_name, url, content_type, secret, events, active, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, repo_owner, repo_name, url, content_type, secret, events, active, session_id, created_at, updated_at
+`
+
+type CreateGithubHookParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	Url         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Secret      string `json:"secret"`
+	Events      string `json:"events"`
+	Active      int64  `json:"active"`
+	SessionID   string `json:"session_id"`
+}
+
+func (q *Queries) CreateGithubHook(ctx context.Context, arg CreateGithubHookParams) (GithubHook, error) {
+	row := q.db.QueryRowContext(ctx, createGithubHook,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Url,
+		arg.ContentType,
+		arg.Secret,
+		arg.Events,
+		arg.Active,
+		arg.SessionID,
+	)
+	var i GithubHook
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Url,
+		&i.ContentType,
+		&i.Secret,
+		&i.Events,
+		&i.Active,
+		&i.SessionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createGithubIssue = `-- name: CreateGithubIssue :one
 
 INSERT INTO github_issues (repo_owner, repo_name, number, title, body, state, session_id)
 VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
+RETURNING id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees
 `
 
 type CreateGithubIssueParams struct {
@@ -54,15 +300,17 @@ type CreateGithubIssueParams struct {
 }
 
 type CreateGithubIssueRow struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	State     string         `json:"state"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID          int64          `json:"id"`
+	RepoOwner   string         `json:"repo_owner"`
+	RepoName    string         `json:"repo_name"`
+	Number      int64          `json:"number"`
+	Title       string         `json:"title"`
+	Body        sql.NullString `json:"body"`
+	State       string         `json:"state"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	StateReason sql.NullString `json:"state_reason"`
+	Assignees   string         `json:"assignees"`
 }
 
 // Issue queries
@@ -87,6 +335,8 @@ func (q *Queries) CreateGithubIssue(ctx context.Context, arg CreateGithubIssuePa
 		&i.State,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.StateReason,
+		&i.Assignees,
 	)
 	return i, err
 }
@@ -205,6 +455,126 @@ func (q *Queries) CreateGithubPullRequest(ctx context.Context, arg CreateGithubP
 	return i, err
 }
 
+const createGithubPullRequestFromIssue = `-- name: CreateGithubPullRequestFromIssue :one
+INSERT INTO github_pull_requests (repo_owner, repo_name, number, title, body, head, base, state, draft, maintainer_can_modify, assignees, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, draft, maintainer_can_modify, assignees
+`
+
+type CreateGithubPullRequestFromIssueParams struct {
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
+	SessionID           string         `json:"session_id"`
+}
+
+type CreateGithubPullRequestFromIssueRow struct {
+	ID                  int64          `json:"id"`
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Merged              int64          `json:"merged"`
+	CreatedAt           int64          `json:"created_at"`
+	UpdatedAt           int64          `json:"updated_at"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
+}
+
+func (q *Queries) CreateGithubPullRequestFromIssue(ctx context.Context, arg CreateGithubPullRequestFromIssueParams) (CreateGithubPullRequestFromIssueRow, error) {
+	row := q.db.QueryRowContext(ctx, createGithubPullRequestFromIssue,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Number,
+		arg.Title,
+		arg.Body,
+		arg.Head,
+		arg.Base,
+		arg.State,
+		arg.Draft,
+		arg.MaintainerCanModify,
+		arg.Assignees,
+		arg.SessionID,
+	)
+	var i CreateGithubPullRequestFromIssueRow
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Number,
+		&i.Title,
+		&i.Body,
+		&i.Head,
+		&i.Base,
+		&i.State,
+		&i.Merged,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Draft,
+		&i.MaintainerCanModify,
+		&i.Assignees,
+	)
+	return i, err
+}
+
+const createGithubReaction = `-- name: CreateGithubReaction :one
+
+INSERT INTO github_reactions (repo_owner, repo_name, subject_type, subject_id, user_login, content, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, user_login, content, created_at
+`
+
+type CreateGithubReactionParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	SubjectType string `json:"subject_type"`
+	SubjectID   int64  `json:"subject_id"`
+	UserLogin   string `json:"user_login"`
+	Content     string `json:"content"`
+	SessionID   string `json:"session_id"`
+}
+
+type CreateGithubReactionRow struct {
+	ID        int64  `json:"id"`
+	UserLogin string `json:"user_login"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Reaction queries
+func (q *Queries) CreateGithubReaction(ctx context.Context, arg CreateGithubReactionParams) (CreateGithubReactionRow, error) {
+	row := q.db.QueryRowContext(ctx, createGithubReaction,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SubjectType,
+		arg.SubjectID,
+		arg.UserLogin,
+		arg.Content,
+		arg.SessionID,
+	)
+	var i CreateGithubReactionRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserLogin,
+		&i.Content,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createGithubRepository = `-- name: CreateGithubRepository :exec
 
 INSERT INTO github_repositories (owner, name, default_branch, description, session_id)
@@ -231,6 +601,73 @@ func (q *Queries) CreateGithubRepository(ctx context.Context, arg CreateGithubRe
 	return err
 }
 
+const createGithubTreeEntry = `-- name: CreateGithubTreeEntry :exec
+INSERT INTO github_tree_entries (repo_owner, repo_name, commit_sha, path, sha, size, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateGithubTreeEntryParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	CommitSha string `json:"commit_sha"`
+	Path      string `json:"path"`
+	Sha       string `json:"sha"`
+	Size      int64  `json:"size"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) CreateGithubTreeEntry(ctx context.Context, arg CreateGithubTreeEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createGithubTreeEntry,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.CommitSha,
+		arg.Path,
+		arg.Sha,
+		arg.Size,
+		arg.SessionID,
+	)
+	return err
+}
+
+const createGithubUser = `-- name: CreateGithubUser :one
+INSERT INTO github_users (login, type, name, email, authenticated, session_id)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, login, type, name, email, authenticated, session_id, created_at
+`
+
+type CreateGithubUserParams struct {
+	Login         string `json:"login"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Authenticated int64  `json:"authenticated"`
+	SessionID     string `json:"session_id"`
+}
+
+// User and organization accounts
+func (q *Queries) CreateGithubUser(ctx context.Context, arg CreateGithubUserParams) (GithubUser, error) {
+	row := q.db.QueryRowContext(ctx, createGithubUser,
+		arg.Login,
+		arg.Type,
+		arg.Name,
+		arg.Email,
+		arg.Authenticated,
+		arg.SessionID,
+	)
+	var i GithubUser
+	err := row.Scan(
+		&i.ID,
+		&i.Login,
+		&i.Type,
+		&i.Name,
+		&i.Email,
+		&i.Authenticated,
+		&i.SessionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createGithubWorkflow = `-- name: CreateGithubWorkflow :exec
 
 INSERT INTO github_workflows (repo_owner, repo_name, workflow_id, name, path, state, session_id)
@@ -324,40 +761,384 @@ func (q *Queries) CreateGithubWorkflowRun(ctx context.Context, arg CreateGithubW
 	return i, err
 }
 
-const createOrUpdateGithubFile = `-- name: CreateOrUpdateGithubFile :exec
-
-INSERT INTO github_files (repo_owner, repo_name, path, content, sha, branch, session_id, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
-ON CONFLICT(repo_owner, repo_name, path, branch, session_id)
-DO UPDATE SET content = excluded.content, sha = excluded.sha, updated_at = unixepoch()
+const createNextGithubIssue = `-- name: CreateNextGithubIssue :one
+INSERT INTO github_issues (repo_owner, repo_name, number, title, body, state, assignees, session_id)
+VALUES (
+    ?1,
+    ?2,
+    (SELECT COALESCE(MAX(number), 0) + 1 FROM (
+        SELECT number FROM github_issues WHERE repo_owner = ?1 AND repo_name = ?2 AND session_id IN (?3, ?4)
+        UNION ALL
+        SELECT number FROM github_pull_requests WHERE repo_owner = ?1 AND repo_name = ?2 AND session_id IN (?3, ?4)
+    )),
+    ?5,
+    ?6,
+    ?7,
+    ?8,
+    ?3
+)
+RETURNING id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees
 `
 
-type CreateOrUpdateGithubFileParams struct {
-	RepoOwner string `json:"repo_owner"`
-	RepoName  string `json:"repo_name"`
-	Path      string `json:"path"`
-	Content   string `json:"content"`
-	Sha       string `json:"sha"`
-	Branch    string `json:"branch"`
-	SessionID string `json:"session_id"`
-}
-
-// File queries
-func (q *Queries) CreateOrUpdateGithubFile(ctx context.Context, arg CreateOrUpdateGithubFileParams) error {
-	_, err := q.db.ExecContext(ctx, createOrUpdateGithubFile,
+type CreateNextGithubIssueParams struct {
+	RepoOwner       string         `json:"repo_owner"`
+	RepoName        string         `json:"repo_name"`
+	SessionID       string         `json:"session_id"`
+	ParentSessionID string         `json:"parent_session_id"`
+	Title           string         `json:"title"`
+	Body            sql.NullString `json:"body"`
+	State           string         `json:"state"`
+	Assignees       string         `json:"assignees"`
+}
+
+type CreateNextGithubIssueRow struct {
+	ID          int64          `json:"id"`
+	RepoOwner   string         `json:"repo_owner"`
+	RepoName    string         `json:"repo_name"`
+	Number      int64          `json:"number"`
+	Title       string         `json:"title"`
+	Body        sql.NullString `json:"body"`
+	State       string         `json:"state"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	StateReason sql.NullString `json:"state_reason"`
+	Assignees   string         `json:"assignees"`
+}
+
+func (q *Queries) CreateNextGithubIssue(ctx context.Context, arg CreateNextGithubIssueParams) (CreateNextGithubIssueRow, error) {
+	row := q.db.QueryRowContext(ctx, createNextGithubIssue,
 		arg.RepoOwner,
 		arg.RepoName,
-		arg.Path,
-		arg.Content,
-		arg.Sha,
-		arg.Branch,
 		arg.SessionID,
+		arg.ParentSessionID,
+		arg.Title,
+		arg.Body,
+		arg.State,
+		arg.Assignees,
 	)
-	return err
+	var i CreateNextGithubIssueRow
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Number,
+		&i.Title,
+		&i.Body,
+		&i.State,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StateReason,
+		&i.Assignees,
+	)
+	return i, err
 }
 
-const deleteGithubSessionData = `-- name: DeleteGithubSessionData :exec
-
+const createNextGithubIssueComment = `-- name: CreateNextGithubIssueComment :one
+INSERT INTO github_issue_comments (repo_owner, repo_name, issue_number, comment_id, body, session_id)
+VALUES (
+    ?1,
+    ?2,
+    ?3,
+    (SELECT COALESCE(MAX(comment_id), 0) + 1 FROM github_issue_comments WHERE repo_owner = ?1 AND repo_name = ?2 AND session_id IN (?4, ?5)),
+    ?6,
+    ?4
+)
+RETURNING id, repo_owner, repo_name, issue_number, comment_id, body, created_at
+`
+
+type CreateNextGithubIssueCommentParams struct {
+	RepoOwner       string `json:"repo_owner"`
+	RepoName        string `json:"repo_name"`
+	IssueNumber     int64  `json:"issue_number"`
+	SessionID       string `json:"session_id"`
+	ParentSessionID string `json:"parent_session_id"`
+	Body            string `json:"body"`
+}
+
+type CreateNextGithubIssueCommentRow struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	CommentID   int64  `json:"comment_id"`
+	Body        string `json:"body"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (q *Queries) CreateNextGithubIssueComment(ctx context.Context, arg CreateNextGithubIssueCommentParams) (CreateNextGithubIssueCommentRow, error) {
+	row := q.db.QueryRowContext(ctx, createNextGithubIssueComment,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.IssueNumber,
+		arg.SessionID,
+		arg.ParentSessionID,
+		arg.Body,
+	)
+	var i CreateNextGithubIssueCommentRow
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.IssueNumber,
+		&i.CommentID,
+		&i.Body,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createNextGithubPullRequest = `-- name: CreateNextGithubPullRequest :one
+INSERT INTO github_pull_requests (repo_owner, repo_name, number, title, body, head, base, state, draft, maintainer_can_modify, assignees, session_id)
+VALUES (
+    ?1,
+    ?2,
+    (SELECT COALESCE(MAX(number), 0) + 1 FROM (
+        SELECT number FROM github_issues WHERE repo_owner = ?1 AND repo_name = ?2 AND session_id IN (?3, ?4)
+        UNION ALL
+        SELECT number FROM github_pull_requests WHERE repo_owner = ?1 AND repo_name = ?2 AND session_id IN (?3, ?4)
+    )),
+    ?5,
+    ?6,
+    ?7,
+    ?8,
+    ?9,
+    ?10,
+    ?11,
+    ?12,
+    ?3
+)
+RETURNING id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, draft, maintainer_can_modify, assignees
+`
+
+type CreateNextGithubPullRequestParams struct {
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	SessionID           string         `json:"session_id"`
+	ParentSessionID     string         `json:"parent_session_id"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
+}
+
+type CreateNextGithubPullRequestRow struct {
+	ID                  int64          `json:"id"`
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Merged              int64          `json:"merged"`
+	CreatedAt           int64          `json:"created_at"`
+	UpdatedAt           int64          `json:"updated_at"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
+}
+
+func (q *Queries) CreateNextGithubPullRequest(ctx context.Context, arg CreateNextGithubPullRequestParams) (CreateNextGithubPullRequestRow, error) {
+	row := q.db.QueryRowContext(ctx, createNextGithubPullRequest,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SessionID,
+		arg.ParentSessionID,
+		arg.Title,
+		arg.Body,
+		arg.Head,
+		arg.Base,
+		arg.State,
+		arg.Draft,
+		arg.MaintainerCanModify,
+		arg.Assignees,
+	)
+	var i CreateNextGithubPullRequestRow
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Number,
+		&i.Title,
+		&i.Body,
+		&i.Head,
+		&i.Base,
+		&i.State,
+		&i.Merged,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Draft,
+		&i.MaintainerCanModify,
+		&i.Assignees,
+	)
+	return i, err
+}
+
+const createOrUpdateGithubFile = `-- name: CreateOrUpdateGithubFile :exec
+
+INSERT INTO github_files (repo_owner, repo_name, path, content, sha, branch, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(repo_owner, repo_name, path, branch, session_id)
+DO UPDATE SET content = excluded.content, sha = excluded.sha, updated_at = unixepoch()
+`
+
+type CreateOrUpdateGithubFileParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Sha       string `json:"sha"`
+	Branch    string `json:"branch"`
+	SessionID string `json:"session_id"`
+}
+
+// File queries
+func (q *Queries) CreateOrUpdateGithubFile(ctx context.Context, arg CreateOrUpdateGithubFileParams) error {
+	_, err := q.db.ExecContext(ctx, createOrUpdateGithubFile,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Path,
+		arg.Content,
+		arg.Sha,
+		arg.Branch,
+		arg.SessionID,
+	)
+	return err
+}
+
+const deleteGithubBranchProtection = `-- name: DeleteGithubBranchProtection :execrows
+DELETE FROM github_branch_protections
+WHERE repo_owner = ? AND repo_name = ? AND branch = ? AND session_id = ?
+`
+
+type DeleteGithubBranchProtectionParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Branch    string `json:"branch"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteGithubBranchProtection(ctx context.Context, arg DeleteGithubBranchProtectionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGithubBranchProtection,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Branch,
+		arg.SessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGithubFile = `-- name: DeleteGithubFile :execrows
+DELETE FROM github_files
+WHERE repo_owner = ? AND repo_name = ? AND path = ? AND branch = ? AND session_id = ?
+`
+
+type DeleteGithubFileParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Path      string `json:"path"`
+	Branch    string `json:"branch"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteGithubFile(ctx context.Context, arg DeleteGithubFileParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGithubFile,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Path,
+		arg.Branch,
+		arg.SessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGithubHook = `-- name: DeleteGithubHook :execrows
+DELETE FROM github_hooks
+WHERE id = ? AND repo_owner = ? AND repo_name = ? AND session_id = ?
+`
+
+type DeleteGithubHookParams struct {
+	ID        int64  `json:"id"`
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteGithubHook(ctx context.Context, arg DeleteGithubHookParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGithubHook,
+		arg.ID,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGithubIssueLabel = `-- name: DeleteGithubIssueLabel :execrows
+DELETE FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND name = ? AND session_id = ?
+`
+
+type DeleteGithubIssueLabelParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	Name        string `json:"name"`
+	SessionID   string `json:"session_id"`
+}
+
+func (q *Queries) DeleteGithubIssueLabel(ctx context.Context, arg DeleteGithubIssueLabelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGithubIssueLabel,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.IssueNumber,
+		arg.Name,
+		arg.SessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGithubIssueLabels = `-- name: DeleteGithubIssueLabels :exec
+DELETE FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND session_id = ?
+`
+
+type DeleteGithubIssueLabelsParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	SessionID   string `json:"session_id"`
+}
+
+func (q *Queries) DeleteGithubIssueLabels(ctx context.Context, arg DeleteGithubIssueLabelsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGithubIssueLabels,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.IssueNumber,
+		arg.SessionID,
+	)
+	return err
+}
+
+const deleteGithubSessionData = `-- name: DeleteGithubSessionData :exec
+
 DELETE FROM github_repositories WHERE session_id = ?
 `
 
@@ -367,6 +1148,30 @@ func (q *Queries) DeleteGithubSessionData(ctx context.Context, sessionID string)
 	return err
 }
 
+const getGithubAuthenticatedUser = `-- name: GetGithubAuthenticatedUser :one
+SELECT id, login, type, name, email, authenticated, session_id, created_at
+FROM github_users
+WHERE authenticated = 1 AND session_id = ?
+ORDER BY id
+LIMIT 1
+`
+
+func (q *Queries) GetGithubAuthenticatedUser(ctx context.Context, sessionID string) (GithubUser, error) {
+	row := q.db.QueryRowContext(ctx, getGithubAuthenticatedUser, sessionID)
+	var i GithubUser
+	err := row.Scan(
+		&i.ID,
+		&i.Login,
+		&i.Type,
+		&i.Name,
+		&i.Email,
+		&i.Authenticated,
+		&i.SessionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getGithubBranch = `-- name: GetGithubBranch :one
 SELECT id, repo_owner, repo_name, name, sha, created_at
 FROM github_branches
@@ -408,6 +1213,199 @@ func (q *Queries) GetGithubBranch(ctx context.Context, arg GetGithubBranchParams
 	return i, err
 }
 
+const getGithubBranchBySHA = `-- name: GetGithubBranchBySHA :one
+SELECT id, repo_owner, repo_name, name, sha, created_at
+FROM github_branches
+WHERE repo_owner = ? AND repo_name = ? AND sha = ? AND session_id = ?
+ORDER BY id
+LIMIT 1
+`
+
+type GetGithubBranchBySHAParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Sha       string `json:"sha"`
+	SessionID string `json:"session_id"`
+}
+
+type GetGithubBranchBySHARow struct {
+	ID        int64  `json:"id"`
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Name      string `json:"name"`
+	Sha       string `json:"sha"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func (q *Queries) GetGithubBranchBySHA(ctx context.Context, arg GetGithubBranchBySHAParams) (GetGithubBranchBySHARow, error) {
+	row := q.db.QueryRowContext(ctx, getGithubBranchBySHA,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Sha,
+		arg.SessionID,
+	)
+	var i GetGithubBranchBySHARow
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Name,
+		&i.Sha,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGithubBranchProtection = `-- name: GetGithubBranchProtection :one
+SELECT required_status_checks, required_reviews, enforce_admins
+FROM github_branch_protections
+WHERE repo_owner = ? AND repo_name = ? AND branch = ? AND session_id = ?
+`
+
+type GetGithubBranchProtectionParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Branch    string `json:"branch"`
+	SessionID string `json:"session_id"`
+}
+
+type GetGithubBranchProtectionRow struct {
+	RequiredStatusChecks sql.NullString `json:"required_status_checks"`
+	RequiredReviews      sql.NullString `json:"required_reviews"`
+	EnforceAdmins        int64          `json:"enforce_admins"`
+}
+
+func (q *Queries) GetGithubBranchProtection(ctx context.Context, arg GetGithubBranchProtectionParams) (GetGithubBranchProtectionRow, error) {
+	row := q.db.QueryRowContext(ctx, getGithubBranchProtection,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Branch,
+		arg.SessionID,
+	)
+	var i GetGithubBranchProtectionRow
+	err := row.Scan(&i.RequiredStatusChecks, &i.RequiredReviews, &i.EnforceAdmins)
+	return i, err
+}
+
+const getGithubCheckRun = `-- name: GetGithubCheckRun :one
+SELECT id, repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id, created_at, updated_at
+FROM github_check_runs
+WHERE repo_owner = ? AND repo_name = ? AND check_run_id = ? AND session_id = ?
+`
+
+type GetGithubCheckRunParams struct {
+	RepoOwner  string `json:"repo_owner"`
+	RepoName   string `json:"repo_name"`
+	CheckRunID int64  `json:"check_run_id"`
+	SessionID  string `json:"session_id"`
+}
+
+func (q *Queries) GetGithubCheckRun(ctx context.Context, arg GetGithubCheckRunParams) (GithubCheckRun, error) {
+	row := q.db.QueryRowContext(ctx, getGithubCheckRun,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.CheckRunID,
+		arg.SessionID,
+	)
+	var i GithubCheckRun
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.CheckRunID,
+		&i.Name,
+		&i.HeadSha,
+		&i.Status,
+		&i.Conclusion,
+		&i.ExternalID,
+		&i.DetailsUrl,
+		&i.OutputTitle,
+		&i.OutputSummary,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.SessionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getGithubCommit = `-- name: GetGithubCommit :one
+SELECT id, repo_owner, repo_name, sha, tree_sha, parent_sha, message, author_name, author_email, session_id, created_at
+FROM github_commits
+WHERE repo_owner = ? AND repo_name = ? AND sha = ? AND session_id = ?
+`
+
+type GetGithubCommitParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Sha       string `json:"sha"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) GetGithubCommit(ctx context.Context, arg GetGithubCommitParams) (GithubCommit, error) {
+	row := q.db.QueryRowContext(ctx, getGithubCommit,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Sha,
+		arg.SessionID,
+	)
+	var i GithubCommit
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Sha,
+		&i.TreeSha,
+		&i.ParentSha,
+		&i.Message,
+		&i.AuthorName,
+		&i.AuthorEmail,
+		&i.SessionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGithubCommitByTreeSHA = `-- name: GetGithubCommitByTreeSHA :one
+SELECT id, repo_owner, repo_name, sha, tree_sha, parent_sha, message, author_name, author_email, session_id, created_at
+FROM github_commits
+WHERE repo_owner = ? AND repo_name = ? AND tree_sha = ? AND session_id = ?
+ORDER BY id
+LIMIT 1
+`
+
+type GetGithubCommitByTreeSHAParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	TreeSha   string `json:"tree_sha"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) GetGithubCommitByTreeSHA(ctx context.Context, arg GetGithubCommitByTreeSHAParams) (GithubCommit, error) {
+	row := q.db.QueryRowContext(ctx, getGithubCommitByTreeSHA,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.TreeSha,
+		arg.SessionID,
+	)
+	var i GithubCommit
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Sha,
+		&i.TreeSha,
+		&i.ParentSha,
+		&i.Message,
+		&i.AuthorName,
+		&i.AuthorEmail,
+		&i.SessionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getGithubFile = `-- name: GetGithubFile :one
 SELECT id, repo_owner, repo_name, path, content, sha, branch, updated_at
 FROM github_files
@@ -455,8 +1453,113 @@ func (q *Queries) GetGithubFile(ctx context.Context, arg GetGithubFileParams) (G
 	return i, err
 }
 
+const getGithubFileBySHA = `-- name: GetGithubFileBySHA :one
+SELECT path, content, sha
+FROM github_files
+WHERE repo_owner = ? AND repo_name = ? AND sha = ? AND session_id = ?
+LIMIT 1
+`
+
+type GetGithubFileBySHAParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Sha       string `json:"sha"`
+	SessionID string `json:"session_id"`
+}
+
+type GetGithubFileBySHARow struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Sha     string `json:"sha"`
+}
+
+func (q *Queries) GetGithubFileBySHA(ctx context.Context, arg GetGithubFileBySHAParams) (GetGithubFileBySHARow, error) {
+	row := q.db.QueryRowContext(ctx, getGithubFileBySHA,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Sha,
+		arg.SessionID,
+	)
+	var i GetGithubFileBySHARow
+	err := row.Scan(&i.Path, &i.Content, &i.Sha)
+	return i, err
+}
+
+const getGithubGist = `-- name: GetGithubGist :one
+SELECT id, description, public, files, owner_login, created_at, updated_at
+FROM github_gists
+WHERE id = ? AND session_id = ?
+`
+
+type GetGithubGistParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+type GetGithubGistRow struct {
+	ID          string         `json:"id"`
+	Description sql.NullString `json:"description"`
+	Public      int64          `json:"public"`
+	Files       string         `json:"files"`
+	OwnerLogin  string         `json:"owner_login"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+func (q *Queries) GetGithubGist(ctx context.Context, arg GetGithubGistParams) (GetGithubGistRow, error) {
+	row := q.db.QueryRowContext(ctx, getGithubGist, arg.ID, arg.SessionID)
+	var i GetGithubGistRow
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.Public,
+		&i.Files,
+		&i.OwnerLogin,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getGithubHook = `-- name: GetGithubHook :one
+SELECT id, repo_owner, repo_name, url, content_type, secret, events, active, session_id, created_at, updated_at
+FROM github_hooks
+WHERE id = ? AND repo_owner = ? AND repo_name = ? AND session_id = ?
+`
+
+type GetGithubHookParams struct {
+	ID        int64  `json:"id"`
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) GetGithubHook(ctx context.Context, arg GetGithubHookParams) (GithubHook, error) {
+	row := q.db.QueryRowContext(ctx, getGithubHook,
+		arg.ID,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SessionID,
+	)
+	var i GithubHook
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.Url,
+		&i.ContentType,
+		&i.Secret,
+		&i.Events,
+		&i.Active,
+		&i.SessionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getGithubIssue = `-- name: GetGithubIssue :one
-SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees, pull_request
 FROM github_issues
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?
 `
@@ -469,15 +1572,18 @@ type GetGithubIssueParams struct {
 }
 
 type GetGithubIssueRow struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	State     string         `json:"state"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID          int64          `json:"id"`
+	RepoOwner   string         `json:"repo_owner"`
+	RepoName    string         `json:"repo_name"`
+	Number      int64          `json:"number"`
+	Title       string         `json:"title"`
+	Body        sql.NullString `json:"body"`
+	State       string         `json:"state"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	StateReason sql.NullString `json:"state_reason"`
+	Assignees   string         `json:"assignees"`
+	PullRequest int64          `json:"pull_request"`
 }
 
 func (q *Queries) GetGithubIssue(ctx context.Context, arg GetGithubIssueParams) (GetGithubIssueRow, error) {
@@ -498,12 +1604,58 @@ func (q *Queries) GetGithubIssue(ctx context.Context, arg GetGithubIssueParams)
 		&i.State,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.StateReason,
+		&i.Assignees,
+		&i.PullRequest,
+	)
+	return i, err
+}
+
+const getGithubIssueComment = `-- name: GetGithubIssueComment :one
+SELECT id, repo_owner, repo_name, issue_number, comment_id, body, created_at
+FROM github_issue_comments
+WHERE repo_owner = ? AND repo_name = ? AND comment_id = ? AND session_id = ?
+`
+
+type GetGithubIssueCommentParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	CommentID int64  `json:"comment_id"`
+	SessionID string `json:"session_id"`
+}
+
+type GetGithubIssueCommentRow struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	CommentID   int64  `json:"comment_id"`
+	Body        string `json:"body"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (q *Queries) GetGithubIssueComment(ctx context.Context, arg GetGithubIssueCommentParams) (GetGithubIssueCommentRow, error) {
+	row := q.db.QueryRowContext(ctx, getGithubIssueComment,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.CommentID,
+		arg.SessionID,
+	)
+	var i GetGithubIssueCommentRow
+	err := row.Scan(
+		&i.ID,
+		&i.RepoOwner,
+		&i.RepoName,
+		&i.IssueNumber,
+		&i.CommentID,
+		&i.Body,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getGithubPullRequest = `-- name: GetGithubPullRequest :one
-SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, merged_at, draft, maintainer_can_modify, assignees
 FROM github_pull_requests
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?
 `
@@ -516,18 +1668,22 @@ type GetGithubPullRequestParams struct {
 }
 
 type GetGithubPullRequestRow struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	Head      string         `json:"head"`
-	Base      string         `json:"base"`
-	State     string         `json:"state"`
-	Merged    int64          `json:"merged"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID                  int64          `json:"id"`
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Merged              int64          `json:"merged"`
+	CreatedAt           int64          `json:"created_at"`
+	UpdatedAt           int64          `json:"updated_at"`
+	MergedAt            sql.NullInt64  `json:"merged_at"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
 }
 
 func (q *Queries) GetGithubPullRequest(ctx context.Context, arg GetGithubPullRequestParams) (GetGithubPullRequestRow, error) {
@@ -550,7 +1706,54 @@ func (q *Queries) GetGithubPullRequest(ctx context.Context, arg GetGithubPullReq
 		&i.State,
 		&i.Merged,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.UpdatedAt,
+		&i.MergedAt,
+		&i.Draft,
+		&i.MaintainerCanModify,
+		&i.Assignees,
+	)
+	return i, err
+}
+
+const getGithubReaction = `-- name: GetGithubReaction :one
+SELECT id, user_login, content, created_at
+FROM github_reactions
+WHERE repo_owner = ? AND repo_name = ? AND subject_type = ? AND subject_id = ? AND user_login = ? AND content = ? AND session_id = ?
+`
+
+type GetGithubReactionParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	SubjectType string `json:"subject_type"`
+	SubjectID   int64  `json:"subject_id"`
+	UserLogin   string `json:"user_login"`
+	Content     string `json:"content"`
+	SessionID   string `json:"session_id"`
+}
+
+type GetGithubReactionRow struct {
+	ID        int64  `json:"id"`
+	UserLogin string `json:"user_login"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func (q *Queries) GetGithubReaction(ctx context.Context, arg GetGithubReactionParams) (GetGithubReactionRow, error) {
+	row := q.db.QueryRowContext(ctx, getGithubReaction,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SubjectType,
+		arg.SubjectID,
+		arg.UserLogin,
+		arg.Content,
+		arg.SessionID,
+	)
+	var i GetGithubReactionRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserLogin,
+		&i.Content,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -590,6 +1793,33 @@ func (q *Queries) GetGithubRepository(ctx context.Context, arg GetGithubReposito
 	return i, err
 }
 
+const getGithubUser = `-- name: GetGithubUser :one
+SELECT id, login, type, name, email, authenticated, session_id, created_at
+FROM github_users
+WHERE login = ? AND session_id = ?
+`
+
+type GetGithubUserParams struct {
+	Login     string `json:"login"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) GetGithubUser(ctx context.Context, arg GetGithubUserParams) (GithubUser, error) {
+	row := q.db.QueryRowContext(ctx, getGithubUser, arg.Login, arg.SessionID)
+	var i GithubUser
+	err := row.Scan(
+		&i.ID,
+		&i.Login,
+		&i.Type,
+		&i.Name,
+		&i.Email,
+		&i.Authenticated,
+		&i.SessionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getGithubWorkflow = `-- name: GetGithubWorkflow :one
 SELECT id, repo_owner, repo_name, workflow_id, name, path, state, created_at
 FROM github_workflows
@@ -686,108 +1916,390 @@ func (q *Queries) GetGithubWorkflowRun(ctx context.Context, arg GetGithubWorkflo
 	return i, err
 }
 
-const getNextCommentID = `-- name: GetNextCommentID :one
-SELECT COALESCE(MAX(comment_id), 0) + 1 as next_id
-FROM github_issue_comments
+const getNextCheckRunID = `-- name: GetNextCheckRunID :one
+SELECT COALESCE(MAX(check_run_id), 0) + 1 as next_id
+FROM github_check_runs
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
 `
 
-type GetNextCommentIDParams struct {
+type GetNextCheckRunIDParams struct {
 	RepoOwner string `json:"repo_owner"`
 	RepoName  string `json:"repo_name"`
 	SessionID string `json:"session_id"`
 }
 
-func (q *Queries) GetNextCommentID(ctx context.Context, arg GetNextCommentIDParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getNextCommentID, arg.RepoOwner, arg.RepoName, arg.SessionID)
+func (q *Queries) GetNextCheckRunID(ctx context.Context, arg GetNextCheckRunIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNextCheckRunID, arg.RepoOwner, arg.RepoName, arg.SessionID)
 	var next_id int64
 	err := row.Scan(&next_id)
 	return next_id, err
 }
 
-const getNextIssueNumber = `-- name: GetNextIssueNumber :one
-SELECT COALESCE(MAX(number), 0) + 1 as next_number
-FROM github_issues
+const getNextWorkflowRunID = `-- name: GetNextWorkflowRunID :one
+SELECT COALESCE(MAX(run_id), 0) + 1 as next_id
+FROM github_workflow_runs
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
 `
 
-type GetNextIssueNumberParams struct {
+type GetNextWorkflowRunIDParams struct {
 	RepoOwner string `json:"repo_owner"`
 	RepoName  string `json:"repo_name"`
 	SessionID string `json:"session_id"`
 }
 
-func (q *Queries) GetNextIssueNumber(ctx context.Context, arg GetNextIssueNumberParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getNextIssueNumber, arg.RepoOwner, arg.RepoName, arg.SessionID)
-	var next_number int64
-	err := row.Scan(&next_number)
-	return next_number, err
+func (q *Queries) GetNextWorkflowRunID(ctx context.Context, arg GetNextWorkflowRunIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNextWorkflowRunID, arg.RepoOwner, arg.RepoName, arg.SessionID)
+	var next_id int64
+	err := row.Scan(&next_id)
+	return next_id, err
 }
 
-const getNextPRNumber = `-- name: GetNextPRNumber :one
-SELECT COALESCE(MAX(number), 0) + 1 as next_number
-FROM github_pull_requests
-WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
+const listGithubBranches = `-- name: ListGithubBranches :many
+SELECT b.id, b.name, b.sha, b.created_at,
+       EXISTS (
+           SELECT 1 FROM github_branch_protections p
+           WHERE p.repo_owner = b.repo_owner AND p.repo_name = b.repo_name AND p.branch = b.name AND p.session_id = b.session_id
+       ) AS protected
+FROM github_branches b
+WHERE b.repo_owner = ? AND b.repo_name = ? AND b.session_id = ?
+ORDER BY b.name ASC, b.id ASC
+LIMIT ? OFFSET ?
 `
 
-type GetNextPRNumberParams struct {
+type ListGithubBranchesParams struct {
 	RepoOwner string `json:"repo_owner"`
 	RepoName  string `json:"repo_name"`
 	SessionID string `json:"session_id"`
+	Limit     int64  `json:"limit"`
+	Offset    int64  `json:"offset"`
 }
 
-func (q *Queries) GetNextPRNumber(ctx context.Context, arg GetNextPRNumberParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getNextPRNumber, arg.RepoOwner, arg.RepoName, arg.SessionID)
-	var next_number int64
-	err := row.Scan(&next_number)
-	return next_number, err
+type ListGithubBranchesRow struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Sha       string `json:"sha"`
+	CreatedAt int64  `json:"created_at"`
+	Protected int64  `json:"protected"`
 }
 
-const getNextWorkflowRunID = `-- name: GetNextWorkflowRunID :one
-SELECT COALESCE(MAX(run_id), 0) + 1 as next_id
-FROM github_workflow_runs
+func (q *Queries) ListGithubBranches(ctx context.Context, arg ListGithubBranchesParams) ([]ListGithubBranchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubBranches,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SessionID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubBranchesRow{}
+	for rows.Next() {
+		var i ListGithubBranchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Sha,
+			&i.CreatedAt,
+			&i.Protected,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubCheckRunsForSHA = `-- name: ListGithubCheckRunsForSHA :many
+SELECT id, repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id, created_at, updated_at
+FROM github_check_runs
+WHERE repo_owner = ? AND repo_name = ? AND head_sha = ? AND session_id = ?
+ORDER BY check_run_id DESC
+`
+
+type ListGithubCheckRunsForSHAParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	HeadSha   string `json:"head_sha"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) ListGithubCheckRunsForSHA(ctx context.Context, arg ListGithubCheckRunsForSHAParams) ([]GithubCheckRun, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubCheckRunsForSHA,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.HeadSha,
+		arg.SessionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GithubCheckRun{}
+	for rows.Next() {
+		var i GithubCheckRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.RepoOwner,
+			&i.RepoName,
+			&i.CheckRunID,
+			&i.Name,
+			&i.HeadSha,
+			&i.Status,
+			&i.Conclusion,
+			&i.ExternalID,
+			&i.DetailsUrl,
+			&i.OutputTitle,
+			&i.OutputSummary,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.SessionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubFilesByBranch = `-- name: ListGithubFilesByBranch :many
+SELECT path, content, sha
+FROM github_files
+WHERE repo_owner = ? AND repo_name = ? AND branch = ? AND session_id = ?
+ORDER BY path
+`
+
+type ListGithubFilesByBranchParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Branch    string `json:"branch"`
+	SessionID string `json:"session_id"`
+}
+
+type ListGithubFilesByBranchRow struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Sha     string `json:"sha"`
+}
+
+func (q *Queries) ListGithubFilesByBranch(ctx context.Context, arg ListGithubFilesByBranchParams) ([]ListGithubFilesByBranchRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubFilesByBranch,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Branch,
+		arg.SessionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubFilesByBranchRow{}
+	for rows.Next() {
+		var i ListGithubFilesByBranchRow
+		if err := rows.Scan(&i.Path, &i.Content, &i.Sha); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubGists = `-- name: ListGithubGists :many
+SELECT id, description, public, files, owner_login, created_at, updated_at
+FROM github_gists
+WHERE session_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+type ListGithubGistsRow struct {
+	ID          string         `json:"id"`
+	Description sql.NullString `json:"description"`
+	Public      int64          `json:"public"`
+	Files       string         `json:"files"`
+	OwnerLogin  string         `json:"owner_login"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+func (q *Queries) ListGithubGists(ctx context.Context, sessionID string) ([]ListGithubGistsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubGists, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubGistsRow{}
+	for rows.Next() {
+		var i ListGithubGistsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Public,
+			&i.Files,
+			&i.OwnerLogin,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubHooks = `-- name: ListGithubHooks :many
+SELECT id, repo_owner, repo_name, url, content_type, secret, events, active, session_id, created_at, updated_at
+FROM github_hooks
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
+ORDER BY id
 `
 
-type GetNextWorkflowRunIDParams struct {
+type ListGithubHooksParams struct {
 	RepoOwner string `json:"repo_owner"`
 	RepoName  string `json:"repo_name"`
 	SessionID string `json:"session_id"`
 }
 
-func (q *Queries) GetNextWorkflowRunID(ctx context.Context, arg GetNextWorkflowRunIDParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getNextWorkflowRunID, arg.RepoOwner, arg.RepoName, arg.SessionID)
-	var next_id int64
-	err := row.Scan(&next_id)
-	return next_id, err
+func (q *Queries) ListGithubHooks(ctx context.Context, arg ListGithubHooksParams) ([]GithubHook, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubHooks, arg.RepoOwner, arg.RepoName, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GithubHook{}
+	for rows.Next() {
+		var i GithubHook
+		if err := rows.Scan(
+			&i.ID,
+			&i.RepoOwner,
+			&i.RepoName,
+			&i.Url,
+			&i.ContentType,
+			&i.Secret,
+			&i.Events,
+			&i.Active,
+			&i.SessionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const listGithubIssueComments = `-- name: ListGithubIssueComments :many
 SELECT id, repo_owner, repo_name, issue_number, comment_id, body, created_at
 FROM github_issue_comments
 WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND session_id = ?
-ORDER BY created_at ASC
+  AND created_at >= ?
+ORDER BY created_at ASC, id ASC
+`
+
+type ListGithubIssueCommentsParams struct {
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	SessionID   string `json:"session_id"`
+	Since       int64  `json:"since"`
+}
+
+type ListGithubIssueCommentsRow struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	CommentID   int64  `json:"comment_id"`
+	Body        string `json:"body"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (q *Queries) ListGithubIssueComments(ctx context.Context, arg ListGithubIssueCommentsParams) ([]ListGithubIssueCommentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubIssueComments,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.IssueNumber,
+		arg.SessionID,
+		arg.Since,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubIssueCommentsRow{}
+	for rows.Next() {
+		var i ListGithubIssueCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RepoOwner,
+			&i.RepoName,
+			&i.IssueNumber,
+			&i.CommentID,
+			&i.Body,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubIssueLabels = `-- name: ListGithubIssueLabels :many
+SELECT name
+FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND session_id = ?
+ORDER BY id ASC
 `
 
-type ListGithubIssueCommentsParams struct {
+type ListGithubIssueLabelsParams struct {
 	RepoOwner   string `json:"repo_owner"`
 	RepoName    string `json:"repo_name"`
 	IssueNumber int64  `json:"issue_number"`
 	SessionID   string `json:"session_id"`
 }
 
-type ListGithubIssueCommentsRow struct {
-	ID          int64  `json:"id"`
-	RepoOwner   string `json:"repo_owner"`
-	RepoName    string `json:"repo_name"`
-	IssueNumber int64  `json:"issue_number"`
-	CommentID   int64  `json:"comment_id"`
-	Body        string `json:"body"`
-	CreatedAt   int64  `json:"created_at"`
-}
-
-func (q *Queries) ListGithubIssueComments(ctx context.Context, arg ListGithubIssueCommentsParams) ([]ListGithubIssueCommentsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listGithubIssueComments,
+func (q *Queries) ListGithubIssueLabels(ctx context.Context, arg ListGithubIssueLabelsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubIssueLabels,
 		arg.RepoOwner,
 		arg.RepoName,
 		arg.IssueNumber,
@@ -797,21 +2309,13 @@ func (q *Queries) ListGithubIssueComments(ctx context.Context, arg ListGithubIss
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListGithubIssueCommentsRow{}
+	items := []string{}
 	for rows.Next() {
-		var i ListGithubIssueCommentsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.RepoOwner,
-			&i.RepoName,
-			&i.IssueNumber,
-			&i.CommentID,
-			&i.Body,
-			&i.CreatedAt,
-		); err != nil {
+		var name string
+		if err := rows.Scan(&name); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, name)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -823,30 +2327,37 @@ func (q *Queries) ListGithubIssueComments(ctx context.Context, arg ListGithubIss
 }
 
 const listGithubIssues = `-- name: ListGithubIssues :many
-SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees, pull_request
 FROM github_issues
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-  AND (?4 = '' OR state = ?4)
-ORDER BY created_at DESC
+  AND (?4 IN ('', 'all') OR state = ?4)
+  AND (IFNULL(?5, '') = '' OR state_reason = ?5)
+  AND updated_at >= ?6
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubIssuesParams struct {
-	RepoOwner   string      `json:"repo_owner"`
-	RepoName    string      `json:"repo_name"`
-	SessionID   string      `json:"session_id"`
-	StateFilter interface{} `json:"state_filter"`
+	RepoOwner         string      `json:"repo_owner"`
+	RepoName          string      `json:"repo_name"`
+	SessionID         string      `json:"session_id"`
+	StateFilter       interface{} `json:"state_filter"`
+	StateReasonFilter interface{} `json:"state_reason_filter"`
+	Since             int64       `json:"since"`
 }
 
 type ListGithubIssuesRow struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	State     string         `json:"state"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID          int64          `json:"id"`
+	RepoOwner   string         `json:"repo_owner"`
+	RepoName    string         `json:"repo_name"`
+	Number      int64          `json:"number"`
+	Title       string         `json:"title"`
+	Body        sql.NullString `json:"body"`
+	State       string         `json:"state"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	StateReason sql.NullString `json:"state_reason"`
+	Assignees   string         `json:"assignees"`
+	PullRequest int64          `json:"pull_request"`
 }
 
 func (q *Queries) ListGithubIssues(ctx context.Context, arg ListGithubIssuesParams) ([]ListGithubIssuesRow, error) {
@@ -855,6 +2366,8 @@ func (q *Queries) ListGithubIssues(ctx context.Context, arg ListGithubIssuesPara
 		arg.RepoName,
 		arg.SessionID,
 		arg.StateFilter,
+		arg.StateReasonFilter,
+		arg.Since,
 	)
 	if err != nil {
 		return nil, err
@@ -873,6 +2386,9 @@ func (q *Queries) ListGithubIssues(ctx context.Context, arg ListGithubIssuesPara
 			&i.State,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.StateReason,
+			&i.Assignees,
+			&i.PullRequest,
 		); err != nil {
 			return nil, err
 		}
@@ -891,7 +2407,7 @@ const listGithubIssuesBySession = `-- name: ListGithubIssuesBySession :many
 SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
 FROM github_issues
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubIssuesBySessionRow struct {
@@ -941,11 +2457,11 @@ func (q *Queries) ListGithubIssuesBySession(ctx context.Context, sessionID strin
 }
 
 const listGithubPullRequests = `-- name: ListGithubPullRequests :many
-SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, merged_at, draft, maintainer_can_modify, assignees
 FROM github_pull_requests
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-  AND (?4 = '' OR state = ?4)
-ORDER BY created_at DESC
+  AND (?4 IN ('', 'all') OR state = ?4)
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubPullRequestsParams struct {
@@ -956,18 +2472,22 @@ type ListGithubPullRequestsParams struct {
 }
 
 type ListGithubPullRequestsRow struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	Head      string         `json:"head"`
-	Base      string         `json:"base"`
-	State     string         `json:"state"`
-	Merged    int64          `json:"merged"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID                  int64          `json:"id"`
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Merged              int64          `json:"merged"`
+	CreatedAt           int64          `json:"created_at"`
+	UpdatedAt           int64          `json:"updated_at"`
+	MergedAt            sql.NullInt64  `json:"merged_at"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
 }
 
 func (q *Queries) ListGithubPullRequests(ctx context.Context, arg ListGithubPullRequestsParams) ([]ListGithubPullRequestsRow, error) {
@@ -997,6 +2517,69 @@ func (q *Queries) ListGithubPullRequests(ctx context.Context, arg ListGithubPull
 			&i.Merged,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MergedAt,
+			&i.Draft,
+			&i.MaintainerCanModify,
+			&i.Assignees,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGithubReactions = `-- name: ListGithubReactions :many
+SELECT id, user_login, content, created_at
+FROM github_reactions
+WHERE repo_owner = ? AND repo_name = ? AND subject_type = ? AND subject_id = ? AND session_id = ?
+  AND (?6 = '' OR content = ?6)
+ORDER BY id ASC
+`
+
+type ListGithubReactionsParams struct {
+	RepoOwner     string      `json:"repo_owner"`
+	RepoName      string      `json:"repo_name"`
+	SubjectType   string      `json:"subject_type"`
+	SubjectID     int64       `json:"subject_id"`
+	SessionID     string      `json:"session_id"`
+	ContentFilter interface{} `json:"content_filter"`
+}
+
+type ListGithubReactionsRow struct {
+	ID        int64  `json:"id"`
+	UserLogin string `json:"user_login"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func (q *Queries) ListGithubReactions(ctx context.Context, arg ListGithubReactionsParams) ([]ListGithubReactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubReactions,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.SubjectType,
+		arg.SubjectID,
+		arg.SessionID,
+		arg.ContentFilter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubReactionsRow{}
+	for rows.Next() {
+		var i ListGithubReactionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserLogin,
+			&i.Content,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1011,11 +2594,52 @@ func (q *Queries) ListGithubPullRequests(ctx context.Context, arg ListGithubPull
 	return items, nil
 }
 
+const listGithubRepoIssueLabels = `-- name: ListGithubRepoIssueLabels :many
+SELECT issue_number, name
+FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
+ORDER BY id ASC
+`
+
+type ListGithubRepoIssueLabelsParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	SessionID string `json:"session_id"`
+}
+
+type ListGithubRepoIssueLabelsRow struct {
+	IssueNumber int64  `json:"issue_number"`
+	Name        string `json:"name"`
+}
+
+func (q *Queries) ListGithubRepoIssueLabels(ctx context.Context, arg ListGithubRepoIssueLabelsParams) ([]ListGithubRepoIssueLabelsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubRepoIssueLabels, arg.RepoOwner, arg.RepoName, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubRepoIssueLabelsRow{}
+	for rows.Next() {
+		var i ListGithubRepoIssueLabelsRow
+		if err := rows.Scan(&i.IssueNumber, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGithubRepositories = `-- name: ListGithubRepositories :many
 SELECT id, owner, name, default_branch, description, created_at
 FROM github_repositories
 WHERE session_id = ? AND owner = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubRepositoriesParams struct {
@@ -1062,11 +2686,59 @@ func (q *Queries) ListGithubRepositories(ctx context.Context, arg ListGithubRepo
 	return items, nil
 }
 
+const listGithubTreeEntries = `-- name: ListGithubTreeEntries :many
+SELECT path, sha, size
+FROM github_tree_entries
+WHERE repo_owner = ? AND repo_name = ? AND commit_sha = ? AND session_id = ?
+ORDER BY path
+`
+
+type ListGithubTreeEntriesParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	CommitSha string `json:"commit_sha"`
+	SessionID string `json:"session_id"`
+}
+
+type ListGithubTreeEntriesRow struct {
+	Path string `json:"path"`
+	Sha  string `json:"sha"`
+	Size int64  `json:"size"`
+}
+
+func (q *Queries) ListGithubTreeEntries(ctx context.Context, arg ListGithubTreeEntriesParams) ([]ListGithubTreeEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGithubTreeEntries,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.CommitSha,
+		arg.SessionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGithubTreeEntriesRow{}
+	for rows.Next() {
+		var i ListGithubTreeEntriesRow
+		if err := rows.Scan(&i.Path, &i.Sha, &i.Size); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGithubWorkflowRuns = `-- name: ListGithubWorkflowRuns :many
 SELECT id, repo_owner, repo_name, run_id, workflow_id, status, conclusion, head_branch, head_sha, created_at, updated_at
 FROM github_workflow_runs
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubWorkflowRunsParams struct {
@@ -1128,7 +2800,7 @@ const listGithubWorkflowRunsForWorkflow = `-- name: ListGithubWorkflowRunsForWor
 SELECT id, repo_owner, repo_name, run_id, workflow_id, status, conclusion, head_branch, head_sha, created_at, updated_at
 FROM github_workflow_runs
 WHERE repo_owner = ? AND repo_name = ? AND workflow_id = ? AND session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubWorkflowRunsForWorkflowParams struct {
@@ -1196,7 +2868,7 @@ const listGithubWorkflows = `-- name: ListGithubWorkflows :many
 SELECT id, repo_owner, repo_name, workflow_id, name, path, state, created_at
 FROM github_workflows
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 `
 
 type ListGithubWorkflowsParams struct {
@@ -1248,21 +2920,21 @@ func (q *Queries) ListGithubWorkflows(ctx context.Context, arg ListGithubWorkflo
 	return items, nil
 }
 
-const mergeGithubPullRequest = `-- name: MergeGithubPullRequest :exec
-UPDATE github_pull_requests
-SET state = 'closed', merged = 1, updated_at = unixepoch()
+const markGithubIssuePullRequest = `-- name: MarkGithubIssuePullRequest :exec
+UPDATE github_issues
+SET pull_request = 1, updated_at = unixepoch()
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?
 `
 
-type MergeGithubPullRequestParams struct {
+type MarkGithubIssuePullRequestParams struct {
 	RepoOwner string `json:"repo_owner"`
 	RepoName  string `json:"repo_name"`
 	Number    int64  `json:"number"`
 	SessionID string `json:"session_id"`
 }
 
-func (q *Queries) MergeGithubPullRequest(ctx context.Context, arg MergeGithubPullRequestParams) error {
-	_, err := q.db.ExecContext(ctx, mergeGithubPullRequest,
+func (q *Queries) MarkGithubIssuePullRequest(ctx context.Context, arg MarkGithubIssuePullRequestParams) error {
+	_, err := q.db.ExecContext(ctx, markGithubIssuePullRequest,
 		arg.RepoOwner,
 		arg.RepoName,
 		arg.Number,
@@ -1271,6 +2943,32 @@ func (q *Queries) MergeGithubPullRequest(ctx context.Context, arg MergeGithubPul
 	return err
 }
 
+const mergeGithubPullRequest = `-- name: MergeGithubPullRequest :execrows
+UPDATE github_pull_requests
+SET state = 'closed', merged = 1, merged_at = unixepoch(), updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ? AND state = 'open' AND merged = 0
+`
+
+type MergeGithubPullRequestParams struct {
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Number    int64  `json:"number"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) MergeGithubPullRequest(ctx context.Context, arg MergeGithubPullRequestParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, mergeGithubPullRequest,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Number,
+		arg.SessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateGithubBranchSHA = `-- name: UpdateGithubBranchSHA :exec
 UPDATE github_branches
 SET sha = ?
@@ -1296,20 +2994,60 @@ func (q *Queries) UpdateGithubBranchSHA(ctx context.Context, arg UpdateGithubBra
 	return err
 }
 
+const updateGithubCheckRun = `-- name: UpdateGithubCheckRun :exec
+UPDATE github_check_runs
+SET name = ?, status = ?, conclusion = ?, external_id = ?, details_url = ?, output_title = ?, output_summary = ?, completed_at = ?, updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND check_run_id = ? AND session_id = ?
+`
+
+type UpdateGithubCheckRunParams struct {
+	Name          string         `json:"name"`
+	Status        string         `json:"status"`
+	Conclusion    sql.NullString `json:"conclusion"`
+	ExternalID    sql.NullString `json:"external_id"`
+	DetailsUrl    sql.NullString `json:"details_url"`
+	OutputTitle   sql.NullString `json:"output_title"`
+	OutputSummary sql.NullString `json:"output_summary"`
+	CompletedAt   sql.NullInt64  `json:"completed_at"`
+	RepoOwner     string         `json:"repo_owner"`
+	RepoName      string         `json:"repo_name"`
+	CheckRunID    int64          `json:"check_run_id"`
+	SessionID     string         `json:"session_id"`
+}
+
+func (q *Queries) UpdateGithubCheckRun(ctx context.Context, arg UpdateGithubCheckRunParams) error {
+	_, err := q.db.ExecContext(ctx, updateGithubCheckRun,
+		arg.Name,
+		arg.Status,
+		arg.Conclusion,
+		arg.ExternalID,
+		arg.DetailsUrl,
+		arg.OutputTitle,
+		arg.OutputSummary,
+		arg.CompletedAt,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.CheckRunID,
+		arg.SessionID,
+	)
+	return err
+}
+
 const updateGithubIssue = `-- name: UpdateGithubIssue :exec
 UPDATE github_issues
-SET title = ?, body = ?, state = ?, updated_at = unixepoch()
+SET title = ?, body = ?, state = ?, state_reason = ?, updated_at = unixepoch()
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?
 `
 
 type UpdateGithubIssueParams struct {
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	State     string         `json:"state"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	SessionID string         `json:"session_id"`
+	Title       string         `json:"title"`
+	Body        sql.NullString `json:"body"`
+	State       string         `json:"state"`
+	StateReason sql.NullString `json:"state_reason"`
+	RepoOwner   string         `json:"repo_owner"`
+	RepoName    string         `json:"repo_name"`
+	Number      int64          `json:"number"`
+	SessionID   string         `json:"session_id"`
 }
 
 func (q *Queries) UpdateGithubIssue(ctx context.Context, arg UpdateGithubIssueParams) error {
@@ -1317,6 +3055,7 @@ func (q *Queries) UpdateGithubIssue(ctx context.Context, arg UpdateGithubIssuePa
 		arg.Title,
 		arg.Body,
 		arg.State,
+		arg.StateReason,
 		arg.RepoOwner,
 		arg.RepoName,
 		arg.Number,
@@ -1324,3 +3063,126 @@ func (q *Queries) UpdateGithubIssue(ctx context.Context, arg UpdateGithubIssuePa
 	)
 	return err
 }
+
+const updateGithubIssueAssignees = `-- name: UpdateGithubIssueAssignees :exec
+UPDATE github_issues
+SET assignees = ?, updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?
+`
+
+type UpdateGithubIssueAssigneesParams struct {
+	Assignees string `json:"assignees"`
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Number    int64  `json:"number"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) UpdateGithubIssueAssignees(ctx context.Context, arg UpdateGithubIssueAssigneesParams) error {
+	_, err := q.db.ExecContext(ctx, updateGithubIssueAssignees,
+		arg.Assignees,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Number,
+		arg.SessionID,
+	)
+	return err
+}
+
+const updateGithubPullRequest = `-- name: UpdateGithubPullRequest :exec
+UPDATE github_pull_requests
+SET title = COALESCE(?1, title),
+    body = COALESCE(?2, body),
+    state = COALESCE(?3, state),
+    base = COALESCE(?4, base),
+    draft = COALESCE(?5, draft),
+    maintainer_can_modify = COALESCE(?6, maintainer_can_modify),
+    updated_at = unixepoch()
+WHERE repo_owner = ?7 AND repo_name = ?8 AND number = ?9 AND session_id = ?10
+`
+
+type UpdateGithubPullRequestParams struct {
+	Title               sql.NullString `json:"title"`
+	Body                sql.NullString `json:"body"`
+	State               sql.NullString `json:"state"`
+	Base                sql.NullString `json:"base"`
+	Draft               sql.NullInt64  `json:"draft"`
+	MaintainerCanModify sql.NullInt64  `json:"maintainer_can_modify"`
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	SessionID           string         `json:"session_id"`
+}
+
+func (q *Queries) UpdateGithubPullRequest(ctx context.Context, arg UpdateGithubPullRequestParams) error {
+	_, err := q.db.ExecContext(ctx, updateGithubPullRequest,
+		arg.Title,
+		arg.Body,
+		arg.State,
+		arg.Base,
+		arg.Draft,
+		arg.MaintainerCanModify,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Number,
+		arg.SessionID,
+	)
+	return err
+}
+
+const updateGithubPullRequestAssignees = `-- name: UpdateGithubPullRequestAssignees :exec
+UPDATE github_pull_requests
+SET assignees = ?, updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?
+`
+
+type UpdateGithubPullRequestAssigneesParams struct {
+	Assignees string `json:"assignees"`
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	Number    int64  `json:"number"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) UpdateGithubPullRequestAssignees(ctx context.Context, arg UpdateGithubPullRequestAssigneesParams) error {
+	_, err := q.db.ExecContext(ctx, updateGithubPullRequestAssignees,
+		arg.Assignees,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Number,
+		arg.SessionID,
+	)
+	return err
+}
+
+const upsertGithubBranchProtection = `-- name: UpsertGithubBranchProtection :exec
+INSERT INTO github_branch_protections (repo_owner, repo_name, branch, required_status_checks, required_reviews, enforce_admins, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(repo_owner, repo_name, branch, session_id)
+DO UPDATE SET required_status_checks = excluded.required_status_checks, required_reviews = excluded.required_reviews,
+              enforce_admins = excluded.enforce_admins, updated_at = unixepoch()
+`
+
+type UpsertGithubBranchProtectionParams struct {
+	RepoOwner            string         `json:"repo_owner"`
+	RepoName             string         `json:"repo_name"`
+	Branch               string         `json:"branch"`
+	RequiredStatusChecks sql.NullString `json:"required_status_checks"`
+	RequiredReviews      sql.NullString `json:"required_reviews"`
+	EnforceAdmins        int64          `json:"enforce_admins"`
+	SessionID            string         `json:"session_id"`
+}
+
+// Branch protection queries
+func (q *Queries) UpsertGithubBranchProtection(ctx context.Context, arg UpsertGithubBranchProtectionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertGithubBranchProtection,
+		arg.RepoOwner,
+		arg.RepoName,
+		arg.Branch,
+		arg.RequiredStatusChecks,
+		arg.RequiredReviews,
+		arg.EnforceAdmins,
+		arg.SessionID,
+	)
+	return err
+}
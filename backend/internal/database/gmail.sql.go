@@ -10,6 +10,62 @@ import (
 	"database/sql"
 )
 
+const clearGmailSendAsDefault = `-- name: ClearGmailSendAsDefault :exec
+UPDATE gmail_send_as_aliases SET is_default = 0 WHERE session_id = ?
+`
+
+func (q *Queries) ClearGmailSendAsDefault(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, clearGmailSendAsDefault, sessionID)
+	return err
+}
+
+const copyGmailMessageToSession = `-- name: CopyGmailMessageToSession :exec
+INSERT INTO gmail_messages (id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at, session_id, cc_email, to_addresses, cc_addresses, user_id)
+SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at, ?1, cc_email, to_addresses, cc_addresses, user_id
+FROM gmail_messages
+WHERE id = ?2 AND session_id = ?3 AND user_id = ?4
+`
+
+type CopyGmailMessageToSessionParams struct {
+	SessionID       string `json:"session_id"`
+	ID              string `json:"id"`
+	ParentSessionID string `json:"parent_session_id"`
+	UserID          string `json:"user_id"`
+}
+
+// Copies a parent session's message into a child session under the same ID, so the child
+// can modify it without touching the parent
+func (q *Queries) CopyGmailMessageToSession(ctx context.Context, arg CopyGmailMessageToSessionParams) error {
+	_, err := q.db.ExecContext(ctx, copyGmailMessageToSession,
+		arg.SessionID,
+		arg.ID,
+		arg.ParentSessionID,
+		arg.UserID,
+	)
+	return err
+}
+
+const countGmailSessionObjects = `-- name: CountGmailSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM gmail_messages WHERE session_id = ?1) AS messages,
+    (SELECT COUNT(*) FROM gmail_attachments WHERE session_id = ?1) AS attachments
+`
+
+type CountGmailSessionObjectsRow struct {
+	Messages    int64 `json:"messages"`
+	Attachments int64 `json:"attachments"`
+}
+
+func (q *Queries) CountGmailSessionObjects(ctx context.Context, sessionID string) (CountGmailSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countGmailSessionObjects, sessionID)
+	var i CountGmailSessionObjectsRow
+	err := row.Scan(
+		&i.Messages,
+		&i.Attachments,
+	)
+	return i, err
+}
+
 const createGmailAttachment = `-- name: CreateGmailAttachment :exec
 INSERT INTO gmail_attachments (id, message_id, filename, mime_type, data, size, session_id)
 VALUES (?, ?, ?, ?, ?, ?, ?)
@@ -40,8 +96,8 @@ func (q *Queries) CreateGmailAttachment(ctx context.Context, arg CreateGmailAtta
 }
 
 const createGmailMessage = `-- name: CreateGmailMessage :exec
-INSERT INTO gmail_messages (id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, session_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO gmail_messages (id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, session_id, cc_email, to_addresses, cc_addresses, user_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateGmailMessageParams struct {
@@ -58,6 +114,10 @@ type CreateGmailMessageParams struct {
 	InternalDate int64          `json:"internal_date"`
 	SizeEstimate int64          `json:"size_estimate"`
 	SessionID    string         `json:"session_id"`
+	CcEmail      string         `json:"cc_email"`
+	ToAddresses  string         `json:"to_addresses"`
+	CcAddresses  string         `json:"cc_addresses"`
+	UserID       string         `json:"user_id"`
 }
 
 func (q *Queries) CreateGmailMessage(ctx context.Context, arg CreateGmailMessageParams) error {
@@ -75,10 +135,95 @@ func (q *Queries) CreateGmailMessage(ctx context.Context, arg CreateGmailMessage
 		arg.InternalDate,
 		arg.SizeEstimate,
 		arg.SessionID,
+		arg.CcEmail,
+		arg.ToAddresses,
+		arg.CcAddresses,
+		arg.UserID,
 	)
 	return err
 }
 
+const createGmailMessageTombstone = `-- name: CreateGmailMessageTombstone :exec
+INSERT OR IGNORE INTO gmail_message_tombstones (session_id, message_id)
+VALUES (?, ?)
+`
+
+type CreateGmailMessageTombstoneParams struct {
+	SessionID string `json:"session_id"`
+	MessageID string `json:"message_id"`
+}
+
+// Tombstones hide a parent session's message from a child session that deleted it
+func (q *Queries) CreateGmailMessageTombstone(ctx context.Context, arg CreateGmailMessageTombstoneParams) error {
+	_, err := q.db.ExecContext(ctx, createGmailMessageTombstone, arg.SessionID, arg.MessageID)
+	return err
+}
+
+const deleteGmailAttachmentsByMessage = `-- name: DeleteGmailAttachmentsByMessage :exec
+DELETE FROM gmail_attachments WHERE message_id = ? AND session_id = ?
+`
+
+type DeleteGmailAttachmentsByMessageParams struct {
+	MessageID string `json:"message_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteGmailAttachmentsByMessage(ctx context.Context, arg DeleteGmailAttachmentsByMessageParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGmailAttachmentsByMessage, arg.MessageID, arg.SessionID)
+	return err
+}
+
+const deleteGmailMessage = `-- name: DeleteGmailMessage :exec
+DELETE FROM gmail_messages WHERE id = ? AND session_id = ? AND user_id = ?
+`
+
+type DeleteGmailMessageParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	UserID    string `json:"user_id"`
+}
+
+func (q *Queries) DeleteGmailMessage(ctx context.Context, arg DeleteGmailMessageParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGmailMessage, arg.ID, arg.SessionID, arg.UserID)
+	return err
+}
+
+const deleteGmailMessageTombstones = `-- name: DeleteGmailMessageTombstones :exec
+DELETE FROM gmail_message_tombstones WHERE session_id = ?
+`
+
+func (q *Queries) DeleteGmailMessageTombstones(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteGmailMessageTombstones, sessionID)
+	return err
+}
+
+const deleteGmailSendAsAlias = `-- name: DeleteGmailSendAsAlias :execrows
+DELETE FROM gmail_send_as_aliases
+WHERE session_id = ? AND send_as_email = ? AND is_primary = 0
+`
+
+type DeleteGmailSendAsAliasParams struct {
+	SessionID   string `json:"session_id"`
+	SendAsEmail string `json:"send_as_email"`
+}
+
+func (q *Queries) DeleteGmailSendAsAlias(ctx context.Context, arg DeleteGmailSendAsAliasParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGmailSendAsAlias, arg.SessionID, arg.SendAsEmail)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGmailSendAsAliases = `-- name: DeleteGmailSendAsAliases :exec
+DELETE FROM gmail_send_as_aliases WHERE session_id = ?
+`
+
+func (q *Queries) DeleteGmailSendAsAliases(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteGmailSendAsAliases, sessionID)
+	return err
+}
+
 const deleteGmailSessionData = `-- name: DeleteGmailSessionData :exec
 DELETE FROM gmail_messages WHERE session_id = ?
 `
@@ -88,6 +233,34 @@ func (q *Queries) DeleteGmailSessionData(ctx context.Context, sessionID string)
 	return err
 }
 
+const deleteGmailWatch = `-- name: DeleteGmailWatch :exec
+DELETE FROM gmail_watches WHERE session_id = ?
+`
+
+func (q *Queries) DeleteGmailWatch(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteGmailWatch, sessionID)
+	return err
+}
+
+const expireGmailWatch = `-- name: ExpireGmailWatch :execrows
+UPDATE gmail_watches
+SET stopped_at = expiration, updated_at = unixepoch()
+WHERE session_id = ? AND stopped_at IS NULL AND expiration <= ?
+`
+
+type ExpireGmailWatchParams struct {
+	SessionID  string `json:"session_id"`
+	Expiration int64  `json:"expiration"`
+}
+
+func (q *Queries) ExpireGmailWatch(ctx context.Context, arg ExpireGmailWatchParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, expireGmailWatch, arg.SessionID, arg.Expiration)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getGmailAttachment = `-- name: GetGmailAttachment :one
 SELECT id, message_id, filename, mime_type, data, size, created_at
 FROM gmail_attachments
@@ -125,14 +298,15 @@ func (q *Queries) GetGmailAttachment(ctx context.Context, arg GetGmailAttachment
 }
 
 const getGmailMessageByID = `-- name: GetGmailMessageByID :one
-SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at
+SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at, cc_email
 FROM gmail_messages
-WHERE id = ? AND session_id = ?
+WHERE id = ? AND session_id = ? AND user_id = ?
 `
 
 type GetGmailMessageByIDParams struct {
 	ID        string `json:"id"`
 	SessionID string `json:"session_id"`
+	UserID    string `json:"user_id"`
 }
 
 type GetGmailMessageByIDRow struct {
@@ -149,10 +323,11 @@ type GetGmailMessageByIDRow struct {
 	InternalDate int64          `json:"internal_date"`
 	SizeEstimate int64          `json:"size_estimate"`
 	CreatedAt    int64          `json:"created_at"`
+	CcEmail      string         `json:"cc_email"`
 }
 
 func (q *Queries) GetGmailMessageByID(ctx context.Context, arg GetGmailMessageByIDParams) (GetGmailMessageByIDRow, error) {
-	row := q.db.QueryRowContext(ctx, getGmailMessageByID, arg.ID, arg.SessionID)
+	row := q.db.QueryRowContext(ctx, getGmailMessageByID, arg.ID, arg.SessionID, arg.UserID)
 	var i GetGmailMessageByIDRow
 	err := row.Scan(
 		&i.ID,
@@ -168,6 +343,74 @@ func (q *Queries) GetGmailMessageByID(ctx context.Context, arg GetGmailMessageBy
 		&i.InternalDate,
 		&i.SizeEstimate,
 		&i.CreatedAt,
+		&i.CcEmail,
+	)
+	return i, err
+}
+
+const getGmailMessageTombstone = `-- name: GetGmailMessageTombstone :one
+SELECT message_id
+FROM gmail_message_tombstones
+WHERE session_id = ? AND message_id = ?
+`
+
+type GetGmailMessageTombstoneParams struct {
+	SessionID string `json:"session_id"`
+	MessageID string `json:"message_id"`
+}
+
+func (q *Queries) GetGmailMessageTombstone(ctx context.Context, arg GetGmailMessageTombstoneParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getGmailMessageTombstone, arg.SessionID, arg.MessageID)
+	var message_id string
+	err := row.Scan(&message_id)
+	return message_id, err
+}
+
+const getGmailSendAsAlias = `-- name: GetGmailSendAsAlias :one
+SELECT session_id, send_as_email, display_name, reply_to_address, signature, is_primary, is_default, created_at
+FROM gmail_send_as_aliases
+WHERE session_id = ? AND send_as_email = ?
+`
+
+type GetGmailSendAsAliasParams struct {
+	SessionID   string `json:"session_id"`
+	SendAsEmail string `json:"send_as_email"`
+}
+
+func (q *Queries) GetGmailSendAsAlias(ctx context.Context, arg GetGmailSendAsAliasParams) (GmailSendAsAlias, error) {
+	row := q.db.QueryRowContext(ctx, getGmailSendAsAlias, arg.SessionID, arg.SendAsEmail)
+	var i GmailSendAsAlias
+	err := row.Scan(
+		&i.SessionID,
+		&i.SendAsEmail,
+		&i.DisplayName,
+		&i.ReplyToAddress,
+		&i.Signature,
+		&i.IsPrimary,
+		&i.IsDefault,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGmailWatch = `-- name: GetGmailWatch :one
+SELECT session_id, topic_name, label_ids, label_filter_behavior, history_id, expiration, stopped_at, updated_at
+FROM gmail_watches
+WHERE session_id = ?
+`
+
+func (q *Queries) GetGmailWatch(ctx context.Context, sessionID string) (GmailWatch, error) {
+	row := q.db.QueryRowContext(ctx, getGmailWatch, sessionID)
+	var i GmailWatch
+	err := row.Scan(
+		&i.SessionID,
+		&i.TopicName,
+		&i.LabelIds,
+		&i.LabelFilterBehavior,
+		&i.HistoryID,
+		&i.Expiration,
+		&i.StoppedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -176,7 +419,7 @@ const listGmailAttachmentsByMessage = `-- name: ListGmailAttachmentsByMessage :m
 SELECT id, message_id, filename, mime_type, size, created_at
 FROM gmail_attachments
 WHERE message_id = ? AND session_id = ?
-ORDER BY created_at
+ORDER BY created_at, rowid
 `
 
 type ListGmailAttachmentsByMessageParams struct {
@@ -226,15 +469,18 @@ func (q *Queries) ListGmailAttachmentsByMessage(ctx context.Context, arg ListGma
 const listGmailMessages = `-- name: ListGmailMessages :many
 SELECT id, thread_id, snippet, label_ids, internal_date
 FROM gmail_messages
-WHERE session_id = ?
-ORDER BY internal_date DESC
-LIMIT ? OFFSET ?
+WHERE session_id = ?1 AND user_id = ?2
+  AND (?3 = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+ORDER BY internal_date DESC, rowid DESC
+LIMIT ?4 OFFSET ?5
 `
 
 type ListGmailMessagesParams struct {
-	SessionID string `json:"session_id"`
-	Limit     int64  `json:"limit"`
-	Offset    int64  `json:"offset"`
+	SessionID    string      `json:"session_id"`
+	UserID       string      `json:"user_id"`
+	IncludeTrash interface{} `json:"include_trash"`
+	Limit        int64       `json:"limit"`
+	Offset       int64       `json:"offset"`
 }
 
 type ListGmailMessagesRow struct {
@@ -245,8 +491,15 @@ type ListGmailMessagesRow struct {
 	InternalDate int64          `json:"internal_date"`
 }
 
+// Trashed messages are skipped unless include_trash is 1
 func (q *Queries) ListGmailMessages(ctx context.Context, arg ListGmailMessagesParams) ([]ListGmailMessagesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listGmailMessages, arg.SessionID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listGmailMessages,
+		arg.SessionID,
+		arg.UserID,
+		arg.IncludeTrash,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -278,7 +531,7 @@ const listGmailMessagesBySession = `-- name: ListGmailMessagesBySession :many
 SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, snippet, label_ids, internal_date, size_estimate, created_at
 FROM gmail_messages
 WHERE session_id = ?
-ORDER BY internal_date DESC
+ORDER BY internal_date DESC, rowid DESC
 `
 
 type ListGmailMessagesBySessionRow struct {
@@ -333,17 +586,232 @@ func (q *Queries) ListGmailMessagesBySession(ctx context.Context, sessionID stri
 	return items, nil
 }
 
+const listGmailMessagesByThread = `-- name: ListGmailMessagesByThread :many
+SELECT id, thread_id, snippet, label_ids, internal_date
+FROM gmail_messages
+WHERE thread_id = ? AND session_id = ? AND user_id = ?
+ORDER BY internal_date ASC, rowid ASC
+`
+
+type ListGmailMessagesByThreadParams struct {
+	ThreadID  string `json:"thread_id"`
+	SessionID string `json:"session_id"`
+	UserID    string `json:"user_id"`
+}
+
+type ListGmailMessagesByThreadRow struct {
+	ID           string         `json:"id"`
+	ThreadID     string         `json:"thread_id"`
+	Snippet      sql.NullString `json:"snippet"`
+	LabelIds     sql.NullString `json:"label_ids"`
+	InternalDate int64          `json:"internal_date"`
+}
+
+// Messages of a thread, oldest first
+func (q *Queries) ListGmailMessagesByThread(ctx context.Context, arg ListGmailMessagesByThreadParams) ([]ListGmailMessagesByThreadRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGmailMessagesByThread, arg.ThreadID, arg.SessionID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGmailMessagesByThreadRow{}
+	for rows.Next() {
+		var i ListGmailMessagesByThreadRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.Snippet,
+			&i.LabelIds,
+			&i.InternalDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGmailMessagesWithParent = `-- name: ListGmailMessagesWithParent :many
+SELECT id, thread_id, snippet, label_ids, internal_date
+FROM gmail_messages
+WHERE user_id = ?1
+  AND (session_id = ?2 OR (session_id = ?3
+    AND id NOT IN (SELECT c.id FROM gmail_messages c WHERE c.session_id = ?2)
+    AND id NOT IN (SELECT t.message_id FROM gmail_message_tombstones t WHERE t.session_id = ?2)))
+  AND (?4 = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+ORDER BY internal_date DESC, rowid DESC
+LIMIT ?5 OFFSET ?6
+`
+
+type ListGmailMessagesWithParentParams struct {
+	UserID          string      `json:"user_id"`
+	SessionID       string      `json:"session_id"`
+	ParentSessionID string      `json:"parent_session_id"`
+	IncludeTrash    interface{} `json:"include_trash"`
+	Limit           int64       `json:"limit"`
+	Offset          int64       `json:"offset"`
+}
+
+type ListGmailMessagesWithParentRow struct {
+	ID           string         `json:"id"`
+	ThreadID     string         `json:"thread_id"`
+	Snippet      sql.NullString `json:"snippet"`
+	LabelIds     sql.NullString `json:"label_ids"`
+	InternalDate int64          `json:"internal_date"`
+}
+
+// Lists a child session's messages together with its parent's. Parent messages the child
+// has copied or deleted are shadowed.
+func (q *Queries) ListGmailMessagesWithParent(ctx context.Context, arg ListGmailMessagesWithParentParams) ([]ListGmailMessagesWithParentRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGmailMessagesWithParent,
+		arg.UserID,
+		arg.SessionID,
+		arg.ParentSessionID,
+		arg.IncludeTrash,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGmailMessagesWithParentRow{}
+	for rows.Next() {
+		var i ListGmailMessagesWithParentRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.Snippet,
+			&i.LabelIds,
+			&i.InternalDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGmailSendAsAliases = `-- name: ListGmailSendAsAliases :many
+SELECT session_id, send_as_email, display_name, reply_to_address, signature, is_primary, is_default, created_at
+FROM gmail_send_as_aliases
+WHERE session_id = ?
+ORDER BY is_primary DESC, send_as_email
+`
+
+// Send-as settings
+func (q *Queries) ListGmailSendAsAliases(ctx context.Context, sessionID string) ([]GmailSendAsAlias, error) {
+	rows, err := q.db.QueryContext(ctx, listGmailSendAsAliases, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GmailSendAsAlias{}
+	for rows.Next() {
+		var i GmailSendAsAlias
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.SendAsEmail,
+			&i.DisplayName,
+			&i.ReplyToAddress,
+			&i.Signature,
+			&i.IsPrimary,
+			&i.IsDefault,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGmailThreads = `-- name: ListGmailThreads :many
+SELECT thread_id, COUNT(*) AS message_count
+FROM gmail_messages
+WHERE session_id = ?1 AND user_id = ?2
+  AND (?3 = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+GROUP BY thread_id
+ORDER BY MAX(internal_date) DESC, thread_id ASC
+LIMIT ?4 OFFSET ?5
+`
+
+type ListGmailThreadsParams struct {
+	SessionID    string      `json:"session_id"`
+	UserID       string      `json:"user_id"`
+	IncludeTrash interface{} `json:"include_trash"`
+	Limit        int64       `json:"limit"`
+	Offset       int64       `json:"offset"`
+}
+
+type ListGmailThreadsRow struct {
+	ThreadID     string `json:"thread_id"`
+	MessageCount int64  `json:"message_count"`
+}
+
+// Threads with their message counts, most recently active first. Trashed messages are
+// skipped unless include_trash is 1.
+func (q *Queries) ListGmailThreads(ctx context.Context, arg ListGmailThreadsParams) ([]ListGmailThreadsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGmailThreads,
+		arg.SessionID,
+		arg.UserID,
+		arg.IncludeTrash,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGmailThreadsRow{}
+	for rows.Next() {
+		var i ListGmailThreadsRow
+		if err := rows.Scan(&i.ThreadID, &i.MessageCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchGmailMessages = `-- name: SearchGmailMessages :many
 SELECT id, thread_id, from_email, to_email, subject, snippet, label_ids, internal_date
 FROM gmail_messages
 WHERE
     session_id = ?
     AND (? = '' OR from_email LIKE '%' || ? || '%')
-    AND (? = '' OR to_email LIKE '%' || ? || '%')
+    AND (? = '' OR to_addresses || ' ' || cc_addresses LIKE '%' || ? || '%')
     AND (? = '' OR subject LIKE '%' || ? || '%')
     AND (? = '' OR body_plain LIKE '%' || ? || '%')
     AND (? = '' OR label_ids LIKE '%' || ? || '%')
-ORDER BY internal_date DESC
+    AND (? = '' OR cc_addresses LIKE '%' || ? || '%')
+    AND (? = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+    AND user_id = ?
+ORDER BY internal_date DESC, rowid DESC
 LIMIT ?
 `
 
@@ -359,6 +827,10 @@ type SearchGmailMessagesParams struct {
 	Column9   sql.NullString `json:"column_9"`
 	Column10  interface{}    `json:"column_10"`
 	Column11  sql.NullString `json:"column_11"`
+	Column12  interface{}    `json:"column_12"`
+	Column13  sql.NullString `json:"column_13"`
+	Column14  interface{}    `json:"column_14"`
+	UserID    string         `json:"user_id"`
 	Limit     int64          `json:"limit"`
 }
 
@@ -386,6 +858,10 @@ func (q *Queries) SearchGmailMessages(ctx context.Context, arg SearchGmailMessag
 		arg.Column9,
 		arg.Column10,
 		arg.Column11,
+		arg.Column12,
+		arg.Column13,
+		arg.Column14,
+		arg.UserID,
 		arg.Limit,
 	)
 	if err != nil {
@@ -417,3 +893,107 @@ func (q *Queries) SearchGmailMessages(ctx context.Context, arg SearchGmailMessag
 	}
 	return items, nil
 }
+
+const stopGmailWatch = `-- name: StopGmailWatch :exec
+UPDATE gmail_watches
+SET stopped_at = ?, updated_at = unixepoch()
+WHERE session_id = ? AND stopped_at IS NULL
+`
+
+type StopGmailWatchParams struct {
+	StoppedAt sql.NullInt64 `json:"stopped_at"`
+	SessionID string        `json:"session_id"`
+}
+
+func (q *Queries) StopGmailWatch(ctx context.Context, arg StopGmailWatchParams) error {
+	_, err := q.db.ExecContext(ctx, stopGmailWatch, arg.StoppedAt, arg.SessionID)
+	return err
+}
+
+const updateGmailMessageLabels = `-- name: UpdateGmailMessageLabels :exec
+UPDATE gmail_messages SET label_ids = ? WHERE id = ? AND session_id = ? AND user_id = ?
+`
+
+type UpdateGmailMessageLabelsParams struct {
+	LabelIds  sql.NullString `json:"label_ids"`
+	ID        string         `json:"id"`
+	SessionID string         `json:"session_id"`
+	UserID    string         `json:"user_id"`
+}
+
+func (q *Queries) UpdateGmailMessageLabels(ctx context.Context, arg UpdateGmailMessageLabelsParams) error {
+	_, err := q.db.ExecContext(ctx, updateGmailMessageLabels,
+		arg.LabelIds,
+		arg.ID,
+		arg.SessionID,
+		arg.UserID,
+	)
+	return err
+}
+
+const upsertGmailSendAsAlias = `-- name: UpsertGmailSendAsAlias :exec
+INSERT INTO gmail_send_as_aliases (session_id, send_as_email, display_name, reply_to_address, signature, is_primary, is_default)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(session_id, send_as_email) DO UPDATE SET
+    display_name = excluded.display_name,
+    reply_to_address = excluded.reply_to_address,
+    signature = excluded.signature,
+    is_default = excluded.is_default
+`
+
+type UpsertGmailSendAsAliasParams struct {
+	SessionID      string `json:"session_id"`
+	SendAsEmail    string `json:"send_as_email"`
+	DisplayName    string `json:"display_name"`
+	ReplyToAddress string `json:"reply_to_address"`
+	Signature      string `json:"signature"`
+	IsPrimary      int64  `json:"is_primary"`
+	IsDefault      int64  `json:"is_default"`
+}
+
+func (q *Queries) UpsertGmailSendAsAlias(ctx context.Context, arg UpsertGmailSendAsAliasParams) error {
+	_, err := q.db.ExecContext(ctx, upsertGmailSendAsAlias,
+		arg.SessionID,
+		arg.SendAsEmail,
+		arg.DisplayName,
+		arg.ReplyToAddress,
+		arg.Signature,
+		arg.IsPrimary,
+		arg.IsDefault,
+	)
+	return err
+}
+
+const upsertGmailWatch = `-- name: UpsertGmailWatch :exec
+INSERT INTO gmail_watches (session_id, topic_name, label_ids, label_filter_behavior, history_id, expiration, stopped_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, NULL, unixepoch())
+ON CONFLICT(session_id) DO UPDATE SET
+    topic_name = excluded.topic_name,
+    label_ids = excluded.label_ids,
+    label_filter_behavior = excluded.label_filter_behavior,
+    history_id = excluded.history_id,
+    expiration = excluded.expiration,
+    stopped_at = NULL,
+    updated_at = unixepoch()
+`
+
+type UpsertGmailWatchParams struct {
+	SessionID           string `json:"session_id"`
+	TopicName           string `json:"topic_name"`
+	LabelIds            string `json:"label_ids"`
+	LabelFilterBehavior string `json:"label_filter_behavior"`
+	HistoryID           int64  `json:"history_id"`
+	Expiration          int64  `json:"expiration"`
+}
+
+func (q *Queries) UpsertGmailWatch(ctx context.Context, arg UpsertGmailWatchParams) error {
+	_, err := q.db.ExecContext(ctx, upsertGmailWatch,
+		arg.SessionID,
+		arg.TopicName,
+		arg.LabelIds,
+		arg.LabelFilterBehavior,
+		arg.HistoryID,
+		arg.Expiration,
+	)
+	return err
+}
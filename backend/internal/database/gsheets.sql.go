@@ -41,6 +41,75 @@ func (q *Queries) ClearRange(ctx context.Context, arg ClearRangeParams) error {
 	return err
 }
 
+const commitRowShift = `-- name: CommitRowShift :exec
+UPDATE gsheets_cells
+SET row = -row
+WHERE spreadsheet_id = ? AND sheet_title = ? AND session_id = ? AND row < 0
+`
+
+type CommitRowShiftParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetTitle    string `json:"sheet_title"`
+	SessionID     string `json:"session_id"`
+}
+
+func (q *Queries) CommitRowShift(ctx context.Context, arg CommitRowShiftParams) error {
+	_, err := q.db.ExecContext(ctx, commitRowShift, arg.SpreadsheetID, arg.SheetTitle, arg.SessionID)
+	return err
+}
+
+const countGsheetsSessionObjects = `-- name: CountGsheetsSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM gsheets_spreadsheets WHERE session_id = ?1) AS spreadsheets,
+    (SELECT COUNT(*) FROM gsheets_sheets WHERE session_id = ?1) AS sheets,
+    (SELECT COUNT(*) FROM gsheets_cells WHERE session_id = ?1) AS cells
+`
+
+type CountGsheetsSessionObjectsRow struct {
+	Spreadsheets int64 `json:"spreadsheets"`
+	Sheets       int64 `json:"sheets"`
+	Cells        int64 `json:"cells"`
+}
+
+func (q *Queries) CountGsheetsSessionObjects(ctx context.Context, sessionID string) (CountGsheetsSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countGsheetsSessionObjects, sessionID)
+	var i CountGsheetsSessionObjectsRow
+	err := row.Scan(
+		&i.Spreadsheets,
+		&i.Sheets,
+		&i.Cells,
+	)
+	return i, err
+}
+
+const createDeveloperMetadata = `-- name: CreateDeveloperMetadata :exec
+INSERT INTO gsheets_developer_metadata (metadata_id, spreadsheet_id, metadata_key, metadata_value, location, visibility, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateDeveloperMetadataParams struct {
+	MetadataID    int64          `json:"metadata_id"`
+	SpreadsheetID string         `json:"spreadsheet_id"`
+	MetadataKey   string         `json:"metadata_key"`
+	MetadataValue sql.NullString `json:"metadata_value"`
+	Location      string         `json:"location"`
+	Visibility    string         `json:"visibility"`
+	SessionID     string         `json:"session_id"`
+}
+
+func (q *Queries) CreateDeveloperMetadata(ctx context.Context, arg CreateDeveloperMetadataParams) error {
+	_, err := q.db.ExecContext(ctx, createDeveloperMetadata,
+		arg.MetadataID,
+		arg.SpreadsheetID,
+		arg.MetadataKey,
+		arg.MetadataValue,
+		arg.Location,
+		arg.Visibility,
+		arg.SessionID,
+	)
+	return err
+}
+
 const createSheet = `-- name: CreateSheet :exec
 INSERT INTO gsheets_sheets (id, spreadsheet_id, title, sheet_id, session_id)
 VALUES (?, ?, ?, ?, ?)
@@ -65,6 +134,34 @@ func (q *Queries) CreateSheet(ctx context.Context, arg CreateSheetParams) error
 	return err
 }
 
+const createSheetMerge = `-- name: CreateSheetMerge :exec
+INSERT INTO gsheets_merges (spreadsheet_id, sheet_id, start_row_index, end_row_index, start_column_index, end_column_index, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateSheetMergeParams struct {
+	SpreadsheetID    string `json:"spreadsheet_id"`
+	SheetID          int64  `json:"sheet_id"`
+	StartRowIndex    int64  `json:"start_row_index"`
+	EndRowIndex      int64  `json:"end_row_index"`
+	StartColumnIndex int64  `json:"start_column_index"`
+	EndColumnIndex   int64  `json:"end_column_index"`
+	SessionID        string `json:"session_id"`
+}
+
+func (q *Queries) CreateSheetMerge(ctx context.Context, arg CreateSheetMergeParams) error {
+	_, err := q.db.ExecContext(ctx, createSheetMerge,
+		arg.SpreadsheetID,
+		arg.SheetID,
+		arg.StartRowIndex,
+		arg.EndRowIndex,
+		arg.StartColumnIndex,
+		arg.EndColumnIndex,
+		arg.SessionID,
+	)
+	return err
+}
+
 const createSpreadsheet = `-- name: CreateSpreadsheet :exec
 INSERT INTO gsheets_spreadsheets (id, title, session_id)
 VALUES (?, ?, ?)
@@ -106,6 +203,37 @@ func (q *Queries) DeleteSheet(ctx context.Context, arg DeleteSheetParams) error
 	return err
 }
 
+const deleteSheetMerge = `-- name: DeleteSheetMerge :exec
+DELETE FROM gsheets_merges
+WHERE id = ? AND session_id = ?
+`
+
+type DeleteSheetMergeParams struct {
+	ID        int64  `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteSheetMerge(ctx context.Context, arg DeleteSheetMergeParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSheetMerge, arg.ID, arg.SessionID)
+	return err
+}
+
+const deleteSheetMerges = `-- name: DeleteSheetMerges :exec
+DELETE FROM gsheets_merges
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?
+`
+
+type DeleteSheetMergesParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetID       int64  `json:"sheet_id"`
+	SessionID     string `json:"session_id"`
+}
+
+func (q *Queries) DeleteSheetMerges(ctx context.Context, arg DeleteSheetMergesParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSheetMerges, arg.SpreadsheetID, arg.SheetID, arg.SessionID)
+	return err
+}
+
 const getCellValue = `-- name: GetCellValue :one
 SELECT value
 FROM gsheets_cells
@@ -134,7 +262,7 @@ func (q *Queries) GetCellValue(ctx context.Context, arg GetCellValueParams) (sql
 }
 
 const getCellsInRange = `-- name: GetCellsInRange :many
-SELECT row, col, value
+SELECT row, col, value, value_type
 FROM gsheets_cells
 WHERE spreadsheet_id = ? AND sheet_title = ?
   AND row >= ? AND row <= ?
@@ -154,9 +282,10 @@ type GetCellsInRangeParams struct {
 }
 
 type GetCellsInRangeRow struct {
-	Row   int64          `json:"row"`
-	Col   int64          `json:"col"`
-	Value sql.NullString `json:"value"`
+	Row       int64          `json:"row"`
+	Col       int64          `json:"col"`
+	Value     sql.NullString `json:"value"`
+	ValueType string         `json:"value_type"`
 }
 
 func (q *Queries) GetCellsInRange(ctx context.Context, arg GetCellsInRangeParams) ([]GetCellsInRangeRow, error) {
@@ -176,7 +305,12 @@ func (q *Queries) GetCellsInRange(ctx context.Context, arg GetCellsInRangeParams
 	items := []GetCellsInRangeRow{}
 	for rows.Next() {
 		var i GetCellsInRangeRow
-		if err := rows.Scan(&i.Row, &i.Col, &i.Value); err != nil {
+		if err := rows.Scan(
+			&i.Row,
+			&i.Col,
+			&i.Value,
+			&i.ValueType,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -209,6 +343,37 @@ func (q *Queries) GetMaxRowInSheet(ctx context.Context, arg GetMaxRowInSheetPara
 	return max_row, err
 }
 
+const getSheetBySheetID = `-- name: GetSheetBySheetID :one
+SELECT id, spreadsheet_id, title, sheet_id
+FROM gsheets_sheets
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?
+`
+
+type GetSheetBySheetIDParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetID       int64  `json:"sheet_id"`
+	SessionID     string `json:"session_id"`
+}
+
+type GetSheetBySheetIDRow struct {
+	ID            string `json:"id"`
+	SpreadsheetID string `json:"spreadsheet_id"`
+	Title         string `json:"title"`
+	SheetID       int64  `json:"sheet_id"`
+}
+
+func (q *Queries) GetSheetBySheetID(ctx context.Context, arg GetSheetBySheetIDParams) (GetSheetBySheetIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getSheetBySheetID, arg.SpreadsheetID, arg.SheetID, arg.SessionID)
+	var i GetSheetBySheetIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.SpreadsheetID,
+		&i.Title,
+		&i.SheetID,
+	)
+	return i, err
+}
+
 const getSheetByTitle = `-- name: GetSheetByTitle :one
 SELECT id, spreadsheet_id, title, sheet_id
 FROM gsheets_sheets
@@ -311,6 +476,57 @@ func (q *Queries) GetSpreadsheet(ctx context.Context, arg GetSpreadsheetParams)
 	return i, err
 }
 
+const listDeveloperMetadataBySpreadsheet = `-- name: ListDeveloperMetadataBySpreadsheet :many
+SELECT metadata_id, spreadsheet_id, metadata_key, metadata_value, location, visibility
+FROM gsheets_developer_metadata
+WHERE spreadsheet_id = ? AND session_id = ?
+ORDER BY created_at ASC, metadata_id ASC
+`
+
+type ListDeveloperMetadataBySpreadsheetParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SessionID     string `json:"session_id"`
+}
+
+type ListDeveloperMetadataBySpreadsheetRow struct {
+	MetadataID    int64          `json:"metadata_id"`
+	SpreadsheetID string         `json:"spreadsheet_id"`
+	MetadataKey   string         `json:"metadata_key"`
+	MetadataValue sql.NullString `json:"metadata_value"`
+	Location      string         `json:"location"`
+	Visibility    string         `json:"visibility"`
+}
+
+func (q *Queries) ListDeveloperMetadataBySpreadsheet(ctx context.Context, arg ListDeveloperMetadataBySpreadsheetParams) ([]ListDeveloperMetadataBySpreadsheetRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDeveloperMetadataBySpreadsheet, arg.SpreadsheetID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDeveloperMetadataBySpreadsheetRow{}
+	for rows.Next() {
+		var i ListDeveloperMetadataBySpreadsheetRow
+		if err := rows.Scan(
+			&i.MetadataID,
+			&i.SpreadsheetID,
+			&i.MetadataKey,
+			&i.MetadataValue,
+			&i.Location,
+			&i.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGsheetsBySession = `-- name: ListGsheetsBySession :many
 SELECT id, title, created_at
 FROM gsheets_spreadsheets
@@ -348,11 +564,180 @@ func (q *Queries) ListGsheetsBySession(ctx context.Context, sessionID string) ([
 	return items, nil
 }
 
+const listOccupiedRows = `-- name: ListOccupiedRows :many
+SELECT DISTINCT row
+FROM gsheets_cells
+WHERE spreadsheet_id = ? AND sheet_title = ? AND session_id = ?
+  AND col >= ? AND col <= ?
+  AND value IS NOT NULL AND value != ''
+ORDER BY row ASC
+`
+
+type ListOccupiedRowsParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetTitle    string `json:"sheet_title"`
+	SessionID     string `json:"session_id"`
+	StartCol      int64  `json:"start_col"`
+	EndCol        int64  `json:"end_col"`
+}
+
+func (q *Queries) ListOccupiedRows(ctx context.Context, arg ListOccupiedRowsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listOccupiedRows,
+		arg.SpreadsheetID,
+		arg.SheetTitle,
+		arg.SessionID,
+		arg.StartCol,
+		arg.EndCol,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var row int64
+		if err := rows.Scan(&row); err != nil {
+			return nil, err
+		}
+		items = append(items, row)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSheetMerges = `-- name: ListSheetMerges :many
+SELECT id, start_row_index, end_row_index, start_column_index, end_column_index
+FROM gsheets_merges
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?
+ORDER BY start_row_index ASC, start_column_index ASC, id ASC
+`
+
+type ListSheetMergesParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetID       int64  `json:"sheet_id"`
+	SessionID     string `json:"session_id"`
+}
+
+type ListSheetMergesRow struct {
+	ID               int64 `json:"id"`
+	StartRowIndex    int64 `json:"start_row_index"`
+	EndRowIndex      int64 `json:"end_row_index"`
+	StartColumnIndex int64 `json:"start_column_index"`
+	EndColumnIndex   int64 `json:"end_column_index"`
+}
+
+func (q *Queries) ListSheetMerges(ctx context.Context, arg ListSheetMergesParams) ([]ListSheetMergesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSheetMerges, arg.SpreadsheetID, arg.SheetID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSheetMergesRow{}
+	for rows.Next() {
+		var i ListSheetMergesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartRowIndex,
+			&i.EndRowIndex,
+			&i.StartColumnIndex,
+			&i.EndColumnIndex,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSpreadsheetCells = `-- name: ListSpreadsheetCells :many
+SELECT sheet_title, row, col, value, value_type
+FROM gsheets_cells
+WHERE spreadsheet_id = ? AND session_id = ?
+ORDER BY sheet_title ASC, row ASC, col ASC
+`
+
+type ListSpreadsheetCellsParams struct {
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SessionID     string `json:"session_id"`
+}
+
+type ListSpreadsheetCellsRow struct {
+	SheetTitle string         `json:"sheet_title"`
+	Row        int64          `json:"row"`
+	Col        int64          `json:"col"`
+	Value      sql.NullString `json:"value"`
+	ValueType  string         `json:"value_type"`
+}
+
+func (q *Queries) ListSpreadsheetCells(ctx context.Context, arg ListSpreadsheetCellsParams) ([]ListSpreadsheetCellsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSpreadsheetCells, arg.SpreadsheetID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSpreadsheetCellsRow{}
+	for rows.Next() {
+		var i ListSpreadsheetCellsRow
+		if err := rows.Scan(
+			&i.SheetTitle,
+			&i.Row,
+			&i.Col,
+			&i.Value,
+			&i.ValueType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const renameSheetCells = `-- name: RenameSheetCells :exec
+UPDATE gsheets_cells
+SET sheet_title = ?1
+WHERE spreadsheet_id = ?2 AND sheet_title = ?3 AND session_id = ?4
+`
+
+type RenameSheetCellsParams struct {
+	NewTitle      string `json:"new_title"`
+	SpreadsheetID string `json:"spreadsheet_id"`
+	OldTitle      string `json:"old_title"`
+	SessionID     string `json:"session_id"`
+}
+
+// Cells are keyed by sheet title, so renaming a sheet moves its cells along
+func (q *Queries) RenameSheetCells(ctx context.Context, arg RenameSheetCellsParams) error {
+	_, err := q.db.ExecContext(ctx, renameSheetCells,
+		arg.NewTitle,
+		arg.SpreadsheetID,
+		arg.OldTitle,
+		arg.SessionID,
+	)
+	return err
+}
+
 const setCellValue = `-- name: SetCellValue :exec
-INSERT INTO gsheets_cells (spreadsheet_id, sheet_title, row, col, value, session_id, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, unixepoch())
+INSERT INTO gsheets_cells (spreadsheet_id, sheet_title, row, col, value, value_type, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
 ON CONFLICT(spreadsheet_id, sheet_title, row, col, session_id)
-DO UPDATE SET value = excluded.value, updated_at = unixepoch()
+DO UPDATE SET value = excluded.value, value_type = excluded.value_type, updated_at = unixepoch()
 `
 
 type SetCellValueParams struct {
@@ -361,6 +746,7 @@ type SetCellValueParams struct {
 	Row           int64          `json:"row"`
 	Col           int64          `json:"col"`
 	Value         sql.NullString `json:"value"`
+	ValueType     string         `json:"value_type"`
 	SessionID     string         `json:"session_id"`
 }
 
@@ -371,7 +757,75 @@ func (q *Queries) SetCellValue(ctx context.Context, arg SetCellValueParams) erro
 		arg.Row,
 		arg.Col,
 		arg.Value,
+		arg.ValueType,
+		arg.SessionID,
+	)
+	return err
+}
+
+const stageRowShift = `-- name: StageRowShift :exec
+UPDATE gsheets_cells
+SET row = -(row + ?)
+WHERE spreadsheet_id = ? AND sheet_title = ? AND session_id = ?
+  AND row >= ?
+`
+
+type StageRowShiftParams struct {
+	RowCount      int64  `json:"row_count"`
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetTitle    string `json:"sheet_title"`
+	SessionID     string `json:"session_id"`
+	FromRow       int64  `json:"from_row"`
+}
+
+// Rows are shifted in two steps so no intermediate update collides with the primary key
+func (q *Queries) StageRowShift(ctx context.Context, arg StageRowShiftParams) error {
+	_, err := q.db.ExecContext(ctx, stageRowShift,
+		arg.RowCount,
+		arg.SpreadsheetID,
+		arg.SheetTitle,
 		arg.SessionID,
+		arg.FromRow,
 	)
 	return err
 }
+
+const updateSheetTitle = `-- name: UpdateSheetTitle :exec
+UPDATE gsheets_sheets
+SET title = ?
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?
+`
+
+type UpdateSheetTitleParams struct {
+	Title         string `json:"title"`
+	SpreadsheetID string `json:"spreadsheet_id"`
+	SheetID       int64  `json:"sheet_id"`
+	SessionID     string `json:"session_id"`
+}
+
+func (q *Queries) UpdateSheetTitle(ctx context.Context, arg UpdateSheetTitleParams) error {
+	_, err := q.db.ExecContext(ctx, updateSheetTitle,
+		arg.Title,
+		arg.SpreadsheetID,
+		arg.SheetID,
+		arg.SessionID,
+	)
+	return err
+}
+
+const updateSpreadsheetTitle = `-- name: UpdateSpreadsheetTitle :exec
+UPDATE gsheets_spreadsheets
+SET title = ?
+WHERE id = ? AND session_id = ?
+`
+
+type UpdateSpreadsheetTitleParams struct {
+	Title     string `json:"title"`
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) UpdateSpreadsheetTitle(ctx context.Context, arg UpdateSpreadsheetTitleParams) error {
+	_, err := q.db.ExecContext(ctx, updateSpreadsheetTitle, arg.Title, arg.ID, arg.SessionID)
+	return err
+}
@@ -10,6 +10,33 @@ import (
 	"database/sql"
 )
 
+const countHubspotSessionObjects = `-- name: CountHubspotSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM hubspot_contacts WHERE session_id = ?1) AS contacts,
+    (SELECT COUNT(*) FROM hubspot_companies WHERE session_id = ?1) AS companies,
+    (SELECT COUNT(*) FROM hubspot_deals WHERE session_id = ?1) AS deals,
+    (SELECT COUNT(*) FROM hubspot_associations WHERE session_id = ?1) AS associations
+`
+
+type CountHubspotSessionObjectsRow struct {
+	Contacts     int64 `json:"contacts"`
+	Companies    int64 `json:"companies"`
+	Deals        int64 `json:"deals"`
+	Associations int64 `json:"associations"`
+}
+
+func (q *Queries) CountHubspotSessionObjects(ctx context.Context, sessionID string) (CountHubspotSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countHubspotSessionObjects, sessionID)
+	var i CountHubspotSessionObjectsRow
+	err := row.Scan(
+		&i.Contacts,
+		&i.Companies,
+		&i.Deals,
+		&i.Associations,
+	)
+	return i, err
+}
+
 const createHubspotAssociation = `-- name: CreateHubspotAssociation :exec
 INSERT INTO hubspot_associations (from_object_type, from_object_id, to_object_type, to_object_id, association_type, session_id)
 VALUES (?, ?, ?, ?, ?, ?)
@@ -197,6 +224,58 @@ func (q *Queries) CreateHubspotDeal(ctx context.Context, arg CreateHubspotDealPa
 	return i, err
 }
 
+const createHubspotPropertyDefinition = `-- name: CreateHubspotPropertyDefinition :one
+INSERT INTO hubspot_property_definitions (session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at
+`
+
+type CreateHubspotPropertyDefinitionParams struct {
+	SessionID   string `json:"session_id"`
+	ObjectType  string `json:"object_type"`
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	FieldType   string `json:"field_type"`
+	GroupName   string `json:"group_name"`
+	Description string `json:"description"`
+	Options     string `json:"options"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
+// Properties API queries
+func (q *Queries) CreateHubspotPropertyDefinition(ctx context.Context, arg CreateHubspotPropertyDefinitionParams) (HubspotPropertyDefinition, error) {
+	row := q.db.QueryRowContext(ctx, createHubspotPropertyDefinition,
+		arg.SessionID,
+		arg.ObjectType,
+		arg.Name,
+		arg.Label,
+		arg.Type,
+		arg.FieldType,
+		arg.GroupName,
+		arg.Description,
+		arg.Options,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i HubspotPropertyDefinition
+	err := row.Scan(
+		&i.SessionID,
+		&i.ObjectType,
+		&i.Name,
+		&i.Label,
+		&i.Type,
+		&i.FieldType,
+		&i.GroupName,
+		&i.Description,
+		&i.Options,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const deleteHubspotSessionData = `-- name: DeleteHubspotSessionData :exec
 DELETE FROM hubspot_associations WHERE session_id = ?
 `
@@ -425,11 +504,122 @@ func (q *Queries) GetHubspotDealByID(ctx context.Context, arg GetHubspotDealByID
 	return i, err
 }
 
+const getHubspotObjectProperties = `-- name: GetHubspotObjectProperties :one
+SELECT properties
+FROM hubspot_object_properties
+WHERE session_id = ? AND object_type = ? AND object_id = ?
+`
+
+type GetHubspotObjectPropertiesParams struct {
+	SessionID  string `json:"session_id"`
+	ObjectType string `json:"object_type"`
+	ObjectID   string `json:"object_id"`
+}
+
+// Values of properties without a typed column
+func (q *Queries) GetHubspotObjectProperties(ctx context.Context, arg GetHubspotObjectPropertiesParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getHubspotObjectProperties, arg.SessionID, arg.ObjectType, arg.ObjectID)
+	var properties string
+	err := row.Scan(&properties)
+	return properties, err
+}
+
+const getHubspotPropertyDefinition = `-- name: GetHubspotPropertyDefinition :one
+SELECT session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at
+FROM hubspot_property_definitions
+WHERE session_id = ? AND object_type = ? AND name = ?
+`
+
+type GetHubspotPropertyDefinitionParams struct {
+	SessionID  string `json:"session_id"`
+	ObjectType string `json:"object_type"`
+	Name       string `json:"name"`
+}
+
+func (q *Queries) GetHubspotPropertyDefinition(ctx context.Context, arg GetHubspotPropertyDefinitionParams) (HubspotPropertyDefinition, error) {
+	row := q.db.QueryRowContext(ctx, getHubspotPropertyDefinition, arg.SessionID, arg.ObjectType, arg.Name)
+	var i HubspotPropertyDefinition
+	err := row.Scan(
+		&i.SessionID,
+		&i.ObjectType,
+		&i.Name,
+		&i.Label,
+		&i.Type,
+		&i.FieldType,
+		&i.GroupName,
+		&i.Description,
+		&i.Options,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listHubspotAssociationsForObject = `-- name: ListHubspotAssociationsForObject :many
+SELECT from_object_type, from_object_id, to_object_type, to_object_id, association_type, created_at
+FROM hubspot_associations
+WHERE session_id = ?1
+  AND ((from_object_type = ?2 AND from_object_id = ?3 AND to_object_type = ?4)
+    OR (to_object_type = ?2 AND to_object_id = ?3 AND from_object_type = ?4))
+ORDER BY created_at ASC, id ASC
+`
+
+type ListHubspotAssociationsForObjectParams struct {
+	SessionID   string `json:"session_id"`
+	ObjectType  string `json:"object_type"`
+	ObjectID    string `json:"object_id"`
+	RelatedType string `json:"related_type"`
+}
+
+type ListHubspotAssociationsForObjectRow struct {
+	FromObjectType  string `json:"from_object_type"`
+	FromObjectID    string `json:"from_object_id"`
+	ToObjectType    string `json:"to_object_type"`
+	ToObjectID      string `json:"to_object_id"`
+	AssociationType string `json:"association_type"`
+	CreatedAt       int64  `json:"created_at"`
+}
+
+func (q *Queries) ListHubspotAssociationsForObject(ctx context.Context, arg ListHubspotAssociationsForObjectParams) ([]ListHubspotAssociationsForObjectRow, error) {
+	rows, err := q.db.QueryContext(ctx, listHubspotAssociationsForObject,
+		arg.SessionID,
+		arg.ObjectType,
+		arg.ObjectID,
+		arg.RelatedType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListHubspotAssociationsForObjectRow{}
+	for rows.Next() {
+		var i ListHubspotAssociationsForObjectRow
+		if err := rows.Scan(
+			&i.FromObjectType,
+			&i.FromObjectID,
+			&i.ToObjectType,
+			&i.ToObjectID,
+			&i.AssociationType,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listHubspotCompanies = `-- name: ListHubspotCompanies :many
 SELECT id, name, domain, city, industry, created_at, updated_at
 FROM hubspot_companies
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 `
 
 type ListHubspotCompaniesRow struct {
@@ -477,7 +667,7 @@ const listHubspotContacts = `-- name: ListHubspotContacts :many
 SELECT id, email, first_name, last_name, mobile_phone, website, created_at, updated_at
 FROM hubspot_contacts
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 `
 
 type ListHubspotContactsRow struct {
@@ -527,7 +717,7 @@ const listHubspotContactsBySession = `-- name: ListHubspotContactsBySession :man
 SELECT id, email, first_name, last_name, mobile_phone, website, created_at, updated_at
 FROM hubspot_contacts
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 `
 
 type ListHubspotContactsBySessionRow struct {
@@ -578,7 +768,7 @@ const listHubspotDeals = `-- name: ListHubspotDeals :many
 SELECT id, deal_name, deal_stage, pipeline, amount, created_at, updated_at
 FROM hubspot_deals
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 `
 
 type ListHubspotDealsRow struct {
@@ -622,6 +812,92 @@ func (q *Queries) ListHubspotDeals(ctx context.Context, sessionID string) ([]Lis
 	return items, nil
 }
 
+const listHubspotObjectProperties = `-- name: ListHubspotObjectProperties :many
+SELECT object_id, properties
+FROM hubspot_object_properties
+WHERE session_id = ? AND object_type = ?
+`
+
+type ListHubspotObjectPropertiesParams struct {
+	SessionID  string `json:"session_id"`
+	ObjectType string `json:"object_type"`
+}
+
+type ListHubspotObjectPropertiesRow struct {
+	ObjectID   string `json:"object_id"`
+	Properties string `json:"properties"`
+}
+
+func (q *Queries) ListHubspotObjectProperties(ctx context.Context, arg ListHubspotObjectPropertiesParams) ([]ListHubspotObjectPropertiesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listHubspotObjectProperties, arg.SessionID, arg.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListHubspotObjectPropertiesRow{}
+	for rows.Next() {
+		var i ListHubspotObjectPropertiesRow
+		if err := rows.Scan(&i.ObjectID, &i.Properties); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHubspotPropertyDefinitions = `-- name: ListHubspotPropertyDefinitions :many
+SELECT session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at
+FROM hubspot_property_definitions
+WHERE session_id = ? AND object_type = ?
+ORDER BY created_at, name
+`
+
+type ListHubspotPropertyDefinitionsParams struct {
+	SessionID  string `json:"session_id"`
+	ObjectType string `json:"object_type"`
+}
+
+func (q *Queries) ListHubspotPropertyDefinitions(ctx context.Context, arg ListHubspotPropertyDefinitionsParams) ([]HubspotPropertyDefinition, error) {
+	rows, err := q.db.QueryContext(ctx, listHubspotPropertyDefinitions, arg.SessionID, arg.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []HubspotPropertyDefinition{}
+	for rows.Next() {
+		var i HubspotPropertyDefinition
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.ObjectType,
+			&i.Name,
+			&i.Label,
+			&i.Type,
+			&i.FieldType,
+			&i.GroupName,
+			&i.Description,
+			&i.Options,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchHubspotContactsByEmail = `-- name: SearchHubspotContactsByEmail :many
 SELECT id, email, first_name, last_name, mobile_phone, website, created_at, updated_at
 FROM hubspot_contacts
@@ -771,3 +1047,27 @@ func (q *Queries) UpdateHubspotDeal(ctx context.Context, arg UpdateHubspotDealPa
 	)
 	return err
 }
+
+const upsertHubspotObjectProperties = `-- name: UpsertHubspotObjectProperties :exec
+INSERT INTO hubspot_object_properties (session_id, object_type, object_id, properties)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(session_id, object_type, object_id) DO UPDATE SET
+    properties = excluded.properties
+`
+
+type UpsertHubspotObjectPropertiesParams struct {
+	SessionID  string `json:"session_id"`
+	ObjectType string `json:"object_type"`
+	ObjectID   string `json:"object_id"`
+	Properties string `json:"properties"`
+}
+
+func (q *Queries) UpsertHubspotObjectProperties(ctx context.Context, arg UpsertHubspotObjectPropertiesParams) error {
+	_, err := q.db.ExecContext(ctx, upsertHubspotObjectProperties,
+		arg.SessionID,
+		arg.ObjectType,
+		arg.ObjectID,
+		arg.Properties,
+	)
+	return err
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"sync"
 
 	"github.com/pressly/goose/v3"
@@ -17,40 +18,68 @@ var (
 	errInit error
 )
 
+// MemoryDSN is a named in-memory database. Shared cache makes every connection in the process
+// open the same database rather than a fresh empty one.
+const MemoryDSN = "file:simulators?mode=memory&cache=shared"
+
 // InitDB initializes the shared libSQL database connection
 func InitDB(dbPath string) error {
 	once.Do(func() {
-		db, errInit = sql.Open("libsql", dbPath)
-		if errInit != nil {
-			return
-		}
-
-		// Test connection
-		errInit = db.PingContext(context.Background())
+		db, errInit = Open(dbPath, "migrations")
 		if errInit != nil {
 			return
 		}
 
-		// Set connection pool settings
-		db.SetMaxOpenConns(25)
-		db.SetMaxIdleConns(5)
-
-		// Run goose migrations
-		if err := goose.SetDialect("sqlite3"); err != nil {
-			errInit = err
-			return
-		}
-		if err := goose.Up(db, "migrations"); err != nil {
-			errInit = err
-			return
-		}
-
 		// Initialize sqlc queries
 		queries = New(db)
 	})
 	return errInit
 }
 
+// Open connects to dbPath and runs the goose migrations in migrationsDir
+func Open(dbPath, migrationsDir string) (*sql.DB, error) {
+	conn, err := sql.Open("libsql", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test connection
+	if err := conn.PingContext(context.Background()); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	// Set connection pool settings
+	if IsMemory(dbPath) {
+		// An in-memory database is dropped when its last connection closes, so keep exactly
+		// one open for the life of the process. It also serializes access, which avoids the
+		// table-level lock errors shared cache raises for concurrent writers.
+		conn.SetMaxOpenConns(1)
+		conn.SetMaxIdleConns(1)
+		conn.SetConnMaxLifetime(0)
+		conn.SetConnMaxIdleTime(0)
+	} else {
+		conn.SetMaxOpenConns(25)
+		conn.SetMaxIdleConns(5)
+	}
+
+	// Run goose migrations
+	if err := goose.SetDialect("sqlite3"); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if err := goose.Up(conn, migrationsDir); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// IsMemory reports whether dbPath names an in-memory database
+func IsMemory(dbPath string) bool {
+	return strings.Contains(dbPath, "mode=memory")
+}
+
 // GetDB returns the shared database connection
 func GetDB() *sql.DB {
 	return db
@@ -10,6 +10,30 @@ import (
 	"database/sql"
 )
 
+const countJiraSessionObjects = `-- name: CountJiraSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM jira_projects WHERE session_id = ?1) AS projects,
+    (SELECT COUNT(*) FROM jira_issues WHERE session_id = ?1) AS issues,
+    (SELECT COUNT(*) FROM jira_comments WHERE session_id = ?1) AS comments
+`
+
+type CountJiraSessionObjectsRow struct {
+	Projects int64 `json:"projects"`
+	Issues   int64 `json:"issues"`
+	Comments int64 `json:"comments"`
+}
+
+func (q *Queries) CountJiraSessionObjects(ctx context.Context, sessionID string) (CountJiraSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countJiraSessionObjects, sessionID)
+	var i CountJiraSessionObjectsRow
+	err := row.Scan(
+		&i.Projects,
+		&i.Issues,
+		&i.Comments,
+	)
+	return i, err
+}
+
 const createJiraComment = `-- name: CreateJiraComment :exec
 INSERT INTO jira_comments (id, issue_key, body, session_id)
 VALUES (?, ?, ?, ?)
@@ -108,6 +132,71 @@ func (q *Queries) CreateJiraTransition(ctx context.Context, arg CreateJiraTransi
 	return err
 }
 
+const deleteJiraCommentsByProject = `-- name: DeleteJiraCommentsByProject :exec
+DELETE FROM jira_comments
+WHERE session_id = ?1
+  AND issue_key IN (SELECT key FROM jira_issues WHERE project_key = ?2 AND session_id = ?1)
+`
+
+type DeleteJiraCommentsByProjectParams struct {
+	SessionID  string `json:"session_id"`
+	ProjectKey string `json:"project_key"`
+}
+
+func (q *Queries) DeleteJiraCommentsByProject(ctx context.Context, arg DeleteJiraCommentsByProjectParams) error {
+	_, err := q.db.ExecContext(ctx, deleteJiraCommentsByProject, arg.SessionID, arg.ProjectKey)
+	return err
+}
+
+const deleteJiraIssueCustomFieldsByProject = `-- name: DeleteJiraIssueCustomFieldsByProject :exec
+DELETE FROM jira_issue_custom_fields
+WHERE session_id = ?1
+  AND issue_key IN (SELECT key FROM jira_issues WHERE project_key = ?2 AND session_id = ?1)
+`
+
+type DeleteJiraIssueCustomFieldsByProjectParams struct {
+	SessionID  string `json:"session_id"`
+	ProjectKey string `json:"project_key"`
+}
+
+func (q *Queries) DeleteJiraIssueCustomFieldsByProject(ctx context.Context, arg DeleteJiraIssueCustomFieldsByProjectParams) error {
+	_, err := q.db.ExecContext(ctx, deleteJiraIssueCustomFieldsByProject, arg.SessionID, arg.ProjectKey)
+	return err
+}
+
+const deleteJiraIssuesByProject = `-- name: DeleteJiraIssuesByProject :exec
+DELETE FROM jira_issues
+WHERE project_key = ? AND session_id = ?
+`
+
+type DeleteJiraIssuesByProjectParams struct {
+	ProjectKey string `json:"project_key"`
+	SessionID  string `json:"session_id"`
+}
+
+func (q *Queries) DeleteJiraIssuesByProject(ctx context.Context, arg DeleteJiraIssuesByProjectParams) error {
+	_, err := q.db.ExecContext(ctx, deleteJiraIssuesByProject, arg.ProjectKey, arg.SessionID)
+	return err
+}
+
+const deleteJiraProject = `-- name: DeleteJiraProject :execrows
+DELETE FROM jira_projects
+WHERE key = ? AND session_id = ?
+`
+
+type DeleteJiraProjectParams struct {
+	Key       string `json:"key"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteJiraProject(ctx context.Context, arg DeleteJiraProjectParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteJiraProject, arg.Key, arg.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteJiraSessionData = `-- name: DeleteJiraSessionData :exec
 DELETE FROM jira_projects WHERE session_id = ?
 `
@@ -159,6 +248,25 @@ func (q *Queries) GetJiraIssueByKey(ctx context.Context, arg GetJiraIssueByKeyPa
 	return i, err
 }
 
+const getJiraIssueCustomFields = `-- name: GetJiraIssueCustomFields :one
+SELECT fields
+FROM jira_issue_custom_fields
+WHERE session_id = ? AND issue_key = ?
+`
+
+type GetJiraIssueCustomFieldsParams struct {
+	SessionID string `json:"session_id"`
+	IssueKey  string `json:"issue_key"`
+}
+
+// Values of fields without a typed column
+func (q *Queries) GetJiraIssueCustomFields(ctx context.Context, arg GetJiraIssueCustomFieldsParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getJiraIssueCustomFields, arg.SessionID, arg.IssueKey)
+	var fields string
+	err := row.Scan(&fields)
+	return fields, err
+}
+
 const getJiraProjectByKey = `-- name: GetJiraProjectByKey :one
 SELECT id, key, name, created_at
 FROM jira_projects
@@ -223,7 +331,7 @@ const listJiraComments = `-- name: ListJiraComments :many
 SELECT id, issue_key, body, created_at
 FROM jira_comments
 WHERE issue_key = ? AND session_id = ?
-ORDER BY created_at ASC
+ORDER BY created_at ASC, rowid ASC
 `
 
 type ListJiraCommentsParams struct {
@@ -266,11 +374,45 @@ func (q *Queries) ListJiraComments(ctx context.Context, arg ListJiraCommentsPara
 	return items, nil
 }
 
+const listJiraIssueCustomFields = `-- name: ListJiraIssueCustomFields :many
+SELECT issue_key, fields
+FROM jira_issue_custom_fields
+WHERE session_id = ?
+`
+
+type ListJiraIssueCustomFieldsRow struct {
+	IssueKey string `json:"issue_key"`
+	Fields   string `json:"fields"`
+}
+
+func (q *Queries) ListJiraIssueCustomFields(ctx context.Context, sessionID string) ([]ListJiraIssueCustomFieldsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listJiraIssueCustomFields, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListJiraIssueCustomFieldsRow{}
+	for rows.Next() {
+		var i ListJiraIssueCustomFieldsRow
+		if err := rows.Scan(&i.IssueKey, &i.Fields); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listJiraIssuesBySession = `-- name: ListJiraIssuesBySession :many
 SELECT id, key, project_key, issue_type, summary, description, assignee, status, created_at, updated_at
 FROM jira_issues
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 `
 
 type ListJiraIssuesBySessionRow struct {
@@ -325,7 +467,7 @@ const listJiraProjects = `-- name: ListJiraProjects :many
 SELECT id, key, name, created_at
 FROM jira_projects
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 `
 
 type ListJiraProjectsRow struct {
@@ -367,7 +509,7 @@ const listJiraTransitions = `-- name: ListJiraTransitions :many
 SELECT id, name, to_status, created_at
 FROM jira_transitions
 WHERE session_id = ?
-ORDER BY created_at ASC
+ORDER BY created_at ASC, rowid ASC
 `
 
 type ListJiraTransitionsRow struct {
@@ -414,7 +556,7 @@ WHERE session_id = ?
     AND (? = '' OR summary LIKE '%' || ? || '%')
     AND (? = '' OR assignee = ?)
     AND (? = '' OR status = ?)
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 LIMIT ?
 `
 
@@ -534,3 +676,21 @@ func (q *Queries) UpdateJiraIssueStatus(ctx context.Context, arg UpdateJiraIssue
 	_, err := q.db.ExecContext(ctx, updateJiraIssueStatus, arg.Status, arg.Key, arg.SessionID)
 	return err
 }
+
+const upsertJiraIssueCustomFields = `-- name: UpsertJiraIssueCustomFields :exec
+INSERT INTO jira_issue_custom_fields (session_id, issue_key, fields)
+VALUES (?, ?, ?)
+ON CONFLICT(session_id, issue_key) DO UPDATE SET
+    fields = excluded.fields
+`
+
+type UpsertJiraIssueCustomFieldsParams struct {
+	SessionID string `json:"session_id"`
+	IssueKey  string `json:"issue_key"`
+	Fields    string `json:"fields"`
+}
+
+func (q *Queries) UpsertJiraIssueCustomFields(ctx context.Context, arg UpsertJiraIssueCustomFieldsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertJiraIssueCustomFields, arg.SessionID, arg.IssueKey, arg.Fields)
+	return err
+}
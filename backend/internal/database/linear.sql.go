@@ -10,10 +10,37 @@ import (
 	"database/sql"
 )
 
+const countLinearSessionObjects = `-- name: CountLinearSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM linear_teams WHERE session_id = ?1) AS teams,
+    (SELECT COUNT(*) FROM linear_users WHERE session_id = ?1) AS users,
+    (SELECT COUNT(*) FROM linear_issues WHERE session_id = ?1) AS issues,
+    (SELECT COUNT(*) FROM linear_projects WHERE session_id = ?1) AS projects
+`
+
+type CountLinearSessionObjectsRow struct {
+	Teams    int64 `json:"teams"`
+	Users    int64 `json:"users"`
+	Issues   int64 `json:"issues"`
+	Projects int64 `json:"projects"`
+}
+
+func (q *Queries) CountLinearSessionObjects(ctx context.Context, sessionID string) (CountLinearSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countLinearSessionObjects, sessionID)
+	var i CountLinearSessionObjectsRow
+	err := row.Scan(
+		&i.Teams,
+		&i.Users,
+		&i.Issues,
+		&i.Projects,
+	)
+	return i, err
+}
+
 const createLinearIssue = `-- name: CreateLinearIssue :one
-INSERT INTO linear_issues (id, team_id, title, description, assignee_id, state_id, url, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+INSERT INTO linear_issues (id, team_id, title, description, assignee_id, state_id, project_id, url, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 `
 
 type CreateLinearIssueParams struct {
@@ -23,6 +50,7 @@ type CreateLinearIssueParams struct {
 	Description sql.NullString `json:"description"`
 	AssigneeID  sql.NullString `json:"assignee_id"`
 	StateID     sql.NullString `json:"state_id"`
+	ProjectID   sql.NullString `json:"project_id"`
 	Url         string         `json:"url"`
 	SessionID   string         `json:"session_id"`
 	CreatedAt   int64          `json:"created_at"`
@@ -40,6 +68,7 @@ type CreateLinearIssueRow struct {
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ArchivedAt  sql.NullInt64  `json:"archived_at"`
+	ProjectID   sql.NullString `json:"project_id"`
 }
 
 // Issues queries
@@ -51,6 +80,7 @@ func (q *Queries) CreateLinearIssue(ctx context.Context, arg CreateLinearIssuePa
 		arg.Description,
 		arg.AssigneeID,
 		arg.StateID,
+		arg.ProjectID,
 		arg.Url,
 		arg.SessionID,
 		arg.CreatedAt,
@@ -68,6 +98,78 @@ func (q *Queries) CreateLinearIssue(ctx context.Context, arg CreateLinearIssuePa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ArchivedAt,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const createLinearOrganization = `-- name: CreateLinearOrganization :exec
+INSERT INTO linear_organizations (id, name, url_key, session_id)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateLinearOrganizationParams struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	UrlKey    string `json:"url_key"`
+	SessionID string `json:"session_id"`
+}
+
+// Organization queries
+func (q *Queries) CreateLinearOrganization(ctx context.Context, arg CreateLinearOrganizationParams) error {
+	_, err := q.db.ExecContext(ctx, createLinearOrganization,
+		arg.ID,
+		arg.Name,
+		arg.UrlKey,
+		arg.SessionID,
+	)
+	return err
+}
+
+const createLinearProject = `-- name: CreateLinearProject :one
+INSERT INTO linear_projects (id, name, description, team_ids, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, description, team_ids, created_at, updated_at
+`
+
+type CreateLinearProjectParams struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	TeamIds     string         `json:"team_ids"`
+	SessionID   string         `json:"session_id"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+type CreateLinearProjectRow struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	TeamIds     string         `json:"team_ids"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+// Projects queries
+func (q *Queries) CreateLinearProject(ctx context.Context, arg CreateLinearProjectParams) (CreateLinearProjectRow, error) {
+	row := q.db.QueryRowContext(ctx, createLinearProject,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.TeamIds,
+		arg.SessionID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i CreateLinearProjectRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.TeamIds,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -154,7 +256,7 @@ func (q *Queries) DeleteLinearSessionData(ctx context.Context, sessionID string)
 }
 
 const getLinearIssueByID = `-- name: GetLinearIssueByID :one
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE id = ? AND session_id = ?
 `
@@ -175,6 +277,7 @@ type GetLinearIssueByIDRow struct {
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ArchivedAt  sql.NullInt64  `json:"archived_at"`
+	ProjectID   sql.NullString `json:"project_id"`
 }
 
 func (q *Queries) GetLinearIssueByID(ctx context.Context, arg GetLinearIssueByIDParams) (GetLinearIssueByIDRow, error) {
@@ -191,6 +294,68 @@ func (q *Queries) GetLinearIssueByID(ctx context.Context, arg GetLinearIssueByID
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ArchivedAt,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const getLinearOrganization = `-- name: GetLinearOrganization :one
+SELECT id, name, url_key, created_at
+FROM linear_organizations
+WHERE session_id = ?
+ORDER BY created_at ASC
+LIMIT 1
+`
+
+type GetLinearOrganizationRow struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	UrlKey    string `json:"url_key"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func (q *Queries) GetLinearOrganization(ctx context.Context, sessionID string) (GetLinearOrganizationRow, error) {
+	row := q.db.QueryRowContext(ctx, getLinearOrganization, sessionID)
+	var i GetLinearOrganizationRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UrlKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLinearProjectByID = `-- name: GetLinearProjectByID :one
+SELECT id, name, description, team_ids, created_at, updated_at
+FROM linear_projects
+WHERE id = ? AND session_id = ?
+`
+
+type GetLinearProjectByIDParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+type GetLinearProjectByIDRow struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	TeamIds     string         `json:"team_ids"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+func (q *Queries) GetLinearProjectByID(ctx context.Context, arg GetLinearProjectByIDParams) (GetLinearProjectByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getLinearProjectByID, arg.ID, arg.SessionID)
+	var i GetLinearProjectByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.TeamIds,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -287,8 +452,35 @@ func (q *Queries) GetLinearUserByID(ctx context.Context, arg GetLinearUserByIDPa
 	return i, err
 }
 
+const getLinearViewer = `-- name: GetLinearViewer :one
+SELECT id, name, email, created_at
+FROM linear_users
+WHERE session_id = ?
+ORDER BY is_viewer DESC, created_at ASC
+LIMIT 1
+`
+
+type GetLinearViewerRow struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func (q *Queries) GetLinearViewer(ctx context.Context, sessionID string) (GetLinearViewerRow, error) {
+	row := q.db.QueryRowContext(ctx, getLinearViewer, sessionID)
+	var i GetLinearViewerRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const listLinearIssues = `-- name: ListLinearIssues :many
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE session_id = ?
 ORDER BY created_at DESC
@@ -305,6 +497,7 @@ type ListLinearIssuesRow struct {
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ArchivedAt  sql.NullInt64  `json:"archived_at"`
+	ProjectID   sql.NullString `json:"project_id"`
 }
 
 func (q *Queries) ListLinearIssues(ctx context.Context, sessionID string) ([]ListLinearIssuesRow, error) {
@@ -327,6 +520,7 @@ func (q *Queries) ListLinearIssues(ctx context.Context, sessionID string) ([]Lis
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ArchivedAt,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -342,7 +536,7 @@ func (q *Queries) ListLinearIssues(ctx context.Context, sessionID string) ([]Lis
 }
 
 const listLinearIssuesBySession = `-- name: ListLinearIssuesBySession :many
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE session_id = ?
 ORDER BY created_at DESC
@@ -359,6 +553,7 @@ type ListLinearIssuesBySessionRow struct {
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ArchivedAt  sql.NullInt64  `json:"archived_at"`
+	ProjectID   sql.NullString `json:"project_id"`
 }
 
 // UI data queries
@@ -382,6 +577,7 @@ func (q *Queries) ListLinearIssuesBySession(ctx context.Context, sessionID strin
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ArchivedAt,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -397,7 +593,7 @@ func (q *Queries) ListLinearIssuesBySession(ctx context.Context, sessionID strin
 }
 
 const listLinearIssuesByTeam = `-- name: ListLinearIssuesByTeam :many
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE team_id = ? AND session_id = ?
 ORDER BY created_at DESC
@@ -419,6 +615,7 @@ type ListLinearIssuesByTeamRow struct {
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ArchivedAt  sql.NullInt64  `json:"archived_at"`
+	ProjectID   sql.NullString `json:"project_id"`
 }
 
 func (q *Queries) ListLinearIssuesByTeam(ctx context.Context, arg ListLinearIssuesByTeamParams) ([]ListLinearIssuesByTeamRow, error) {
@@ -441,6 +638,53 @@ func (q *Queries) ListLinearIssuesByTeam(ctx context.Context, arg ListLinearIssu
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ArchivedAt,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinearProjects = `-- name: ListLinearProjects :many
+SELECT id, name, description, team_ids, created_at, updated_at
+FROM linear_projects
+WHERE session_id = ?
+ORDER BY created_at ASC
+`
+
+type ListLinearProjectsRow struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	TeamIds     string         `json:"team_ids"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+func (q *Queries) ListLinearProjects(ctx context.Context, sessionID string) ([]ListLinearProjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLinearProjects, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLinearProjectsRow
+	for rows.Next() {
+		var i ListLinearProjectsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.TeamIds,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -588,12 +832,29 @@ func (q *Queries) ListLinearUsers(ctx context.Context, sessionID string) ([]List
 	return items, nil
 }
 
+const setLinearViewer = `-- name: SetLinearViewer :exec
+UPDATE linear_users
+SET is_viewer = CASE WHEN id = ? THEN 1 ELSE 0 END
+WHERE session_id = ?
+`
+
+type SetLinearViewerParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) SetLinearViewer(ctx context.Context, arg SetLinearViewerParams) error {
+	_, err := q.db.ExecContext(ctx, setLinearViewer, arg.ID, arg.SessionID)
+	return err
+}
+
 const updateLinearIssue = `-- name: UpdateLinearIssue :exec
 UPDATE linear_issues
 SET title = COALESCE(?, title),
     description = COALESCE(?, description),
     assignee_id = COALESCE(?, assignee_id),
     state_id = COALESCE(?, state_id),
+    project_id = COALESCE(?, project_id),
     updated_at = ?
 WHERE id = ? AND session_id = ?
 `
@@ -603,6 +864,7 @@ type UpdateLinearIssueParams struct {
 	Description sql.NullString `json:"description"`
 	AssigneeID  sql.NullString `json:"assignee_id"`
 	StateID     sql.NullString `json:"state_id"`
+	ProjectID   sql.NullString `json:"project_id"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ID          string         `json:"id"`
 	SessionID   string         `json:"session_id"`
@@ -614,6 +876,7 @@ func (q *Queries) UpdateLinearIssue(ctx context.Context, arg UpdateLinearIssuePa
 		arg.Description,
 		arg.AssigneeID,
 		arg.StateID,
+		arg.ProjectID,
 		arg.UpdatedAt,
 		arg.ID,
 		arg.SessionID,
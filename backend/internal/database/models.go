@@ -8,6 +8,12 @@ import (
 	"database/sql"
 )
 
+type ConfigProfile struct {
+	Name      string `json:"name"`
+	Overrides string `json:"overrides"`
+	SavedAt   int64  `json:"saved_at"`
+}
+
 type DatadogEvent struct {
 	ID        int64          `json:"id"`
 	Title     string         `json:"title"`
@@ -25,6 +31,39 @@ type DatadogIncident struct {
 	SessionID        string         `json:"session_id"`
 	CreatedAt        int64          `json:"created_at"`
 	UpdatedAt        int64          `json:"updated_at"`
+	PublicID         int64          `json:"public_id"`
+}
+
+type DatadogIncidentAttachment struct {
+	ID             string `json:"id"`
+	IncidentID     string `json:"incident_id"`
+	AttachmentType string `json:"attachment_type"`
+	DocumentUrl    string `json:"document_url"`
+	Title          string `json:"title"`
+	SessionID      string `json:"session_id"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+type DatadogIncidentTodo struct {
+	ID         string         `json:"id"`
+	IncidentID string         `json:"incident_id"`
+	Content    string         `json:"content"`
+	Assignees  string         `json:"assignees"`
+	DueDate    sql.NullString `json:"due_date"`
+	Completed  sql.NullString `json:"completed"`
+	SessionID  string         `json:"session_id"`
+	CreatedAt  int64          `json:"created_at"`
+	UpdatedAt  int64          `json:"updated_at"`
+}
+
+type DatadogMetricMetadatum struct {
+	MetricName  string         `json:"metric_name"`
+	Type        sql.NullString `json:"type"`
+	Description sql.NullString `json:"description"`
+	Unit        sql.NullString `json:"unit"`
+	SessionID   string         `json:"session_id"`
+	UpdatedAt   int64          `json:"updated_at"`
 }
 
 type DatadogMetric struct {
@@ -46,6 +85,7 @@ type DatadogMonitor struct {
 	SessionID string         `json:"session_id"`
 	CreatedAt int64          `json:"created_at"`
 	UpdatedAt int64          `json:"updated_at"`
+	Tags      sql.NullString `json:"tags"`
 }
 
 type GdocsContent struct {
@@ -76,6 +116,51 @@ type GithubBranch struct {
 	CreatedAt int64  `json:"created_at"`
 }
 
+type GithubBranchProtection struct {
+	RepoOwner            string         `json:"repo_owner"`
+	RepoName             string         `json:"repo_name"`
+	Branch               string         `json:"branch"`
+	RequiredStatusChecks sql.NullString `json:"required_status_checks"`
+	RequiredReviews      sql.NullString `json:"required_reviews"`
+	EnforceAdmins        int64          `json:"enforce_admins"`
+	SessionID            string         `json:"session_id"`
+	UpdatedAt            int64          `json:"updated_at"`
+}
+
+type GithubCheckRun struct {
+	ID            int64          `json:"id"`
+	RepoOwner     string         `json:"repo_owner"`
+	RepoName      string         `json:"repo_name"`
+	CheckRunID    int64          `json:"check_run_id"`
+	Name          string         `json:"name"`
+	HeadSha       string         `json:"head_sha"`
+	Status        string         `json:"status"`
+	Conclusion    sql.NullString `json:"conclusion"`
+	ExternalID    sql.NullString `json:"external_id"`
+	DetailsUrl    sql.NullString `json:"details_url"`
+	OutputTitle   sql.NullString `json:"output_title"`
+	OutputSummary sql.NullString `json:"output_summary"`
+	StartedAt     sql.NullInt64  `json:"started_at"`
+	CompletedAt   sql.NullInt64  `json:"completed_at"`
+	SessionID     string         `json:"session_id"`
+	CreatedAt     int64          `json:"created_at"`
+	UpdatedAt     int64          `json:"updated_at"`
+}
+
+type GithubCommit struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	Sha         string `json:"sha"`
+	TreeSha     string `json:"tree_sha"`
+	ParentSha   string `json:"parent_sha"`
+	Message     string `json:"message"`
+	AuthorName  string `json:"author_name"`
+	AuthorEmail string `json:"author_email"`
+	SessionID   string `json:"session_id"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
 type GithubFile struct {
 	ID        int64  `json:"id"`
 	RepoOwner string `json:"repo_owner"`
@@ -88,17 +173,45 @@ type GithubFile struct {
 	UpdatedAt int64  `json:"updated_at"`
 }
 
+type GithubGist struct {
+	ID          string         `json:"id"`
+	Description sql.NullString `json:"description"`
+	Public      int64          `json:"public"`
+	Files       string         `json:"files"`
+	OwnerLogin  string         `json:"owner_login"`
+	SessionID   string         `json:"session_id"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+}
+
+type GithubHook struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	Url         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Secret      string `json:"secret"`
+	Events      string `json:"events"`
+	Active      int64  `json:"active"`
+	SessionID   string `json:"session_id"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
 type GithubIssue struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	State     string         `json:"state"`
-	SessionID string         `json:"session_id"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID          int64          `json:"id"`
+	RepoOwner   string         `json:"repo_owner"`
+	RepoName    string         `json:"repo_name"`
+	Number      int64          `json:"number"`
+	Title       string         `json:"title"`
+	Body        sql.NullString `json:"body"`
+	State       string         `json:"state"`
+	SessionID   string         `json:"session_id"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	StateReason sql.NullString `json:"state_reason"`
+	Assignees   string         `json:"assignees"`
+	PullRequest int64          `json:"pull_request"`
 }
 
 type GithubIssueComment struct {
@@ -112,20 +225,46 @@ type GithubIssueComment struct {
 	CreatedAt   int64  `json:"created_at"`
 }
 
+type GithubIssueLabel struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	IssueNumber int64  `json:"issue_number"`
+	Name        string `json:"name"`
+	SessionID   string `json:"session_id"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
 type GithubPullRequest struct {
-	ID        int64          `json:"id"`
-	RepoOwner string         `json:"repo_owner"`
-	RepoName  string         `json:"repo_name"`
-	Number    int64          `json:"number"`
-	Title     string         `json:"title"`
-	Body      sql.NullString `json:"body"`
-	Head      string         `json:"head"`
-	Base      string         `json:"base"`
-	State     string         `json:"state"`
-	Merged    int64          `json:"merged"`
-	SessionID string         `json:"session_id"`
-	CreatedAt int64          `json:"created_at"`
-	UpdatedAt int64          `json:"updated_at"`
+	ID                  int64          `json:"id"`
+	RepoOwner           string         `json:"repo_owner"`
+	RepoName            string         `json:"repo_name"`
+	Number              int64          `json:"number"`
+	Title               string         `json:"title"`
+	Body                sql.NullString `json:"body"`
+	Head                string         `json:"head"`
+	Base                string         `json:"base"`
+	State               string         `json:"state"`
+	Merged              int64          `json:"merged"`
+	SessionID           string         `json:"session_id"`
+	CreatedAt           int64          `json:"created_at"`
+	UpdatedAt           int64          `json:"updated_at"`
+	MergedAt            sql.NullInt64  `json:"merged_at"`
+	Draft               int64          `json:"draft"`
+	MaintainerCanModify int64          `json:"maintainer_can_modify"`
+	Assignees           string         `json:"assignees"`
+}
+
+type GithubReaction struct {
+	ID          int64  `json:"id"`
+	RepoOwner   string `json:"repo_owner"`
+	RepoName    string `json:"repo_name"`
+	SubjectType string `json:"subject_type"`
+	SubjectID   int64  `json:"subject_id"`
+	UserLogin   string `json:"user_login"`
+	Content     string `json:"content"`
+	SessionID   string `json:"session_id"`
+	CreatedAt   int64  `json:"created_at"`
 }
 
 type GithubRepository struct {
@@ -138,6 +277,28 @@ type GithubRepository struct {
 	CreatedAt     int64          `json:"created_at"`
 }
 
+type GithubTreeEntry struct {
+	ID        int64  `json:"id"`
+	RepoOwner string `json:"repo_owner"`
+	RepoName  string `json:"repo_name"`
+	CommitSha string `json:"commit_sha"`
+	Path      string `json:"path"`
+	Sha       string `json:"sha"`
+	Size      int64  `json:"size"`
+	SessionID string `json:"session_id"`
+}
+
+type GithubUser struct {
+	ID            int64  `json:"id"`
+	Login         string `json:"login"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Authenticated int64  `json:"authenticated"`
+	SessionID     string `json:"session_id"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
 type GithubWorkflow struct {
 	ID         int64  `json:"id"`
 	RepoOwner  string `json:"repo_owner"`
@@ -191,6 +352,32 @@ type GmailMessage struct {
 	SizeEstimate int64          `json:"size_estimate"`
 	CreatedAt    int64          `json:"created_at"`
 	SessionID    string         `json:"session_id"`
+	CcEmail      string         `json:"cc_email"`
+	ToAddresses  string         `json:"to_addresses"`
+	CcAddresses  string         `json:"cc_addresses"`
+	UserID       string         `json:"user_id"`
+}
+
+type GmailSendAsAlias struct {
+	SessionID      string `json:"session_id"`
+	SendAsEmail    string `json:"send_as_email"`
+	DisplayName    string `json:"display_name"`
+	ReplyToAddress string `json:"reply_to_address"`
+	Signature      string `json:"signature"`
+	IsPrimary      int64  `json:"is_primary"`
+	IsDefault      int64  `json:"is_default"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type GmailWatch struct {
+	SessionID           string        `json:"session_id"`
+	TopicName           string        `json:"topic_name"`
+	LabelIds            string        `json:"label_ids"`
+	LabelFilterBehavior string        `json:"label_filter_behavior"`
+	HistoryID           int64         `json:"history_id"`
+	Expiration          int64         `json:"expiration"`
+	StoppedAt           sql.NullInt64 `json:"stopped_at"`
+	UpdatedAt           int64         `json:"updated_at"`
 }
 
 type GsheetsCell struct {
@@ -201,6 +388,30 @@ type GsheetsCell struct {
 	Value         sql.NullString `json:"value"`
 	SessionID     string         `json:"session_id"`
 	UpdatedAt     int64          `json:"updated_at"`
+	ValueType     string         `json:"value_type"`
+}
+
+type GsheetsDeveloperMetadatum struct {
+	MetadataID    int64          `json:"metadata_id"`
+	SpreadsheetID string         `json:"spreadsheet_id"`
+	MetadataKey   string         `json:"metadata_key"`
+	MetadataValue sql.NullString `json:"metadata_value"`
+	Location      string         `json:"location"`
+	Visibility    string         `json:"visibility"`
+	SessionID     string         `json:"session_id"`
+	CreatedAt     int64          `json:"created_at"`
+}
+
+type GsheetsMerge struct {
+	ID               int64  `json:"id"`
+	SpreadsheetID    string `json:"spreadsheet_id"`
+	SheetID          int64  `json:"sheet_id"`
+	StartRowIndex    int64  `json:"start_row_index"`
+	EndRowIndex      int64  `json:"end_row_index"`
+	StartColumnIndex int64  `json:"start_column_index"`
+	EndColumnIndex   int64  `json:"end_column_index"`
+	SessionID        string `json:"session_id"`
+	CreatedAt        int64  `json:"created_at"`
 }
 
 type GsheetsSheet struct {
@@ -264,6 +475,38 @@ type HubspotDeal struct {
 	UpdatedAt int64          `json:"updated_at"`
 }
 
+type HubspotObjectProperty struct {
+	SessionID  string `json:"session_id"`
+	ObjectType string `json:"object_type"`
+	ObjectID   string `json:"object_id"`
+	Properties string `json:"properties"`
+}
+
+type HubspotPropertyDefinition struct {
+	SessionID   string `json:"session_id"`
+	ObjectType  string `json:"object_type"`
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	FieldType   string `json:"field_type"`
+	GroupName   string `json:"group_name"`
+	Description string `json:"description"`
+	Options     string `json:"options"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
+
+type IdempotencyKey struct {
+	SessionID      string `json:"session_id"`
+	Method         string `json:"method"`
+	Path           string `json:"path"`
+	IdempotencyKey string `json:"idempotency_key"`
+	StatusCode     int64  `json:"status_code"`
+	ContentType    string `json:"content_type"`
+	Body           []byte `json:"body"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
 type JiraComment struct {
 	ID        string `json:"id"`
 	IssueKey  string `json:"issue_key"`
@@ -286,6 +529,12 @@ type JiraIssue struct {
 	UpdatedAt   int64          `json:"updated_at"`
 }
 
+type JiraIssueCustomField struct {
+	SessionID string `json:"session_id"`
+	IssueKey  string `json:"issue_key"`
+	Fields    string `json:"fields"`
+}
+
 type JiraProject struct {
 	ID        string `json:"id"`
 	Key       string `json:"key"`
@@ -314,6 +563,25 @@ type LinearIssue struct {
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 	ArchivedAt  sql.NullInt64  `json:"archived_at"`
+	ProjectID   sql.NullString `json:"project_id"`
+}
+
+type LinearOrganization struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	UrlKey    string `json:"url_key"`
+	SessionID string `json:"session_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type LinearProject struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	TeamIds     string         `json:"team_ids"`
+	SessionID   string         `json:"session_id"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
 }
 
 type LinearState struct {
@@ -339,6 +607,7 @@ type LinearUser struct {
 	Email     string `json:"email"`
 	SessionID string `json:"session_id"`
 	CreatedAt int64  `json:"created_at"`
+	IsViewer  int64  `json:"is_viewer"`
 }
 
 type OutlookMessage struct {
@@ -352,6 +621,9 @@ type OutlookMessage struct {
 	ReceivedDatetime string         `json:"received_datetime"`
 	SessionID        string         `json:"session_id"`
 	CreatedAt        int64          `json:"created_at"`
+	ConversationID   string         `json:"conversation_id"`
+	ChangeSeq        int64          `json:"change_seq"`
+	CcEmail          string         `json:"cc_email"`
 }
 
 type PagerdutyEscalationPolicy struct {
@@ -371,6 +643,7 @@ type PagerdutyIncident struct {
 	SessionID   string         `json:"session_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
+	PriorityID  sql.NullString `json:"priority_id"`
 }
 
 type PagerdutyOncall struct {
@@ -381,6 +654,15 @@ type PagerdutyOncall struct {
 	CreatedAt          int64  `json:"created_at"`
 }
 
+type PagerdutyPriority struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OrderIndex  int64  `json:"order_index"`
+	SessionID   string `json:"session_id"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
 type PagerdutyService struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
@@ -435,6 +717,21 @@ type PostgresTable struct {
 	CreatedAt    int64  `json:"created_at"`
 }
 
+type RequestLog struct {
+	ID           int64  `json:"id"`
+	SessionID    string `json:"session_id"`
+	Simulator    string `json:"simulator"`
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	RawQuery     string `json:"raw_query"`
+	Headers      string `json:"headers"`
+	RequestBody  []byte `json:"request_body"`
+	StatusCode   int64  `json:"status_code"`
+	ResponseBody []byte `json:"response_body"`
+	CreatedAt    int64  `json:"created_at"`
+	DurationUs   int64  `json:"duration_us"`
+}
+
 type ResendEmail struct {
 	ID        string         `json:"id"`
 	FromEmail string         `json:"from_email"`
@@ -448,6 +745,18 @@ type ResendEmail struct {
 	CreatedAt int64          `json:"created_at"`
 }
 
+type ResponseOverride struct {
+	ID          int64  `json:"id"`
+	SessionID   string `json:"session_id"`
+	Simulator   string `json:"simulator"`
+	Method      string `json:"method"`
+	PathPattern string `json:"path_pattern"`
+	StatusCode  int64  `json:"status_code"`
+	Body        string `json:"body"`
+	ContentType string `json:"content_type"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
 type Session struct {
 	ID           string `json:"id"`
 	CreatedAt    int64  `json:"created_at"`
@@ -455,21 +764,68 @@ type Session struct {
 }
 
 type SessionConfig struct {
-	SessionID          string `json:"session_id"`
-	SimulatorName      string `json:"simulator_name"`
-	TimeoutMinMs       int64  `json:"timeout_min_ms"`
-	TimeoutMaxMs       int64  `json:"timeout_max_ms"`
-	RateLimitPerMinute int64  `json:"rate_limit_per_minute"`
-	RateLimitPerDay    int64  `json:"rate_limit_per_day"`
-	CreatedAt          int64  `json:"created_at"`
-	UpdatedAt          int64  `json:"updated_at"`
+	SessionID                   string         `json:"session_id"`
+	SimulatorName               string         `json:"simulator_name"`
+	TimeoutMinMs                int64          `json:"timeout_min_ms"`
+	TimeoutMaxMs                int64          `json:"timeout_max_ms"`
+	RateLimitPerMinute          int64          `json:"rate_limit_per_minute"`
+	RateLimitPerDay             int64          `json:"rate_limit_per_day"`
+	CreatedAt                   int64          `json:"created_at"`
+	UpdatedAt                   int64          `json:"updated_at"`
+	ValidationEnabled           int64          `json:"validation_enabled"`
+	FaultTruncateRate           float64        `json:"fault_truncate_rate"`
+	FaultSignatureSkewSeconds   int64          `json:"fault_signature_skew_seconds"`
+	ValidationStrictContentType int64          `json:"validation_strict_content_type"`
+	ResponseHeaders             sql.NullString `json:"response_headers"`
+}
+
+type SessionParent struct {
+	SessionID       string `json:"session_id"`
+	ParentSessionID string `json:"parent_session_id"`
+	CreatedAt       int64  `json:"created_at"`
 }
 
-type SlackChannel struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
+type SessionSeed struct {
+	SessionID string `json:"session_id"`
+	Seed      int64  `json:"seed"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type SessionTombstone struct {
+	SessionID string `json:"session_id"`
+	Kind      string `json:"kind"`
+	ObjectKey string `json:"object_key"`
 	CreatedAt int64  `json:"created_at"`
+}
+
+type SlackChannel struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	CreatedAt      int64  `json:"created_at"`
+	SessionID      string `json:"session_id"`
+	Topic          string `json:"topic"`
+	TopicCreator   string `json:"topic_creator"`
+	TopicLastSet   int64  `json:"topic_last_set"`
+	Purpose        string `json:"purpose"`
+	PurposeCreator string `json:"purpose_creator"`
+	PurposeLastSet int64  `json:"purpose_last_set"`
+}
+
+type SlackChannelMember struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
 	SessionID string `json:"session_id"`
+	JoinedAt  int64  `json:"joined_at"`
+}
+
+type SlackEphemeralMessage struct {
+	ID        int64  `json:"id"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	Timestamp string `json:"timestamp"`
+	SessionID string `json:"session_id"`
+	CreatedAt int64  `json:"created_at"`
 }
 
 type SlackFile struct {
@@ -495,6 +851,34 @@ type SlackMessage struct {
 	CreatedAt   int64          `json:"created_at"`
 	Attachments sql.NullString `json:"attachments"`
 	SessionID   string         `json:"session_id"`
+	Blocks      sql.NullString `json:"blocks"`
+	ThreadTs    sql.NullString `json:"thread_ts"`
+}
+
+type SlackReaction struct {
+	ID        int64  `json:"id"`
+	ChannelID string `json:"channel_id"`
+	MessageTs string `json:"message_ts"`
+	Name      string `json:"name"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type SlackResponseWarning struct {
+	SessionID string `json:"session_id"`
+	Warnings  string `json:"warnings"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+type SlackScheduledMessage struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	PostAt    int64  `json:"post_at"`
+	SessionID string `json:"session_id"`
+	CreatedAt int64  `json:"created_at"`
 }
 
 type SlackUser struct {
@@ -522,6 +906,29 @@ type SlackUser struct {
 	SessionID      string         `json:"session_id"`
 }
 
+type WebhookDelivery struct {
+	ID         int64  `json:"id"`
+	DeliveryID string `json:"delivery_id"`
+	SessionID  string `json:"session_id"`
+	Simulator  string `json:"simulator"`
+	Event      string `json:"event"`
+	Url        string `json:"url"`
+	Payload    []byte `json:"payload"`
+	Attempt    int64  `json:"attempt"`
+	StatusCode int64  `json:"status_code"`
+	Error      string `json:"error"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+type WebhookReceiver struct {
+	ID        int64  `json:"id"`
+	SessionID string `json:"session_id"`
+	Simulator string `json:"simulator"`
+	Url       string `json:"url"`
+	Secret    string `json:"secret"`
+	CreatedAt int64  `json:"created_at"`
+}
+
 type WhatsappMessage struct {
 	ID            string         `json:"id"`
 	PhoneNumberID string         `json:"phone_number_id"`
@@ -10,9 +10,25 @@ import (
 	"database/sql"
 )
 
+const countOutlookSessionObjects = `-- name: CountOutlookSessionObjects :one
+SELECT COUNT(*) FROM outlook_messages WHERE session_id = ?
+`
+
+func (q *Queries) CountOutlookSessionObjects(ctx context.Context, sessionID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOutlookSessionObjects, sessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createOutlookMessage = `-- name: CreateOutlookMessage :exec
-INSERT INTO outlook_messages (id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, session_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO outlook_messages (id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, session_id, change_seq, cc_email)
+VALUES (
+    ?1, ?2, ?3, ?4, ?5, ?6,
+    ?7, ?8, ?9, ?10,
+    (SELECT COALESCE(MAX(change_seq), 0) + 1 FROM outlook_messages WHERE session_id = ?10),
+    ?11
+)
 `
 
 type CreateOutlookMessageParams struct {
@@ -24,7 +40,9 @@ type CreateOutlookMessageParams struct {
 	BodyType         string         `json:"body_type"`
 	IsRead           int64          `json:"is_read"`
 	ReceivedDatetime string         `json:"received_datetime"`
+	ConversationID   string         `json:"conversation_id"`
 	SessionID        string         `json:"session_id"`
+	CcEmail          string         `json:"cc_email"`
 }
 
 func (q *Queries) CreateOutlookMessage(ctx context.Context, arg CreateOutlookMessageParams) error {
@@ -37,7 +55,9 @@ func (q *Queries) CreateOutlookMessage(ctx context.Context, arg CreateOutlookMes
 		arg.BodyType,
 		arg.IsRead,
 		arg.ReceivedDatetime,
+		arg.ConversationID,
 		arg.SessionID,
+		arg.CcEmail,
 	)
 	return err
 }
@@ -52,7 +72,7 @@ func (q *Queries) DeleteOutlookSessionData(ctx context.Context, sessionID string
 }
 
 const getOutlookMessageByID = `-- name: GetOutlookMessageByID :one
-SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, created_at
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, created_at, cc_email
 FROM outlook_messages
 WHERE id = ? AND session_id = ?
 `
@@ -71,7 +91,9 @@ type GetOutlookMessageByIDRow struct {
 	BodyType         string         `json:"body_type"`
 	IsRead           int64          `json:"is_read"`
 	ReceivedDatetime string         `json:"received_datetime"`
+	ConversationID   string         `json:"conversation_id"`
 	CreatedAt        int64          `json:"created_at"`
+	CcEmail          string         `json:"cc_email"`
 }
 
 func (q *Queries) GetOutlookMessageByID(ctx context.Context, arg GetOutlookMessageByIDParams) (GetOutlookMessageByIDRow, error) {
@@ -86,13 +108,79 @@ func (q *Queries) GetOutlookMessageByID(ctx context.Context, arg GetOutlookMessa
 		&i.BodyType,
 		&i.IsRead,
 		&i.ReceivedDatetime,
+		&i.ConversationID,
 		&i.CreatedAt,
+		&i.CcEmail,
 	)
 	return i, err
 }
 
+const listOutlookMessageChanges = `-- name: ListOutlookMessageChanges :many
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, change_seq, cc_email
+FROM outlook_messages
+WHERE session_id = ? AND change_seq > ?
+ORDER BY change_seq
+LIMIT ?
+`
+
+type ListOutlookMessageChangesParams struct {
+	SessionID string `json:"session_id"`
+	ChangeSeq int64  `json:"change_seq"`
+	Limit     int64  `json:"limit"`
+}
+
+type ListOutlookMessageChangesRow struct {
+	ID               string         `json:"id"`
+	FromEmail        string         `json:"from_email"`
+	ToEmail          string         `json:"to_email"`
+	Subject          string         `json:"subject"`
+	BodyContent      sql.NullString `json:"body_content"`
+	BodyType         string         `json:"body_type"`
+	IsRead           int64          `json:"is_read"`
+	ReceivedDatetime string         `json:"received_datetime"`
+	ConversationID   string         `json:"conversation_id"`
+	ChangeSeq        int64          `json:"change_seq"`
+	CcEmail          string         `json:"cc_email"`
+}
+
+// Messages written after a change sequence number, oldest change first
+func (q *Queries) ListOutlookMessageChanges(ctx context.Context, arg ListOutlookMessageChangesParams) ([]ListOutlookMessageChangesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOutlookMessageChanges, arg.SessionID, arg.ChangeSeq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOutlookMessageChangesRow{}
+	for rows.Next() {
+		var i ListOutlookMessageChangesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromEmail,
+			&i.ToEmail,
+			&i.Subject,
+			&i.BodyContent,
+			&i.BodyType,
+			&i.IsRead,
+			&i.ReceivedDatetime,
+			&i.ConversationID,
+			&i.ChangeSeq,
+			&i.CcEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOutlookMessages = `-- name: ListOutlookMessages :many
-SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, cc_email
 FROM outlook_messages
 WHERE session_id = ?
 ORDER BY received_datetime DESC
@@ -113,6 +201,8 @@ type ListOutlookMessagesRow struct {
 	BodyType         string         `json:"body_type"`
 	IsRead           int64          `json:"is_read"`
 	ReceivedDatetime string         `json:"received_datetime"`
+	ConversationID   string         `json:"conversation_id"`
+	CcEmail          string         `json:"cc_email"`
 }
 
 func (q *Queries) ListOutlookMessages(ctx context.Context, arg ListOutlookMessagesParams) ([]ListOutlookMessagesRow, error) {
@@ -133,6 +223,8 @@ func (q *Queries) ListOutlookMessages(ctx context.Context, arg ListOutlookMessag
 			&i.BodyType,
 			&i.IsRead,
 			&i.ReceivedDatetime,
+			&i.ConversationID,
+			&i.CcEmail,
 		); err != nil {
 			return nil, err
 		}
@@ -201,7 +293,7 @@ func (q *Queries) ListOutlookMessagesBySession(ctx context.Context, sessionID st
 }
 
 const searchOutlookMessages = `-- name: SearchOutlookMessages :many
-SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, cc_email
 FROM outlook_messages
 WHERE
     session_id = ?
@@ -235,6 +327,8 @@ type SearchOutlookMessagesRow struct {
 	BodyType         string         `json:"body_type"`
 	IsRead           int64          `json:"is_read"`
 	ReceivedDatetime string         `json:"received_datetime"`
+	ConversationID   string         `json:"conversation_id"`
+	CcEmail          string         `json:"cc_email"`
 }
 
 func (q *Queries) SearchOutlookMessages(ctx context.Context, arg SearchOutlookMessagesParams) ([]SearchOutlookMessagesRow, error) {
@@ -266,6 +360,8 @@ func (q *Queries) SearchOutlookMessages(ctx context.Context, arg SearchOutlookMe
 			&i.BodyType,
 			&i.IsRead,
 			&i.ReceivedDatetime,
+			&i.ConversationID,
+			&i.CcEmail,
 		); err != nil {
 			return nil, err
 		}
@@ -282,17 +378,18 @@ func (q *Queries) SearchOutlookMessages(ctx context.Context, arg SearchOutlookMe
 
 const updateOutlookMessageReadStatus = `-- name: UpdateOutlookMessageReadStatus :exec
 UPDATE outlook_messages
-SET is_read = ?
-WHERE id = ? AND session_id = ?
+SET is_read = ?1,
+    change_seq = (SELECT COALESCE(MAX(change_seq), 0) + 1 FROM outlook_messages WHERE session_id = ?2)
+WHERE id = ?3 AND session_id = ?2
 `
 
 type UpdateOutlookMessageReadStatusParams struct {
 	IsRead    int64  `json:"is_read"`
-	ID        string `json:"id"`
 	SessionID string `json:"session_id"`
+	ID        string `json:"id"`
 }
 
 func (q *Queries) UpdateOutlookMessageReadStatus(ctx context.Context, arg UpdateOutlookMessageReadStatusParams) error {
-	_, err := q.db.ExecContext(ctx, updateOutlookMessageReadStatus, arg.IsRead, arg.ID, arg.SessionID)
+	_, err := q.db.ExecContext(ctx, updateOutlookMessageReadStatus, arg.IsRead, arg.SessionID, arg.ID)
 	return err
 }
@@ -10,6 +10,30 @@ import (
 	"database/sql"
 )
 
+const countPagerDutySessionObjects = `-- name: CountPagerDutySessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM pagerduty_services WHERE session_id = ?1) AS services,
+    (SELECT COUNT(*) FROM pagerduty_incidents WHERE session_id = ?1) AS incidents,
+    (SELECT COUNT(*) FROM pagerduty_escalation_policies WHERE session_id = ?1) AS escalation_policies
+`
+
+type CountPagerDutySessionObjectsRow struct {
+	Services           int64 `json:"services"`
+	Incidents          int64 `json:"incidents"`
+	EscalationPolicies int64 `json:"escalation_policies"`
+}
+
+func (q *Queries) CountPagerDutySessionObjects(ctx context.Context, sessionID string) (CountPagerDutySessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countPagerDutySessionObjects, sessionID)
+	var i CountPagerDutySessionObjectsRow
+	err := row.Scan(
+		&i.Services,
+		&i.Incidents,
+		&i.EscalationPolicies,
+	)
+	return i, err
+}
+
 const createPagerDutyEscalationPolicy = `-- name: CreatePagerDutyEscalationPolicy :exec
 INSERT INTO pagerduty_escalation_policies (id, name, session_id)
 VALUES (?, ?, ?)
@@ -28,9 +52,9 @@ func (q *Queries) CreatePagerDutyEscalationPolicy(ctx context.Context, arg Creat
 }
 
 const createPagerDutyIncident = `-- name: CreatePagerDutyIncident :one
-INSERT INTO pagerduty_incidents (id, title, service_id, urgency, status, body_details, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, title, service_id, urgency, status, body_details, created_at, updated_at
+INSERT INTO pagerduty_incidents (id, title, service_id, urgency, status, body_details, priority_id, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 `
 
 type CreatePagerDutyIncidentParams struct {
@@ -40,6 +64,7 @@ type CreatePagerDutyIncidentParams struct {
 	Urgency     string         `json:"urgency"`
 	Status      string         `json:"status"`
 	BodyDetails sql.NullString `json:"body_details"`
+	PriorityID  sql.NullString `json:"priority_id"`
 	SessionID   string         `json:"session_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
@@ -52,6 +77,7 @@ type CreatePagerDutyIncidentRow struct {
 	Urgency     string         `json:"urgency"`
 	Status      string         `json:"status"`
 	BodyDetails sql.NullString `json:"body_details"`
+	PriorityID  sql.NullString `json:"priority_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 }
@@ -65,6 +91,7 @@ func (q *Queries) CreatePagerDutyIncident(ctx context.Context, arg CreatePagerDu
 		arg.Urgency,
 		arg.Status,
 		arg.BodyDetails,
+		arg.PriorityID,
 		arg.SessionID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -77,6 +104,7 @@ func (q *Queries) CreatePagerDutyIncident(ctx context.Context, arg CreatePagerDu
 		&i.Urgency,
 		&i.Status,
 		&i.BodyDetails,
+		&i.PriorityID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -106,6 +134,31 @@ func (q *Queries) CreatePagerDutyOnCall(ctx context.Context, arg CreatePagerDuty
 	return err
 }
 
+const createPagerDutyPriority = `-- name: CreatePagerDutyPriority :exec
+INSERT OR IGNORE INTO pagerduty_priorities (id, name, description, order_index, session_id)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreatePagerDutyPriorityParams struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OrderIndex  int64  `json:"order_index"`
+	SessionID   string `json:"session_id"`
+}
+
+// Priorities queries
+func (q *Queries) CreatePagerDutyPriority(ctx context.Context, arg CreatePagerDutyPriorityParams) error {
+	_, err := q.db.ExecContext(ctx, createPagerDutyPriority,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.OrderIndex,
+		arg.SessionID,
+	)
+	return err
+}
+
 const createPagerDutyService = `-- name: CreatePagerDutyService :exec
 INSERT INTO pagerduty_services (id, name, session_id)
 VALUES (?, ?, ?)
@@ -158,7 +211,7 @@ func (q *Queries) GetPagerDutyEscalationPolicyByID(ctx context.Context, arg GetP
 }
 
 const getPagerDutyIncidentByID = `-- name: GetPagerDutyIncidentByID :one
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE id = ? AND session_id = ?
 `
@@ -175,6 +228,7 @@ type GetPagerDutyIncidentByIDRow struct {
 	Urgency     string         `json:"urgency"`
 	Status      string         `json:"status"`
 	BodyDetails sql.NullString `json:"body_details"`
+	PriorityID  sql.NullString `json:"priority_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 }
@@ -189,6 +243,7 @@ func (q *Queries) GetPagerDutyIncidentByID(ctx context.Context, arg GetPagerDuty
 		&i.Urgency,
 		&i.Status,
 		&i.BodyDetails,
+		&i.PriorityID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -225,6 +280,38 @@ func (q *Queries) GetPagerDutyOnCallByID(ctx context.Context, arg GetPagerDutyOn
 	return i, err
 }
 
+const getPagerDutyPriorityByID = `-- name: GetPagerDutyPriorityByID :one
+SELECT id, name, description, order_index, created_at
+FROM pagerduty_priorities
+WHERE id = ? AND session_id = ?
+`
+
+type GetPagerDutyPriorityByIDParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+type GetPagerDutyPriorityByIDRow struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OrderIndex  int64  `json:"order_index"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (q *Queries) GetPagerDutyPriorityByID(ctx context.Context, arg GetPagerDutyPriorityByIDParams) (GetPagerDutyPriorityByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getPagerDutyPriorityByID, arg.ID, arg.SessionID)
+	var i GetPagerDutyPriorityByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.OrderIndex,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getPagerDutyServiceByID = `-- name: GetPagerDutyServiceByID :one
 SELECT id, name, created_at
 FROM pagerduty_services
@@ -286,7 +373,7 @@ func (q *Queries) ListPagerDutyEscalationPolicies(ctx context.Context, sessionID
 }
 
 const listPagerDutyIncidents = `-- name: ListPagerDutyIncidents :many
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE session_id = ?
 ORDER BY created_at DESC
@@ -299,6 +386,7 @@ type ListPagerDutyIncidentsRow struct {
 	Urgency     string         `json:"urgency"`
 	Status      string         `json:"status"`
 	BodyDetails sql.NullString `json:"body_details"`
+	PriorityID  sql.NullString `json:"priority_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 }
@@ -319,6 +407,7 @@ func (q *Queries) ListPagerDutyIncidents(ctx context.Context, sessionID string)
 			&i.Urgency,
 			&i.Status,
 			&i.BodyDetails,
+			&i.PriorityID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -336,7 +425,7 @@ func (q *Queries) ListPagerDutyIncidents(ctx context.Context, sessionID string)
 }
 
 const listPagerDutyIncidentsByStatus = `-- name: ListPagerDutyIncidentsByStatus :many
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE status = ? AND session_id = ?
 ORDER BY created_at DESC
@@ -354,6 +443,7 @@ type ListPagerDutyIncidentsByStatusRow struct {
 	Urgency     string         `json:"urgency"`
 	Status      string         `json:"status"`
 	BodyDetails sql.NullString `json:"body_details"`
+	PriorityID  sql.NullString `json:"priority_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 }
@@ -374,6 +464,7 @@ func (q *Queries) ListPagerDutyIncidentsByStatus(ctx context.Context, arg ListPa
 			&i.Urgency,
 			&i.Status,
 			&i.BodyDetails,
+			&i.PriorityID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -432,6 +523,50 @@ func (q *Queries) ListPagerDutyOnCalls(ctx context.Context, sessionID string) ([
 	return items, nil
 }
 
+const listPagerDutyPriorities = `-- name: ListPagerDutyPriorities :many
+SELECT id, name, description, order_index, created_at
+FROM pagerduty_priorities
+WHERE session_id = ?
+ORDER BY order_index ASC
+`
+
+type ListPagerDutyPrioritiesRow struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OrderIndex  int64  `json:"order_index"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (q *Queries) ListPagerDutyPriorities(ctx context.Context, sessionID string) ([]ListPagerDutyPrioritiesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPagerDutyPriorities, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPagerDutyPrioritiesRow{}
+	for rows.Next() {
+		var i ListPagerDutyPrioritiesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.OrderIndex,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPagerDutyServices = `-- name: ListPagerDutyServices :many
 SELECT id, name, created_at
 FROM pagerduty_services
@@ -469,7 +604,7 @@ func (q *Queries) ListPagerDutyServices(ctx context.Context, sessionID string) (
 }
 
 const listPagerdutyIncidentsBySession = `-- name: ListPagerdutyIncidentsBySession :many
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE session_id = ?
 ORDER BY created_at DESC
@@ -482,6 +617,7 @@ type ListPagerdutyIncidentsBySessionRow struct {
 	Urgency     string         `json:"urgency"`
 	Status      string         `json:"status"`
 	BodyDetails sql.NullString `json:"body_details"`
+	PriorityID  sql.NullString `json:"priority_id"`
 	CreatedAt   int64          `json:"created_at"`
 	UpdatedAt   int64          `json:"updated_at"`
 }
@@ -503,6 +639,7 @@ func (q *Queries) ListPagerdutyIncidentsBySession(ctx context.Context, sessionID
 			&i.Urgency,
 			&i.Status,
 			&i.BodyDetails,
+			&i.PriorityID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -519,6 +656,30 @@ func (q *Queries) ListPagerdutyIncidentsBySession(ctx context.Context, sessionID
 	return items, nil
 }
 
+const updatePagerDutyIncidentPriority = `-- name: UpdatePagerDutyIncidentPriority :exec
+UPDATE pagerduty_incidents
+SET priority_id = ?,
+    updated_at = ?
+WHERE id = ? AND session_id = ?
+`
+
+type UpdatePagerDutyIncidentPriorityParams struct {
+	PriorityID sql.NullString `json:"priority_id"`
+	UpdatedAt  int64          `json:"updated_at"`
+	ID         string         `json:"id"`
+	SessionID  string         `json:"session_id"`
+}
+
+func (q *Queries) UpdatePagerDutyIncidentPriority(ctx context.Context, arg UpdatePagerDutyIncidentPriorityParams) error {
+	_, err := q.db.ExecContext(ctx, updatePagerDutyIncidentPriority,
+		arg.PriorityID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.SessionID,
+	)
+	return err
+}
+
 const updatePagerDutyIncidentStatus = `-- name: UpdatePagerDutyIncidentStatus :exec
 UPDATE pagerduty_incidents
 SET status = ?,
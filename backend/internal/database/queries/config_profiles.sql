@@ -0,0 +1,16 @@
+-- name: UpsertConfigProfile :exec
+INSERT INTO config_profiles (name, overrides, saved_at)
+VALUES (?, ?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    overrides = excluded.overrides,
+    saved_at = excluded.saved_at;
+
+-- name: GetConfigProfile :one
+SELECT name, overrides, saved_at
+FROM config_profiles
+WHERE name = ?;
+
+-- name: ListConfigProfiles :many
+SELECT name, overrides, saved_at
+FROM config_profiles
+ORDER BY name;
@@ -1,11 +1,21 @@
 -- Incidents (v2 API)
 
--- name: CreateDatadogIncident :exec
-INSERT INTO datadog_incidents (id, title, customer_impacted, severity, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?);
+-- name: CreateDatadogIncident :one
+INSERT INTO datadog_incidents (id, title, customer_impacted, severity, session_id, created_at, updated_at, public_id)
+VALUES (
+    sqlc.arg(id),
+    sqlc.arg(title),
+    sqlc.arg(customer_impacted),
+    sqlc.arg(severity),
+    sqlc.arg(session_id),
+    sqlc.arg(created_at),
+    sqlc.arg(updated_at),
+    (SELECT COALESCE(MAX(public_id), 0) + 1 FROM datadog_incidents WHERE session_id IN (sqlc.arg(session_id), sqlc.arg(parent_session_id)))
+)
+RETURNING public_id;
 
 -- name: GetDatadogIncidentByID :one
-SELECT id, title, customer_impacted, severity, session_id, created_at, updated_at
+SELECT id, title, customer_impacted, severity, session_id, created_at, updated_at, public_id
 FROM datadog_incidents
 WHERE id = ? AND session_id = ?;
 
@@ -18,21 +28,41 @@ SET title = COALESCE(sqlc.narg('title'), title),
 WHERE id = sqlc.arg('id') AND session_id = sqlc.arg('session_id');
 
 -- name: ListDatadogIncidents :many
-SELECT id, title, customer_impacted, severity, created_at, updated_at
+SELECT id, title, customer_impacted, severity, created_at, updated_at, public_id
 FROM datadog_incidents
 WHERE session_id = ?
-ORDER BY created_at DESC
+ORDER BY created_at DESC, public_id DESC
 LIMIT ?;
 
+-- name: CreateDatadogIncidentAttachment :exec
+INSERT INTO datadog_incident_attachments (id, incident_id, attachment_type, document_url, title, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListDatadogIncidentAttachments :many
+SELECT id, incident_id, attachment_type, document_url, title, session_id, created_at, updated_at
+FROM datadog_incident_attachments
+WHERE incident_id = ? AND session_id = ?
+ORDER BY created_at ASC, rowid ASC;
+
+-- name: CreateDatadogIncidentTodo :exec
+INSERT INTO datadog_incident_todos (id, incident_id, content, assignees, due_date, completed, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListDatadogIncidentTodos :many
+SELECT id, incident_id, content, assignees, due_date, completed, session_id, created_at, updated_at
+FROM datadog_incident_todos
+WHERE incident_id = ? AND session_id = ?
+ORDER BY created_at ASC, rowid ASC;
+
 -- Monitors (v1 API)
 
 -- name: CreateDatadogMonitor :one
-INSERT INTO datadog_monitors (name, type, query, message, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, name, type, query, message, created_at, updated_at;
+INSERT INTO datadog_monitors (name, type, query, message, tags, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, type, query, message, tags, created_at, updated_at;
 
 -- name: GetDatadogMonitorByID :one
-SELECT id, name, type, query, message, session_id, created_at, updated_at
+SELECT id, name, type, query, message, session_id, created_at, updated_at, tags
 FROM datadog_monitors
 WHERE id = ? AND session_id = ?;
 
@@ -49,7 +79,7 @@ DELETE FROM datadog_monitors
 WHERE id = ? AND session_id = ?;
 
 -- name: ListDatadogMonitors :many
-SELECT id, name, type, query, message, created_at, updated_at
+SELECT id, name, type, query, message, tags, created_at, updated_at
 FROM datadog_monitors
 WHERE session_id = ?
 ORDER BY created_at DESC;
@@ -93,10 +123,47 @@ WHERE session_id = ?
 ORDER BY timestamp DESC
 LIMIT ?;
 
+-- name: ListDatadogMetricsInRange :many
+SELECT value, tags, timestamp
+FROM datadog_metrics
+WHERE session_id = ? AND metric_name = ? AND timestamp >= ? AND timestamp <= ?
+ORDER BY timestamp ASC, id ASC;
+
+-- name: ListDatadogMetricTags :many
+SELECT DISTINCT tags
+FROM datadog_metrics
+WHERE session_id = ? AND metric_name = ?;
+
+-- Metric metadata (v1 API)
+
+-- name: GetDatadogMetricMetadata :one
+SELECT metric_name, type, description, unit, updated_at
+FROM datadog_metric_metadata
+WHERE metric_name = ? AND session_id = ?;
+
+-- name: UpsertDatadogMetricMetadata :exec
+INSERT INTO datadog_metric_metadata (metric_name, type, description, unit, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (metric_name, session_id) DO UPDATE SET
+    type = COALESCE(excluded.type, datadog_metric_metadata.type),
+    description = COALESCE(excluded.description, datadog_metric_metadata.description),
+    unit = COALESCE(excluded.unit, datadog_metric_metadata.unit),
+    updated_at = excluded.updated_at;
+
 -- Cleanup
 
 -- name: DeleteDatadogSessionData :exec
 DELETE FROM datadog_incidents WHERE session_id = ?;
+DELETE FROM datadog_incident_attachments WHERE session_id = ?;
+DELETE FROM datadog_incident_todos WHERE session_id = ?;
 DELETE FROM datadog_monitors WHERE session_id = ?;
 DELETE FROM datadog_events WHERE session_id = ?;
 DELETE FROM datadog_metrics WHERE session_id = ?;
+DELETE FROM datadog_metric_metadata WHERE session_id = ?;
+
+-- name: CountDatadogSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM datadog_metrics WHERE session_id = sqlc.arg(session_id)) AS metrics,
+    (SELECT COUNT(*) FROM datadog_events WHERE session_id = sqlc.arg(session_id)) AS events,
+    (SELECT COUNT(*) FROM datadog_monitors WHERE session_id = sqlc.arg(session_id)) AS monitors,
+    (SELECT COUNT(*) FROM datadog_incidents WHERE session_id = sqlc.arg(session_id)) AS incidents;
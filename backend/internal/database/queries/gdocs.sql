@@ -30,3 +30,6 @@ SELECT id, title, revision_id, document_id, created_at
 FROM gdocs_documents
 WHERE session_id = ?
 ORDER BY created_at DESC;
+
+-- name: CountGdocsSessionObjects :one
+SELECT COUNT(*) FROM gdocs_documents WHERE session_id = ?;
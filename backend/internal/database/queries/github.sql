@@ -13,36 +13,61 @@ WHERE owner = ? AND name = ? AND session_id = ?;
 SELECT id, owner, name, default_branch, description, created_at
 FROM github_repositories
 WHERE session_id = ? AND owner = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, id DESC;
 
 -- Issue queries
 
 -- name: CreateGithubIssue :one
 INSERT INTO github_issues (repo_owner, repo_name, number, title, body, state, session_id)
 VALUES (?, ?, ?, ?, ?, ?, ?)
-RETURNING id, repo_owner, repo_name, number, title, body, state, created_at, updated_at;
+RETURNING id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees;
 
 -- name: GetGithubIssue :one
-SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees, pull_request
 FROM github_issues
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?;
 
 -- name: ListGithubIssues :many
-SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees, pull_request
 FROM github_issues
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-  AND (sqlc.arg(state_filter) = '' OR state = sqlc.arg(state_filter))
-ORDER BY created_at DESC;
+  AND (sqlc.arg(state_filter) IN ('', 'all') OR state = sqlc.arg(state_filter))
+  AND (IFNULL(sqlc.arg(state_reason_filter), '') = '' OR state_reason = sqlc.arg(state_reason_filter))
+  AND updated_at >= sqlc.arg(since)
+ORDER BY created_at DESC, id DESC;
 
 -- name: UpdateGithubIssue :exec
 UPDATE github_issues
-SET title = ?, body = ?, state = ?, updated_at = unixepoch()
+SET title = ?, body = ?, state = ?, state_reason = ?, updated_at = unixepoch()
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?;
 
--- name: GetNextIssueNumber :one
-SELECT COALESCE(MAX(number), 0) + 1 as next_number
-FROM github_issues
-WHERE repo_owner = ? AND repo_name = ? AND session_id = ?;
+-- name: CreateNextGithubIssue :one
+INSERT INTO github_issues (repo_owner, repo_name, number, title, body, state, assignees, session_id)
+VALUES (
+    sqlc.arg(repo_owner),
+    sqlc.arg(repo_name),
+    (SELECT COALESCE(MAX(number), 0) + 1 FROM (
+        SELECT number FROM github_issues WHERE repo_owner = sqlc.arg(repo_owner) AND repo_name = sqlc.arg(repo_name) AND session_id IN (sqlc.arg(session_id), sqlc.arg(parent_session_id))
+        UNION ALL
+        SELECT number FROM github_pull_requests WHERE repo_owner = sqlc.arg(repo_owner) AND repo_name = sqlc.arg(repo_name) AND session_id IN (sqlc.arg(session_id), sqlc.arg(parent_session_id))
+    )),
+    sqlc.arg(title),
+    sqlc.arg(body),
+    sqlc.arg(state),
+    sqlc.arg(assignees),
+    sqlc.arg(session_id)
+)
+RETURNING id, repo_owner, repo_name, number, title, body, state, created_at, updated_at, state_reason, assignees;
+
+-- name: MarkGithubIssuePullRequest :exec
+UPDATE github_issues
+SET pull_request = 1, updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?;
+
+-- name: UpdateGithubIssueAssignees :exec
+UPDATE github_issues
+SET assignees = ?, updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?;
 
 -- Pull Request queries
 
@@ -52,27 +77,65 @@ VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at;
 
 -- name: GetGithubPullRequest :one
-SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, merged_at, draft, maintainer_can_modify, assignees
 FROM github_pull_requests
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?;
 
 -- name: ListGithubPullRequests :many
-SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at
+SELECT id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, merged_at, draft, maintainer_can_modify, assignees
 FROM github_pull_requests
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-  AND (sqlc.arg(state_filter) = '' OR state = sqlc.arg(state_filter))
-ORDER BY created_at DESC;
+  AND (sqlc.arg(state_filter) IN ('', 'all') OR state = sqlc.arg(state_filter))
+ORDER BY created_at DESC, id DESC;
 
--- name: MergeGithubPullRequest :exec
+-- name: UpdateGithubPullRequest :exec
+UPDATE github_pull_requests
+SET title = COALESCE(sqlc.narg('title'), title),
+    body = COALESCE(sqlc.narg('body'), body),
+    state = COALESCE(sqlc.narg('state'), state),
+    base = COALESCE(sqlc.narg('base'), base),
+    draft = COALESCE(sqlc.narg('draft'), draft),
+    maintainer_can_modify = COALESCE(sqlc.narg('maintainer_can_modify'), maintainer_can_modify),
+    updated_at = unixepoch()
+WHERE repo_owner = sqlc.arg('repo_owner') AND repo_name = sqlc.arg('repo_name') AND number = sqlc.arg('number') AND session_id = sqlc.arg('session_id');
+
+-- name: MergeGithubPullRequest :execrows
 UPDATE github_pull_requests
-SET state = 'closed', merged = 1, updated_at = unixepoch()
+SET state = 'closed', merged = 1, merged_at = unixepoch(), updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ? AND state = 'open' AND merged = 0;
+
+-- name: CreateNextGithubPullRequest :one
+INSERT INTO github_pull_requests (repo_owner, repo_name, number, title, body, head, base, state, draft, maintainer_can_modify, assignees, session_id)
+VALUES (
+    sqlc.arg(repo_owner),
+    sqlc.arg(repo_name),
+    (SELECT COALESCE(MAX(number), 0) + 1 FROM (
+        SELECT number FROM github_issues WHERE repo_owner = sqlc.arg(repo_owner) AND repo_name = sqlc.arg(repo_name) AND session_id IN (sqlc.arg(session_id), sqlc.arg(parent_session_id))
+        UNION ALL
+        SELECT number FROM github_pull_requests WHERE repo_owner = sqlc.arg(repo_owner) AND repo_name = sqlc.arg(repo_name) AND session_id IN (sqlc.arg(session_id), sqlc.arg(parent_session_id))
+    )),
+    sqlc.arg(title),
+    sqlc.arg(body),
+    sqlc.arg(head),
+    sqlc.arg(base),
+    sqlc.arg(state),
+    sqlc.arg(draft),
+    sqlc.arg(maintainer_can_modify),
+    sqlc.arg(assignees),
+    sqlc.arg(session_id)
+)
+RETURNING id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, draft, maintainer_can_modify, assignees;
+
+-- name: CreateGithubPullRequestFromIssue :one
+INSERT INTO github_pull_requests (repo_owner, repo_name, number, title, body, head, base, state, draft, maintainer_can_modify, assignees, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, repo_owner, repo_name, number, title, body, head, base, state, merged, created_at, updated_at, draft, maintainer_can_modify, assignees;
+
+-- name: UpdateGithubPullRequestAssignees :exec
+UPDATE github_pull_requests
+SET assignees = ?, updated_at = unixepoch()
 WHERE repo_owner = ? AND repo_name = ? AND number = ? AND session_id = ?;
 
--- name: GetNextPRNumber :one
-SELECT COALESCE(MAX(number), 0) + 1 as next_number
-FROM github_pull_requests
-WHERE repo_owner = ? AND repo_name = ? AND session_id = ?;
-
 -- File queries
 
 -- name: CreateOrUpdateGithubFile :exec
@@ -81,11 +144,27 @@ VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
 ON CONFLICT(repo_owner, repo_name, path, branch, session_id)
 DO UPDATE SET content = excluded.content, sha = excluded.sha, updated_at = unixepoch();
 
+-- name: DeleteGithubFile :execrows
+DELETE FROM github_files
+WHERE repo_owner = ? AND repo_name = ? AND path = ? AND branch = ? AND session_id = ?;
+
 -- name: GetGithubFile :one
 SELECT id, repo_owner, repo_name, path, content, sha, branch, updated_at
 FROM github_files
 WHERE repo_owner = ? AND repo_name = ? AND path = ? AND branch = ? AND session_id = ?;
 
+-- name: ListGithubFilesByBranch :many
+SELECT path, content, sha
+FROM github_files
+WHERE repo_owner = ? AND repo_name = ? AND branch = ? AND session_id = ?
+ORDER BY path;
+
+-- name: GetGithubFileBySHA :one
+SELECT path, content, sha
+FROM github_files
+WHERE repo_owner = ? AND repo_name = ? AND sha = ? AND session_id = ?
+LIMIT 1;
+
 -- Branch queries
 
 -- name: CreateGithubBranch :exec
@@ -97,11 +176,61 @@ SELECT id, repo_owner, repo_name, name, sha, created_at
 FROM github_branches
 WHERE repo_owner = ? AND repo_name = ? AND name = ? AND session_id = ?;
 
+-- name: GetGithubBranchBySHA :one
+SELECT id, repo_owner, repo_name, name, sha, created_at
+FROM github_branches
+WHERE repo_owner = ? AND repo_name = ? AND sha = ? AND session_id = ?
+ORDER BY id
+LIMIT 1;
+
+-- name: ListGithubBranches :many
+SELECT b.id, b.name, b.sha, b.created_at,
+       EXISTS (
+           SELECT 1 FROM github_branch_protections p
+           WHERE p.repo_owner = b.repo_owner AND p.repo_name = b.repo_name AND p.branch = b.name AND p.session_id = b.session_id
+       ) AS protected
+FROM github_branches b
+WHERE b.repo_owner = ? AND b.repo_name = ? AND b.session_id = ?
+ORDER BY b.name ASC, b.id ASC
+LIMIT ? OFFSET ?;
+
 -- name: UpdateGithubBranchSHA :exec
 UPDATE github_branches
 SET sha = ?
 WHERE repo_owner = ? AND repo_name = ? AND name = ? AND session_id = ?;
 
+-- Commit graph queries
+
+-- name: CreateGithubCommit :exec
+INSERT INTO github_commits (repo_owner, repo_name, sha, tree_sha, parent_sha, message, author_name, author_email, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CountGithubCommits :one
+SELECT COUNT(*) FROM github_commits
+WHERE repo_owner = ? AND repo_name = ? AND session_id = ?;
+
+-- name: GetGithubCommit :one
+SELECT id, repo_owner, repo_name, sha, tree_sha, parent_sha, message, author_name, author_email, session_id, created_at
+FROM github_commits
+WHERE repo_owner = ? AND repo_name = ? AND sha = ? AND session_id = ?;
+
+-- name: GetGithubCommitByTreeSHA :one
+SELECT id, repo_owner, repo_name, sha, tree_sha, parent_sha, message, author_name, author_email, session_id, created_at
+FROM github_commits
+WHERE repo_owner = ? AND repo_name = ? AND tree_sha = ? AND session_id = ?
+ORDER BY id
+LIMIT 1;
+
+-- name: CreateGithubTreeEntry :exec
+INSERT INTO github_tree_entries (repo_owner, repo_name, commit_sha, path, sha, size, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListGithubTreeEntries :many
+SELECT path, sha, size
+FROM github_tree_entries
+WHERE repo_owner = ? AND repo_name = ? AND commit_sha = ? AND session_id = ?
+ORDER BY path;
+
 -- Workflow queries
 
 -- name: CreateGithubWorkflow :exec
@@ -117,7 +246,7 @@ WHERE repo_owner = ? AND repo_name = ? AND workflow_id = ? AND session_id = ?;
 SELECT id, repo_owner, repo_name, workflow_id, name, path, state, created_at
 FROM github_workflows
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, id DESC;
 
 -- Workflow Run queries
 
@@ -135,19 +264,47 @@ WHERE repo_owner = ? AND repo_name = ? AND run_id = ? AND session_id = ?;
 SELECT id, repo_owner, repo_name, run_id, workflow_id, status, conclusion, head_branch, head_sha, created_at, updated_at
 FROM github_workflow_runs
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, id DESC;
 
 -- name: ListGithubWorkflowRunsForWorkflow :many
 SELECT id, repo_owner, repo_name, run_id, workflow_id, status, conclusion, head_branch, head_sha, created_at, updated_at
 FROM github_workflow_runs
 WHERE repo_owner = ? AND repo_name = ? AND workflow_id = ? AND session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, id DESC;
 
 -- name: GetNextWorkflowRunID :one
 SELECT COALESCE(MAX(run_id), 0) + 1 as next_id
 FROM github_workflow_runs
 WHERE repo_owner = ? AND repo_name = ? AND session_id = ?;
 
+-- Check run queries
+
+-- name: CreateGithubCheckRun :one
+INSERT INTO github_check_runs (repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id, created_at, updated_at;
+
+-- name: GetGithubCheckRun :one
+SELECT id, repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id, created_at, updated_at
+FROM github_check_runs
+WHERE repo_owner = ? AND repo_name = ? AND check_run_id = ? AND session_id = ?;
+
+-- name: ListGithubCheckRunsForSHA :many
+SELECT id, repo_owner, repo_name, check_run_id, name, head_sha, status, conclusion, external_id, details_url, output_title, output_summary, started_at, completed_at, session_id, created_at, updated_at
+FROM github_check_runs
+WHERE repo_owner = ? AND repo_name = ? AND head_sha = ? AND session_id = ?
+ORDER BY check_run_id DESC;
+
+-- name: UpdateGithubCheckRun :exec
+UPDATE github_check_runs
+SET name = ?, status = ?, conclusion = ?, external_id = ?, details_url = ?, output_title = ?, output_summary = ?, completed_at = ?, updated_at = unixepoch()
+WHERE repo_owner = ? AND repo_name = ? AND check_run_id = ? AND session_id = ?;
+
+-- name: GetNextCheckRunID :one
+SELECT COALESCE(MAX(check_run_id), 0) + 1 as next_id
+FROM github_check_runs
+WHERE repo_owner = ? AND repo_name = ? AND session_id = ?;
+
 -- Issue Comment queries
 
 -- name: CreateGithubIssueComment :one
@@ -155,19 +312,110 @@ INSERT INTO github_issue_comments (repo_owner, repo_name, issue_number, comment_
 VALUES (?, ?, ?, ?, ?, ?)
 RETURNING id, repo_owner, repo_name, issue_number, comment_id, body, created_at;
 
--- name: GetNextCommentID :one
-SELECT COALESCE(MAX(comment_id), 0) + 1 as next_id
-FROM github_issue_comments
-WHERE repo_owner = ? AND repo_name = ? AND session_id = ?;
+-- name: CreateNextGithubIssueComment :one
+INSERT INTO github_issue_comments (repo_owner, repo_name, issue_number, comment_id, body, session_id)
+VALUES (
+    sqlc.arg(repo_owner),
+    sqlc.arg(repo_name),
+    sqlc.arg(issue_number),
+    (SELECT COALESCE(MAX(comment_id), 0) + 1 FROM github_issue_comments WHERE repo_owner = sqlc.arg(repo_owner) AND repo_name = sqlc.arg(repo_name) AND session_id IN (sqlc.arg(session_id), sqlc.arg(parent_session_id))),
+    sqlc.arg(body),
+    sqlc.arg(session_id)
+)
+RETURNING id, repo_owner, repo_name, issue_number, comment_id, body, created_at;
 
 -- name: ListGithubIssueComments :many
 SELECT id, repo_owner, repo_name, issue_number, comment_id, body, created_at
 FROM github_issue_comments
 WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND session_id = ?
-ORDER BY created_at ASC;
+  AND created_at >= sqlc.arg(since)
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetGithubIssueComment :one
+SELECT id, repo_owner, repo_name, issue_number, comment_id, body, created_at
+FROM github_issue_comments
+WHERE repo_owner = ? AND repo_name = ? AND comment_id = ? AND session_id = ?;
+
+-- Issue label queries
+
+-- name: AddGithubIssueLabel :exec
+INSERT OR IGNORE INTO github_issue_labels (repo_owner, repo_name, issue_number, name, session_id)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListGithubIssueLabels :many
+SELECT name
+FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND session_id = ?
+ORDER BY id ASC;
+
+-- name: ListGithubRepoIssueLabels :many
+SELECT issue_number, name
+FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
+ORDER BY id ASC;
+
+-- name: DeleteGithubIssueLabel :execrows
+DELETE FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND name = ? AND session_id = ?;
+
+-- name: DeleteGithubIssueLabels :exec
+DELETE FROM github_issue_labels
+WHERE repo_owner = ? AND repo_name = ? AND issue_number = ? AND session_id = ?;
+
+-- Reaction queries
+
+-- name: CreateGithubReaction :one
+INSERT INTO github_reactions (repo_owner, repo_name, subject_type, subject_id, user_login, content, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, user_login, content, created_at;
+
+-- name: GetGithubReaction :one
+SELECT id, user_login, content, created_at
+FROM github_reactions
+WHERE repo_owner = ? AND repo_name = ? AND subject_type = ? AND subject_id = ? AND user_login = ? AND content = ? AND session_id = ?;
+
+-- name: ListGithubReactions :many
+SELECT id, user_login, content, created_at
+FROM github_reactions
+WHERE repo_owner = ? AND repo_name = ? AND subject_type = ? AND subject_id = ? AND session_id = ?
+  AND (sqlc.arg(content_filter) = '' OR content = sqlc.arg(content_filter))
+ORDER BY id ASC;
+
+-- Branch protection queries
+
+-- name: UpsertGithubBranchProtection :exec
+INSERT INTO github_branch_protections (repo_owner, repo_name, branch, required_status_checks, required_reviews, enforce_admins, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(repo_owner, repo_name, branch, session_id)
+DO UPDATE SET required_status_checks = excluded.required_status_checks, required_reviews = excluded.required_reviews,
+              enforce_admins = excluded.enforce_admins, updated_at = unixepoch();
+
+-- name: GetGithubBranchProtection :one
+SELECT required_status_checks, required_reviews, enforce_admins
+FROM github_branch_protections
+WHERE repo_owner = ? AND repo_name = ? AND branch = ? AND session_id = ?;
+
+-- name: DeleteGithubBranchProtection :execrows
+DELETE FROM github_branch_protections
+WHERE repo_owner = ? AND repo_name = ? AND branch = ? AND session_id = ?;
 
 -- Cleanup queries
 
+-- name: CreateGithubGist :exec
+INSERT INTO github_gists (id, description, public, files, owner_login, session_id)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetGithubGist :one
+SELECT id, description, public, files, owner_login, created_at, updated_at
+FROM github_gists
+WHERE id = ? AND session_id = ?;
+
+-- name: ListGithubGists :many
+SELECT id, description, public, files, owner_login, created_at, updated_at
+FROM github_gists
+WHERE session_id = ?
+ORDER BY created_at DESC, id DESC;
+
 -- name: DeleteGithubSessionData :exec
 DELETE FROM github_repositories WHERE session_id = ?;
 DELETE FROM github_issues WHERE session_id = ?;
@@ -177,10 +425,66 @@ DELETE FROM github_branches WHERE session_id = ?;
 DELETE FROM github_workflows WHERE session_id = ?;
 DELETE FROM github_workflow_runs WHERE session_id = ?;
 DELETE FROM github_issue_comments WHERE session_id = ?;
+DELETE FROM github_reactions WHERE session_id = ?;
+DELETE FROM github_issue_labels WHERE session_id = ?;
+DELETE FROM github_branch_protections WHERE session_id = ?;
+DELETE FROM github_gists WHERE session_id = ?;
+DELETE FROM github_check_runs WHERE session_id = ?;
+DELETE FROM github_users WHERE session_id = ?;
+DELETE FROM github_commits WHERE session_id = ?;
+DELETE FROM github_tree_entries WHERE session_id = ?;
 
 -- UI data queries
 -- name: ListGithubIssuesBySession :many
 SELECT id, repo_owner, repo_name, number, title, body, state, created_at, updated_at
 FROM github_issues
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, id DESC;
+
+-- name: CountGithubSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM github_repositories WHERE session_id = sqlc.arg(session_id)) AS repositories,
+    (SELECT COUNT(*) FROM github_issues WHERE session_id = sqlc.arg(session_id)) AS issues,
+    (SELECT COUNT(*) FROM github_pull_requests WHERE session_id = sqlc.arg(session_id)) AS pulls,
+    (SELECT COUNT(*) FROM github_issue_comments WHERE session_id = sqlc.arg(session_id)) AS comments,
+    (SELECT COUNT(*) FROM github_branches WHERE session_id = sqlc.arg(session_id)) AS branches,
+    (SELECT COUNT(*) FROM github_files WHERE session_id = sqlc.arg(session_id)) AS files,
+    (SELECT COUNT(*) FROM github_workflow_runs WHERE session_id = sqlc.arg(session_id)) AS workflow_runs;
+
+-- name: CreateGithubHook :one
+INSERT INTO github_hooks (repo_owner, repo_name, url, content_type, secret, events, active, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, repo_owner, repo_name, url, content_type, secret, events, active, session_id, created_at, updated_at;
+
+-- name: GetGithubHook :one
+SELECT id, repo_owner, repo_name, url, content_type, secret, events, active, session_id, created_at, updated_at
+FROM github_hooks
+WHERE id = ? AND repo_owner = ? AND repo_name = ? AND session_id = ?;
+
+-- name: ListGithubHooks :many
+SELECT id, repo_owner, repo_name, url, content_type, secret, events, active, session_id, created_at, updated_at
+FROM github_hooks
+WHERE repo_owner = ? AND repo_name = ? AND session_id = ?
+ORDER BY id;
+
+-- name: DeleteGithubHook :execrows
+DELETE FROM github_hooks
+WHERE id = ? AND repo_owner = ? AND repo_name = ? AND session_id = ?;
+
+-- User and organization accounts
+-- name: CreateGithubUser :one
+INSERT INTO github_users (login, type, name, email, authenticated, session_id)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, login, type, name, email, authenticated, session_id, created_at;
+
+-- name: GetGithubUser :one
+SELECT id, login, type, name, email, authenticated, session_id, created_at
+FROM github_users
+WHERE login = ? AND session_id = ?;
+
+-- name: GetGithubAuthenticatedUser :one
+SELECT id, login, type, name, email, authenticated, session_id, created_at
+FROM github_users
+WHERE authenticated = 1 AND session_id = ?
+ORDER BY id
+LIMIT 1;
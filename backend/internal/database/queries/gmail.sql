@@ -1,18 +1,33 @@
 -- name: CreateGmailMessage :exec
-INSERT INTO gmail_messages (id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, session_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO gmail_messages (id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, session_id, cc_email, to_addresses, cc_addresses, user_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetGmailMessageByID :one
-SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at
+SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at, cc_email
 FROM gmail_messages
-WHERE id = ? AND session_id = ?;
+WHERE id = ? AND session_id = ? AND user_id = ?;
 
+-- Trashed messages are skipped unless include_trash is 1
 -- name: ListGmailMessages :many
 SELECT id, thread_id, snippet, label_ids, internal_date
 FROM gmail_messages
-WHERE session_id = ?
-ORDER BY internal_date DESC
-LIMIT ? OFFSET ?;
+WHERE session_id = sqlc.arg(session_id) AND user_id = sqlc.arg(user_id)
+  AND (sqlc.arg(include_trash) = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+ORDER BY internal_date DESC, rowid DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- Lists a child session's messages together with its parent's. Parent messages the child
+-- has copied or deleted are shadowed.
+-- name: ListGmailMessagesWithParent :many
+SELECT id, thread_id, snippet, label_ids, internal_date
+FROM gmail_messages
+WHERE user_id = sqlc.arg(user_id)
+  AND (session_id = sqlc.arg(session_id) OR (session_id = sqlc.arg(parent_session_id)
+    AND id NOT IN (SELECT c.id FROM gmail_messages c WHERE c.session_id = sqlc.arg(session_id))
+    AND id NOT IN (SELECT t.message_id FROM gmail_message_tombstones t WHERE t.session_id = sqlc.arg(session_id))))
+  AND (sqlc.arg(include_trash) = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+ORDER BY internal_date DESC, rowid DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
 
 -- name: SearchGmailMessages :many
 SELECT id, thread_id, from_email, to_email, subject, snippet, label_ids, internal_date
@@ -20,22 +35,133 @@ FROM gmail_messages
 WHERE
     session_id = ?
     AND (? = '' OR from_email LIKE '%' || ? || '%')
-    AND (? = '' OR to_email LIKE '%' || ? || '%')
+    AND (? = '' OR to_addresses || ' ' || cc_addresses LIKE '%' || ? || '%')
     AND (? = '' OR subject LIKE '%' || ? || '%')
     AND (? = '' OR body_plain LIKE '%' || ? || '%')
     AND (? = '' OR label_ids LIKE '%' || ? || '%')
-ORDER BY internal_date DESC
+    AND (? = '' OR cc_addresses LIKE '%' || ? || '%')
+    AND (? = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+    AND user_id = ?
+ORDER BY internal_date DESC, rowid DESC
 LIMIT ?;
 
+-- name: DeleteGmailMessage :exec
+DELETE FROM gmail_messages WHERE id = ? AND session_id = ? AND user_id = ?;
+
+-- Messages of a thread, oldest first
+-- name: ListGmailMessagesByThread :many
+SELECT id, thread_id, snippet, label_ids, internal_date
+FROM gmail_messages
+WHERE thread_id = ? AND session_id = ? AND user_id = ?
+ORDER BY internal_date ASC, rowid ASC;
+
+-- Threads with their message counts, most recently active first. Trashed messages are
+-- skipped unless include_trash is 1.
+-- name: ListGmailThreads :many
+SELECT thread_id, COUNT(*) AS message_count
+FROM gmail_messages
+WHERE session_id = sqlc.arg(session_id) AND user_id = sqlc.arg(user_id)
+  AND (sqlc.arg(include_trash) = 1 OR label_ids IS NULL OR label_ids NOT LIKE '%"TRASH"%')
+GROUP BY thread_id
+ORDER BY MAX(internal_date) DESC, thread_id ASC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- Copies a parent session's message into a child session under the same ID, so the child
+-- can modify it without touching the parent
+-- name: CopyGmailMessageToSession :exec
+INSERT INTO gmail_messages (id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at, session_id, cc_email, to_addresses, cc_addresses, user_id)
+SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids, internal_date, size_estimate, created_at, sqlc.arg(session_id), cc_email, to_addresses, cc_addresses, user_id
+FROM gmail_messages
+WHERE id = sqlc.arg(id) AND session_id = sqlc.arg(parent_session_id) AND user_id = sqlc.arg(user_id);
+
+-- Tombstones hide a parent session's message from a child session that deleted it
+-- name: CreateGmailMessageTombstone :exec
+INSERT OR IGNORE INTO gmail_message_tombstones (session_id, message_id)
+VALUES (?, ?);
+
+-- name: GetGmailMessageTombstone :one
+SELECT message_id
+FROM gmail_message_tombstones
+WHERE session_id = ? AND message_id = ?;
+
+-- name: DeleteGmailMessageTombstones :exec
+DELETE FROM gmail_message_tombstones WHERE session_id = ?;
+
+-- name: UpdateGmailMessageLabels :exec
+UPDATE gmail_messages SET label_ids = ? WHERE id = ? AND session_id = ? AND user_id = ?;
+
 -- name: DeleteGmailSessionData :exec
 DELETE FROM gmail_messages WHERE session_id = ?;
+DELETE FROM gmail_message_tombstones WHERE session_id = ?;
+
+-- name: DeleteGmailWatch :exec
+DELETE FROM gmail_watches WHERE session_id = ?;
+
+-- name: DeleteGmailSendAsAliases :exec
+DELETE FROM gmail_send_as_aliases WHERE session_id = ?;
+
+-- Push notification watches
+-- name: UpsertGmailWatch :exec
+INSERT INTO gmail_watches (session_id, topic_name, label_ids, label_filter_behavior, history_id, expiration, stopped_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, NULL, unixepoch())
+ON CONFLICT(session_id) DO UPDATE SET
+    topic_name = excluded.topic_name,
+    label_ids = excluded.label_ids,
+    label_filter_behavior = excluded.label_filter_behavior,
+    history_id = excluded.history_id,
+    expiration = excluded.expiration,
+    stopped_at = NULL,
+    updated_at = unixepoch();
+
+-- name: GetGmailWatch :one
+SELECT session_id, topic_name, label_ids, label_filter_behavior, history_id, expiration, stopped_at, updated_at
+FROM gmail_watches
+WHERE session_id = ?;
+
+-- name: StopGmailWatch :exec
+UPDATE gmail_watches
+SET stopped_at = ?, updated_at = unixepoch()
+WHERE session_id = ? AND stopped_at IS NULL;
+
+-- name: ExpireGmailWatch :execrows
+UPDATE gmail_watches
+SET stopped_at = expiration, updated_at = unixepoch()
+WHERE session_id = ? AND stopped_at IS NULL AND expiration <= ?;
+
+-- Send-as settings
+-- name: ListGmailSendAsAliases :many
+SELECT session_id, send_as_email, display_name, reply_to_address, signature, is_primary, is_default, created_at
+FROM gmail_send_as_aliases
+WHERE session_id = ?
+ORDER BY is_primary DESC, send_as_email;
+
+-- name: GetGmailSendAsAlias :one
+SELECT session_id, send_as_email, display_name, reply_to_address, signature, is_primary, is_default, created_at
+FROM gmail_send_as_aliases
+WHERE session_id = ? AND send_as_email = ?;
+
+-- name: UpsertGmailSendAsAlias :exec
+INSERT INTO gmail_send_as_aliases (session_id, send_as_email, display_name, reply_to_address, signature, is_primary, is_default)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(session_id, send_as_email) DO UPDATE SET
+    display_name = excluded.display_name,
+    reply_to_address = excluded.reply_to_address,
+    signature = excluded.signature,
+    is_default = excluded.is_default;
+
+-- name: ClearGmailSendAsDefault :exec
+UPDATE gmail_send_as_aliases SET is_default = 0 WHERE session_id = ?;
+
+-- name: DeleteGmailSendAsAlias :execrows
+DELETE FROM gmail_send_as_aliases
+WHERE session_id = ? AND send_as_email = ? AND is_primary = 0;
 
 -- UI data queries
 -- name: ListGmailMessagesBySession :many
 SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, snippet, label_ids, internal_date, size_estimate, created_at
 FROM gmail_messages
 WHERE session_id = ?
-ORDER BY internal_date DESC;
+ORDER BY internal_date DESC, rowid DESC;
 
 -- Attachment queries
 -- name: CreateGmailAttachment :exec
@@ -51,4 +177,12 @@ WHERE id = ? AND session_id = ?;
 SELECT id, message_id, filename, mime_type, size, created_at
 FROM gmail_attachments
 WHERE message_id = ? AND session_id = ?
-ORDER BY created_at;
+ORDER BY created_at, rowid;
+
+-- name: DeleteGmailAttachmentsByMessage :exec
+DELETE FROM gmail_attachments WHERE message_id = ? AND session_id = ?;
+
+-- name: CountGmailSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM gmail_messages WHERE session_id = sqlc.arg(session_id)) AS messages,
+    (SELECT COUNT(*) FROM gmail_attachments WHERE session_id = sqlc.arg(session_id)) AS attachments;
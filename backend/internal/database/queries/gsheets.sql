@@ -7,6 +7,11 @@ SELECT id, title, created_at
 FROM gsheets_spreadsheets
 WHERE id = ? AND session_id = ?;
 
+-- name: UpdateSpreadsheetTitle :exec
+UPDATE gsheets_spreadsheets
+SET title = ?
+WHERE id = ? AND session_id = ?;
+
 -- name: CreateSheet :exec
 INSERT INTO gsheets_sheets (id, spreadsheet_id, title, sheet_id, session_id)
 VALUES (?, ?, ?, ?, ?);
@@ -22,15 +27,31 @@ SELECT id, spreadsheet_id, title, sheet_id
 FROM gsheets_sheets
 WHERE spreadsheet_id = ? AND title = ? AND session_id = ?;
 
+-- name: GetSheetBySheetID :one
+SELECT id, spreadsheet_id, title, sheet_id
+FROM gsheets_sheets
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?;
+
 -- name: DeleteSheet :exec
 DELETE FROM gsheets_sheets
 WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?;
 
+-- name: UpdateSheetTitle :exec
+UPDATE gsheets_sheets
+SET title = ?
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?;
+
+-- Cells are keyed by sheet title, so renaming a sheet moves its cells along
+-- name: RenameSheetCells :exec
+UPDATE gsheets_cells
+SET sheet_title = sqlc.arg(new_title)
+WHERE spreadsheet_id = sqlc.arg(spreadsheet_id) AND sheet_title = sqlc.arg(old_title) AND session_id = sqlc.arg(session_id);
+
 -- name: SetCellValue :exec
-INSERT INTO gsheets_cells (spreadsheet_id, sheet_title, row, col, value, session_id, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, unixepoch())
+INSERT INTO gsheets_cells (spreadsheet_id, sheet_title, row, col, value, value_type, session_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, unixepoch())
 ON CONFLICT(spreadsheet_id, sheet_title, row, col, session_id)
-DO UPDATE SET value = excluded.value, updated_at = unixepoch();
+DO UPDATE SET value = excluded.value, value_type = excluded.value_type, updated_at = unixepoch();
 
 -- name: GetCellValue :one
 SELECT value
@@ -38,7 +59,7 @@ FROM gsheets_cells
 WHERE spreadsheet_id = ? AND sheet_title = ? AND row = ? AND col = ? AND session_id = ?;
 
 -- name: GetCellsInRange :many
-SELECT row, col, value
+SELECT row, col, value, value_type
 FROM gsheets_cells
 WHERE spreadsheet_id = ? AND sheet_title = ?
   AND row >= ? AND row <= ?
@@ -46,6 +67,12 @@ WHERE spreadsheet_id = ? AND sheet_title = ?
   AND session_id = ?
 ORDER BY row ASC, col ASC;
 
+-- name: ListSpreadsheetCells :many
+SELECT sheet_title, row, col, value, value_type
+FROM gsheets_cells
+WHERE spreadsheet_id = ? AND session_id = ?
+ORDER BY sheet_title ASC, row ASC, col ASC;
+
 -- name: ClearRange :exec
 DELETE FROM gsheets_cells
 WHERE spreadsheet_id = ? AND sheet_title = ?
@@ -58,8 +85,57 @@ SELECT COALESCE(MAX(row), 0) as max_row
 FROM gsheets_cells
 WHERE spreadsheet_id = ? AND sheet_title = ? AND session_id = ?;
 
+-- name: ListOccupiedRows :many
+SELECT DISTINCT row
+FROM gsheets_cells
+WHERE spreadsheet_id = sqlc.arg(spreadsheet_id) AND sheet_title = sqlc.arg(sheet_title) AND session_id = sqlc.arg(session_id)
+  AND col >= sqlc.arg(start_col) AND col <= sqlc.arg(end_col)
+  AND value IS NOT NULL AND value != ''
+ORDER BY row ASC;
+
+-- Rows are shifted in two steps so no intermediate update collides with the primary key
+-- name: StageRowShift :exec
+UPDATE gsheets_cells
+SET row = -(row + sqlc.arg(row_count))
+WHERE spreadsheet_id = sqlc.arg(spreadsheet_id) AND sheet_title = sqlc.arg(sheet_title) AND session_id = sqlc.arg(session_id)
+  AND row >= sqlc.arg(from_row);
+
+-- name: CommitRowShift :exec
+UPDATE gsheets_cells
+SET row = -row
+WHERE spreadsheet_id = ? AND sheet_title = ? AND session_id = ? AND row < 0;
+
+-- name: CreateDeveloperMetadata :exec
+INSERT INTO gsheets_developer_metadata (metadata_id, spreadsheet_id, metadata_key, metadata_value, location, visibility, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListDeveloperMetadataBySpreadsheet :many
+SELECT metadata_id, spreadsheet_id, metadata_key, metadata_value, location, visibility
+FROM gsheets_developer_metadata
+WHERE spreadsheet_id = ? AND session_id = ?
+ORDER BY created_at ASC, metadata_id ASC;
+
+-- name: CreateSheetMerge :exec
+INSERT INTO gsheets_merges (spreadsheet_id, sheet_id, start_row_index, end_row_index, start_column_index, end_column_index, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListSheetMerges :many
+SELECT id, start_row_index, end_row_index, start_column_index, end_column_index
+FROM gsheets_merges
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?
+ORDER BY start_row_index ASC, start_column_index ASC, id ASC;
+
+-- name: DeleteSheetMerge :exec
+DELETE FROM gsheets_merges
+WHERE id = ? AND session_id = ?;
+
+-- name: DeleteSheetMerges :exec
+DELETE FROM gsheets_merges
+WHERE spreadsheet_id = ? AND sheet_id = ? AND session_id = ?;
+
 -- name: DeleteGsheetsSessionData :exec
 DELETE FROM gsheets_spreadsheets WHERE session_id = ?;
+DELETE FROM gsheets_merges WHERE session_id = ?;
 
 -- UI data queries
 -- name: ListGsheetsBySession :many
@@ -67,3 +143,9 @@ SELECT id, title, created_at
 FROM gsheets_spreadsheets
 WHERE session_id = ?
 ORDER BY created_at DESC;
+
+-- name: CountGsheetsSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM gsheets_spreadsheets WHERE session_id = sqlc.arg(session_id)) AS spreadsheets,
+    (SELECT COUNT(*) FROM gsheets_sheets WHERE session_id = sqlc.arg(session_id)) AS sheets,
+    (SELECT COUNT(*) FROM gsheets_cells WHERE session_id = sqlc.arg(session_id)) AS cells;
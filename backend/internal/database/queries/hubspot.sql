@@ -27,7 +27,7 @@ WHERE email = ? AND session_id = ?;
 SELECT id, email, first_name, last_name, mobile_phone, website, created_at, updated_at
 FROM hubspot_contacts
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, rowid DESC;
 
 -- Deals queries
 -- name: CreateHubspotDeal :one
@@ -52,7 +52,7 @@ WHERE id = ? AND session_id = ?;
 SELECT id, deal_name, deal_stage, pipeline, amount, created_at, updated_at
 FROM hubspot_deals
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, rowid DESC;
 
 -- Companies queries
 -- name: CreateHubspotCompany :one
@@ -78,7 +78,7 @@ WHERE id = ? AND session_id = ?;
 SELECT id, name, domain, city, industry, created_at, updated_at
 FROM hubspot_companies
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, rowid DESC;
 
 -- Associations queries
 -- name: CreateHubspotAssociation :exec
@@ -95,16 +95,67 @@ SELECT from_object_type, from_object_id, to_object_type, to_object_id, associati
 FROM hubspot_associations
 WHERE from_object_type = ? AND from_object_id = ? AND to_object_type = ? AND session_id = ?;
 
+-- name: ListHubspotAssociationsForObject :many
+SELECT from_object_type, from_object_id, to_object_type, to_object_id, association_type, created_at
+FROM hubspot_associations
+WHERE session_id = sqlc.arg(session_id)
+  AND ((from_object_type = sqlc.arg(object_type) AND from_object_id = sqlc.arg(object_id) AND to_object_type = sqlc.arg(related_type))
+    OR (to_object_type = sqlc.arg(object_type) AND to_object_id = sqlc.arg(object_id) AND from_object_type = sqlc.arg(related_type)))
+ORDER BY created_at ASC, id ASC;
+
+-- Properties API queries
+-- name: CreateHubspotPropertyDefinition :one
+INSERT INTO hubspot_property_definitions (session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at;
+
+-- name: GetHubspotPropertyDefinition :one
+SELECT session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at
+FROM hubspot_property_definitions
+WHERE session_id = ? AND object_type = ? AND name = ?;
+
+-- name: ListHubspotPropertyDefinitions :many
+SELECT session_id, object_type, name, label, type, field_type, group_name, description, options, created_at, updated_at
+FROM hubspot_property_definitions
+WHERE session_id = ? AND object_type = ?
+ORDER BY created_at, name;
+
+-- Values of properties without a typed column
+-- name: GetHubspotObjectProperties :one
+SELECT properties
+FROM hubspot_object_properties
+WHERE session_id = ? AND object_type = ? AND object_id = ?;
+
+-- name: ListHubspotObjectProperties :many
+SELECT object_id, properties
+FROM hubspot_object_properties
+WHERE session_id = ? AND object_type = ?;
+
+-- name: UpsertHubspotObjectProperties :exec
+INSERT INTO hubspot_object_properties (session_id, object_type, object_id, properties)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(session_id, object_type, object_id) DO UPDATE SET
+    properties = excluded.properties;
+
 -- Session management
 -- name: DeleteHubspotSessionData :exec
 DELETE FROM hubspot_associations WHERE session_id = ?;
 DELETE FROM hubspot_companies WHERE session_id = ?;
 DELETE FROM hubspot_deals WHERE session_id = ?;
 DELETE FROM hubspot_contacts WHERE session_id = ?;
+DELETE FROM hubspot_object_properties WHERE session_id = ?;
+DELETE FROM hubspot_property_definitions WHERE session_id = ?;
 
 -- UI data queries
 -- name: ListHubspotContactsBySession :many
 SELECT id, email, first_name, last_name, mobile_phone, website, created_at, updated_at
 FROM hubspot_contacts
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, rowid DESC;
+
+-- name: CountHubspotSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM hubspot_contacts WHERE session_id = sqlc.arg(session_id)) AS contacts,
+    (SELECT COUNT(*) FROM hubspot_companies WHERE session_id = sqlc.arg(session_id)) AS companies,
+    (SELECT COUNT(*) FROM hubspot_deals WHERE session_id = sqlc.arg(session_id)) AS deals,
+    (SELECT COUNT(*) FROM hubspot_associations WHERE session_id = sqlc.arg(session_id)) AS associations;
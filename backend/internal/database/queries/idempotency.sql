@@ -0,0 +1,9 @@
+-- name: GetIdempotentResponse :one
+SELECT status_code, content_type, body
+FROM idempotency_keys
+WHERE session_id = ? AND method = ? AND path = ? AND idempotency_key = ?;
+
+-- name: SaveIdempotentResponse :exec
+INSERT INTO idempotency_keys (session_id, method, path, idempotency_key, status_code, content_type, body)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(session_id, method, path, idempotency_key) DO NOTHING;
@@ -7,11 +7,29 @@ SELECT id, key, name, created_at
 FROM jira_projects
 WHERE key = ? AND session_id = ?;
 
+-- name: DeleteJiraProject :execrows
+DELETE FROM jira_projects
+WHERE key = ? AND session_id = ?;
+
+-- name: DeleteJiraIssuesByProject :exec
+DELETE FROM jira_issues
+WHERE project_key = ? AND session_id = ?;
+
+-- name: DeleteJiraCommentsByProject :exec
+DELETE FROM jira_comments
+WHERE session_id = sqlc.arg(session_id)
+  AND issue_key IN (SELECT key FROM jira_issues WHERE project_key = sqlc.arg(project_key) AND session_id = sqlc.arg(session_id));
+
+-- name: DeleteJiraIssueCustomFieldsByProject :exec
+DELETE FROM jira_issue_custom_fields
+WHERE session_id = sqlc.arg(session_id)
+  AND issue_key IN (SELECT key FROM jira_issues WHERE project_key = sqlc.arg(project_key) AND session_id = sqlc.arg(session_id));
+
 -- name: ListJiraProjects :many
 SELECT id, key, name, created_at
 FROM jira_projects
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, rowid DESC;
 
 -- name: CreateJiraIssue :exec
 INSERT INTO jira_issues (id, key, project_key, issue_type, summary, description, assignee, status, session_id)
@@ -41,9 +59,26 @@ WHERE session_id = ?
     AND (? = '' OR summary LIKE '%' || ? || '%')
     AND (? = '' OR assignee = ?)
     AND (? = '' OR status = ?)
-ORDER BY created_at DESC
+ORDER BY created_at DESC, rowid DESC
 LIMIT ?;
 
+-- Values of fields without a typed column
+-- name: GetJiraIssueCustomFields :one
+SELECT fields
+FROM jira_issue_custom_fields
+WHERE session_id = ? AND issue_key = ?;
+
+-- name: ListJiraIssueCustomFields :many
+SELECT issue_key, fields
+FROM jira_issue_custom_fields
+WHERE session_id = ?;
+
+-- name: UpsertJiraIssueCustomFields :exec
+INSERT INTO jira_issue_custom_fields (session_id, issue_key, fields)
+VALUES (?, ?, ?)
+ON CONFLICT(session_id, issue_key) DO UPDATE SET
+    fields = excluded.fields;
+
 -- name: CreateJiraComment :exec
 INSERT INTO jira_comments (id, issue_key, body, session_id)
 VALUES (?, ?, ?, ?);
@@ -52,7 +87,7 @@ VALUES (?, ?, ?, ?);
 SELECT id, issue_key, body, created_at
 FROM jira_comments
 WHERE issue_key = ? AND session_id = ?
-ORDER BY created_at ASC;
+ORDER BY created_at ASC, rowid ASC;
 
 -- name: CreateJiraTransition :exec
 INSERT INTO jira_transitions (id, name, to_status, session_id)
@@ -62,7 +97,7 @@ VALUES (?, ?, ?, ?);
 SELECT id, name, to_status, created_at
 FROM jira_transitions
 WHERE session_id = ?
-ORDER BY created_at ASC;
+ORDER BY created_at ASC, rowid ASC;
 
 -- name: GetJiraTransitionByName :one
 SELECT id, name, to_status, created_at
@@ -74,10 +109,17 @@ DELETE FROM jira_projects WHERE session_id = ?;
 DELETE FROM jira_issues WHERE session_id = ?;
 DELETE FROM jira_comments WHERE session_id = ?;
 DELETE FROM jira_transitions WHERE session_id = ?;
+DELETE FROM jira_issue_custom_fields WHERE session_id = ?;
 
 -- UI data queries
 -- name: ListJiraIssuesBySession :many
 SELECT id, key, project_key, issue_type, summary, description, assignee, status, created_at, updated_at
 FROM jira_issues
 WHERE session_id = ?
-ORDER BY created_at DESC;
+ORDER BY created_at DESC, rowid DESC;
+
+-- name: CountJiraSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM jira_projects WHERE session_id = sqlc.arg(session_id)) AS projects,
+    (SELECT COUNT(*) FROM jira_issues WHERE session_id = sqlc.arg(session_id)) AS issues,
+    (SELECT COUNT(*) FROM jira_comments WHERE session_id = sqlc.arg(session_id)) AS comments;
@@ -30,6 +30,30 @@ FROM linear_users
 WHERE session_id = ?
 ORDER BY created_at ASC;
 
+-- name: GetLinearViewer :one
+SELECT id, name, email, created_at
+FROM linear_users
+WHERE session_id = ?
+ORDER BY is_viewer DESC, created_at ASC
+LIMIT 1;
+
+-- name: SetLinearViewer :exec
+UPDATE linear_users
+SET is_viewer = CASE WHEN id = ? THEN 1 ELSE 0 END
+WHERE session_id = ?;
+
+-- Organization queries
+-- name: CreateLinearOrganization :exec
+INSERT INTO linear_organizations (id, name, url_key, session_id)
+VALUES (?, ?, ?, ?);
+
+-- name: GetLinearOrganization :one
+SELECT id, name, url_key, created_at
+FROM linear_organizations
+WHERE session_id = ?
+ORDER BY created_at ASC
+LIMIT 1;
+
 -- States queries
 -- name: CreateLinearState :exec
 INSERT INTO linear_states (id, name, type, team_id, session_id)
@@ -48,12 +72,12 @@ ORDER BY created_at ASC;
 
 -- Issues queries
 -- name: CreateLinearIssue :one
-INSERT INTO linear_issues (id, team_id, title, description, assignee_id, state_id, url, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at;
+INSERT INTO linear_issues (id, team_id, title, description, assignee_id, state_id, project_id, url, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id;
 
 -- name: GetLinearIssueByID :one
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE id = ? AND session_id = ?;
 
@@ -63,31 +87,57 @@ SET title = COALESCE(?, title),
     description = COALESCE(?, description),
     assignee_id = COALESCE(?, assignee_id),
     state_id = COALESCE(?, state_id),
+    project_id = COALESCE(?, project_id),
     updated_at = ?
 WHERE id = ? AND session_id = ?;
 
 -- name: ListLinearIssuesByTeam :many
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE team_id = ? AND session_id = ?
 ORDER BY created_at DESC;
 
 -- name: ListLinearIssues :many
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE session_id = ?
 ORDER BY created_at DESC;
 
+-- Projects queries
+-- name: CreateLinearProject :one
+INSERT INTO linear_projects (id, name, description, team_ids, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, name, description, team_ids, created_at, updated_at;
+
+-- name: GetLinearProjectByID :one
+SELECT id, name, description, team_ids, created_at, updated_at
+FROM linear_projects
+WHERE id = ? AND session_id = ?;
+
+-- name: ListLinearProjects :many
+SELECT id, name, description, team_ids, created_at, updated_at
+FROM linear_projects
+WHERE session_id = ?
+ORDER BY created_at ASC;
+
 -- Session management
 -- name: DeleteLinearSessionData :exec
 DELETE FROM linear_issues WHERE session_id = ?;
+DELETE FROM linear_projects WHERE session_id = ?;
 DELETE FROM linear_states WHERE session_id = ?;
 DELETE FROM linear_users WHERE session_id = ?;
 DELETE FROM linear_teams WHERE session_id = ?;
 
 -- UI data queries
 -- name: ListLinearIssuesBySession :many
-SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at
+SELECT id, team_id, title, description, assignee_id, state_id, url, created_at, updated_at, archived_at, project_id
 FROM linear_issues
 WHERE session_id = ?
 ORDER BY created_at DESC;
+
+-- name: CountLinearSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM linear_teams WHERE session_id = sqlc.arg(session_id)) AS teams,
+    (SELECT COUNT(*) FROM linear_users WHERE session_id = sqlc.arg(session_id)) AS users,
+    (SELECT COUNT(*) FROM linear_issues WHERE session_id = sqlc.arg(session_id)) AS issues,
+    (SELECT COUNT(*) FROM linear_projects WHERE session_id = sqlc.arg(session_id)) AS projects;
@@ -1,21 +1,26 @@
 -- name: CreateOutlookMessage :exec
-INSERT INTO outlook_messages (id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, session_id)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+INSERT INTO outlook_messages (id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, session_id, change_seq, cc_email)
+VALUES (
+    sqlc.arg(id), sqlc.arg(from_email), sqlc.arg(to_email), sqlc.arg(subject), sqlc.arg(body_content), sqlc.arg(body_type),
+    sqlc.arg(is_read), sqlc.arg(received_datetime), sqlc.arg(conversation_id), sqlc.arg(session_id),
+    (SELECT COALESCE(MAX(change_seq), 0) + 1 FROM outlook_messages WHERE session_id = sqlc.arg(session_id)),
+    sqlc.arg(cc_email)
+);
 
 -- name: GetOutlookMessageByID :one
-SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, created_at
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, created_at, cc_email
 FROM outlook_messages
 WHERE id = ? AND session_id = ?;
 
 -- name: ListOutlookMessages :many
-SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, cc_email
 FROM outlook_messages
 WHERE session_id = ?
 ORDER BY received_datetime DESC
 LIMIT ?;
 
 -- name: SearchOutlookMessages :many
-SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, cc_email
 FROM outlook_messages
 WHERE
     session_id = ?
@@ -26,10 +31,19 @@ WHERE
 ORDER BY received_datetime DESC
 LIMIT ?;
 
+-- Messages written after a change sequence number, oldest change first
+-- name: ListOutlookMessageChanges :many
+SELECT id, from_email, to_email, subject, body_content, body_type, is_read, received_datetime, conversation_id, change_seq, cc_email
+FROM outlook_messages
+WHERE session_id = ? AND change_seq > ?
+ORDER BY change_seq
+LIMIT ?;
+
 -- name: UpdateOutlookMessageReadStatus :exec
 UPDATE outlook_messages
-SET is_read = ?
-WHERE id = ? AND session_id = ?;
+SET is_read = sqlc.arg(is_read),
+    change_seq = (SELECT COALESCE(MAX(change_seq), 0) + 1 FROM outlook_messages WHERE session_id = sqlc.arg(session_id))
+WHERE id = sqlc.arg(id) AND session_id = sqlc.arg(session_id);
 
 -- name: DeleteOutlookSessionData :exec
 DELETE FROM outlook_messages WHERE session_id = ?;
@@ -40,3 +54,6 @@ SELECT id, from_email, to_email, subject, body_content, body_type, is_read, rece
 FROM outlook_messages
 WHERE session_id = ?
 ORDER BY received_datetime DESC;
+
+-- name: CountOutlookSessionObjects :one
+SELECT COUNT(*) FROM outlook_messages WHERE session_id = ?;
@@ -48,12 +48,12 @@ ORDER BY created_at ASC;
 
 -- Incidents queries
 -- name: CreatePagerDutyIncident :one
-INSERT INTO pagerduty_incidents (id, title, service_id, urgency, status, body_details, session_id, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, title, service_id, urgency, status, body_details, created_at, updated_at;
+INSERT INTO pagerduty_incidents (id, title, service_id, urgency, status, body_details, priority_id, session_id, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at;
 
 -- name: GetPagerDutyIncidentByID :one
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE id = ? AND session_id = ?;
 
@@ -63,18 +63,40 @@ SET status = ?,
     updated_at = ?
 WHERE id = ? AND session_id = ?;
 
+-- name: UpdatePagerDutyIncidentPriority :exec
+UPDATE pagerduty_incidents
+SET priority_id = ?,
+    updated_at = ?
+WHERE id = ? AND session_id = ?;
+
 -- name: ListPagerDutyIncidents :many
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE session_id = ?
 ORDER BY created_at DESC;
 
 -- name: ListPagerDutyIncidentsByStatus :many
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE status = ? AND session_id = ?
 ORDER BY created_at DESC;
 
+-- Priorities queries
+-- name: CreatePagerDutyPriority :exec
+INSERT OR IGNORE INTO pagerduty_priorities (id, name, description, order_index, session_id)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetPagerDutyPriorityByID :one
+SELECT id, name, description, order_index, created_at
+FROM pagerduty_priorities
+WHERE id = ? AND session_id = ?;
+
+-- name: ListPagerDutyPriorities :many
+SELECT id, name, description, order_index, created_at
+FROM pagerduty_priorities
+WHERE session_id = ?
+ORDER BY order_index ASC;
+
 -- Session management
 -- name: DeletePagerDutySessionData :exec
 DELETE FROM pagerduty_incidents WHERE session_id = ?;
@@ -84,7 +106,13 @@ DELETE FROM pagerduty_services WHERE session_id = ?;
 
 -- UI data queries
 -- name: ListPagerdutyIncidentsBySession :many
-SELECT id, title, service_id, urgency, status, body_details, created_at, updated_at
+SELECT id, title, service_id, urgency, status, body_details, priority_id, created_at, updated_at
 FROM pagerduty_incidents
 WHERE session_id = ?
 ORDER BY created_at DESC;
+
+-- name: CountPagerDutySessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM pagerduty_services WHERE session_id = sqlc.arg(session_id)) AS services,
+    (SELECT COUNT(*) FROM pagerduty_incidents WHERE session_id = sqlc.arg(session_id)) AS incidents,
+    (SELECT COUNT(*) FROM pagerduty_escalation_policies WHERE session_id = sqlc.arg(session_id)) AS escalation_policies;
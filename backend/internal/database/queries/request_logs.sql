@@ -0,0 +1,28 @@
+-- name: CreateRequestLog :one
+INSERT INTO request_logs (session_id, simulator, method, path, raw_query, headers, request_body, status_code, response_body, duration_us)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: GetRequestLog :one
+SELECT id, session_id, simulator, method, path, raw_query, headers, request_body, status_code, response_body, created_at, duration_us
+FROM request_logs
+WHERE id = ?;
+
+-- name: ListRequestLogs :many
+SELECT id, session_id, simulator, method, path, raw_query, headers, request_body, status_code, response_body, created_at, duration_us
+FROM request_logs
+WHERE session_id = ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: ListRequestLogLatencies :many
+SELECT method, path, duration_us
+FROM request_logs
+WHERE simulator = ? AND created_at >= ?
+ORDER BY id;
+
+-- name: ListSessionRequestLogs :many
+SELECT id, session_id, simulator, method, path, raw_query, headers, request_body, status_code, response_body, created_at, duration_us
+FROM request_logs
+WHERE session_id = ?
+ORDER BY id;
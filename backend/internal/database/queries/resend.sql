@@ -23,3 +23,6 @@ SELECT id, from_email, to_emails, subject, html, cc_emails, bcc_emails, reply_to
 FROM resend_emails
 WHERE session_id = ?
 ORDER BY created_at DESC;
+
+-- name: CountResendSessionObjects :one
+SELECT COUNT(*) FROM resend_emails WHERE session_id = ?;
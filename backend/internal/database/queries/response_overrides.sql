@@ -0,0 +1,18 @@
+-- name: CreateResponseOverride :one
+INSERT INTO response_overrides (session_id, simulator, method, path_pattern, status_code, body, content_type)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, session_id, simulator, method, path_pattern, status_code, body, content_type, created_at;
+
+-- name: ListResponseOverrides :many
+SELECT id, session_id, simulator, method, path_pattern, status_code, body, content_type, created_at
+FROM response_overrides
+WHERE session_id = ? AND simulator = ?
+ORDER BY id DESC;
+
+-- name: DeleteResponseOverride :execrows
+DELETE FROM response_overrides
+WHERE id = ? AND simulator = ?;
+
+-- name: DeleteResponseOverrides :exec
+DELETE FROM response_overrides
+WHERE session_id = ?;
@@ -1,11 +1,11 @@
 -- name: GetSessionConfig :one
-SELECT timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day
+SELECT timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, fault_truncate_rate, fault_signature_skew_seconds, validation_strict_content_type, response_headers
 FROM session_configs
 WHERE session_id = ? AND simulator_name = ?;
 
 -- name: UpsertSessionConfig :exec
-INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, unixepoch())
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
 ON CONFLICT(session_id, simulator_name) DO UPDATE SET
     timeout_min_ms = excluded.timeout_min_ms,
     timeout_max_ms = excluded.timeout_max_ms,
@@ -13,12 +13,35 @@ ON CONFLICT(session_id, simulator_name) DO UPDATE SET
     rate_limit_per_day = excluded.rate_limit_per_day,
     updated_at = unixepoch();
 
+-- name: UpsertSessionValidationConfig :exec
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(session_id, simulator_name) DO UPDATE SET
+    validation_enabled = excluded.validation_enabled,
+    validation_strict_content_type = excluded.validation_strict_content_type,
+    updated_at = unixepoch();
+
+-- name: UpsertSessionFaultsConfig :exec
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(session_id, simulator_name) DO UPDATE SET
+    fault_truncate_rate = excluded.fault_truncate_rate,
+    fault_signature_skew_seconds = excluded.fault_signature_skew_seconds,
+    updated_at = unixepoch();
+
+-- name: UpsertSessionHeadersConfig :exec
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, response_headers, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(session_id, simulator_name) DO UPDATE SET
+    response_headers = excluded.response_headers,
+    updated_at = unixepoch();
+
 -- name: DeleteSessionConfig :exec
 DELETE FROM session_configs
 WHERE session_id = ? AND simulator_name = ?;
 
 -- name: ListSessionConfigs :many
-SELECT session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, created_at, updated_at
+SELECT session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, created_at, updated_at, validation_enabled, fault_truncate_rate, fault_signature_skew_seconds, validation_strict_content_type, response_headers
 FROM session_configs
 WHERE session_id = ?
 ORDER BY simulator_name;
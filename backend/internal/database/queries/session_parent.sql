@@ -0,0 +1,13 @@
+-- name: SetSessionParent :exec
+INSERT INTO session_parents (session_id, parent_session_id)
+VALUES (?, ?)
+ON CONFLICT(session_id) DO UPDATE SET
+    parent_session_id = excluded.parent_session_id;
+
+-- name: GetSessionParent :one
+SELECT parent_session_id
+FROM session_parents
+WHERE session_id = ?;
+
+-- name: DeleteSessionParent :exec
+DELETE FROM session_parents WHERE session_id = ?;
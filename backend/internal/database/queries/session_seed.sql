@@ -0,0 +1,13 @@
+-- name: SetSessionSeed :exec
+INSERT INTO session_seeds (session_id, seed)
+VALUES (?, ?)
+ON CONFLICT(session_id) DO UPDATE SET
+    seed = excluded.seed;
+
+-- name: GetSessionSeed :one
+SELECT seed
+FROM session_seeds
+WHERE session_id = ?;
+
+-- name: DeleteSessionSeed :exec
+DELETE FROM session_seeds WHERE session_id = ?;
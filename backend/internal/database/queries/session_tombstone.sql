@@ -0,0 +1,18 @@
+-- Tombstones hide a parent session's object from a child session that deleted it
+
+-- name: CreateSessionTombstone :exec
+INSERT OR IGNORE INTO session_tombstones (session_id, kind, object_key)
+VALUES (?, ?, ?);
+
+-- name: GetSessionTombstone :one
+SELECT object_key
+FROM session_tombstones
+WHERE session_id = ? AND kind = ? AND object_key = ?;
+
+-- name: ListSessionTombstones :many
+SELECT object_key
+FROM session_tombstones
+WHERE session_id = ? AND kind = ?;
+
+-- name: DeleteSessionTombstones :exec
+DELETE FROM session_tombstones WHERE session_id = ?;
@@ -1,13 +1,50 @@
 -- name: CreateMessage :exec
-INSERT INTO slack_messages (channel_id, type, user_id, text, timestamp, attachments, session_id)
-VALUES (?, ?, ?, ?, ?, ?, ?);
+INSERT INTO slack_messages (channel_id, type, user_id, text, timestamp, attachments, blocks, thread_ts, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CreateEphemeralMessage :exec
+INSERT INTO slack_ephemeral_messages (channel_id, user_id, text, timestamp, session_id)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListEphemeralMessagesByUser :many
+SELECT channel_id, user_id, text, timestamp
+FROM slack_ephemeral_messages
+WHERE user_id = ? AND session_id = ?
+ORDER BY id ASC;
 
+-- Top-level messages only; replies are counted on their parent
 -- name: GetMessagesByChannel :many
-SELECT type, user_id, text, timestamp, attachments
+SELECT type, user_id, text, timestamp, attachments, blocks,
+       (SELECT COUNT(*) FROM slack_messages AS replies
+        WHERE replies.channel_id = slack_messages.channel_id
+          AND replies.session_id = slack_messages.session_id
+          AND replies.thread_ts = slack_messages.timestamp) AS reply_count
 FROM slack_messages
-WHERE channel_id = ? AND session_id = ?
+WHERE channel_id = ? AND session_id = ? AND thread_ts IS NULL
 ORDER BY timestamp DESC;
 
+-- name: GetSlackMessage :one
+SELECT type, user_id, text, timestamp, blocks, thread_ts
+FROM slack_messages
+WHERE channel_id = ? AND timestamp = ? AND session_id = ?;
+
+-- name: ListSlackThreadMessages :many
+SELECT type, user_id, text, timestamp, blocks, thread_ts
+FROM slack_messages
+WHERE channel_id = sqlc.arg(channel_id) AND session_id = sqlc.arg(session_id)
+  AND (timestamp = sqlc.arg(thread_ts) OR thread_ts = sqlc.arg(thread_ts))
+ORDER BY timestamp ASC;
+
+-- name: CreateSlackReaction :execrows
+INSERT OR IGNORE INTO slack_reactions (channel_id, message_ts, name, user_id, session_id)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListSlackReactions :many
+SELECT name, user_id
+FROM slack_reactions
+WHERE channel_id = ? AND message_ts = ? AND session_id = ?
+ORDER BY id ASC;
+
 -- name: ListChannels :many
 SELECT id, name, created_at
 FROM slack_channels
@@ -15,7 +52,7 @@ WHERE session_id = ?
 ORDER BY created_at ASC;
 
 -- name: GetChannelByID :one
-SELECT id, name, created_at
+SELECT id, name, created_at, topic, topic_creator, topic_last_set, purpose, purpose_creator, purpose_last_set
 FROM slack_channels
 WHERE id = ? AND session_id = ?;
 
@@ -35,6 +72,15 @@ SELECT id, team_id, name, real_name, email, display_name, first_name, last_name,
 FROM slack_users
 WHERE id = ? AND session_id = ?;
 
+-- name: ListSlackUsers :many
+SELECT id, team_id, name, real_name, email, display_name, first_name, last_name,
+       is_admin, is_owner, is_bot, timezone, timezone_label, timezone_offset,
+       image_24, image_32, image_48, image_72, image_192, image_512, created_at
+FROM slack_users
+WHERE session_id = ?
+ORDER BY created_at ASC, id ASC
+LIMIT ? OFFSET ?;
+
 -- name: CreateUser :exec
 INSERT INTO slack_users (id, team_id, name, real_name, email, display_name, first_name, last_name,
                          is_admin, is_owner, is_bot, timezone, timezone_label, timezone_offset,
@@ -45,7 +91,54 @@ VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 INSERT INTO slack_channels (id, name, created_at, session_id)
 VALUES (?, ?, ?, ?);
 
+-- name: JoinSlackChannel :execrows
+INSERT OR IGNORE INTO slack_channel_members (channel_id, user_id, session_id)
+VALUES (?, ?, ?);
+
+-- name: LeaveSlackChannel :execrows
+DELETE FROM slack_channel_members
+WHERE channel_id = ? AND user_id = ? AND session_id = ?;
+
+-- name: IsSlackChannelMember :one
+SELECT EXISTS (
+    SELECT 1 FROM slack_channel_members
+    WHERE channel_id = ? AND user_id = ? AND session_id = ?
+) AS is_member;
+
+-- name: CountSlackChannelMembers :one
+SELECT COUNT(*) FROM slack_channel_members
+WHERE channel_id = ? AND session_id = ?;
+
+-- name: UpdateSlackChannelTopic :exec
+UPDATE slack_channels
+SET topic = ?, topic_creator = ?, topic_last_set = unixepoch()
+WHERE id = ? AND session_id = ?;
+
+-- name: UpdateSlackChannelPurpose :exec
+UPDATE slack_channels
+SET purpose = ?, purpose_creator = ?, purpose_last_set = unixepoch()
+WHERE id = ? AND session_id = ?;
+
 -- Session management queries
+-- name: CreateSlackScheduledMessage :exec
+INSERT INTO slack_scheduled_messages (id, channel_id, user_id, text, post_at, session_id)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListSlackScheduledMessages :many
+SELECT id, channel_id, user_id, text, post_at, session_id, created_at
+FROM slack_scheduled_messages
+WHERE session_id = ?
+ORDER BY post_at, id;
+
+-- name: ListDueSlackScheduledMessages :many
+SELECT id, channel_id, user_id, text, post_at, session_id, created_at
+FROM slack_scheduled_messages
+WHERE session_id = ? AND post_at <= ?
+ORDER BY post_at, id;
+
+-- name: DeleteSlackScheduledMessage :execrows
+DELETE FROM slack_scheduled_messages WHERE id = ? AND session_id = ?;
+
 -- name: CreateSession :exec
 INSERT INTO sessions (id) VALUES (?);
 
@@ -59,6 +152,21 @@ SELECT id, created_at, last_accessed FROM sessions ORDER BY created_at DESC;
 DELETE FROM slack_messages WHERE session_id = ?;
 DELETE FROM slack_files WHERE session_id = ?;
 
+-- name: DeleteSlackScheduledMessages :exec
+DELETE FROM slack_scheduled_messages WHERE session_id = ?;
+
+-- name: DeleteSlackChannelMembers :exec
+DELETE FROM slack_channel_members WHERE session_id = ?;
+
+-- name: DeleteSlackReactions :exec
+DELETE FROM slack_reactions WHERE session_id = ?;
+
+-- name: DeleteSlackResponseWarnings :exec
+DELETE FROM slack_response_warnings WHERE session_id = ?;
+
+-- name: DeleteSlackEphemeralMessages :exec
+DELETE FROM slack_ephemeral_messages WHERE session_id = ?;
+
 -- name: UpdateSessionAccess :exec
 UPDATE sessions SET last_accessed = unixepoch() WHERE id = ?;
 
@@ -80,3 +188,20 @@ WHERE session_id = ?;
 SELECT id, filename, title, filetype, size, upload_url, channel_id, user_id, created_at
 FROM slack_files
 WHERE session_id = ?;
+
+-- name: CountSlackSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM slack_channels WHERE session_id = sqlc.arg(session_id)) AS channels,
+    (SELECT COUNT(*) FROM slack_users WHERE session_id = sqlc.arg(session_id)) AS users,
+    (SELECT COUNT(*) FROM slack_messages WHERE session_id = sqlc.arg(session_id)) AS messages,
+    (SELECT COUNT(*) FROM slack_files WHERE session_id = sqlc.arg(session_id)) AS files;
+
+-- name: GetSlackResponseWarnings :one
+SELECT warnings FROM slack_response_warnings WHERE session_id = ?;
+
+-- name: UpsertSlackResponseWarnings :exec
+INSERT INTO slack_response_warnings (session_id, warnings, updated_at)
+VALUES (?, ?, unixepoch())
+ON CONFLICT(session_id) DO UPDATE SET
+    warnings = excluded.warnings,
+    updated_at = unixepoch();
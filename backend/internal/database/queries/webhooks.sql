@@ -0,0 +1,31 @@
+-- name: CreateWebhookReceiver :one
+INSERT INTO webhook_receivers (session_id, simulator, url, secret)
+VALUES (?, ?, ?, ?)
+RETURNING id, session_id, simulator, url, secret, created_at;
+
+-- name: ListWebhookReceivers :many
+SELECT id, session_id, simulator, url, secret, created_at
+FROM webhook_receivers
+WHERE session_id = ?
+ORDER BY id;
+
+-- name: ListWebhookReceiversForSimulator :many
+SELECT id, session_id, simulator, url, secret, created_at
+FROM webhook_receivers
+WHERE session_id = ? AND simulator = ?
+ORDER BY id;
+
+-- name: DeleteWebhookReceiver :execrows
+DELETE FROM webhook_receivers
+WHERE id = ? AND session_id = ?;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (delivery_id, session_id, simulator, event, url, payload, attempt, status_code, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id;
+
+-- name: ListWebhookDeliveries :many
+SELECT id, delivery_id, session_id, simulator, event, url, payload, attempt, status_code, error, created_at
+FROM webhook_deliveries
+WHERE session_id = ?
+ORDER BY id;
@@ -23,3 +23,6 @@ SELECT id, phone_number_id, to_number, message_type, text_body, media_url, capti
 FROM whatsapp_messages
 WHERE session_id = ?
 ORDER BY created_at DESC;
+
+-- name: CountWhatsAppSessionObjects :one
+SELECT COUNT(*) FROM whatsapp_messages WHERE session_id = ?;
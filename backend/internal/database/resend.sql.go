@@ -10,6 +10,17 @@ import (
 	"database/sql"
 )
 
+const countResendSessionObjects = `-- name: CountResendSessionObjects :one
+SELECT COUNT(*) FROM resend_emails WHERE session_id = ?
+`
+
+func (q *Queries) CountResendSessionObjects(ctx context.Context, sessionID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResendSessionObjects, sessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createResendEmail = `-- name: CreateResendEmail :exec
 INSERT INTO resend_emails (id, from_email, to_emails, subject, html, cc_emails, bcc_emails, reply_to, session_id)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 )
 
 const deleteSessionConfig = `-- name: DeleteSessionConfig :exec
@@ -25,7 +26,7 @@ func (q *Queries) DeleteSessionConfig(ctx context.Context, arg DeleteSessionConf
 }
 
 const getSessionConfig = `-- name: GetSessionConfig :one
-SELECT timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day
+SELECT timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, fault_truncate_rate, fault_signature_skew_seconds, validation_strict_content_type, response_headers
 FROM session_configs
 WHERE session_id = ? AND simulator_name = ?
 `
@@ -36,10 +37,15 @@ type GetSessionConfigParams struct {
 }
 
 type GetSessionConfigRow struct {
-	TimeoutMinMs       int64 `json:"timeout_min_ms"`
-	TimeoutMaxMs       int64 `json:"timeout_max_ms"`
-	RateLimitPerMinute int64 `json:"rate_limit_per_minute"`
-	RateLimitPerDay    int64 `json:"rate_limit_per_day"`
+	TimeoutMinMs                int64          `json:"timeout_min_ms"`
+	TimeoutMaxMs                int64          `json:"timeout_max_ms"`
+	RateLimitPerMinute          int64          `json:"rate_limit_per_minute"`
+	RateLimitPerDay             int64          `json:"rate_limit_per_day"`
+	ValidationEnabled           int64          `json:"validation_enabled"`
+	FaultTruncateRate           float64        `json:"fault_truncate_rate"`
+	FaultSignatureSkewSeconds   int64          `json:"fault_signature_skew_seconds"`
+	ValidationStrictContentType int64          `json:"validation_strict_content_type"`
+	ResponseHeaders             sql.NullString `json:"response_headers"`
 }
 
 func (q *Queries) GetSessionConfig(ctx context.Context, arg GetSessionConfigParams) (GetSessionConfigRow, error) {
@@ -50,12 +56,17 @@ func (q *Queries) GetSessionConfig(ctx context.Context, arg GetSessionConfigPara
 		&i.TimeoutMaxMs,
 		&i.RateLimitPerMinute,
 		&i.RateLimitPerDay,
+		&i.ValidationEnabled,
+		&i.FaultTruncateRate,
+		&i.FaultSignatureSkewSeconds,
+		&i.ValidationStrictContentType,
+		&i.ResponseHeaders,
 	)
 	return i, err
 }
 
 const listSessionConfigs = `-- name: ListSessionConfigs :many
-SELECT session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, created_at, updated_at
+SELECT session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, created_at, updated_at, validation_enabled, fault_truncate_rate, fault_signature_skew_seconds, validation_strict_content_type, response_headers
 FROM session_configs
 WHERE session_id = ?
 ORDER BY simulator_name
@@ -79,6 +90,11 @@ func (q *Queries) ListSessionConfigs(ctx context.Context, sessionID string) ([]S
 			&i.RateLimitPerDay,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ValidationEnabled,
+			&i.FaultTruncateRate,
+			&i.FaultSignatureSkewSeconds,
+			&i.ValidationStrictContentType,
+			&i.ResponseHeaders,
 		); err != nil {
 			return nil, err
 		}
@@ -94,8 +110,8 @@ func (q *Queries) ListSessionConfigs(ctx context.Context, sessionID string) ([]S
 }
 
 const upsertSessionConfig = `-- name: UpsertSessionConfig :exec
-INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, unixepoch())
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
 ON CONFLICT(session_id, simulator_name) DO UPDATE SET
     timeout_min_ms = excluded.timeout_min_ms,
     timeout_max_ms = excluded.timeout_max_ms,
@@ -105,12 +121,16 @@ ON CONFLICT(session_id, simulator_name) DO UPDATE SET
 `
 
 type UpsertSessionConfigParams struct {
-	SessionID          string `json:"session_id"`
-	SimulatorName      string `json:"simulator_name"`
-	TimeoutMinMs       int64  `json:"timeout_min_ms"`
-	TimeoutMaxMs       int64  `json:"timeout_max_ms"`
-	RateLimitPerMinute int64  `json:"rate_limit_per_minute"`
-	RateLimitPerDay    int64  `json:"rate_limit_per_day"`
+	SessionID                   string  `json:"session_id"`
+	SimulatorName               string  `json:"simulator_name"`
+	TimeoutMinMs                int64   `json:"timeout_min_ms"`
+	TimeoutMaxMs                int64   `json:"timeout_max_ms"`
+	RateLimitPerMinute          int64   `json:"rate_limit_per_minute"`
+	RateLimitPerDay             int64   `json:"rate_limit_per_day"`
+	ValidationEnabled           int64   `json:"validation_enabled"`
+	ValidationStrictContentType int64   `json:"validation_strict_content_type"`
+	FaultTruncateRate           float64 `json:"fault_truncate_rate"`
+	FaultSignatureSkewSeconds   int64   `json:"fault_signature_skew_seconds"`
 }
 
 func (q *Queries) UpsertSessionConfig(ctx context.Context, arg UpsertSessionConfigParams) error {
@@ -121,6 +141,125 @@ func (q *Queries) UpsertSessionConfig(ctx context.Context, arg UpsertSessionConf
 		arg.TimeoutMaxMs,
 		arg.RateLimitPerMinute,
 		arg.RateLimitPerDay,
+		arg.ValidationEnabled,
+		arg.ValidationStrictContentType,
+		arg.FaultTruncateRate,
+		arg.FaultSignatureSkewSeconds,
+	)
+	return err
+}
+
+const upsertSessionFaultsConfig = `-- name: UpsertSessionFaultsConfig :exec
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(session_id, simulator_name) DO UPDATE SET
+    fault_truncate_rate = excluded.fault_truncate_rate,
+    fault_signature_skew_seconds = excluded.fault_signature_skew_seconds,
+    updated_at = unixepoch()
+`
+
+type UpsertSessionFaultsConfigParams struct {
+	SessionID                   string  `json:"session_id"`
+	SimulatorName               string  `json:"simulator_name"`
+	TimeoutMinMs                int64   `json:"timeout_min_ms"`
+	TimeoutMaxMs                int64   `json:"timeout_max_ms"`
+	RateLimitPerMinute          int64   `json:"rate_limit_per_minute"`
+	RateLimitPerDay             int64   `json:"rate_limit_per_day"`
+	ValidationEnabled           int64   `json:"validation_enabled"`
+	ValidationStrictContentType int64   `json:"validation_strict_content_type"`
+	FaultTruncateRate           float64 `json:"fault_truncate_rate"`
+	FaultSignatureSkewSeconds   int64   `json:"fault_signature_skew_seconds"`
+}
+
+func (q *Queries) UpsertSessionFaultsConfig(ctx context.Context, arg UpsertSessionFaultsConfigParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSessionFaultsConfig,
+		arg.SessionID,
+		arg.SimulatorName,
+		arg.TimeoutMinMs,
+		arg.TimeoutMaxMs,
+		arg.RateLimitPerMinute,
+		arg.RateLimitPerDay,
+		arg.ValidationEnabled,
+		arg.ValidationStrictContentType,
+		arg.FaultTruncateRate,
+		arg.FaultSignatureSkewSeconds,
+	)
+	return err
+}
+
+const upsertSessionHeadersConfig = `-- name: UpsertSessionHeadersConfig :exec
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, response_headers, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(session_id, simulator_name) DO UPDATE SET
+    response_headers = excluded.response_headers,
+    updated_at = unixepoch()
+`
+
+type UpsertSessionHeadersConfigParams struct {
+	SessionID                   string         `json:"session_id"`
+	SimulatorName               string         `json:"simulator_name"`
+	TimeoutMinMs                int64          `json:"timeout_min_ms"`
+	TimeoutMaxMs                int64          `json:"timeout_max_ms"`
+	RateLimitPerMinute          int64          `json:"rate_limit_per_minute"`
+	RateLimitPerDay             int64          `json:"rate_limit_per_day"`
+	ValidationEnabled           int64          `json:"validation_enabled"`
+	ValidationStrictContentType int64          `json:"validation_strict_content_type"`
+	FaultTruncateRate           float64        `json:"fault_truncate_rate"`
+	FaultSignatureSkewSeconds   int64          `json:"fault_signature_skew_seconds"`
+	ResponseHeaders             sql.NullString `json:"response_headers"`
+}
+
+func (q *Queries) UpsertSessionHeadersConfig(ctx context.Context, arg UpsertSessionHeadersConfigParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSessionHeadersConfig,
+		arg.SessionID,
+		arg.SimulatorName,
+		arg.TimeoutMinMs,
+		arg.TimeoutMaxMs,
+		arg.RateLimitPerMinute,
+		arg.RateLimitPerDay,
+		arg.ValidationEnabled,
+		arg.ValidationStrictContentType,
+		arg.FaultTruncateRate,
+		arg.FaultSignatureSkewSeconds,
+		arg.ResponseHeaders,
+	)
+	return err
+}
+
+const upsertSessionValidationConfig = `-- name: UpsertSessionValidationConfig :exec
+INSERT INTO session_configs (session_id, simulator_name, timeout_min_ms, timeout_max_ms, rate_limit_per_minute, rate_limit_per_day, validation_enabled, validation_strict_content_type, fault_truncate_rate, fault_signature_skew_seconds, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, unixepoch())
+ON CONFLICT(session_id, simulator_name) DO UPDATE SET
+    validation_enabled = excluded.validation_enabled,
+    validation_strict_content_type = excluded.validation_strict_content_type,
+    updated_at = unixepoch()
+`
+
+type UpsertSessionValidationConfigParams struct {
+	SessionID                   string  `json:"session_id"`
+	SimulatorName               string  `json:"simulator_name"`
+	TimeoutMinMs                int64   `json:"timeout_min_ms"`
+	TimeoutMaxMs                int64   `json:"timeout_max_ms"`
+	RateLimitPerMinute          int64   `json:"rate_limit_per_minute"`
+	RateLimitPerDay             int64   `json:"rate_limit_per_day"`
+	ValidationEnabled           int64   `json:"validation_enabled"`
+	ValidationStrictContentType int64   `json:"validation_strict_content_type"`
+	FaultTruncateRate           float64 `json:"fault_truncate_rate"`
+	FaultSignatureSkewSeconds   int64   `json:"fault_signature_skew_seconds"`
+}
+
+func (q *Queries) UpsertSessionValidationConfig(ctx context.Context, arg UpsertSessionValidationConfigParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSessionValidationConfig,
+		arg.SessionID,
+		arg.SimulatorName,
+		arg.TimeoutMinMs,
+		arg.TimeoutMaxMs,
+		arg.RateLimitPerMinute,
+		arg.RateLimitPerDay,
+		arg.ValidationEnabled,
+		arg.ValidationStrictContentType,
+		arg.FaultTruncateRate,
+		arg.FaultSignatureSkewSeconds,
 	)
 	return err
 }
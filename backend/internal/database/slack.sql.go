@@ -10,6 +10,50 @@ import (
 	"database/sql"
 )
 
+const countSlackChannelMembers = `-- name: CountSlackChannelMembers :one
+SELECT COUNT(*) FROM slack_channel_members
+WHERE channel_id = ? AND session_id = ?
+`
+
+type CountSlackChannelMembersParams struct {
+	ChannelID string `json:"channel_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) CountSlackChannelMembers(ctx context.Context, arg CountSlackChannelMembersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSlackChannelMembers, arg.ChannelID, arg.SessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSlackSessionObjects = `-- name: CountSlackSessionObjects :one
+SELECT
+    (SELECT COUNT(*) FROM slack_channels WHERE session_id = ?1) AS channels,
+    (SELECT COUNT(*) FROM slack_users WHERE session_id = ?1) AS users,
+    (SELECT COUNT(*) FROM slack_messages WHERE session_id = ?1) AS messages,
+    (SELECT COUNT(*) FROM slack_files WHERE session_id = ?1) AS files
+`
+
+type CountSlackSessionObjectsRow struct {
+	Channels int64 `json:"channels"`
+	Users    int64 `json:"users"`
+	Messages int64 `json:"messages"`
+	Files    int64 `json:"files"`
+}
+
+func (q *Queries) CountSlackSessionObjects(ctx context.Context, sessionID string) (CountSlackSessionObjectsRow, error) {
+	row := q.db.QueryRowContext(ctx, countSlackSessionObjects, sessionID)
+	var i CountSlackSessionObjectsRow
+	err := row.Scan(
+		&i.Channels,
+		&i.Users,
+		&i.Messages,
+		&i.Files,
+	)
+	return i, err
+}
+
 const createChannel = `-- name: CreateChannel :exec
 INSERT INTO slack_channels (id, name, created_at, session_id)
 VALUES (?, ?, ?, ?)
@@ -32,6 +76,30 @@ func (q *Queries) CreateChannel(ctx context.Context, arg CreateChannelParams) er
 	return err
 }
 
+const createEphemeralMessage = `-- name: CreateEphemeralMessage :exec
+INSERT INTO slack_ephemeral_messages (channel_id, user_id, text, timestamp, session_id)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateEphemeralMessageParams struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	Timestamp string `json:"timestamp"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) CreateEphemeralMessage(ctx context.Context, arg CreateEphemeralMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createEphemeralMessage,
+		arg.ChannelID,
+		arg.UserID,
+		arg.Text,
+		arg.Timestamp,
+		arg.SessionID,
+	)
+	return err
+}
+
 const createFile = `-- name: CreateFile :exec
 INSERT INTO slack_files (id, filename, title, filetype, size, upload_url, channel_id, user_id, session_id)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
@@ -65,8 +133,8 @@ func (q *Queries) CreateFile(ctx context.Context, arg CreateFileParams) error {
 }
 
 const createMessage = `-- name: CreateMessage :exec
-INSERT INTO slack_messages (channel_id, type, user_id, text, timestamp, attachments, session_id)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO slack_messages (channel_id, type, user_id, text, timestamp, attachments, blocks, thread_ts, session_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateMessageParams struct {
@@ -76,6 +144,8 @@ type CreateMessageParams struct {
 	Text        string         `json:"text"`
 	Timestamp   string         `json:"timestamp"`
 	Attachments sql.NullString `json:"attachments"`
+	Blocks      sql.NullString `json:"blocks"`
+	ThreadTs    sql.NullString `json:"thread_ts"`
 	SessionID   string         `json:"session_id"`
 }
 
@@ -87,6 +157,8 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) er
 		arg.Text,
 		arg.Timestamp,
 		arg.Attachments,
+		arg.Blocks,
+		arg.ThreadTs,
 		arg.SessionID,
 	)
 	return err
@@ -102,6 +174,59 @@ func (q *Queries) CreateSession(ctx context.Context, id string) error {
 	return err
 }
 
+const createSlackReaction = `-- name: CreateSlackReaction :execrows
+INSERT OR IGNORE INTO slack_reactions (channel_id, message_ts, name, user_id, session_id)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateSlackReactionParams struct {
+	ChannelID string `json:"channel_id"`
+	MessageTs string `json:"message_ts"`
+	Name      string `json:"name"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) CreateSlackReaction(ctx context.Context, arg CreateSlackReactionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createSlackReaction,
+		arg.ChannelID,
+		arg.MessageTs,
+		arg.Name,
+		arg.UserID,
+		arg.SessionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createSlackScheduledMessage = `-- name: CreateSlackScheduledMessage :exec
+INSERT INTO slack_scheduled_messages (id, channel_id, user_id, text, post_at, session_id)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateSlackScheduledMessageParams struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	PostAt    int64  `json:"post_at"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) CreateSlackScheduledMessage(ctx context.Context, arg CreateSlackScheduledMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createSlackScheduledMessage,
+		arg.ID,
+		arg.ChannelID,
+		arg.UserID,
+		arg.Text,
+		arg.PostAt,
+		arg.SessionID,
+	)
+	return err
+}
+
 const createUser = `-- name: CreateUser :exec
 INSERT INTO slack_users (id, team_id, name, real_name, email, display_name, first_name, last_name,
                          is_admin, is_owner, is_bot, timezone, timezone_label, timezone_offset,
@@ -169,8 +294,70 @@ func (q *Queries) DeleteSessionData(ctx context.Context, sessionID string) error
 	return err
 }
 
+const deleteSlackChannelMembers = `-- name: DeleteSlackChannelMembers :exec
+DELETE FROM slack_channel_members WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSlackChannelMembers(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSlackChannelMembers, sessionID)
+	return err
+}
+
+const deleteSlackEphemeralMessages = `-- name: DeleteSlackEphemeralMessages :exec
+DELETE FROM slack_ephemeral_messages WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSlackEphemeralMessages(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSlackEphemeralMessages, sessionID)
+	return err
+}
+
+const deleteSlackReactions = `-- name: DeleteSlackReactions :exec
+DELETE FROM slack_reactions WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSlackReactions(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSlackReactions, sessionID)
+	return err
+}
+
+const deleteSlackResponseWarnings = `-- name: DeleteSlackResponseWarnings :exec
+DELETE FROM slack_response_warnings WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSlackResponseWarnings(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSlackResponseWarnings, sessionID)
+	return err
+}
+
+const deleteSlackScheduledMessage = `-- name: DeleteSlackScheduledMessage :execrows
+DELETE FROM slack_scheduled_messages WHERE id = ? AND session_id = ?
+`
+
+type DeleteSlackScheduledMessageParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) DeleteSlackScheduledMessage(ctx context.Context, arg DeleteSlackScheduledMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSlackScheduledMessage, arg.ID, arg.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSlackScheduledMessages = `-- name: DeleteSlackScheduledMessages :exec
+DELETE FROM slack_scheduled_messages WHERE session_id = ?
+`
+
+func (q *Queries) DeleteSlackScheduledMessages(ctx context.Context, sessionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSlackScheduledMessages, sessionID)
+	return err
+}
+
 const getChannelByID = `-- name: GetChannelByID :one
-SELECT id, name, created_at
+SELECT id, name, created_at, topic, topic_creator, topic_last_set, purpose, purpose_creator, purpose_last_set
 FROM slack_channels
 WHERE id = ? AND session_id = ?
 `
@@ -181,15 +368,31 @@ type GetChannelByIDParams struct {
 }
 
 type GetChannelByIDRow struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt int64  `json:"created_at"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	CreatedAt      int64  `json:"created_at"`
+	Topic          string `json:"topic"`
+	TopicCreator   string `json:"topic_creator"`
+	TopicLastSet   int64  `json:"topic_last_set"`
+	Purpose        string `json:"purpose"`
+	PurposeCreator string `json:"purpose_creator"`
+	PurposeLastSet int64  `json:"purpose_last_set"`
 }
 
 func (q *Queries) GetChannelByID(ctx context.Context, arg GetChannelByIDParams) (GetChannelByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getChannelByID, arg.ID, arg.SessionID)
 	var i GetChannelByIDRow
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.Topic,
+		&i.TopicCreator,
+		&i.TopicLastSet,
+		&i.Purpose,
+		&i.PurposeCreator,
+		&i.PurposeLastSet,
+	)
 	return i, err
 }
 
@@ -234,9 +437,13 @@ func (q *Queries) GetFileByID(ctx context.Context, arg GetFileByIDParams) (GetFi
 }
 
 const getMessagesByChannel = `-- name: GetMessagesByChannel :many
-SELECT type, user_id, text, timestamp, attachments
+SELECT type, user_id, text, timestamp, attachments, blocks,
+       (SELECT COUNT(*) FROM slack_messages AS replies
+        WHERE replies.channel_id = slack_messages.channel_id
+          AND replies.session_id = slack_messages.session_id
+          AND replies.thread_ts = slack_messages.timestamp) AS reply_count
 FROM slack_messages
-WHERE channel_id = ? AND session_id = ?
+WHERE channel_id = ? AND session_id = ? AND thread_ts IS NULL
 ORDER BY timestamp DESC
 `
 
@@ -251,8 +458,11 @@ type GetMessagesByChannelRow struct {
 	Text        string         `json:"text"`
 	Timestamp   string         `json:"timestamp"`
 	Attachments sql.NullString `json:"attachments"`
+	Blocks      sql.NullString `json:"blocks"`
+	ReplyCount  int64          `json:"reply_count"`
 }
 
+// Top-level messages only; replies are counted on their parent
 func (q *Queries) GetMessagesByChannel(ctx context.Context, arg GetMessagesByChannelParams) ([]GetMessagesByChannelRow, error) {
 	rows, err := q.db.QueryContext(ctx, getMessagesByChannel, arg.ChannelID, arg.SessionID)
 	if err != nil {
@@ -268,6 +478,8 @@ func (q *Queries) GetMessagesByChannel(ctx context.Context, arg GetMessagesByCha
 			&i.Text,
 			&i.Timestamp,
 			&i.Attachments,
+			&i.Blocks,
+			&i.ReplyCount,
 		); err != nil {
 			return nil, err
 		}
@@ -293,6 +505,52 @@ func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
 	return i, err
 }
 
+const getSlackMessage = `-- name: GetSlackMessage :one
+SELECT type, user_id, text, timestamp, blocks, thread_ts
+FROM slack_messages
+WHERE channel_id = ? AND timestamp = ? AND session_id = ?
+`
+
+type GetSlackMessageParams struct {
+	ChannelID string `json:"channel_id"`
+	Timestamp string `json:"timestamp"`
+	SessionID string `json:"session_id"`
+}
+
+type GetSlackMessageRow struct {
+	Type      string         `json:"type"`
+	UserID    string         `json:"user_id"`
+	Text      string         `json:"text"`
+	Timestamp string         `json:"timestamp"`
+	Blocks    sql.NullString `json:"blocks"`
+	ThreadTs  sql.NullString `json:"thread_ts"`
+}
+
+func (q *Queries) GetSlackMessage(ctx context.Context, arg GetSlackMessageParams) (GetSlackMessageRow, error) {
+	row := q.db.QueryRowContext(ctx, getSlackMessage, arg.ChannelID, arg.Timestamp, arg.SessionID)
+	var i GetSlackMessageRow
+	err := row.Scan(
+		&i.Type,
+		&i.UserID,
+		&i.Text,
+		&i.Timestamp,
+		&i.Blocks,
+		&i.ThreadTs,
+	)
+	return i, err
+}
+
+const getSlackResponseWarnings = `-- name: GetSlackResponseWarnings :one
+SELECT warnings FROM slack_response_warnings WHERE session_id = ?
+`
+
+func (q *Queries) GetSlackResponseWarnings(ctx context.Context, sessionID string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getSlackResponseWarnings, sessionID)
+	var warnings string
+	err := row.Scan(&warnings)
+	return warnings, err
+}
+
 const getUserByID = `-- name: GetUserByID :one
 SELECT id, team_id, name, real_name, email, display_name, first_name, last_name,
        is_admin, is_owner, is_bot, timezone, timezone_label, timezone_offset,
@@ -359,6 +617,64 @@ func (q *Queries) GetUserByID(ctx context.Context, arg GetUserByIDParams) (GetUs
 	return i, err
 }
 
+const isSlackChannelMember = `-- name: IsSlackChannelMember :one
+SELECT EXISTS (
+    SELECT 1 FROM slack_channel_members
+    WHERE channel_id = ? AND user_id = ? AND session_id = ?
+) AS is_member
+`
+
+type IsSlackChannelMemberParams struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) IsSlackChannelMember(ctx context.Context, arg IsSlackChannelMemberParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, isSlackChannelMember, arg.ChannelID, arg.UserID, arg.SessionID)
+	var is_member int64
+	err := row.Scan(&is_member)
+	return is_member, err
+}
+
+const joinSlackChannel = `-- name: JoinSlackChannel :execrows
+INSERT OR IGNORE INTO slack_channel_members (channel_id, user_id, session_id)
+VALUES (?, ?, ?)
+`
+
+type JoinSlackChannelParams struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) JoinSlackChannel(ctx context.Context, arg JoinSlackChannelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, joinSlackChannel, arg.ChannelID, arg.UserID, arg.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const leaveSlackChannel = `-- name: LeaveSlackChannel :execrows
+DELETE FROM slack_channel_members
+WHERE channel_id = ? AND user_id = ? AND session_id = ?
+`
+
+type LeaveSlackChannelParams struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func (q *Queries) LeaveSlackChannel(ctx context.Context, arg LeaveSlackChannelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, leaveSlackChannel, arg.ChannelID, arg.UserID, arg.SessionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const listChannels = `-- name: ListChannels :many
 SELECT id, name, created_at
 FROM slack_channels
@@ -395,6 +711,96 @@ func (q *Queries) ListChannels(ctx context.Context, sessionID string) ([]ListCha
 	return items, nil
 }
 
+const listDueSlackScheduledMessages = `-- name: ListDueSlackScheduledMessages :many
+SELECT id, channel_id, user_id, text, post_at, session_id, created_at
+FROM slack_scheduled_messages
+WHERE session_id = ? AND post_at <= ?
+ORDER BY post_at, id
+`
+
+type ListDueSlackScheduledMessagesParams struct {
+	SessionID string `json:"session_id"`
+	PostAt    int64  `json:"post_at"`
+}
+
+func (q *Queries) ListDueSlackScheduledMessages(ctx context.Context, arg ListDueSlackScheduledMessagesParams) ([]SlackScheduledMessage, error) {
+	rows, err := q.db.QueryContext(ctx, listDueSlackScheduledMessages, arg.SessionID, arg.PostAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SlackScheduledMessage{}
+	for rows.Next() {
+		var i SlackScheduledMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChannelID,
+			&i.UserID,
+			&i.Text,
+			&i.PostAt,
+			&i.SessionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEphemeralMessagesByUser = `-- name: ListEphemeralMessagesByUser :many
+SELECT channel_id, user_id, text, timestamp
+FROM slack_ephemeral_messages
+WHERE user_id = ? AND session_id = ?
+ORDER BY id ASC
+`
+
+type ListEphemeralMessagesByUserParams struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+type ListEphemeralMessagesByUserRow struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	Timestamp string `json:"timestamp"`
+}
+
+func (q *Queries) ListEphemeralMessagesByUser(ctx context.Context, arg ListEphemeralMessagesByUserParams) ([]ListEphemeralMessagesByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEphemeralMessagesByUser, arg.UserID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEphemeralMessagesByUserRow{}
+	for rows.Next() {
+		var i ListEphemeralMessagesByUserRow
+		if err := rows.Scan(
+			&i.ChannelID,
+			&i.UserID,
+			&i.Text,
+			&i.Timestamp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listFilesBySession = `-- name: ListFilesBySession :many
 SELECT id, filename, title, filetype, size, upload_url, channel_id, user_id, created_at
 FROM slack_files
@@ -520,6 +926,223 @@ func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
 	return items, nil
 }
 
+const listSlackReactions = `-- name: ListSlackReactions :many
+SELECT name, user_id
+FROM slack_reactions
+WHERE channel_id = ? AND message_ts = ? AND session_id = ?
+ORDER BY id ASC
+`
+
+type ListSlackReactionsParams struct {
+	ChannelID string `json:"channel_id"`
+	MessageTs string `json:"message_ts"`
+	SessionID string `json:"session_id"`
+}
+
+type ListSlackReactionsRow struct {
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+}
+
+func (q *Queries) ListSlackReactions(ctx context.Context, arg ListSlackReactionsParams) ([]ListSlackReactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSlackReactions, arg.ChannelID, arg.MessageTs, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSlackReactionsRow{}
+	for rows.Next() {
+		var i ListSlackReactionsRow
+		if err := rows.Scan(&i.Name, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlackScheduledMessages = `-- name: ListSlackScheduledMessages :many
+SELECT id, channel_id, user_id, text, post_at, session_id, created_at
+FROM slack_scheduled_messages
+WHERE session_id = ?
+ORDER BY post_at, id
+`
+
+func (q *Queries) ListSlackScheduledMessages(ctx context.Context, sessionID string) ([]SlackScheduledMessage, error) {
+	rows, err := q.db.QueryContext(ctx, listSlackScheduledMessages, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SlackScheduledMessage{}
+	for rows.Next() {
+		var i SlackScheduledMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChannelID,
+			&i.UserID,
+			&i.Text,
+			&i.PostAt,
+			&i.SessionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlackThreadMessages = `-- name: ListSlackThreadMessages :many
+SELECT type, user_id, text, timestamp, blocks, thread_ts
+FROM slack_messages
+WHERE channel_id = ?1 AND session_id = ?2
+  AND (timestamp = ?3 OR thread_ts = ?3)
+ORDER BY timestamp ASC
+`
+
+type ListSlackThreadMessagesParams struct {
+	ChannelID string `json:"channel_id"`
+	SessionID string `json:"session_id"`
+	ThreadTs  string `json:"thread_ts"`
+}
+
+type ListSlackThreadMessagesRow struct {
+	Type      string         `json:"type"`
+	UserID    string         `json:"user_id"`
+	Text      string         `json:"text"`
+	Timestamp string         `json:"timestamp"`
+	Blocks    sql.NullString `json:"blocks"`
+	ThreadTs  sql.NullString `json:"thread_ts"`
+}
+
+func (q *Queries) ListSlackThreadMessages(ctx context.Context, arg ListSlackThreadMessagesParams) ([]ListSlackThreadMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSlackThreadMessages, arg.ChannelID, arg.SessionID, arg.ThreadTs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSlackThreadMessagesRow{}
+	for rows.Next() {
+		var i ListSlackThreadMessagesRow
+		if err := rows.Scan(
+			&i.Type,
+			&i.UserID,
+			&i.Text,
+			&i.Timestamp,
+			&i.Blocks,
+			&i.ThreadTs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlackUsers = `-- name: ListSlackUsers :many
+SELECT id, team_id, name, real_name, email, display_name, first_name, last_name,
+       is_admin, is_owner, is_bot, timezone, timezone_label, timezone_offset,
+       image_24, image_32, image_48, image_72, image_192, image_512, created_at
+FROM slack_users
+WHERE session_id = ?
+ORDER BY created_at ASC, id ASC
+LIMIT ? OFFSET ?
+`
+
+type ListSlackUsersParams struct {
+	SessionID string `json:"session_id"`
+	Limit     int64  `json:"limit"`
+	Offset    int64  `json:"offset"`
+}
+
+type ListSlackUsersRow struct {
+	ID             string         `json:"id"`
+	TeamID         string         `json:"team_id"`
+	Name           string         `json:"name"`
+	RealName       string         `json:"real_name"`
+	Email          sql.NullString `json:"email"`
+	DisplayName    sql.NullString `json:"display_name"`
+	FirstName      sql.NullString `json:"first_name"`
+	LastName       sql.NullString `json:"last_name"`
+	IsAdmin        int64          `json:"is_admin"`
+	IsOwner        int64          `json:"is_owner"`
+	IsBot          int64          `json:"is_bot"`
+	Timezone       sql.NullString `json:"timezone"`
+	TimezoneLabel  sql.NullString `json:"timezone_label"`
+	TimezoneOffset sql.NullInt64  `json:"timezone_offset"`
+	Image24        sql.NullString `json:"image_24"`
+	Image32        sql.NullString `json:"image_32"`
+	Image48        sql.NullString `json:"image_48"`
+	Image72        sql.NullString `json:"image_72"`
+	Image192       sql.NullString `json:"image_192"`
+	Image512       sql.NullString `json:"image_512"`
+	CreatedAt      int64          `json:"created_at"`
+}
+
+func (q *Queries) ListSlackUsers(ctx context.Context, arg ListSlackUsersParams) ([]ListSlackUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSlackUsers, arg.SessionID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSlackUsersRow{}
+	for rows.Next() {
+		var i ListSlackUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.RealName,
+			&i.Email,
+			&i.DisplayName,
+			&i.FirstName,
+			&i.LastName,
+			&i.IsAdmin,
+			&i.IsOwner,
+			&i.IsBot,
+			&i.Timezone,
+			&i.TimezoneLabel,
+			&i.TimezoneOffset,
+			&i.Image24,
+			&i.Image32,
+			&i.Image48,
+			&i.Image72,
+			&i.Image192,
+			&i.Image512,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsersBySession = `-- name: ListUsersBySession :many
 SELECT id, team_id, name, real_name, email, display_name, first_name, last_name,
        is_admin, is_owner, is_bot, timezone, timezone_label, timezone_offset,
@@ -605,3 +1228,67 @@ func (q *Queries) UpdateSessionAccess(ctx context.Context, id string) error {
 	_, err := q.db.ExecContext(ctx, updateSessionAccess, id)
 	return err
 }
+
+const updateSlackChannelPurpose = `-- name: UpdateSlackChannelPurpose :exec
+UPDATE slack_channels
+SET purpose = ?, purpose_creator = ?, purpose_last_set = unixepoch()
+WHERE id = ? AND session_id = ?
+`
+
+type UpdateSlackChannelPurposeParams struct {
+	Purpose        string `json:"purpose"`
+	PurposeCreator string `json:"purpose_creator"`
+	ID             string `json:"id"`
+	SessionID      string `json:"session_id"`
+}
+
+func (q *Queries) UpdateSlackChannelPurpose(ctx context.Context, arg UpdateSlackChannelPurposeParams) error {
+	_, err := q.db.ExecContext(ctx, updateSlackChannelPurpose,
+		arg.Purpose,
+		arg.PurposeCreator,
+		arg.ID,
+		arg.SessionID,
+	)
+	return err
+}
+
+const updateSlackChannelTopic = `-- name: UpdateSlackChannelTopic :exec
+UPDATE slack_channels
+SET topic = ?, topic_creator = ?, topic_last_set = unixepoch()
+WHERE id = ? AND session_id = ?
+`
+
+type UpdateSlackChannelTopicParams struct {
+	Topic        string `json:"topic"`
+	TopicCreator string `json:"topic_creator"`
+	ID           string `json:"id"`
+	SessionID    string `json:"session_id"`
+}
+
+func (q *Queries) UpdateSlackChannelTopic(ctx context.Context, arg UpdateSlackChannelTopicParams) error {
+	_, err := q.db.ExecContext(ctx, updateSlackChannelTopic,
+		arg.Topic,
+		arg.TopicCreator,
+		arg.ID,
+		arg.SessionID,
+	)
+	return err
+}
+
+const upsertSlackResponseWarnings = `-- name: UpsertSlackResponseWarnings :exec
+INSERT INTO slack_response_warnings (session_id, warnings, updated_at)
+VALUES (?, ?, unixepoch())
+ON CONFLICT(session_id) DO UPDATE SET
+    warnings = excluded.warnings,
+    updated_at = unixepoch()
+`
+
+type UpsertSlackResponseWarningsParams struct {
+	SessionID string `json:"session_id"`
+	Warnings  string `json:"warnings"`
+}
+
+func (q *Queries) UpsertSlackResponseWarnings(ctx context.Context, arg UpsertSlackResponseWarningsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSlackResponseWarnings, arg.SessionID, arg.Warnings)
+	return err
+}
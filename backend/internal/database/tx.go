@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// txBeginner is implemented by *sql.DB
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// ExecTx runs fn inside a transaction, committing on success and rolling back on error.
+// If the queries are already bound to a transaction, fn runs within it.
+func (q *Queries) ExecTx(ctx context.Context, fn func(*Queries) error) error {
+	beginner, ok := q.db.(txBeginner)
+	if !ok {
+		return fn(q)
+	}
+
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(q.WithTx(tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
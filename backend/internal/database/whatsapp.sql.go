@@ -10,6 +10,17 @@ import (
 	"database/sql"
 )
 
+const countWhatsAppSessionObjects = `-- name: CountWhatsAppSessionObjects :one
+SELECT COUNT(*) FROM whatsapp_messages WHERE session_id = ?
+`
+
+func (q *Queries) CountWhatsAppSessionObjects(ctx context.Context, sessionID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countWhatsAppSessionObjects, sessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createWhatsAppMessage = `-- name: CreateWhatsAppMessage :exec
 INSERT INTO whatsapp_messages (id, phone_number_id, to_number, message_type, text_body, media_url, caption, template_name, language_code, session_id)
 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
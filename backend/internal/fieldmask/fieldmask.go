@@ -0,0 +1,153 @@
+// Package fieldmask implements the partial-response "fields" parameter of Google APIs,
+// e.g. "spreadsheetId,sheets(properties/title,data.rowData)".
+package fieldmask
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// node maps selected field names to their sub-selections; a nil node selects the whole value
+type node map[string]node
+
+// Apply returns value with only the fields selected by mask. The value is round-tripped
+// through JSON, so the result is built from maps and slices ready to be encoded.
+func Apply(value interface{}, mask string) (interface{}, error) {
+	selection, err := parse(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+	return filter(decoded, selection), nil
+}
+
+// filter keeps the selected keys of objects, applying the selection to every element of arrays
+func filter(value interface{}, selection node) interface{} {
+	if selection == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for key, child := range v {
+			sub, ok := selection[key]
+			if !ok {
+				sub, ok = selection["*"]
+			}
+			if ok {
+				result[key] = filter(child, sub)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, filter(item, selection))
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// parser reads a mask such as "a,b/c,d(e,f.g)"; "/" and "." both separate nested names
+type parser struct {
+	mask string
+	pos  int
+}
+
+func parse(mask string) (node, error) {
+	p := &parser{mask: strings.ReplaceAll(mask, " ", "")}
+	selection, err := p.list()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.mask) {
+		return nil, fmt.Errorf("invalid field mask %q: unexpected %q at position %d", mask, p.mask[p.pos], p.pos)
+	}
+	return selection, nil
+}
+
+func (p *parser) list() (node, error) {
+	selection := node{}
+	for {
+		if err := p.item(selection); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.mask) && p.mask[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return selection, nil
+	}
+}
+
+func (p *parser) item(selection node) error {
+	start := p.pos
+	for p.pos < len(p.mask) && !strings.ContainsRune(",()", rune(p.mask[p.pos])) {
+		p.pos++
+	}
+	names := strings.FieldsFunc(p.mask[start:p.pos], func(r rune) bool {
+		return r == '/' || r == '.'
+	})
+	if len(names) == 0 {
+		return fmt.Errorf("invalid field mask %q: empty field name at position %d", p.mask, start)
+	}
+
+	var sub node
+	if p.pos < len(p.mask) && p.mask[p.pos] == '(' {
+		p.pos++
+		var err error
+		if sub, err = p.list(); err != nil {
+			return err
+		}
+		if p.pos >= len(p.mask) || p.mask[p.pos] != ')' {
+			return fmt.Errorf("invalid field mask %q: missing ')'", p.mask)
+		}
+		p.pos++
+	}
+
+	insert(selection, names, sub)
+	return nil
+}
+
+// insert adds a nested path to a selection, widening rather than narrowing existing entries
+func insert(selection node, names []string, sub node) {
+	for _, name := range names[:len(names)-1] {
+		child, ok := selection[name]
+		if ok && child == nil {
+			return
+		}
+		if !ok {
+			child = node{}
+			selection[name] = child
+		}
+		selection = child
+	}
+	merge(selection, names[len(names)-1], sub)
+}
+
+func merge(selection node, name string, sub node) {
+	existing, ok := selection[name]
+	switch {
+	case !ok:
+		selection[name] = sub
+	case existing == nil:
+		// Already selected in full
+	case sub == nil:
+		selection[name] = nil
+	default:
+		for key, child := range sub {
+			merge(existing, key, child)
+		}
+	}
+}
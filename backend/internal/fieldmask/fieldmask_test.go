@@ -0,0 +1,67 @@
+package fieldmask_test
+
+import (
+	"testing"
+
+	"github.com/recreate-run/nova-simulators/internal/fieldmask"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	value := map[string]interface{}{
+		"spreadsheetId": "abc",
+		"properties":    map[string]interface{}{"title": "Budget", "locale": "en_US"},
+		"sheets": []interface{}{
+			map[string]interface{}{
+				"properties": map[string]interface{}{"sheetId": 1, "title": "Sheet1"},
+				"data":       []interface{}{map[string]interface{}{"rowData": []interface{}{}}},
+			},
+		},
+	}
+
+	t.Run("TopLevelFields", func(t *testing.T) {
+		result, err := fieldmask.Apply(value, "spreadsheetId")
+		require.NoError(t, err, "Apply should succeed")
+		assert.Equal(t, map[string]interface{}{"spreadsheetId": "abc"}, result)
+	})
+
+	t.Run("NestedPaths", func(t *testing.T) {
+		result, err := fieldmask.Apply(value, "properties.title,sheets/properties/title")
+		require.NoError(t, err, "Apply should succeed")
+		assert.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{"title": "Budget"},
+			"sheets": []interface{}{
+				map[string]interface{}{"properties": map[string]interface{}{"title": "Sheet1"}},
+			},
+		}, result)
+	})
+
+	t.Run("SubSelections", func(t *testing.T) {
+		result, err := fieldmask.Apply(value, "sheets(properties(sheetId),data)")
+		require.NoError(t, err, "Apply should succeed")
+		assert.Equal(t, map[string]interface{}{
+			"sheets": []interface{}{
+				map[string]interface{}{
+					"properties": map[string]interface{}{"sheetId": float64(1)},
+					"data":       []interface{}{map[string]interface{}{"rowData": []interface{}{}}},
+				},
+			},
+		}, result)
+	})
+
+	t.Run("WiderSelectionWins", func(t *testing.T) {
+		result, err := fieldmask.Apply(value, "properties/title,properties")
+		require.NoError(t, err, "Apply should succeed")
+		assert.Equal(t, map[string]interface{}{
+			"properties": map[string]interface{}{"title": "Budget", "locale": "en_US"},
+		}, result)
+	})
+
+	t.Run("InvalidMasks", func(t *testing.T) {
+		for _, mask := range []string{"", "sheets(properties", "a,,b", "a)"} {
+			_, err := fieldmask.Apply(value, mask)
+			assert.Error(t, err, "Mask %q should be rejected", mask)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+// Package listcap enforces a global cap on how many items a single list response may contain,
+// protecting clients from runaway responses in large sessions.
+package listcap
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// TruncatedHeader is set on list responses that were cut short by the cap
+const TruncatedHeader = "X-Nova-Truncated"
+
+// maxItems is the cap; zero or less disables it
+var maxItems atomic.Int64
+
+// SetMax sets the maximum number of items per list response; n <= 0 disables the cap
+func SetMax(n int) {
+	maxItems.Store(int64(n))
+}
+
+// Max returns the current cap, or 0 if none is set
+func Max() int {
+	if n := maxItems.Load(); n > 0 {
+		return int(n)
+	}
+	return 0
+}
+
+// Clamp limits a requested page size to the cap, for handlers with native pagination that
+// can hand out a cursor for the rest. It reports whether the size was reduced.
+func Clamp(pageSize int) (int, bool) {
+	limit := Max()
+	if limit == 0 || pageSize <= limit {
+		return pageSize, false
+	}
+	return limit, true
+}
+
+// MarkTruncated flags a response as cut short by the cap
+func MarkTruncated(w http.ResponseWriter) {
+	w.Header().Set(TruncatedHeader, "true")
+}
+
+// Truncate cuts items to the cap for handlers without pagination, flagging the response
+// when anything was dropped
+func Truncate[T any](w http.ResponseWriter, items []T) []T {
+	limit := Max()
+	if limit == 0 || len(items) <= limit {
+		return items
+	}
+	MarkTruncated(w)
+	return items[:limit]
+}
@@ -0,0 +1,55 @@
+package listcap_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/recreate-run/nova-simulators/internal/listcap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Cleanup(func() {
+		listcap.SetMax(0)
+	})
+
+	items := []int{1, 2, 3, 4, 5}
+
+	// No cap leaves lists untouched
+	rec := httptest.NewRecorder()
+	assert.Equal(t, items, listcap.Truncate(rec, items), "Uncapped lists should be unchanged")
+	assert.Empty(t, rec.Header().Get(listcap.TruncatedHeader), "Uncapped lists should not be flagged")
+
+	// A low cap truncates and flags the response
+	listcap.SetMax(2)
+	rec = httptest.NewRecorder()
+	assert.Equal(t, []int{1, 2}, listcap.Truncate(rec, items), "List should be cut to the cap")
+	assert.Equal(t, "true", rec.Header().Get(listcap.TruncatedHeader), "Truncated lists should be flagged")
+
+	// Lists within the cap are not flagged
+	rec = httptest.NewRecorder()
+	assert.Equal(t, []int{1}, listcap.Truncate(rec, items[:1]), "Short lists should be unchanged")
+	assert.Empty(t, rec.Header().Get(listcap.TruncatedHeader), "Short lists should not be flagged")
+}
+
+func TestClamp(t *testing.T) {
+	t.Cleanup(func() {
+		listcap.SetMax(0)
+	})
+
+	size, clamped := listcap.Clamp(50)
+	assert.Equal(t, 50, size, "Uncapped page sizes should be unchanged")
+	assert.False(t, clamped, "Uncapped page sizes should not be clamped")
+
+	listcap.SetMax(10)
+	size, clamped = listcap.Clamp(50)
+	assert.Equal(t, 10, size, "Page size should be reduced to the cap")
+	assert.True(t, clamped, "Reduced page sizes should be reported")
+
+	size, clamped = listcap.Clamp(5)
+	assert.Equal(t, 5, size, "Smaller page sizes should be unchanged")
+	assert.False(t, clamped, "Smaller page sizes should not be clamped")
+
+	listcap.SetMax(-1)
+	assert.Equal(t, 0, listcap.Max(), "Negative caps should disable the limit")
+}
@@ -2,18 +2,24 @@ package logging
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"sync"
 	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
 var (
-	logFile *os.File
-	logMu   sync.Mutex
+	logFile  *os.File
+	logStore *database.Queries
+	logMu    sync.Mutex
 )
 
 // InitLogger initializes the unified log file
@@ -30,6 +36,14 @@ func InitLogger(filename string) error {
 	return nil
 }
 
+// InitStore enables capturing every request into the request-log store so it can be replayed
+func InitStore(queries *database.Queries) {
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	logStore = queries
+}
+
 // CloseLogger closes the log file
 func CloseLogger() {
 	logMu.Lock()
@@ -118,7 +132,32 @@ Response Body (JSON):
 			if logFile != nil {
 				_, _ = logFile.WriteString(logEntry)
 			}
+			store := logStore
 			logMu.Unlock()
+
+			if store != nil {
+				storeRequest(store, simulatorName, r, requestBody, capture, duration)
+			}
 		})
 	}
 }
+
+// storeRequest records a request and its response in the request-log store
+func storeRequest(store *database.Queries, simulatorName string, r *http.Request, requestBody string, capture *responseCapture, duration time.Duration) {
+	headers, _ := json.Marshal(r.Header)
+	_, err := store.CreateRequestLog(context.Background(), database.CreateRequestLogParams{
+		SessionID:    session.FromContext(r.Context()),
+		Simulator:    simulatorName,
+		Method:       r.Method,
+		Path:         r.URL.Path,
+		RawQuery:     r.URL.RawQuery,
+		Headers:      string(headers),
+		RequestBody:  []byte(requestBody),
+		StatusCode:   int64(capture.statusCode),
+		ResponseBody: capture.body.Bytes(),
+		DurationUs:   duration.Microseconds(),
+	})
+	if err != nil {
+		log.Printf("[%s] ✗ Failed to store request log: %v", simulatorName, err)
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/config"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// formPaths lists, per simulator, the path prefixes whose endpoints take form-encoded bodies.
+// Every other endpoint with a body takes JSON.
+var formPaths = map[string][]string{
+	"slack": {"/api/"},
+}
+
+// rawPaths lists path prefixes that accept arbitrary media, such as file uploads
+var rawPaths = map[string][]string{
+	"slack": {"/upload/"},
+}
+
+// ContentType returns a middleware that rejects request bodies sent with a Content-Type the
+// endpoint doesn't accept, answering 415 Unsupported Media Type in the provider's error
+// envelope. It only applies when strict_content_type is enabled for the session/simulator.
+func ContentType(configManager *config.Manager, simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength == 0 || matchesPrefix(rawPaths[simulatorName], r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sessionID := session.FromContext(r.Context())
+			cfg := configManager.GetValidationConfig(r.Context(), sessionID, simulatorName)
+			if !cfg.StrictContentType {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil {
+				mediaType = ""
+			}
+
+			if matchesPrefix(formPaths[simulatorName], r.URL.Path) {
+				if mediaType != "application/x-www-form-urlencoded" && mediaType != "multipart/form-data" {
+					writeUnsupportedMediaType(w, sessionID, simulatorName, mediaType, "invalid_form_data")
+					return
+				}
+			} else if !isJSONMediaType(mediaType) {
+				writeUnsupportedMediaType(w, sessionID, simulatorName, mediaType, "Unsupported Media Type: request body must be JSON")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isJSONMediaType accepts application/json and its variants, such as application/vnd.api+json
+// and the text/json some SDKs send
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func matchesPrefix(prefixes []string, path string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeUnsupportedMediaType(w http.ResponseWriter, sessionID, simulatorName, mediaType, message string) {
+	log.Printf("[%s] ✗ Rejected body with Content-Type %q", simulatorName, mediaType)
+	provider, ok := apierror.ForSimulator(simulatorName)
+	if !ok {
+		http.Error(w, message, http.StatusUnsupportedMediaType)
+		return
+	}
+	apierror.WriteForSession(w, sessionID, provider, http.StatusUnsupportedMediaType, message)
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// FailNextHeader forces the request carrying it to fail with the given status, e.g.
+// "X-Nova-Fail-Next: 500"
+const FailNextHeader = "X-Nova-Fail-Next"
+
+// FailNext returns a middleware that answers a request carrying FailNextHeader with that status
+// in the simulator's error envelope instead of running the handler, regardless of the session's
+// fault config or overrides. Values that are not a 4xx or 5xx status are ignored.
+func FailNext(simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(FailNextHeader)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			status, err := strconv.Atoi(value)
+			if err != nil || status < 400 || status > 599 {
+				log.Printf("[%s] ✗ Ignoring invalid %s %q", simulatorName, FailNextHeader, value)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log.Printf("[%s] ⚡ %s forced %s %s to fail with %d", simulatorName, FailNextHeader, r.Method, r.URL.Path, status)
+			provider, _ := apierror.ForSimulator(simulatorName)
+			apierror.WriteForSession(w, session.FromContext(r.Context()), provider, status, http.StatusText(status))
+		})
+	}
+}
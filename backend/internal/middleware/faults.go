@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/recreate-run/nova-simulators/internal/config"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// faultResolution is the granularity of fault rates
+const faultResolution = 1000000
+
+// Faults returns a middleware that injects configured response faults. A truncated response
+// advertises its full Content-Length, sends only part of the body and then closes the
+// connection, so clients see an unexpected EOF while decoding.
+//
+// It must wrap any middleware that replaces the ResponseWriter, since truncation needs to
+// hijack the underlying connection.
+func Faults(configManager *config.Manager, simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sessionID := session.FromContext(r.Context())
+			cfg := configManager.GetFaultsConfig(r.Context(), sessionID, simulatorName)
+			if cfg.TruncateRate <= 0 || session.RandomIntn(sessionID, faultResolution) >= int(cfg.TruncateRate*faultResolution) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			hijacker, ok := w.(http.Hijacker)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, r)
+			writeTruncated(hijacker, rec, simulatorName)
+		})
+	}
+}
+
+// writeTruncated writes the status line, headers and the first half of the recorded body
+// straight to the connection, then closes it
+func writeTruncated(hijacker http.Hijacker, rec *httptest.ResponseRecorder, simulatorName string) {
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		log.Printf("[%s] ✗ Failed to hijack connection for truncation: %v", simulatorName, err)
+		return
+	}
+	defer conn.Close()
+
+	body := rec.Body.Bytes()
+	header := rec.Header().Clone()
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	header.Set("Connection", "close")
+
+	_, _ = fmt.Fprintf(buf, "HTTP/1.1 %d %s\r\n", rec.Code, http.StatusText(rec.Code))
+	_ = header.Write(buf)
+	_, _ = buf.WriteString("\r\n")
+	_, _ = buf.Write(body[:len(body)/2])
+	_ = buf.Flush()
+
+	log.Printf("[%s] ⚡ Truncated response after %d of %d bytes", simulatorName, len(body)/2, len(body))
+}
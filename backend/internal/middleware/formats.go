@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+)
+
+// formatValidators check string fields whose content has structure beyond their JSON type.
+// Each returns an empty string when the value is acceptable.
+var formatValidators = map[string]func(value string) string{
+	"rfc822": validateRFC822,
+}
+
+// validateRFC822 checks a base64url-encoded RFC 822 message has a From header and well-formed MIME parts
+func validateRFC822(value string) string {
+	raw, err := base64.URLEncoding.DecodeString(value)
+	if err != nil {
+		raw, err = base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return "must be a base64url-encoded RFC 822 message"
+		}
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Sprintf("invalid RFC 822 message: %v", err)
+	}
+
+	from := msg.Header.Get("From")
+	if strings.TrimSpace(from) == "" {
+		return "message is missing a From header"
+	}
+	if _, err := mail.ParseAddressList(from); err != nil {
+		return fmt.Sprintf("invalid From header: %v", err)
+	}
+
+	if err := validateMIMEPart(msg.Header.Get("Content-Type"), msg.Body); err != nil {
+		return fmt.Sprintf("invalid MIME structure: %v", err)
+	}
+	return ""
+}
+
+// validateMIMEPart walks multipart bodies recursively, reporting the first malformed part
+func validateMIMEPart(contentType string, body io.Reader) error {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil
+	}
+	if params["boundary"] == "" {
+		return fmt.Errorf("%s without a boundary", mediaType)
+	}
+
+	reader := multipart.NewReader(body, params["boundary"])
+	parts := 0
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateMIMEPart(part.Header.Get("Content-Type"), part); err != nil {
+			return err
+		}
+		parts++
+	}
+	if parts == 0 {
+		return fmt.Errorf("%s has no parts", mediaType)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/config"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// headerInjector adds configured headers just before the status line is written
+type headerInjector struct {
+	http.ResponseWriter
+	request     *http.Request
+	rules       []config.HeaderRule
+	wroteHeader bool
+}
+
+func (hi *headerInjector) WriteHeader(statusCode int) {
+	if !hi.wroteHeader {
+		hi.wroteHeader = true
+		for i := range hi.rules {
+			rule := &hi.rules[i]
+			if HeaderRuleMatches(rule, statusCode, hi.request) {
+				for name, value := range rule.Headers {
+					hi.Header().Set(name, value)
+				}
+			}
+		}
+	}
+	hi.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (hi *headerInjector) Write(b []byte) (int, error) {
+	if !hi.wroteHeader {
+		hi.WriteHeader(http.StatusOK)
+	}
+	return hi.ResponseWriter.Write(b)
+}
+
+// ResponseHeaders returns a middleware that injects the session's configured response headers,
+// so clients that branch on headers like Retry-After or ETag can be exercised. It wraps the
+// rate limiter and overrides, so rules can target their responses too (e.g. 429s).
+func ResponseHeaders(configManager *config.Manager, simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers := configManager.GetHeadersConfig(context.Background(), session.FromContext(r.Context()), simulatorName)
+			if len(headers.Rules) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(&headerInjector{ResponseWriter: w, request: r, rules: headers.Rules}, r)
+		})
+	}
+}
+
+// HeaderRuleMatches reports whether a header rule applies to a request and response status.
+// An empty path matches every endpoint; otherwise it uses the same syntax as overrides.
+func HeaderRuleMatches(rule *config.HeaderRule, statusCode int, r *http.Request) bool {
+	if rule.Status != 0 && rule.Status != statusCode {
+		return false
+	}
+	if rule.Path == "" {
+		return rule.Method == "" || rule.Method == "*" || strings.EqualFold(rule.Method, r.Method)
+	}
+	return OverrideMatches(rule.Method, rule.Path, r)
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// IdempotencyKeyHeader is the request header clients use to make retries safe
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyRecorder captures the response while still writing it to the client
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *idempotencyRecorder) WriteHeader(statusCode int) {
+	rec.statusCode = statusCode
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Idempotency returns a middleware that replays the first successful response for a repeated
+// Idempotency-Key instead of running the handler again
+func Idempotency(queries *database.Queries, simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The mount prefix is stripped by now, so include the simulator to keep paths distinct
+			params := database.GetIdempotentResponseParams{
+				SessionID:      session.FromContext(r.Context()),
+				Method:         r.Method,
+				Path:           "/" + simulatorName + r.URL.Path,
+				IdempotencyKey: key,
+			}
+
+			cached, err := queries.GetIdempotentResponse(context.Background(), params)
+			if err == nil {
+				log.Printf("[%s] ↺ Replaying response for Idempotency-Key %s", simulatorName, key)
+				if cached.ContentType != "" {
+					w.Header().Set("Content-Type", cached.ContentType)
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(int(cached.StatusCode))
+				_, _ = w.Write(cached.Body)
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// Only successful responses are stored so failed attempts can be retried
+			if rec.statusCode < 200 || rec.statusCode >= 300 {
+				return
+			}
+			err = queries.SaveIdempotentResponse(context.Background(), database.SaveIdempotentResponseParams{
+				SessionID:      params.SessionID,
+				Method:         params.Method,
+				Path:           params.Path,
+				IdempotencyKey: params.IdempotencyKey,
+				StatusCode:     int64(rec.statusCode),
+				ContentType:    rec.Header().Get("Content-Type"),
+				Body:           rec.body.Bytes(),
+			})
+			if err != nil {
+				log.Printf("[%s] ✗ Failed to store idempotent response: %v", simulatorName, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// OverrideHeader marks a response that came from a registered override, naming its ID
+const OverrideHeader = "X-Nova-Override"
+
+// Overrides returns a middleware that answers requests matching a session's registered
+// overrides with the canned status and body instead of running the handler. The most
+// recently registered matching override wins.
+func Overrides(queries *database.Queries, simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			overrides, err := queries.ListResponseOverrides(context.Background(), database.ListResponseOverridesParams{
+				SessionID: session.FromContext(r.Context()),
+				Simulator: simulatorName,
+			})
+			if err != nil {
+				log.Printf("[%s] ✗ Failed to load response overrides: %v", simulatorName, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for i := range overrides {
+				override := &overrides[i]
+				if !OverrideMatches(override.Method, override.PathPattern, r) {
+					continue
+				}
+				log.Printf("[%s] ⚡ Override %d answered %s %s with %d", simulatorName, override.ID, r.Method, r.URL.Path, override.StatusCode)
+				if override.ContentType != "" {
+					w.Header().Set("Content-Type", override.ContentType)
+				}
+				w.Header().Set(OverrideHeader, strconv.FormatInt(override.ID, 10))
+				w.WriteHeader(int(override.StatusCode))
+				_, _ = w.Write([]byte(override.Body))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// OverrideMatches reports whether a request matches an override's method and path pattern.
+// An empty method or "*" matches any method; the pattern uses path.Match syntax against the
+// simulator-relative path, so "*" matches a single segment (e.g. "/repos/*/*/pulls").
+func OverrideMatches(method, pattern string, r *http.Request) bool {
+	if method != "" && method != "*" && !strings.EqualFold(method, r.Method) {
+		return false
+	}
+	matched, err := path.Match(pattern, r.URL.Path)
+	return err == nil && matched
+}
+
+// ValidOverridePattern reports whether pattern is a well-formed override path pattern
+func ValidOverridePattern(pattern string) bool {
+	if !strings.HasPrefix(pattern, "/") {
+		return false
+	}
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
@@ -87,9 +87,36 @@ func (rl *RateLimiter) checkRateLimit(ctx context.Context, sessionID string) err
 	return nil
 }
 
+// state reports the session's per-minute and per-day buckets. Windows that have not started or
+// have expired are reported full, resetting one window from now.
+func (rl *RateLimiter) state(ctx context.Context, sessionID string) []config.RateLimitBucket {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	cfg := rl.configManager.GetRateLimitConfig(ctx, sessionID, rl.simulator)
+
+	now := time.Now()
+	minute := config.RateLimitBucket{Name: "minute", Limit: cfg.PerMinute, Remaining: cfg.PerMinute, ResetAt: now.Add(1 * time.Minute)}
+	daily := config.RateLimitBucket{Name: "day", Limit: cfg.PerDay, Remaining: cfg.PerDay, ResetAt: now.Add(24 * time.Hour)}
+
+	if state := rl.rateLimits[sessionID]; state != nil {
+		if !now.After(state.minuteReset) {
+			minute.Remaining = max(cfg.PerMinute-state.minuteCount, 0)
+			minute.ResetAt = state.minuteReset
+		}
+		if !now.After(state.dailyReset) {
+			daily.Remaining = max(cfg.PerDay-state.dailyCount, 0)
+			daily.ResetAt = state.dailyReset
+		}
+	}
+
+	return []config.RateLimitBucket{minute, daily}
+}
+
 // RateLimit returns a middleware that enforces rate limits per session
 func RateLimit(configManager *config.Manager, simulatorName string) func(http.Handler) http.Handler {
 	limiter := NewRateLimiter(configManager, simulatorName)
+	configManager.RegisterRateLimiter(simulatorName, limiter.state)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// Recovery returns a middleware that turns a handler panic into a 500 in the simulator's error
+// envelope, so a bug in one simulator fails that request instead of taking down the server.
+func Recovery(simulatorName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				// ErrAbortHandler is how handlers deliberately abort a response; let net/http handle it
+				if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(recovered)
+				}
+
+				log.Printf("[%s] ✗ Panic serving %s %s: %v\n%s", simulatorName, r.Method, r.URL.Path, recovered, debug.Stack())
+				provider, _ := apierror.ForSimulator(simulatorName)
+				apierror.WriteForSession(w, session.FromContext(r.Context()), provider, http.StatusInternalServerError, "Internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+// requestSchemas lists the expected JSON request bodies of implemented endpoints, keyed by simulator.
+// Simulators without an entry pass through validation unchanged.
+var requestSchemas = map[string][]RequestSchema{
+	"gmail": {
+		{
+			Method: "POST",
+			Path:   "users/*/messages/send",
+			Fields: []FieldRule{
+				{Path: "raw", Type: "string", Required: true, Format: "rfc822"},
+				{Path: "threadId", Type: "string"},
+			},
+		},
+		{
+			Method: "POST",
+			Path:   "users/*/messages/import",
+			Fields: []FieldRule{
+				{Path: "raw", Type: "string", Required: true, Format: "rfc822"},
+				{Path: "labelIds", Type: "array"},
+			},
+		},
+	},
+	"outlook": {
+		{
+			Method: "POST",
+			Path:   "me/sendMail",
+			Fields: []FieldRule{
+				{Path: "message", Type: "object", Required: true},
+				{Path: "message.subject", Type: "string"},
+				{Path: "message.body", Type: "object"},
+				{Path: "message.toRecipients", Type: "array", Required: true},
+				{Path: "saveToSentItems", Type: "boolean"},
+			},
+		},
+	},
+	"pagerduty": {
+		{
+			Method: "POST",
+			Path:   "incidents",
+			Fields: []FieldRule{
+				{Path: "incident", Type: "object", Required: true},
+				{Path: "incident.title", Type: "string", Required: true},
+				{Path: "incident.service", Type: "object", Required: true},
+				{Path: "incident.service.id", Type: "string", Required: true},
+				{Path: "incident.urgency", Type: "string"},
+				{Path: "incident.priority", Type: "object"},
+			},
+		},
+		{
+			Method: "PUT",
+			Path:   "incidents",
+			Fields: []FieldRule{
+				{Path: "incidents", Type: "array", Required: true},
+			},
+		},
+	},
+	"resend": {
+		{
+			Method: "POST",
+			Path:   "emails",
+			Fields: []FieldRule{
+				{Path: "from", Type: "string", Required: true},
+				{Path: "to", Type: "array", Required: true},
+				{Path: "subject", Type: "string", Required: true},
+				{Path: "html", Type: "string"},
+			},
+		},
+	},
+	"jira": {
+		{
+			Method: "POST",
+			Path:   "rest/api/2/issue",
+			Fields: []FieldRule{
+				{Path: "fields", Type: "object", Required: true},
+				{Path: "fields.project", Type: "object", Required: true},
+				{Path: "fields.project.key", Type: "string", Required: true},
+				{Path: "fields.issuetype", Type: "object", Required: true},
+				{Path: "fields.summary", Type: "string", Required: true},
+				{Path: "fields.description", Type: "string"},
+			},
+		},
+	},
+}
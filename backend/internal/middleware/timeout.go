@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	mathrand "math/rand"
 	"net/http"
 	"time"
 
@@ -21,8 +20,7 @@ func Timeout(configManager *config.Manager, simulatorName string) func(http.Hand
 			if cfg.MaxMs > 0 {
 				delay := cfg.MinMs
 				if cfg.MaxMs > cfg.MinMs {
-					//nolint:gosec // G404: Using math/rand for delay simulation, not security-critical
-					delay += mathrand.Intn(cfg.MaxMs - cfg.MinMs)
+					delay += session.RandomIntn(sessionID, cfg.MaxMs-cfg.MinMs)
 				}
 				time.Sleep(time.Duration(delay) * time.Millisecond)
 			}
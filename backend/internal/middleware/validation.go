@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/config"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// FieldRule describes one expected field in a JSON request body
+type FieldRule struct {
+	Path     string // Dotted path from the body root, e.g. "message.subject"
+	Type     string // "string", "number", "boolean", "object", "array", or "" for any
+	Required bool
+	Format   string // Optional content check for string fields, e.g. "rfc822"
+}
+
+// RequestSchema describes the expected JSON body of an endpoint
+type RequestSchema struct {
+	Method string
+	Path   string // Matched against the end of the request path; "*" matches one segment
+	Fields []FieldRule
+}
+
+// ValidationError describes the first field that failed validation
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("Invalid value at '%s': %s", e.Field, e.Message)
+}
+
+// Validation returns a middleware that validates JSON request bodies against endpoint schemas
+// when validation is enabled for the session/simulator
+func Validation(configManager *config.Manager, simulatorName string) func(http.Handler) http.Handler {
+	schemas := requestSchemas[simulatorName]
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Validation is opt-in so lenient clients keep working by default
+			sessionID := session.FromContext(r.Context())
+			cfg := configManager.GetValidationConfig(r.Context(), sessionID, simulatorName)
+			if !cfg.Enabled || !strings.Contains(r.Header.Get("Content-Type"), "json") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			schema := matchSchema(schemas, r.Method, r.URL.Path)
+			if schema == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Read the body and restore it for the handler
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeValidationError(w, sessionID, simulatorName, &ValidationError{Message: "Failed to read request body"})
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if verr := ValidateBody(schema, body); verr != nil {
+				writeValidationError(w, sessionID, simulatorName, verr)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ValidateBody checks a JSON body against a schema and returns the first offending field
+func ValidateBody(schema *RequestSchema, body []byte) *ValidationError {
+	var root map[string]interface{}
+	if err := json.Unmarshal(body, &root); err != nil {
+		return &ValidationError{Message: "Request body must be a JSON object"}
+	}
+
+	for _, rule := range schema.Fields {
+		value, found := lookupField(root, rule.Path)
+		if !found {
+			if rule.Required {
+				return &ValidationError{Field: rule.Path, Message: "required field is missing"}
+			}
+			continue
+		}
+		if rule.Type != "" && jsonType(value) != rule.Type {
+			return &ValidationError{
+				Field:   rule.Path,
+				Message: fmt.Sprintf("expected %s, got %s", rule.Type, jsonType(value)),
+			}
+		}
+		if validate, ok := formatValidators[rule.Format]; ok {
+			if str, isString := value.(string); isString {
+				if msg := validate(str); msg != "" {
+					return &ValidationError{Field: rule.Path, Message: msg}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchSchema finds the schema whose method and path suffix match the request
+func matchSchema(schemas []RequestSchema, method, path string) *RequestSchema {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range schemas {
+		if schemas[i].Method != method {
+			continue
+		}
+		pattern := strings.Split(schemas[i].Path, "/")
+		if len(pattern) > len(segments) {
+			continue
+		}
+		tail := segments[len(segments)-len(pattern):]
+		matched := true
+		for j, p := range pattern {
+			if p != "*" && p != tail[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return &schemas[i]
+		}
+	}
+	return nil
+}
+
+// lookupField walks a dotted path through nested JSON objects
+func lookupField(root map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = root
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj[key]
+		if !ok || current == nil {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return "null"
+	}
+}
+
+// writeValidationError answers with a 400 in the simulator's error envelope. Google's envelope
+// also names the offending field, as its field violations do.
+func writeValidationError(w http.ResponseWriter, sessionID, simulatorName string, verr *ValidationError) {
+	log.Printf("[%s] ✗ Request validation failed: %s", simulatorName, verr.Error())
+	if provider, _ := apierror.ForSimulator(simulatorName); provider != apierror.Google {
+		apierror.WriteForSession(w, sessionID, provider, http.StatusBadRequest, verr.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    400,
+			"message": verr.Error(),
+			"status":  "INVALID_ARGUMENT",
+			"field":   verr.Field,
+			"errors": []map[string]interface{}{
+				{
+					"message": verr.Error(),
+					"domain":  "global",
+					"reason":  "invalidArgument",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,120 @@
+// Package pagetoken issues signed, expiring pagination cursors. A token carries the offset of
+// the next page and when it was issued, signed together with the session, the list and a hash of
+// the list's filters with a key generated at startup, so tampered, foreign or stale tokens are
+// rejected instead of silently changing results. Age is measured on the session clock, so
+// advancing a session's clock expires its tokens. Tokens do not survive a restart.
+package pagetoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// DefaultTTL is how long a token stays valid after it was issued
+const DefaultTTL = time.Hour
+
+var (
+	// ErrInvalid is returned for tokens that are malformed, tampered with or issued for another
+	// session, list or set of filters
+	ErrInvalid = errors.New("invalid page token")
+	// ErrExpired is returned for tokens older than the TTL
+	ErrExpired = errors.New("page token has expired")
+)
+
+var (
+	key = newKey()
+	ttl atomic.Int64
+)
+
+func init() {
+	ttl.Store(int64(DefaultTTL))
+}
+
+func newKey() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("pagetoken: failed to generate signing key: " + err.Error())
+	}
+	return b
+}
+
+// SetTTL sets how long tokens stay valid; d <= 0 restores DefaultTTL
+func SetTTL(d time.Duration) {
+	if d <= 0 {
+		d = DefaultTTL
+	}
+	ttl.Store(int64(d))
+}
+
+// Filters returns a copy of a request's parameters without its paging parameters, leaving the
+// filters that select the list. Clients may change the page size between pages, but not the list.
+func Filters(values url.Values, paging ...string) url.Values {
+	filters := make(url.Values, len(values))
+	for name, value := range values {
+		filters[name] = value
+	}
+	for _, name := range paging {
+		filters.Del(name)
+	}
+	return filters
+}
+
+// Encode returns a token for offset within the session's list named by scope, e.g.
+// "gmail.messages", and selected by filters
+func Encode(sessionID, scope string, filters url.Values, offset int) string {
+	payload := strconv.Itoa(offset) + ":" + strconv.FormatInt(session.Now(sessionID).UnixNano(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + sign(sessionID, scope, filters, payload)
+}
+
+// Decode verifies a token issued by Encode for the same session, scope and filters and returns
+// its offset
+func Decode(sessionID, scope string, filters url.Values, token string) (int, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalid
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, ErrInvalid
+	}
+	payload := string(raw)
+	if !hmac.Equal([]byte(signature), []byte(sign(sessionID, scope, filters, payload))) {
+		return 0, ErrInvalid
+	}
+
+	offsetStr, issuedStr, ok := strings.Cut(payload, ":")
+	if !ok {
+		return 0, ErrInvalid
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalid
+	}
+	issued, err := strconv.ParseInt(issuedStr, 10, 64)
+	if err != nil {
+		return 0, ErrInvalid
+	}
+	if session.Now(sessionID).Sub(time.Unix(0, issued)) > time.Duration(ttl.Load()) {
+		return 0, ErrExpired
+	}
+	return offset, nil
+}
+
+func sign(sessionID, scope string, filters url.Values, payload string) string {
+	// Encode sorts by key, so the hash does not depend on parameter order
+	filterHash := sha256.Sum256([]byte(filters.Encode()))
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(sessionID + "\n" + scope + "\n" + base64.RawURLEncoding.EncodeToString(filterHash[:]) + "\n" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
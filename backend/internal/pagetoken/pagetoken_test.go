@@ -0,0 +1,92 @@
+package pagetoken_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/pagetoken"
+	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	filters := url.Values{"q": {"from:alice"}}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		token := pagetoken.Encode("session-a", "gmail.messages", filters, 30)
+		offset, err := pagetoken.Decode("session-a", "gmail.messages", filters, token)
+		require.NoError(t, err, "Decode should accept its own token")
+		assert.Equal(t, 30, offset, "Offset should round-trip")
+	})
+
+	t.Run("TamperedTokenRejected", func(t *testing.T) {
+		token := []byte(pagetoken.Encode("session-a", "gmail.messages", filters, 30))
+		// Flip a character in the payload so it no longer matches the signature
+		if token[0] == 'A' {
+			token[0] = 'B'
+		} else {
+			token[0] = 'A'
+		}
+		_, err := pagetoken.Decode("session-a", "gmail.messages", filters, string(token))
+		require.ErrorIs(t, err, pagetoken.ErrInvalid, "Tampered token should be rejected")
+	})
+
+	t.Run("MalformedTokenRejected", func(t *testing.T) {
+		for _, token := range []string{"", "MzA=", "not-a-token.sig", "MzA6MQ"} {
+			_, err := pagetoken.Decode("session-a", "gmail.messages", filters, token)
+			require.ErrorIs(t, err, pagetoken.ErrInvalid, "Malformed token %q should be rejected", token)
+		}
+	})
+
+	t.Run("OtherScopeRejected", func(t *testing.T) {
+		token := pagetoken.Encode("session-a", "slack.users", filters, 30)
+		_, err := pagetoken.Decode("session-a", "gmail.messages", filters, token)
+		require.ErrorIs(t, err, pagetoken.ErrInvalid, "Token from another list should be rejected")
+	})
+
+	t.Run("OtherSessionRejected", func(t *testing.T) {
+		token := pagetoken.Encode("session-b", "gmail.messages", filters, 30)
+		_, err := pagetoken.Decode("session-a", "gmail.messages", filters, token)
+		require.ErrorIs(t, err, pagetoken.ErrInvalid, "Token from another session should be rejected")
+	})
+
+	t.Run("OtherFiltersRejected", func(t *testing.T) {
+		token := pagetoken.Encode("session-a", "gmail.messages", filters, 30)
+		_, err := pagetoken.Decode("session-a", "gmail.messages", url.Values{"q": {"from:bob"}}, token)
+		require.ErrorIs(t, err, pagetoken.ErrInvalid, "Token for other filters should be rejected")
+	})
+
+	t.Run("PagingParamsIgnored", func(t *testing.T) {
+		token := pagetoken.Encode("session-a", "gmail.messages",
+			pagetoken.Filters(url.Values{"q": {"from:alice"}, "maxResults": {"10"}}, "maxResults", "pageToken"), 30)
+		offset, err := pagetoken.Decode("session-a", "gmail.messages",
+			pagetoken.Filters(url.Values{"q": {"from:alice"}, "maxResults": {"20"}, "pageToken": {token}}, "maxResults", "pageToken"), token)
+		require.NoError(t, err, "Changing the page size should keep the token valid")
+		assert.Equal(t, 30, offset, "Offset should round-trip")
+	})
+
+	t.Run("SessionClockExpiresToken", func(t *testing.T) {
+		t.Cleanup(func() {
+			session.ClearClock("session-clock")
+		})
+
+		token := pagetoken.Encode("session-clock", "gmail.messages", filters, 30)
+		session.Advance("session-clock", pagetoken.DefaultTTL+time.Minute)
+		_, err := pagetoken.Decode("session-clock", "gmail.messages", filters, token)
+		require.ErrorIs(t, err, pagetoken.ErrExpired, "Advancing the session clock past the TTL should expire the token")
+	})
+
+	t.Run("ExpiredTokenRejected", func(t *testing.T) {
+		pagetoken.SetTTL(time.Nanosecond)
+		t.Cleanup(func() {
+			pagetoken.SetTTL(0)
+		})
+
+		token := pagetoken.Encode("session-a", "gmail.messages", filters, 30)
+		time.Sleep(time.Millisecond)
+		_, err := pagetoken.Decode("session-a", "gmail.messages", filters, token)
+		require.ErrorIs(t, err, pagetoken.ErrExpired, "Stale token should be rejected")
+	})
+}
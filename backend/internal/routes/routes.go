@@ -0,0 +1,107 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Route is a method and path pattern a simulator handler recognizes.
+// Path segments in braces, e.g. "{owner}", are placeholders.
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// Handler serves the route table of every simulator
+type Handler struct {
+	routes map[string][]Route
+}
+
+// NewHandler creates a handler serving the built-in simulator route table, with each
+// simulator's paths under the mount prefix returned by prefix
+func NewHandler(prefix func(simulator string) string) *Handler {
+	return &Handler{
+		routes: Mounted(prefix),
+	}
+}
+
+// Mounted returns the route table with each simulator's paths under the mount prefix
+// returned by prefix
+func Mounted(prefix func(simulator string) string) map[string][]Route {
+	mounted := make(map[string][]Route, len(simulatorRoutes))
+	for simulator, simRoutes := range simulatorRoutes {
+		mountPrefix := prefix(simulator)
+		entries := make([]Route, 0, len(simRoutes))
+		for _, route := range simRoutes {
+			entries = append(entries, Route{Method: route.Method, Path: mountPrefix + route.Path})
+		}
+		mounted[simulator] = entries
+	}
+	return mounted
+}
+
+// ServeHTTP handles GET /api/routes
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.routes); err != nil {
+		log.Printf("Failed to encode routes: %v", err)
+	}
+}
+
+// Counts returns how many routes each simulator recognizes
+func Counts() map[string]int {
+	counts := make(map[string]int, len(simulatorRoutes))
+	for simulator, simRoutes := range simulatorRoutes {
+		counts[simulator] = len(simRoutes)
+	}
+	return counts
+}
+
+// Match returns the simulator route a request resolves to. The path and the returned route are
+// relative to the simulator's mount prefix. When several routes match, the one with the fewest
+// placeholders wins, so "/messages/send" is preferred over "/messages/{messageId}".
+func Match(simulator, method, path string) (Route, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var best Route
+	bestPlaceholders := -1
+	for _, route := range simulatorRoutes[simulator] {
+		if !strings.EqualFold(route.Method, method) {
+			continue
+		}
+		placeholders, ok := matchSegments(strings.Split(strings.Trim(route.Path, "/"), "/"), segments)
+		if ok && (bestPlaceholders < 0 || placeholders < bestPlaceholders) {
+			best = route
+			bestPlaceholders = placeholders
+		}
+	}
+	return best, bestPlaceholders >= 0
+}
+
+// matchSegments reports whether path segments fit a route pattern and how many placeholders it used
+func matchSegments(pattern, segments []string) (int, bool) {
+	if len(pattern) != len(segments) {
+		return 0, false
+	}
+	placeholders := 0
+	for i, part := range pattern {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if segments[i] == "" {
+				return 0, false
+			}
+			placeholders++
+			continue
+		}
+		if part != segments[i] {
+			return 0, false
+		}
+	}
+	return placeholders, true
+}
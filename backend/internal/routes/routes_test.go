@@ -0,0 +1,78 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/recreate-run/nova-simulators/internal/routes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesEndpoint(t *testing.T) {
+	server := httptest.NewServer(routes.NewHandler(func(simulator string) string {
+		if simulator == "github" {
+			return "/api.github.test"
+		}
+		return "/" + simulator
+	}))
+	defer server.Close()
+
+	t.Run("ListsGitHubIssuesAndPulls", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/api/routes", http.NoBody)
+		require.NoError(t, err, "Failed to create request")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Request should succeed")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Expected 200 OK")
+
+		var table map[string][]routes.Route
+		err = json.NewDecoder(resp.Body).Decode(&table)
+		require.NoError(t, err, "Failed to decode response")
+
+		github := table["github"]
+		require.NotEmpty(t, github, "GitHub entry should be present")
+		assert.Contains(t, github, routes.Route{Method: "GET", Path: "/api.github.test/api/v3/repos/{owner}/{repo}/issues"})
+		assert.Contains(t, github, routes.Route{Method: "POST", Path: "/api.github.test/api/v3/repos/{owner}/{repo}/issues"})
+		assert.Contains(t, github, routes.Route{Method: "GET", Path: "/api.github.test/api/v3/repos/{owner}/{repo}/pulls"})
+		assert.Contains(t, github, routes.Route{Method: "PUT", Path: "/api.github.test/api/v3/repos/{owner}/{repo}/pulls/{number}/merge"})
+		assert.Contains(t, table["gmail"], routes.Route{Method: "GET", Path: "/gmail/v1/users/{userId}/messages"},
+			"Simulators without a custom prefix should keep their default")
+
+		for simulator, entries := range table {
+			assert.NotEmpty(t, entries, "Simulator %s should list routes", simulator)
+		}
+	})
+
+	t.Run("RejectsNonGet", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/api/routes", http.NoBody)
+		require.NoError(t, err, "Failed to create request")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Request should succeed")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "Expected 405")
+	})
+}
+
+func TestMatch(t *testing.T) {
+	route, ok := routes.Match("github", "GET", "/api/v3/repos/acme/widgets/pulls/7")
+	require.True(t, ok, "PR path should match")
+	assert.Equal(t, "/api/v3/repos/{owner}/{repo}/pulls/{number}", route.Path, "Placeholders should match any segment")
+
+	route, ok = routes.Match("gmail", "POST", "/v1/users/me/messages/send")
+	require.True(t, ok, "Send path should match")
+	assert.Equal(t, "/v1/users/{userId}/messages/send", route.Path, "The me alias should match the userId placeholder")
+
+	route, ok = routes.Match("datadog", "GET", "/api/v1/monitor/search")
+	require.True(t, ok, "Monitor search path should match")
+	assert.Equal(t, "/api/v1/monitor/search", route.Path, "Literal segments should win over placeholders")
+
+	_, ok = routes.Match("github", "DELETE", "/api/v3/repos/acme/widgets/pulls/7")
+	assert.False(t, ok, "Method should be part of the match")
+
+	_, ok = routes.Match("github", "GET", "/api/v3/unknown")
+	assert.False(t, ok, "Unknown paths should not match")
+}
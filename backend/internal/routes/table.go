@@ -0,0 +1,232 @@
+package routes
+
+// simulatorRoutes lists the routes each simulator handler recognizes, keyed by simulator.
+// Paths are relative to the prefix the simulator is mounted under in the main server.
+// Keep this in sync with the routing switch in each simulator's ServeHTTP.
+var simulatorRoutes = map[string][]Route{
+	"slack": {
+		{Method: "POST", Path: "/api/auth.test"},
+		{Method: "POST", Path: "/api/chat.postMessage"},
+		{Method: "POST", Path: "/api/chat.postEphemeral"},
+		{Method: "POST", Path: "/api/chat.scheduleMessage"},
+		{Method: "POST", Path: "/api/chat.scheduledMessages.list"},
+		{Method: "POST", Path: "/api/chat.deleteScheduledMessage"},
+		{Method: "POST", Path: "/api/conversations.list"},
+		{Method: "POST", Path: "/api/conversations.history"},
+		{Method: "POST", Path: "/api/conversations.replies"},
+		{Method: "POST", Path: "/api/conversations.info"},
+		{Method: "POST", Path: "/api/conversations.join"},
+		{Method: "POST", Path: "/api/conversations.leave"},
+		{Method: "POST", Path: "/api/conversations.setTopic"},
+		{Method: "POST", Path: "/api/conversations.setPurpose"},
+		{Method: "POST", Path: "/api/reactions.add"},
+		{Method: "POST", Path: "/api/reactions.get"},
+		{Method: "POST", Path: "/api/files.getUploadURLExternal"},
+		{Method: "POST", Path: "/api/files.completeUploadExternal"},
+		{Method: "POST", Path: "/api/users.info"},
+		{Method: "POST", Path: "/api/users.list"},
+		{Method: "POST", Path: "/upload/{fileId}"},
+		{Method: "GET", Path: "/debug/ephemerals"},
+		{Method: "GET", Path: "/debug/warnings"},
+		{Method: "PUT", Path: "/debug/warnings"},
+	},
+	"gmail": {
+		{Method: "POST", Path: "/v1/users/{userId}/messages/send"},
+		{Method: "POST", Path: "/v1/users/{userId}/messages/import"},
+		{Method: "POST", Path: "/v1/users/{userId}/messages/batchDelete"},
+		{Method: "GET", Path: "/v1/users/{userId}/messages"},
+		{Method: "GET", Path: "/v1/users/{userId}/messages/{messageId}"},
+		{Method: "DELETE", Path: "/v1/users/{userId}/messages/{messageId}"},
+		{Method: "POST", Path: "/v1/users/{userId}/messages/{messageId}/modify"},
+		{Method: "POST", Path: "/v1/users/{userId}/messages/{messageId}/trash"},
+		{Method: "POST", Path: "/v1/users/{userId}/messages/{messageId}/untrash"},
+		{Method: "GET", Path: "/v1/users/{userId}/threads"},
+		{Method: "GET", Path: "/v1/users/{userId}/threads/{threadId}"},
+		{Method: "POST", Path: "/v1/users/{userId}/threads/{threadId}/modify"},
+		{Method: "GET", Path: "/v1/users/{userId}/messages/{messageId}/attachments/{attachmentId}"},
+		{Method: "POST", Path: "/v1/users/{userId}/watch"},
+		{Method: "POST", Path: "/v1/users/{userId}/stop"},
+		{Method: "GET", Path: "/v1/users/{userId}/settings/sendAs"},
+		{Method: "POST", Path: "/v1/users/{userId}/settings/sendAs"},
+		{Method: "GET", Path: "/v1/users/{userId}/settings/sendAs/{sendAsEmail}"},
+		{Method: "PUT", Path: "/v1/users/{userId}/settings/sendAs/{sendAsEmail}"},
+		{Method: "PATCH", Path: "/v1/users/{userId}/settings/sendAs/{sendAsEmail}"},
+		{Method: "DELETE", Path: "/v1/users/{userId}/settings/sendAs/{sendAsEmail}"},
+		{Method: "GET", Path: "/debug/watch"},
+	},
+	"gdocs": {
+		{Method: "POST", Path: "/v1/documents"},
+		{Method: "GET", Path: "/v1/documents/{documentId}"},
+		{Method: "POST", Path: "/v1/documents/{documentId}:batchUpdate"},
+	},
+	"gsheets": {
+		{Method: "POST", Path: "/v4/spreadsheets"},
+		{Method: "GET", Path: "/v4/spreadsheets/{spreadsheetId}"},
+		{Method: "POST", Path: "/v4/spreadsheets/{spreadsheetId}:batchUpdate"},
+		{Method: "POST", Path: "/v4/spreadsheets/{spreadsheetId}/developerMetadata:search"},
+		{Method: "GET", Path: "/v4/spreadsheets/{spreadsheetId}/values/{range}"},
+		{Method: "PUT", Path: "/v4/spreadsheets/{spreadsheetId}/values/{range}"},
+		{Method: "POST", Path: "/v4/spreadsheets/{spreadsheetId}/values/{range}:append"},
+	},
+	"datadog": {
+		{Method: "POST", Path: "/api/v2/incidents"},
+		{Method: "GET", Path: "/api/v2/incidents"},
+		{Method: "GET", Path: "/api/v2/incidents/{incidentId}"},
+		{Method: "PATCH", Path: "/api/v2/incidents/{incidentId}"},
+		{Method: "GET", Path: "/api/v2/incidents/{incidentId}/relationships/attachments"},
+		{Method: "POST", Path: "/api/v2/incidents/{incidentId}/relationships/attachments"},
+		{Method: "GET", Path: "/api/v2/incidents/{incidentId}/attachments"},
+		{Method: "GET", Path: "/api/v2/incidents/{incidentId}/relationships/todos"},
+		{Method: "POST", Path: "/api/v2/incidents/{incidentId}/relationships/todos"},
+		{Method: "POST", Path: "/api/v1/monitor"},
+		{Method: "GET", Path: "/api/v1/monitor"},
+		{Method: "GET", Path: "/api/v1/monitor/search"},
+		{Method: "POST", Path: "/api/v1/monitor/validate"},
+		{Method: "GET", Path: "/api/v1/monitor/{monitorId}"},
+		{Method: "PUT", Path: "/api/v1/monitor/{monitorId}"},
+		{Method: "DELETE", Path: "/api/v1/monitor/{monitorId}"},
+		{Method: "POST", Path: "/api/v1/events"},
+		{Method: "POST", Path: "/api/v2/series"},
+		{Method: "POST", Path: "/api/v1/series"},
+		{Method: "GET", Path: "/api/v1/query"},
+		{Method: "GET", Path: "/api/v1/metrics/{metricName}"},
+		{Method: "PUT", Path: "/api/v1/metrics/{metricName}"},
+		{Method: "GET", Path: "/api/v2/metrics/{metricName}/tags"},
+		{Method: "GET", Path: "/api/v1/validate"},
+		{Method: "GET", Path: "/api/v2/users"},
+	},
+	"resend": {
+		{Method: "POST", Path: "/emails"},
+	},
+	"linear": {
+		{Method: "POST", Path: "/graphql"},
+		{Method: "PUT", Path: "/viewer"},
+	},
+	"github": {
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/issues"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/issues"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}"},
+		{Method: "PATCH", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/comments"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/comments"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/reactions"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/reactions"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/assignees"},
+		{Method: "DELETE", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/assignees"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/labels"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/labels"},
+		{Method: "DELETE", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/labels"},
+		{Method: "DELETE", Path: "/api/v3/repos/{owner}/{repo}/issues/{number}/labels/{name}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/issues/comments/{commentId}/reactions"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/issues/comments/{commentId}/reactions"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/pulls"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/pulls"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/pulls/{number}"},
+		{Method: "PATCH", Path: "/api/v3/repos/{owner}/{repo}/pulls/{number}"},
+		{Method: "PUT", Path: "/api/v3/repos/{owner}/{repo}/pulls/{number}/merge"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/pulls/{number}/comments"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/contents/{path}"},
+		{Method: "PUT", Path: "/api/v3/repos/{owner}/{repo}/contents/{path}"},
+		{Method: "DELETE", Path: "/api/v3/repos/{owner}/{repo}/contents/{path}"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/git/refs"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/git/trees/{sha}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/git/blobs/{sha}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/git/ref/heads/{branch}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/branches"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/branches/{branch}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/branches/{branch}/protection"},
+		{Method: "PUT", Path: "/api/v3/repos/{owner}/{repo}/branches/{branch}/protection"},
+		{Method: "DELETE", Path: "/api/v3/repos/{owner}/{repo}/branches/{branch}/protection"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/actions/workflows"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/actions/workflows/{workflowId}"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/actions/workflows/{workflowId}/dispatches"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/actions/runs"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/check-runs"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/check-runs/{checkRunId}"},
+		{Method: "PATCH", Path: "/api/v3/repos/{owner}/{repo}/check-runs/{checkRunId}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/commits"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/commits/{ref}"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/commits/{ref}/check-runs"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/hooks"},
+		{Method: "POST", Path: "/api/v3/repos/{owner}/{repo}/hooks"},
+		{Method: "GET", Path: "/api/v3/repos/{owner}/{repo}/hooks/{hookId}"},
+		{Method: "DELETE", Path: "/api/v3/repos/{owner}/{repo}/hooks/{hookId}"},
+		{Method: "POST", Path: "/api/v3/gists"},
+		{Method: "GET", Path: "/api/v3/gists"},
+		{Method: "GET", Path: "/api/v3/gists/{gistId}"},
+		{Method: "GET", Path: "/api/v3/user"},
+		{Method: "GET", Path: "/api/v3/users/{login}"},
+		{Method: "GET", Path: "/api/v3/orgs/{org}"},
+	},
+	"outlook": {
+		{Method: "POST", Path: "/v1.0/me/sendMail"},
+		{Method: "GET", Path: "/v1.0/me/messages"},
+		{Method: "GET", Path: "/v1.0/me/messages/{messageId}"},
+		{Method: "GET", Path: "/v1.0/me/mailFolders/{folderId}/messages/delta"},
+		{Method: "PATCH", Path: "/v1.0/me/messages/{messageId}"},
+		{Method: "POST", Path: "/v1.0/me/messages/{messageId}/reply"},
+		{Method: "POST", Path: "/v1.0/me/messages/{messageId}/replyAll"},
+		{Method: "POST", Path: "/v1.0/me/messages/{messageId}/forward"},
+		{Method: "POST", Path: "/v1.0/$batch"},
+	},
+	"pagerduty": {
+		{Method: "POST", Path: "/incidents"},
+		{Method: "GET", Path: "/incidents"},
+		{Method: "PUT", Path: "/incidents"},
+		{Method: "GET", Path: "/incidents/{incidentId}"},
+		{Method: "PUT", Path: "/incidents/{incidentId}"},
+		{Method: "GET", Path: "/services"},
+		{Method: "GET", Path: "/escalation_policies"},
+		{Method: "GET", Path: "/oncalls"},
+		{Method: "GET", Path: "/priorities"},
+	},
+	"hubspot": {
+		{Method: "POST", Path: "/crm/v3/objects/contacts"},
+		{Method: "GET", Path: "/crm/v3/objects/contacts"},
+		{Method: "POST", Path: "/crm/v3/objects/contacts/search"},
+		{Method: "GET", Path: "/crm/v3/objects/contacts/{contactId}"},
+		{Method: "PATCH", Path: "/crm/v3/objects/contacts/{contactId}"},
+		{Method: "POST", Path: "/crm/v3/objects/deals"},
+		{Method: "GET", Path: "/crm/v3/objects/deals"},
+		{Method: "GET", Path: "/crm/v3/objects/deals/{dealId}"},
+		{Method: "PATCH", Path: "/crm/v3/objects/deals/{dealId}"},
+		{Method: "POST", Path: "/crm/v3/objects/companies"},
+		{Method: "GET", Path: "/crm/v3/objects/companies"},
+		{Method: "GET", Path: "/crm/v3/objects/companies/{companyId}"},
+		{Method: "PATCH", Path: "/crm/v3/objects/companies/{companyId}"},
+		{Method: "PUT", Path: "/crm/v3/objects/{objectType}/{objectId}/associations/{toObjectType}/{toObjectId}/{associationType}"},
+		{Method: "POST", Path: "/crm/v4/associations/{fromObjectType}/{toObjectType}/batch/create"},
+		{Method: "GET", Path: "/crm/v3/properties/{objectType}"},
+		{Method: "POST", Path: "/crm/v3/properties/{objectType}"},
+		{Method: "GET", Path: "/crm/v3/properties/{objectType}/{propertyName}"},
+	},
+	"jira": {
+		{Method: "GET", Path: "/rest/api/2/project"},
+		{Method: "POST", Path: "/rest/api/2/project"},
+		{Method: "GET", Path: "/rest/api/2/project/{projectKey}"},
+		{Method: "DELETE", Path: "/rest/api/2/project/{projectKey}"},
+		{Method: "GET", Path: "/rest/api/2/status"},
+		{Method: "POST", Path: "/rest/api/2/issue"},
+		{Method: "GET", Path: "/rest/api/2/search"},
+		{Method: "POST", Path: "/rest/api/2/search"},
+		{Method: "GET", Path: "/rest/api/2/issue/createmeta"},
+		{Method: "GET", Path: "/rest/api/2/issue/{issueKey}/editmeta"},
+		{Method: "GET", Path: "/rest/api/2/issue/{issueKey}"},
+		{Method: "PUT", Path: "/rest/api/2/issue/{issueKey}"},
+		{Method: "GET", Path: "/rest/api/2/issue/{issueKey}/transitions"},
+		{Method: "POST", Path: "/rest/api/2/issue/{issueKey}/transitions"},
+		{Method: "GET", Path: "/rest/api/2/issue/{issueKey}/comment"},
+		{Method: "POST", Path: "/rest/api/2/issue/{issueKey}/comment"},
+	},
+	"whatsapp": {
+		{Method: "POST", Path: "/v21.0/{phoneNumberId}/messages"},
+	},
+	"postgres": {
+		{Method: "POST", Path: "/query"},
+		{Method: "POST", Path: "/exec"},
+		{Method: "GET", Path: "/schema"},
+		{Method: "POST", Path: "/seed"},
+		{Method: "DELETE", Path: "/session"},
+	},
+}
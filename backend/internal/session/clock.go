@@ -0,0 +1,58 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// clocks holds the frozen virtual time for each session in test clock mode
+	clocks  = make(map[string]time.Time)
+	clockMu sync.Mutex
+)
+
+// Now returns the current time for a session
+// Sessions in test clock mode read their virtual clock; others read the wall clock
+func Now(sessionID string) time.Time {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	if now, ok := clocks[sessionID]; ok {
+		return now
+	}
+	return time.Now()
+}
+
+// Advance moves a session's clock forward by d and returns the new time
+// The first call switches the session to test clock mode, freezing its clock at the wall
+// clock time so it only moves on later calls
+func Advance(sessionID string, d time.Duration) time.Time {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	now, ok := clocks[sessionID]
+	if !ok {
+		now = time.Now()
+	}
+	now = now.Add(d)
+	clocks[sessionID] = now
+	return now
+}
+
+// HasTestClock reports whether a session is in test clock mode, so its time only moves when
+// advanced
+func HasTestClock(sessionID string) bool {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	_, ok := clocks[sessionID]
+	return ok
+}
+
+// ClearClock returns a session to the wall clock
+func ClearClock(sessionID string) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	delete(clocks, sessionID)
+}
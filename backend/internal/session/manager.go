@@ -5,26 +5,86 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
 )
 
+// CounterFunc reports per-kind object counts a simulator holds for a session
+type CounterFunc func(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error)
+
+// TickFunc fires a simulator's scheduled work that is due at now, returning how many items fired
+type TickFunc func(ctx context.Context, queries *database.Queries, sessionID string, now time.Time) (int, error)
+
 // Manager handles session lifecycle operations
 type Manager struct {
-	queries *database.Queries
+	queries  *database.Queries
+	counters map[string]CounterFunc
+	tickers  map[string]TickFunc
 }
 
+// CreateSessionRequest is the optional body accepted by POST /sessions
+type CreateSessionRequest struct {
+	// Seed makes every random value generated for the session reproducible
+	Seed *int64 `json:"seed,omitempty"`
+	// ParentSession layers the new session over an existing one: reads fall through to the
+	// parent for objects the child doesn't have, while writes stay in the child
+	ParentSession string `json:"parent_session,omitempty"`
+}
+
+// WaitResponse reports the outcome of GET /sessions/{id}/wait
+type WaitResponse struct {
+	SessionID string `json:"session_id"`
+	Simulator string `json:"simulator"`
+	Type      string `json:"type,omitempty"`
+	Count     int64  `json:"count"`
+	Target    int64  `json:"target"`
+	Satisfied bool   `json:"satisfied"`
+	WaitedMs  int64  `json:"waited_ms"`
+}
+
+// TickResponse reports the outcome of POST /api/tick
+type TickResponse struct {
+	SessionID string         `json:"session_id"`
+	Now       time.Time      `json:"now"`
+	Fired     map[string]int `json:"fired"`
+}
+
+const (
+	defaultWaitTimeout = 10 * time.Second
+	maxWaitTimeout     = 60 * time.Second
+	waitPollInterval   = 50 * time.Millisecond
+	// waitWriteMargin is how long a wait's response may take to write once the wait is over
+	waitWriteMargin = 5 * time.Second
+)
+
 // NewManager creates a new session manager
 func NewManager(queries *database.Queries) *Manager {
+	setStore(queries)
 	return &Manager{
-		queries: queries,
+		queries:  queries,
+		counters: make(map[string]CounterFunc),
+		tickers:  make(map[string]TickFunc),
 	}
 }
 
+// RegisterCounter adds a simulator to the per-session fan-out used by /sessions/{id}/stats
+func (m *Manager) RegisterCounter(simulator string, counter CounterFunc) {
+	m.counters[simulator] = counter
+}
+
+// RegisterTicker adds a simulator's scheduled work to the fan-out run by /api/tick
+func (m *Manager) RegisterTicker(simulator string, ticker TickFunc) {
+	m.tickers[simulator] = ticker
+}
+
 // ServeHTTP implements http.Handler interface for session management endpoints
 func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	mux := http.NewServeMux()
@@ -36,7 +96,7 @@ func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (m *Manager) handleSessions(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		m.createSession(w)
+		m.createSession(w, r)
 	case http.MethodGet:
 		m.listSessions(w)
 	default:
@@ -45,7 +105,8 @@ func (m *Manager) handleSessions(w http.ResponseWriter, r *http.Request) {
 }
 
 func (m *Manager) handleSessionDetail(w http.ResponseWriter, r *http.Request) {
-	// Extract session ID from path: /sessions/{id} or /sessions/{id}/reset
+	// Extract session ID from path: /sessions/{id}, /sessions/{id}/reset, /sessions/{id}/stats
+	// or /sessions/{id}/wait
 	path := strings.TrimPrefix(r.URL.Path, "/sessions/")
 	parts := strings.Split(path, "/")
 
@@ -66,6 +127,26 @@ func (m *Manager) handleSessionDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check for /stats suffix
+	if len(parts) > 1 && parts[1] == "stats" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		m.sessionStats(w, sessionID)
+		return
+	}
+
+	// Check for /wait suffix
+	if len(parts) > 1 && parts[1] == "wait" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		m.waitForCount(w, r, sessionID)
+		return
+	}
+
 	// Handle DELETE for session deletion
 	if r.Method == http.MethodDelete {
 		m.deleteSession(w, sessionID)
@@ -75,9 +156,28 @@ func (m *Manager) handleSessionDetail(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 }
 
-func (m *Manager) createSession(w http.ResponseWriter) {
+func (m *Manager) createSession(w http.ResponseWriter, r *http.Request) {
 	log.Println("[session] → Creating new session")
 
+	// Body is optional - an empty body creates an unseeded session
+	var req CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The parent must exist and must not itself read through to another session
+	if req.ParentSession != "" {
+		if _, err := m.queries.GetSession(context.Background(), req.ParentSession); err != nil {
+			http.Error(w, fmt.Sprintf("Unknown parent session: %q", req.ParentSession), http.StatusBadRequest)
+			return
+		}
+		if Parent(req.ParentSession) != "" {
+			http.Error(w, "Parent session cannot itself have a parent", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Generate random session ID
 	sessionID := generateSessionID()
 
@@ -89,6 +189,36 @@ func (m *Manager) createSession(w http.ResponseWriter) {
 		return
 	}
 
+	// Persist the seed and install the deterministic random source
+	if req.Seed != nil {
+		err = m.queries.SetSessionSeed(context.Background(), database.SetSessionSeedParams{
+			SessionID: sessionID,
+			Seed:      *req.Seed,
+		})
+		if err != nil {
+			log.Printf("[session] ✗ Failed to store session seed: %v", err)
+			http.Error(w, "Failed to create session", http.StatusInternalServerError)
+			return
+		}
+		SetSeed(sessionID, *req.Seed)
+		log.Printf("[session]   Seeded session %s with %d", sessionID, *req.Seed)
+	}
+
+	// Persist the parent and start reading through to it
+	if req.ParentSession != "" {
+		err = m.queries.SetSessionParent(context.Background(), database.SetSessionParentParams{
+			SessionID:       sessionID,
+			ParentSessionID: req.ParentSession,
+		})
+		if err != nil {
+			log.Printf("[session] ✗ Failed to store parent session: %v", err)
+			http.Error(w, "Failed to create session", http.StatusInternalServerError)
+			return
+		}
+		SetParent(sessionID, req.ParentSession)
+		log.Printf("[session]   Session %s reads through to %s", sessionID, req.ParentSession)
+	}
+
 	// Create working directory for session
 	dir := NewDirectory(sessionID)
 	if err := dir.Create(); err != nil {
@@ -100,6 +230,9 @@ func (m *Manager) createSession(w http.ResponseWriter) {
 		"session_id": sessionID,
 		"status":     "created",
 	}
+	if req.ParentSession != "" {
+		response["parent_session"] = req.ParentSession
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
@@ -109,17 +242,76 @@ func (m *Manager) createSession(w http.ResponseWriter) {
 func (m *Manager) deleteSession(w http.ResponseWriter, sessionID string) {
 	log.Printf("[session] → Deleting session: %s", sessionID)
 
+	// A deleted session no longer has a seed or reads through to its parent. The rows go first
+	// so the in-memory state cleared below isn't reloaded from them.
+	if err := m.queries.DeleteSessionSeed(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete session seed: %v", err)
+	}
+	if err := m.queries.DeleteSessionParent(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete parent session link: %v", err)
+	}
+
+	m.clearSession(sessionID)
+	ClearParent(sessionID)
+
+	response := map[string]string{
+		"session_id": sessionID,
+		"status":     "deleted",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[session] ✓ Session deleted: %s", sessionID)
+}
+
+// clearSession drops a session's simulator data, working directory and in-memory state. The
+// seed and parent stored for it are kept.
+func (m *Manager) clearSession(sessionID string) {
 	// Delete all Slack data for this session
 	err := m.queries.DeleteSessionData(context.Background(), sessionID)
 	if err != nil {
 		log.Printf("[session] ✗ Failed to delete Slack session data: %v", err)
 	}
+	if err := m.queries.DeleteSlackScheduledMessages(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Slack scheduled messages: %v", err)
+	}
+	if err := m.queries.DeleteSlackChannelMembers(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Slack channel members: %v", err)
+	}
+	if err := m.queries.DeleteSlackReactions(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Slack reactions: %v", err)
+	}
+	if err := m.queries.DeleteSlackResponseWarnings(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Slack response warnings: %v", err)
+	}
+	if err := m.queries.DeleteSlackEphemeralMessages(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Slack ephemeral messages: %v", err)
+	}
 
 	// Delete all Gmail data for this session
 	err = m.queries.DeleteGmailSessionData(context.Background(), sessionID)
 	if err != nil {
 		log.Printf("[session] ✗ Failed to delete Gmail session data: %v", err)
 	}
+	if err := m.queries.DeleteGmailMessageTombstones(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Gmail message tombstones: %v", err)
+	}
+	if err := m.queries.DeleteGmailWatch(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Gmail watch: %v", err)
+	}
+	if err := m.queries.DeleteGmailSendAsAliases(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete Gmail send-as aliases: %v", err)
+	}
+
+	// Canned responses registered for the session stop short-circuiting its requests
+	if err := m.queries.DeleteResponseOverrides(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete response overrides: %v", err)
+	}
+
+	// Objects the session deleted from its parent become visible again
+	if err := m.queries.DeleteSessionTombstones(context.Background(), sessionID); err != nil {
+		log.Printf("[session] ✗ Failed to delete session tombstones: %v", err)
+	}
 
 	// Delete working directory for session
 	dir := NewDirectory(sessionID)
@@ -128,6 +320,19 @@ func (m *Manager) deleteSession(w http.ResponseWriter, sessionID string) {
 		// Continue even if directory deletion fails
 	}
 
+	// Drop the deterministic random source and test clock for this session
+	ClearSeed(sessionID)
+	ClearClock(sessionID)
+}
+
+func (m *Manager) resetSession(w http.ResponseWriter, sessionID string) {
+	log.Printf("[session] → Resetting session: %s", sessionID)
+
+	// Clearing everything is simpler than selective cleanup. The session entry, its seed and
+	// its parent are kept, so a reset child keeps reading through to its parent.
+	// Clearing the seed restarts its sequence, so the reset session replays identically
+	m.clearSession(sessionID)
+
 	response := map[string]string{
 		"session_id": sessionID,
 		"status":     "deleted",
@@ -135,17 +340,171 @@ func (m *Manager) deleteSession(w http.ResponseWriter, sessionID string) {
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[session] ✓ Session deleted: %s", sessionID)
+	log.Printf("[session] ✓ Session reset: %s", sessionID)
 }
 
-func (m *Manager) resetSession(w http.ResponseWriter, sessionID string) {
-	log.Printf("[session] → Resetting session: %s", sessionID)
+func (m *Manager) sessionStats(w http.ResponseWriter, sessionID string) {
+	log.Printf("[session] → Counting objects for session: %s", sessionID)
 
-	// Delete and recreate is simpler than selective cleanup
-	m.deleteSession(w, sessionID)
-	// Note: We don't recreate the session entry itself, just clear the data
+	stats := make(map[string]map[string]int64, len(m.counters))
+	for simulator, counter := range m.counters {
+		counts, err := counter(context.Background(), m.queries, sessionID)
+		if err != nil {
+			log.Printf("[session] ✗ Failed to count %s objects: %v", simulator, err)
+			http.Error(w, "Failed to count session objects", http.StatusInternalServerError)
+			return
+		}
+		stats[simulator] = counts
+	}
 
-	log.Printf("[session] ✓ Session reset: %s", sessionID)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+	log.Printf("[session] ✓ Counted objects for %d simulators", len(stats))
+}
+
+// waitForCount blocks until a simulator holds at least count objects for the session, or the
+// timeout elapses. type narrows the count to one kind of object; without it all kinds are summed.
+func (m *Manager) waitForCount(w http.ResponseWriter, r *http.Request, sessionID string) {
+	query := r.URL.Query()
+	simulator := query.Get("simulator")
+	kind := query.Get("type")
+
+	counter, ok := m.counters[simulator]
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown simulator: %q", simulator), http.StatusBadRequest)
+		return
+	}
+
+	target, err := strconv.ParseInt(query.Get("count"), 10, 64)
+	if err != nil || target < 0 {
+		http.Error(w, "count must be a non-negative integer", http.StatusBadRequest)
+		return
+	}
+
+	timeout := defaultWaitTimeout
+	if raw := query.Get("timeout"); raw != "" {
+		timeout, err = time.ParseDuration(raw)
+		if err != nil || timeout <= 0 {
+			http.Error(w, "timeout must be a positive duration, e.g. 10s", http.StatusBadRequest)
+			return
+		}
+		timeout = min(timeout, maxWaitTimeout)
+	}
+
+	log.Printf("[session] → Waiting up to %s for %d %s objects in session %s", timeout, target, simulator, sessionID)
+
+	// A wait may outlast the server's write timeout, so move the deadline past it
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + waitWriteMargin)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("[session] ✗ Failed to extend write deadline: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	start := time.Now()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	var count int64
+poll:
+	for {
+		counts, err := counter(context.Background(), m.queries, sessionID)
+		if err != nil {
+			log.Printf("[session] ✗ Failed to count %s objects: %v", simulator, err)
+			http.Error(w, "Failed to count session objects", http.StatusInternalServerError)
+			return
+		}
+		count = sumCounts(counts, kind)
+		if count >= target {
+			break
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			break poll
+		}
+	}
+
+	response := WaitResponse{
+		SessionID: sessionID,
+		Simulator: simulator,
+		Type:      kind,
+		Count:     count,
+		Target:    target,
+		Satisfied: count >= target,
+		WaitedMs:  time.Since(start).Milliseconds(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Satisfied {
+		// The final count is still reported so callers can see how far the session got
+		w.WriteHeader(http.StatusRequestTimeout)
+		log.Printf("[session] ✗ Timed out waiting for %s objects: %d/%d", simulator, count, target)
+	} else {
+		log.Printf("[session] ✓ Session %s reached %d/%d %s objects", sessionID, count, target, simulator)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleTick serves POST /api/tick?session=&duration=. It advances the session's test clock
+// and synchronously fires every registered simulator's work that has become due, so tests
+// don't wait on real timers. A zero duration fires work already due without moving the clock.
+func (m *Manager) HandleTick(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	sessionID := query.Get("session")
+	if sessionID == "" {
+		http.Error(w, "session is required", http.StatusBadRequest)
+		return
+	}
+
+	var duration time.Duration
+	if raw := query.Get("duration"); raw != "" {
+		var err error
+		duration, err = time.ParseDuration(raw)
+		if err != nil || duration < 0 {
+			http.Error(w, "duration must be a non-negative duration, e.g. 5m", http.StatusBadRequest)
+			return
+		}
+	}
+
+	log.Printf("[session] → Advancing clock for session %s by %s", sessionID, duration)
+
+	now := Advance(sessionID, duration)
+	fired := make(map[string]int, len(m.tickers))
+	for simulator, ticker := range m.tickers {
+		count, err := ticker(context.Background(), m.queries, sessionID, now)
+		if err != nil {
+			log.Printf("[session] ✗ Failed to fire %s scheduled work: %v", simulator, err)
+			http.Error(w, "Failed to fire scheduled work", http.StatusInternalServerError)
+			return
+		}
+		fired[simulator] = count
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(TickResponse{
+		SessionID: sessionID,
+		Now:       now,
+		Fired:     fired,
+	})
+	log.Printf("[session] ✓ Session %s clock at %s", sessionID, now.Format(time.RFC3339))
+}
+
+// sumCounts returns the count for one kind of object, or the total when kind is empty
+func sumCounts(counts map[string]int64, kind string) int64 {
+	if kind != "" {
+		return counts[kind]
+	}
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+	return total
 }
 
 func (m *Manager) listSessions(w http.ResponseWriter) {
@@ -2,6 +2,7 @@ package session_test
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/recreate-run/nova-simulators/internal/database"
 	"github.com/recreate-run/nova-simulators/internal/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,3 +238,125 @@ func TestSessionManagerDirectoryWithFiles(t *testing.T) {
 		assert.True(t, os.IsNotExist(err), "Test file should not exist")
 	})
 }
+
+func TestSessionManagerResetClearsData(t *testing.T) {
+	// Setup: Create test database and manager
+	queries := setupTestDB(t)
+	server := httptest.NewServer(session.NewManager(queries))
+	defer server.Close()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll("sessions")
+	})
+
+	ctx := context.Background()
+	sessionID := "reset-clears-data"
+	require.NoError(t, queries.CreateSession(ctx, sessionID), "Failed to create session")
+
+	// Setup: Store one object of each kind the reset has to clear
+	require.NoError(t, queries.CreateSlackScheduledMessage(ctx, database.CreateSlackScheduledMessageParams{
+		ID:        "Q1",
+		ChannelID: "C1",
+		UserID:    "U1",
+		Text:      "later",
+		PostAt:    1,
+		SessionID: sessionID,
+	}), "Failed to schedule message")
+	_, err := queries.JoinSlackChannel(ctx, database.JoinSlackChannelParams{
+		ChannelID: "C1",
+		UserID:    "U1",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err, "Failed to join channel")
+	_, err = queries.CreateSlackReaction(ctx, database.CreateSlackReactionParams{
+		ChannelID: "C1",
+		MessageTs: "1.000001",
+		Name:      "thumbsup",
+		UserID:    "U1",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err, "Failed to add reaction")
+	require.NoError(t, queries.UpsertSlackResponseWarnings(ctx, database.UpsertSlackResponseWarningsParams{
+		SessionID: sessionID,
+		Warnings:  `["superfluous_charset"]`,
+	}), "Failed to store response warnings")
+	require.NoError(t, queries.UpsertGmailWatch(ctx, database.UpsertGmailWatchParams{
+		SessionID:  sessionID,
+		TopicName:  "projects/demo/topics/mail",
+		HistoryID:  1,
+		Expiration: 1,
+	}), "Failed to store watch")
+	require.NoError(t, queries.UpsertGmailSendAsAlias(ctx, database.UpsertGmailSendAsAliasParams{
+		SessionID:   sessionID,
+		SendAsEmail: "owner@corp.example",
+		IsPrimary:   1,
+		IsDefault:   1,
+	}), "Failed to store send-as alias")
+	require.NoError(t, queries.CreateEphemeralMessage(ctx, database.CreateEphemeralMessageParams{
+		ChannelID: "C1",
+		UserID:    "U1",
+		Text:      "only you can see this",
+		Timestamp: "1.000002",
+		SessionID: sessionID,
+	}), "Failed to store ephemeral message")
+	_, err = queries.CreateResponseOverride(ctx, database.CreateResponseOverrideParams{
+		SessionID:   sessionID,
+		Simulator:   "github",
+		Method:      "GET",
+		PathPattern: "/repos/{owner}/{repo}",
+		StatusCode:  500,
+		Body:        `{"message":"boom"}`,
+		ContentType: "application/json",
+	})
+	require.NoError(t, err, "Failed to store response override")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/sessions/"+sessionID+"/reset", http.NoBody)
+	require.NoError(t, err, "Failed to create reset request")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to reset session")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Expected 200 OK")
+
+	scheduled, err := queries.ListSlackScheduledMessages(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Empty(t, scheduled, "Scheduled messages should not survive a reset")
+
+	members, err := queries.CountSlackChannelMembers(ctx, database.CountSlackChannelMembersParams{
+		ChannelID: "C1",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, members, "Channel members should not survive a reset")
+
+	reactions, err := queries.ListSlackReactions(ctx, database.ListSlackReactionsParams{
+		ChannelID: "C1",
+		MessageTs: "1.000001",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, reactions, "Reactions should not survive a reset")
+
+	_, err = queries.GetSlackResponseWarnings(ctx, sessionID)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Response warnings should not survive a reset")
+
+	_, err = queries.GetGmailWatch(ctx, sessionID)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "The Gmail watch should not survive a reset")
+
+	aliases, err := queries.ListGmailSendAsAliases(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Empty(t, aliases, "Send-as aliases should not survive a reset")
+
+	ephemerals, err := queries.ListEphemeralMessagesByUser(ctx, database.ListEphemeralMessagesByUserParams{
+		UserID:    "U1",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, ephemerals, "Ephemeral messages should not survive a reset")
+
+	overrides, err := queries.ListResponseOverrides(ctx, database.ListResponseOverridesParams{
+		SessionID: sessionID,
+		Simulator: "github",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, overrides, "Response overrides should not survive a reset")
+}
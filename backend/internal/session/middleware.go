@@ -39,6 +39,11 @@ func Middleware(next http.Handler) http.Handler {
 		// Add session ID to context
 		ctx := context.WithValue(r.Context(), SessionIDKey, sessionID)
 
+		// Child sessions carry their parent so reads can fall through to it
+		if parentID := Parent(sessionID); parentID != "" {
+			ctx = context.WithValue(ctx, ParentSessionKey, parentID)
+		}
+
 		// Call next handler with updated context
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -0,0 +1,183 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+)
+
+// ParentSessionKey is the context key for the parent of a child session
+const ParentSessionKey contextKey = "parent_session_id"
+
+var (
+	// parents maps child sessions to the session whose data they read through to. Sessions
+	// looked up without a parent map to "" so the database is only asked once.
+	parents  = make(map[string]string)
+	parentMu sync.RWMutex
+)
+
+// SetParent makes reads in sessionID fall through to parentID for objects the child doesn't have
+func SetParent(sessionID, parentID string) {
+	parentMu.Lock()
+	defer parentMu.Unlock()
+
+	parents[sessionID] = parentID
+}
+
+// ClearParent forgets the cached parent of a session; the next lookup reloads it from the
+// database
+func ClearParent(sessionID string) {
+	parentMu.Lock()
+	defer parentMu.Unlock()
+
+	delete(parents, sessionID)
+}
+
+// Parent returns the parent of a session, or "" if it has none. Links are loaded from the
+// session_parents table the first time a session is seen, so they survive restarts.
+func Parent(sessionID string) string {
+	parentMu.RLock()
+	parentID, ok := parents[sessionID]
+	parentMu.RUnlock()
+	if ok {
+		return parentID
+	}
+
+	if queries := currentStore(); queries != nil {
+		stored, err := queries.GetSessionParent(context.Background(), sessionID)
+		if err == nil {
+			parentID = stored
+		}
+	}
+
+	parentMu.Lock()
+	defer parentMu.Unlock()
+
+	// A link installed while the database was read wins over what was read
+	if current, ok := parents[sessionID]; ok {
+		return current
+	}
+	parents[sessionID] = parentID
+	return parentID
+}
+
+// ParentFromContext retrieves the parent session ID from context, or "" if the session has none
+func ParentFromContext(ctx context.Context) string {
+	parentID, ok := ctx.Value(ParentSessionKey).(string)
+	if !ok {
+		return ""
+	}
+	return parentID
+}
+
+// Hide records that the request's session deleted key, an object of the given kind that its
+// parent session holds, so ReadThrough and ListThrough stop falling through to it. It does
+// nothing for sessions without a parent.
+func Hide(ctx context.Context, kind, key string) error {
+	if ParentFromContext(ctx) == "" {
+		return nil
+	}
+	queries := currentStore()
+	if queries == nil {
+		return errors.New("session: no store for tombstones")
+	}
+	return queries.CreateSessionTombstone(context.Background(), database.CreateSessionTombstoneParams{
+		SessionID: FromContext(ctx),
+		Kind:      kind,
+		ObjectKey: key,
+	})
+}
+
+// hidden reports whether a session deleted its parent's object
+func hidden(sessionID, kind, key string) (bool, error) {
+	queries := currentStore()
+	if queries == nil {
+		return false, nil
+	}
+	_, err := queries.GetSessionTombstone(context.Background(), database.GetSessionTombstoneParams{
+		SessionID: sessionID,
+		Kind:      kind,
+		ObjectKey: key,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ReadThrough runs get against the request's session and, if the object isn't there, against
+// the parent session unless the child has hidden it. kind and key name the object the way Hide
+// was given them. Only reads should use it; writes always target the child session.
+func ReadThrough[T any](ctx context.Context, kind, key string, get func(sessionID string) (T, error)) (T, error) {
+	sessionID := FromContext(ctx)
+	result, err := get(sessionID)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return result, err
+	}
+	parentID := ParentFromContext(ctx)
+	if parentID == "" {
+		return result, err
+	}
+	gone, hideErr := hidden(sessionID, kind, key)
+	if hideErr != nil {
+		return result, hideErr
+	}
+	if gone {
+		return result, err
+	}
+	return get(parentID)
+}
+
+// ListThrough runs list against the request's session and, for a child session, appends the
+// parent's items unless the child holds an object with the same key or has hidden it. The
+// child's items come first. When list filters, all must list the child's objects without the
+// filters, so a child object that doesn't match still shadows the parent's; it is nil when
+// list returns everything.
+func ListThrough[T any](ctx context.Context, kind string, list, all func(sessionID string) ([]T, error), key func(T) string) ([]T, error) {
+	sessionID := FromContext(ctx)
+	items, err := list(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	parentID := ParentFromContext(ctx)
+	if parentID == "" {
+		return items, nil
+	}
+
+	owned := items
+	if all != nil {
+		if owned, err = all(sessionID); err != nil {
+			return nil, err
+		}
+	}
+	seen := make(map[string]bool, len(owned))
+	for i := range owned {
+		seen[key(owned[i])] = true
+	}
+	if queries := currentStore(); queries != nil {
+		deleted, err := queries.ListSessionTombstones(context.Background(), database.ListSessionTombstonesParams{
+			SessionID: sessionID,
+			Kind:      kind,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, objectKey := range deleted {
+			seen[objectKey] = true
+		}
+	}
+
+	inherited, err := list(parentID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range inherited {
+		if !seen[key(inherited[i])] {
+			items = append(items, inherited[i])
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,134 @@
+package session_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID    string
+	Value string
+}
+
+func TestListThroughShadowsParentItems(t *testing.T) {
+	stored := map[string][]record{
+		"parent": {{ID: "a", Value: "parent a"}, {ID: "b", Value: "parent b"}},
+		"child":  {{ID: "b", Value: "child b"}, {ID: "c", Value: "child c"}},
+	}
+	list := func(sessionID string) ([]record, error) {
+		return stored[sessionID], nil
+	}
+	key := func(r record) string { return r.ID }
+
+	ctx := session.WithSessionID(context.Background(), "child")
+	items, err := session.ListThrough(ctx, "records", list, nil, key)
+	require.NoError(t, err)
+	assert.Equal(t, stored["child"], items, "Without a parent only the child's items are listed")
+
+	ctx = context.WithValue(ctx, session.ParentSessionKey, "parent")
+	items, err = session.ListThrough(ctx, "records", list, nil, key)
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{ID: "b", Value: "child b"},
+		{ID: "c", Value: "child c"},
+		{ID: "a", Value: "parent a"},
+	}, items, "Child items should come first and shadow the parent's")
+}
+
+func TestListThroughShadowsFilteredOutItems(t *testing.T) {
+	stored := map[string][]record{
+		"parent": {{ID: "a", Value: "open"}, {ID: "b", Value: "open"}},
+		"child":  {{ID: "b", Value: "closed"}},
+	}
+	all := func(sessionID string) ([]record, error) {
+		return stored[sessionID], nil
+	}
+	open := func(sessionID string) ([]record, error) {
+		var matching []record
+		for _, r := range stored[sessionID] {
+			if r.Value == "open" {
+				matching = append(matching, r)
+			}
+		}
+		return matching, nil
+	}
+	key := func(r record) string { return r.ID }
+
+	ctx := session.WithSessionID(context.Background(), "child")
+	ctx = context.WithValue(ctx, session.ParentSessionKey, "parent")
+	items, err := session.ListThrough(ctx, "records", open, all, key)
+	require.NoError(t, err)
+	assert.Equal(t, []record{{ID: "a", Value: "open"}}, items,
+		"The child's object should hide the parent's even when the filter drops it")
+}
+
+func TestHideSkipsInheritedObjects(t *testing.T) {
+	// Tombstones are stored through the manager's database
+	session.NewManager(setupTestDB(t))
+
+	stored := map[string][]record{
+		"parent": {{ID: "a", Value: "parent a"}, {ID: "b", Value: "parent b"}},
+	}
+	list := func(sessionID string) ([]record, error) {
+		return stored[sessionID], nil
+	}
+	get := func(sessionID string) (record, error) {
+		for _, r := range stored[sessionID] {
+			if r.ID == "a" {
+				return r, nil
+			}
+		}
+		return record{}, sql.ErrNoRows
+	}
+	key := func(r record) string { return r.ID }
+
+	ctx := session.WithSessionID(context.Background(), "hide-child")
+	ctx = context.WithValue(ctx, session.ParentSessionKey, "parent")
+	require.NoError(t, session.Hide(ctx, "records", "a"))
+
+	items, err := session.ListThrough(ctx, "records", list, nil, key)
+	require.NoError(t, err)
+	assert.Equal(t, []record{{ID: "b", Value: "parent b"}}, items, "Hidden objects should not be listed")
+
+	_, err = session.ReadThrough(ctx, "records", "a", get)
+	require.ErrorIs(t, err, sql.ErrNoRows, "Hidden objects should not be read")
+
+	items, err = session.ListThrough(ctx, "other", list, nil, key)
+	require.NoError(t, err)
+	assert.Len(t, items, 2, "Tombstones should only hide objects of their kind")
+}
+
+func TestReadThroughFallsBackToParent(t *testing.T) {
+	stored := map[string]map[string]string{
+		"parent": {"a": "parent a", "b": "parent b"},
+		"child":  {"b": "child b"},
+	}
+	get := func(id string) func(sessionID string) (string, error) {
+		return func(sessionID string) (string, error) {
+			value, ok := stored[sessionID][id]
+			if !ok {
+				return "", sql.ErrNoRows
+			}
+			return value, nil
+		}
+	}
+
+	ctx := session.WithSessionID(context.Background(), "child")
+	ctx = context.WithValue(ctx, session.ParentSessionKey, "parent")
+
+	value, err := session.ReadThrough(ctx, "records", "a", get("a"))
+	require.NoError(t, err)
+	assert.Equal(t, "parent a", value, "Missing objects should be read from the parent")
+
+	value, err = session.ReadThrough(ctx, "records", "b", get("b"))
+	require.NoError(t, err)
+	assert.Equal(t, "child b", value, "The child's copy should win")
+
+	_, err = session.ReadThrough(ctx, "records", "z", get("z"))
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Objects in neither session should not be found")
+}
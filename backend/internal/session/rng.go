@@ -0,0 +1,128 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	mathrand "math/rand"
+	"sync"
+)
+
+var (
+	// sources holds the deterministic random source for each session looked up so far. Sessions
+	// without a seed map to nil so the database is only asked once.
+	sources = make(map[string]mathrand.Source64)
+	rngMu   sync.Mutex
+)
+
+// SetSeed installs a deterministic random source for a session
+// Re-seeding restarts the sequence, so a reset session replays the same values
+func SetSeed(sessionID string, seed int64) {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	sources[sessionID] = newSeededSource(seed)
+}
+
+// ClearSeed forgets the random source of a session; the next draw reloads the seed from the
+// database and starts its sequence over
+func ClearSeed(sessionID string) {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	delete(sources, sessionID)
+}
+
+func newSeededSource(seed int64) mathrand.Source64 {
+	//nolint:gosec // G404: Deterministic output is the point of a seeded session
+	return mathrand.NewSource(seed).(mathrand.Source64)
+}
+
+// sourceForSession returns the seeded source of a session, or nil if it has no seed. Seeds are
+// loaded from the session_seeds table the first time a session is seen, so they survive restarts.
+func sourceForSession(sessionID string) mathrand.Source64 {
+	rngMu.Lock()
+	source, ok := sources[sessionID]
+	rngMu.Unlock()
+	if ok {
+		return source
+	}
+
+	if queries := currentStore(); queries != nil {
+		if seed, err := queries.GetSessionSeed(context.Background(), sessionID); err == nil {
+			source = newSeededSource(seed)
+		}
+	}
+
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	// A seed installed while the database was read wins over what was read
+	if current, ok := sources[sessionID]; ok {
+		return current
+	}
+	sources[sessionID] = source
+	return source
+}
+
+// sessionSource draws from a seeded session's source under rngMu, so every *rand.Rand handed
+// out for the session advances one shared deterministic sequence
+type sessionSource struct {
+	source mathrand.Source64
+}
+
+func (s sessionSource) Int63() int64 {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	return s.source.Int63()
+}
+
+func (s sessionSource) Uint64() uint64 {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	return s.source.Uint64()
+}
+
+// Seed is a no-op; a session's sequence is only restarted through SetSeed
+func (s sessionSource) Seed(int64) {}
+
+// cryptoSource draws from crypto/rand for sessions without a seed
+type cryptoSource struct{}
+
+func (cryptoSource) Int63() int64 {
+	return int64(cryptoSource{}.Uint64() &^ (1 << 63))
+}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (cryptoSource) Seed(int64) {}
+
+// RNGForSession returns a random generator for a session. Seeded sessions draw from their
+// deterministic sequence; others from crypto/rand. The generator is safe to use from the
+// calling goroutine, but its Read buffers bytes, so it should not be shared between goroutines.
+func RNGForSession(sessionID string) *mathrand.Rand {
+	if source := sourceForSession(sessionID); source != nil {
+		//nolint:gosec // G404: Deterministic output is the point of a seeded session
+		return mathrand.New(sessionSource{source: source})
+	}
+	//nolint:gosec // G404: Backed by crypto/rand
+	return mathrand.New(cryptoSource{})
+}
+
+// RandomBytes fills b with random bytes for a session
+// Seeded sessions draw from their deterministic source; others use crypto/rand
+func RandomBytes(sessionID string, b []byte) {
+	_, _ = RNGForSession(sessionID).Read(b)
+}
+
+// RandomIntn returns a random int in [0, n) for a session
+// Seeded sessions draw from their deterministic source; others use crypto/rand
+func RandomIntn(sessionID string, n int) int {
+	return RNGForSession(sessionID).Intn(n)
+}
@@ -0,0 +1,164 @@
+package session_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/recreate-run/nova-simulators/internal/session"
+	simulatorGmail "github.com/recreate-run/nova-simulators/simulators/gmail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSeededScenario sends a fixed sequence of emails and returns the raw response bodies
+func runSeededScenario(t *testing.T, serverURL, sessionID string) []string {
+	t.Helper()
+
+	messages := []string{
+		"From: alice@example.com\r\nTo: bob@example.com\r\nSubject: First\r\n\r\nFirst message",
+		"From: bob@example.com\r\nTo: alice@example.com\r\nSubject: Second\r\n\r\nSecond message",
+		"From: alice@example.com\r\nTo: carol@example.com\r\nSubject: Third\r\n\r\nThird message",
+	}
+
+	outputs := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		body, err := json.Marshal(map[string]string{
+			"raw": base64.URLEncoding.EncodeToString([]byte(msg)),
+		})
+		require.NoError(t, err, "Failed to marshal request")
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			serverURL+"/gmail/v1/users/me/messages/send", bytes.NewReader(body))
+		require.NoError(t, err, "Failed to create send request")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(session.SessionHeaderName, sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Failed to send message")
+		respBody, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		require.NoError(t, err, "Failed to read response")
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Send should succeed: %s", respBody)
+
+		outputs = append(outputs, string(respBody))
+	}
+
+	return outputs
+}
+
+func TestSessionSeedReproducible(t *testing.T) {
+	// Setup: Create test database, session manager, and Gmail simulator
+	queries := setupTestDB(t)
+	managerServer := httptest.NewServer(session.NewManager(queries))
+	defer managerServer.Close()
+	gmailServer := httptest.NewServer(session.Middleware(simulatorGmail.NewHandler(queries)))
+	defer gmailServer.Close()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll("sessions")
+	})
+
+	ctx := context.Background()
+
+	// Create a seeded session
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, managerServer.URL+"/sessions",
+		bytes.NewReader([]byte(`{"seed": 42}`)))
+	require.NoError(t, err, "Failed to create POST request")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to create session")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Expected 200 OK")
+
+	var created map[string]string
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	require.NoError(t, err, "Failed to decode response")
+	sessionID := created["session_id"]
+	require.NotEmpty(t, sessionID, "Session ID should not be empty")
+
+	t.Cleanup(func() {
+		session.ClearSeed(sessionID)
+	})
+
+	// Run the scenario, reset the session, and run it again
+	first := runSeededScenario(t, gmailServer.URL, sessionID)
+
+	resetReq, err := http.NewRequestWithContext(ctx, http.MethodPost, managerServer.URL+"/sessions/"+sessionID+"/reset", http.NoBody)
+	require.NoError(t, err, "Failed to create reset request")
+	resetResp, err := http.DefaultClient.Do(resetReq)
+	require.NoError(t, err, "Failed to reset session")
+	defer resetResp.Body.Close()
+	require.Equal(t, http.StatusOK, resetResp.StatusCode, "Expected 200 OK")
+
+	second := runSeededScenario(t, gmailServer.URL, sessionID)
+
+	// Verify both runs produced byte-for-byte identical output
+	assert.Equal(t, first, second, "Seeded runs should produce identical responses")
+	assert.NotEqual(t, first[0], first[1], "Generated IDs should still differ within a run")
+}
+
+func TestSessionSeedUnseededIsRandom(t *testing.T) {
+	a := make([]byte, 16)
+	b := make([]byte, 16)
+	session.RandomBytes("unseeded-session", a)
+	session.RandomBytes("unseeded-session", b)
+	assert.NotEqual(t, a, b, "Unseeded sessions should not repeat values")
+
+	session.SetSeed("seeded-session", 7)
+	t.Cleanup(func() {
+		session.ClearSeed("seeded-session")
+	})
+	session.RandomBytes("seeded-session", a)
+	session.SetSeed("seeded-session", 7)
+	session.RandomBytes("seeded-session", b)
+	assert.Equal(t, a, b, "Re-seeding should restart the sequence")
+}
+
+func TestSessionSeedLoadedFromDatabase(t *testing.T) {
+	queries := setupTestDB(t)
+	managerServer := httptest.NewServer(session.NewManager(queries))
+	defer managerServer.Close()
+	t.Cleanup(func() {
+		_ = os.RemoveAll("sessions")
+	})
+
+	ctx := context.Background()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, managerServer.URL+"/sessions",
+		bytes.NewReader([]byte(`{"seed": 99}`)))
+	require.NoError(t, err, "Failed to create POST request")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to create session")
+	defer resp.Body.Close()
+	var created map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	sessionID := created["session_id"]
+	t.Cleanup(func() {
+		session.ClearSeed(sessionID)
+	})
+
+	first := session.RNGForSession(sessionID).Int63()
+
+	// Forgetting the in-memory source is what a restart does; the seed is reloaded from the database
+	session.ClearSeed(sessionID)
+	assert.Equal(t, first, session.RNGForSession(sessionID).Int63(), "The stored seed should restart the sequence")
+
+	// Deleting the session deletes its seed
+	delReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, managerServer.URL+"/sessions/"+sessionID, http.NoBody)
+	require.NoError(t, err)
+	delResp, err := http.DefaultClient.Do(delReq)
+	require.NoError(t, err)
+	defer delResp.Body.Close()
+	require.Equal(t, http.StatusOK, delResp.StatusCode)
+
+	_, err = queries.GetSessionSeed(ctx, sessionID)
+	require.ErrorIs(t, err, sql.ErrNoRows, "The seed should be deleted with the session")
+	assert.NotEqual(t, session.RNGForSession(sessionID).Int63(), session.RNGForSession(sessionID).Int63(),
+		"A deleted session should no longer be seeded")
+}
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"sync"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+)
+
+var (
+	// store is where per-session settings that outlive the process (seeds and parents) are
+	// loaded from on first use. It is set by NewManager; without it sessions have neither.
+	store   *database.Queries
+	storeMu sync.RWMutex
+)
+
+func setStore(queries *database.Queries) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	store = queries
+}
+
+func currentStore() *database.Queries {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+
+	return store
+}
@@ -0,0 +1,118 @@
+// Package signing signs outgoing webhooks the way providers do, so receivers can run their
+// real verification code against the simulator. Timestamps can be skewed through the faults
+// config to exercise clients' clock-tolerance checks.
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Svix header names, used by Resend webhooks
+const (
+	SvixIDHeader        = "svix-id"
+	SvixTimestampHeader = "svix-timestamp"
+	SvixSignatureHeader = "svix-signature"
+)
+
+// GitHubSignatureHeader carries the HMAC-SHA256 of a GitHub webhook payload
+const GitHubSignatureHeader = "X-Hub-Signature-256"
+
+// DefaultTolerance is how far a Svix timestamp may drift before receivers reject it
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrInvalidSignature is returned when no signature matches the payload
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+	// ErrTimestampOutOfTolerance is returned when the signed timestamp is too far from the receiver's clock
+	ErrTimestampOutOfTolerance = errors.New("webhook timestamp is outside the tolerance window")
+)
+
+// SkewedTime shifts now by the configured signature skew
+func SkewedTime(now time.Time, skewSeconds int) time.Time {
+	return now.Add(time.Duration(skewSeconds) * time.Second)
+}
+
+// SignSvix returns the svix-id, svix-timestamp and svix-signature headers for a payload.
+// The secret uses Svix's "whsec_<base64 key>" form.
+func SignSvix(secret, msgID string, timestamp time.Time, payload []byte) (http.Header, error) {
+	key, err := svixKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	header := make(http.Header)
+	header.Set(SvixIDHeader, msgID)
+	header.Set(SvixTimestampHeader, unix)
+	header.Set(SvixSignatureHeader, "v1,"+svixSignature(key, msgID, unix, payload))
+	return header, nil
+}
+
+// VerifySvix checks Svix headers the way receiver libraries do: the timestamp must be within
+// tolerance of now and one of the space-separated v1 signatures must match
+func VerifySvix(secret string, header http.Header, payload []byte, now time.Time, tolerance time.Duration) error {
+	key, err := svixKey(secret)
+	if err != nil {
+		return err
+	}
+
+	msgID := header.Get(SvixIDHeader)
+	unix := header.Get(SvixTimestampHeader)
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: %w", SvixTimestampHeader, err)
+	}
+	drift := now.Sub(time.Unix(seconds, 0))
+	if drift > tolerance || drift < -tolerance {
+		return ErrTimestampOutOfTolerance
+	}
+
+	expected := svixSignature(key, msgID, unix, payload)
+	for _, candidate := range strings.Fields(header.Get(SvixSignatureHeader)) {
+		version, signature, ok := strings.Cut(candidate, ",")
+		if ok && version == "v1" && hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// SignGitHub returns the X-Hub-Signature-256 value for a payload: "sha256=" followed by the
+// hex HMAC-SHA256 of the body keyed with the hook secret
+func SignGitHub(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyGitHub checks an X-Hub-Signature-256 value against the payload
+func VerifyGitHub(secret, signature string, payload []byte) error {
+	if !hmac.Equal([]byte(signature), []byte(SignGitHub(secret, payload))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func svixKey(secret string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook secret: %w", err)
+	}
+	return key, nil
+}
+
+func svixSignature(key []byte, msgID, unix string, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msgID + "." + unix + "."))
+	mac.Write(payload)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,70 @@
+package signing_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/config"
+	"github.com/recreate-run/nova-simulators/internal/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSvixSignatureSkew(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("simulator-webhook-secret"))
+	payload := []byte(`{"type":"email.delivered","data":{"email_id":"abc"}}`)
+	now := time.Now()
+
+	sign := func(t *testing.T, faults config.FaultsConfig) http.Header {
+		t.Helper()
+		header, err := signing.SignSvix(secret, "msg_1", signing.SkewedTime(now, faults.SignatureSkewSeconds), payload)
+		require.NoError(t, err, "SignSvix should succeed")
+		return header
+	}
+
+	t.Run("NoSkewVerifies", func(t *testing.T) {
+		header := sign(t, config.FaultsConfig{})
+		assert.NoError(t, signing.VerifySvix(secret, header, payload, now, signing.DefaultTolerance))
+	})
+
+	t.Run("SkewWithinToleranceVerifies", func(t *testing.T) {
+		header := sign(t, config.FaultsConfig{SignatureSkewSeconds: -120})
+		assert.NoError(t, signing.VerifySvix(secret, header, payload, now, signing.DefaultTolerance))
+	})
+
+	t.Run("ExcessiveSkewRejected", func(t *testing.T) {
+		for _, skew := range []int{-600, 600} {
+			header := sign(t, config.FaultsConfig{SignatureSkewSeconds: skew})
+			err := signing.VerifySvix(secret, header, payload, now, signing.DefaultTolerance)
+			assert.ErrorIs(t, err, signing.ErrTimestampOutOfTolerance, "Skew of %ds should be rejected", skew)
+		}
+	})
+
+	t.Run("TamperedPayloadRejected", func(t *testing.T) {
+		header := sign(t, config.FaultsConfig{})
+		err := signing.VerifySvix(secret, header, []byte(`{"type":"email.bounced"}`), now, signing.DefaultTolerance)
+		assert.ErrorIs(t, err, signing.ErrInvalidSignature)
+	})
+}
+
+func TestGitHubSignature(t *testing.T) {
+	payload := []byte(`{"action":"opened","issue":{"number":1}}`)
+
+	t.Run("KnownVector", func(t *testing.T) {
+		// Example from GitHub's "Validating webhook deliveries" documentation
+		signature := signing.SignGitHub("It's a Secret to Everybody", []byte("Hello, World!"))
+		assert.Equal(t, "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17", signature)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		signature := signing.SignGitHub("hook-secret", payload)
+		assert.NoError(t, signing.VerifyGitHub("hook-secret", signature, payload))
+	})
+
+	t.Run("WrongSecretRejected", func(t *testing.T) {
+		signature := signing.SignGitHub("hook-secret", payload)
+		assert.ErrorIs(t, signing.VerifyGitHub("other-secret", signature, payload), signing.ErrInvalidSignature)
+	})
+}
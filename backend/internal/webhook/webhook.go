@@ -0,0 +1,391 @@
+// Package webhook delivers outbound webhooks for simulators. Simulators register a Provider
+// that turns their events into payloads and signs them the way the real service does, then call
+// Emit, which delivers to every receiver registered for the session and simulator, or EmitTo
+// for simulators that keep their own webhook configs. Failed deliveries are retried with
+// exponential backoff and every attempt is recorded in the webhook_deliveries table. Retries
+// wait on a timer rather than in a worker; sessions on a test clock wait out the backoff on
+// that clock instead, and their retries are sent by FireRetries when /api/tick moves the clock
+// past it. A delivery that can't be queued within enqueueWait is dropped and recorded as failed.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/recreate-run/nova-simulators/internal/signing"
+)
+
+const (
+	// DefaultBackoff is the wait before the first retry; it doubles on every further attempt
+	DefaultBackoff = time.Second
+	// MaxAttempts is how many times a delivery is tried before it is given up
+	MaxAttempts = 5
+	// workers is how many deliveries are sent concurrently
+	workers = 4
+	// queueSize is how many deliveries wait for a worker before new ones are dropped
+	queueSize = 256
+	// enqueueWait is how long a full queue is waited on before a delivery is dropped
+	enqueueWait = 100 * time.Millisecond
+)
+
+// Request is an outgoing delivery as seen by a provider's Headers func
+type Request struct {
+	ID        string
+	Event     string
+	Secret    string
+	Timestamp time.Time
+	Payload   []byte
+}
+
+// Provider describes how a simulator's events become webhook requests
+type Provider struct {
+	// Payload builds the JSON body for an event from the data passed to Emit
+	Payload func(event string, data interface{}) (interface{}, error)
+	// Headers returns the provider's event and signature headers; nil sends only Content-Type
+	Headers func(req *Request) (http.Header, error)
+}
+
+// SkewFunc returns how many seconds a session's signed timestamps are shifted by, from the
+// session's faults config
+type SkewFunc func(ctx context.Context, sessionID, simulator string) int
+
+// Target is where a delivery is sent and the secret it is signed with
+type Target struct {
+	URL    string
+	Secret string
+}
+
+type delivery struct {
+	id        string
+	sessionID string
+	simulator string
+	event     string
+	target    Target
+	payload   []byte
+	// skew is the signature skew in effect when the delivery was queued
+	skew int
+	// attempt is the next attempt, and wait the backoff after it fails; zero for new deliveries
+	attempt int
+	wait    time.Duration
+}
+
+// parkedDelivery is a failed delivery waiting for its session's test clock to reach due
+type parkedDelivery struct {
+	delivery
+	due time.Time
+}
+
+// errQueueFull is recorded for deliveries dropped because every worker was busy
+var errQueueFull = errors.New("webhook queue full")
+
+var (
+	providers = make(map[string]Provider)
+	store     *database.Queries
+	queue     chan delivery
+	pending   sync.WaitGroup
+	backoff   = DefaultBackoff
+	skewFor   SkewFunc
+	client    = &http.Client{Timeout: 10 * time.Second}
+	mu        sync.RWMutex
+
+	// parked holds retries of sessions on a test clock until FireRetries finds them due
+	parked   []parkedDelivery
+	parkedMu sync.Mutex
+)
+
+// Register sets the provider used for a simulator's events
+func Register(simulator string, provider Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	providers[simulator] = provider
+}
+
+// Init enables deliveries, recording attempts through queries. Passing nil disables Emit.
+func Init(queries *database.Queries) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	store = queries
+	if queries == nil || queue != nil {
+		return
+	}
+	queue = make(chan delivery, queueSize)
+	for range workers {
+		go worker(queue)
+	}
+}
+
+// SetBackoff sets the wait before the first retry; d <= 0 restores DefaultBackoff
+func SetBackoff(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if d <= 0 {
+		d = DefaultBackoff
+	}
+	backoff = d
+}
+
+// SetSkew sets where signature skew is read from; nil signs with the current time
+func SetSkew(fn SkewFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	skewFor = fn
+}
+
+// Wait blocks until every queued delivery has succeeded, run out of attempts or is waiting on
+// its session's test clock
+func Wait() {
+	pending.Wait()
+}
+
+// Emit queues an event for every receiver the request's session registered for simulator. It
+// returns once the deliveries are queued; they are sent in the background.
+func Emit(ctx context.Context, simulator, event string, data interface{}) error {
+	mu.RLock()
+	queries := store
+	mu.RUnlock()
+	if queries == nil {
+		return nil
+	}
+
+	receivers, err := queries.ListWebhookReceiversForSimulator(context.Background(), database.ListWebhookReceiversForSimulatorParams{
+		SessionID: session.FromContext(ctx),
+		Simulator: simulator,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhook receivers: %w", err)
+	}
+
+	targets := make([]Target, 0, len(receivers))
+	for i := range receivers {
+		targets = append(targets, Target{URL: receivers[i].Url, Secret: receivers[i].Secret})
+	}
+	return EmitTo(ctx, simulator, event, data, targets)
+}
+
+// EmitTo queues an event for the given targets, for simulators that store their own webhook
+// configs. Deliveries are recorded against the request's session.
+func EmitTo(ctx context.Context, simulator, event string, data interface{}, targets []Target) error {
+	mu.RLock()
+	provider, ok := providers[simulator]
+	enabled := store != nil
+	skewSource := skewFor
+	mu.RUnlock()
+
+	if !enabled || len(targets) == 0 {
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("no webhook provider registered for %s", simulator)
+	}
+
+	body, err := provider.Payload(event, data)
+	if err != nil {
+		return fmt.Errorf("failed to build %s payload: %w", event, err)
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s payload: %w", event, err)
+	}
+
+	sessionID := session.FromContext(ctx)
+	skew := 0
+	if skewSource != nil {
+		skew = skewSource(ctx, sessionID, simulator)
+	}
+	dropped := 0
+	for _, target := range targets {
+		if !enqueue(delivery{
+			id:        newDeliveryID(sessionID),
+			sessionID: sessionID,
+			simulator: simulator,
+			event:     event,
+			target:    target,
+			payload:   payload,
+			skew:      skew,
+		}) {
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		return fmt.Errorf("webhook queue full: dropped %d of %d %s deliveries", dropped, len(targets), event)
+	}
+	log.Printf("[webhook] → Queued %s %s for %d receiver(s)", simulator, event, len(targets))
+	return nil
+}
+
+// enqueue hands d to the workers, waiting at most enqueueWait for room in the queue. A delivery
+// that doesn't fit is recorded as a failed attempt and reported with false.
+func enqueue(d delivery) bool {
+	pending.Add(1)
+	select {
+	case queue <- d:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(enqueueWait)
+	defer timer.Stop()
+	select {
+	case queue <- d:
+		return true
+	case <-timer.C:
+		pending.Done()
+		attempt := max(d.attempt, 1)
+		record(&d, attempt, 0, errQueueFull)
+		log.Printf("[webhook] ✗ Dropped %s %s to %s: %v", d.simulator, d.event, d.target.URL, errQueueFull)
+		return false
+	}
+}
+
+func worker(queue <-chan delivery) {
+	for d := range queue {
+		deliver(&d)
+		pending.Done()
+	}
+}
+
+// deliver sends d once. A failed attempt is retried after the backoff: on a timer, or for
+// sessions on a test clock, when FireRetries finds it due. MaxAttempts ends the retries.
+func deliver(d *delivery) {
+	mu.RLock()
+	provider := providers[d.simulator]
+	if d.attempt == 0 {
+		d.attempt, d.wait = 1, backoff
+	}
+	mu.RUnlock()
+
+	status, err := send(provider, d)
+	record(d, d.attempt, status, err)
+	if err == nil && status >= 200 && status < 300 {
+		log.Printf("[webhook] ✓ Delivered %s %s to %s (attempt %d)", d.simulator, d.event, d.target.URL, d.attempt)
+		return
+	}
+	if d.attempt == MaxAttempts {
+		log.Printf("[webhook] ✗ Gave up delivering %s %s to %s after %d attempts", d.simulator, d.event, d.target.URL, MaxAttempts)
+		return
+	}
+
+	wait := d.wait
+	retry := *d
+	retry.attempt++
+	retry.wait *= 2
+	if session.HasTestClock(d.sessionID) {
+		parkedMu.Lock()
+		parked = append(parked, parkedDelivery{delivery: retry, due: session.Now(d.sessionID).Add(wait)})
+		parkedMu.Unlock()
+		log.Printf("[webhook] → Parked retry of %s %s for %s of session time", d.simulator, d.event, wait)
+		return
+	}
+
+	// The retry stays pending while its timer runs, so Wait covers it
+	pending.Add(1)
+	time.AfterFunc(wait, func() {
+		defer pending.Done()
+		enqueue(retry)
+	})
+}
+
+// FireRetries queues the session's parked retries whose backoff has elapsed by now, returning
+// how many were queued. It is the webhook ticker of /api/tick.
+func FireRetries(_ context.Context, _ *database.Queries, sessionID string, now time.Time) (int, error) {
+	parkedMu.Lock()
+	var due []delivery
+	waiting := parked[:0]
+	for i := range parked {
+		if parked[i].sessionID == sessionID && !parked[i].due.After(now) {
+			due = append(due, parked[i].delivery)
+			continue
+		}
+		waiting = append(waiting, parked[i])
+	}
+	parked = waiting
+	parkedMu.Unlock()
+
+	for _, d := range due {
+		enqueue(d)
+	}
+	if len(due) > 0 {
+		log.Printf("[webhook] → Queued %d retries for session %s", len(due), sessionID)
+	}
+	return len(due), nil
+}
+
+func send(provider Provider, d *delivery) (int, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, d.target.URL, bytes.NewReader(d.payload))
+	if err != nil {
+		return 0, err
+	}
+	if provider.Headers != nil {
+		header, err := provider.Headers(&Request{
+			ID:        d.id,
+			Event:     d.event,
+			Secret:    d.target.Secret,
+			Timestamp: signing.SkewedTime(time.Now(), d.skew),
+			Payload:   d.payload,
+		})
+		if err != nil {
+			return 0, err
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func record(d *delivery, attempt, status int, sendErr error) {
+	mu.RLock()
+	queries := store
+	mu.RUnlock()
+	if queries == nil {
+		return
+	}
+
+	errText := ""
+	if sendErr != nil {
+		errText = sendErr.Error()
+	}
+	_, err := queries.CreateWebhookDelivery(context.Background(), database.CreateWebhookDeliveryParams{
+		DeliveryID: d.id,
+		SessionID:  d.sessionID,
+		Simulator:  d.simulator,
+		Event:      d.event,
+		Url:        d.target.URL,
+		Payload:    d.payload,
+		Attempt:    int64(attempt),
+		StatusCode: int64(status),
+		Error:      errText,
+	})
+	if err != nil {
+		log.Printf("[webhook] ✗ Failed to record delivery attempt: %v", err)
+	}
+}
+
+func newDeliveryID(sessionID string) string {
+	b := make([]byte, 16)
+	session.RandomBytes(sessionID, b)
+	return hex.EncodeToString(b)
+}
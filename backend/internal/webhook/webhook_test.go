@@ -0,0 +1,132 @@
+package webhook_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/recreate-run/nova-simulators/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func setupTestDB(t *testing.T) *database.Queries {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err, "Failed to open test database")
+	// Every connection to :memory: is a separate database, so share one across goroutines
+	db.SetMaxOpenConns(1)
+
+	err = goose.SetDialect("sqlite3")
+	require.NoError(t, err, "Failed to set goose dialect")
+
+	err = goose.Up(db, "../../migrations")
+	require.NoError(t, err, "Failed to run migrations")
+
+	return database.New(db)
+}
+
+// setup enables deliveries for a "test" simulator that sends its data as the payload
+func setup(t *testing.T, backoff time.Duration) *database.Queries {
+	t.Helper()
+	queries := setupTestDB(t)
+	webhook.Init(queries)
+	webhook.SetBackoff(backoff)
+	webhook.Register("test", webhook.Provider{
+		Payload: func(_ string, data interface{}) (interface{}, error) { return data, nil },
+	})
+	t.Cleanup(func() {
+		webhook.Wait()
+		webhook.Init(nil)
+		webhook.SetBackoff(0)
+	})
+	return queries
+}
+
+func TestRetriesDoNotHoldWorkers(t *testing.T) {
+	setup(t, 500*time.Millisecond)
+	ctx := session.WithSessionID(context.Background(), "webhook-retry-timers")
+
+	// The flaky targets fail until recovered, so every worker's delivery has a retry waiting
+	var flakyCalls atomic.Int32
+	var recovered atomic.Bool
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		flakyCalls.Add(1)
+		if recovered.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer flaky.Close()
+	defer webhook.Wait()
+
+	delivered := make(chan struct{}, 1)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delivered <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+
+	targets := make([]webhook.Target, 0, 4)
+	for range 4 {
+		targets = append(targets, webhook.Target{URL: flaky.URL})
+	}
+	require.NoError(t, webhook.EmitTo(ctx, "test", "flaky", map[string]string{}, targets))
+	require.Eventually(t, func() bool { return flakyCalls.Load() == 4 }, time.Second, 10*time.Millisecond,
+		"Every flaky target should have been tried once")
+
+	require.NoError(t, webhook.EmitTo(ctx, "test", "healthy", map[string]string{}, []webhook.Target{{URL: healthy.URL}}))
+	select {
+	case <-delivered:
+	case <-time.After(250 * time.Millisecond):
+		t.Fatal("A new delivery should not wait behind the backoff of earlier retries")
+	}
+
+	// Let the retries succeed so the test doesn't wait out every backoff
+	recovered.Store(true)
+}
+
+func TestEmitToDropsWhenQueueFull(t *testing.T) {
+	queries := setup(t, time.Second)
+	sessionID := "webhook-queue-full"
+	ctx := session.WithSessionID(context.Background(), sessionID)
+
+	// The receiver holds every delivery until released, so the queue fills up
+	release := make(chan struct{})
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer blocked.Close()
+	defer func() {
+		close(release)
+		webhook.Wait()
+	}()
+
+	// Four deliveries occupy the workers and 256 fill the queue
+	targets := make([]webhook.Target, 260)
+	for i := range targets {
+		targets[i] = webhook.Target{URL: blocked.URL}
+	}
+	require.NoError(t, webhook.EmitTo(ctx, "test", "fill", map[string]string{}, targets), "A queue with room should accept every delivery")
+
+	start := time.Now()
+	err := webhook.EmitTo(ctx, "test", "overflow", map[string]string{}, []webhook.Target{{URL: blocked.URL}})
+	require.Error(t, err, "A full queue should drop the delivery")
+	assert.Less(t, time.Since(start), time.Second, "EmitTo should not block on a full queue")
+
+	deliveries, err := queries.ListWebhookDeliveries(context.Background(), sessionID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1, "Only the dropped delivery should be recorded so far")
+	assert.Equal(t, "overflow", deliveries[0].Event)
+	assert.Equal(t, "webhook queue full", deliveries[0].Error, "The drop should be recorded")
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Organization and viewer data resolved by the viewer/organization bootstrap queries
+CREATE TABLE IF NOT EXISTS linear_organizations (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    url_key TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_linear_organizations_session ON linear_organizations(session_id);
+
+-- Marks the user returned as the authenticated viewer for a session
+ALTER TABLE linear_users ADD COLUMN is_viewer INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE linear_users DROP COLUMN is_viewer;
+DROP INDEX IF EXISTS idx_linear_organizations_session;
+DROP TABLE IF EXISTS linear_organizations;
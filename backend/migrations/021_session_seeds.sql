@@ -0,0 +1,13 @@
+-- +goose Up
+-- Add session_seeds table for reproducible per-session randomness
+-- Sessions without a row here keep using non-deterministic random sources
+
+CREATE TABLE IF NOT EXISTS session_seeds (
+    session_id TEXT PRIMARY KEY,
+    seed INTEGER NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS session_seeds;
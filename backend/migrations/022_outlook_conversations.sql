@@ -0,0 +1,9 @@
+-- +goose Up
+-- Group replies and forwards with the message they reference
+ALTER TABLE outlook_messages ADD COLUMN conversation_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_outlook_messages_conversation ON outlook_messages(conversation_id, session_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outlook_messages_conversation;
+ALTER TABLE outlook_messages DROP COLUMN conversation_id;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Incident priorities (P1-P5); defaults are seeded per session on first use
+CREATE TABLE IF NOT EXISTS pagerduty_priorities (
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    order_index INTEGER NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pagerduty_priorities_session ON pagerduty_priorities(session_id);
+
+ALTER TABLE pagerduty_incidents ADD COLUMN priority_id TEXT;
+
+-- +goose Down
+ALTER TABLE pagerduty_incidents DROP COLUMN priority_id;
+DROP INDEX IF EXISTS idx_pagerduty_priorities_session;
+DROP TABLE IF EXISTS pagerduty_priorities;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Opt-in request body validation per session/simulator
+ALTER TABLE session_configs ADD COLUMN validation_enabled INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE session_configs DROP COLUMN validation_enabled;
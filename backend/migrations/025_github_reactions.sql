@@ -0,0 +1,20 @@
+-- +goose Up
+-- Reactions on issues and issue comments; subject_id is the issue number or comment ID
+CREATE TABLE IF NOT EXISTS github_reactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    subject_type TEXT NOT NULL,
+    subject_id INTEGER NOT NULL,
+    user_login TEXT NOT NULL,
+    content TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE(repo_owner, repo_name, subject_type, subject_id, user_login, content, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_reactions_session ON github_reactions(session_id, repo_owner, repo_name, subject_type, subject_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_github_reactions_session;
+DROP TABLE IF EXISTS github_reactions;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Type tag so numbers and booleans round-trip as typed JSON values
+ALTER TABLE gsheets_cells ADD COLUMN value_type TEXT NOT NULL DEFAULT 'string';
+
+-- +goose Down
+ALTER TABLE gsheets_cells DROP COLUMN value_type;
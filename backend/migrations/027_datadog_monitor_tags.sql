@@ -0,0 +1,6 @@
+-- +goose Up
+-- Monitor tags stored as a JSON array, used by monitor search
+ALTER TABLE datadog_monitors ADD COLUMN tags TEXT;
+
+-- +goose Down
+ALTER TABLE datadog_monitors DROP COLUMN tags;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Ephemeral messages are visible to a single user and never appear in channel history
+CREATE TABLE IF NOT EXISTS slack_ephemeral_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    timestamp TEXT NOT NULL,
+    session_id TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_ephemeral_messages_session_user ON slack_ephemeral_messages(session_id, user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_slack_ephemeral_messages_session_user;
+DROP TABLE IF EXISTS slack_ephemeral_messages;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Developer metadata attached to spreadsheets, sheets, or dimension ranges
+CREATE TABLE IF NOT EXISTS gsheets_developer_metadata (
+    metadata_id INTEGER NOT NULL,
+    spreadsheet_id TEXT NOT NULL,
+    metadata_key TEXT NOT NULL,
+    metadata_value TEXT,
+    location TEXT NOT NULL,
+    visibility TEXT NOT NULL DEFAULT 'DOCUMENT',
+    session_id TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (spreadsheet_id, metadata_id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gsheets_developer_metadata_key ON gsheets_developer_metadata(session_id, spreadsheet_id, metadata_key);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_gsheets_developer_metadata_key;
+DROP TABLE IF EXISTS gsheets_developer_metadata;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- First response recorded for each Idempotency-Key, replayed verbatim on retries
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    session_id TEXT NOT NULL,
+    method TEXT NOT NULL,
+    path TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    content_type TEXT NOT NULL DEFAULT '',
+    body BLOB NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (session_id, method, path, idempotency_key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
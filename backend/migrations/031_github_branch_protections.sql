@@ -0,0 +1,16 @@
+-- +goose Up
+-- Branch protection rules; status checks and review settings are stored as JSON
+CREATE TABLE IF NOT EXISTS github_branch_protections (
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    branch TEXT NOT NULL,
+    required_status_checks TEXT,
+    required_reviews TEXT,
+    enforce_admins INTEGER NOT NULL DEFAULT 0,
+    session_id TEXT NOT NULL,
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (repo_owner, repo_name, branch, session_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS github_branch_protections;
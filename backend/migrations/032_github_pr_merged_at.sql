@@ -0,0 +1,6 @@
+-- +goose Up
+-- Unix timestamp of the merge, NULL until the pull request is merged
+ALTER TABLE github_pull_requests ADD COLUMN merged_at INTEGER;
+
+-- +goose Down
+ALTER TABLE github_pull_requests DROP COLUMN merged_at;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Metric metadata managed through /api/v1/metrics/{metric_name}
+CREATE TABLE IF NOT EXISTS datadog_metric_metadata (
+    metric_name TEXT NOT NULL,
+    type TEXT,
+    description TEXT,
+    unit TEXT,
+    session_id TEXT NOT NULL DEFAULT '',
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (metric_name, session_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS datadog_metric_metadata;
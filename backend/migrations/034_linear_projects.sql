@@ -0,0 +1,20 @@
+-- +goose Up
+-- Projects group issues across teams; team_ids holds a JSON array of team IDs
+CREATE TABLE IF NOT EXISTS linear_projects (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    team_ids TEXT NOT NULL DEFAULT '[]',
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_linear_projects_session ON linear_projects(session_id);
+
+ALTER TABLE linear_issues ADD COLUMN project_id TEXT;
+
+-- +goose Down
+ALTER TABLE linear_issues DROP COLUMN project_id;
+DROP INDEX IF EXISTS idx_linear_projects_session;
+DROP TABLE IF EXISTS linear_projects;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Captured simulator requests, kept so they can be inspected and replayed
+CREATE TABLE IF NOT EXISTS request_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL,
+    simulator TEXT NOT NULL,
+    method TEXT NOT NULL,
+    path TEXT NOT NULL,
+    raw_query TEXT NOT NULL DEFAULT '',
+    headers TEXT NOT NULL DEFAULT '{}',
+    request_body BLOB,
+    status_code INTEGER NOT NULL,
+    response_body BLOB,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_logs_session ON request_logs(session_id, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_request_logs_session;
+DROP TABLE IF EXISTS request_logs;
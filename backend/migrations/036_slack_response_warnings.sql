@@ -0,0 +1,10 @@
+-- +goose Up
+-- Warnings the Slack simulator attaches to responses, configured per session
+CREATE TABLE IF NOT EXISTS slack_response_warnings (
+    session_id TEXT PRIMARY KEY,
+    warnings TEXT NOT NULL DEFAULT '',
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS slack_response_warnings;
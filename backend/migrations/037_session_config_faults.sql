@@ -0,0 +1,6 @@
+-- +goose Up
+-- Opt-in fault injection per session/simulator
+ALTER TABLE session_configs ADD COLUMN fault_truncate_rate REAL NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE session_configs DROP COLUMN fault_truncate_rate;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Gists owned by the simulated user; files are stored as a JSON map of filename to content
+CREATE TABLE IF NOT EXISTS github_gists (
+    id TEXT NOT NULL,
+    description TEXT,
+    public INTEGER NOT NULL DEFAULT 0,
+    files TEXT NOT NULL,
+    owner_login TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_gists_session ON github_gists(session_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_github_gists_session;
+DROP TABLE IF EXISTS github_gists;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Shift emitted webhook signature timestamps to exercise clients' clock tolerance
+ALTER TABLE session_configs ADD COLUMN fault_signature_skew_seconds INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE session_configs DROP COLUMN fault_signature_skew_seconds;
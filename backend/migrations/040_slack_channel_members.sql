@@ -0,0 +1,28 @@
+-- +goose Up
+-- Channel membership and the topic/purpose set through conversations.setTopic/setPurpose
+CREATE TABLE IF NOT EXISTS slack_channel_members (
+    channel_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    joined_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (channel_id, user_id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_channel_members_session ON slack_channel_members(session_id);
+
+ALTER TABLE slack_channels ADD COLUMN topic TEXT NOT NULL DEFAULT '';
+ALTER TABLE slack_channels ADD COLUMN topic_creator TEXT NOT NULL DEFAULT '';
+ALTER TABLE slack_channels ADD COLUMN topic_last_set INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE slack_channels ADD COLUMN purpose TEXT NOT NULL DEFAULT '';
+ALTER TABLE slack_channels ADD COLUMN purpose_creator TEXT NOT NULL DEFAULT '';
+ALTER TABLE slack_channels ADD COLUMN purpose_last_set INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE slack_channels DROP COLUMN purpose_last_set;
+ALTER TABLE slack_channels DROP COLUMN purpose_creator;
+ALTER TABLE slack_channels DROP COLUMN purpose;
+ALTER TABLE slack_channels DROP COLUMN topic_last_set;
+ALTER TABLE slack_channels DROP COLUMN topic_creator;
+ALTER TABLE slack_channels DROP COLUMN topic;
+DROP INDEX IF EXISTS idx_slack_channel_members_session;
+DROP TABLE IF EXISTS slack_channel_members;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Cc header plus normalized recipient lists (lowercase bare addresses, space-separated) for to:/cc: search
+ALTER TABLE gmail_messages ADD COLUMN cc_email TEXT NOT NULL DEFAULT '';
+ALTER TABLE gmail_messages ADD COLUMN to_addresses TEXT NOT NULL DEFAULT '';
+ALTER TABLE gmail_messages ADD COLUMN cc_addresses TEXT NOT NULL DEFAULT '';
+
+UPDATE gmail_messages SET to_addresses = lower(replace(to_email, ',', ' '));
+
+-- +goose Down
+ALTER TABLE gmail_messages DROP COLUMN cc_addresses;
+ALTER TABLE gmail_messages DROP COLUMN to_addresses;
+ALTER TABLE gmail_messages DROP COLUMN cc_email;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Canned responses that short-circuit a simulator for matching requests in a session
+CREATE TABLE IF NOT EXISTS response_overrides (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL,
+    simulator TEXT NOT NULL,
+    method TEXT NOT NULL,
+    path_pattern TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT 'application/json',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_response_overrides_session ON response_overrides(session_id, simulator);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_response_overrides_session;
+DROP TABLE IF EXISTS response_overrides;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Messages queued by chat.scheduleMessage, posted once the session clock reaches post_at
+CREATE TABLE IF NOT EXISTS slack_scheduled_messages (
+    id TEXT PRIMARY KEY,
+    channel_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    post_at INTEGER NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_scheduled_messages_session ON slack_scheduled_messages(session_id, post_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_slack_scheduled_messages_session;
+DROP TABLE IF EXISTS slack_scheduled_messages;
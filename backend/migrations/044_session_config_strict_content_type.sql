@@ -0,0 +1,6 @@
+-- +goose Up
+-- Reject request bodies whose Content-Type doesn't match what the endpoint accepts
+ALTER TABLE session_configs ADD COLUMN validation_strict_content_type INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE session_configs DROP COLUMN validation_strict_content_type;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Push notification watches requested through users.watch, one per session
+CREATE TABLE IF NOT EXISTS gmail_watches (
+    session_id TEXT PRIMARY KEY,
+    topic_name TEXT NOT NULL,
+    label_ids TEXT NOT NULL DEFAULT '',
+    label_filter_behavior TEXT NOT NULL DEFAULT '',
+    history_id INTEGER NOT NULL,
+    expiration INTEGER NOT NULL,
+    stopped_at INTEGER,
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS gmail_watches;
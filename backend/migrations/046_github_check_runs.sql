@@ -0,0 +1,28 @@
+-- +goose Up
+-- Check runs reported against commits through the Checks API
+CREATE TABLE IF NOT EXISTS github_check_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    check_run_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    head_sha TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'queued',
+    conclusion TEXT,
+    external_id TEXT,
+    details_url TEXT,
+    output_title TEXT,
+    output_summary TEXT,
+    started_at INTEGER,
+    completed_at INTEGER,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE(repo_owner, repo_name, check_run_id, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_check_runs_session ON github_check_runs(session_id, repo_owner, repo_name, head_sha);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_github_check_runs_session;
+DROP TABLE IF EXISTS github_check_runs;
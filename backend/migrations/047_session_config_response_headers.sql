@@ -0,0 +1,6 @@
+-- +goose Up
+-- Response header injection rules as JSON; NULL falls back to the YAML default
+ALTER TABLE session_configs ADD COLUMN response_headers TEXT;
+
+-- +goose Down
+ALTER TABLE session_configs DROP COLUMN response_headers;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Attachments (postmortems and links) recorded against incidents
+CREATE TABLE IF NOT EXISTS datadog_incident_attachments (
+    id TEXT PRIMARY KEY,
+    incident_id TEXT NOT NULL,
+    attachment_type TEXT NOT NULL,
+    document_url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    session_id TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_datadog_incident_attachments_session ON datadog_incident_attachments(session_id, incident_id);
+
+-- Follow-up todos tracked on incidents; assignees are stored as the JSON array the client sent
+CREATE TABLE IF NOT EXISTS datadog_incident_todos (
+    id TEXT PRIMARY KEY,
+    incident_id TEXT NOT NULL,
+    content TEXT NOT NULL,
+    assignees TEXT NOT NULL DEFAULT '[]',
+    due_date TEXT,
+    completed TEXT,
+    session_id TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_datadog_incident_todos_session ON datadog_incident_todos(session_id, incident_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_datadog_incident_todos_session;
+DROP TABLE IF EXISTS datadog_incident_todos;
+DROP INDEX IF EXISTS idx_datadog_incident_attachments_session;
+DROP TABLE IF EXISTS datadog_incident_attachments;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Draft pull requests cannot be merged until they are marked ready for review
+ALTER TABLE github_pull_requests ADD COLUMN draft INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE github_pull_requests ADD COLUMN maintainer_can_modify INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE github_pull_requests DROP COLUMN maintainer_can_modify;
+ALTER TABLE github_pull_requests DROP COLUMN draft;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Handler latency of each captured request, used for the latency percentile report
+ALTER TABLE request_logs ADD COLUMN duration_us INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_request_logs_simulator ON request_logs(simulator, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_request_logs_simulator;
+ALTER TABLE request_logs DROP COLUMN duration_us;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Block Kit blocks posted with a message, stored as the JSON array sent by the client
+ALTER TABLE slack_messages ADD COLUMN blocks TEXT;
+
+-- +goose Down
+ALTER TABLE slack_messages DROP COLUMN blocks;
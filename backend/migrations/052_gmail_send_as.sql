@@ -0,0 +1,16 @@
+-- +goose Up
+-- Send-as addresses returned by users.settings.sendAs; a session without rows gets a primary default
+CREATE TABLE IF NOT EXISTS gmail_send_as_aliases (
+    session_id TEXT NOT NULL,
+    send_as_email TEXT NOT NULL,
+    display_name TEXT NOT NULL DEFAULT '',
+    reply_to_address TEXT NOT NULL DEFAULT '',
+    signature TEXT NOT NULL DEFAULT '',
+    is_primary INTEGER NOT NULL DEFAULT 0,
+    is_default INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (session_id, send_as_email)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS gmail_send_as_aliases;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Add session_parents table for sessions layered over a shared read-only baseline
+-- Reads in a child session fall through to its parent; writes stay in the child
+
+CREATE TABLE IF NOT EXISTS session_parents (
+    session_id TEXT PRIMARY KEY,
+    parent_session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS session_parents;
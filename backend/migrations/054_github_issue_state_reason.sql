@@ -0,0 +1,6 @@
+-- +goose Up
+-- Closed issues record why they were closed: completed or not_planned; reopened issues record reopened
+ALTER TABLE github_issues ADD COLUMN state_reason TEXT;
+
+-- +goose Down
+ALTER TABLE github_issues DROP COLUMN state_reason;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Outbound webhook receivers registered per session and simulator
+CREATE TABLE IF NOT EXISTS webhook_receivers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL,
+    simulator TEXT NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_receivers_session ON webhook_receivers(session_id, simulator);
+
+-- Every attempt to deliver a webhook, including retries
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    delivery_id TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    simulator TEXT NOT NULL,
+    event TEXT NOT NULL,
+    url TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_session ON webhook_deliveries(session_id, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhook_deliveries_session;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP INDEX IF EXISTS idx_webhook_receivers_session;
+DROP TABLE IF EXISTS webhook_receivers;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Repository webhooks registered through the GitHub hooks API
+CREATE TABLE IF NOT EXISTS github_hooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT 'json',
+    secret TEXT NOT NULL DEFAULT '',
+    events TEXT NOT NULL DEFAULT '["push"]',
+    active INTEGER NOT NULL DEFAULT 1,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_hooks_repo ON github_hooks(session_id, repo_owner, repo_name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_github_hooks_repo;
+DROP TABLE IF EXISTS github_hooks;
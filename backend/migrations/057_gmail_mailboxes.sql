@@ -0,0 +1,10 @@
+-- +goose Up
+-- Mailbox a message belongs to within its session: the {userId} path segment it was sent or
+-- imported under, with "me" and the primary address both stored as 'me'
+ALTER TABLE gmail_messages ADD COLUMN user_id TEXT NOT NULL DEFAULT 'me';
+
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_mailbox ON gmail_messages(session_id, user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_gmail_messages_mailbox;
+ALTER TABLE gmail_messages DROP COLUMN user_id;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Custom properties defined through the properties API
+CREATE TABLE IF NOT EXISTS hubspot_property_definitions (
+    session_id TEXT NOT NULL,
+    object_type TEXT NOT NULL,
+    name TEXT NOT NULL,
+    label TEXT NOT NULL,
+    type TEXT NOT NULL,
+    field_type TEXT NOT NULL,
+    group_name TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    options TEXT NOT NULL DEFAULT '[]',
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (session_id, object_type, name)
+);
+
+-- Values of properties without a typed column, as a JSON object per contact, deal or company
+CREATE TABLE IF NOT EXISTS hubspot_object_properties (
+    session_id TEXT NOT NULL,
+    object_type TEXT NOT NULL,
+    object_id TEXT NOT NULL,
+    properties TEXT NOT NULL DEFAULT '{}',
+    PRIMARY KEY (session_id, object_type, object_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS hubspot_object_properties;
+DROP TABLE IF EXISTS hubspot_property_definitions;
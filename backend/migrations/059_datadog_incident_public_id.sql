@@ -0,0 +1,18 @@
+-- +goose Up
+-- Per-session incident number shown as public_id alongside the UUID, allocated as MAX + 1
+ALTER TABLE datadog_incidents ADD COLUMN public_id INTEGER NOT NULL DEFAULT 0;
+
+UPDATE datadog_incidents
+SET public_id = (
+    SELECT COUNT(*)
+    FROM datadog_incidents AS earlier
+    WHERE earlier.session_id = datadog_incidents.session_id
+      AND (earlier.created_at < datadog_incidents.created_at
+        OR (earlier.created_at = datadog_incidents.created_at AND earlier.rowid <= datadog_incidents.rowid))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_datadog_incidents_public_id ON datadog_incidents(session_id, public_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_datadog_incidents_public_id;
+ALTER TABLE datadog_incidents DROP COLUMN public_id;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Emoji reactions left on messages through reactions.add, keyed by channel and message timestamp
+CREATE TABLE IF NOT EXISTS slack_reactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    channel_id TEXT NOT NULL,
+    message_ts TEXT NOT NULL,
+    name TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE (session_id, channel_id, message_ts, name, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_reactions_message ON slack_reactions(session_id, channel_id, message_ts);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_slack_reactions_message;
+DROP TABLE IF EXISTS slack_reactions;
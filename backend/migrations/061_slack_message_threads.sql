@@ -0,0 +1,9 @@
+-- +goose Up
+-- Timestamp of the thread parent a message was posted into; NULL for top-level messages
+ALTER TABLE slack_messages ADD COLUMN thread_ts TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_slack_messages_thread ON slack_messages(session_id, channel_id, thread_ts);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_slack_messages_thread;
+ALTER TABLE slack_messages DROP COLUMN thread_ts;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Labels applied to issues, in the order they were added
+CREATE TABLE IF NOT EXISTS github_issue_labels (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    issue_number INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE(repo_owner, repo_name, issue_number, name, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_issue_labels_session ON github_issue_labels(session_id, repo_owner, repo_name, issue_number);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_github_issue_labels_session;
+DROP TABLE IF EXISTS github_issue_labels;
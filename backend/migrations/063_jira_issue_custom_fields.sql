@@ -0,0 +1,11 @@
+-- +goose Up
+-- Values of fields without a typed column, such as customfield_10001, as a JSON object per issue
+CREATE TABLE IF NOT EXISTS jira_issue_custom_fields (
+    session_id TEXT NOT NULL,
+    issue_key TEXT NOT NULL,
+    fields TEXT NOT NULL DEFAULT '{}',
+    PRIMARY KEY (session_id, issue_key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS jira_issue_custom_fields;
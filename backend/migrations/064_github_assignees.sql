@@ -0,0 +1,8 @@
+-- +goose Up
+-- Assignee logins of issues and pull requests, as a JSON array
+ALTER TABLE github_issues ADD COLUMN assignees TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE github_pull_requests ADD COLUMN assignees TEXT NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE github_pull_requests DROP COLUMN assignees;
+ALTER TABLE github_issues DROP COLUMN assignees;
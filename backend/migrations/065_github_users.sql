@@ -0,0 +1,16 @@
+-- +goose Up
+-- Seeded user and organization accounts; the authenticated row is the session's current user
+CREATE TABLE IF NOT EXISTS github_users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    login TEXT NOT NULL COLLATE NOCASE,
+    type TEXT NOT NULL DEFAULT 'User',
+    name TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    authenticated INTEGER NOT NULL DEFAULT 0,
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE(login, session_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS github_users;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Commits recorded by content writes, each pointing at its parent and a snapshot of the branch's files
+CREATE TABLE IF NOT EXISTS github_commits (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    sha TEXT NOT NULL,
+    tree_sha TEXT NOT NULL,
+    parent_sha TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    author_name TEXT NOT NULL DEFAULT '',
+    author_email TEXT NOT NULL DEFAULT '',
+    session_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE(repo_owner, repo_name, sha, session_id)
+);
+
+CREATE TABLE IF NOT EXISTS github_tree_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_owner TEXT NOT NULL,
+    repo_name TEXT NOT NULL,
+    commit_sha TEXT NOT NULL,
+    path TEXT NOT NULL,
+    sha TEXT NOT NULL,
+    size INTEGER NOT NULL DEFAULT 0,
+    session_id TEXT NOT NULL,
+    UNIQUE(repo_owner, repo_name, commit_sha, path, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_commits_tree ON github_commits(session_id, repo_owner, repo_name, tree_sha);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_github_commits_tree;
+DROP TABLE IF EXISTS github_tree_entries;
+DROP TABLE IF EXISTS github_commits;
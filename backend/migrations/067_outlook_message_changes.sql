@@ -0,0 +1,10 @@
+-- +goose Up
+-- Per-session change sequence behind messages delta queries, bumped whenever a message is written
+ALTER TABLE outlook_messages ADD COLUMN change_seq INTEGER NOT NULL DEFAULT 0;
+UPDATE outlook_messages SET change_seq = rowid;
+
+CREATE INDEX IF NOT EXISTS idx_outlook_messages_change_seq ON outlook_messages(session_id, change_seq);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_outlook_messages_change_seq;
+ALTER TABLE outlook_messages DROP COLUMN change_seq;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Merged cell ranges, stored as 0-based end-exclusive grid indexes on the sheet with sheet_id
+CREATE TABLE IF NOT EXISTS gsheets_merges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    spreadsheet_id TEXT NOT NULL,
+    sheet_id INTEGER NOT NULL,
+    start_row_index INTEGER NOT NULL,
+    end_row_index INTEGER NOT NULL,
+    start_column_index INTEGER NOT NULL,
+    end_column_index INTEGER NOT NULL,
+    session_id TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    FOREIGN KEY (spreadsheet_id) REFERENCES gsheets_spreadsheets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_gsheets_merges_sheet ON gsheets_merges(session_id, spreadsheet_id, sheet_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_gsheets_merges_sheet;
+DROP TABLE IF EXISTS gsheets_merges;
@@ -0,0 +1,90 @@
+-- +goose Up
+-- Key messages by session so a child session can hold its own copy of a parent's message
+-- under the same ID when it modifies it, and record messages a child deleted from its parent
+CREATE TABLE gmail_messages_by_session (
+    id TEXT NOT NULL,
+    thread_id TEXT NOT NULL,
+    from_email TEXT NOT NULL,
+    to_email TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body_plain TEXT,
+    body_html TEXT,
+    raw_message TEXT NOT NULL,
+    snippet TEXT,
+    label_ids TEXT,
+    internal_date INTEGER NOT NULL,
+    size_estimate INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    session_id TEXT NOT NULL DEFAULT '',
+    cc_email TEXT NOT NULL DEFAULT '',
+    to_addresses TEXT NOT NULL DEFAULT '',
+    cc_addresses TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL DEFAULT 'me',
+    PRIMARY KEY (session_id, id)
+);
+
+INSERT INTO gmail_messages_by_session
+SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids,
+       internal_date, size_estimate, created_at, session_id, cc_email, to_addresses, cc_addresses, user_id
+FROM gmail_messages
+ORDER BY rowid;
+
+DROP TABLE gmail_messages;
+ALTER TABLE gmail_messages_by_session RENAME TO gmail_messages;
+
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_thread_id ON gmail_messages(thread_id);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_from_email ON gmail_messages(from_email);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_to_email ON gmail_messages(to_email);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_internal_date ON gmail_messages(internal_date);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_session ON gmail_messages(session_id);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_subject ON gmail_messages(subject);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_mailbox ON gmail_messages(session_id, user_id);
+
+CREATE TABLE IF NOT EXISTS gmail_message_tombstones (
+    session_id TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (session_id, message_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS gmail_message_tombstones;
+
+CREATE TABLE gmail_messages_by_id (
+    id TEXT PRIMARY KEY,
+    thread_id TEXT NOT NULL,
+    from_email TEXT NOT NULL,
+    to_email TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body_plain TEXT,
+    body_html TEXT,
+    raw_message TEXT NOT NULL,
+    snippet TEXT,
+    label_ids TEXT,
+    internal_date INTEGER NOT NULL,
+    size_estimate INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    session_id TEXT NOT NULL DEFAULT '',
+    cc_email TEXT NOT NULL DEFAULT '',
+    to_addresses TEXT NOT NULL DEFAULT '',
+    cc_addresses TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL DEFAULT 'me'
+);
+
+-- Child copies share their parent's ID; the first row for each ID is kept
+INSERT OR IGNORE INTO gmail_messages_by_id
+SELECT id, thread_id, from_email, to_email, subject, body_plain, body_html, raw_message, snippet, label_ids,
+       internal_date, size_estimate, created_at, session_id, cc_email, to_addresses, cc_addresses, user_id
+FROM gmail_messages
+ORDER BY rowid;
+
+DROP TABLE gmail_messages;
+ALTER TABLE gmail_messages_by_id RENAME TO gmail_messages;
+
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_thread_id ON gmail_messages(thread_id);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_from_email ON gmail_messages(from_email);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_to_email ON gmail_messages(to_email);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_internal_date ON gmail_messages(internal_date);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_session ON gmail_messages(session_id);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_subject ON gmail_messages(subject);
+CREATE INDEX IF NOT EXISTS idx_gmail_messages_mailbox ON gmail_messages(session_id, user_id);
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Whether an issue was converted into a pull request, which then holds the issue's number
+ALTER TABLE github_issues ADD COLUMN pull_request INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE github_issues DROP COLUMN pull_request;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Cc addresses of a message; like to_email, a comma-separated list
+ALTER TABLE outlook_messages ADD COLUMN cc_email TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE outlook_messages DROP COLUMN cc_email;
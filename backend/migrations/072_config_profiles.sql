@@ -0,0 +1,12 @@
+-- +goose Up
+-- Named config profiles: snapshots of a session's overrides that can be applied to any session
+-- Overrides are stored as the JSON list of per-simulator configs; saved_at is in Unix milliseconds
+
+CREATE TABLE IF NOT EXISTS config_profiles (
+    name TEXT PRIMARY KEY,
+    overrides TEXT NOT NULL,
+    saved_at INTEGER NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS config_profiles;
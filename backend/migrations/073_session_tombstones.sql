@@ -0,0 +1,14 @@
+-- +goose Up
+-- Objects a child session deleted from its parent. Reads and lists that fall through to the
+-- parent skip them. Gmail messages keep their own gmail_message_tombstones table.
+
+CREATE TABLE IF NOT EXISTS session_tombstones (
+    session_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    object_key TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (session_id, kind, object_key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS session_tombstones;
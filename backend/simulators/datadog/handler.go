@@ -2,18 +2,22 @@ package datadog
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -62,6 +66,7 @@ type IncidentUpdateRequest struct {
 
 type IncidentResponseAttributes struct {
 	Title            string                             `json:"title"`
+	PublicID         int64                              `json:"public_id"`
 	CustomerImpacted *bool                              `json:"customer_impacted,omitempty"`
 	Fields           map[string]IncidentFieldAttributes `json:"fields,omitempty"`
 	Created          *string                            `json:"created,omitempty"`
@@ -82,15 +87,138 @@ type IncidentListResponse struct {
 	Data []IncidentResponseData `json:"data"`
 }
 
+// Incident attachments and todos (v2 API relationships)
+type IncidentAttachmentDocument struct {
+	DocumentURL string `json:"documentUrl"`
+	Title       string `json:"title,omitempty"`
+}
+
+type IncidentAttachmentAttributes struct {
+	AttachmentType string                     `json:"attachment_type"`
+	Attachment     IncidentAttachmentDocument `json:"attachment"`
+	Modified       *string                    `json:"modified,omitempty"`
+}
+
+type IncidentAttachmentData struct {
+	ID            string                       `json:"id,omitempty"`
+	Type          string                       `json:"type"`
+	Attributes    IncidentAttachmentAttributes `json:"attributes"`
+	Relationships *IncidentRelationships       `json:"relationships,omitempty"`
+}
+
+type IncidentAttachmentRequest struct {
+	Data IncidentAttachmentData `json:"data"`
+}
+
+type IncidentAttachmentResponse struct {
+	Data IncidentAttachmentData `json:"data"`
+}
+
+type IncidentAttachmentListResponse struct {
+	Data []IncidentAttachmentData `json:"data"`
+}
+
+// IncidentTodoAttributes echoes assignees as sent, since clients may pass handles or user objects
+type IncidentTodoAttributes struct {
+	Content    string          `json:"content"`
+	Assignees  json.RawMessage `json:"assignees,omitempty"`
+	DueDate    *string         `json:"due_date"`
+	Completed  *string         `json:"completed"`
+	IncidentID string          `json:"incident_id,omitempty"`
+	Created    *string         `json:"created,omitempty"`
+	Modified   *string         `json:"modified,omitempty"`
+}
+
+type IncidentTodoData struct {
+	ID            string                 `json:"id,omitempty"`
+	Type          string                 `json:"type"`
+	Attributes    IncidentTodoAttributes `json:"attributes"`
+	Relationships *IncidentRelationships `json:"relationships,omitempty"`
+}
+
+type IncidentTodoRequest struct {
+	Data IncidentTodoData `json:"data"`
+}
+
+type IncidentTodoResponse struct {
+	Data IncidentTodoData `json:"data"`
+}
+
+type IncidentTodoListResponse struct {
+	Data []IncidentTodoData `json:"data"`
+}
+
+// IncidentRelationships links a sub-resource back to its incident
+type IncidentRelationships struct {
+	Incident IncidentRelationship `json:"incident"`
+}
+
+type IncidentRelationship struct {
+	Data IncidentRelationshipData `json:"data"`
+}
+
+type IncidentRelationshipData struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// validAttachmentTypes are the attachment kinds the incidents API accepts
+var validAttachmentTypes = map[string]bool{
+	"link":       true,
+	"postmortem": true,
+}
+
 // Monitors (v1 API)
 type Monitor struct {
-	ID      *int64  `json:"id,omitempty"`
-	Name    *string `json:"name,omitempty"`
-	Type    string  `json:"type"`
-	Query   string  `json:"query"`
-	Message *string `json:"message,omitempty"`
-	Created *string `json:"created,omitempty"`
-	Modified *string `json:"modified,omitempty"`
+	ID       *int64   `json:"id,omitempty"`
+	Name     *string  `json:"name,omitempty"`
+	Type     string   `json:"type"`
+	Query    string   `json:"query"`
+	Message  *string  `json:"message,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Created  *string  `json:"created,omitempty"`
+	Modified *string  `json:"modified,omitempty"`
+}
+
+// MonitorSearchResponse is the paginated envelope returned by /api/v1/monitor/search
+type MonitorSearchResponse struct {
+	Monitors []Monitor             `json:"monitors"`
+	Metadata MonitorSearchMetadata `json:"metadata"`
+}
+
+type MonitorSearchMetadata struct {
+	Page       int `json:"page"`
+	PageCount  int `json:"page_count"`
+	PerPage    int `json:"per_page"`
+	TotalCount int `json:"total_count"`
+}
+
+// MonitorErrorResponse lists the problems found with a monitor definition
+type MonitorErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// monitorTypes are the monitor types accepted by the v1 API
+var monitorTypes = map[string]bool{
+	"composite":                 true,
+	"event alert":               true,
+	"log alert":                 true,
+	"metric alert":              true,
+	"process alert":             true,
+	"query alert":               true,
+	"rum alert":                 true,
+	"service check":             true,
+	"synthetics alert":          true,
+	"trace-analytics alert":     true,
+	"slo alert":                 true,
+	"event-v2 alert":            true,
+	"audit alert":               true,
+	"ci-pipelines alert":        true,
+	"ci-tests alert":            true,
+	"error-tracking alert":      true,
+	"database-monitoring alert": true,
+	"network-performance alert": true,
+	"cost alert":                true,
 }
 
 type MonitorUpdateRequest struct {
@@ -139,6 +267,172 @@ type MetricSubmitResponse struct {
 	Errors []string `json:"errors,omitempty"`
 }
 
+// MetricSeriesV1 is a series in the legacy POST /api/v1/series payload; points are [seconds, value] pairs
+type MetricSeriesV1 struct {
+	Metric   string       `json:"metric"`
+	Points   [][]*float64 `json:"points"`
+	Tags     []string     `json:"tags,omitempty"`
+	Host     string       `json:"host,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Interval *int64       `json:"interval,omitempty"`
+}
+
+type MetricPayloadV1 struct {
+	Series []MetricSeriesV1 `json:"series"`
+}
+
+// IntakePayloadAccepted is the response to v1 intake endpoints
+type IntakePayloadAccepted struct {
+	Status string `json:"status"`
+}
+
+// MetricMetadata is the body of GET/PUT /api/v1/metrics/{metric_name}
+type MetricMetadata struct {
+	Type        *string `json:"type,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Unit        *string `json:"unit,omitempty"`
+}
+
+// MetricTagConfigurationResponse is returned by /api/v2/metrics/{metric_name}/tags
+type MetricTagConfigurationResponse struct {
+	Data MetricTagConfigurationData `json:"data"`
+}
+
+type MetricTagConfigurationData struct {
+	ID         string                           `json:"id"`
+	Type       string                           `json:"type"`
+	Attributes MetricTagConfigurationAttributes `json:"attributes"`
+}
+
+type MetricTagConfigurationAttributes struct {
+	MetricType string   `json:"metric_type"`
+	Tags       []string `json:"tags"`
+}
+
+// MetricsQueryResponse is the body of GET /api/v1/query
+type MetricsQueryResponse struct {
+	Status   string               `json:"status"`
+	ResType  string               `json:"res_type"`
+	Query    string               `json:"query"`
+	FromDate int64                `json:"from_date"`
+	ToDate   int64                `json:"to_date"`
+	GroupBy  []string             `json:"group_by"`
+	Message  string               `json:"message"`
+	Series   []MetricsQuerySeries `json:"series"`
+}
+
+// MetricsQuerySeries is one timeseries in a query response; points are [milliseconds, value] pairs
+type MetricsQuerySeries struct {
+	Metric      string      `json:"metric"`
+	DisplayName string      `json:"display_name"`
+	Expression  string      `json:"expression"`
+	Scope       string      `json:"scope"`
+	Aggr        string      `json:"aggr"`
+	Pointlist   [][]float64 `json:"pointlist"`
+	Start       int64       `json:"start"`
+	End         int64       `json:"end"`
+	Interval    int64       `json:"interval"`
+	Length      int64       `json:"length"`
+	QueryIndex  int64       `json:"query_index"`
+	TagSet      []string    `json:"tag_set"`
+}
+
+// ValidateResponse is the body of GET /api/v1/validate
+type ValidateResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// Users (v2 API)
+type UserAttributes struct {
+	Name           string `json:"name"`
+	Handle         string `json:"handle"`
+	Email          string `json:"email"`
+	Title          string `json:"title,omitempty"`
+	Status         string `json:"status"`
+	Disabled       bool   `json:"disabled"`
+	Verified       bool   `json:"verified"`
+	ServiceAccount bool   `json:"service_account"`
+	CreatedAt      string `json:"created_at"`
+	ModifiedAt     string `json:"modified_at"`
+}
+
+type UserData struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Attributes UserAttributes `json:"attributes"`
+}
+
+type UsersPageMeta struct {
+	TotalCount         int `json:"total_count"`
+	TotalFilteredCount int `json:"total_filtered_count"`
+}
+
+type UsersMeta struct {
+	Page UsersPageMeta `json:"page"`
+}
+
+type UsersResponse struct {
+	Data []UserData `json:"data"`
+	Meta UsersMeta  `json:"meta"`
+}
+
+// seededUsers is the fixed org membership every session sees on GET /api/v2/users
+var seededUsers = []UserData{
+	{
+		ID:   "3ad549bf-eba0-11e9-a77a-0705486660d0",
+		Type: "users",
+		Attributes: UserAttributes{
+			Name:       "Simulator Admin",
+			Handle:     "admin@example.com",
+			Email:      "admin@example.com",
+			Title:      "Administrator",
+			Status:     "Active",
+			Verified:   true,
+			CreatedAt:  "2024-01-01T00:00:00.000000+00:00",
+			ModifiedAt: "2024-01-01T00:00:00.000000+00:00",
+		},
+	},
+	{
+		ID:   "5d1e1a2c-eba0-11e9-a77a-0705486660d0",
+		Type: "users",
+		Attributes: UserAttributes{
+			Name:       "Simulator On-Call",
+			Handle:     "oncall@example.com",
+			Email:      "oncall@example.com",
+			Status:     "Active",
+			Verified:   true,
+			CreatedAt:  "2024-01-01T00:00:00.000000+00:00",
+			ModifiedAt: "2024-01-01T00:00:00.000000+00:00",
+		},
+	},
+}
+
+// metricQuery is one parsed expression such as "avg:system.cpu{env:prod}.rollup(avg, 60)"
+type metricQuery struct {
+	expression     string
+	aggregator     string
+	metric         string
+	scope          string
+	scopeTags      []string
+	rollupMethod   string
+	rollupInterval int64
+}
+
+// metricQueryPattern matches [aggregator:]metric[{scope}][.rollup(method[, seconds])]
+var metricQueryPattern = regexp.MustCompile(
+	`^(?:(avg|sum|min|max):)?([A-Za-z0-9_.]+?)(?:\{([^}]*)\})?(?:\.rollup\(\s*(avg|sum|min|max|count)\s*(?:,\s*(\d+)\s*)?\))?$`)
+
+// defaultRollupInterval is used when a rollup names a method but no interval
+const defaultRollupInterval = 60
+
+// validMetricTypes lists the metric types accepted by metadata updates
+var validMetricTypes = map[string]bool{
+	"gauge":        true,
+	"rate":         true,
+	"count":        true,
+	"distribution": true,
+}
+
 // Handler implements the Datadog simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -151,6 +445,20 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountDatadogSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"metrics":   counts.Metrics,
+		"events":    counts.Events,
+		"monitors":  counts.Monitors,
+		"incidents": counts.Incidents,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[datadog] → %s %s", r.Method, r.URL.Path)
@@ -180,6 +488,36 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if path == "/api/v1/series" {
+		h.handleMetricsV1(w, r)
+		return
+	}
+
+	if path == "/api/v1/query" {
+		h.handleQueryMetricsV1(w, r)
+		return
+	}
+
+	if strings.HasPrefix(path, "/api/v1/metrics/") {
+		h.handleMetricMetadataV1(w, r)
+		return
+	}
+
+	if strings.HasPrefix(path, "/api/v2/metrics/") {
+		h.handleMetricTagsV2(w, r)
+		return
+	}
+
+	if path == "/api/v1/validate" && r.Method == http.MethodGet {
+		h.handleValidateV1(w, r)
+		return
+	}
+
+	if path == "/api/v2/users" && r.Method == http.MethodGet {
+		h.handleListUsersV2(w, r)
+		return
+	}
+
 	http.NotFound(w, r)
 }
 
@@ -195,6 +533,8 @@ func (h *Handler) handleIncidentsV2(w http.ResponseWriter, r *http.Request) {
 		h.handleCreateIncident(w, r)
 	case path == "" && r.Method == http.MethodGet:
 		h.handleListIncidents(w, r)
+	case strings.Contains(path, "/relationships/") || strings.HasSuffix(path, "/attachments"):
+		h.handleIncidentRelationships(w, r, strings.TrimPrefix(path, "/"))
 	case strings.HasPrefix(path, "/") && r.Method == http.MethodGet:
 		incidentID := strings.TrimPrefix(path, "/")
 		h.handleGetIncident(w, r, incidentID)
@@ -217,9 +557,9 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate incident ID
-	incidentID := generateIncidentID()
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().Unix()
+	incidentID := generateIncidentID(sessionID)
+	now := session.Now(sessionID).Unix()
 
 	// Extract severity from fields
 	var severity sql.NullString
@@ -240,7 +580,7 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Store incident in database
-	err := h.queries.CreateDatadogIncident(context.Background(), database.CreateDatadogIncidentParams{
+	publicID, err := h.queries.CreateDatadogIncident(context.Background(), database.CreateDatadogIncidentParams{
 		ID:               incidentID,
 		Title:            req.Data.Attributes.Title,
 		CustomerImpacted: customerImpacted,
@@ -248,6 +588,7 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 		SessionID:        sessionID,
 		CreatedAt:        now,
 		UpdatedAt:        now,
+		ParentSessionID:  session.Parent(sessionID),
 	})
 
 	if err != nil {
@@ -263,6 +604,7 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 
 	attrs := IncidentResponseAttributes{
 		Title:            req.Data.Attributes.Title,
+		PublicID:         publicID,
 		CustomerImpacted: &customerImpactedBool,
 		Created:          &createdTime,
 		Modified:         &modifiedTime,
@@ -296,11 +638,11 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetIncident(w http.ResponseWriter, r *http.Request, incidentID string) {
 	log.Printf("[datadog] → Received get incident request for ID: %s", incidentID)
 
-	sessionID := session.FromContext(r.Context())
-
-	incident, err := h.queries.GetDatadogIncidentByID(context.Background(), database.GetDatadogIncidentByIDParams{
-		ID:        incidentID,
-		SessionID: sessionID,
+	incident, err := session.ReadThrough(r.Context(), "datadog_incidents", incidentID, func(candidate string) (database.DatadogIncident, error) {
+		return h.queries.GetDatadogIncidentByID(context.Background(), database.GetDatadogIncidentByIDParams{
+			ID:        incidentID,
+			SessionID: candidate,
+		})
 	})
 
 	if err != nil {
@@ -315,6 +657,7 @@ func (h *Handler) handleGetIncident(w http.ResponseWriter, r *http.Request, inci
 
 	attrs := IncidentResponseAttributes{
 		Title:            incident.Title,
+		PublicID:         incident.PublicID,
 		CustomerImpacted: &customerImpactedBool,
 		Created:          &createdTime,
 		Modified:         &modifiedTime,
@@ -355,7 +698,7 @@ func (h *Handler) handleUpdateIncident(w http.ResponseWriter, r *http.Request, i
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().Unix()
+	now := session.Now(sessionID).Unix()
 
 	// Prepare update params
 	var title sql.NullString
@@ -418,6 +761,7 @@ func (h *Handler) handleUpdateIncident(w http.ResponseWriter, r *http.Request, i
 
 	attrs := IncidentResponseAttributes{
 		Title:            incident.Title,
+		PublicID:         incident.PublicID,
 		CustomerImpacted: &customerImpactedBool,
 		Created:          &createdTime,
 		Modified:         &modifiedTime,
@@ -450,7 +794,6 @@ func (h *Handler) handleUpdateIncident(w http.ResponseWriter, r *http.Request, i
 func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 	log.Println("[datadog] → Received list incidents request")
 
-	sessionID := session.FromContext(r.Context())
 	pageSize := int64(100)
 
 	if ps := r.URL.Query().Get("page[size]"); ps != "" {
@@ -459,16 +802,23 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	incidents, err := h.queries.ListDatadogIncidents(context.Background(), database.ListDatadogIncidentsParams{
-		SessionID: sessionID,
-		Limit:     pageSize,
-	})
+	incidents, err := session.ListThrough(r.Context(), "datadog_incidents", func(candidate string) ([]database.ListDatadogIncidentsRow, error) {
+		return h.queries.ListDatadogIncidents(context.Background(), database.ListDatadogIncidentsParams{
+			SessionID: candidate,
+			Limit:     pageSize,
+		})
+	}, nil, func(incident database.ListDatadogIncidentsRow) string { return incident.ID })
 
 	if err != nil {
 		log.Printf("[datadog] ✗ Failed to list incidents: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	// Merge the parent's incidents into the newest-first page
+	sort.SliceStable(incidents, func(i, j int) bool { return incidents[i].CreatedAt > incidents[j].CreatedAt })
+	if int64(len(incidents)) > pageSize {
+		incidents = incidents[:pageSize]
+	}
 
 	data := make([]IncidentResponseData, 0, len(incidents))
 	for _, incident := range incidents {
@@ -478,6 +828,7 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 
 		attrs := IncidentResponseAttributes{
 			Title:            incident.Title,
+			PublicID:         incident.PublicID,
 			CustomerImpacted: &customerImpactedBool,
 			Created:          &createdTime,
 			Modified:         &modifiedTime,
@@ -501,6 +852,8 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	data = listcap.Truncate(w, data)
+
 	response := IncidentListResponse{
 		Data: data,
 	}
@@ -510,6 +863,256 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[datadog] ✓ Listed %d incidents", len(incidents))
 }
 
+// handleIncidentRelationships serves the attachments and todos of an incident:
+// /api/v2/incidents/{id}/relationships/{attachments|todos}
+func (h *Handler) handleIncidentRelationships(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(path, "/")
+	// The client SDKs list attachments at /api/v2/incidents/{id}/attachments
+	if len(parts) == 2 && parts[1] == "attachments" {
+		parts = []string{parts[0], "relationships", "attachments"}
+	}
+	if len(parts) != 3 || parts[1] != "relationships" {
+		http.NotFound(w, r)
+		return
+	}
+	incidentID := parts[0]
+
+	sessionID := session.FromContext(r.Context())
+	_, err := h.queries.GetDatadogIncidentByID(context.Background(), database.GetDatadogIncidentByIDParams{
+		ID:        incidentID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Incident not found: %s", incidentID)
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case parts[2] == "attachments" && r.Method == http.MethodGet:
+		h.handleListIncidentAttachments(w, incidentID, sessionID)
+	case parts[2] == "attachments" && r.Method == http.MethodPost:
+		h.handleCreateIncidentAttachment(w, r, incidentID, sessionID)
+	case parts[2] == "todos" && r.Method == http.MethodGet:
+		h.handleListIncidentTodos(w, incidentID, sessionID)
+	case parts[2] == "todos" && r.Method == http.MethodPost:
+		h.handleCreateIncidentTodo(w, r, incidentID, sessionID)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) handleCreateIncidentAttachment(w http.ResponseWriter, r *http.Request, incidentID, sessionID string) {
+	log.Printf("[datadog] → Received create attachment request for incident: %s", incidentID)
+
+	var req IncidentAttachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[datadog] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	attrs := req.Data.Attributes
+	if !validAttachmentTypes[attrs.AttachmentType] {
+		http.Error(w, "attachment_type must be one of link, postmortem", http.StatusBadRequest)
+		return
+	}
+	if attrs.Attachment.DocumentURL == "" {
+		http.Error(w, "attachment.documentUrl is required", http.StatusBadRequest)
+		return
+	}
+
+	attachmentID := generateIncidentID(sessionID)
+	now := session.Now(sessionID).Unix()
+	err := h.queries.CreateDatadogIncidentAttachment(context.Background(), database.CreateDatadogIncidentAttachmentParams{
+		ID:             attachmentID,
+		IncidentID:     incidentID,
+		AttachmentType: attrs.AttachmentType,
+		DocumentUrl:    attrs.Attachment.DocumentURL,
+		Title:          attrs.Attachment.Title,
+		SessionID:      sessionID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to store attachment: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := IncidentAttachmentResponse{
+		Data: incidentAttachmentFromDB(&database.DatadogIncidentAttachment{
+			ID:             attachmentID,
+			IncidentID:     incidentID,
+			AttachmentType: attrs.AttachmentType,
+			DocumentUrl:    attrs.Attachment.DocumentURL,
+			Title:          attrs.Attachment.Title,
+			UpdatedAt:      now,
+		}),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[datadog] ✓ Attachment %s added to incident %s", attachmentID, incidentID)
+}
+
+func (h *Handler) handleListIncidentAttachments(w http.ResponseWriter, incidentID, sessionID string) {
+	log.Printf("[datadog] → Received list attachments request for incident: %s", incidentID)
+
+	attachments, err := h.queries.ListDatadogIncidentAttachments(context.Background(), database.ListDatadogIncidentAttachmentsParams{
+		IncidentID: incidentID,
+		SessionID:  sessionID,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to list attachments: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := make([]IncidentAttachmentData, 0, len(attachments))
+	for i := range attachments {
+		data = append(data, incidentAttachmentFromDB(&attachments[i]))
+	}
+	data = listcap.Truncate(w, data)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(IncidentAttachmentListResponse{Data: data})
+	log.Printf("[datadog] ✓ Listed %d attachments for incident %s", len(data), incidentID)
+}
+
+func (h *Handler) handleCreateIncidentTodo(w http.ResponseWriter, r *http.Request, incidentID, sessionID string) {
+	log.Printf("[datadog] → Received create todo request for incident: %s", incidentID)
+
+	var req IncidentTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[datadog] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	attrs := req.Data.Attributes
+	if attrs.Content == "" {
+		http.Error(w, "content is required", http.StatusBadRequest)
+		return
+	}
+	assignees := "[]"
+	if len(attrs.Assignees) > 0 && string(attrs.Assignees) != "null" {
+		assignees = string(attrs.Assignees)
+	}
+
+	todo := database.DatadogIncidentTodo{
+		ID:         generateIncidentID(sessionID),
+		IncidentID: incidentID,
+		Content:    attrs.Content,
+		Assignees:  assignees,
+		SessionID:  sessionID,
+		CreatedAt:  session.Now(sessionID).Unix(),
+	}
+	todo.UpdatedAt = todo.CreatedAt
+	if attrs.DueDate != nil {
+		todo.DueDate = sql.NullString{String: *attrs.DueDate, Valid: true}
+	}
+	if attrs.Completed != nil {
+		todo.Completed = sql.NullString{String: *attrs.Completed, Valid: true}
+	}
+
+	err := h.queries.CreateDatadogIncidentTodo(context.Background(), database.CreateDatadogIncidentTodoParams{
+		ID:         todo.ID,
+		IncidentID: todo.IncidentID,
+		Content:    todo.Content,
+		Assignees:  todo.Assignees,
+		DueDate:    todo.DueDate,
+		Completed:  todo.Completed,
+		SessionID:  todo.SessionID,
+		CreatedAt:  todo.CreatedAt,
+		UpdatedAt:  todo.UpdatedAt,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to store todo: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(IncidentTodoResponse{Data: incidentTodoFromDB(&todo)})
+	log.Printf("[datadog] ✓ Todo %s added to incident %s", todo.ID, incidentID)
+}
+
+func (h *Handler) handleListIncidentTodos(w http.ResponseWriter, incidentID, sessionID string) {
+	log.Printf("[datadog] → Received list todos request for incident: %s", incidentID)
+
+	todos, err := h.queries.ListDatadogIncidentTodos(context.Background(), database.ListDatadogIncidentTodosParams{
+		IncidentID: incidentID,
+		SessionID:  sessionID,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to list todos: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := make([]IncidentTodoData, 0, len(todos))
+	for i := range todos {
+		data = append(data, incidentTodoFromDB(&todos[i]))
+	}
+	data = listcap.Truncate(w, data)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(IncidentTodoListResponse{Data: data})
+	log.Printf("[datadog] ✓ Listed %d todos for incident %s", len(data), incidentID)
+}
+
+func incidentAttachmentFromDB(attachment *database.DatadogIncidentAttachment) IncidentAttachmentData {
+	modified := time.Unix(attachment.UpdatedAt, 0).Format(time.RFC3339)
+	return IncidentAttachmentData{
+		ID:   attachment.ID,
+		Type: "incident_attachments",
+		Attributes: IncidentAttachmentAttributes{
+			AttachmentType: attachment.AttachmentType,
+			Attachment: IncidentAttachmentDocument{
+				DocumentURL: attachment.DocumentUrl,
+				Title:       attachment.Title,
+			},
+			Modified: &modified,
+		},
+		Relationships: incidentRelationships(attachment.IncidentID),
+	}
+}
+
+func incidentTodoFromDB(todo *database.DatadogIncidentTodo) IncidentTodoData {
+	created := time.Unix(todo.CreatedAt, 0).Format(time.RFC3339)
+	modified := time.Unix(todo.UpdatedAt, 0).Format(time.RFC3339)
+	attrs := IncidentTodoAttributes{
+		Content:    todo.Content,
+		Assignees:  json.RawMessage(todo.Assignees),
+		IncidentID: todo.IncidentID,
+		Created:    &created,
+		Modified:   &modified,
+	}
+	if todo.DueDate.Valid {
+		attrs.DueDate = &todo.DueDate.String
+	}
+	if todo.Completed.Valid {
+		attrs.Completed = &todo.Completed.String
+	}
+	return IncidentTodoData{
+		ID:            todo.ID,
+		Type:          "incident_todos",
+		Attributes:    attrs,
+		Relationships: incidentRelationships(todo.IncidentID),
+	}
+}
+
+func incidentRelationships(incidentID string) *IncidentRelationships {
+	return &IncidentRelationships{
+		Incident: IncidentRelationship{
+			Data: IncidentRelationshipData{ID: incidentID, Type: "incidents"},
+		},
+	}
+}
+
 // Monitors V1 handlers
 
 func (h *Handler) handleMonitorsV1(w http.ResponseWriter, r *http.Request) {
@@ -522,6 +1125,10 @@ func (h *Handler) handleMonitorsV1(w http.ResponseWriter, r *http.Request) {
 		h.handleCreateMonitor(w, r)
 	case path == "" && r.Method == http.MethodGet:
 		h.handleListMonitors(w, r)
+	case path == "/search" && r.Method == http.MethodGet:
+		h.handleSearchMonitors(w, r)
+	case path == "/validate" && r.Method == http.MethodPost:
+		h.handleValidateMonitor(w, r)
 	case strings.HasPrefix(path, "/") && r.Method == http.MethodGet:
 		monitorIDStr := strings.TrimPrefix(path, "/")
 		if monitorID, err := strconv.ParseInt(monitorIDStr, 10, 64); err == nil {
@@ -558,8 +1165,14 @@ func (h *Handler) handleCreateMonitor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if errs := validateMonitor(&req); len(errs) > 0 {
+		log.Printf("[datadog] ✗ Invalid monitor: %v", errs)
+		writeMonitorErrors(w, errs)
+		return
+	}
+
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().Unix()
+	now := session.Now(sessionID).Unix()
 
 	var message sql.NullString
 	if req.Message != nil {
@@ -571,11 +1184,18 @@ func (h *Handler) handleCreateMonitor(w http.ResponseWriter, r *http.Request) {
 		name = *req.Name
 	}
 
+	var tags sql.NullString
+	if len(req.Tags) > 0 {
+		tagsJSON, _ := json.Marshal(req.Tags)
+		tags = sql.NullString{String: string(tagsJSON), Valid: true}
+	}
+
 	monitor, err := h.queries.CreateDatadogMonitor(context.Background(), database.CreateDatadogMonitorParams{
 		Name:      name,
 		Type:      req.Type,
 		Query:     req.Query,
 		Message:   message,
+		Tags:      tags,
 		SessionID: sessionID,
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -603,30 +1223,76 @@ func (h *Handler) handleCreateMonitor(w http.ResponseWriter, r *http.Request) {
 		response.Message = &monitor.Message.String
 	}
 
+	if monitor.Tags.Valid {
+		_ = json.Unmarshal([]byte(monitor.Tags.String), &response.Tags)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[datadog] ✓ Monitor created: %d", monitor.ID)
 }
 
-func (h *Handler) handleGetMonitor(w http.ResponseWriter, r *http.Request, monitorID int64) {
-	log.Printf("[datadog] → Received get monitor request for ID: %d", monitorID)
-
-	sessionID := session.FromContext(r.Context())
+func (h *Handler) handleValidateMonitor(w http.ResponseWriter, r *http.Request) {
+	log.Println("[datadog] → Received validate monitor request")
 
-	monitor, err := h.queries.GetDatadogMonitorByID(context.Background(), database.GetDatadogMonitorByIDParams{
-		ID:        monitorID,
-		SessionID: sessionID,
-	})
+	var req Monitor
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[datadog] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
 
-	if err != nil {
-		log.Printf("[datadog] ✗ Failed to get monitor: %v", err)
-		http.NotFound(w, r)
+	if errs := validateMonitor(&req); len(errs) > 0 {
+		log.Printf("[datadog] ✗ Invalid monitor: %v", errs)
+		writeMonitorErrors(w, errs)
 		return
 	}
 
-	createdTime := time.Unix(monitor.CreatedAt, 0).Format(time.RFC3339)
-	modifiedTime := time.Unix(monitor.UpdatedAt, 0).Format(time.RFC3339)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+	log.Println("[datadog] ✓ Monitor is valid")
+}
+
+// validateMonitor checks a monitor definition the way the create endpoint does and returns
+// every problem found
+func validateMonitor(monitor *Monitor) []string {
+	var errs []string
+	if monitor.Type == "" {
+		errs = append(errs, "The value provided for parameter 'type' is invalid: type is required")
+	} else if !monitorTypes[monitor.Type] {
+		errs = append(errs, fmt.Sprintf("The value provided for parameter 'type' is invalid: unknown monitor type %q", monitor.Type))
+	}
+	if strings.TrimSpace(monitor.Query) == "" {
+		errs = append(errs, "The value provided for parameter 'query' is invalid: query is required")
+	}
+	return errs
+}
+
+func writeMonitorErrors(w http.ResponseWriter, errs []string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(MonitorErrorResponse{Errors: errs})
+}
+
+func (h *Handler) handleGetMonitor(w http.ResponseWriter, r *http.Request, monitorID int64) {
+	log.Printf("[datadog] → Received get monitor request for ID: %d", monitorID)
+
+	monitor, err := session.ReadThrough(r.Context(), "datadog_monitors", strconv.FormatInt(monitorID, 10), func(candidate string) (database.DatadogMonitor, error) {
+		return h.queries.GetDatadogMonitorByID(context.Background(), database.GetDatadogMonitorByIDParams{
+			ID:        monitorID,
+			SessionID: candidate,
+		})
+	})
+
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to get monitor: %v", err)
+		http.NotFound(w, r)
+		return
+	}
+
+	createdTime := time.Unix(monitor.CreatedAt, 0).Format(time.RFC3339)
+	modifiedTime := time.Unix(monitor.UpdatedAt, 0).Format(time.RFC3339)
 
 	response := Monitor{
 		ID:       &monitor.ID,
@@ -641,6 +1307,10 @@ func (h *Handler) handleGetMonitor(w http.ResponseWriter, r *http.Request, monit
 		response.Message = &monitor.Message.String
 	}
 
+	if monitor.Tags.Valid {
+		_ = json.Unmarshal([]byte(monitor.Tags.String), &response.Tags)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[datadog] ✓ Returned monitor: %d", monitorID)
@@ -657,7 +1327,7 @@ func (h *Handler) handleUpdateMonitor(w http.ResponseWriter, r *http.Request, mo
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().Unix()
+	now := session.Now(sessionID).Unix()
 
 	var name, query, message sql.NullString
 	if req.Name != nil {
@@ -713,6 +1383,10 @@ func (h *Handler) handleUpdateMonitor(w http.ResponseWriter, r *http.Request, mo
 		response.Message = &monitor.Message.String
 	}
 
+	if monitor.Tags.Valid {
+		_ = json.Unmarshal([]byte(monitor.Tags.String), &response.Tags)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[datadog] ✓ Monitor updated: %d", monitorID)
@@ -728,6 +1402,19 @@ func (h *Handler) handleDeleteMonitor(w http.ResponseWriter, r *http.Request, mo
 		SessionID: sessionID,
 	})
 
+	// A monitor inherited from the parent session is left in place and hidden from the child
+	if parentID := session.ParentFromContext(r.Context()); err == nil && parentID != "" {
+		_, err = h.queries.GetDatadogMonitorByID(context.Background(), database.GetDatadogMonitorByIDParams{
+			ID:        monitorID,
+			SessionID: parentID,
+		})
+		if err == nil {
+			err = session.Hide(r.Context(), "datadog_monitors", strconv.FormatInt(monitorID, 10))
+		} else if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+	}
+
 	if err != nil {
 		log.Printf("[datadog] ✗ Failed to delete monitor: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -738,12 +1425,17 @@ func (h *Handler) handleDeleteMonitor(w http.ResponseWriter, r *http.Request, mo
 	log.Printf("[datadog] ✓ Monitor deleted: %d", monitorID)
 }
 
+// listMonitors lists the session's monitors followed by those it inherits from its parent
+func (h *Handler) listMonitors(ctx context.Context) ([]database.ListDatadogMonitorsRow, error) {
+	return session.ListThrough(ctx, "datadog_monitors", func(candidate string) ([]database.ListDatadogMonitorsRow, error) {
+		return h.queries.ListDatadogMonitors(context.Background(), candidate)
+	}, nil, func(monitor database.ListDatadogMonitorsRow) string { return strconv.FormatInt(monitor.ID, 10) })
+}
+
 func (h *Handler) handleListMonitors(w http.ResponseWriter, r *http.Request) {
 	log.Println("[datadog] → Received list monitors request")
 
-	sessionID := session.FromContext(r.Context())
-
-	monitors, err := h.queries.ListDatadogMonitors(context.Background(), sessionID)
+	monitors, err := h.listMonitors(r.Context())
 
 	if err != nil {
 		log.Printf("[datadog] ✗ Failed to list monitors: %v", err)
@@ -752,29 +1444,114 @@ func (h *Handler) handleListMonitors(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := make([]Monitor, 0, len(monitors))
-	for _, monitor := range monitors {
-		createdTime := time.Unix(monitor.CreatedAt, 0).Format(time.RFC3339)
-		modifiedTime := time.Unix(monitor.UpdatedAt, 0).Format(time.RFC3339)
+	for i := range monitors {
+		response = append(response, monitorFromListRow(&monitors[i]))
+	}
+	response = listcap.Truncate(w, response)
 
-		m := Monitor{
-			ID:       &monitor.ID,
-			Name:     &monitor.Name,
-			Type:     monitor.Type,
-			Query:    monitor.Query,
-			Created:  &createdTime,
-			Modified: &modifiedTime,
-		}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[datadog] ✓ Listed %d monitors", len(monitors))
+}
+
+func (h *Handler) handleSearchMonitors(w http.ResponseWriter, r *http.Request) {
+	log.Println("[datadog] → Received search monitors request")
 
-		if monitor.Message.Valid {
-			m.Message = &monitor.Message.String
+	query := strings.ToLower(r.URL.Query().Get("query"))
+
+	// Pages are zero-based, matching the Datadog API
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 0 {
+		page = 0
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage <= 0 {
+		perPage = 30
+	}
+	perPage, clamped := listcap.Clamp(perPage)
+
+	monitors, err := h.listMonitors(r.Context())
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to list monitors: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Substring match on name, query, and tags
+	matched := make([]Monitor, 0, len(monitors))
+	for i := range monitors {
+		m := monitorFromListRow(&monitors[i])
+		if query == "" || monitorMatches(&m, query) {
+			matched = append(matched, m)
 		}
+	}
 
-		response = append(response, m)
+	start := page * perPage
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + perPage
+	if end > len(matched) {
+		end = len(matched)
+	}
+	if clamped && end < len(matched) {
+		listcap.MarkTruncated(w)
+	}
+
+	response := MonitorSearchResponse{
+		Monitors: matched[start:end],
+		Metadata: MonitorSearchMetadata{
+			Page:       page,
+			PageCount:  (len(matched) + perPage - 1) / perPage,
+			PerPage:    perPage,
+			TotalCount: len(matched),
+		},
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[datadog] ✓ Listed %d monitors", len(monitors))
+	log.Printf("[datadog] ✓ Search matched %d monitors", len(matched))
+}
+
+// monitorFromListRow converts a stored monitor to its API representation
+func monitorFromListRow(monitor *database.ListDatadogMonitorsRow) Monitor {
+	createdTime := time.Unix(monitor.CreatedAt, 0).Format(time.RFC3339)
+	modifiedTime := time.Unix(monitor.UpdatedAt, 0).Format(time.RFC3339)
+
+	m := Monitor{
+		ID:       &monitor.ID,
+		Name:     &monitor.Name,
+		Type:     monitor.Type,
+		Query:    monitor.Query,
+		Created:  &createdTime,
+		Modified: &modifiedTime,
+	}
+
+	if monitor.Message.Valid {
+		m.Message = &monitor.Message.String
+	}
+
+	if monitor.Tags.Valid {
+		_ = json.Unmarshal([]byte(monitor.Tags.String), &m.Tags)
+	}
+
+	return m
+}
+
+// monitorMatches reports whether the lowercased query is a substring of the monitor's name, query, or tags
+func monitorMatches(m *Monitor, query string) bool {
+	if m.Name != nil && strings.Contains(strings.ToLower(*m.Name), query) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(m.Query), query) {
+		return true
+	}
+	for _, tag := range m.Tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return true
+		}
+	}
+	return false
 }
 
 // Events V1 handlers
@@ -799,7 +1576,7 @@ func (h *Handler) handlePostEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().Unix()
+	now := session.Now(sessionID).Unix()
 
 	var tags sql.NullString
 	if len(req.Tags) > 0 {
@@ -844,6 +1621,83 @@ func (h *Handler) handlePostEvent(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[datadog] ✓ Event posted: %d", event.ID)
 }
 
+// Metrics V1 handlers
+
+func (h *Handler) handleMetricsV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		h.handleSubmitMetricsV1(w, r)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+// handleSubmitMetricsV1 accepts the legacy series payload still sent by the agent and older
+// clients, storing points alongside v2 submissions. A series host is stored as a host: tag.
+func (h *Handler) handleSubmitMetricsV1(w http.ResponseWriter, r *http.Request) {
+	log.Println("[datadog] → Received v1 submit metrics request")
+
+	var req MetricPayloadV1
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[datadog] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	for _, series := range req.Series {
+		if series.Metric == "" {
+			log.Println("[datadog] ✗ Series without metric name")
+			http.Error(w, "Series must have a metric name", http.StatusBadRequest)
+			return
+		}
+		for _, point := range series.Points {
+			if len(point) != 2 || point[0] == nil || point[1] == nil {
+				log.Printf("[datadog] ✗ Invalid point for %s: %v", series.Metric, point)
+				http.Error(w, "Points must be [timestamp, value] pairs", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	sessionID := session.FromContext(r.Context())
+	now := session.Now(sessionID).Unix()
+	stored := 0
+
+	for _, series := range req.Series {
+		seriesTags := series.Tags
+		if series.Host != "" {
+			seriesTags = append(append([]string{}, seriesTags...), "host:"+series.Host)
+		}
+		var tags sql.NullString
+		if len(seriesTags) > 0 {
+			tagsJSON, _ := json.Marshal(seriesTags)
+			tags = sql.NullString{String: string(tagsJSON), Valid: true}
+		}
+
+		for _, point := range series.Points {
+			err := h.queries.CreateDatadogMetric(context.Background(), database.CreateDatadogMetricParams{
+				MetricName: series.Metric,
+				Value:      *point[1],
+				Tags:       tags,
+				Timestamp:  int64(*point[0]),
+				SessionID:  sessionID,
+				CreatedAt:  now,
+			})
+			if err != nil {
+				log.Printf("[datadog] ✗ Failed to store metric: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			stored++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(IntakePayloadAccepted{Status: "ok"})
+	log.Printf("[datadog] ✓ Stored %d v1 metric points", stored)
+}
+
 // Metrics V2 handlers
 
 func (h *Handler) handleMetricsV2(w http.ResponseWriter, r *http.Request) {
@@ -866,7 +1720,7 @@ func (h *Handler) handleSubmitMetrics(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().Unix()
+	now := session.Now(sessionID).Unix()
 
 	for _, series := range req.Series {
 		for _, point := range series.Points {
@@ -913,11 +1767,514 @@ func (h *Handler) handleSubmitMetrics(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[datadog] ✓ Metrics submitted")
 }
 
+// Metrics query V1 handlers
+
+func (h *Handler) handleQueryMetricsV1(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log.Println("[datadog] → Received query metrics request")
+
+	params := r.URL.Query()
+	from, fromErr := strconv.ParseInt(params.Get("from"), 10, 64)
+	to, toErr := strconv.ParseInt(params.Get("to"), 10, 64)
+	if fromErr != nil || toErr != nil {
+		http.Error(w, "from and to must be unix timestamps in seconds", http.StatusBadRequest)
+		return
+	}
+
+	rawQuery := params.Get("query")
+	queries, err := parseMetricQueries(rawQuery)
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to parse query: %v", err)
+		http.Error(w, "Error parsing query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+
+	series := make([]MetricsQuerySeries, 0, len(queries))
+	for i := range queries {
+		rows, err := h.queries.ListDatadogMetricsInRange(context.Background(), database.ListDatadogMetricsInRangeParams{
+			SessionID:   sessionID,
+			MetricName:  queries[i].metric,
+			Timestamp:   from,
+			Timestamp_2: to,
+		})
+		if err != nil {
+			log.Printf("[datadog] ✗ Failed to query metrics: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		points := queries[i].evaluate(rows)
+		if len(points) == 0 {
+			continue
+		}
+		series = append(series, queries[i].series(int64(i), points))
+	}
+
+	response := MetricsQueryResponse{
+		Status:   "ok",
+		ResType:  "time_series",
+		Query:    rawQuery,
+		FromDate: from * 1000,
+		ToDate:   to * 1000,
+		GroupBy:  []string{},
+		Series:   series,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[datadog] ✓ Returned %d series for %d queries", len(series), len(queries))
+}
+
+// parseMetricQueries splits a comma-separated query string, ignoring commas inside
+// scopes and function arguments, and parses each expression
+func parseMetricQueries(raw string) ([]metricQuery, error) {
+	var expressions []string
+	depth, start := 0, 0
+	for i, ch := range raw {
+		switch ch {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				expressions = append(expressions, raw[start:i])
+				start = i + 1
+			}
+		}
+	}
+	expressions = append(expressions, raw[start:])
+
+	queries := make([]metricQuery, 0, len(expressions))
+	for _, expression := range expressions {
+		expression = strings.TrimSpace(expression)
+		match := metricQueryPattern.FindStringSubmatch(expression)
+		if match == nil {
+			return nil, fmt.Errorf("unsupported expression %q", expression)
+		}
+
+		query := metricQuery{
+			expression:   expression,
+			aggregator:   match[1],
+			metric:       match[2],
+			scope:        strings.TrimSpace(match[3]),
+			rollupMethod: match[4],
+		}
+		if query.aggregator == "" {
+			query.aggregator = "avg"
+		}
+		if query.scope == "" {
+			query.scope = "*"
+		}
+		if query.scope != "*" {
+			for _, tag := range strings.Split(query.scope, ",") {
+				query.scopeTags = append(query.scopeTags, strings.TrimSpace(tag))
+			}
+		}
+		if query.rollupMethod != "" {
+			query.rollupInterval = defaultRollupInterval
+			if match[5] != "" {
+				query.rollupInterval, _ = strconv.ParseInt(match[5], 10, 64)
+				if query.rollupInterval <= 0 {
+					return nil, fmt.Errorf("rollup interval must be positive in %q", expression)
+				}
+			}
+		}
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+// metricQueryPoint is an evaluated point at a unix timestamp in seconds
+type metricQueryPoint struct {
+	timestamp int64
+	value     float64
+}
+
+// evaluate filters rows to the query scope, combines series sharing a timestamp with the
+// space aggregator, and then applies the rollup, if any
+func (q *metricQuery) evaluate(rows []database.ListDatadogMetricsInRangeRow) []metricQueryPoint {
+	byTimestamp := make(map[int64][]float64)
+	var timestamps []int64
+	for _, row := range rows {
+		if !q.inScope(row.Tags) {
+			continue
+		}
+		if _, seen := byTimestamp[row.Timestamp]; !seen {
+			timestamps = append(timestamps, row.Timestamp)
+		}
+		byTimestamp[row.Timestamp] = append(byTimestamp[row.Timestamp], row.Value)
+	}
+
+	// Rows arrive ordered by timestamp, so timestamps and buckets stay ordered
+	points := make([]metricQueryPoint, 0, len(timestamps))
+	for _, ts := range timestamps {
+		points = append(points, metricQueryPoint{timestamp: ts, value: aggregateValues(q.aggregator, byTimestamp[ts])})
+	}
+	if q.rollupInterval == 0 {
+		return points
+	}
+
+	var rolled []metricQueryPoint
+	var bucket []float64
+	bucketStart := int64(0)
+	for i, point := range points {
+		start := point.timestamp - point.timestamp%q.rollupInterval
+		if i > 0 && start != bucketStart {
+			rolled = append(rolled, metricQueryPoint{timestamp: bucketStart, value: aggregateValues(q.rollupMethod, bucket)})
+			bucket = nil
+		}
+		bucketStart = start
+		bucket = append(bucket, point.value)
+	}
+	if len(bucket) > 0 {
+		rolled = append(rolled, metricQueryPoint{timestamp: bucketStart, value: aggregateValues(q.rollupMethod, bucket)})
+	}
+	return rolled
+}
+
+// inScope reports whether a point's stored tags include every tag in the query scope
+func (q *metricQuery) inScope(tags sql.NullString) bool {
+	if len(q.scopeTags) == 0 {
+		return true
+	}
+	var pointTags []string
+	if tags.Valid {
+		_ = json.Unmarshal([]byte(tags.String), &pointTags)
+	}
+	for _, want := range q.scopeTags {
+		found := false
+		for _, tag := range pointTags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// series builds the response entry for the evaluated points of a query
+func (q *metricQuery) series(index int64, points []metricQueryPoint) MetricsQuerySeries {
+	pointlist := make([][]float64, 0, len(points))
+	for _, point := range points {
+		pointlist = append(pointlist, []float64{float64(point.timestamp * 1000), point.value})
+	}
+
+	interval := q.rollupInterval
+	if interval == 0 {
+		interval = 1
+	}
+
+	return MetricsQuerySeries{
+		Metric:      q.metric,
+		DisplayName: q.metric,
+		Expression:  q.expression,
+		Scope:       q.scope,
+		Aggr:        q.aggregator,
+		Pointlist:   pointlist,
+		Start:       points[0].timestamp * 1000,
+		End:         points[len(points)-1].timestamp * 1000,
+		Interval:    interval,
+		Length:      int64(len(points)),
+		QueryIndex:  index,
+		TagSet:      []string{},
+	}
+}
+
+// aggregateValues combines values with avg, sum, min, max, or count
+func aggregateValues(method string, values []float64) float64 {
+	result := values[0]
+	switch method {
+	case "sum", "avg":
+		for _, v := range values[1:] {
+			result += v
+		}
+		if method == "avg" {
+			result /= float64(len(values))
+		}
+	case "min":
+		for _, v := range values[1:] {
+			result = math.Min(result, v)
+		}
+	case "max":
+		for _, v := range values[1:] {
+			result = math.Max(result, v)
+		}
+	case "count":
+		result = float64(len(values))
+	}
+	return result
+}
+
+// Metric metadata V1 handlers
+
+func (h *Handler) handleMetricMetadataV1(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	path = strings.TrimPrefix(path, "/datadog")
+	metricName := strings.TrimPrefix(path, "/api/v1/metrics/")
+
+	if metricName == "" || strings.Contains(metricName, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetMetricMetadata(w, r, metricName)
+	case http.MethodPut:
+		h.handleUpdateMetricMetadata(w, r, metricName)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) handleGetMetricMetadata(w http.ResponseWriter, r *http.Request, metricName string) {
+	log.Printf("[datadog] → Received get metric metadata request for: %s", metricName)
+
+	sessionID := session.FromContext(r.Context())
+
+	metadata, err := h.queries.GetDatadogMetricMetadata(context.Background(), database.GetDatadogMetricMetadataParams{
+		MetricName: metricName,
+		SessionID:  sessionID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[datadog] ✗ Failed to get metric metadata: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := MetricMetadata{}
+	if err == nil {
+		response = metricMetadataFromRow(&metadata)
+	} else {
+		// Metrics known only from submitted series have empty metadata
+		tags, err := h.queries.ListDatadogMetricTags(context.Background(), database.ListDatadogMetricTagsParams{
+			SessionID:  sessionID,
+			MetricName: metricName,
+		})
+		if err != nil || len(tags) == 0 {
+			log.Printf("[datadog] ✗ Metric not found: %s", metricName)
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[datadog] ✓ Returned metric metadata: %s", metricName)
+}
+
+func (h *Handler) handleUpdateMetricMetadata(w http.ResponseWriter, r *http.Request, metricName string) {
+	log.Printf("[datadog] → Received update metric metadata request for: %s", metricName)
+
+	var req MetricMetadata
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[datadog] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	if req.Type != nil && !validMetricTypes[*req.Type] {
+		log.Printf("[datadog] ✗ Invalid metric type: %s", *req.Type)
+		http.Error(w, "Invalid metric type: "+*req.Type, http.StatusBadRequest)
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+
+	params := database.UpsertDatadogMetricMetadataParams{
+		MetricName: metricName,
+		SessionID:  sessionID,
+		UpdatedAt:  session.Now(sessionID).Unix(),
+	}
+	if req.Type != nil {
+		params.Type = sql.NullString{String: *req.Type, Valid: true}
+	}
+	if req.Description != nil {
+		params.Description = sql.NullString{String: *req.Description, Valid: true}
+	}
+	if req.Unit != nil {
+		params.Unit = sql.NullString{String: *req.Unit, Valid: true}
+	}
+
+	if err := h.queries.UpsertDatadogMetricMetadata(context.Background(), params); err != nil {
+		log.Printf("[datadog] ✗ Failed to update metric metadata: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	metadata, err := h.queries.GetDatadogMetricMetadata(context.Background(), database.GetDatadogMetricMetadataParams{
+		MetricName: metricName,
+		SessionID:  sessionID,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to get metric metadata: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(metricMetadataFromRow(&metadata))
+	log.Printf("[datadog] ✓ Metric metadata updated: %s", metricName)
+}
+
+// Metric tags V2 handlers
+
+func (h *Handler) handleMetricTagsV2(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	path = strings.TrimPrefix(path, "/datadog")
+	path = strings.TrimPrefix(path, "/api/v2/metrics/")
+
+	metricName, ok := strings.CutSuffix(path, "/tags")
+	if !ok || metricName == "" || r.Method != http.MethodGet {
+		http.NotFound(w, r)
+		return
+	}
+
+	log.Printf("[datadog] → Received list tag configuration request for: %s", metricName)
+
+	sessionID := session.FromContext(r.Context())
+
+	rows, err := h.queries.ListDatadogMetricTags(context.Background(), database.ListDatadogMetricTagsParams{
+		SessionID:  sessionID,
+		MetricName: metricName,
+	})
+	if err != nil {
+		log.Printf("[datadog] ✗ Failed to list metric tags: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if len(rows) == 0 {
+		log.Printf("[datadog] ✗ Metric not found: %s", metricName)
+		http.NotFound(w, r)
+		return
+	}
+
+	// Collect the distinct tags seen across every submitted series
+	seen := make(map[string]bool)
+	tags := []string{}
+	for _, row := range rows {
+		if !row.Valid {
+			continue
+		}
+		var seriesTags []string
+		_ = json.Unmarshal([]byte(row.String), &seriesTags)
+		for _, tag := range seriesTags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	metricType := "gauge"
+	metadata, err := h.queries.GetDatadogMetricMetadata(context.Background(), database.GetDatadogMetricMetadataParams{
+		MetricName: metricName,
+		SessionID:  sessionID,
+	})
+	if err == nil && metadata.Type.Valid {
+		metricType = metadata.Type.String
+	}
+
+	response := MetricTagConfigurationResponse{
+		Data: MetricTagConfigurationData{
+			ID:   metricName,
+			Type: "manage_tags",
+			Attributes: MetricTagConfigurationAttributes{
+				MetricType: metricType,
+				Tags:       tags,
+			},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[datadog] ✓ Returned %d tags for metric: %s", len(tags), metricName)
+}
+
+// Authentication V1 handlers
+
+// handleValidateV1 reports whether the request carries an API key. Keys are not checked
+// against anything, but a request without one is rejected the way Datadog does.
+func (h *Handler) handleValidateV1(w http.ResponseWriter, r *http.Request) {
+	log.Println("[datadog] → Received validate API key request")
+
+	if r.Header.Get("DD-API-KEY") == "" {
+		log.Println("[datadog] ✗ Missing API key")
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(ValidateResponse{Valid: true})
+	log.Println("[datadog] ✓ API key validated")
+}
+
+// Users V2 handlers
+
+func (h *Handler) handleListUsersV2(w http.ResponseWriter, r *http.Request) {
+	log.Println("[datadog] → Received list users request")
+
+	// Optional case-insensitive filter on name, handle, and email
+	filter := strings.ToLower(r.URL.Query().Get("filter"))
+	users := make([]UserData, 0, len(seededUsers))
+	for i := range seededUsers {
+		attrs := seededUsers[i].Attributes
+		if filter != "" &&
+			!strings.Contains(strings.ToLower(attrs.Name), filter) &&
+			!strings.Contains(strings.ToLower(attrs.Handle), filter) &&
+			!strings.Contains(strings.ToLower(attrs.Email), filter) {
+			continue
+		}
+		users = append(users, seededUsers[i])
+	}
+	users = listcap.Truncate(w, users)
+
+	response := UsersResponse{
+		Data: users,
+		Meta: UsersMeta{
+			Page: UsersPageMeta{
+				TotalCount:         len(seededUsers),
+				TotalFilteredCount: len(users),
+			},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[datadog] ✓ Listed %d users", len(users))
+}
+
 // Helper functions
 
-func generateIncidentID() string {
+func metricMetadataFromRow(row *database.GetDatadogMetricMetadataRow) MetricMetadata {
+	var metadata MetricMetadata
+	if row.Type.Valid {
+		metadata.Type = &row.Type.String
+	}
+	if row.Description.Valid {
+		metadata.Description = &row.Description.String
+	}
+	if row.Unit.Valid {
+		metadata.Unit = &row.Unit.String
+	}
+	return metadata
+}
+
+func generateIncidentID(sessionID string) string {
 	b := make([]byte, 16)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return fmt.Sprintf("%s-%s-%s-%s-%s",
 		hex.EncodeToString(b[0:4]),
 		hex.EncodeToString(b[4:6]),
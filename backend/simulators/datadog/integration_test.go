@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
 	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
@@ -244,8 +246,169 @@ func TestDatadogIncidents(t *testing.T) {
 	})
 }
 
+func TestDatadogIncidentPublicIDs(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	handler := session.Middleware(simulatorDatadog.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	createIncident := func(t *testing.T, incidentsAPI *datadogV2.IncidentsApi, title string) datadogV2.IncidentResponseData {
+		t.Helper()
+		resp, r, err := incidentsAPI.CreateIncident(ctx, datadogV2.IncidentCreateRequest{
+			Data: datadogV2.IncidentCreateData{
+				Type:       datadogV2.INCIDENTTYPE_INCIDENTS,
+				Attributes: datadogV2.IncidentCreateAttributes{Title: title},
+			},
+		})
+		if err == nil {
+			defer r.Body.Close()
+		}
+		require.NoError(t, err, "CreateIncident should succeed")
+		return resp.Data
+	}
+
+	incidentsAPI := datadogV2.NewIncidentsApi(setupDatadogClient(t, server.URL, "datadog-test-session-public-ids"))
+
+	t.Run("SequentialPerSession", func(t *testing.T) {
+		var created []datadogV2.IncidentResponseData
+		for i, title := range []string{"Login failures", "Queue backlog", "Disk pressure"} {
+			incident := createIncident(t, incidentsAPI, title)
+			assert.Equal(t, int64(i+1), incident.Attributes.GetPublicId(), "public_id should count up from 1")
+			created = append(created, incident)
+		}
+
+		getResp, r, err := incidentsAPI.GetIncident(ctx, created[1].Id, *datadogV2.NewGetIncidentOptionalParameters())
+		if err == nil {
+			defer r.Body.Close()
+		}
+		require.NoError(t, err, "GetIncident should succeed")
+		assert.Equal(t, int64(2), getResp.Data.Attributes.GetPublicId(), "Get should return the stored public_id")
+		assert.Equal(t, created[1].Id, getResp.Data.Id, "The UUID should still identify the incident")
+
+		listResp, r, err := incidentsAPI.ListIncidents(ctx, *datadogV2.NewListIncidentsOptionalParameters())
+		if err == nil {
+			defer r.Body.Close()
+		}
+		require.NoError(t, err, "ListIncidents should succeed")
+		publicIDs := make(map[string]int64)
+		for _, incident := range listResp.GetData() {
+			publicIDs[incident.Id] = incident.Attributes.GetPublicId()
+		}
+		for i, incident := range created {
+			assert.Equal(t, int64(i+1), publicIDs[incident.Id], "List should return the stored public_id")
+		}
+	})
+
+	t.Run("IndependentSessions", func(t *testing.T) {
+		otherAPI := datadogV2.NewIncidentsApi(setupDatadogClient(t, server.URL, "datadog-test-session-public-ids-other"))
+		incident := createIncident(t, otherAPI, "Cache misses")
+		assert.Equal(t, int64(1), incident.Attributes.GetPublicId(), "Each session should number its incidents from 1")
+	})
+}
+
 // Monitors Tests (v1 API)
 
+func TestDatadogIncidentTodosAndAttachments(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "datadog-test-session-incident-todos"
+	handler := session.Middleware(simulatorDatadog.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	apiClient := setupDatadogClient(t, server.URL, sessionID)
+	apiClient.GetConfig().SetUnstableOperationEnabled("v2.CreateIncidentTodo", true)
+	apiClient.GetConfig().SetUnstableOperationEnabled("v2.ListIncidentTodos", true)
+	apiClient.GetConfig().SetUnstableOperationEnabled("v2.ListIncidentAttachments", true)
+	incidentsAPI := datadogV2.NewIncidentsApi(apiClient)
+
+	ctx := context.Background()
+
+	createResp, createR, err := incidentsAPI.CreateIncident(ctx, datadogV2.IncidentCreateRequest{
+		Data: datadogV2.IncidentCreateData{
+			Type:       datadogV2.INCIDENTTYPE_INCIDENTS,
+			Attributes: datadogV2.IncidentCreateAttributes{Title: "Checkout errors"},
+		},
+	})
+	if err == nil {
+		defer createR.Body.Close()
+	}
+	require.NoError(t, err, "CreateIncident should succeed")
+	incidentID := createResp.Data.Id
+
+	t.Run("AddAndListTodo", func(t *testing.T) {
+		handle := "@oncall"
+		dueDate := "2026-01-31T00:00:00Z"
+		attrs := datadogV2.IncidentTodoAttributes{
+			Content:   "Write the postmortem",
+			Assignees: []datadogV2.IncidentTodoAssignee{datadogV2.IncidentTodoAssigneeHandleAsIncidentTodoAssignee(&handle)},
+		}
+		attrs.SetDueDate(dueDate)
+		todoResp, r, err := incidentsAPI.CreateIncidentTodo(ctx, incidentID, datadogV2.IncidentTodoCreateRequest{
+			Data: datadogV2.IncidentTodoCreateData{
+				Type:       datadogV2.INCIDENTTODOTYPE_INCIDENT_TODOS,
+				Attributes: attrs,
+			},
+		})
+		if err == nil {
+			defer r.Body.Close()
+		}
+		require.NoError(t, err, "CreateIncidentTodo should succeed")
+		assert.Equal(t, http.StatusCreated, r.StatusCode, "Should return 201 Created")
+		assert.NotEmpty(t, todoResp.Data.Id, "Todo should have an ID")
+
+		listResp, r, err := incidentsAPI.ListIncidentTodos(ctx, incidentID)
+		if err == nil {
+			defer r.Body.Close()
+		}
+		require.NoError(t, err, "ListIncidentTodos should succeed")
+		require.Len(t, listResp.Data, 1, "Incident should have one todo")
+		todo := listResp.Data[0]
+		assert.Equal(t, todoResp.Data.Id, todo.Id, "Listed todo should match the created one")
+		assert.Equal(t, "Write the postmortem", todo.Attributes.Content, "Content should be echoed")
+		assert.Equal(t, incidentID, todo.Attributes.GetIncidentId(), "Todo should reference its incident")
+		assert.Equal(t, dueDate, todo.Attributes.GetDueDate(), "Due date should be echoed")
+		require.Len(t, todo.Attributes.Assignees, 1, "Assignees should be echoed")
+		require.NotNil(t, todo.Attributes.Assignees[0].IncidentTodoAssigneeHandle, "Assignee should be a handle")
+		assert.Equal(t, handle, *todo.Attributes.Assignees[0].IncidentTodoAssigneeHandle, "Assignee handle should match")
+	})
+
+	t.Run("AddAndListAttachment", func(t *testing.T) {
+		body := `{"data":{"type":"incident_attachments","attributes":{"attachment_type":"link","attachment":{"documentUrl":"https://runbooks.example.com/checkout","title":"Runbook"}}}}`
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			server.URL+"/datadog/api/v2/incidents/"+incidentID+"/relationships/attachments", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Session-ID", sessionID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Create attachment request should succeed")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Should return 201 Created")
+
+		listResp, r, err := incidentsAPI.ListIncidentAttachments(ctx, incidentID)
+		if err == nil {
+			defer r.Body.Close()
+		}
+		require.NoError(t, err, "ListIncidentAttachments should succeed")
+		require.Len(t, listResp.Data, 1, "Incident should have one attachment")
+		link := listResp.Data[0].Attributes.IncidentAttachmentLinkAttributes
+		require.NotNil(t, link, "Attachment should be a link")
+		assert.Equal(t, "https://runbooks.example.com/checkout", link.Attachment.DocumentUrl, "Document URL should be echoed")
+		assert.Equal(t, "Runbook", link.Attachment.Title, "Title should be echoed")
+	})
+
+	t.Run("UnknownIncident", func(t *testing.T) {
+		_, r, err := incidentsAPI.ListIncidentTodos(ctx, "00000000-0000-0000-0000-000000000000")
+		if r != nil {
+			defer r.Body.Close()
+		}
+		require.Error(t, err, "Listing todos of a missing incident should fail")
+		assert.Equal(t, http.StatusNotFound, r.StatusCode, "Should return 404")
+	})
+}
+
 func TestDatadogMonitors(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -435,6 +598,84 @@ func TestDatadogMonitors(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.StatusCode, "Should return 200 OK")
 		assert.GreaterOrEqual(t, len(resp), 3, "Should have at least 3 monitors")
 	})
+
+	t.Run("SearchMonitors", func(t *testing.T) {
+		// Create monitors with a distinctive name fragment, one matched by tag only
+		for i, name := range []string{"Checkout Latency Monitor", "Checkout Error Monitor", "Disk Monitor"} {
+			body := datadogV1.Monitor{
+				Name:  &name,
+				Type:  datadogV1.MONITORTYPE_METRIC_ALERT,
+				Query: "avg(last_5m):avg:system.disk.used{*} > 90",
+			}
+			if i == 2 {
+				body.Tags = []string{"service:checkout"}
+			}
+			_, createR, err := monitorsAPI.CreateMonitor(ctx, body)
+			require.NoError(t, err, "CreateMonitor should succeed")
+			if createR != nil && createR.Body != nil {
+				_ = createR.Body.Close()
+			}
+		}
+
+		// Search by name fragment with a page size smaller than the result set
+		params := datadogV1.NewSearchMonitorsOptionalParameters().WithQuery("checkout").WithPerPage(2)
+		resp, r, err := monitorsAPI.SearchMonitors(ctx, *params)
+		if err == nil {
+			defer r.Body.Close()
+		}
+
+		// Assertions
+		require.NoError(t, err, "SearchMonitors should not return error")
+		assert.Equal(t, http.StatusOK, r.StatusCode, "Should return 200 OK")
+		require.NotNil(t, resp.Metadata, "Should have metadata")
+		assert.Equal(t, int64(3), resp.Metadata.GetTotalCount(), "Should match name and tag fragments")
+		assert.Equal(t, int64(2), resp.Metadata.GetPageCount(), "Should have 2 pages")
+		assert.Len(t, resp.Monitors, 2, "First page should be full")
+
+		// Second page holds the remainder
+		params = datadogV1.NewSearchMonitorsOptionalParameters().WithQuery("checkout").WithPerPage(2).WithPage(1)
+		resp, r2, err := monitorsAPI.SearchMonitors(ctx, *params)
+		if err == nil {
+			defer r2.Body.Close()
+		}
+		require.NoError(t, err, "SearchMonitors should not return error")
+		assert.Len(t, resp.Monitors, 1, "Second page should hold the remaining monitor")
+	})
+
+	t.Run("ValidateMonitor", func(t *testing.T) {
+		listParams := datadogV1.NewListMonitorsOptionalParameters()
+		before, r, err := monitorsAPI.ListMonitors(ctx, *listParams)
+		require.NoError(t, err, "ListMonitors should succeed")
+		r.Body.Close()
+
+		name := "Disk Usage Monitor"
+		_, r, err = monitorsAPI.ValidateMonitor(ctx, datadogV1.Monitor{
+			Name:  &name,
+			Type:  datadogV1.MONITORTYPE_METRIC_ALERT,
+			Query: "avg(last_5m):avg:system.disk.in_use{*} > 0.9",
+		})
+		require.NoError(t, err, "A valid monitor should pass validation")
+		r.Body.Close()
+		assert.Equal(t, http.StatusOK, r.StatusCode, "Should return 200 OK")
+
+		_, r, err = monitorsAPI.ValidateMonitor(ctx, datadogV1.Monitor{
+			Name:  &name,
+			Type:  datadogV1.MonitorType("bogus alert"),
+			Query: "",
+		})
+		require.Error(t, err, "An invalid monitor should fail validation")
+		r.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, r.StatusCode, "Should return 400 Bad Request")
+		var apiErr datadog.GenericOpenAPIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Contains(t, string(apiErr.Body()), "type", "Errors should mention the unknown type")
+		assert.Contains(t, string(apiErr.Body()), "query", "Errors should mention the missing query")
+
+		after, r, err := monitorsAPI.ListMonitors(ctx, *listParams)
+		require.NoError(t, err, "ListMonitors should succeed")
+		r.Body.Close()
+		assert.Len(t, after, len(before), "Validation should not persist monitors")
+	})
 }
 
 // Events Tests (v1 API)
@@ -595,4 +836,231 @@ func TestDatadogMetrics(t *testing.T) {
 		require.NoError(t, err, "SubmitMetrics should not return error")
 		assert.Equal(t, http.StatusAccepted, r.StatusCode, "Should return 202 Accepted")
 	})
+
+	t.Run("ListTagConfiguration", func(t *testing.T) {
+		metricName := "custom.queue.depth"
+		value := 7.0
+
+		body := datadogV2.MetricPayload{
+			Series: []datadogV2.MetricSeries{
+				{
+					Metric: metricName,
+					Points: []datadogV2.MetricPoint{{Value: &value}},
+					Tags:   []string{"env:prod", "queue:emails"},
+				},
+				{
+					Metric: metricName,
+					Points: []datadogV2.MetricPoint{{Value: &value}},
+					Tags:   []string{"env:staging", "queue:emails"},
+				},
+			},
+		}
+		_, r, err := metricsAPI.SubmitMetrics(ctx, body, *datadogV2.NewSubmitMetricsOptionalParameters())
+		require.NoError(t, err, "SubmitMetrics should not return error")
+		_ = r.Body.Close()
+
+		resp, r, err := metricsAPI.ListTagConfigurationByName(ctx, metricName)
+		require.NoError(t, err, "ListTagConfigurationByName should not return error")
+		defer r.Body.Close()
+
+		data := resp.GetData()
+		assert.Equal(t, metricName, data.GetId(), "Tag configuration ID should be the metric name")
+		attributes := data.GetAttributes()
+		assert.Equal(t, []string{"env:prod", "env:staging", "queue:emails"}, attributes.GetTags(), "Should return distinct tags from submitted series")
+	})
+
+	t.Run("ListTagConfigurationUnknownMetric", func(t *testing.T) {
+		_, r, err := metricsAPI.ListTagConfigurationByName(ctx, "custom.never.submitted")
+		require.Error(t, err, "Unknown metric should return error")
+		defer r.Body.Close()
+		assert.Equal(t, http.StatusNotFound, r.StatusCode, "Should return 404 Not Found")
+	})
+
+	t.Run("MetricMetadata", func(t *testing.T) {
+		metadataAPI := datadogV1.NewMetricsApi(apiClient)
+		metricName := "custom.queue.depth"
+
+		update := datadogV1.MetricMetadata{
+			Type:        datadog.PtrString("gauge"),
+			Description: datadog.PtrString("Messages waiting in the queue"),
+			Unit:        datadog.PtrString("message"),
+		}
+		updated, r, err := metadataAPI.UpdateMetricMetadata(ctx, metricName, update)
+		require.NoError(t, err, "UpdateMetricMetadata should not return error")
+		_ = r.Body.Close()
+		assert.Equal(t, "gauge", updated.GetType(), "Type should be updated")
+
+		// A partial update keeps the other fields
+		_, r, err = metadataAPI.UpdateMetricMetadata(ctx, metricName, datadogV1.MetricMetadata{
+			Unit: datadog.PtrString("item"),
+		})
+		require.NoError(t, err, "UpdateMetricMetadata should not return error")
+		_ = r.Body.Close()
+
+		metadata, r, err := metadataAPI.GetMetricMetadata(ctx, metricName)
+		require.NoError(t, err, "GetMetricMetadata should not return error")
+		defer r.Body.Close()
+		assert.Equal(t, "gauge", metadata.GetType(), "Type should be preserved")
+		assert.Equal(t, "Messages waiting in the queue", metadata.GetDescription(), "Description should be preserved")
+		assert.Equal(t, "item", metadata.GetUnit(), "Unit should be updated")
+
+		_, r, err = metadataAPI.UpdateMetricMetadata(ctx, metricName, datadogV1.MetricMetadata{
+			Type: datadog.PtrString("histogram"),
+		})
+		require.Error(t, err, "Invalid metric type should be rejected")
+		defer r.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, r.StatusCode, "Should return 400 Bad Request")
+	})
+	t.Run("QueryWithRollup", func(t *testing.T) {
+		queryAPI := datadogV1.NewMetricsApi(apiClient)
+
+		// Two full minutes of per-second points, plus staging noise outside the query scope
+		base := (time.Now().Unix()/60 - 10) * 60
+		latency := make([]datadogV2.MetricPoint, 0, 120)
+		errorCount := make([]datadogV2.MetricPoint, 0, 12)
+		for i := int64(0); i < 120; i++ {
+			latency = append(latency, datadogV2.MetricPoint{
+				Timestamp: datadog.PtrInt64(base + i),
+				Value:     datadog.PtrFloat64(float64(i)),
+			})
+			if i%10 == 0 {
+				errorCount = append(errorCount, datadogV2.MetricPoint{
+					Timestamp: datadog.PtrInt64(base + i),
+					Value:     datadog.PtrFloat64(1),
+				})
+			}
+		}
+		body := datadogV2.MetricPayload{
+			Series: []datadogV2.MetricSeries{
+				{Metric: "custom.query.latency", Points: latency, Tags: []string{"env:prod"}},
+				{Metric: "custom.query.latency", Points: latency[:1], Tags: []string{"env:staging"}},
+				{Metric: "custom.query.errors", Points: errorCount, Tags: []string{"env:prod"}},
+			},
+		}
+		_, r, err := metricsAPI.SubmitMetrics(ctx, body, *datadogV2.NewSubmitMetricsOptionalParameters())
+		require.NoError(t, err, "SubmitMetrics should not return error")
+		_ = r.Body.Close()
+
+		query := "avg:custom.query.latency{env:prod}.rollup(avg, 60),sum:custom.query.errors{*}.rollup(sum, 60)"
+		resp, r, err := queryAPI.QueryMetrics(ctx, base, base+119, query)
+		require.NoError(t, err, "QueryMetrics should not return error")
+		defer r.Body.Close()
+
+		series := resp.GetSeries()
+		require.Len(t, series, 2, "Should return one series per query")
+
+		// Per-second values 0..119 average to 29.5 and 89.5 per minute
+		assert.Equal(t, "custom.query.latency", series[0].GetMetric())
+		assert.Equal(t, int64(60), series[0].GetInterval(), "Interval should match the rollup")
+		require.Len(t, series[0].Pointlist, 2, "Should bucket into two 60-second windows")
+		assert.InDelta(t, float64(base*1000), *series[0].Pointlist[0][0], 0, "First bucket should start at the window boundary")
+		assert.InDelta(t, 29.5, *series[0].Pointlist[0][1], 0.0001, "First bucket should average its points")
+		assert.InDelta(t, float64((base+60)*1000), *series[0].Pointlist[1][0], 0, "Second bucket should start a minute later")
+		assert.InDelta(t, 89.5, *series[0].Pointlist[1][1], 0.0001, "Second bucket should average its points")
+
+		// Six error points per minute summed
+		assert.Equal(t, int64(1), series[1].GetQueryIndex(), "Second series should come from the second query")
+		require.Len(t, series[1].Pointlist, 2, "Should bucket into two 60-second windows")
+		assert.InDelta(t, 6.0, *series[1].Pointlist[0][1], 0, "Rollup sum should add the points in a window")
+		assert.InDelta(t, 6.0, *series[1].Pointlist[1][1], 0, "Rollup sum should add the points in a window")
+	})
+
+	t.Run("SubmitV1Series", func(t *testing.T) {
+		v1API := datadogV1.NewMetricsApi(apiClient)
+
+		base := (time.Now().Unix()/60 - 20) * 60
+		body := datadogV1.MetricsPayload{
+			Series: []datadogV1.Series{
+				{
+					Metric: "custom.agent.load",
+					Host:   datadog.PtrString("web-1"),
+					Tags:   []string{"env:prod"},
+					Type:   datadog.PtrString("gauge"),
+					Points: [][]*float64{
+						{datadog.PtrFloat64(float64(base)), datadog.PtrFloat64(1.5)},
+						{datadog.PtrFloat64(float64(base + 30)), datadog.PtrFloat64(2.5)},
+						{datadog.PtrFloat64(float64(base + 60)), datadog.PtrFloat64(4)},
+					},
+				},
+			},
+		}
+		accepted, r, err := v1API.SubmitMetrics(ctx, body, *datadogV1.NewSubmitMetricsOptionalParameters())
+		require.NoError(t, err, "v1 SubmitMetrics should not return error")
+		_ = r.Body.Close()
+		assert.Equal(t, "ok", accepted.GetStatus(), "Should acknowledge the payload")
+
+		resp, r, err := v1API.QueryMetrics(ctx, base, base+119, "avg:custom.agent.load{host:web-1}.rollup(avg, 60)")
+		require.NoError(t, err, "QueryMetrics should not return error")
+		defer r.Body.Close()
+
+		series := resp.GetSeries()
+		require.Len(t, series, 1, "Should return the v1 series")
+		require.Len(t, series[0].Pointlist, 2, "Should bucket into two 60-second windows")
+		assert.InDelta(t, 2.0, *series[0].Pointlist[0][1], 0.0001, "First bucket should average the v1 points")
+		assert.InDelta(t, 4.0, *series[0].Pointlist[1][1], 0.0001, "Second bucket should hold the last v1 point")
+
+		_, r, err = v1API.SubmitMetrics(ctx, datadogV1.MetricsPayload{
+			Series: []datadogV1.Series{
+				{Metric: "custom.agent.load", Points: [][]*float64{{datadog.PtrFloat64(float64(base))}}},
+			},
+		}, *datadogV1.NewSubmitMetricsOptionalParameters())
+		require.Error(t, err, "Malformed points should be rejected")
+		defer r.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, r.StatusCode, "Should return 400 Bad Request")
+	})
+
+	t.Run("QueryInvalidExpression", func(t *testing.T) {
+		queryAPI := datadogV1.NewMetricsApi(apiClient)
+		now := time.Now().Unix()
+		_, r, err := queryAPI.QueryMetrics(ctx, now-60, now, "avg:custom.query.latency{*}.rollup(median, 60)")
+		require.Error(t, err, "Unsupported rollup should be rejected")
+		defer r.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, r.StatusCode, "Should return 400 Bad Request")
+	})
+}
+
+func TestDatadogClientBootstrap(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "datadog-test-session-bootstrap"
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorDatadog.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	apiClient := setupDatadogClient(t, server.URL, sessionID)
+	ctx := context.WithValue(context.Background(), datadog.ContextAPIKeys, map[string]datadog.APIKey{
+		"apiKeyAuth": {Key: "test-api-key"},
+		"appKeyAuth": {Key: "test-app-key"},
+	})
+
+	t.Run("ValidateWithKey", func(t *testing.T) {
+		resp, r, err := datadogV1.NewAuthenticationApi(apiClient).Validate(ctx)
+		require.NoError(t, err, "Validate should not return error")
+		defer r.Body.Close()
+		assert.True(t, resp.GetValid(), "API key should be valid")
+	})
+
+	t.Run("ValidateWithoutKey", func(t *testing.T) {
+		_, r, err := datadogV1.NewAuthenticationApi(apiClient).Validate(context.Background())
+		require.Error(t, err, "Validate without a key should fail")
+		defer r.Body.Close()
+		assert.Equal(t, http.StatusForbidden, r.StatusCode, "Should return 403 Forbidden")
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		resp, r, err := datadogV2.NewUsersApi(apiClient).ListUsers(ctx, *datadogV2.NewListUsersOptionalParameters())
+		require.NoError(t, err, "ListUsers should not return error")
+		defer r.Body.Close()
+		require.NotEmpty(t, resp.Data, "Should return the seeded users")
+		assert.Equal(t, "admin@example.com", resp.Data[0].Attributes.GetEmail(), "First user should be the admin")
+
+		filtered, r, err := datadogV2.NewUsersApi(apiClient).ListUsers(ctx, *datadogV2.NewListUsersOptionalParameters().WithFilter("on-call"))
+		require.NoError(t, err, "Filtered ListUsers should not return error")
+		defer r.Body.Close()
+		assert.Len(t, filtered.Data, 1, "Filter should match one user")
+	})
 }
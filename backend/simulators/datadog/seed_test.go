@@ -133,7 +133,7 @@ func seedDatadogTestData(t *testing.T, ctx context.Context, queries *database.Qu
 		if incident.CustomerImpacted {
 			customerImpacted = 1
 		}
-		err := queries.CreateDatadogIncident(ctx, database.CreateDatadogIncidentParams{
+		_, err := queries.CreateDatadogIncident(ctx, database.CreateDatadogIncidentParams{
 			ID:               incident.ID,
 			Title:            incident.Title,
 			CustomerImpacted: customerImpacted,
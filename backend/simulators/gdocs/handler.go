@@ -2,7 +2,6 @@ package gdocs
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"log"
@@ -101,6 +100,15 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	count, err := queries.CountGdocsSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"documents": count}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[gdocs] → %s %s", r.Method, r.URL.Path)
@@ -150,13 +158,13 @@ func (h *Handler) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate document ID and revision ID
-	documentID := generateDocumentID()
-	revisionID := generateRevisionID()
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Generate document ID and revision ID
+	documentID := generateDocumentID(sessionID)
+	revisionID := generateRevisionID(sessionID)
+
 	// Create document in database
 	err := h.queries.CreateGdocsDocument(context.Background(), database.CreateGdocsDocumentParams{
 		ID:         documentID,
@@ -228,13 +236,15 @@ func (h *Handler) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetDocument(w http.ResponseWriter, r *http.Request, documentID string) {
 	log.Printf("[gdocs] → Received get document request for ID: %s", documentID)
 
-	// Extract session ID from context
-	sessionID := session.FromContext(r.Context())
-
-	// Query document from database
-	dbDoc, err := h.queries.GetGdocsDocumentByID(context.Background(), database.GetGdocsDocumentByIDParams{
-		DocumentID: documentID,
-		SessionID:  sessionID,
+	// Query document from database, falling through to the parent session. Its content
+	// lives in whichever session holds the document.
+	var sessionID string
+	dbDoc, err := session.ReadThrough(r.Context(), "gdocs_documents", documentID, func(candidate string) (database.GdocsDocument, error) {
+		sessionID = candidate
+		return h.queries.GetGdocsDocumentByID(context.Background(), database.GetGdocsDocumentByIDParams{
+			DocumentID: documentID,
+			SessionID:  candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[gdocs] ✗ Failed to get document: %v", err)
@@ -483,15 +493,15 @@ func (h *Handler) processReplaceAllText(content []StructuralElement, req *Replac
 
 // Helper functions
 
-func generateDocumentID() string {
+func generateDocumentID(sessionID string) string {
 	b := make([]byte, 16)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
 
-func generateRevisionID() string {
+func generateRevisionID(sessionID string) string {
 	b := make([]byte, 8)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
 
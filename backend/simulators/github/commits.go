@@ -0,0 +1,414 @@
+package github
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v80/github"
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// initialCommitMessage is the message of the commit a repository's main branch starts at
+const initialCommitMessage = "Initial commit"
+
+// treeFile is a blob listed in a tree: a file on a branch or an entry in a commit snapshot
+type treeFile struct {
+	path string
+	sha  string
+	size int
+}
+
+// Commit handlers
+
+func (h *Handler) handleListCommits(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
+	ctx := context.Background()
+
+	ref := r.URL.Query().Get("sha")
+	if ref == "" {
+		ref = "main"
+		if dbRepo, err := h.queries.GetGithubRepository(ctx, database.GetGithubRepositoryParams{
+			Owner:     owner,
+			Name:      repo,
+			SessionID: sessionID,
+		}); err == nil {
+			ref = dbRepo.DefaultBranch
+		}
+	}
+
+	head, err := h.resolveCommit(ctx, owner, repo, ref, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "No commit found for SHA: "+ref)
+		return
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to resolve %s: %v", ref, err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Walk first parents from the head, newest first, keeping the requested page
+	path := strings.Trim(r.URL.Query().Get("path"), "/")
+	perPage, page := parsePage(r)
+	skip := (page - 1) * perPage
+	commits := []*github.RepositoryCommit{}
+	for commit := &head; len(commits) < perPage; {
+		matches := true
+		if path != "" {
+			files, err := h.changedFiles(ctx, commit)
+			if err != nil {
+				log.Printf("[github] ✗ Failed to diff commit %s: %v", commit.Sha, err)
+				apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+			matches = touchesPath(files, path)
+		}
+		switch {
+		case !matches:
+		case skip > 0:
+			skip--
+		default:
+			commits = append(commits, toGithubRepositoryCommit(commit))
+		}
+
+		if commit.ParentSha == "" {
+			break
+		}
+		parent, err := h.queries.GetGithubCommit(ctx, database.GetGithubCommitParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Sha:       commit.ParentSha,
+			SessionID: sessionID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			log.Printf("[github] ✗ Failed to get commit %s: %v", commit.ParentSha, err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		commit = &parent
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(commits)
+	log.Printf("[github] ✓ Listed %d commits from %s in %s/%s", len(commits), ref, owner, repo)
+}
+
+func (h *Handler) handleGetCommit(w http.ResponseWriter, owner, repo, ref, sessionID string) {
+	ctx := context.Background()
+
+	commit, err := h.resolveCommit(ctx, owner, repo, ref, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "No commit found for SHA: "+ref)
+		return
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to resolve %s: %v", ref, err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	files, err := h.changedFiles(ctx, &commit)
+	if err != nil {
+		log.Printf("[github] ✗ Failed to diff commit %s: %v", commit.Sha, err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := toGithubRepositoryCommit(&commit)
+	response.Files = files
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[github] ✓ Returned commit %s from %s/%s", commit.Sha, owner, repo)
+}
+
+// resolveCommit finds a recorded commit by SHA, or the commit at the head of a branch by name
+func (h *Handler) resolveCommit(ctx context.Context, owner, repo, ref, sessionID string) (database.GithubCommit, error) {
+	commit, err := h.queries.GetGithubCommit(ctx, database.GetGithubCommitParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Sha:       ref,
+		SessionID: sessionID,
+	})
+	if !errors.Is(err, sql.ErrNoRows) {
+		return commit, err
+	}
+
+	branch, err := h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Name:      strings.TrimPrefix(ref, "heads/"),
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return commit, err
+	}
+	return h.queries.GetGithubCommit(ctx, database.GetGithubCommitParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Sha:       branch.Sha,
+		SessionID: sessionID,
+	})
+}
+
+// changedFiles diffs a commit's snapshot against its parent's. Blob SHAs are compared, so a
+// file rewritten with the same content is unchanged.
+func (h *Handler) changedFiles(ctx context.Context, commit *database.GithubCommit) ([]*github.CommitFile, error) {
+	current, err := h.queries.ListGithubTreeEntries(ctx, database.ListGithubTreeEntriesParams{
+		RepoOwner: commit.RepoOwner,
+		RepoName:  commit.RepoName,
+		CommitSha: commit.Sha,
+		SessionID: commit.SessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	previous, err := h.queries.ListGithubTreeEntries(ctx, database.ListGithubTreeEntriesParams{
+		RepoOwner: commit.RepoOwner,
+		RepoName:  commit.RepoName,
+		CommitSha: commit.ParentSha,
+		SessionID: commit.SessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	before := make(map[string]string, len(previous))
+	for i := range previous {
+		before[previous[i].Path] = previous[i].Sha
+	}
+
+	files := []*github.CommitFile{}
+	for i := range current {
+		entry := &current[i]
+		sha, existed := before[entry.Path]
+		delete(before, entry.Path)
+		switch {
+		case !existed:
+			files = append(files, toGithubCommitFile(entry.Path, entry.Sha, "added"))
+		case sha != entry.Sha:
+			files = append(files, toGithubCommitFile(entry.Path, entry.Sha, "modified"))
+		}
+	}
+	for path, sha := range before {
+		files = append(files, toGithubCommitFile(path, sha, "removed"))
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].GetFilename() < files[j].GetFilename()
+	})
+	return files, nil
+}
+
+// touchesPath reports whether any changed file is path or lies under it
+func touchesPath(files []*github.CommitFile, path string) bool {
+	for _, file := range files {
+		if file.GetFilename() == path || strings.HasPrefix(file.GetFilename(), path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// commitBranch records a commit of the branch's current files on top of parentSHA and moves
+// the branch to it, creating the branch when parentSHA is empty. Run it in the transaction
+// that wrote the files.
+func commitBranch(ctx context.Context, q *database.Queries, owner, repo, branch, parentSHA, message string,
+	author *github.CommitAuthor, sessionID string) (database.GithubCommit, error) {
+	files, err := q.ListGithubFilesByBranch(ctx, database.ListGithubFilesByBranchParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Branch:    branch,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return database.GithubCommit{}, err
+	}
+
+	snapshot := make([]treeFile, 0, len(files))
+	for i := range files {
+		snapshot = append(snapshot, treeFile{path: files[i].Path, sha: files[i].Sha, size: len(files[i].Content)})
+	}
+
+	commit := database.GithubCommit{
+		RepoOwner:  owner,
+		RepoName:   repo,
+		TreeSha:    treeSHA(snapshot),
+		ParentSha:  parentSHA,
+		Message:    message,
+		AuthorName: authenticatedUserLogin,
+		SessionID:  sessionID,
+		CreatedAt:  session.Now(sessionID).Unix(),
+	}
+	if author != nil {
+		commit.AuthorName = author.GetName()
+		commit.AuthorEmail = author.GetEmail()
+	}
+
+	// The SHA depends only on the commit's content and its place in the repository's history,
+	// so replaying a session's writes reproduces it
+	seq, err := q.CountGithubCommits(ctx, database.CountGithubCommitsParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return commit, err
+	}
+	commit.Sha = generateSHA(fmt.Sprintf("commit:%s:%s:%s:%s:%s:%d",
+		parentSHA, commit.TreeSha, message, commit.AuthorName, commit.AuthorEmail, seq))
+
+	err = q.CreateGithubCommit(ctx, database.CreateGithubCommitParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		Sha:         commit.Sha,
+		TreeSha:     commit.TreeSha,
+		ParentSha:   commit.ParentSha,
+		Message:     commit.Message,
+		AuthorName:  commit.AuthorName,
+		AuthorEmail: commit.AuthorEmail,
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		return commit, err
+	}
+	for _, file := range snapshot {
+		err := q.CreateGithubTreeEntry(ctx, database.CreateGithubTreeEntryParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			CommitSha: commit.Sha,
+			Path:      file.path,
+			Sha:       file.sha,
+			Size:      int64(file.size),
+			SessionID: sessionID,
+		})
+		if err != nil {
+			return commit, err
+		}
+	}
+
+	if parentSHA == "" {
+		err = q.CreateGithubBranch(ctx, database.CreateGithubBranchParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Name:      branch,
+			Sha:       commit.Sha,
+			SessionID: sessionID,
+		})
+	} else {
+		err = q.UpdateGithubBranchSHA(ctx, database.UpdateGithubBranchSHAParams{
+			Sha:       commit.Sha,
+			RepoOwner: owner,
+			RepoName:  repo,
+			Name:      branch,
+			SessionID: sessionID,
+		})
+	}
+	return commit, err
+}
+
+// mergeBranch writes the head branch's files over the base branch and records the merge as a
+// commit on the base branch. Run it in the transaction that marks the pull request merged.
+func mergeBranch(ctx context.Context, q *database.Queries, owner, repo, base, head, message, sessionID string) (database.GithubCommit, error) {
+	files, err := q.ListGithubFilesByBranch(ctx, database.ListGithubFilesByBranchParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Branch:    head,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return database.GithubCommit{}, err
+	}
+	for i := range files {
+		err := q.CreateOrUpdateGithubFile(ctx, database.CreateOrUpdateGithubFileParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Path:      files[i].Path,
+			Content:   files[i].Content,
+			Sha:       files[i].Sha,
+			Branch:    base,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			return database.GithubCommit{}, err
+		}
+	}
+
+	parentSHA := branchHead(ctx, q, owner, repo, base, sessionID)
+	return commitBranch(ctx, q, owner, repo, base, parentSHA, message, nil, sessionID)
+}
+
+// branchHead returns the SHA a branch points at, or "" when the branch doesn't exist
+func branchHead(ctx context.Context, q *database.Queries, owner, repo, branch, sessionID string) string {
+	dbBranch, err := q.GetGithubBranch(ctx, database.GetGithubBranchParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Name:      branch,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return ""
+	}
+	return dbBranch.Sha
+}
+
+// treeSHA derives a tree's SHA from its blobs, so identical snapshots share a tree
+func treeSHA(files []treeFile) string {
+	var b strings.Builder
+	b.WriteString("tree:")
+	for _, file := range files {
+		b.WriteString(file.path + " " + file.sha + "\n")
+	}
+	return generateSHA(b.String())
+}
+
+func toGithubRepositoryCommit(commit *database.GithubCommit) *github.RepositoryCommit {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/", commit.RepoOwner, commit.RepoName)
+	author := &github.CommitAuthor{
+		Name:  github.Ptr(commit.AuthorName),
+		Email: github.Ptr(commit.AuthorEmail),
+		Date:  &github.Timestamp{Time: time.Unix(commit.CreatedAt, 0)},
+	}
+
+	parents := []*github.Commit{}
+	if commit.ParentSha != "" {
+		parents = append(parents, &github.Commit{
+			SHA: github.Ptr(commit.ParentSha),
+			URL: github.Ptr(apiURL + commit.ParentSha),
+		})
+	}
+
+	return &github.RepositoryCommit{
+		SHA:     github.Ptr(commit.Sha),
+		URL:     github.Ptr(apiURL + commit.Sha),
+		HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/%s/%s/commit/%s", commit.RepoOwner, commit.RepoName, commit.Sha)),
+		Commit: &github.Commit{
+			SHA:       github.Ptr(commit.Sha),
+			Message:   github.Ptr(commit.Message),
+			Author:    author,
+			Committer: author,
+			Tree:      &github.Tree{SHA: github.Ptr(commit.TreeSha)},
+		},
+		Parents: parents,
+	}
+}
+
+func toGithubCommitFile(path, sha, status string) *github.CommitFile {
+	return &github.CommitFile{
+		Filename: github.Ptr(path),
+		SHA:      github.Ptr(sha),
+		Status:   github.Ptr(status),
+	}
+}
@@ -4,19 +4,62 @@ import (
 	"context"
 	"crypto/sha1" //nolint:gosec // Used for generating fake SHAs in simulator, not for security
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/go-github/v80/github"
+	"github.com/recreate-run/nova-simulators/internal/apierror"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
+// authenticatedUserLogin is the login attributed to actions taken through the simulator
+const authenticatedUserLogin = "simulator-user"
+
+// validReactionContents lists the reaction types GitHub accepts
+var validReactionContents = map[string]bool{
+	"+1":       true,
+	"-1":       true,
+	"laugh":    true,
+	"confused": true,
+	"heart":    true,
+	"hooray":   true,
+	"rocket":   true,
+	"eyes":     true,
+}
+
+// validCheckRunStatuses lists the check run statuses GitHub accepts
+var validCheckRunStatuses = map[string]bool{
+	"queued":      true,
+	"in_progress": true,
+	"completed":   true,
+	"waiting":     true,
+	"requested":   true,
+	"pending":     true,
+}
+
+// validCheckRunConclusions lists the conclusions a completed check run can report
+var validCheckRunConclusions = map[string]bool{
+	"success":         true,
+	"failure":         true,
+	"neutral":         true,
+	"cancelled":       true,
+	"skipped":         true,
+	"timed_out":       true,
+	"action_required": true,
+	"stale":           true,
+}
+
 // Handler implements the GitHub simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -29,6 +72,23 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountGithubSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"repositories":  counts.Repositories,
+		"issues":        counts.Issues,
+		"pulls":         counts.Pulls,
+		"comments":      counts.Comments,
+		"branches":      counts.Branches,
+		"files":         counts.Files,
+		"workflow_runs": counts.WorkflowRuns,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[github] → %s %s", r.Method, r.URL.Path)
@@ -40,8 +100,19 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// /api/v3/repos/{owner}/{repo}/pulls
 	// /api/v3/repos/{owner}/{repo}/contents/{path}
 	// /api/v3/repos/{owner}/{repo}/git/refs
+	// /api/v3/repos/{owner}/{repo}/git/trees/{sha}
+	// /api/v3/repos/{owner}/{repo}/git/blobs/{sha}
+	// /api/v3/repos/{owner}/{repo}/branches/{branch}/protection
 	// /api/v3/repos/{owner}/{repo}/actions/workflows
 	// /api/v3/repos/{owner}/{repo}/actions/runs
+	// /api/v3/repos/{owner}/{repo}/check-runs/{check_run_id}
+	// /api/v3/repos/{owner}/{repo}/commits/{ref}/check-runs
+	// /api/v3/repos/{owner}/{repo}/hooks[/{hook_id}]
+	// /api/v3/gists
+	// /api/v3/gists/{gist_id}
+	// /api/v3/user
+	// /api/v3/users/{login}
+	// /api/v3/orgs/{org}
 
 	// Strip /api/v3 prefix if present
 	path := strings.TrimPrefix(r.URL.Path, "/api/v3")
@@ -69,33 +140,60 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			h.handleContents(w, r, owner, repo, parts[4:])
 		case "git":
 			h.handleGit(w, r, owner, repo, parts[4:])
+		case "branches":
+			h.handleBranches(w, r, owner, repo, parts[4:])
 		case "actions":
 			h.handleActions(w, r, owner, repo, parts[4:])
+		case "check-runs":
+			h.handleCheckRuns(w, r, owner, repo, parts[4:])
+		case "commits":
+			h.handleCommits(w, r, owner, repo, parts[4:])
+		case "hooks":
+			h.handleHooks(w, r, owner, repo, parts[4:])
 		default:
-			http.NotFound(w, r)
+			apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 		}
 		return
 	}
 
-	http.NotFound(w, r)
+	if parts[0] == "gists" {
+		h.handleGists(w, r, parts[1:])
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && parts[0] == "user":
+		h.handleAuthenticatedUser(w, r)
+		return
+	case len(parts) == 2 && parts[0] == "users":
+		h.handleGetUser(w, r, parts[1])
+		return
+	case len(parts) == 2 && parts[0] == "orgs":
+		h.handleGetOrganization(w, r, parts[1])
+		return
+	}
+
+	apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 }
 
 // Repository handlers
 
 func (h *Handler) handleGetRepository(w http.ResponseWriter, r *http.Request, owner, repo string) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	sessionID := session.FromContext(r.Context())
 	ctx := context.Background()
 
-	// Try to get repository from database
-	dbRepo, err := h.queries.GetGithubRepository(ctx, database.GetGithubRepositoryParams{
-		Owner:     owner,
-		Name:      repo,
-		SessionID: sessionID,
+	// Try to get repository from database, falling through to the parent session
+	dbRepo, err := session.ReadThrough(r.Context(), "github_repositories", owner+"/"+repo, func(candidate string) (database.GetGithubRepositoryRow, error) {
+		return h.queries.GetGithubRepository(ctx, database.GetGithubRepositoryParams{
+			Owner:     owner,
+			Name:      repo,
+			SessionID: candidate,
+		})
 	})
 
 	if err != nil {
@@ -109,19 +207,12 @@ func (h *Handler) handleGetRepository(w http.ResponseWriter, r *http.Request, ow
 		})
 		if err != nil {
 			log.Printf("[github] ✗ Failed to create repository: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 
-		// Create main branch with initial commit
-		initialSHA := generateSHA("initial commit")
-		err = h.queries.CreateGithubBranch(ctx, database.CreateGithubBranchParams{
-			RepoOwner: owner,
-			RepoName:  repo,
-			Name:      "main",
-			Sha:       initialSHA,
-			SessionID: sessionID,
-		})
+		// Create main branch with an empty initial commit
+		_, err = commitBranch(ctx, h.queries, owner, repo, "main", "", initialCommitMessage, nil, sessionID)
 		if err != nil {
 			log.Printf("[github] ✗ Failed to create main branch: %v", err)
 		}
@@ -162,15 +253,30 @@ func (h *Handler) handleIssues(w http.ResponseWriter, r *http.Request, owner, re
 		case http.MethodPost:
 			h.handleCreateIssue(w, r, owner, repo, sessionID)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	// Handle issue comment reactions: /repos/{owner}/{repo}/issues/comments/{id}/reactions
+	if parts[0] == "comments" {
+		if len(parts) == 3 && parts[2] == "reactions" {
+			commentID, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid comment ID")
+				return
+			}
+			h.handleReactions(w, r, owner, repo, "issue_comment", commentID, sessionID)
+			return
 		}
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 		return
 	}
 
 	// Handle specific issue
 	issueNum, err := strconv.Atoi(parts[0])
 	if err != nil {
-		http.Error(w, "Invalid issue number", http.StatusBadRequest)
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid issue number")
 		return
 	}
 
@@ -182,20 +288,49 @@ func (h *Handler) handleIssues(w http.ResponseWriter, r *http.Request, owner, re
 		case http.MethodPatch:
 			h.handleUpdateIssue(w, r, owner, repo, issueNum, sessionID)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
 		}
 		return
 	}
 
 	if len(parts) == 2 && parts[1] == "comments" {
-		// POST /repos/{owner}/{repo}/issues/{number}/comments
-		if r.Method == http.MethodPost {
+		// GET or POST /repos/{owner}/{repo}/issues/{number}/comments
+		switch r.Method {
+		case http.MethodGet:
+			h.handleListIssueComments(w, r, owner, repo, issueNum, sessionID)
+			return
+		case http.MethodPost:
 			h.handleCreateIssueComment(w, r, owner, repo, issueNum, sessionID)
 			return
 		}
 	}
 
-	http.NotFound(w, r)
+	if len(parts) == 2 && parts[1] == "reactions" {
+		// GET or POST /repos/{owner}/{repo}/issues/{number}/reactions
+		h.handleReactions(w, r, owner, repo, "issue", int64(issueNum), sessionID)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "assignees" {
+		// POST or DELETE /repos/{owner}/{repo}/issues/{number}/assignees
+		h.handleIssueAssignees(w, r, owner, repo, int64(issueNum), sessionID)
+		return
+	}
+
+	if len(parts) >= 2 && parts[1] == "labels" {
+		// /repos/{owner}/{repo}/issues/{number}/labels[/{name}]
+		h.handleIssueLabels(w, r, owner, repo, int64(issueNum), strings.Join(parts[2:], "/"), sessionID)
+		return
+	}
+
+	apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+}
+
+// validStateReasons are the values of an issue's state_reason: why it was closed, or that it was reopened
+var validStateReasons = map[string]bool{
+	"completed":   true,
+	"not_planned": true,
+	"reopened":    true,
 }
 
 func (h *Handler) handleListIssues(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
@@ -204,22 +339,97 @@ func (h *Handler) handleListIssues(w http.ResponseWriter, r *http.Request, owner
 	if state == "" {
 		state = "open"
 	}
+	since, ok := parseSince(r)
+	if !ok {
+		writeValidationFailed(w, "Issue", "since", "invalid")
+		return
+	}
 
-	dbIssues, err := h.queries.ListGithubIssues(ctx, database.ListGithubIssuesParams{
-		RepoOwner:   owner,
-		RepoName:    repo,
-		SessionID:   sessionID,
-		StateFilter: state,
-	})
+	stateReason := r.URL.Query().Get("state_reason")
+	if stateReason != "" && !validStateReasons[stateReason] {
+		writeValidationFailed(w, "Issue", "state_reason", "invalid")
+		return
+	}
+
+	assigneeFilter := r.URL.Query().Get("assignee")
+
+	var labelFilter []string
+	if labels := r.URL.Query().Get("labels"); labels != "" {
+		for _, name := range strings.Split(labels, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				labelFilter = append(labelFilter, name)
+			}
+		}
+	}
 
+	// Issues inherited from the parent session carry the parent's labels
+	issues, err := session.ListThrough(r.Context(), repoKind("github_issues", owner, repo), func(candidate string) ([]*github.Issue, error) {
+		return h.listIssues(ctx, owner, repo, candidate, &issueFilter{
+			State:       state,
+			StateReason: stateReason,
+			Since:       since,
+			Labels:      labelFilter,
+			Assignee:    assigneeFilter,
+		})
+	}, func(candidate string) ([]*github.Issue, error) {
+		return h.listIssues(ctx, owner, repo, candidate, &issueFilter{})
+	}, func(issue *github.Issue) string { return strconv.Itoa(issue.GetNumber()) })
 	if err != nil {
 		log.Printf("[github] ✗ Failed to list issues: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].GetCreatedAt().After(issues[j].GetCreatedAt().Time) })
+
+	issues = paginate(w, r, issues)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(issues)
+	log.Printf("[github] ✓ Listed %d issues for %s/%s", len(issues), owner, repo)
+}
+
+// issueFilter holds the query parameters of an issue list
+type issueFilter struct {
+	State       string
+	StateReason string
+	Since       int64
+	Labels      []string
+	Assignee    string
+}
+
+// listIssues returns a session's issues in a repository that match the filter, newest first
+func (h *Handler) listIssues(ctx context.Context, owner, repo, sessionID string, filter *issueFilter) ([]*github.Issue, error) {
+	dbIssues, err := h.queries.ListGithubIssues(ctx, database.ListGithubIssuesParams{
+		RepoOwner:         owner,
+		RepoName:          repo,
+		SessionID:         sessionID,
+		StateFilter:       filter.State,
+		StateReasonFilter: filter.StateReason,
+		Since:             filter.Since,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	dbLabels, err := h.queries.ListGithubRepoIssueLabels(ctx, database.ListGithubRepoIssueLabelsParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	labelsByIssue := make(map[int64][]string)
+	for _, dbLabel := range dbLabels {
+		labelsByIssue[dbLabel.IssueNumber] = append(labelsByIssue[dbLabel.IssueNumber], dbLabel.Name)
+	}
 
 	issues := make([]*github.Issue, 0, len(dbIssues))
 	for _, dbIssue := range dbIssues {
+		if !hasAllLabels(labelsByIssue[dbIssue.Number], filter.Labels) || !matchesAssignee(dbIssue.Assignees, filter.Assignee) {
+			continue
+		}
 		issue := &github.Issue{
 			ID:        github.Ptr(dbIssue.ID),
 			Number:    github.Ptr(int(dbIssue.Number)),
@@ -231,12 +441,17 @@ func (h *Handler) handleListIssues(w http.ResponseWriter, r *http.Request, owner
 		if dbIssue.Body.Valid {
 			issue.Body = github.Ptr(dbIssue.Body.String)
 		}
+		if dbIssue.StateReason.Valid {
+			issue.StateReason = github.Ptr(dbIssue.StateReason.String)
+		}
+		if dbIssue.PullRequest == 1 {
+			issue.PullRequestLinks = pullRequestLinks(owner, repo, dbIssue.Number)
+		}
+		issue.Labels = toGithubLabels(labelsByIssue[dbIssue.Number])
+		issue.Assignee, issue.Assignees = toGithubAssignees(dbIssue.Assignees)
 		issues = append(issues, issue)
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(issues)
-	log.Printf("[github] ✓ Listed %d issues for %s/%s", len(issues), owner, repo)
+	return issues, nil
 }
 
 func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
@@ -244,19 +459,7 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request, owne
 
 	var req github.IssueRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	// Get next issue number
-	nextNum, err := h.queries.GetNextIssueNumber(ctx, database.GetNextIssueNumberParams{
-		RepoOwner: owner,
-		RepoName:  repo,
-		SessionID: sessionID,
-	})
-	if err != nil {
-		log.Printf("[github] ✗ Failed to get next issue number: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -265,19 +468,41 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request, owne
 		body = sql.NullString{String: *req.Body, Valid: true}
 	}
 
-	dbIssue, err := h.queries.CreateGithubIssue(ctx, database.CreateGithubIssueParams{
-		RepoOwner: owner,
-		RepoName:  repo,
-		Number:    nextNum,
-		Title:     *req.Title,
-		Body:      body,
-		State:     "open",
-		SessionID: sessionID,
+	// The deprecated single assignee is accepted alongside the list
+	var assignees []string
+	if req.Assignee != nil {
+		assignees = append(assignees, *req.Assignee)
+	}
+	if req.Assignees != nil {
+		assignees = append(assignees, *req.Assignees...)
+	}
+
+	// The number is allocated in the insert itself so concurrent creates never collide
+	dbIssue, err := h.queries.CreateNextGithubIssue(ctx, database.CreateNextGithubIssueParams{
+		RepoOwner:       owner,
+		RepoName:        repo,
+		Title:           *req.Title,
+		Body:            body,
+		State:           "open",
+		Assignees:       encodeAssignees(assignees),
+		SessionID:       sessionID,
+		ParentSessionID: session.Parent(sessionID),
 	})
 
 	if err != nil {
 		log.Printf("[github] ✗ Failed to create issue: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	var labelNames []string
+	if req.Labels != nil {
+		labelNames = *req.Labels
+	}
+	labels, err := h.setIssueLabels(ctx, owner, repo, dbIssue.Number, labelNames, sessionID)
+	if err != nil {
+		log.Printf("[github] ✗ Failed to label issue: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -292,25 +517,48 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request, owne
 	if dbIssue.Body.Valid {
 		issue.Body = github.Ptr(dbIssue.Body.String)
 	}
+	issue.Labels = toGithubLabels(labels)
+	issue.Assignee, issue.Assignees = toGithubAssignees(dbIssue.Assignees)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(issue)
 	log.Printf("[github] ✓ Created issue #%d for %s/%s", dbIssue.Number, owner, repo)
+
+	h.emitEvent(r.Context(), owner, repo, "issues", map[string]interface{}{
+		"action": "opened",
+		"issue":  issue,
+	})
 }
 
 func (h *Handler) handleGetIssue(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
 	ctx := context.Background()
 
-	dbIssue, err := h.queries.GetGithubIssue(ctx, database.GetGithubIssueParams{
-		RepoOwner: owner,
-		RepoName:  repo,
-		Number:    int64(number),
-		SessionID: sessionID,
+	// Fall through to the parent session; the labels live in whichever session holds the issue
+	dbIssue, err := session.ReadThrough(r.Context(), repoKind("github_issues", owner, repo), strconv.Itoa(number), func(candidate string) (database.GetGithubIssueRow, error) {
+		sessionID = candidate
+		return h.queries.GetGithubIssue(ctx, database.GetGithubIssueParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Number:    int64(number),
+			SessionID: candidate,
+		})
 	})
 
 	if err != nil {
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	labels, err := h.queries.ListGithubIssueLabels(ctx, database.ListGithubIssueLabelsParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		IssueNumber: int64(number),
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list issue labels: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -325,6 +573,14 @@ func (h *Handler) handleGetIssue(w http.ResponseWriter, r *http.Request, owner,
 	if dbIssue.Body.Valid {
 		issue.Body = github.Ptr(dbIssue.Body.String)
 	}
+	if dbIssue.StateReason.Valid {
+		issue.StateReason = github.Ptr(dbIssue.StateReason.String)
+	}
+	if dbIssue.PullRequest == 1 {
+		issue.PullRequestLinks = pullRequestLinks(owner, repo, dbIssue.Number)
+	}
+	issue.Labels = toGithubLabels(labels)
+	issue.Assignee, issue.Assignees = toGithubAssignees(dbIssue.Assignees)
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(issue)
@@ -336,7 +592,7 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, owne
 
 	var req github.IssueRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
@@ -349,7 +605,7 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, owne
 	})
 
 	if err != nil {
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 		return
 	}
 
@@ -369,23 +625,73 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, owne
 		state = *req.State
 	}
 
+	// Closing records why, defaulting to completed; reopening records reopened
+	stateReason := dbIssue.StateReason
+	switch {
+	case req.StateReason != nil:
+		if !validStateReasons[*req.StateReason] || (state == "closed") == (*req.StateReason == "reopened") {
+			writeValidationFailed(w, "Issue", "state_reason", "invalid")
+			return
+		}
+		stateReason = sql.NullString{String: *req.StateReason, Valid: true}
+	case state == "closed" && dbIssue.State != "closed":
+		stateReason = sql.NullString{String: "completed", Valid: true}
+	case state == "open" && dbIssue.State == "closed":
+		stateReason = sql.NullString{String: "reopened", Valid: true}
+	}
+
 	err = h.queries.UpdateGithubIssue(ctx, database.UpdateGithubIssueParams{
-		Title:     title,
-		Body:      body,
-		State:     state,
-		RepoOwner: owner,
-		RepoName:  repo,
-		Number:    int64(number),
-		SessionID: sessionID,
+		Title:       title,
+		Body:        body,
+		State:       state,
+		StateReason: stateReason,
+		RepoOwner:   owner,
+		RepoName:    repo,
+		Number:      int64(number),
+		SessionID:   sessionID,
 	})
 
 	if err != nil {
 		log.Printf("[github] ✗ Failed to update issue: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Assignees and labels in the request replace the existing ones; omitting them leaves them alone
+	if req.Assignees != nil {
+		err = h.queries.UpdateGithubIssueAssignees(ctx, database.UpdateGithubIssueAssigneesParams{
+			Assignees: encodeAssignees(*req.Assignees),
+			RepoOwner: owner,
+			RepoName:  repo,
+			Number:    int64(number),
+			SessionID: sessionID,
+		})
+		if err != nil {
+			log.Printf("[github] ✗ Failed to update issue assignees: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+	}
+
+	var labels []string
+	if req.Labels != nil {
+		labels, err = h.setIssueLabels(ctx, owner, repo, int64(number), *req.Labels, sessionID)
+	} else {
+		labels, err = h.queries.ListGithubIssueLabels(ctx, database.ListGithubIssueLabelsParams{
+			RepoOwner:   owner,
+			RepoName:    repo,
+			IssueNumber: int64(number),
+			SessionID:   sessionID,
+		})
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to update issue labels: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	// Return updated issue
+	previousState := dbIssue.State
 	dbIssue, _ = h.queries.GetGithubIssue(ctx, database.GetGithubIssueParams{
 		RepoOwner: owner,
 		RepoName:  repo,
@@ -404,45 +710,87 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, owne
 	if dbIssue.Body.Valid {
 		issue.Body = github.Ptr(dbIssue.Body.String)
 	}
+	if dbIssue.StateReason.Valid {
+		issue.StateReason = github.Ptr(dbIssue.StateReason.String)
+	}
+	if dbIssue.PullRequest == 1 {
+		issue.PullRequestLinks = pullRequestLinks(owner, repo, dbIssue.Number)
+	}
+	issue.Labels = toGithubLabels(labels)
+	issue.Assignee, issue.Assignees = toGithubAssignees(dbIssue.Assignees)
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(issue)
 	log.Printf("[github] ✓ Updated issue #%d for %s/%s", number, owner, repo)
+
+	h.emitEvent(r.Context(), owner, repo, "issues", map[string]interface{}{
+		"action": stateChangeAction(previousState, dbIssue.State),
+		"issue":  issue,
+	})
 }
 
-func (h *Handler) handleCreateIssueComment(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
+func (h *Handler) handleListIssueComments(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
 	ctx := context.Background()
 
-	var req github.IssueComment
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	since, ok := parseSince(r)
+	if !ok {
+		writeValidationFailed(w, "IssueComment", "since", "invalid")
 		return
 	}
 
-	// Get next comment ID
-	nextID, err := h.queries.GetNextCommentID(ctx, database.GetNextCommentIDParams{
-		RepoOwner: owner,
-		RepoName:  repo,
-		SessionID: sessionID,
+	dbComments, err := h.queries.ListGithubIssueComments(ctx, database.ListGithubIssueCommentsParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		IssueNumber: int64(number),
+		SessionID:   sessionID,
+		Since:       since,
 	})
 	if err != nil {
-		log.Printf("[github] ✗ Failed to get next comment ID: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		log.Printf("[github] ✗ Failed to list comments: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	dbComment, err := h.queries.CreateGithubIssueComment(ctx, database.CreateGithubIssueCommentParams{
-		RepoOwner:   owner,
-		RepoName:    repo,
-		IssueNumber: int64(number),
-		CommentID:   nextID,
-		Body:        *req.Body,
-		SessionID:   sessionID,
+	comments := make([]*github.IssueComment, 0, len(dbComments))
+	for i := range dbComments {
+		createdAt := github.Ptr(github.Timestamp{Time: time.Unix(dbComments[i].CreatedAt, 0)})
+		comments = append(comments, &github.IssueComment{
+			ID:        github.Ptr(dbComments[i].CommentID),
+			Body:      github.Ptr(dbComments[i].Body),
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		})
+	}
+
+	comments = paginate(w, r, comments)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(comments)
+	log.Printf("[github] ✓ Listed %d comments on issue #%d for %s/%s", len(comments), number, owner, repo)
+}
+
+func (h *Handler) handleCreateIssueComment(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
+	ctx := context.Background()
+
+	var req github.IssueComment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// The comment ID is allocated in the insert itself so concurrent creates never collide
+	dbComment, err := h.queries.CreateNextGithubIssueComment(ctx, database.CreateNextGithubIssueCommentParams{
+		RepoOwner:       owner,
+		RepoName:        repo,
+		IssueNumber:     int64(number),
+		Body:            *req.Body,
+		SessionID:       sessionID,
+		ParentSessionID: session.Parent(sessionID),
 	})
 
 	if err != nil {
 		log.Printf("[github] ✗ Failed to create comment: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -458,371 +806,1570 @@ func (h *Handler) handleCreateIssueComment(w http.ResponseWriter, r *http.Reques
 	log.Printf("[github] ✓ Created comment on issue #%d for %s/%s", number, owner, repo)
 }
 
-// Pull Request handlers
+// Assignee handlers
 
-func (h *Handler) handlePullRequests(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	sessionID := session.FromContext(r.Context())
+// handleIssueAssignees adds (POST) or removes (DELETE) assignees. Pull requests share the issue
+// endpoint, so a number with no issue falls back to the pull request with that number.
+func (h *Handler) handleIssueAssignees(w http.ResponseWriter, r *http.Request, owner, repo string, number int64, sessionID string) {
+	ctx := context.Background()
 
-	if len(parts) == 0 {
-		// List or create PRs
-		switch r.Method {
-		case http.MethodGet:
-			h.handleListPullRequests(w, r, owner, repo, sessionID)
-		case http.MethodPost:
-			h.handleCreatePullRequest(w, r, owner, repo, sessionID)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	// Handle specific PR
-	prNum, err := strconv.Atoi(parts[0])
-	if err != nil {
-		http.Error(w, "Invalid PR number", http.StatusBadRequest)
+	var req struct {
+		Assignees []string `json:"assignees"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	if len(parts) == 1 {
-		// GET /repos/{owner}/{repo}/pulls/{number}
-		if r.Method == http.MethodGet {
-			h.handleGetPullRequest(w, r, owner, repo, prNum, sessionID)
-			return
+	change := func(current string) string {
+		if r.Method == http.MethodPost {
+			return encodeAssignees(append(decodeAssignees(current), req.Assignees...))
+		}
+		remaining := []string{}
+		for _, login := range decodeAssignees(current) {
+			removed := false
+			for _, remove := range req.Assignees {
+				if strings.EqualFold(login, remove) {
+					removed = true
+					break
+				}
+			}
+			if !removed {
+				remaining = append(remaining, login)
+			}
 		}
+		return encodeAssignees(remaining)
 	}
 
-	if len(parts) == 2 && parts[1] == "merge" {
-		// PUT /repos/{owner}/{repo}/pulls/{number}/merge
-		if r.Method == http.MethodPut {
-			h.handleMergePullRequest(w, r, owner, repo, prNum, sessionID)
-			return
-		}
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		status = http.StatusCreated
 	}
 
-	if len(parts) == 2 && parts[1] == "comments" {
-		// POST /repos/{owner}/{repo}/pulls/{number}/comments (same as issue comments)
-		if r.Method == http.MethodPost {
-			h.handleCreateIssueComment(w, r, owner, repo, prNum, sessionID)
+	dbIssue, err := h.queries.GetGithubIssue(ctx, database.GetGithubIssueParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Number:    number,
+		SessionID: sessionID,
+	})
+	if err == nil {
+		assignees := change(dbIssue.Assignees)
+		err = h.queries.UpdateGithubIssueAssignees(ctx, database.UpdateGithubIssueAssigneesParams{
+			Assignees: assignees,
+			RepoOwner: owner,
+			RepoName:  repo,
+			Number:    number,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			log.Printf("[github] ✗ Failed to update issue assignees: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 			return
 		}
-	}
 
-	http.NotFound(w, r)
-}
+		issue := &github.Issue{
+			ID:     github.Ptr(dbIssue.ID),
+			Number: github.Ptr(int(dbIssue.Number)),
+			Title:  github.Ptr(dbIssue.Title),
+			State:  github.Ptr(dbIssue.State),
+		}
+		if dbIssue.Body.Valid {
+			issue.Body = github.Ptr(dbIssue.Body.String)
+		}
+		issue.Assignee, issue.Assignees = toGithubAssignees(assignees)
 
-func (h *Handler) handleListPullRequests(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
-	ctx := context.Background()
-	state := r.URL.Query().Get("state")
-	if state == "" {
-		state = "open"
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(issue)
+		log.Printf("[github] ✓ Updated assignees of issue #%d for %s/%s", number, owner, repo)
+		return
 	}
 
-	dbPRs, err := h.queries.ListGithubPullRequests(ctx, database.ListGithubPullRequestsParams{
-		RepoOwner:   owner,
-		RepoName:    repo,
-		SessionID:   sessionID,
-		StateFilter: state,
-	})
-
-	if err != nil {
-		log.Printf("[github] ✗ Failed to list PRs: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	prs := make([]*github.PullRequest, 0, len(dbPRs))
-	for i := range dbPRs {
-		pr := &github.PullRequest{
-			ID:        github.Ptr(dbPRs[i].ID),
-			Number:    github.Ptr(int(dbPRs[i].Number)),
-			Title:     github.Ptr(dbPRs[i].Title),
-			State:     github.Ptr(dbPRs[i].State),
-			Head:      &github.PullRequestBranch{Ref: github.Ptr(dbPRs[i].Head)},
-			Base:      &github.PullRequestBranch{Ref: github.Ptr(dbPRs[i].Base)},
-			Merged:    github.Ptr(dbPRs[i].Merged == 1),
-			CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPRs[i].CreatedAt, 0)}),
-			UpdatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPRs[i].UpdatedAt, 0)}),
-		}
-		if dbPRs[i].Body.Valid {
-			pr.Body = github.Ptr(dbPRs[i].Body.String)
-		}
-		prs = append(prs, pr)
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(prs)
-	log.Printf("[github] ✓ Listed %d PRs for %s/%s", len(prs), owner, repo)
-}
-
-func (h *Handler) handleCreatePullRequest(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
-	ctx := context.Background()
-
-	var req github.NewPullRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	// Get next PR number
-	nextNum, err := h.queries.GetNextPRNumber(ctx, database.GetNextPRNumberParams{
+	dbPR, err := h.queries.GetGithubPullRequest(ctx, database.GetGithubPullRequestParams{
 		RepoOwner: owner,
 		RepoName:  repo,
+		Number:    number,
 		SessionID: sessionID,
 	})
 	if err != nil {
-		log.Printf("[github] ✗ Failed to get next PR number: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 		return
 	}
 
-	body := sql.NullString{}
-	if req.Body != nil {
-		body = sql.NullString{String: *req.Body, Valid: true}
-	}
-
-	dbPR, err := h.queries.CreateGithubPullRequest(ctx, database.CreateGithubPullRequestParams{
+	assignees := change(dbPR.Assignees)
+	err = h.queries.UpdateGithubPullRequestAssignees(ctx, database.UpdateGithubPullRequestAssigneesParams{
+		Assignees: assignees,
 		RepoOwner: owner,
 		RepoName:  repo,
-		Number:    nextNum,
-		Title:     *req.Title,
-		Body:      body,
-		Head:      *req.Head,
-		Base:      *req.Base,
-		State:     "open",
+		Number:    number,
 		SessionID: sessionID,
 	})
-
 	if err != nil {
-		log.Printf("[github] ✗ Failed to create PR: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		log.Printf("[github] ✗ Failed to update PR assignees: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	pr := &github.PullRequest{
-		ID:        github.Ptr(dbPR.ID),
-		Number:    github.Ptr(int(dbPR.Number)),
-		Title:     github.Ptr(dbPR.Title),
-		State:     github.Ptr(dbPR.State),
-		Head:      &github.PullRequestBranch{Ref: github.Ptr(dbPR.Head)},
-		Base:      &github.PullRequestBranch{Ref: github.Ptr(dbPR.Base)},
-		Merged:    github.Ptr(dbPR.Merged == 1),
-		CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPR.CreatedAt, 0)}),
-		UpdatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPR.UpdatedAt, 0)}),
+	issue := &github.Issue{
+		ID:               github.Ptr(dbPR.ID),
+		Number:           github.Ptr(int(dbPR.Number)),
+		Title:            github.Ptr(dbPR.Title),
+		State:            github.Ptr(dbPR.State),
+		PullRequestLinks: pullRequestLinks(owner, repo, number),
 	}
 	if dbPR.Body.Valid {
-		pr.Body = github.Ptr(dbPR.Body.String)
+		issue.Body = github.Ptr(dbPR.Body.String)
 	}
+	issue.Assignee, issue.Assignees = toGithubAssignees(assignees)
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(pr)
-	log.Printf("[github] ✓ Created PR #%d for %s/%s", dbPR.Number, owner, repo)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(issue)
+	log.Printf("[github] ✓ Updated assignees of PR #%d for %s/%s", number, owner, repo)
 }
 
-func (h *Handler) handleGetPullRequest(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
-	ctx := context.Background()
-
-	dbPR, err := h.queries.GetGithubPullRequest(ctx, database.GetGithubPullRequestParams{
-		RepoOwner: owner,
-		RepoName:  repo,
-		Number:    int64(number),
-		SessionID: sessionID,
-	})
+// pullRequestLinks marks an issue response as belonging to a pull request
+func pullRequestLinks(owner, repo string, number int64) *github.PullRequestLinks {
+	return &github.PullRequestLinks{
+		URL: github.Ptr(fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", owner, repo, number)),
+	}
+}
 
-	if err != nil {
-		http.NotFound(w, r)
-		return
+// encodeAssignees stores logins as a JSON array, dropping blanks and repeats
+func encodeAssignees(logins []string) string {
+	unique := []string{}
+	for _, login := range logins {
+		login = strings.TrimSpace(login)
+		if login == "" {
+			continue
+		}
+		seen := false
+		for _, existing := range unique {
+			if strings.EqualFold(existing, login) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			unique = append(unique, login)
+		}
 	}
+	data, _ := json.Marshal(unique)
+	return string(data)
+}
 
-	pr := &github.PullRequest{
-		ID:        github.Ptr(dbPR.ID),
-		Number:    github.Ptr(int(dbPR.Number)),
-		Title:     github.Ptr(dbPR.Title),
-		State:     github.Ptr(dbPR.State),
-		Head:      &github.PullRequestBranch{Ref: github.Ptr(dbPR.Head)},
-		Base:      &github.PullRequestBranch{Ref: github.Ptr(dbPR.Base)},
-		Merged:    github.Ptr(dbPR.Merged == 1),
-		CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPR.CreatedAt, 0)}),
-		UpdatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPR.UpdatedAt, 0)}),
+// decodeAssignees reads a stored JSON array of logins
+func decodeAssignees(assigneesJSON string) []string {
+	var logins []string
+	_ = json.Unmarshal([]byte(assigneesJSON), &logins)
+	return logins
+}
+
+// toGithubAssignees converts stored logins to the API's first assignee and assignee list
+func toGithubAssignees(assigneesJSON string) (*github.User, []*github.User) {
+	logins := decodeAssignees(assigneesJSON)
+	users := make([]*github.User, 0, len(logins))
+	for _, login := range logins {
+		users = append(users, &github.User{Login: github.Ptr(login)})
 	}
-	if dbPR.Body.Valid {
-		pr.Body = github.Ptr(dbPR.Body.String)
+	if len(users) == 0 {
+		return nil, users
 	}
+	return users[0], users
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(pr)
-	log.Printf("[github] ✓ Returned PR #%d for %s/%s", number, owner, repo)
+// matchesAssignee applies the issue list assignee filter: a login, "none" for unassigned
+// issues or "*" for assigned ones
+func matchesAssignee(assigneesJSON, filter string) bool {
+	logins := decodeAssignees(assigneesJSON)
+	switch filter {
+	case "":
+		return true
+	case "none":
+		return len(logins) == 0
+	case "*":
+		return len(logins) > 0
+	}
+	for _, login := range logins {
+		if strings.EqualFold(login, filter) {
+			return true
+		}
+	}
+	return false
 }
 
-func (h *Handler) handleMergePullRequest(w http.ResponseWriter, _ *http.Request, owner, repo string, number int, sessionID string) {
+// Issue label handlers
+
+// defaultLabelColor is the color GitHub gives a label created by applying it to an issue
+const defaultLabelColor = "ededed"
+
+func (h *Handler) handleIssueLabels(w http.ResponseWriter, r *http.Request, owner, repo string, number int64, name, sessionID string) {
 	ctx := context.Background()
 
-	err := h.queries.MergeGithubPullRequest(ctx, database.MergeGithubPullRequestParams{
+	_, err := h.queries.GetGithubIssue(ctx, database.GetGithubIssueParams{
 		RepoOwner: owner,
 		RepoName:  repo,
-		Number:    int64(number),
+		Number:    number,
 		SessionID: sessionID,
 	})
-
 	if err != nil {
-		log.Printf("[github] ✗ Failed to merge PR: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 		return
 	}
 
-	response := map[string]interface{}{
-		"sha":     generateSHA(fmt.Sprintf("merge-%d", number)),
-		"merged":  true,
-		"message": "Pull Request successfully merged",
+	switch {
+	case name != "" && r.Method == http.MethodDelete:
+		h.handleRemoveIssueLabel(w, owner, repo, number, name, sessionID)
+	case name != "":
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+	case r.Method == http.MethodGet:
+		h.writeIssueLabels(w, owner, repo, number, sessionID)
+	case r.Method == http.MethodPost:
+		h.handleAddIssueLabels(w, r, owner, repo, number, sessionID)
+	case r.Method == http.MethodDelete:
+		if _, err := h.setIssueLabels(ctx, owner, repo, number, nil, sessionID); err != nil {
+			log.Printf("[github] ✗ Failed to remove issue labels: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		log.Printf("[github] ✓ Removed all labels from issue #%d for %s/%s", number, owner, repo)
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[github] ✓ Merged PR #%d for %s/%s", number, owner, repo)
 }
 
-// Git handlers (refs, files)
+func (h *Handler) handleAddIssueLabels(w http.ResponseWriter, r *http.Request, owner, repo string, number int64, sessionID string) {
+	ctx := context.Background()
 
-func (h *Handler) handleGit(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	if len(parts) < 1 {
-		http.NotFound(w, r)
+	// GitHub accepts either a bare array of names or {"labels": [...]}
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	var names []string
+	if err := json.Unmarshal(raw, &names); err != nil {
+		var req struct {
+			Labels []string `json:"labels"`
+		}
+		if err := json.Unmarshal(raw, &req); err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		names = req.Labels
+	}
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			writeValidationFailed(w, "Label", "name", "invalid")
+			return
+		}
+	}
 
-	switch parts[0] {
-	case "refs", "ref":
-		// Support both /git/refs and /git/ref
-		h.handleRefs(w, r, owner, repo, parts[1:])
-	default:
-		http.NotFound(w, r)
+	err := h.queries.ExecTx(ctx, func(q *database.Queries) error {
+		for _, name := range names {
+			err := q.AddGithubIssueLabel(ctx, database.AddGithubIssueLabelParams{
+				RepoOwner:   owner,
+				RepoName:    repo,
+				IssueNumber: number,
+				Name:        name,
+				SessionID:   sessionID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to add issue labels: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
 	}
+
+	log.Printf("[github] ✓ Added %d labels to issue #%d for %s/%s", len(names), number, owner, repo)
+	h.writeIssueLabels(w, owner, repo, number, sessionID)
 }
 
-func (h *Handler) handleRefs(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	sessionID := session.FromContext(r.Context())
-	ctx := context.Background()
+func (h *Handler) handleRemoveIssueLabel(w http.ResponseWriter, owner, repo string, number int64, name, sessionID string) {
+	removed, err := h.queries.DeleteGithubIssueLabel(context.Background(), database.DeleteGithubIssueLabelParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		IssueNumber: number,
+		Name:        name,
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to remove issue label: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if removed == 0 {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Label does not exist")
+		return
+	}
 
-	if len(parts) == 0 {
-		// POST /git/refs (create new ref)
-		if r.Method == http.MethodPost {
-			var req github.CreateRef
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				http.Error(w, "Invalid request body", http.StatusBadRequest)
-				return
-			}
+	log.Printf("[github] ✓ Removed label %q from issue #%d for %s/%s", name, number, owner, repo)
+	h.writeIssueLabels(w, owner, repo, number, sessionID)
+}
 
-			// Extract branch name from ref
-			refStr := req.Ref
-			if !strings.HasPrefix(refStr, "refs/heads/") {
-				http.Error(w, "Only branch refs supported", http.StatusBadRequest)
-				return
-			}
-			newBranch := strings.TrimPrefix(refStr, "refs/heads/")
+// writeIssueLabels responds with the issue's current labels
+func (h *Handler) writeIssueLabels(w http.ResponseWriter, owner, repo string, number int64, sessionID string) {
+	names, err := h.queries.ListGithubIssueLabels(context.Background(), database.ListGithubIssueLabelsParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		IssueNumber: number,
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list issue labels: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
 
-			err := h.queries.CreateGithubBranch(ctx, database.CreateGithubBranchParams{
-				RepoOwner: owner,
-				RepoName:  repo,
-				Name:      newBranch,
-				Sha:       req.SHA,
-				SessionID: sessionID,
-			})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toGithubLabels(names))
+	log.Printf("[github] ✓ Returned %d labels for issue #%d in %s/%s", len(names), number, owner, repo)
+}
 
+// setIssueLabels replaces an issue's labels and returns them in stored order
+func (h *Handler) setIssueLabels(ctx context.Context, owner, repo string, number int64, names []string, sessionID string) ([]string, error) {
+	err := h.queries.ExecTx(ctx, func(q *database.Queries) error {
+		err := q.DeleteGithubIssueLabels(ctx, database.DeleteGithubIssueLabelsParams{
+			RepoOwner:   owner,
+			RepoName:    repo,
+			IssueNumber: number,
+			SessionID:   sessionID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			err := q.AddGithubIssueLabel(ctx, database.AddGithubIssueLabelParams{
+				RepoOwner:   owner,
+				RepoName:    repo,
+				IssueNumber: number,
+				Name:        name,
+				SessionID:   sessionID,
+			})
 			if err != nil {
-				log.Printf("[github] ✗ Failed to create branch: %v", err)
-				http.Error(w, "Failed to create branch", http.StatusInternalServerError)
-				return
+				return err
 			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.queries.ListGithubIssueLabels(ctx, database.ListGithubIssueLabelsParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		IssueNumber: number,
+		SessionID:   sessionID,
+	})
+}
 
-			// Return a Reference response
-			response := &github.Reference{
-				Ref: &req.Ref,
-				Object: &github.GitObject{
-					SHA: &req.SHA,
-				},
-			}
+// toGithubLabels converts stored label names to the API shape
+func toGithubLabels(names []string) []*github.Label {
+	labels := make([]*github.Label, 0, len(names))
+	for _, name := range names {
+		labels = append(labels, &github.Label{
+			Name:  github.Ptr(name),
+			Color: github.Ptr(defaultLabelColor),
+		})
+	}
+	return labels
+}
 
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(response)
-			log.Printf("[github] ✓ Created branch %s in %s/%s", newBranch, owner, repo)
-			return
+// hasAllLabels reports whether labels include every name in want, ignoring case as GitHub does
+func hasAllLabels(labels, want []string) bool {
+	for _, name := range want {
+		found := false
+		for _, label := range labels {
+			if strings.EqualFold(label, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
 		}
-		http.NotFound(w, r)
-		return
 	}
+	return true
+}
 
-	// Handle /git/refs/heads/{branch}
-	if parts[0] == "heads" && len(parts) == 2 {
-		branchName := parts[1]
+// Reaction handlers
 
-		switch r.Method {
-		case http.MethodGet:
-			// GET /repos/{owner}/{repo}/git/refs/heads/{branch}
-			dbBranch, err := h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+func (h *Handler) handleReactions(w http.ResponseWriter, r *http.Request, owner, repo, subjectType string, subjectID int64, sessionID string) {
+	ctx := context.Background()
+
+	// The reacted-to issue or comment must exist; pull requests take issue reactions too
+	var err error
+	if subjectType == "issue" {
+		_, err = h.queries.GetGithubIssue(ctx, database.GetGithubIssueParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Number:    subjectID,
+			SessionID: sessionID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			_, err = h.queries.GetGithubPullRequest(ctx, database.GetGithubPullRequestParams{
 				RepoOwner: owner,
 				RepoName:  repo,
-				Name:      branchName,
+				Number:    subjectID,
 				SessionID: sessionID,
 			})
-
-			if err != nil {
-				http.NotFound(w, r)
-				return
-			}
-
-			ref := &github.Reference{
-				Ref: github.Ptr("refs/heads/" + branchName),
-				Object: &github.GitObject{
-					SHA: github.Ptr(dbBranch.Sha),
-				},
-			}
-
-			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(ref)
-			log.Printf("[github] ✓ Returned ref for branch %s in %s/%s", branchName, owner, repo)
-
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
+	} else {
+		_, err = h.queries.GetGithubIssueComment(ctx, database.GetGithubIssueCommentParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			CommentID: subjectID,
+			SessionID: sessionID,
+		})
+	}
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 		return
 	}
 
-	http.NotFound(w, r)
+	switch r.Method {
+	case http.MethodGet:
+		h.handleListReactions(w, r, owner, repo, subjectType, subjectID, sessionID)
+	case http.MethodPost:
+		h.handleCreateReaction(w, r, owner, repo, subjectType, subjectID, sessionID)
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+	}
 }
 
-// Contents handlers
+func (h *Handler) handleListReactions(w http.ResponseWriter, r *http.Request, owner, repo, subjectType string, subjectID int64, sessionID string) {
+	ctx := context.Background()
 
-func (h *Handler) handleContents(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	if len(parts) == 0 {
-		http.Error(w, "Path required", http.StatusBadRequest)
+	dbReactions, err := h.queries.ListGithubReactions(ctx, database.ListGithubReactionsParams{
+		RepoOwner:     owner,
+		RepoName:      repo,
+		SubjectType:   subjectType,
+		SubjectID:     subjectID,
+		SessionID:     sessionID,
+		ContentFilter: r.URL.Query().Get("content"),
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list reactions: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	sessionID := session.FromContext(r.Context())
-	ctx := context.Background()
-	path := strings.Join(parts, "/")
-	branch := r.URL.Query().Get("ref")
-	if branch == "" {
-		branch = "main"
+	reactions := make([]*github.Reaction, 0, len(dbReactions))
+	for _, dbReaction := range dbReactions {
+		reactions = append(reactions, toGithubReaction(dbReaction.ID, dbReaction.UserLogin, dbReaction.Content, dbReaction.CreatedAt))
 	}
 
-	switch r.Method {
-	case http.MethodGet:
-		// GET /repos/{owner}/{repo}/contents/{path}
-		dbFile, err := h.queries.GetGithubFile(ctx, database.GetGithubFileParams{
-			RepoOwner: owner,
-			RepoName:  repo,
-			Path:      path,
-			Branch:    branch,
-			SessionID: sessionID,
-		})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reactions)
+	log.Printf("[github] ✓ Listed %d reactions on %s %d for %s/%s", len(reactions), subjectType, subjectID, owner, repo)
+}
 
-		if err != nil {
-			http.NotFound(w, r)
-			return
-		}
+func (h *Handler) handleCreateReaction(w http.ResponseWriter, r *http.Request, owner, repo, subjectType string, subjectID int64, sessionID string) {
+	ctx := context.Background()
 
-		content := &github.RepositoryContent{
+	var req struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !validReactionContents[req.Content] {
+		writeValidationFailed(w, "Reaction", "content", "invalid")
+		return
+	}
+
+	// A user can only leave each reaction once; repeating it returns the existing reaction
+	existing, err := h.queries.GetGithubReaction(ctx, database.GetGithubReactionParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		UserLogin:   authenticatedUserLogin,
+		Content:     req.Content,
+		SessionID:   sessionID,
+	})
+	if err == nil {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(toGithubReaction(existing.ID, existing.UserLogin, existing.Content, existing.CreatedAt))
+		log.Printf("[github] ✓ Reaction %s already exists on %s %d for %s/%s", req.Content, subjectType, subjectID, owner, repo)
+		return
+	}
+
+	dbReaction, err := h.queries.CreateGithubReaction(ctx, database.CreateGithubReactionParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		UserLogin:   authenticatedUserLogin,
+		Content:     req.Content,
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to create reaction: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toGithubReaction(dbReaction.ID, dbReaction.UserLogin, dbReaction.Content, dbReaction.CreatedAt))
+	log.Printf("[github] ✓ Created reaction %s on %s %d for %s/%s", req.Content, subjectType, subjectID, owner, repo)
+}
+
+// Pull Request handlers
+
+func (h *Handler) handlePullRequests(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	sessionID := session.FromContext(r.Context())
+
+	if len(parts) == 0 {
+		// List or create PRs
+		switch r.Method {
+		case http.MethodGet:
+			h.handleListPullRequests(w, r, owner, repo, sessionID)
+		case http.MethodPost:
+			h.handleCreatePullRequest(w, r, owner, repo, sessionID)
+		default:
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	// Handle specific PR
+	prNum, err := strconv.Atoi(parts[0])
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid PR number")
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			// GET /repos/{owner}/{repo}/pulls/{number}
+			h.handleGetPullRequest(w, r, owner, repo, prNum, sessionID)
+			return
+		case http.MethodPatch:
+			// PATCH /repos/{owner}/{repo}/pulls/{number}
+			h.handleUpdatePullRequest(w, r, owner, repo, prNum, sessionID)
+			return
+		}
+	}
+
+	if len(parts) == 2 && parts[1] == "merge" {
+		// PUT /repos/{owner}/{repo}/pulls/{number}/merge
+		if r.Method == http.MethodPut {
+			h.handleMergePullRequest(w, r, owner, repo, prNum, sessionID)
+			return
+		}
+	}
+
+	if len(parts) == 2 && parts[1] == "comments" {
+		// POST /repos/{owner}/{repo}/pulls/{number}/comments (same as issue comments)
+		if r.Method == http.MethodPost {
+			h.handleCreateIssueComment(w, r, owner, repo, prNum, sessionID)
+			return
+		}
+	}
+
+	apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+}
+
+func (h *Handler) handleListPullRequests(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
+	ctx := context.Background()
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		state = "open"
+	}
+
+	dbPRs, err := session.ListThrough(r.Context(), repoKind("github_pull_requests", owner, repo), func(candidate string) ([]database.ListGithubPullRequestsRow, error) {
+		return h.queries.ListGithubPullRequests(ctx, database.ListGithubPullRequestsParams{
+			RepoOwner:   owner,
+			RepoName:    repo,
+			SessionID:   candidate,
+			StateFilter: state,
+		})
+	}, func(candidate string) ([]database.ListGithubPullRequestsRow, error) {
+		return h.queries.ListGithubPullRequests(ctx, database.ListGithubPullRequestsParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			SessionID: candidate,
+		})
+	}, func(pr database.ListGithubPullRequestsRow) string { return strconv.FormatInt(pr.Number, 10) })
+
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list PRs: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	sort.SliceStable(dbPRs, func(i, j int) bool { return dbPRs[i].CreatedAt > dbPRs[j].CreatedAt })
+
+	prs := make([]*github.PullRequest, 0, len(dbPRs))
+	for i := range dbPRs {
+		pr := &github.PullRequest{
+			ID:                  github.Ptr(dbPRs[i].ID),
+			Number:              github.Ptr(int(dbPRs[i].Number)),
+			Title:               github.Ptr(dbPRs[i].Title),
+			State:               github.Ptr(dbPRs[i].State),
+			Head:                &github.PullRequestBranch{Ref: github.Ptr(dbPRs[i].Head)},
+			Base:                &github.PullRequestBranch{Ref: github.Ptr(dbPRs[i].Base)},
+			Merged:              github.Ptr(dbPRs[i].Merged == 1),
+			Draft:               github.Ptr(dbPRs[i].Draft == 1),
+			MaintainerCanModify: github.Ptr(dbPRs[i].MaintainerCanModify == 1),
+			CreatedAt:           github.Ptr(github.Timestamp{Time: time.Unix(dbPRs[i].CreatedAt, 0)}),
+			UpdatedAt:           github.Ptr(github.Timestamp{Time: time.Unix(dbPRs[i].UpdatedAt, 0)}),
+		}
+		if dbPRs[i].Body.Valid {
+			pr.Body = github.Ptr(dbPRs[i].Body.String)
+		}
+		if dbPRs[i].MergedAt.Valid {
+			pr.MergedAt = github.Ptr(github.Timestamp{Time: time.Unix(dbPRs[i].MergedAt.Int64, 0)})
+		}
+		pr.Assignee, pr.Assignees = toGithubAssignees(dbPRs[i].Assignees)
+		prs = append(prs, pr)
+	}
+
+	prs = paginate(w, r, prs)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(prs)
+	log.Printf("[github] ✓ Listed %d PRs for %s/%s", len(prs), owner, repo)
+}
+
+func (h *Handler) handleCreatePullRequest(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
+	ctx := context.Background()
+
+	// Assignees aren't part of NewPullRequest but are accepted so PRs can be created assigned
+	var req struct {
+		github.NewPullRequest
+		Assignees []string `json:"assignees"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Head == nil || *req.Head == "" {
+		writeValidationFailed(w, "PullRequest", "head", "missing_field")
+		return
+	}
+	if req.Base == nil || *req.Base == "" {
+		writeValidationFailed(w, "PullRequest", "base", "missing_field")
+		return
+	}
+
+	title := ""
+	if req.Title != nil {
+		title = *req.Title
+	}
+	if req.Issue == nil && title == "" {
+		writeValidationFailed(w, "PullRequest", "title", "missing_field")
+		return
+	}
+
+	var dbPR database.CreateNextGithubPullRequestRow
+	var err error
+	if req.Issue != nil {
+		dbPR, err = h.convertIssueToPullRequest(ctx, database.CreateGithubPullRequestFromIssueParams{
+			RepoOwner:           owner,
+			RepoName:            repo,
+			Number:              int64(*req.Issue),
+			Head:                *req.Head,
+			Base:                *req.Base,
+			State:               "open",
+			Draft:               boolToInt(req.GetDraft()),
+			MaintainerCanModify: boolToInt(req.GetMaintainerCanModify()),
+			Assignees:           encodeAssignees(req.Assignees),
+			SessionID:           sessionID,
+		})
+		if errors.Is(err, errIssueNotConvertible) {
+			writeValidationFailed(w, "PullRequest", "issue", "invalid")
+			return
+		}
+	} else {
+		// The number is allocated in the insert itself so concurrent creates never collide
+		dbPR, err = h.queries.CreateNextGithubPullRequest(ctx, database.CreateNextGithubPullRequestParams{
+			RepoOwner:           owner,
+			RepoName:            repo,
+			Title:               title,
+			Body:                nullString(req.Body),
+			Head:                *req.Head,
+			Base:                *req.Base,
+			State:               "open",
+			Draft:               boolToInt(req.GetDraft()),
+			MaintainerCanModify: boolToInt(req.GetMaintainerCanModify()),
+			Assignees:           encodeAssignees(req.Assignees),
+			SessionID:           sessionID,
+			ParentSessionID:     session.Parent(sessionID),
+		})
+	}
+
+	if err != nil {
+		log.Printf("[github] ✗ Failed to create PR: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	pr := &github.PullRequest{
+		ID:                  github.Ptr(dbPR.ID),
+		Number:              github.Ptr(int(dbPR.Number)),
+		Title:               github.Ptr(dbPR.Title),
+		State:               github.Ptr(dbPR.State),
+		Head:                &github.PullRequestBranch{Ref: github.Ptr(dbPR.Head)},
+		Base:                &github.PullRequestBranch{Ref: github.Ptr(dbPR.Base)},
+		Merged:              github.Ptr(dbPR.Merged == 1),
+		Draft:               github.Ptr(dbPR.Draft == 1),
+		MaintainerCanModify: github.Ptr(dbPR.MaintainerCanModify == 1),
+		CreatedAt:           github.Ptr(github.Timestamp{Time: time.Unix(dbPR.CreatedAt, 0)}),
+		UpdatedAt:           github.Ptr(github.Timestamp{Time: time.Unix(dbPR.UpdatedAt, 0)}),
+	}
+	if dbPR.Body.Valid {
+		pr.Body = github.Ptr(dbPR.Body.String)
+	}
+	pr.Assignee, pr.Assignees = toGithubAssignees(dbPR.Assignees)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(pr)
+	log.Printf("[github] ✓ Created PR #%d for %s/%s", dbPR.Number, owner, repo)
+
+	h.emitEvent(r.Context(), owner, repo, "pull_request", map[string]interface{}{
+		"action":       "opened",
+		"number":       dbPR.Number,
+		"pull_request": pr,
+	})
+}
+
+// errIssueNotConvertible reports an issue that is missing or already a pull request
+var errIssueNotConvertible = errors.New("issue cannot be converted to a pull request")
+
+// convertIssueToPullRequest opens a pull request under an issue's number, taking its title and
+// body, and marks the issue as that pull request
+func (h *Handler) convertIssueToPullRequest(ctx context.Context, arg database.CreateGithubPullRequestFromIssueParams) (database.CreateNextGithubPullRequestRow, error) {
+	var dbPR database.CreateGithubPullRequestFromIssueRow
+	err := h.queries.ExecTx(ctx, func(q *database.Queries) error {
+		issue, err := q.GetGithubIssue(ctx, database.GetGithubIssueParams{
+			RepoOwner: arg.RepoOwner,
+			RepoName:  arg.RepoName,
+			Number:    arg.Number,
+			SessionID: arg.SessionID,
+		})
+		if errors.Is(err, sql.ErrNoRows) || issue.PullRequest == 1 {
+			return errIssueNotConvertible
+		}
+		if err != nil {
+			return err
+		}
+
+		arg.Title = issue.Title
+		arg.Body = issue.Body
+		if dbPR, err = q.CreateGithubPullRequestFromIssue(ctx, arg); err != nil {
+			return err
+		}
+		return q.MarkGithubIssuePullRequest(ctx, database.MarkGithubIssuePullRequestParams{
+			RepoOwner: arg.RepoOwner,
+			RepoName:  arg.RepoName,
+			Number:    arg.Number,
+			SessionID: arg.SessionID,
+		})
+	})
+	return database.CreateNextGithubPullRequestRow(dbPR), err
+}
+
+func (h *Handler) handleGetPullRequest(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
+	ctx := context.Background()
+
+	dbPR, err := session.ReadThrough(r.Context(), repoKind("github_pull_requests", owner, repo), strconv.Itoa(number), func(candidate string) (database.GetGithubPullRequestRow, error) {
+		return h.queries.GetGithubPullRequest(ctx, database.GetGithubPullRequestParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Number:    int64(number),
+			SessionID: candidate,
+		})
+	})
+
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	pr := &github.PullRequest{
+		ID:                  github.Ptr(dbPR.ID),
+		Number:              github.Ptr(int(dbPR.Number)),
+		Title:               github.Ptr(dbPR.Title),
+		State:               github.Ptr(dbPR.State),
+		Head:                &github.PullRequestBranch{Ref: github.Ptr(dbPR.Head)},
+		Base:                &github.PullRequestBranch{Ref: github.Ptr(dbPR.Base)},
+		Merged:              github.Ptr(dbPR.Merged == 1),
+		Draft:               github.Ptr(dbPR.Draft == 1),
+		MaintainerCanModify: github.Ptr(dbPR.MaintainerCanModify == 1),
+		CreatedAt:           github.Ptr(github.Timestamp{Time: time.Unix(dbPR.CreatedAt, 0)}),
+		UpdatedAt:           github.Ptr(github.Timestamp{Time: time.Unix(dbPR.UpdatedAt, 0)}),
+	}
+	if dbPR.Body.Valid {
+		pr.Body = github.Ptr(dbPR.Body.String)
+	}
+	if dbPR.MergedAt.Valid {
+		pr.MergedAt = github.Ptr(github.Timestamp{Time: time.Unix(dbPR.MergedAt.Int64, 0)})
+	}
+	pr.Assignee, pr.Assignees = toGithubAssignees(dbPR.Assignees)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(pr)
+	log.Printf("[github] ✓ Returned PR #%d for %s/%s", number, owner, repo)
+}
+
+func (h *Handler) handleUpdatePullRequest(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
+	ctx := context.Background()
+
+	var req struct {
+		Title               *string `json:"title"`
+		Body                *string `json:"body"`
+		State               *string `json:"state"`
+		Base                *string `json:"base"`
+		Draft               *bool   `json:"draft"`
+		MaintainerCanModify *bool   `json:"maintainer_can_modify"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.State != nil && *req.State != "open" && *req.State != "closed" {
+		writeValidationFailed(w, "PullRequest", "state", "invalid")
+		return
+	}
+
+	params := database.UpdateGithubPullRequestParams{
+		Title:     nullString(req.Title),
+		Body:      nullString(req.Body),
+		State:     nullString(req.State),
+		Base:      nullString(req.Base),
+		RepoOwner: owner,
+		RepoName:  repo,
+		Number:    int64(number),
+		SessionID: sessionID,
+	}
+	if req.Draft != nil {
+		params.Draft = sql.NullInt64{Int64: boolToInt(*req.Draft), Valid: true}
+	}
+	if req.MaintainerCanModify != nil {
+		params.MaintainerCanModify = sql.NullInt64{Int64: boolToInt(*req.MaintainerCanModify), Valid: true}
+	}
+
+	current, err := h.queries.GetGithubPullRequest(ctx, database.GetGithubPullRequestParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Number:    int64(number),
+		SessionID: sessionID,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if err := h.queries.UpdateGithubPullRequest(ctx, params); err != nil {
+		log.Printf("[github] ✗ Failed to update PR: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	log.Printf("[github] ✓ Updated PR #%d for %s/%s", number, owner, repo)
+	h.handleGetPullRequest(w, r, owner, repo, number, sessionID)
+
+	action := "edited"
+	if req.State != nil {
+		action = stateChangeAction(current.State, *req.State)
+	}
+	h.emitPullRequestEvent(r.Context(), owner, repo, number, action)
+}
+
+func (h *Handler) handleMergePullRequest(w http.ResponseWriter, r *http.Request, owner, repo string, number int, sessionID string) {
+	ctx := context.Background()
+
+	var req struct {
+		SHA           string `json:"sha"`
+		CommitTitle   string `json:"commit_title"`
+		CommitMessage string `json:"commit_message"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	dbPR, err := h.queries.GetGithubPullRequest(ctx, database.GetGithubPullRequestParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Number:    int64(number),
+		SessionID: sessionID,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if dbPR.Merged == 1 {
+		writeMergeError(w, http.StatusMethodNotAllowed, "Pull Request is already merged")
+		return
+	}
+	if dbPR.State != "open" {
+		writeMergeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	}
+	if dbPR.Draft == 1 {
+		writeMergeError(w, http.StatusMethodNotAllowed, "Draft pull requests cannot be merged until they are marked ready for review")
+		return
+	}
+
+	// A sha in the request must match the current head of the PR branch
+	if req.SHA != "" {
+		head, err := h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Name:      dbPR.Head,
+			SessionID: sessionID,
+		})
+		if err == nil && head.Sha != req.SHA {
+			writeMergeError(w, http.StatusConflict, "Head branch was modified. Review and try the merge again.")
+			return
+		}
+	}
+
+	// Enforce protection on the base branch; the simulator records no reviews or statuses,
+	// so any required review or status check blocks the merge
+	protection, err := h.queries.GetGithubBranchProtection(ctx, database.GetGithubBranchProtectionParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Branch:    dbPR.Base,
+		SessionID: sessionID,
+	})
+	if err == nil {
+		rules := toGithubProtection(owner, repo, dbPR.Base, protection)
+		if reviews := rules.RequiredPullRequestReviews; reviews != nil && reviews.RequiredApprovingReviewCount > 0 {
+			writeMergeError(w, http.StatusMethodNotAllowed, fmt.Sprintf(
+				"At least %d approving review is required by reviewers with write access.", reviews.RequiredApprovingReviewCount))
+			return
+		}
+		if checks := rules.RequiredStatusChecks; checks != nil && checks.Contexts != nil && len(*checks.Contexts) > 0 {
+			writeMergeError(w, http.StatusMethodNotAllowed, fmt.Sprintf(
+				"Required status check %q is expected.", (*checks.Contexts)[0]))
+			return
+		}
+	}
+
+	message := req.CommitTitle
+	if message == "" {
+		message = fmt.Sprintf("Merge pull request #%d from %s", number, dbPR.Head)
+	}
+	if req.CommitMessage != "" {
+		message += "\n\n" + req.CommitMessage
+	}
+
+	// Mark the PR merged and move the base branch to a merge commit together
+	var before string
+	var mergeCommit database.GithubCommit
+	err = h.queries.ExecTx(ctx, func(q *database.Queries) error {
+		merged, err := q.MergeGithubPullRequest(ctx, database.MergeGithubPullRequestParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Number:    int64(number),
+			SessionID: sessionID,
+		})
+		if err != nil {
+			return err
+		}
+		// A concurrent merge or close got there first
+		if merged == 0 {
+			return errNotMergeable
+		}
+		before = branchHead(ctx, q, owner, repo, dbPR.Base, sessionID)
+		mergeCommit, err = mergeBranch(ctx, q, owner, repo, dbPR.Base, dbPR.Head, message, sessionID)
+		return err
+	})
+	if errors.Is(err, errNotMergeable) {
+		writeMergeError(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to merge PR: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := map[string]interface{}{
+		"sha":     mergeCommit.Sha,
+		"merged":  true,
+		"message": "Pull Request successfully merged",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[github] ✓ Merged PR #%d for %s/%s", number, owner, repo)
+
+	h.emitPullRequestEvent(r.Context(), owner, repo, number, "closed")
+	h.emitPushEvent(r.Context(), owner, repo, dbPR.Base, before, mergeCommit.Sha, message)
+}
+
+// errNotMergeable reports a pull request that was merged or closed while a merge was underway
+var errNotMergeable = errors.New("pull request is not mergeable")
+
+// writeMergeError writes a GitHub-style error for a rejected merge
+func writeMergeError(w http.ResponseWriter, status int, message string) {
+	log.Printf("[github] ✗ Merge rejected: %s", message)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest/pulls/pulls#merge-a-pull-request",
+	})
+}
+
+// Git handlers (refs, trees, blobs)
+
+func (h *Handler) handleGit(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	if len(parts) < 1 {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch parts[0] {
+	case "refs", "ref":
+		// Support both /git/refs and /git/ref
+		h.handleRefs(w, r, owner, repo, parts[1:])
+	case "trees":
+		h.handleGetTree(w, r, owner, repo, parts[1:])
+	case "blobs":
+		h.handleGetBlob(w, r, owner, repo, parts[1:])
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (h *Handler) handleRefs(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	if len(parts) == 0 {
+		// POST /git/refs (create new ref)
+		if r.Method == http.MethodPost {
+			var req github.CreateRef
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+
+			// Extract branch name from ref
+			refStr := req.Ref
+			if !strings.HasPrefix(refStr, "refs/heads/") {
+				apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Only branch refs supported")
+				return
+			}
+			newBranch := strings.TrimPrefix(refStr, "refs/heads/")
+
+			err := h.queries.CreateGithubBranch(ctx, database.CreateGithubBranchParams{
+				RepoOwner: owner,
+				RepoName:  repo,
+				Name:      newBranch,
+				Sha:       req.SHA,
+				SessionID: sessionID,
+			})
+
+			if err != nil {
+				log.Printf("[github] ✗ Failed to create branch: %v", err)
+				apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Failed to create branch")
+				return
+			}
+
+			// Return a Reference response
+			response := &github.Reference{
+				Ref: &req.Ref,
+				Object: &github.GitObject{
+					SHA: &req.SHA,
+				},
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(response)
+			log.Printf("[github] ✓ Created branch %s in %s/%s", newBranch, owner, repo)
+			return
+		}
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// Handle /git/refs/heads/{branch}
+	if parts[0] == "heads" && len(parts) == 2 {
+		branchName := parts[1]
+
+		switch r.Method {
+		case http.MethodGet:
+			// GET /repos/{owner}/{repo}/git/refs/heads/{branch}
+			dbBranch, err := h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+				RepoOwner: owner,
+				RepoName:  repo,
+				Name:      branchName,
+				SessionID: sessionID,
+			})
+
+			if err != nil {
+				apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+				return
+			}
+
+			ref := &github.Reference{
+				Ref: github.Ptr("refs/heads/" + branchName),
+				Object: &github.GitObject{
+					SHA: github.Ptr(dbBranch.Sha),
+				},
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(ref)
+			log.Printf("[github] ✓ Returned ref for branch %s in %s/%s", branchName, owner, repo)
+
+		default:
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+}
+
+// handleGetTree serves GET /git/trees/{sha}. The sha names a branch head (or the branch itself),
+// whose entries are derived from the files stored on that branch, or an earlier commit or tree,
+// whose entries come from the commit's snapshot. Directories become tree entries; with
+// ?recursive set, every nested entry is listed instead of just the top level.
+func (h *Handler) handleGetTree(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if len(parts) != 1 || parts[0] == "" {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	rootSHA, files, err := h.treeFiles(ctx, owner, repo, parts[0], sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list files: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	recursive := r.URL.Query().Get("recursive") != ""
+	entries := treeEntries(files, rootSHA, recursive)
+
+	tree := &github.Tree{
+		SHA:       github.Ptr(rootSHA),
+		Entries:   entries,
+		Truncated: github.Ptr(false),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tree)
+	log.Printf("[github] ✓ Returned tree %s with %d entries for %s/%s", rootSHA, len(entries), owner, repo)
+}
+
+// treeFiles resolves a tree SHA to its root SHA and files: a branch head or name lists the
+// branch's files, and a commit or tree SHA lists the commit's snapshot
+func (h *Handler) treeFiles(ctx context.Context, owner, repo, sha, sessionID string) (string, []treeFile, error) {
+	branch, err := h.queries.GetGithubBranchBySHA(ctx, database.GetGithubBranchBySHAParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Sha:       sha,
+		SessionID: sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		var byName database.GetGithubBranchRow
+		byName, err = h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Name:      sha,
+			SessionID: sessionID,
+		})
+		branch = database.GetGithubBranchBySHARow(byName)
+	}
+	if err == nil {
+		files, err := h.queries.ListGithubFilesByBranch(ctx, database.ListGithubFilesByBranchParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Branch:    branch.Name,
+			SessionID: sessionID,
+		})
+		result := make([]treeFile, 0, len(files))
+		for i := range files {
+			result = append(result, treeFile{path: files[i].Path, sha: files[i].Sha, size: len(files[i].Content)})
+		}
+		return branch.Sha, result, err
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", nil, err
+	}
+
+	commit, err := h.queries.GetGithubCommit(ctx, database.GetGithubCommitParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Sha:       sha,
+		SessionID: sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		commit, err = h.queries.GetGithubCommitByTreeSHA(ctx, database.GetGithubCommitByTreeSHAParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			TreeSha:   sha,
+			SessionID: sessionID,
+		})
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	entries, err := h.queries.ListGithubTreeEntries(ctx, database.ListGithubTreeEntriesParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		CommitSha: commit.Sha,
+		SessionID: sessionID,
+	})
+	result := make([]treeFile, 0, len(entries))
+	for i := range entries {
+		result = append(result, treeFile{path: entries[i].Path, sha: entries[i].Sha, size: int(entries[i].Size)})
+	}
+	return commit.TreeSha, result, err
+}
+
+// treeEntries builds git tree entries for a tree's files, synthesizing a tree entry for
+// every directory. Without recursive only top-level entries are returned.
+func treeEntries(files []treeFile, rootSHA string, recursive bool) []*github.TreeEntry {
+	entries := []*github.TreeEntry{}
+	dirs := make(map[string]bool)
+
+	for i := range files {
+		file := &files[i]
+		segments := strings.Split(file.path, "/")
+		for depth := 1; depth < len(segments); depth++ {
+			dir := strings.Join(segments[:depth], "/")
+			if dirs[dir] || (!recursive && depth > 1) {
+				continue
+			}
+			dirs[dir] = true
+			entries = append(entries, &github.TreeEntry{
+				Path: github.Ptr(dir),
+				Mode: github.Ptr("040000"),
+				Type: github.Ptr("tree"),
+				SHA:  github.Ptr(generateSHA("tree:" + rootSHA + ":" + dir)),
+			})
+		}
+		if !recursive && len(segments) > 1 {
+			continue
+		}
+		entries = append(entries, &github.TreeEntry{
+			Path: github.Ptr(file.path),
+			Mode: github.Ptr("100644"),
+			Type: github.Ptr("blob"),
+			SHA:  github.Ptr(file.sha),
+			Size: github.Ptr(file.size),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].GetPath() < entries[j].GetPath()
+	})
+	return entries
+}
+
+// handleGetBlob serves GET /git/blobs/{sha}, returning a stored file's content base64-encoded
+func (h *Handler) handleGetBlob(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if len(parts) != 1 || parts[0] == "" {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	file, err := h.queries.GetGithubFileBySHA(context.Background(), database.GetGithubFileBySHAParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Sha:       parts[0],
+		SessionID: sessionID,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	blob := &github.Blob{
+		SHA:      github.Ptr(file.Sha),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(file.Content))),
+		Encoding: github.Ptr("base64"),
+		Size:     github.Ptr(len(file.Content)),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(blob)
+	log.Printf("[github] ✓ Returned blob %s (%s) from %s/%s", file.Sha, file.Path, owner, repo)
+}
+
+// Branch handlers
+
+func (h *Handler) handleBranches(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	// Branch names may contain slashes, e.g. feature/login
+	if len(parts) >= 2 && parts[len(parts)-1] == "protection" {
+		h.handleBranchProtection(w, r, owner, repo, strings.Join(parts[:len(parts)-1], "/"))
+		return
+	}
+
+	if len(parts) >= 1 && parts[0] != "" {
+		if r.Method != http.MethodGet {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		h.handleGetBranch(w, r, owner, repo, strings.Join(parts, "/"))
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	h.handleListBranches(w, r, owner, repo)
+}
+
+func (h *Handler) handleListBranches(w http.ResponseWriter, r *http.Request, owner, repo string) {
+	log.Printf("[github] → Listing branches in %s/%s", owner, repo)
+
+	perPage, page := parsePage(r)
+	dbBranches, err := h.queries.ListGithubBranches(context.Background(), database.ListGithubBranchesParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: session.FromContext(r.Context()),
+		Limit:     int64(perPage),
+		Offset:    int64((page - 1) * perPage),
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list branches: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Failed to list branches")
+		return
+	}
+
+	branches := make([]*github.Branch, 0, len(dbBranches))
+	for i := range dbBranches {
+		branches = append(branches, &github.Branch{
+			Name: github.Ptr(dbBranches[i].Name),
+			Commit: &github.RepositoryCommit{
+				SHA: github.Ptr(dbBranches[i].Sha),
+				URL: github.Ptr(fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s", owner, repo, dbBranches[i].Sha)),
+			},
+			Protected: github.Ptr(dbBranches[i].Protected != 0),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(branches)
+	log.Printf("[github] ✓ Listed %d branches in %s/%s", len(branches), owner, repo)
+}
+
+func (h *Handler) handleGetBranch(w http.ResponseWriter, r *http.Request, owner, repo, branch string) {
+	log.Printf("[github] → Getting branch %s in %s/%s", branch, owner, repo)
+
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	dbBranch, err := h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Name:      branch,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Branch %s not found in %s/%s", branch, owner, repo)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Branch not found")
+		return
+	}
+
+	// A branch is protected when a protection rule is stored for it
+	_, err = h.queries.GetGithubBranchProtection(ctx, database.GetGithubBranchProtectionParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Branch:    branch,
+		SessionID: sessionID,
+	})
+	protected := err == nil
+
+	response := &github.Branch{
+		Name: github.Ptr(dbBranch.Name),
+		Commit: &github.RepositoryCommit{
+			SHA: github.Ptr(dbBranch.Sha),
+			URL: github.Ptr(fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s", owner, repo, dbBranch.Sha)),
+		},
+		Protected: github.Ptr(protected),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[github] ✓ Returned branch %s in %s/%s (protected: %t)", branch, owner, repo, protected)
+}
+
+func (h *Handler) handleBranchProtection(w http.ResponseWriter, r *http.Request, owner, repo, branch string) {
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	switch r.Method {
+	case http.MethodGet:
+		// GET /repos/{owner}/{repo}/branches/{branch}/protection
+		dbProtection, err := h.queries.GetGithubBranchProtection(ctx, database.GetGithubBranchProtectionParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Branch:    branch,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			writeBranchNotProtected(w)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(toGithubProtection(owner, repo, branch, dbProtection))
+		log.Printf("[github] ✓ Returned protection for branch %s in %s/%s", branch, owner, repo)
+
+	case http.MethodPut:
+		// PUT /repos/{owner}/{repo}/branches/{branch}/protection
+		var req github.ProtectionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		params := database.UpsertGithubBranchProtectionParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Branch:    branch,
+			SessionID: sessionID,
+		}
+		if req.RequiredStatusChecks != nil {
+			checks := *req.RequiredStatusChecks
+			// GitHub mirrors legacy contexts into the checks list
+			if checks.Checks == nil && checks.Contexts != nil {
+				list := make([]*github.RequiredStatusCheck, 0, len(*checks.Contexts))
+				for _, c := range *checks.Contexts {
+					list = append(list, &github.RequiredStatusCheck{Context: c})
+				}
+				checks.Checks = &list
+			}
+			data, _ := json.Marshal(checks)
+			params.RequiredStatusChecks = sql.NullString{String: string(data), Valid: true}
+		}
+		if req.RequiredPullRequestReviews != nil {
+			reviews := github.PullRequestReviewsEnforcement{
+				DismissStaleReviews:          req.RequiredPullRequestReviews.DismissStaleReviews,
+				RequireCodeOwnerReviews:      req.RequiredPullRequestReviews.RequireCodeOwnerReviews,
+				RequiredApprovingReviewCount: req.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+				RequireLastPushApproval:      req.RequiredPullRequestReviews.GetRequireLastPushApproval(),
+			}
+			data, _ := json.Marshal(reviews)
+			params.RequiredReviews = sql.NullString{String: string(data), Valid: true}
+		}
+		if req.EnforceAdmins {
+			params.EnforceAdmins = 1
+		}
+
+		if err := h.queries.UpsertGithubBranchProtection(ctx, params); err != nil {
+			log.Printf("[github] ✗ Failed to update branch protection: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		dbProtection := database.GetGithubBranchProtectionRow{
+			RequiredStatusChecks: params.RequiredStatusChecks,
+			RequiredReviews:      params.RequiredReviews,
+			EnforceAdmins:        params.EnforceAdmins,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(toGithubProtection(owner, repo, branch, dbProtection))
+		log.Printf("[github] ✓ Updated protection for branch %s in %s/%s", branch, owner, repo)
+
+	case http.MethodDelete:
+		// DELETE /repos/{owner}/{repo}/branches/{branch}/protection
+		deleted, err := h.queries.DeleteGithubBranchProtection(ctx, database.DeleteGithubBranchProtectionParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Branch:    branch,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			log.Printf("[github] ✗ Failed to delete branch protection: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		if deleted == 0 {
+			writeBranchNotProtected(w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		log.Printf("[github] ✓ Removed protection for branch %s in %s/%s", branch, owner, repo)
+
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// Contents handlers
+
+func (h *Handler) handleContents(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	if len(parts) == 0 {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Path required")
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+	path := strings.Join(parts, "/")
+	branch := r.URL.Query().Get("ref")
+	if branch == "" {
+		branch = "main"
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// GET /repos/{owner}/{repo}/contents/{path}
+		dbFile, err := h.queries.GetGithubFile(ctx, database.GetGithubFileParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			Path:      path,
+			Branch:    branch,
+			SessionID: sessionID,
+		})
+
+		if err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		content := &github.RepositoryContent{
 			Type:    github.Ptr("file"),
 			Name:    github.Ptr(path),
 			Path:    github.Ptr(path),
@@ -830,329 +2377,1088 @@ func (h *Handler) handleContents(w http.ResponseWriter, r *http.Request, owner,
 			Content: github.Ptr(dbFile.Content),
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(content)
-		log.Printf("[github] ✓ Returned file %s from %s/%s@%s", path, owner, repo, branch)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(content)
+		log.Printf("[github] ✓ Returned file %s from %s/%s@%s", path, owner, repo, branch)
+
+	case http.MethodPut:
+		// PUT /repos/{owner}/{repo}/contents/{path} (create or update file)
+		var req github.RepositoryContentFileOptions
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		fileBranch := branch
+		if req.Branch != nil {
+			fileBranch = *req.Branch
+		}
+
+		content := string(req.Content)
+		sha := generateSHA(content)
+
+		author := req.Author
+		if author == nil {
+			author = req.Committer
+		}
+		var parentSHA string
+		var commit database.GithubCommit
+		err := h.queries.ExecTx(ctx, func(q *database.Queries) error {
+			// Every write is a commit on top of the branch's head as of this transaction
+			parentSHA = branchHead(ctx, q, owner, repo, fileBranch, sessionID)
+			err := q.CreateOrUpdateGithubFile(ctx, database.CreateOrUpdateGithubFileParams{
+				RepoOwner: owner,
+				RepoName:  repo,
+				Path:      path,
+				Content:   content,
+				Sha:       sha,
+				Branch:    fileBranch,
+				SessionID: sessionID,
+			})
+			if err != nil {
+				return err
+			}
+			commit, err = commitBranch(ctx, q, owner, repo, fileBranch, parentSHA, req.GetMessage(), author, sessionID)
+			return err
+		})
+
+		if err != nil {
+			log.Printf("[github] ✗ Failed to create/update file: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		response := &github.RepositoryContentResponse{
+			Content: &github.RepositoryContent{
+				Name: github.Ptr(path),
+				Path: github.Ptr(path),
+				SHA:  github.Ptr(sha),
+			},
+			Commit: *toGithubRepositoryCommit(&commit).Commit,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(response)
+		log.Printf("[github] ✓ Created/updated file %s in %s/%s@%s", path, owner, repo, fileBranch)
+
+		h.emitPushEvent(r.Context(), owner, repo, fileBranch, parentSHA, commit.Sha, req.GetMessage())
+
+	case http.MethodDelete:
+		h.handleDeleteContents(w, r, owner, repo, path, branch)
+
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handleDeleteContents handles DELETE /repos/{owner}/{repo}/contents/{path}, removing the file
+// from the branch as a new commit
+func (h *Handler) handleDeleteContents(w http.ResponseWriter, r *http.Request, owner, repo, path, branch string) {
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	var req github.RepositoryContentFileOptions
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	fileBranch := branch
+	if req.Branch != nil {
+		fileBranch = *req.Branch
+	}
+
+	fileParams := database.GetGithubFileParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Path:      path,
+		Branch:    fileBranch,
+		SessionID: sessionID,
+	}
+	dbFile, err := h.queries.GetGithubFile(ctx, fileParams)
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+	if req.SHA != nil && *req.SHA != dbFile.Sha {
+		apierror.Write(w, apierror.GitHub, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, *req.SHA))
+		return
+	}
+
+	author := req.Author
+	if author == nil {
+		author = req.Committer
+	}
+	var parentSHA string
+	var commit database.GithubCommit
+	err = h.queries.ExecTx(ctx, func(q *database.Queries) error {
+		parentSHA = branchHead(ctx, q, owner, repo, fileBranch, sessionID)
+		_, err := q.DeleteGithubFile(ctx, database.DeleteGithubFileParams(fileParams))
+		if err != nil {
+			return err
+		}
+		commit, err = commitBranch(ctx, q, owner, repo, fileBranch, parentSHA, req.GetMessage(), author, sessionID)
+		return err
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to delete file: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := &github.RepositoryContentResponse{
+		Commit: *toGithubRepositoryCommit(&commit).Commit,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[github] ✓ Deleted file %s in %s/%s@%s", path, owner, repo, fileBranch)
+
+	h.emitPushEvent(r.Context(), owner, repo, fileBranch, parentSHA, commit.Sha, req.GetMessage())
+}
+
+// Actions handlers (workflows and runs)
+
+func (h *Handler) handleActions(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	if len(parts) < 1 {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch parts[0] {
+	case "workflows":
+		h.handleWorkflows(w, r, owner, repo, parts[1:])
+	case "runs":
+		h.handleWorkflowRuns(w, r, owner, repo, parts[1:])
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (h *Handler) handleWorkflows(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	if len(parts) == 0 {
+		// GET /repos/{owner}/{repo}/actions/workflows
+		if r.Method != http.MethodGet {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		dbWorkflows, err := h.queries.ListGithubWorkflows(ctx, database.ListGithubWorkflowsParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			SessionID: sessionID,
+		})
+
+		if err != nil {
+			log.Printf("[github] ✗ Failed to list workflows: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		workflows := make([]*github.Workflow, 0, len(dbWorkflows))
+		for _, dbWf := range dbWorkflows {
+			wf := &github.Workflow{
+				ID:        github.Ptr(dbWf.WorkflowID),
+				Name:      github.Ptr(dbWf.Name),
+				Path:      github.Ptr(dbWf.Path),
+				State:     github.Ptr(dbWf.State),
+				CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbWf.CreatedAt, 0)}),
+			}
+			workflows = append(workflows, wf)
+		}
+
+		response := &github.Workflows{
+			TotalCount: github.Ptr(len(workflows)),
+			Workflows:  workflows,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+		log.Printf("[github] ✓ Listed %d workflows for %s/%s", len(workflows), owner, repo)
+		return
+	}
+
+	// Handle specific workflow by ID
+	workflowID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid workflow ID")
+		return
+	}
+
+	if len(parts) == 1 {
+		// GET /repos/{owner}/{repo}/actions/workflows/{id}
+		if r.Method != http.MethodGet {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		dbWf, err := h.queries.GetGithubWorkflow(ctx, database.GetGithubWorkflowParams{
+			RepoOwner:  owner,
+			RepoName:   repo,
+			WorkflowID: workflowID,
+			SessionID:  sessionID,
+		})
+
+		if err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		wf := &github.Workflow{
+			ID:        github.Ptr(dbWf.WorkflowID),
+			Name:      github.Ptr(dbWf.Name),
+			Path:      github.Ptr(dbWf.Path),
+			State:     github.Ptr(dbWf.State),
+			CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbWf.CreatedAt, 0)}),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(wf)
+		log.Printf("[github] ✓ Returned workflow %d for %s/%s", workflowID, owner, repo)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "dispatches" {
+		// POST /repos/{owner}/{repo}/actions/workflows/{id}/dispatches
+		if r.Method != http.MethodPost {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req github.CreateWorkflowDispatchEventRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		// Get next run ID
+		nextRunID, err := h.queries.GetNextWorkflowRunID(ctx, database.GetNextWorkflowRunIDParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			log.Printf("[github] ✗ Failed to get next run ID: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		// Create workflow run
+		_, err = h.queries.CreateGithubWorkflowRun(ctx, database.CreateGithubWorkflowRunParams{
+			RepoOwner:  owner,
+			RepoName:   repo,
+			RunID:      nextRunID,
+			WorkflowID: workflowID,
+			Status:     "completed",
+			Conclusion: sql.NullString{String: "success", Valid: true},
+			HeadBranch: sql.NullString{String: req.Ref, Valid: true},
+			HeadSha:    sql.NullString{String: generateSHA(req.Ref), Valid: true},
+			SessionID:  sessionID,
+		})
+
+		if err != nil {
+			log.Printf("[github] ✗ Failed to create workflow run: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		log.Printf("[github] ✓ Triggered workflow %d for %s/%s", workflowID, owner, repo)
+		return
+	}
+
+	apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+}
+
+func (h *Handler) handleWorkflowRuns(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
+
+	if len(parts) == 0 {
+		// GET /repos/{owner}/{repo}/actions/runs
+		if r.Method != http.MethodGet {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		dbRuns, err := h.queries.ListGithubWorkflowRuns(ctx, database.ListGithubWorkflowRunsParams{
+			RepoOwner: owner,
+			RepoName:  repo,
+			SessionID: sessionID,
+		})
 
-	case http.MethodPut:
-		// PUT /repos/{owner}/{repo}/contents/{path} (create or update file)
-		var req github.RepositoryContentFileOptions
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+		if err != nil {
+			log.Printf("[github] ✗ Failed to list workflow runs: %v", err)
+			apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 
-		fileBranch := branch
-		if req.Branch != nil {
-			fileBranch = *req.Branch
+		runs := make([]*github.WorkflowRun, 0, len(dbRuns))
+		for i := range dbRuns {
+			run := &github.WorkflowRun{
+				ID:         github.Ptr(dbRuns[i].RunID),
+				WorkflowID: github.Ptr(dbRuns[i].WorkflowID),
+				Status:     github.Ptr(dbRuns[i].Status),
+				CreatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRuns[i].CreatedAt, 0)}),
+				UpdatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRuns[i].UpdatedAt, 0)}),
+			}
+			if dbRuns[i].Conclusion.Valid {
+				run.Conclusion = github.Ptr(dbRuns[i].Conclusion.String)
+			}
+			if dbRuns[i].HeadBranch.Valid {
+				run.HeadBranch = github.Ptr(dbRuns[i].HeadBranch.String)
+			}
+			if dbRuns[i].HeadSha.Valid {
+				run.HeadSHA = github.Ptr(dbRuns[i].HeadSha.String)
+			}
+			runs = append(runs, run)
 		}
 
-		content := string(req.Content)
-		sha := generateSHA(content)
+		response := &github.WorkflowRuns{
+			TotalCount:   github.Ptr(len(runs)),
+			WorkflowRuns: paginate(w, r, runs),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+		log.Printf("[github] ✓ Listed %d workflow runs for %s/%s", len(runs), owner, repo)
+		return
+	}
+
+	// Handle specific run by ID
+	runID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid run ID")
+		return
+	}
+
+	if len(parts) == 1 {
+		// GET /repos/{owner}/{repo}/actions/runs/{id}
+		if r.Method != http.MethodGet {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
 
-		err := h.queries.CreateOrUpdateGithubFile(ctx, database.CreateOrUpdateGithubFileParams{
+		dbRun, err := h.queries.GetGithubWorkflowRun(ctx, database.GetGithubWorkflowRunParams{
 			RepoOwner: owner,
 			RepoName:  repo,
-			Path:      path,
-			Content:   content,
-			Sha:       sha,
-			Branch:    fileBranch,
+			RunID:     runID,
 			SessionID: sessionID,
 		})
 
 		if err != nil {
-			log.Printf("[github] ✗ Failed to create/update file: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 			return
 		}
 
-		response := &github.RepositoryContentResponse{
-			Content: &github.RepositoryContent{
-				Name: github.Ptr(path),
-				Path: github.Ptr(path),
-				SHA:  github.Ptr(sha),
-			},
-			Commit: github.Commit{
-				SHA: github.Ptr(sha),
-			},
+		run := &github.WorkflowRun{
+			ID:         github.Ptr(dbRun.RunID),
+			WorkflowID: github.Ptr(dbRun.WorkflowID),
+			Status:     github.Ptr(dbRun.Status),
+			CreatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRun.CreatedAt, 0)}),
+			UpdatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRun.UpdatedAt, 0)}),
+		}
+		if dbRun.Conclusion.Valid {
+			run.Conclusion = github.Ptr(dbRun.Conclusion.String)
+		}
+		if dbRun.HeadBranch.Valid {
+			run.HeadBranch = github.Ptr(dbRun.HeadBranch.String)
+		}
+		if dbRun.HeadSha.Valid {
+			run.HeadSHA = github.Ptr(dbRun.HeadSha.String)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusCreated)
-		_ = json.NewEncoder(w).Encode(response)
-		log.Printf("[github] ✓ Created/updated file %s in %s/%s@%s", path, owner, repo, fileBranch)
+		_ = json.NewEncoder(w).Encode(run)
+		log.Printf("[github] ✓ Returned workflow run %d for %s/%s", runID, owner, repo)
+		return
+	}
+
+	apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+}
+
+// Check run handlers
+
+func (h *Handler) handleCheckRuns(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	sessionID := session.FromContext(r.Context())
 
+	if len(parts) == 0 {
+		// POST /repos/{owner}/{repo}/check-runs
+		if r.Method != http.MethodPost {
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		h.handleCreateCheckRun(w, r, owner, repo, sessionID)
+		return
+	}
+
+	checkRunID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || len(parts) > 1 {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetCheckRun(w, owner, repo, checkRunID, sessionID)
+	case http.MethodPatch:
+		h.handleUpdateCheckRun(w, r, owner, repo, checkRunID, sessionID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
-// Actions handlers (workflows and runs)
+func (h *Handler) handleCommits(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	sessionID := session.FromContext(r.Context())
 
-func (h *Handler) handleActions(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	if len(parts) < 1 {
-		http.NotFound(w, r)
+	switch {
+	case len(parts) == 0 || parts[0] == "":
+		// GET /repos/{owner}/{repo}/commits
+		h.handleListCommits(w, r, owner, repo, sessionID)
+	case len(parts) >= 2 && parts[len(parts)-1] == "check-runs":
+		// GET /repos/{owner}/{repo}/commits/{ref}/check-runs, where ref may be heads/{branch} or tags/{tag}
+		h.handleListCheckRunsForRef(w, r, owner, repo, strings.Join(parts[:len(parts)-1], "/"), sessionID)
+	default:
+		// GET /repos/{owner}/{repo}/commits/{ref}
+		h.handleGetCommit(w, owner, repo, strings.Join(parts, "/"), sessionID)
+	}
+}
+
+func (h *Handler) handleCreateCheckRun(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
+	ctx := context.Background()
+
+	var req github.CreateCheckRunOptions
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		writeValidationFailed(w, "CheckRun", "name", "missing_field")
+		return
+	}
+	if req.HeadSHA == "" {
+		writeValidationFailed(w, "CheckRun", "head_sha", "missing_field")
 		return
 	}
 
-	switch parts[0] {
-	case "workflows":
-		h.handleWorkflows(w, r, owner, repo, parts[1:])
-	case "runs":
-		h.handleWorkflowRuns(w, r, owner, repo, parts[1:])
+	status := "queued"
+	if req.Status != nil {
+		status = *req.Status
+	}
+	conclusion := nullString(req.Conclusion)
+	// Providing a conclusion automatically completes the check run
+	if conclusion.Valid {
+		status = "completed"
+	}
+	if field, code := validateCheckRun(status, conclusion); field != "" {
+		writeValidationFailed(w, "CheckRun", field, code)
+		return
+	}
+
+	now := session.Now(sessionID)
+	startedAt := sql.NullInt64{Int64: now.Unix(), Valid: true}
+	if req.StartedAt != nil {
+		startedAt.Int64 = req.StartedAt.Unix()
+	}
+	completedAt := sql.NullInt64{}
+	if status == "completed" {
+		completedAt = sql.NullInt64{Int64: now.Unix(), Valid: true}
+		if req.CompletedAt != nil {
+			completedAt.Int64 = req.CompletedAt.Unix()
+		}
+	}
+
+	var outputTitle, outputSummary sql.NullString
+	if req.Output != nil {
+		outputTitle = nullString(req.Output.Title)
+		outputSummary = nullString(req.Output.Summary)
+	}
+
+	nextID, err := h.queries.GetNextCheckRunID(ctx, database.GetNextCheckRunIDParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to get next check run ID: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	dbRun, err := h.queries.CreateGithubCheckRun(ctx, database.CreateGithubCheckRunParams{
+		RepoOwner:     owner,
+		RepoName:      repo,
+		CheckRunID:    nextID,
+		Name:          req.Name,
+		HeadSha:       req.HeadSHA,
+		Status:        status,
+		Conclusion:    conclusion,
+		ExternalID:    nullString(req.ExternalID),
+		DetailsUrl:    nullString(req.DetailsURL),
+		OutputTitle:   outputTitle,
+		OutputSummary: outputSummary,
+		StartedAt:     startedAt,
+		CompletedAt:   completedAt,
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to create check run: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(checkRunFromDB(owner, repo, &dbRun))
+	log.Printf("[github] ✓ Created check run %d (%s) for %s/%s@%s", dbRun.CheckRunID, dbRun.Name, owner, repo, dbRun.HeadSha)
+}
+
+func (h *Handler) handleGetCheckRun(w http.ResponseWriter, owner, repo string, checkRunID int64, sessionID string) {
+	dbRun, err := h.queries.GetGithubCheckRun(context.Background(), database.GetGithubCheckRunParams{
+		RepoOwner:  owner,
+		RepoName:   repo,
+		CheckRunID: checkRunID,
+		SessionID:  sessionID,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(checkRunFromDB(owner, repo, &dbRun))
+	log.Printf("[github] ✓ Returned check run %d for %s/%s", checkRunID, owner, repo)
+}
+
+func (h *Handler) handleUpdateCheckRun(w http.ResponseWriter, r *http.Request, owner, repo string, checkRunID int64, sessionID string) {
+	ctx := context.Background()
+	params := database.GetGithubCheckRunParams{
+		RepoOwner:  owner,
+		RepoName:   repo,
+		CheckRunID: checkRunID,
+		SessionID:  sessionID,
+	}
+
+	dbRun, err := h.queries.GetGithubCheckRun(ctx, params)
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var req github.UpdateCheckRunOptions
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	update := database.UpdateGithubCheckRunParams{
+		Name:          dbRun.Name,
+		Status:        dbRun.Status,
+		Conclusion:    dbRun.Conclusion,
+		ExternalID:    dbRun.ExternalID,
+		DetailsUrl:    dbRun.DetailsUrl,
+		OutputTitle:   dbRun.OutputTitle,
+		OutputSummary: dbRun.OutputSummary,
+		CompletedAt:   dbRun.CompletedAt,
+		RepoOwner:     owner,
+		RepoName:      repo,
+		CheckRunID:    checkRunID,
+		SessionID:     sessionID,
+	}
+	if req.Name != "" {
+		update.Name = req.Name
+	}
+	if req.Status != nil {
+		update.Status = *req.Status
+	}
+	if req.Conclusion != nil {
+		update.Conclusion = nullString(req.Conclusion)
+		update.Status = "completed"
+	}
+	if req.ExternalID != nil {
+		update.ExternalID = nullString(req.ExternalID)
+	}
+	if req.DetailsURL != nil {
+		update.DetailsUrl = nullString(req.DetailsURL)
+	}
+	if req.Output != nil {
+		update.OutputTitle = nullString(req.Output.Title)
+		update.OutputSummary = nullString(req.Output.Summary)
+	}
+	if field, code := validateCheckRun(update.Status, update.Conclusion); field != "" {
+		writeValidationFailed(w, "CheckRun", field, code)
+		return
+	}
+	if update.Status == "completed" && !update.CompletedAt.Valid {
+		update.CompletedAt = sql.NullInt64{Int64: session.Now(sessionID).Unix(), Valid: true}
+	}
+	if req.CompletedAt != nil {
+		update.CompletedAt = sql.NullInt64{Int64: req.CompletedAt.Unix(), Valid: true}
+	}
+
+	if err := h.queries.UpdateGithubCheckRun(ctx, update); err != nil {
+		log.Printf("[github] ✗ Failed to update check run: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	dbRun, err = h.queries.GetGithubCheckRun(ctx, params)
+	if err != nil {
+		log.Printf("[github] ✗ Failed to reload check run: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(checkRunFromDB(owner, repo, &dbRun))
+	log.Printf("[github] ✓ Updated check run %d for %s/%s", checkRunID, owner, repo)
+}
+
+func (h *Handler) handleListCheckRunsForRef(w http.ResponseWriter, r *http.Request, owner, repo, ref, sessionID string) {
+	ctx := context.Background()
+	query := r.URL.Query()
+
+	// Branch names resolve to their head commit; anything else is treated as a commit SHA
+	sha := ref
+	branch := strings.TrimPrefix(ref, "heads/")
+	dbBranch, err := h.queries.GetGithubBranch(ctx, database.GetGithubBranchParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Name:      branch,
+		SessionID: sessionID,
+	})
+	if err == nil {
+		sha = dbBranch.Sha
+	}
+
+	dbRuns, err := h.queries.ListGithubCheckRunsForSHA(ctx, database.ListGithubCheckRunsForSHAParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		HeadSha:   sha,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list check runs: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// filter=latest (the default) keeps only the most recent run of each check name
+	latestOnly := query.Get("filter") != "all"
+	checkName := query.Get("check_name")
+	status := query.Get("status")
+	seen := make(map[string]bool)
+
+	runs := make([]*github.CheckRun, 0, len(dbRuns))
+	for i := range dbRuns {
+		if checkName != "" && dbRuns[i].Name != checkName {
+			continue
+		}
+		if latestOnly {
+			if seen[dbRuns[i].Name] {
+				continue
+			}
+			seen[dbRuns[i].Name] = true
+		}
+		if status != "" && dbRuns[i].Status != status {
+			continue
+		}
+		runs = append(runs, checkRunFromDB(owner, repo, &dbRuns[i]))
+	}
+
+	response := &github.ListCheckRunsResults{
+		Total:     github.Ptr(len(runs)),
+		CheckRuns: runs,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[github] ✓ Listed %d check runs for %s/%s@%s", len(runs), owner, repo, ref)
+}
+
+// validateCheckRun returns the offending field and error code when a check run's
+// status and conclusion are not a combination GitHub accepts
+func validateCheckRun(status string, conclusion sql.NullString) (field, code string) {
+	switch {
+	case !validCheckRunStatuses[status]:
+		return "status", "invalid"
+	case conclusion.Valid && !validCheckRunConclusions[conclusion.String]:
+		return "conclusion", "invalid"
+	case status == "completed" && !conclusion.Valid:
+		return "conclusion", "missing_field"
+	}
+	return "", ""
+}
+
+func checkRunFromDB(owner, repo string, dbRun *database.GithubCheckRun) *github.CheckRun {
+	run := &github.CheckRun{
+		ID:      github.Ptr(dbRun.CheckRunID),
+		HeadSHA: github.Ptr(dbRun.HeadSha),
+		URL:     github.Ptr(fmt.Sprintf("https://api.github.com/repos/%s/%s/check-runs/%d", owner, repo, dbRun.CheckRunID)),
+		HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/%s/%s/runs/%d", owner, repo, dbRun.CheckRunID)),
+		Name:    github.Ptr(dbRun.Name),
+		Status:  github.Ptr(dbRun.Status),
+		Output: &github.CheckRunOutput{
+			AnnotationsCount: github.Ptr(0),
+		},
+	}
+	if dbRun.Conclusion.Valid {
+		run.Conclusion = github.Ptr(dbRun.Conclusion.String)
+	}
+	if dbRun.ExternalID.Valid {
+		run.ExternalID = github.Ptr(dbRun.ExternalID.String)
+	}
+	if dbRun.DetailsUrl.Valid {
+		run.DetailsURL = github.Ptr(dbRun.DetailsUrl.String)
+	}
+	if dbRun.OutputTitle.Valid {
+		run.Output.Title = github.Ptr(dbRun.OutputTitle.String)
+	}
+	if dbRun.OutputSummary.Valid {
+		run.Output.Summary = github.Ptr(dbRun.OutputSummary.String)
+	}
+	if dbRun.StartedAt.Valid {
+		run.StartedAt = github.Ptr(github.Timestamp{Time: time.Unix(dbRun.StartedAt.Int64, 0)})
+	}
+	if dbRun.CompletedAt.Valid {
+		run.CompletedAt = github.Ptr(github.Timestamp{Time: time.Unix(dbRun.CompletedAt.Int64, 0)})
+	}
+	return run
+}
+
+// Helper functions
+
+// Gist handlers
+
+func (h *Handler) handleGists(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		// POST /gists
+		h.handleCreateGist(w, r)
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		// GET /gists
+		h.handleListGists(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		// GET /gists/{gist_id}
+		h.handleGetGist(w, r, parts[0])
+	case len(parts) <= 1:
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
 	default:
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
 	}
 }
 
-func (h *Handler) handleWorkflows(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	sessionID := session.FromContext(r.Context())
-	ctx := context.Background()
+func (h *Handler) handleCreateGist(w http.ResponseWriter, r *http.Request) {
+	log.Println("[github] → Creating gist")
 
-	if len(parts) == 0 {
-		// GET /repos/{owner}/{repo}/actions/workflows
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
+	var req github.Gist
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// GitHub requires at least one file, and every file needs content
+	files := make(map[string]string, len(req.Files))
+	for name, file := range req.Files {
+		if file.GetContent() == "" {
+			files = nil
+			break
 		}
+		files[string(name)] = file.GetContent()
+	}
+	if len(files) == 0 {
+		writeValidationFailed(w, "Gist", "files", "missing_field")
+		return
+	}
 
-		dbWorkflows, err := h.queries.ListGithubWorkflows(ctx, database.ListGithubWorkflowsParams{
-			RepoOwner: owner,
-			RepoName:  repo,
-			SessionID: sessionID,
-		})
+	filesJSON, err := json.Marshal(files)
+	if err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
 
-		if err != nil {
-			log.Printf("[github] ✗ Failed to list workflows: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
+	sessionID := session.FromContext(r.Context())
+	ctx := context.Background()
 
-		workflows := make([]*github.Workflow, 0, len(dbWorkflows))
-		for _, dbWf := range dbWorkflows {
-			wf := &github.Workflow{
-				ID:        github.Ptr(dbWf.WorkflowID),
-				Name:      github.Ptr(dbWf.Name),
-				Path:      github.Ptr(dbWf.Path),
-				State:     github.Ptr(dbWf.State),
-				CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbWf.CreatedAt, 0)}),
-			}
-			workflows = append(workflows, wf)
-		}
+	idBytes := make([]byte, 16)
+	session.RandomBytes(sessionID, idBytes)
+	gistID := hex.EncodeToString(idBytes)
 
-		response := &github.Workflows{
-			TotalCount: github.Ptr(len(workflows)),
-			Workflows:  workflows,
-		}
+	var description sql.NullString
+	if req.Description != nil {
+		description = sql.NullString{String: *req.Description, Valid: true}
+	}
+	public := int64(0)
+	if req.GetPublic() {
+		public = 1
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(response)
-		log.Printf("[github] ✓ Listed %d workflows for %s/%s", len(workflows), owner, repo)
+	err = h.queries.CreateGithubGist(ctx, database.CreateGithubGistParams{
+		ID:          gistID,
+		Description: description,
+		Public:      public,
+		Files:       string(filesJSON),
+		OwnerLogin:  authenticatedUserLogin,
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to create gist: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	// Handle specific workflow by ID
-	workflowID, err := strconv.ParseInt(parts[0], 10, 64)
+	dbGist, err := h.queries.GetGithubGist(ctx, database.GetGithubGistParams{
+		ID:        gistID,
+		SessionID: sessionID,
+	})
 	if err != nil {
-		http.Error(w, "Invalid workflow ID", http.StatusBadRequest)
+		log.Printf("[github] ✗ Failed to get created gist: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	if len(parts) == 1 {
-		// GET /repos/{owner}/{repo}/actions/workflows/{id}
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toGithubGist(database.ListGithubGistsRow(dbGist)))
+	log.Printf("[github] ✓ Created gist %s with %d files", gistID, len(files))
+}
 
-		dbWf, err := h.queries.GetGithubWorkflow(ctx, database.GetGithubWorkflowParams{
-			RepoOwner:  owner,
-			RepoName:   repo,
-			WorkflowID: workflowID,
-			SessionID:  sessionID,
-		})
+func (h *Handler) handleGetGist(w http.ResponseWriter, r *http.Request, gistID string) {
+	log.Printf("[github] → Getting gist %s", gistID)
 
-		if err != nil {
-			http.NotFound(w, r)
-			return
-		}
+	sessionID := session.FromContext(r.Context())
+	dbGist, err := h.queries.GetGithubGist(context.Background(), database.GetGithubGistParams{
+		ID:        gistID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Gist %s not found", gistID)
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
 
-		wf := &github.Workflow{
-			ID:        github.Ptr(dbWf.WorkflowID),
-			Name:      github.Ptr(dbWf.Name),
-			Path:      github.Ptr(dbWf.Path),
-			State:     github.Ptr(dbWf.State),
-			CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbWf.CreatedAt, 0)}),
-		}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toGithubGist(database.ListGithubGistsRow(dbGist)))
+	log.Printf("[github] ✓ Returned gist %s", gistID)
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(wf)
-		log.Printf("[github] ✓ Returned workflow %d for %s/%s", workflowID, owner, repo)
+func (h *Handler) handleListGists(w http.ResponseWriter, r *http.Request) {
+	log.Println("[github] → Listing gists")
+
+	sessionID := session.FromContext(r.Context())
+	dbGists, err := h.queries.ListGithubGists(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list gists: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	if len(parts) == 2 && parts[1] == "dispatches" {
-		// POST /repos/{owner}/{repo}/actions/workflows/{id}/dispatches
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+	gists := make([]*github.Gist, 0, len(dbGists))
+	for _, dbGist := range dbGists {
+		gists = append(gists, toGithubGist(dbGist))
+	}
+	gists = paginate(w, r, gists)
 
-		var req github.CreateWorkflowDispatchEventRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
-			return
-		}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(gists)
+	log.Printf("[github] ✓ Listed %d gists", len(gists))
+}
 
-		// Get next run ID
-		nextRunID, err := h.queries.GetNextWorkflowRunID(ctx, database.GetNextWorkflowRunIDParams{
-			RepoOwner: owner,
-			RepoName:  repo,
-			SessionID: sessionID,
-		})
-		if err != nil {
-			log.Printf("[github] ✗ Failed to get next run ID: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
+// toGithubGist converts a stored gist to the API shape
+func toGithubGist(dbGist database.ListGithubGistsRow) *github.Gist {
+	var contents map[string]string
+	_ = json.Unmarshal([]byte(dbGist.Files), &contents)
+
+	files := make(map[github.GistFilename]github.GistFile, len(contents))
+	for name, content := range contents {
+		files[github.GistFilename(name)] = github.GistFile{
+			Filename: github.Ptr(name),
+			Content:  github.Ptr(content),
+			Size:     github.Ptr(len(content)),
+			Type:     github.Ptr("text/plain"),
+			RawURL:   github.Ptr(fmt.Sprintf("https://gist.githubusercontent.com/%s/%s/raw/%s", dbGist.OwnerLogin, dbGist.ID, name)),
 		}
+	}
 
-		// Create workflow run
-		_, err = h.queries.CreateGithubWorkflowRun(ctx, database.CreateGithubWorkflowRunParams{
-			RepoOwner:  owner,
-			RepoName:   repo,
-			RunID:      nextRunID,
-			WorkflowID: workflowID,
-			Status:     "completed",
-			Conclusion: sql.NullString{String: "success", Valid: true},
-			HeadBranch: sql.NullString{String: req.Ref, Valid: true},
-			HeadSha:    sql.NullString{String: generateSHA(req.Ref), Valid: true},
-			SessionID:  sessionID,
-		})
-
-		if err != nil {
-			log.Printf("[github] ✗ Failed to create workflow run: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
+	gist := &github.Gist{
+		ID:         github.Ptr(dbGist.ID),
+		Public:     github.Ptr(dbGist.Public == 1),
+		Owner:      &github.User{Login: github.Ptr(dbGist.OwnerLogin)},
+		Files:      files,
+		Comments:   github.Ptr(0),
+		HTMLURL:    github.Ptr("https://gist.github.com/" + dbGist.ID),
+		GitPullURL: github.Ptr("https://gist.github.com/" + dbGist.ID + ".git"),
+		GitPushURL: github.Ptr("https://gist.github.com/" + dbGist.ID + ".git"),
+		CreatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbGist.CreatedAt, 0)}),
+		UpdatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbGist.UpdatedAt, 0)}),
+	}
+	if dbGist.Description.Valid {
+		gist.Description = github.Ptr(dbGist.Description.String)
+	}
+	return gist
+}
 
-		w.WriteHeader(http.StatusNoContent)
-		log.Printf("[github] ✓ Triggered workflow %d for %s/%s", workflowID, owner, repo)
-		return
+func toGithubReaction(id int64, userLogin, content string, createdAt int64) *github.Reaction {
+	return &github.Reaction{
+		ID:        github.Ptr(id),
+		User:      &github.User{Login: github.Ptr(userLogin)},
+		Content:   github.Ptr(content),
+		CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(createdAt, 0)}),
 	}
+}
 
-	http.NotFound(w, r)
+func generateSHA(content string) string {
+	hash := sha1.Sum([]byte(content)) //nolint:gosec // Used for generating fake SHAs in simulator, not for security
+	return fmt.Sprintf("%x", hash)
 }
 
-func (h *Handler) handleWorkflowRuns(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
-	sessionID := session.FromContext(r.Context())
-	ctx := context.Background()
+// nullString converts an optional request field to a nullable column value
+func nullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
 
-	if len(parts) == 0 {
-		// GET /repos/{owner}/{repo}/actions/runs
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+// parseSince reads the RFC3339 since parameter as a unix timestamp. Timestamps are stored with
+// second precision, so since is truncated to the second; a missing since matches everything.
+func parseSince(r *http.Request) (int64, bool) {
+	raw := r.URL.Query().Get("since")
+	if raw == "" {
+		return 0, true
+	}
+	since, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return 0, false
+	}
+	return since.Unix(), true
+}
 
-		dbRuns, err := h.queries.ListGithubWorkflowRuns(ctx, database.ListGithubWorkflowRunsParams{
-			RepoOwner: owner,
-			RepoName:  repo,
-			SessionID: sessionID,
-		})
+// parsePage reads the per_page and page parameters of a list request. Like GitHub, per_page
+// defaults to 30 and is capped at 100, and pages are numbered from 1.
+func parsePage(r *http.Request) (perPage, page int) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 30
+	}
+	perPage = min(perPage, 100)
 
-		if err != nil {
-			log.Printf("[github] ✗ Failed to list workflow runs: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
-		}
+	page, err = strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	return perPage, page
+}
 
-		runs := make([]*github.WorkflowRun, 0, len(dbRuns))
-		for i := range dbRuns {
-			run := &github.WorkflowRun{
-				ID:         github.Ptr(dbRuns[i].RunID),
-				WorkflowID: github.Ptr(dbRuns[i].WorkflowID),
-				Status:     github.Ptr(dbRuns[i].Status),
-				CreatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRuns[i].CreatedAt, 0)}),
-				UpdatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRuns[i].UpdatedAt, 0)}),
-			}
-			if dbRuns[i].Conclusion.Valid {
-				run.Conclusion = github.Ptr(dbRuns[i].Conclusion.String)
-			}
-			if dbRuns[i].HeadBranch.Valid {
-				run.HeadBranch = github.Ptr(dbRuns[i].HeadBranch.String)
-			}
-			if dbRuns[i].HeadSha.Valid {
-				run.HeadSHA = github.Ptr(dbRuns[i].HeadSha.String)
-			}
-			runs = append(runs, run)
-		}
+// repoKind names objects numbered within a repository, such as issues, for session tombstones
+func repoKind(kind, owner, repo string) string {
+	return kind + ":" + owner + "/" + repo
+}
 
-		response := &github.WorkflowRuns{
-			TotalCount:   github.Ptr(len(runs)),
-			WorkflowRuns: runs,
+// paginate returns the page of items requested by per_page and page, setting a Link header
+// with the neighbouring and boundary pages the way GitHub does. Pages larger than the list cap
+// are shrunk to it, so the rest of the list stays reachable through the next link.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) []T {
+	perPage, page := parsePage(r)
+	perPage, clamped := listcap.Clamp(perPage)
+	lastPage := max(1, (len(items)+perPage-1)/perPage)
+
+	var links []string
+	if page < lastPage {
+		links = append(links, pageLink(r, page+1, "next"), pageLink(r, lastPage, "last"))
+		if clamped {
+			listcap.MarkTruncated(w)
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(response)
-		log.Printf("[github] ✓ Listed %d workflow runs for %s/%s", len(runs), owner, repo)
-		return
 	}
-
-	// Handle specific run by ID
-	runID, err := strconv.ParseInt(parts[0], 10, 64)
-	if err != nil {
-		http.Error(w, "Invalid run ID", http.StatusBadRequest)
-		return
+	if page > 1 {
+		links = append(links, pageLink(r, 1, "first"), pageLink(r, min(page-1, lastPage), "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
 	}
 
-	if len(parts) == 1 {
-		// GET /repos/{owner}/{repo}/actions/runs/{id}
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	return items[start:end]
+}
 
-		dbRun, err := h.queries.GetGithubWorkflowRun(ctx, database.GetGithubWorkflowRunParams{
-			RepoOwner: owner,
-			RepoName:  repo,
-			RunID:     runID,
-			SessionID: sessionID,
-		})
+// pageLink formats one Link header entry: the request's own URL with page replaced
+func pageLink(r *http.Request, page int, rel string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	path := r.URL.Path
+	if r.RequestURI != "" {
+		path, _, _ = strings.Cut(r.RequestURI, "?")
+	}
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	return fmt.Sprintf("<%s://%s%s?%s>; rel=%q", scheme, r.Host, path, query.Encode(), rel)
+}
 
-		if err != nil {
-			http.NotFound(w, r)
-			return
-		}
+// boolToInt converts a bool to the 0/1 integer stored in SQLite
+func boolToInt(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
 
-		run := &github.WorkflowRun{
-			ID:         github.Ptr(dbRun.RunID),
-			WorkflowID: github.Ptr(dbRun.WorkflowID),
-			Status:     github.Ptr(dbRun.Status),
-			CreatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRun.CreatedAt, 0)}),
-			UpdatedAt:  github.Ptr(github.Timestamp{Time: time.Unix(dbRun.UpdatedAt, 0)}),
-		}
-		if dbRun.Conclusion.Valid {
-			run.Conclusion = github.Ptr(dbRun.Conclusion.String)
-		}
-		if dbRun.HeadBranch.Valid {
-			run.HeadBranch = github.Ptr(dbRun.HeadBranch.String)
+// writeValidationFailed writes GitHub's 422 response for a single invalid field
+func writeValidationFailed(w http.ResponseWriter, resource, field, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"message": "Validation Failed",
+		"errors": []map[string]string{
+			{"resource": resource, "field": field, "code": code},
+		},
+	})
+}
+
+// toGithubProtection converts a stored branch protection row to the API shape
+func toGithubProtection(owner, repo, branch string, dbProtection database.GetGithubBranchProtectionRow) *github.Protection {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/branches/%s/protection", owner, repo, branch)
+	protection := &github.Protection{
+		EnforceAdmins: &github.AdminEnforcement{
+			URL:     github.Ptr(url + "/enforce_admins"),
+			Enabled: dbProtection.EnforceAdmins == 1,
+		},
+		URL: github.Ptr(url),
+	}
+
+	if dbProtection.RequiredStatusChecks.Valid {
+		var checks github.RequiredStatusChecks
+		if err := json.Unmarshal([]byte(dbProtection.RequiredStatusChecks.String), &checks); err == nil {
+			checks.URL = github.Ptr(url + "/required_status_checks")
+			protection.RequiredStatusChecks = &checks
 		}
-		if dbRun.HeadSha.Valid {
-			run.HeadSHA = github.Ptr(dbRun.HeadSha.String)
+	}
+	if dbProtection.RequiredReviews.Valid {
+		var reviews github.PullRequestReviewsEnforcement
+		if err := json.Unmarshal([]byte(dbProtection.RequiredReviews.String), &reviews); err == nil {
+			protection.RequiredPullRequestReviews = &reviews
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(run)
-		log.Printf("[github] ✓ Returned workflow run %d for %s/%s", runID, owner, repo)
-		return
 	}
 
-	http.NotFound(w, r)
+	return protection
 }
 
-// Helper functions
-
-func generateSHA(content string) string {
-	hash := sha1.Sum([]byte(content)) //nolint:gosec // Used for generating fake SHAs in simulator, not for security
-	return fmt.Sprintf("%x", hash)
+// writeBranchNotProtected writes GitHub's 404 response for an unprotected branch
+func writeBranchNotProtected(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"message":           "Branch not protected",
+		"documentation_url": "https://docs.github.com/rest/branches/branch-protection#get-branch-protection",
+	})
 }
@@ -0,0 +1,370 @@
+package github
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v80/github"
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/recreate-run/nova-simulators/internal/signing"
+	"github.com/recreate-run/nova-simulators/internal/webhook"
+)
+
+// Headers GitHub sets on every webhook delivery
+const (
+	eventHeader    = "X-GitHub-Event"
+	deliveryHeader = "X-GitHub-Delivery"
+)
+
+// validHookContentTypes lists the payload formats a repository webhook can ask for
+var validHookContentTypes = map[string]bool{
+	"json": true,
+	"form": true,
+}
+
+// WebhookProvider sends events with GitHub's X-GitHub-Event and X-GitHub-Delivery headers,
+// signed with X-Hub-Signature-256 when the hook has a secret. Payloads are always JSON.
+func WebhookProvider() webhook.Provider {
+	return webhook.Provider{
+		Payload: func(_ string, data interface{}) (interface{}, error) {
+			return data, nil
+		},
+		Headers: func(req *webhook.Request) (http.Header, error) {
+			header := make(http.Header)
+			header.Set(eventHeader, req.Event)
+			header.Set(deliveryHeader, deliveryGUID(req.ID))
+			header.Set("User-Agent", "GitHub-Hookshot/simulator")
+			if req.Secret != "" {
+				header.Set(signing.GitHubSignatureHeader, signing.SignGitHub(req.Secret, req.Payload))
+			}
+			return header, nil
+		},
+	}
+}
+
+// Hook handlers
+
+func (h *Handler) handleHooks(w http.ResponseWriter, r *http.Request, owner, repo string, parts []string) {
+	sessionID := session.FromContext(r.Context())
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			h.handleListHooks(w, owner, repo, sessionID)
+		case http.MethodPost:
+			h.handleCreateHook(w, r, owner, repo, sessionID)
+		default:
+			apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	hookID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || len(parts) > 1 {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetHook(w, owner, repo, hookID, sessionID)
+	case http.MethodDelete:
+		h.handleDeleteHook(w, owner, repo, hookID, sessionID)
+	default:
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *Handler) handleCreateHook(w http.ResponseWriter, r *http.Request, owner, repo, sessionID string) {
+	var req github.Hook
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.GitHub, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	config := req.Config
+	if config == nil {
+		config = &github.HookConfig{}
+	}
+	if parsed, err := url.Parse(config.GetURL()); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		writeValidationFailed(w, "Hook", "url", "invalid")
+		return
+	}
+	contentType := config.GetContentType()
+	if contentType == "" {
+		contentType = "form"
+	}
+	if !validHookContentTypes[contentType] {
+		writeValidationFailed(w, "Hook", "content_type", "invalid")
+		return
+	}
+
+	events := req.Events
+	if len(events) == 0 {
+		events = []string{"push"}
+	}
+	eventsJSON, _ := json.Marshal(events)
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	dbHook, err := h.queries.CreateGithubHook(context.Background(), database.CreateGithubHookParams{
+		RepoOwner:   owner,
+		RepoName:    repo,
+		Url:         config.GetURL(),
+		ContentType: contentType,
+		Secret:      config.GetSecret(),
+		Events:      string(eventsJSON),
+		Active:      boolToInt(active),
+		SessionID:   sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to create hook: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toGithubHook(&dbHook))
+	log.Printf("[github] ✓ Created hook %d for %s/%s", dbHook.ID, owner, repo)
+}
+
+func (h *Handler) handleListHooks(w http.ResponseWriter, owner, repo, sessionID string) {
+	dbHooks, err := h.queries.ListGithubHooks(context.Background(), database.ListGithubHooksParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list hooks: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	hooks := make([]*github.Hook, 0, len(dbHooks))
+	for i := range dbHooks {
+		hooks = append(hooks, toGithubHook(&dbHooks[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(hooks)
+	log.Printf("[github] ✓ Listed %d hooks for %s/%s", len(hooks), owner, repo)
+}
+
+func (h *Handler) handleGetHook(w http.ResponseWriter, owner, repo string, hookID int64, sessionID string) {
+	dbHook, err := h.queries.GetGithubHook(context.Background(), database.GetGithubHookParams{
+		ID:        hookID,
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to get hook: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toGithubHook(&dbHook))
+	log.Printf("[github] ✓ Returned hook %d for %s/%s", hookID, owner, repo)
+}
+
+func (h *Handler) handleDeleteHook(w http.ResponseWriter, owner, repo string, hookID int64, sessionID string) {
+	deleted, err := h.queries.DeleteGithubHook(context.Background(), database.DeleteGithubHookParams{
+		ID:        hookID,
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to delete hook: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if deleted == 0 {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("[github] ✓ Deleted hook %d for %s/%s", hookID, owner, repo)
+}
+
+// emitEvent delivers a repository event to the repository's active hooks subscribed to it.
+// The repository and sender are added to payload, as GitHub does for every event.
+func (h *Handler) emitEvent(ctx context.Context, owner, repo, event string, payload map[string]interface{}) {
+	dbHooks, err := h.queries.ListGithubHooks(context.Background(), database.ListGithubHooksParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		SessionID: session.FromContext(ctx),
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to list hooks for %s event: %v", event, err)
+		return
+	}
+
+	var targets []webhook.Target
+	for i := range dbHooks {
+		if dbHooks[i].Active == 1 && hookWantsEvent(&dbHooks[i], event) {
+			targets = append(targets, webhook.Target{URL: dbHooks[i].Url, Secret: dbHooks[i].Secret})
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	payload["repository"] = &github.Repository{
+		Name:     github.Ptr(repo),
+		FullName: github.Ptr(owner + "/" + repo),
+		Owner:    &github.User{Login: github.Ptr(owner)},
+		HTMLURL:  github.Ptr(fmt.Sprintf("https://github.com/%s/%s", owner, repo)),
+	}
+	payload["sender"] = &github.User{Login: github.Ptr(authenticatedUserLogin)}
+
+	if err := webhook.EmitTo(ctx, "github", event, payload, targets); err != nil {
+		log.Printf("[github] ✗ Failed to queue %s event: %v", event, err)
+	}
+}
+
+// emitPullRequestEvent sends a pull_request event with the pull request as currently stored
+func (h *Handler) emitPullRequestEvent(ctx context.Context, owner, repo string, number int, action string) {
+	dbPR, err := h.queries.GetGithubPullRequest(context.Background(), database.GetGithubPullRequestParams{
+		RepoOwner: owner,
+		RepoName:  repo,
+		Number:    int64(number),
+		SessionID: session.FromContext(ctx),
+	})
+	if err != nil {
+		log.Printf("[github] ✗ Failed to load PR #%d for pull_request event: %v", number, err)
+		return
+	}
+
+	pr := &github.PullRequest{
+		ID:        github.Ptr(dbPR.ID),
+		Number:    github.Ptr(int(dbPR.Number)),
+		Title:     github.Ptr(dbPR.Title),
+		State:     github.Ptr(dbPR.State),
+		Head:      &github.PullRequestBranch{Ref: github.Ptr(dbPR.Head)},
+		Base:      &github.PullRequestBranch{Ref: github.Ptr(dbPR.Base)},
+		Merged:    github.Ptr(dbPR.Merged == 1),
+		Draft:     github.Ptr(dbPR.Draft == 1),
+		CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPR.CreatedAt, 0)}),
+		UpdatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbPR.UpdatedAt, 0)}),
+	}
+	if dbPR.Body.Valid {
+		pr.Body = github.Ptr(dbPR.Body.String)
+	}
+	if dbPR.MergedAt.Valid {
+		pr.MergedAt = github.Ptr(github.Timestamp{Time: time.Unix(dbPR.MergedAt.Int64, 0)})
+	}
+	pr.Assignee, pr.Assignees = toGithubAssignees(dbPR.Assignees)
+
+	h.emitEvent(ctx, owner, repo, "pull_request", map[string]interface{}{
+		"action":       action,
+		"number":       number,
+		"pull_request": pr,
+	})
+}
+
+// emitPushEvent sends a push event for a single commit to a branch. An empty before SHA is sent
+// as the all-zero SHA GitHub uses for new refs.
+func (h *Handler) emitPushEvent(ctx context.Context, owner, repo, branch, before, after, message string) {
+	if before == "" {
+		before = strings.Repeat("0", 40)
+	}
+
+	commit := map[string]interface{}{
+		"id":        after,
+		"message":   message,
+		"timestamp": session.Now(session.FromContext(ctx)).UTC().Format(time.RFC3339),
+		"author":    map[string]string{"name": authenticatedUserLogin},
+	}
+	h.emitEvent(ctx, owner, repo, "push", map[string]interface{}{
+		"ref":         "refs/heads/" + branch,
+		"before":      before,
+		"after":       after,
+		"commits":     []interface{}{commit},
+		"head_commit": commit,
+		"pusher":      map[string]string{"name": authenticatedUserLogin},
+	})
+}
+
+// stateChangeAction names the webhook action for an update that moved from one state to another
+func stateChangeAction(previous, current string) string {
+	switch {
+	case previous != "closed" && current == "closed":
+		return "closed"
+	case previous == "closed" && current != "closed":
+		return "reopened"
+	default:
+		return "edited"
+	}
+}
+
+// hookWantsEvent reports whether a hook subscribed to event, directly or through "*"
+func hookWantsEvent(hook *database.GithubHook, event string) bool {
+	var events []string
+	_ = json.Unmarshal([]byte(hook.Events), &events)
+	for _, subscribed := range events {
+		if subscribed == event || subscribed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// toGithubHook converts a stored hook to the API shape; like GitHub, the secret is masked
+func toGithubHook(dbHook *database.GithubHook) *github.Hook {
+	var events []string
+	_ = json.Unmarshal([]byte(dbHook.Events), &events)
+
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/hooks/%d", dbHook.RepoOwner, dbHook.RepoName, dbHook.ID)
+	config := &github.HookConfig{
+		URL:         github.Ptr(dbHook.Url),
+		ContentType: github.Ptr(dbHook.ContentType),
+		InsecureSSL: github.Ptr("0"),
+	}
+	if dbHook.Secret != "" {
+		config.Secret = github.Ptr("********")
+	}
+
+	return &github.Hook{
+		ID:        github.Ptr(dbHook.ID),
+		Type:      github.Ptr("Repository"),
+		Name:      github.Ptr("web"),
+		Active:    github.Ptr(dbHook.Active == 1),
+		Events:    events,
+		Config:    config,
+		URL:       github.Ptr(apiURL),
+		TestURL:   github.Ptr(apiURL + "/test"),
+		PingURL:   github.Ptr(apiURL + "/pings"),
+		CreatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbHook.CreatedAt, 0)}),
+		UpdatedAt: github.Ptr(github.Timestamp{Time: time.Unix(dbHook.UpdatedAt, 0)}),
+	}
+}
+
+// deliveryGUID formats a delivery ID as the UUID GitHub sends in X-GitHub-Delivery
+func deliveryGUID(id string) string {
+	if len(id) != 32 {
+		return id
+	}
+	return id[0:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:32]
+}
@@ -3,14 +3,21 @@ package github_test
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v80/github"
 	"github.com/pressly/goose/v3"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/recreate-run/nova-simulators/internal/webhook"
 	simulatorGithub "github.com/recreate-run/nova-simulators/simulators/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,6 +226,870 @@ func TestGithubSimulatorIssues(t *testing.T) {
 		assert.NotNil(t, comment, "Should return comment")
 		assert.Equal(t, "This is a test comment", comment.GetBody(), "Comment body should match")
 	})
+
+	t.Run("AddIssueReaction", func(t *testing.T) {
+		// Create an issue
+		created, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+			Title: github.Ptr("Reaction Test Issue"),
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		// Add a reaction
+		reaction, resp, err := client.Reactions.CreateIssueReaction(ctx, owner, repo, created.GetNumber(), "heart")
+
+		// Assertions
+		require.NoError(t, err, "Create reaction should not return error")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "New reaction should return 201")
+		assert.Equal(t, "heart", reaction.GetContent(), "Content should match")
+		assert.NotEmpty(t, reaction.GetUser().GetLogin(), "Reaction should carry a user")
+
+		// Reacting again with the same content is deduplicated
+		again, resp, err := client.Reactions.CreateIssueReaction(ctx, owner, repo, created.GetNumber(), "heart")
+		require.NoError(t, err, "Repeat reaction should not return error")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Existing reaction should return 200")
+		assert.Equal(t, reaction.GetID(), again.GetID(), "Should return the existing reaction")
+
+		_, _, err = client.Reactions.CreateIssueReaction(ctx, owner, repo, created.GetNumber(), "+1")
+		require.NoError(t, err, "Second content should succeed")
+
+		// List reactions
+		reactions, _, err := client.Reactions.ListIssueReactions(ctx, owner, repo, created.GetNumber(), nil)
+		require.NoError(t, err, "List reactions should not return error")
+		require.Len(t, reactions, 2, "Should have one reaction per content")
+		assert.Equal(t, "heart", reactions[0].GetContent())
+		assert.Equal(t, "+1", reactions[1].GetContent())
+
+		// Filter by content
+		hearts, _, err := client.Reactions.ListIssueReactions(ctx, owner, repo, created.GetNumber(), &github.ListReactionOptions{Content: "heart"})
+		require.NoError(t, err, "Filtered list should not return error")
+		assert.Len(t, hearts, 1, "Should filter by content")
+
+		// Invalid content is rejected
+		_, resp, err = client.Reactions.CreateIssueReaction(ctx, owner, repo, created.GetNumber(), "thumbsup")
+		require.Error(t, err, "Invalid content should fail")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Invalid content should return 422")
+	})
+
+	t.Run("AddPullRequestReaction", func(t *testing.T) {
+		pr, _, err := client.PullRequests.Create(ctx, owner, "reaction-pr-repo", &github.NewPullRequest{
+			Title: github.Ptr("Reaction Test PR"),
+			Head:  github.Ptr("feature"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create PR should succeed")
+
+		// Pull requests are reacted to through the issue endpoints
+		reaction, resp, err := client.Reactions.CreateIssueReaction(ctx, owner, "reaction-pr-repo", pr.GetNumber(), "hooray")
+		require.NoError(t, err, "Reacting to a PR should not return error")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "New reaction should return 201")
+		assert.Equal(t, "hooray", reaction.GetContent(), "Content should match")
+
+		reactions, _, err := client.Reactions.ListIssueReactions(ctx, owner, "reaction-pr-repo", pr.GetNumber(), nil)
+		require.NoError(t, err, "List reactions should not return error")
+		require.Len(t, reactions, 1, "Should list the PR's reaction")
+		assert.Equal(t, "hooray", reactions[0].GetContent(), "Content should match")
+
+		_, resp, err = client.Reactions.CreateIssueReaction(ctx, owner, "reaction-pr-repo", pr.GetNumber()+1, "hooray")
+		require.Error(t, err, "Reacting to a missing issue should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+
+	t.Run("AddIssueCommentReaction", func(t *testing.T) {
+		// Create an issue with a comment
+		created, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+			Title: github.Ptr("Comment Reaction Test Issue"),
+		})
+		require.NoError(t, err, "Create should succeed")
+		comment, _, err := client.Issues.CreateComment(ctx, owner, repo, created.GetNumber(), &github.IssueComment{
+			Body: github.Ptr("React to me"),
+		})
+		require.NoError(t, err, "Create comment should succeed")
+
+		// Add a reaction to the comment and list it
+		_, _, err = client.Reactions.CreateIssueCommentReaction(ctx, owner, repo, comment.GetID(), "rocket")
+		require.NoError(t, err, "Create comment reaction should not return error")
+
+		reactions, _, err := client.Reactions.ListIssueCommentReactions(ctx, owner, repo, comment.GetID(), nil)
+		require.NoError(t, err, "List comment reactions should not return error")
+		require.Len(t, reactions, 1, "Should have one reaction")
+		assert.Equal(t, "rocket", reactions[0].GetContent(), "Content should match")
+	})
+}
+
+func TestGithubSimulatorIssuesSince(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-since"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "since-repo"
+
+	createIssue := func(t *testing.T, title string) *github.Issue {
+		t.Helper()
+		issue, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr(title)})
+		require.NoError(t, err, "Create should succeed")
+		return issue
+	}
+
+	older := createIssue(t, "Older issue")
+	createIssue(t, "Another older issue")
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, older.GetNumber(), &github.IssueComment{Body: github.Ptr("Older comment")})
+	require.NoError(t, err, "Create comment should succeed")
+
+	// Timestamps have second precision, so start the newer items in a fresh second
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	since := time.Now()
+
+	createIssue(t, "Newer issue")
+	closed := createIssue(t, "Newer closed issue")
+	_, _, err = client.Issues.Edit(ctx, owner, repo, closed.GetNumber(), &github.IssueRequest{State: github.Ptr("closed")})
+	require.NoError(t, err, "Closing should succeed")
+	_, _, err = client.Issues.CreateComment(ctx, owner, repo, older.GetNumber(), &github.IssueComment{Body: github.Ptr("Newer comment")})
+	require.NoError(t, err, "Create comment should succeed")
+
+	titles := func(issues []*github.Issue) []string {
+		result := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			result = append(result, issue.GetTitle())
+		}
+		return result
+	}
+
+	t.Run("ListIssuesSince", func(t *testing.T) {
+		all, _, err := client.Issues.ListByRepo(ctx, owner, repo, nil)
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, all, 3, "Every open issue should be listed without since")
+
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			Since: since,
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Equal(t, []string{"Newer issue"}, titles(issues), "Only newer open issues should be returned")
+	})
+
+	t.Run("SinceCombinesWithState", func(t *testing.T) {
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			State: "closed",
+			Since: since,
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Equal(t, []string{"Newer closed issue"}, titles(issues), "Only newer closed issues should be returned")
+	})
+
+	t.Run("IssueUpdatesCountAsNewer", func(t *testing.T) {
+		_, _, err := client.Issues.Edit(ctx, owner, repo, older.GetNumber(), &github.IssueRequest{Body: github.Ptr("Edited")})
+		require.NoError(t, err, "Edit should succeed")
+
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			State: "open",
+			Since: since,
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.ElementsMatch(t, []string{"Newer issue", "Older issue"}, titles(issues), "Edited issues should be returned")
+	})
+
+	t.Run("ListCommentsSince", func(t *testing.T) {
+		all, _, err := client.Issues.ListComments(ctx, owner, repo, older.GetNumber(), nil)
+		require.NoError(t, err, "List comments should succeed")
+		assert.Len(t, all, 2, "Both comments should be listed without since")
+
+		newer, _, err := client.Issues.ListComments(ctx, owner, repo, older.GetNumber(), &github.IssueListCommentsOptions{
+			Since: &since,
+		})
+		require.NoError(t, err, "List comments should succeed")
+		require.Len(t, newer, 1, "Only the newer comment should be returned")
+		assert.Equal(t, "Newer comment", newer[0].GetBody(), "Body should match")
+	})
+
+	t.Run("InvalidSince", func(t *testing.T) {
+		req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues?since=yesterday", owner, repo), nil)
+		require.NoError(t, err, "Building the request should succeed")
+		resp, err := client.Do(ctx, req, nil)
+		require.Error(t, err, "Invalid since should fail")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Should return 422")
+	})
+}
+
+func TestGithubSimulatorIssueStateReason(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-state-reason"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "state-reason-repo"
+
+	createIssue := func(t *testing.T, title string) *github.Issue {
+		t.Helper()
+		issue, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr(title)})
+		require.NoError(t, err, "Create should succeed")
+		return issue
+	}
+
+	notPlanned := createIssue(t, "Won't fix")
+	completed := createIssue(t, "Done")
+
+	t.Run("CloseAsNotPlanned", func(t *testing.T) {
+		_, _, err := client.Issues.Edit(ctx, owner, repo, notPlanned.GetNumber(), &github.IssueRequest{
+			State:       github.Ptr("closed"),
+			StateReason: github.Ptr("not_planned"),
+		})
+		require.NoError(t, err, "Closing as not planned should succeed")
+
+		issue, _, err := client.Issues.Get(ctx, owner, repo, notPlanned.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "closed", issue.GetState())
+		assert.Equal(t, "not_planned", issue.GetStateReason(), "Get should return the state reason")
+	})
+
+	t.Run("CloseDefaultsToCompleted", func(t *testing.T) {
+		issue, _, err := client.Issues.Edit(ctx, owner, repo, completed.GetNumber(), &github.IssueRequest{State: github.Ptr("closed")})
+		require.NoError(t, err, "Closing should succeed")
+		assert.Equal(t, "completed", issue.GetStateReason(), "Closing without a reason should record completed")
+	})
+
+	t.Run("ListByStateReason", func(t *testing.T) {
+		req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues?state=closed&state_reason=not_planned", owner, repo), nil)
+		require.NoError(t, err)
+		var issues []*github.Issue
+		_, err = client.Do(ctx, req, &issues)
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, issues, 1, "Only the not planned issue should match")
+		assert.Equal(t, notPlanned.GetNumber(), issues[0].GetNumber())
+	})
+
+	t.Run("ReopenRecordsReopened", func(t *testing.T) {
+		issue, _, err := client.Issues.Edit(ctx, owner, repo, completed.GetNumber(), &github.IssueRequest{State: github.Ptr("open")})
+		require.NoError(t, err, "Reopening should succeed")
+		assert.Equal(t, "reopened", issue.GetStateReason())
+	})
+
+	t.Run("InvalidStateReason", func(t *testing.T) {
+		_, resp, err := client.Issues.Edit(ctx, owner, repo, notPlanned.GetNumber(), &github.IssueRequest{StateReason: github.Ptr("abandoned")})
+		require.Error(t, err, "Unknown state reason should be rejected")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestGithubSimulatorIssueLabels(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-labels"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "labels-repo"
+
+	labelNames := func(labels []*github.Label) []string {
+		names := make([]string, 0, len(labels))
+		for _, label := range labels {
+			names = append(names, label.GetName())
+		}
+		return names
+	}
+
+	bug, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+		Title:  github.Ptr("Crash on save"),
+		Labels: &[]string{"bug", "ui"},
+	})
+	require.NoError(t, err, "Create should succeed")
+	assert.Equal(t, []string{"bug", "ui"}, labelNames(bug.Labels), "Create should return the labels")
+
+	feature, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+		Title:  github.Ptr("Dark mode"),
+		Labels: &[]string{"enhancement"},
+	})
+	require.NoError(t, err, "Create should succeed")
+
+	_, _, err = client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr("Unlabeled")})
+	require.NoError(t, err, "Create should succeed")
+
+	t.Run("GetIncludesLabels", func(t *testing.T) {
+		issue, _, err := client.Issues.Get(ctx, owner, repo, bug.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, []string{"bug", "ui"}, labelNames(issue.Labels), "Get should return the labels")
+	})
+
+	t.Run("ListByLabel", func(t *testing.T) {
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{Labels: []string{"bug"}})
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, issues, 1, "Only the bug should match")
+		assert.Equal(t, bug.GetNumber(), issues[0].GetNumber())
+		assert.Equal(t, []string{"bug", "ui"}, labelNames(issues[0].Labels), "List should return the labels")
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{Labels: []string{"bug", "enhancement"}})
+		require.NoError(t, err, "List should succeed")
+		assert.Empty(t, issues, "An issue must carry every requested label")
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, nil)
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 3, "No filter should return every issue")
+	})
+
+	t.Run("AddAndList", func(t *testing.T) {
+		labels, _, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, feature.GetNumber(), []string{"triage", "enhancement"})
+		require.NoError(t, err, "AddLabelsToIssue should succeed")
+		assert.Equal(t, []string{"enhancement", "triage"}, labelNames(labels), "Adding an existing label should not duplicate it")
+
+		labels, _, err = client.Issues.ListLabelsByIssue(ctx, owner, repo, feature.GetNumber(), nil)
+		require.NoError(t, err, "ListLabelsByIssue should succeed")
+		assert.Equal(t, []string{"enhancement", "triage"}, labelNames(labels))
+	})
+
+	t.Run("RemoveOne", func(t *testing.T) {
+		_, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, feature.GetNumber(), "triage")
+		require.NoError(t, err, "RemoveLabelForIssue should succeed")
+
+		labels, _, err := client.Issues.ListLabelsByIssue(ctx, owner, repo, feature.GetNumber(), nil)
+		require.NoError(t, err, "ListLabelsByIssue should succeed")
+		assert.Equal(t, []string{"enhancement"}, labelNames(labels))
+
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, feature.GetNumber(), "triage")
+		require.Error(t, err, "Removing a label the issue lacks should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("EditReplacesLabels", func(t *testing.T) {
+		issue, _, err := client.Issues.Edit(ctx, owner, repo, bug.GetNumber(), &github.IssueRequest{Labels: &[]string{"regression"}})
+		require.NoError(t, err, "Edit should succeed")
+		assert.Equal(t, []string{"regression"}, labelNames(issue.Labels), "Labels in an edit should replace the existing ones")
+
+		issue, _, err = client.Issues.Edit(ctx, owner, repo, bug.GetNumber(), &github.IssueRequest{Title: github.Ptr("Crash on save (macOS)")})
+		require.NoError(t, err, "Edit should succeed")
+		assert.Equal(t, []string{"regression"}, labelNames(issue.Labels), "Edits without labels should keep them")
+	})
+
+	t.Run("RemoveAll", func(t *testing.T) {
+		_, err := client.Issues.RemoveLabelsForIssue(ctx, owner, repo, bug.GetNumber())
+		require.NoError(t, err, "RemoveLabelsForIssue should succeed")
+
+		labels, _, err := client.Issues.ListLabelsByIssue(ctx, owner, repo, bug.GetNumber(), nil)
+		require.NoError(t, err, "ListLabelsByIssue should succeed")
+		assert.Empty(t, labels, "Issue should have no labels left")
+	})
+
+	t.Run("UnknownIssue", func(t *testing.T) {
+		_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, 999, []string{"bug"})
+		require.Error(t, err, "Labeling a missing issue should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGithubSimulatorStateAll(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-state-all"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "state-all-repo"
+
+	t.Run("Issues", func(t *testing.T) {
+		for _, title := range []string{"Open one", "Open two", "Closed one"} {
+			issue, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr(title)})
+			require.NoError(t, err, "Create should succeed")
+			if title == "Closed one" {
+				_, _, err = client.Issues.Edit(ctx, owner, repo, issue.GetNumber(), &github.IssueRequest{State: github.Ptr("closed")})
+				require.NoError(t, err, "Closing should succeed")
+			}
+		}
+
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{State: "all"})
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 3, "state=all should return open and closed issues")
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{State: "open"})
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 2, "state=open should return only open issues")
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{State: "closed"})
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, issues, 1, "state=closed should return only closed issues")
+		assert.Equal(t, "Closed one", issues[0].GetTitle())
+	})
+
+	t.Run("PullRequests", func(t *testing.T) {
+		for _, head := range []string{"open-branch", "closed-branch"} {
+			pr, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+				Title: github.Ptr("PR from " + head),
+				Head:  github.Ptr(head),
+				Base:  github.Ptr("main"),
+			})
+			require.NoError(t, err, "Create PR should succeed")
+			if head == "closed-branch" {
+				_, _, err = client.PullRequests.Edit(ctx, owner, repo, pr.GetNumber(), &github.PullRequest{State: github.Ptr("closed")})
+				require.NoError(t, err, "Closing should succeed")
+			}
+		}
+
+		prs, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{State: "all"})
+		require.NoError(t, err, "List PRs should succeed")
+		assert.Len(t, prs, 2, "state=all should return open and closed PRs")
+
+		prs, _, err = client.PullRequests.List(ctx, owner, repo, nil)
+		require.NoError(t, err, "List PRs should succeed")
+		require.Len(t, prs, 1, "The default state should be open")
+		assert.Equal(t, "open", prs[0].GetState())
+	})
+}
+
+func TestGithubSimulatorPagination(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-pagination"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "pagination-repo"
+	for i := 1; i <= 55; i++ {
+		_, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr(fmt.Sprintf("Issue %d", i))})
+		require.NoError(t, err, "Create should succeed")
+	}
+
+	t.Run("WalkPages", func(t *testing.T) {
+		seen := map[int]bool{}
+		opts := &github.IssueListByRepoOptions{}
+
+		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		require.NoError(t, err, "First page should succeed")
+		assert.Len(t, issues, 30, "per_page should default to 30")
+		assert.Equal(t, 2, resp.NextPage, "The first page should link to the next")
+		assert.Equal(t, 2, resp.LastPage, "The first page should link to the last")
+		for _, issue := range issues {
+			seen[issue.GetNumber()] = true
+		}
+
+		opts.ListOptions.Page = resp.NextPage
+		issues, resp, err = client.Issues.ListByRepo(ctx, owner, repo, opts)
+		require.NoError(t, err, "Second page should succeed")
+		assert.Len(t, issues, 25, "The last page should hold the remainder")
+		assert.Zero(t, resp.NextPage, "The last page should not link further")
+		assert.Equal(t, 1, resp.PrevPage, "The last page should link back")
+		for _, issue := range issues {
+			seen[issue.GetNumber()] = true
+		}
+		assert.Len(t, seen, 55, "The pages should cover every issue once")
+	})
+
+	t.Run("PerPage", func(t *testing.T) {
+		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			ListOptions: github.ListOptions{PerPage: 20, Page: 2},
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 20)
+		assert.Equal(t, 3, resp.NextPage)
+		assert.Equal(t, 3, resp.LastPage)
+		assert.Contains(t, resp.Header.Get("Link"), "per_page=20", "Links should keep the request's parameters")
+
+		issues, resp, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			ListOptions: github.ListOptions{PerPage: 500},
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 55, "per_page should be capped at 100")
+		assert.Empty(t, resp.Header.Get("Link"), "A single page should not be linked")
+	})
+
+	t.Run("ListCapLeavesNextPage", func(t *testing.T) {
+		listcap.SetMax(40)
+		t.Cleanup(func() { listcap.SetMax(0) })
+
+		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 40, "Pages should shrink to the cap")
+		assert.Equal(t, "true", resp.Header.Get(listcap.TruncatedHeader), "Capped pages should be flagged")
+		require.Equal(t, 2, resp.NextPage, "The rest should stay reachable")
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			ListOptions: github.ListOptions{PerPage: 100, Page: resp.NextPage},
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, issues, 15, "The next page should hold the remainder")
+	})
+
+	t.Run("PastTheEnd", func(t *testing.T) {
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			ListOptions: github.ListOptions{Page: 5},
+		})
+		require.NoError(t, err, "List should succeed")
+		assert.Empty(t, issues, "Pages past the end should be empty")
+	})
+
+	t.Run("PullRequests", func(t *testing.T) {
+		for i := 1; i <= 3; i++ {
+			_, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+				Title: github.Ptr(fmt.Sprintf("PR %d", i)),
+				Head:  github.Ptr(fmt.Sprintf("feature-%d", i)),
+				Base:  github.Ptr("main"),
+			})
+			require.NoError(t, err, "Create PR should succeed")
+		}
+
+		prs, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			ListOptions: github.ListOptions{PerPage: 2},
+		})
+		require.NoError(t, err, "List PRs should succeed")
+		assert.Len(t, prs, 2)
+		assert.Equal(t, 2, resp.NextPage)
+	})
+}
+
+func TestGithubSimulatorAssignees(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-assignees"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "assignees-repo"
+
+	logins := func(users []*github.User) []string {
+		names := make([]string, 0, len(users))
+		for _, user := range users {
+			names = append(names, user.GetLogin())
+		}
+		return names
+	}
+
+	assigned, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+		Title:     github.Ptr("Fix login"),
+		Assignees: &[]string{"alice", "bob"},
+	})
+	require.NoError(t, err, "Create should succeed")
+	assert.Equal(t, []string{"alice", "bob"}, logins(assigned.Assignees), "Create should return the assignees")
+	assert.Equal(t, "alice", assigned.GetAssignee().GetLogin(), "The first assignee should be the assignee")
+
+	unassigned, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr("Triage me")})
+	require.NoError(t, err, "Create should succeed")
+	assert.Empty(t, unassigned.Assignees, "Issue should start unassigned")
+	assert.Nil(t, unassigned.Assignee)
+
+	t.Run("GetIncludesAssignees", func(t *testing.T) {
+		issue, _, err := client.Issues.Get(ctx, owner, repo, assigned.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, []string{"alice", "bob"}, logins(issue.Assignees))
+	})
+
+	t.Run("ListByAssignee", func(t *testing.T) {
+		issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{Assignee: "alice"})
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, issues, 1, "Only the assigned issue should match")
+		assert.Equal(t, assigned.GetNumber(), issues[0].GetNumber())
+		assert.Equal(t, []string{"alice", "bob"}, logins(issues[0].Assignees), "List should return the assignees")
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{Assignee: "none"})
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, issues, 1, "Only the unassigned issue should match none")
+		assert.Equal(t, unassigned.GetNumber(), issues[0].GetNumber())
+
+		issues, _, err = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{Assignee: "*"})
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, issues, 1, "Only the assigned issue should match *")
+		assert.Equal(t, assigned.GetNumber(), issues[0].GetNumber())
+	})
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		issue, _, err := client.Issues.AddAssignees(ctx, owner, repo, unassigned.GetNumber(), []string{"carol", "dave", "carol"})
+		require.NoError(t, err, "AddAssignees should succeed")
+		assert.Equal(t, []string{"carol", "dave"}, logins(issue.Assignees), "Repeated logins should be added once")
+
+		issue, _, err = client.Issues.RemoveAssignees(ctx, owner, repo, unassigned.GetNumber(), []string{"carol"})
+		require.NoError(t, err, "RemoveAssignees should succeed")
+		assert.Equal(t, []string{"dave"}, logins(issue.Assignees))
+
+		issue, _, err = client.Issues.Get(ctx, owner, repo, unassigned.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, []string{"dave"}, logins(issue.Assignees), "Changes should be persisted")
+	})
+
+	t.Run("EditReplacesAssignees", func(t *testing.T) {
+		issue, _, err := client.Issues.Edit(ctx, owner, repo, assigned.GetNumber(), &github.IssueRequest{Assignees: &[]string{"erin"}})
+		require.NoError(t, err, "Edit should succeed")
+		assert.Equal(t, []string{"erin"}, logins(issue.Assignees), "Assignees in an edit should replace the existing ones")
+
+		issue, _, err = client.Issues.Edit(ctx, owner, repo, assigned.GetNumber(), &github.IssueRequest{Title: github.Ptr("Fix login flow")})
+		require.NoError(t, err, "Edit should succeed")
+		assert.Equal(t, []string{"erin"}, logins(issue.Assignees), "Edits without assignees should keep them")
+
+		issue, _, err = client.Issues.Edit(ctx, owner, repo, assigned.GetNumber(), &github.IssueRequest{Assignees: &[]string{}})
+		require.NoError(t, err, "Edit should succeed")
+		assert.Empty(t, issue.Assignees, "An empty list should clear the assignees")
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		pr, _, err := client.PullRequests.Create(ctx, owner, "assignees-pr-repo", &github.NewPullRequest{
+			Title: github.Ptr("Add feature"),
+			Head:  github.Ptr("feature"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create PR should succeed")
+		assert.Empty(t, pr.Assignees, "PR should start unassigned")
+
+		issue, _, err := client.Issues.AddAssignees(ctx, owner, "assignees-pr-repo", pr.GetNumber(), []string{"frank"})
+		require.NoError(t, err, "AddAssignees should accept a PR number")
+		assert.Equal(t, []string{"frank"}, logins(issue.Assignees))
+		assert.True(t, issue.IsPullRequest(), "The response should be marked as a pull request")
+
+		pr, _, err = client.PullRequests.Get(ctx, owner, "assignees-pr-repo", pr.GetNumber())
+		require.NoError(t, err, "Get PR should succeed")
+		assert.Equal(t, []string{"frank"}, logins(pr.Assignees), "PR should carry the assignee")
+		assert.Equal(t, "frank", pr.GetAssignee().GetLogin())
+	})
+
+	t.Run("UnknownIssue", func(t *testing.T) {
+		_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, 999, []string{"alice"})
+		require.Error(t, err, "Assigning a missing issue should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGithubSimulatorUsers(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	newClient := func(t *testing.T, sessionID string) *github.Client {
+		t.Helper()
+		client := github.NewClient(&http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}).WithAuthToken("test-token")
+		client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+		require.NoError(t, err, "Failed to set enterprise URLs")
+		return client
+	}
+
+	sessionID := "github-test-session-users"
+	for _, params := range []database.CreateGithubUserParams{
+		{Login: "octocat", Type: "User", Name: "The Octocat", Email: "octocat@example.com", Authenticated: 1, SessionID: sessionID},
+		{Login: "hubot", Type: "User", Name: "Hubot", SessionID: sessionID},
+		{Login: "acme", Type: "Organization", Name: "Acme Corp", SessionID: sessionID},
+	} {
+		_, err := queries.CreateGithubUser(ctx, params)
+		require.NoError(t, err, "Seeding %s should succeed", params.Login)
+	}
+	client := newClient(t, sessionID)
+
+	t.Run("AuthenticatedUser", func(t *testing.T) {
+		user, _, err := client.Users.Get(ctx, "")
+		require.NoError(t, err, "Get authenticated user should succeed")
+		assert.Equal(t, "octocat", user.GetLogin(), "The seeded authenticated user should be returned")
+		assert.Equal(t, "User", user.GetType())
+		assert.Equal(t, "The Octocat", user.GetName())
+		assert.Equal(t, "octocat@example.com", user.GetEmail())
+		assert.NotZero(t, user.GetID())
+	})
+
+	t.Run("DefaultAuthenticatedUser", func(t *testing.T) {
+		user, _, err := newClient(t, "github-test-session-users-default").Users.Get(ctx, "")
+		require.NoError(t, err, "Get authenticated user should succeed")
+		assert.Equal(t, "simulator-user", user.GetLogin(), "Sessions without a seeded user should get the default")
+		assert.Equal(t, "User", user.GetType())
+	})
+
+	t.Run("UserByLogin", func(t *testing.T) {
+		user, _, err := client.Users.Get(ctx, "HUBOT")
+		require.NoError(t, err, "Get user should succeed")
+		assert.Equal(t, "hubot", user.GetLogin(), "Logins should match case-insensitively")
+		assert.Equal(t, "Hubot", user.GetName())
+
+		org, _, err := client.Users.Get(ctx, "acme")
+		require.NoError(t, err, "Organizations should be returned by the users endpoint")
+		assert.Equal(t, "Organization", org.GetType())
+
+		_, resp, err := client.Users.Get(ctx, "nobody")
+		require.Error(t, err, "Unknown users should not be found")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Organization", func(t *testing.T) {
+		org, _, err := client.Organizations.Get(ctx, "acme")
+		require.NoError(t, err, "Get organization should succeed")
+		assert.Equal(t, "acme", org.GetLogin())
+		assert.Equal(t, "Acme Corp", org.GetName())
+		assert.Equal(t, "Organization", org.GetType())
+
+		_, resp, err := client.Organizations.Get(ctx, "hubot")
+		require.Error(t, err, "Users should not be returned as organizations")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("SessionIsolation", func(t *testing.T) {
+		_, resp, err := newClient(t, "github-test-session-users-other").Organizations.Get(ctx, "acme")
+		require.Error(t, err, "Accounts should be scoped to their session")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGithubSimulatorWebhooks(t *testing.T) {
+	queries := setupTestDB(t)
+
+	webhook.Init(queries)
+	webhook.SetBackoff(10 * time.Millisecond)
+	webhook.Register("github", simulatorGithub.WebhookProvider())
+	t.Cleanup(func() {
+		webhook.Wait()
+		webhook.Init(nil)
+		webhook.SetBackoff(0)
+	})
+
+	type delivered struct {
+		header http.Header
+		body   []byte
+	}
+	var mu sync.Mutex
+	var deliveries []delivered
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		deliveries = append(deliveries, delivered{header: r.Header.Clone(), body: body})
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-webhooks"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "webhook-repo"
+	secret := "hook-secret"
+
+	hook, _, err := client.Repositories.CreateHook(ctx, owner, repo, &github.Hook{
+		Config: &github.HookConfig{
+			URL:         github.Ptr(receiver.URL),
+			ContentType: github.Ptr("json"),
+			Secret:      github.Ptr(secret),
+		},
+		Events: []string{"issues"},
+		Active: github.Ptr(true),
+	})
+	require.NoError(t, err, "CreateHook should succeed")
+
+	t.Run("CreateHook", func(t *testing.T) {
+		assert.NotZero(t, hook.GetID())
+		assert.Equal(t, []string{"issues"}, hook.Events)
+		assert.Equal(t, receiver.URL, hook.GetConfig().GetURL())
+		assert.Equal(t, "********", hook.GetConfig().GetSecret(), "Secret should be masked")
+	})
+
+	t.Run("ListHooks", func(t *testing.T) {
+		hooks, _, err := client.Repositories.ListHooks(ctx, owner, repo, nil)
+		require.NoError(t, err, "ListHooks should succeed")
+		require.Len(t, hooks, 1)
+		assert.Equal(t, hook.GetID(), hooks[0].GetID())
+	})
+
+	t.Run("IssueEventDelivered", func(t *testing.T) {
+		issue, _, err := client.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr("Hooked")})
+		require.NoError(t, err, "Create issue should succeed")
+		webhook.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, deliveries, 1, "The issues event should be delivered once")
+		d := deliveries[0]
+		assert.Equal(t, "issues", github.WebHookType(&http.Request{Header: d.header}))
+		assert.NotEmpty(t, github.DeliveryID(&http.Request{Header: d.header}))
+		require.NoError(t, github.ValidateSignature(d.header.Get(github.SHA256SignatureHeader), d.body, []byte(secret)),
+			"Signature should validate with the hook secret")
+
+		event, err := github.ParseWebHook("issues", d.body)
+		require.NoError(t, err, "Payload should parse as an issues event")
+		issuesEvent, ok := event.(*github.IssuesEvent)
+		require.True(t, ok)
+		assert.Equal(t, "opened", issuesEvent.GetAction())
+		assert.Equal(t, issue.GetNumber(), issuesEvent.GetIssue().GetNumber())
+		assert.Equal(t, owner+"/"+repo, issuesEvent.GetRepo().GetFullName())
+	})
+
+	t.Run("UnsubscribedEventsSkipped", func(t *testing.T) {
+		_, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.Ptr("Not hooked"),
+			Head:  github.Ptr("feature"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create PR should succeed")
+		webhook.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, deliveries, 1, "A hook subscribed to issues should not get pull_request events")
+	})
+
+	t.Run("DeleteHook", func(t *testing.T) {
+		_, err := client.Repositories.DeleteHook(ctx, owner, repo, hook.GetID())
+		require.NoError(t, err, "DeleteHook should succeed")
+
+		_, resp, err := client.Repositories.GetHook(ctx, owner, repo, hook.GetID())
+		require.Error(t, err, "Deleted hook should not be found")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
 }
 
 func TestGithubSimulatorPullRequests(t *testing.T) {
@@ -318,23 +1189,219 @@ func TestGithubSimulatorPullRequests(t *testing.T) {
 		assert.Equal(t, "Get Test PR", pr.GetTitle(), "Title should match")
 	})
 
-	t.Run("MergePullRequest", func(t *testing.T) {
-		// Create a PR
-		prReq := &github.NewPullRequest{
-			Title: github.Ptr("Merge Test PR"),
-			Head:  github.Ptr("merge-branch"),
-			Base:  github.Ptr("main"),
-		}
-		created, _, err := client.PullRequests.Create(ctx, owner, repo, prReq)
-		require.NoError(t, err, "Create should succeed")
+	t.Run("MergePullRequest", func(t *testing.T) {
+		// Create a PR
+		prReq := &github.NewPullRequest{
+			Title: github.Ptr("Merge Test PR"),
+			Head:  github.Ptr("merge-branch"),
+			Base:  github.Ptr("main"),
+		}
+		created, _, err := client.PullRequests.Create(ctx, owner, repo, prReq)
+		require.NoError(t, err, "Create should succeed")
+
+		// Merge the PR
+		mergeResult, _, err := client.PullRequests.Merge(ctx, owner, repo, created.GetNumber(), "Merged via test", &github.PullRequestOptions{})
+
+		// Assertions
+		require.NoError(t, err, "Merge should not return error")
+		assert.NotNil(t, mergeResult, "Should return merge result")
+		assert.True(t, mergeResult.GetMerged(), "Should be merged")
+
+		// Subsequent gets reflect the merge
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, created.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "closed", pr.GetState(), "Merged PR should be closed")
+		assert.True(t, pr.GetMerged(), "Merged flag should be set")
+		assert.NotNil(t, pr.MergedAt, "merged_at should be set")
+
+		// Merging again is rejected
+		_, resp, err := client.PullRequests.Merge(ctx, owner, repo, created.GetNumber(), "Again", &github.PullRequestOptions{})
+		require.Error(t, err, "Double merge should fail")
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "Double merge should return 405")
+	})
+
+	t.Run("MergeProtectedBranchWithoutReview", func(t *testing.T) {
+		_, _, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, "release", &github.ProtectionRequest{
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+				RequiredApprovingReviewCount: 1,
+			},
+		})
+		require.NoError(t, err, "Protecting the branch should succeed")
+
+		created, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.Ptr("Unreviewed PR"),
+			Head:  github.Ptr("unreviewed-branch"),
+			Base:  github.Ptr("release"),
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		_, resp, err := client.PullRequests.Merge(ctx, owner, repo, created.GetNumber(), "Merge", &github.PullRequestOptions{})
+		require.Error(t, err, "Merge without review should fail")
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "Should return 405")
+		assert.Contains(t, err.Error(), "approving review is required", "Error should explain the protection rule")
+
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, created.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "open", pr.GetState(), "Rejected PR should stay open")
+		assert.False(t, pr.GetMerged(), "Rejected PR should not be merged")
+	})
+
+	t.Run("DraftPullRequestCannotBeMerged", func(t *testing.T) {
+		created, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title:               github.Ptr("Draft PR"),
+			Head:                github.Ptr("draft-branch"),
+			Base:                github.Ptr("main"),
+			Draft:               github.Ptr(true),
+			MaintainerCanModify: github.Ptr(true),
+		})
+		require.NoError(t, err, "Create should succeed")
+		assert.True(t, created.GetDraft(), "Created PR should be a draft")
+
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, created.GetNumber())
+		require.NoError(t, err, "Get should succeed")
+		assert.True(t, pr.GetDraft(), "Get should report draft")
+		assert.True(t, pr.GetMaintainerCanModify(), "Get should report maintainer_can_modify")
+
+		_, resp, err := client.PullRequests.Merge(ctx, owner, repo, created.GetNumber(), "Merge", &github.PullRequestOptions{})
+		require.Error(t, err, "Merging a draft should fail")
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "Should return 405")
+
+		// Mark the PR ready for review
+		req, err := client.NewRequest(http.MethodPatch,
+			fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, created.GetNumber()),
+			map[string]bool{"draft": false})
+		require.NoError(t, err, "Building the request should succeed")
+		ready := new(github.PullRequest)
+		_, err = client.Do(ctx, req, ready)
+		require.NoError(t, err, "Marking ready should succeed")
+		assert.False(t, ready.GetDraft(), "PR should no longer be a draft")
+
+		mergeResult, _, err := client.PullRequests.Merge(ctx, owner, repo, created.GetNumber(), "Merge", &github.PullRequestOptions{})
+		require.NoError(t, err, "Merging a ready PR should succeed")
+		assert.True(t, mergeResult.GetMerged(), "Should be merged")
+	})
+
+	t.Run("EditPullRequest", func(t *testing.T) {
+		created, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.Ptr("Edit me"),
+			Head:  github.Ptr("edit-branch"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		pr, _, err := client.PullRequests.Edit(ctx, owner, repo, created.GetNumber(), &github.PullRequest{
+			Title: github.Ptr("Edited"),
+			State: github.Ptr("closed"),
+		})
+		require.NoError(t, err, "Edit should succeed")
+		assert.Equal(t, "Edited", pr.GetTitle(), "Title should be updated")
+		assert.Equal(t, "closed", pr.GetState(), "State should be updated")
+		assert.Equal(t, "main", pr.Base.GetRef(), "Base should be unchanged")
+
+		_, resp, err := client.PullRequests.Edit(ctx, owner, repo, 9999, &github.PullRequest{Title: github.Ptr("Missing")})
+		require.Error(t, err, "Editing a missing PR should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+
+	t.Run("MergeRecordsCommitOnBase", func(t *testing.T) {
+		mergeRepo := "merge-commit-repo"
+		_, _, err := client.Repositories.Get(ctx, owner, mergeRepo)
+		require.NoError(t, err, "Get repo should succeed")
+		mainRef, _, err := client.Git.GetRef(ctx, owner, mergeRepo, "refs/heads/main")
+		require.NoError(t, err, "Get main ref should succeed")
+		_, _, err = client.Git.CreateRef(ctx, owner, mergeRepo, github.CreateRef{
+			Ref: "refs/heads/feature",
+			SHA: mainRef.Object.GetSHA(),
+		})
+		require.NoError(t, err, "Create branch should succeed")
+		_, _, err = client.Repositories.CreateFile(ctx, owner, mergeRepo, "feature.txt", &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Add feature"),
+			Content: []byte("feature"),
+			Branch:  github.Ptr("feature"),
+		})
+		require.NoError(t, err, "Create file should succeed")
+
+		pr, _, err := client.PullRequests.Create(ctx, owner, mergeRepo, &github.NewPullRequest{
+			Title: github.Ptr("Add feature"),
+			Head:  github.Ptr("feature"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create PR should succeed")
+		mergeResult, _, err := client.PullRequests.Merge(ctx, owner, mergeRepo, pr.GetNumber(), "Ship it", &github.PullRequestOptions{})
+		require.NoError(t, err, "Merge should succeed")
+
+		// The base branch moves to the merge commit, which carries the head's files
+		mainRef, _, err = client.Git.GetRef(ctx, owner, mergeRepo, "refs/heads/main")
+		require.NoError(t, err, "Get main ref should succeed")
+		assert.Equal(t, mergeResult.GetSHA(), mainRef.Object.GetSHA(), "main should point at the merge commit")
+
+		commit, _, err := client.Repositories.GetCommit(ctx, owner, mergeRepo, mergeResult.GetSHA(), nil)
+		require.NoError(t, err, "The merge commit should be recorded")
+		assert.Contains(t, commit.Commit.GetMessage(), "Ship it", "The commit message should be used")
+		require.Len(t, commit.Files, 1, "The merge should bring in the head's change")
+		assert.Equal(t, "feature.txt", commit.Files[0].GetFilename())
+
+		_, _, _, err = client.Repositories.GetContents(ctx, owner, mergeRepo, "feature.txt", &github.RepositoryContentGetOptions{Ref: "main"})
+		require.NoError(t, err, "The head's file should be on main")
+	})
+
+	t.Run("CreatePullRequestFromIssue", func(t *testing.T) {
+		issue, _, err := client.Issues.Create(ctx, owner, "convert-repo", &github.IssueRequest{
+			Title: github.Ptr("Issue to convert"),
+			Body:  github.Ptr("Issue body"),
+		})
+		require.NoError(t, err, "Creating the issue should succeed")
+
+		pr, _, err := client.PullRequests.Create(ctx, owner, "convert-repo", &github.NewPullRequest{
+			Issue: github.Ptr(issue.GetNumber()),
+			Head:  github.Ptr("issue-branch"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create from issue should succeed")
+		assert.Equal(t, "Issue to convert", pr.GetTitle(), "Title should come from the issue")
+		assert.Equal(t, "Issue body", pr.GetBody(), "Body should come from the issue")
+		assert.Equal(t, issue.GetNumber(), pr.GetNumber(), "The PR should keep the issue's number")
+
+		converted, _, err := client.Issues.Get(ctx, owner, "convert-repo", issue.GetNumber())
+		require.NoError(t, err, "Getting the converted issue should succeed")
+		assert.True(t, converted.IsPullRequest(), "The issue should now be the pull request")
+
+		_, resp, err := client.PullRequests.Create(ctx, owner, "convert-repo", &github.NewPullRequest{
+			Issue: github.Ptr(issue.GetNumber()),
+			Head:  github.Ptr("issue-branch"),
+			Base:  github.Ptr("main"),
+		})
+		require.Error(t, err, "Converting an issue twice should fail")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Should return 422")
+
+		_, resp, err = client.PullRequests.Create(ctx, owner, "convert-repo", &github.NewPullRequest{
+			Issue: github.Ptr(9999),
+			Head:  github.Ptr("issue-branch"),
+			Base:  github.Ptr("main"),
+		})
+		require.Error(t, err, "Create from a missing issue should fail")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Should return 422")
+	})
+
+	t.Run("IssuesAndPullRequestsShareNumbers", func(t *testing.T) {
+		issue, _, err := client.Issues.Create(ctx, owner, "shared-numbers-repo", &github.IssueRequest{
+			Title: github.Ptr("First issue"),
+		})
+		require.NoError(t, err, "Creating the issue should succeed")
 
-		// Merge the PR
-		mergeResult, _, err := client.PullRequests.Merge(ctx, owner, repo, created.GetNumber(), "Merged via test", &github.PullRequestOptions{})
+		pr, _, err := client.PullRequests.Create(ctx, owner, "shared-numbers-repo", &github.NewPullRequest{
+			Title: github.Ptr("First PR"),
+			Head:  github.Ptr("feature"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Creating the PR should succeed")
+		assert.Equal(t, issue.GetNumber()+1, pr.GetNumber(), "The PR should not reuse the issue's number")
 
-		// Assertions
-		require.NoError(t, err, "Merge should not return error")
-		assert.NotNil(t, mergeResult, "Should return merge result")
-		assert.True(t, mergeResult.GetMerged(), "Should be merged")
+		next, _, err := client.Issues.Create(ctx, owner, "shared-numbers-repo", &github.IssueRequest{
+			Title: github.Ptr("Second issue"),
+		})
+		require.NoError(t, err, "Creating the second issue should succeed")
+		assert.Equal(t, pr.GetNumber()+1, next.GetNumber(), "The issue should not reuse the PR's number")
 	})
 }
 
@@ -437,6 +1504,416 @@ func TestGithubSimulatorFiles(t *testing.T) {
 		require.NoError(t, err, "Update file should not return error")
 		assert.NotNil(t, result, "Should return result")
 	})
+
+	t.Run("DeleteFile", func(t *testing.T) {
+		// Create file first
+		path := "delete-test.md"
+		created, _, err := client.Repositories.CreateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Add file"),
+			Content: []byte("Short-lived content"),
+			Branch:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		// Delete file
+		result, _, err := client.Repositories.DeleteFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Remove file"),
+			Branch:  github.Ptr("main"),
+			SHA:     created.Content.SHA,
+		})
+
+		// Assertions
+		require.NoError(t, err, "Delete file should not return error")
+		assert.Equal(t, "Remove file", result.Commit.GetMessage(), "The commit should carry the delete's message")
+		assert.NotEqual(t, created.Commit.GetSHA(), result.Commit.GetSHA(), "Deleting should record a new commit")
+
+		_, _, _, err = client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: "main"})
+		require.Error(t, err, "Deleted file should no longer be readable")
+
+		commit, _, err := client.Repositories.GetCommit(ctx, owner, repo, result.Commit.GetSHA(), nil)
+		require.NoError(t, err, "GetCommit should succeed")
+		require.Len(t, commit.Files, 1, "Only the deleted file should be listed")
+		assert.Equal(t, "removed", commit.Files[0].GetStatus())
+
+		_, resp, err := client.Repositories.DeleteFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Remove again"),
+			Branch:  github.Ptr("main"),
+			SHA:     created.Content.SHA,
+		})
+		require.Error(t, err, "Deleting a missing file should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("DeleteFileSHAMismatch", func(t *testing.T) {
+		path := "stale-delete.md"
+		_, _, err := client.Repositories.CreateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Add file"),
+			Content: []byte("Current content"),
+			Branch:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		_, resp, err := client.Repositories.DeleteFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Remove file"),
+			Branch:  github.Ptr("main"),
+			SHA:     github.Ptr("stale-sha"),
+		})
+		require.Error(t, err, "A stale SHA should be rejected")
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		_, _, _, err = client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: "main"})
+		require.NoError(t, err, "The file should survive a rejected delete")
+	})
+}
+
+func TestGithubSimulatorGitTrees(t *testing.T) {
+	queries := setupTestDB(t)
+	sessionID := "github-test-session-trees"
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: sessionID},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "tree-repo"
+
+	// Fetching the repository creates its main branch
+	_, _, err = client.Repositories.Get(ctx, owner, repo)
+	require.NoError(t, err, "Get repository should succeed")
+
+	files := map[string]string{
+		"README.md":           "# Tree repo",
+		"src/main.go":         "package main",
+		"src/internal/db.go":  "package internal",
+		"docs/guide/intro.md": "Welcome",
+	}
+	blobSHAs := make(map[string]string, len(files))
+	for path, content := range files {
+		result, _, err := client.Repositories.CreateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr("Add " + path),
+			Content: []byte(content),
+			Branch:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "CreateFile should succeed for %s", path)
+		blobSHAs[path] = result.Content.GetSHA()
+	}
+
+	// Each write moves main to a new commit, so read the head once the files are in place
+	mainRef, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/main")
+	require.NoError(t, err, "GetRef should succeed")
+	mainSHA := mainRef.GetObject().GetSHA()
+
+	entryTypes := func(tree *github.Tree) map[string]string {
+		types := make(map[string]string, len(tree.Entries))
+		for _, entry := range tree.Entries {
+			types[entry.GetPath()] = entry.GetType()
+		}
+		return types
+	}
+
+	t.Run("GetTopLevelTree", func(t *testing.T) {
+		tree, _, err := client.Git.GetTree(ctx, owner, repo, mainSHA, false)
+		require.NoError(t, err, "GetTree should not return error")
+		assert.Equal(t, mainSHA, tree.GetSHA(), "Tree SHA should match the branch head")
+		assert.Equal(t, map[string]string{
+			"README.md": "blob",
+			"docs":      "tree",
+			"src":       "tree",
+		}, entryTypes(tree), "Only top-level entries should be returned")
+	})
+
+	t.Run("GetRecursiveTree", func(t *testing.T) {
+		tree, _, err := client.Git.GetTree(ctx, owner, repo, mainSHA, true)
+		require.NoError(t, err, "GetTree should not return error")
+		assert.Equal(t, map[string]string{
+			"README.md":           "blob",
+			"docs":                "tree",
+			"docs/guide":          "tree",
+			"docs/guide/intro.md": "blob",
+			"src":                 "tree",
+			"src/internal":        "tree",
+			"src/internal/db.go":  "blob",
+			"src/main.go":         "blob",
+		}, entryTypes(tree), "All nested entries should be returned")
+
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" {
+				assert.Equal(t, blobSHAs[entry.GetPath()], entry.GetSHA(), "Blob SHA should match the file SHA")
+				assert.Equal(t, len(files[entry.GetPath()]), entry.GetSize(), "Blob size should match")
+			}
+		}
+	})
+
+	t.Run("GetBlob", func(t *testing.T) {
+		blob, _, err := client.Git.GetBlob(ctx, owner, repo, blobSHAs["src/main.go"])
+		require.NoError(t, err, "GetBlob should not return error")
+		assert.Equal(t, "base64", blob.GetEncoding(), "Encoding should be base64")
+		decoded, err := base64.StdEncoding.DecodeString(blob.GetContent())
+		require.NoError(t, err, "Content should be valid base64")
+		assert.Equal(t, "package main", string(decoded), "Decoded content should match")
+	})
+
+	t.Run("UnknownSHA", func(t *testing.T) {
+		_, resp, err := client.Git.GetTree(ctx, owner, repo, "0000000000000000000000000000000000000000", false)
+		require.Error(t, err, "Unknown tree should be rejected")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+
+		_, resp, err = client.Git.GetBlob(ctx, owner, repo, "0000000000000000000000000000000000000000")
+		require.Error(t, err, "Unknown blob should be rejected")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+}
+
+func TestGithubSimulatorCommitHistory(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-commits"},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "commits-repo"
+
+	// Fetching the repository creates its main branch at an empty initial commit
+	_, _, err = client.Repositories.Get(ctx, owner, repo)
+	require.NoError(t, err, "Get repository should succeed")
+
+	writes := []struct{ path, content, message string }{
+		{"README.md", "# Commits", "Add README"},
+		{"src/app.go", "package app", "Add app"},
+		{"README.md", "# Commits\n\nNow with docs", "Expand README"},
+	}
+	shas := make([]string, 0, len(writes))
+	for _, write := range writes {
+		result, _, err := client.Repositories.CreateFile(ctx, owner, repo, write.path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr(write.message),
+			Content: []byte(write.content),
+			Branch:  github.Ptr("main"),
+			Author:  &github.CommitAuthor{Name: github.Ptr("Ada"), Email: github.Ptr("ada@example.com")},
+		})
+		require.NoError(t, err, "CreateFile should succeed for %s", write.path)
+		assert.Equal(t, write.message, result.Commit.GetMessage(), "The commit should carry the write's message")
+		shas = append(shas, result.Commit.GetSHA())
+	}
+
+	messages := func(commits []*github.RepositoryCommit) []string {
+		result := make([]string, 0, len(commits))
+		for _, commit := range commits {
+			result = append(result, commit.GetCommit().GetMessage())
+		}
+		return result
+	}
+
+	t.Run("BranchAdvances", func(t *testing.T) {
+		ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/main")
+		require.NoError(t, err, "GetRef should succeed")
+		assert.Equal(t, shas[2], ref.GetObject().GetSHA(), "main should point at the latest commit")
+	})
+
+	t.Run("ListCommits", func(t *testing.T) {
+		commits, _, err := client.Repositories.ListCommits(ctx, owner, repo, nil)
+		require.NoError(t, err, "ListCommits should succeed")
+		assert.Equal(t, []string{"Expand README", "Add app", "Add README", "Initial commit"}, messages(commits),
+			"History should be listed newest first")
+
+		for i := 0; i < len(commits)-1; i++ {
+			require.Len(t, commits[i].Parents, 1, "Each commit should have one parent")
+			assert.Equal(t, commits[i+1].GetSHA(), commits[i].Parents[0].GetSHA(), "Parents should chain")
+		}
+		assert.Empty(t, commits[3].Parents, "The initial commit should have no parent")
+		assert.Equal(t, "Ada", commits[0].GetCommit().GetAuthor().GetName(), "The author should be recorded")
+	})
+
+	t.Run("ListCommitsByPath", func(t *testing.T) {
+		commits, _, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{Path: "README.md"})
+		require.NoError(t, err, "ListCommits should succeed")
+		assert.Equal(t, []string{"Expand README", "Add README"}, messages(commits), "Only commits touching the path should be listed")
+
+		commits, _, err = client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{Path: "src"})
+		require.NoError(t, err, "ListCommits should succeed")
+		assert.Equal(t, []string{"Add app"}, messages(commits), "A directory should match the files under it")
+	})
+
+	t.Run("ListCommitsPaged", func(t *testing.T) {
+		commits, _, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+			SHA:         shas[1],
+			ListOptions: github.ListOptions{PerPage: 1, Page: 2},
+		})
+		require.NoError(t, err, "ListCommits should succeed")
+		assert.Equal(t, []string{"Add README"}, messages(commits), "Paging should walk from the given SHA")
+	})
+
+	t.Run("GetCommit", func(t *testing.T) {
+		commit, _, err := client.Repositories.GetCommit(ctx, owner, repo, shas[2], nil)
+		require.NoError(t, err, "GetCommit should succeed")
+		assert.Equal(t, "Expand README", commit.GetCommit().GetMessage())
+		require.Len(t, commit.Files, 1, "Only the changed file should be listed")
+		assert.Equal(t, "README.md", commit.Files[0].GetFilename())
+		assert.Equal(t, "modified", commit.Files[0].GetStatus())
+
+		commit, _, err = client.Repositories.GetCommit(ctx, owner, repo, shas[1], nil)
+		require.NoError(t, err, "GetCommit should succeed")
+		require.Len(t, commit.Files, 1)
+		assert.Equal(t, "added", commit.Files[0].GetStatus())
+
+		commit, _, err = client.Repositories.GetCommit(ctx, owner, repo, "main", nil)
+		require.NoError(t, err, "GetCommit should accept a branch name")
+		assert.Equal(t, shas[2], commit.GetSHA())
+
+		_, resp, err := client.Repositories.GetCommit(ctx, owner, repo, "0000000000000000000000000000000000000000", nil)
+		require.Error(t, err, "Unknown commits should be rejected")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("ReplayReproducesSHAs", func(t *testing.T) {
+		replayClient := github.NewClient(&http.Client{
+			Transport: &sessionHTTPTransport{sessionID: "github-test-session-commits-replay"},
+		}).WithAuthToken("test-token")
+		replayClient, err := replayClient.WithEnterpriseURLs(server.URL, server.URL)
+		require.NoError(t, err, "Failed to set enterprise URLs")
+
+		_, _, err = replayClient.Repositories.Get(ctx, owner, repo)
+		require.NoError(t, err, "Get repository should succeed")
+		for i, write := range writes {
+			result, _, err := replayClient.Repositories.CreateFile(ctx, owner, repo, write.path, &github.RepositoryContentFileOptions{
+				Message: github.Ptr(write.message),
+				Content: []byte(write.content),
+				Branch:  github.Ptr("main"),
+				Author:  &github.CommitAuthor{Name: github.Ptr("Ada"), Email: github.Ptr("ada@example.com")},
+			})
+			require.NoError(t, err, "CreateFile should succeed for %s", write.path)
+			assert.Equal(t, shas[i], result.Commit.GetSHA(), "The same writes should produce the same commit")
+		}
+	})
+
+	t.Run("TreeOfEarlierCommit", func(t *testing.T) {
+		commit, _, err := client.Repositories.GetCommit(ctx, owner, repo, shas[0], nil)
+		require.NoError(t, err, "GetCommit should succeed")
+
+		tree, _, err := client.Git.GetTree(ctx, owner, repo, commit.GetCommit().GetTree().GetSHA(), true)
+		require.NoError(t, err, "GetTree should resolve a commit's tree")
+		require.Len(t, tree.Entries, 1, "The first commit's tree should only hold the README")
+		assert.Equal(t, "README.md", tree.Entries[0].GetPath())
+	})
+}
+
+func TestGithubSimulatorCheckRuns(t *testing.T) {
+	queries := setupTestDB(t)
+	sessionID := "github-test-session-check-runs"
+
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: sessionID},
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	owner, repo := "test-owner", "checks-repo"
+
+	// Fetching the repository creates its main branch
+	_, _, err = client.Repositories.Get(ctx, owner, repo)
+	require.NoError(t, err, "Get repository should succeed")
+	mainRef, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/main")
+	require.NoError(t, err, "GetRef should succeed")
+	mainSHA := mainRef.GetObject().GetSHA()
+
+	var buildID int64
+
+	t.Run("CreateCheckRun", func(t *testing.T) {
+		run, resp, err := client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+			Name:    "build",
+			HeadSHA: mainSHA,
+			Status:  github.Ptr("in_progress"),
+		})
+		require.NoError(t, err, "CreateCheckRun should succeed")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Should return 201")
+		assert.Equal(t, "build", run.GetName(), "Name should match")
+		assert.Equal(t, mainSHA, run.GetHeadSHA(), "Head SHA should match")
+		assert.Equal(t, "in_progress", run.GetStatus(), "Status should match")
+		assert.Empty(t, run.GetConclusion(), "In-progress runs have no conclusion")
+		buildID = run.GetID()
+
+		_, _, err = client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+			Name:       "lint",
+			HeadSHA:    mainSHA,
+			Conclusion: github.Ptr("failure"),
+			Output: &github.CheckRunOutput{
+				Title:   github.Ptr("2 lint errors"),
+				Summary: github.Ptr("Fix them"),
+			},
+		})
+		require.NoError(t, err, "CreateCheckRun with conclusion should succeed")
+	})
+
+	t.Run("CompleteCheckRun", func(t *testing.T) {
+		run, _, err := client.Checks.UpdateCheckRun(ctx, owner, repo, buildID, github.UpdateCheckRunOptions{
+			Conclusion: github.Ptr("success"),
+		})
+		require.NoError(t, err, "UpdateCheckRun should succeed")
+		assert.Equal(t, "completed", run.GetStatus(), "A conclusion should complete the run")
+		assert.Equal(t, "success", run.GetConclusion(), "Conclusion should match")
+		assert.NotNil(t, run.CompletedAt, "Completed runs should have a completion time")
+
+		fetched, _, err := client.Checks.GetCheckRun(ctx, owner, repo, buildID)
+		require.NoError(t, err, "GetCheckRun should succeed")
+		assert.Equal(t, "success", fetched.GetConclusion(), "Update should be persisted")
+	})
+
+	t.Run("ListCheckRunsForRef", func(t *testing.T) {
+		for _, ref := range []string{mainSHA, "main", "heads/main"} {
+			result, _, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, nil)
+			require.NoError(t, err, "ListCheckRunsForRef should succeed for %s", ref)
+			require.Equal(t, 2, result.GetTotal(), "Should list both check runs for %s", ref)
+			conclusions := map[string]string{}
+			for _, run := range result.CheckRuns {
+				conclusions[run.GetName()] = run.GetConclusion()
+			}
+			assert.Equal(t, map[string]string{"build": "success", "lint": "failure"}, conclusions, "Should report each check's conclusion")
+		}
+
+		result, _, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, mainSHA, &github.ListCheckRunsOptions{
+			CheckName: github.Ptr("lint"),
+		})
+		require.NoError(t, err, "ListCheckRunsForRef should succeed")
+		require.Len(t, result.CheckRuns, 1, "Should filter by check name")
+		assert.Equal(t, "2 lint errors", result.CheckRuns[0].GetOutput().GetTitle(), "Output should be returned")
+
+		result, _, err = client.Checks.ListCheckRunsForRef(ctx, owner, repo, "0000000000000000000000000000000000000000", nil)
+		require.NoError(t, err, "ListCheckRunsForRef should succeed for an unknown SHA")
+		assert.Equal(t, 0, result.GetTotal(), "Unknown SHAs should have no check runs")
+	})
+
+	t.Run("RejectsCompletedWithoutConclusion", func(t *testing.T) {
+		_, resp, err := client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+			Name:    "test",
+			HeadSHA: mainSHA,
+			Status:  github.Ptr("completed"),
+		})
+		require.Error(t, err, "Completed runs need a conclusion")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Should return 422")
+	})
 }
 
 func TestGithubSimulatorBranches(t *testing.T) {
@@ -509,6 +1986,113 @@ func TestGithubSimulatorBranches(t *testing.T) {
 		// Assertions
 		require.NoError(t, err, "Create ref should not return error")
 	})
+
+	t.Run("BranchProtection", func(t *testing.T) {
+		// Unprotected branches return 404
+		_, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, "main")
+		require.Error(t, err, "Unprotected branch should return error")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+
+		// Protect the branch
+		contexts := []string{"ci/build", "ci/test"}
+		protection, _, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, "main", &github.ProtectionRequest{
+			RequiredStatusChecks: &github.RequiredStatusChecks{
+				Strict:   true,
+				Contexts: &contexts,
+			},
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{
+				RequiredApprovingReviewCount: 2,
+				DismissStaleReviews:          true,
+			},
+			EnforceAdmins: true,
+		})
+		require.NoError(t, err, "Update branch protection should not return error")
+		assert.True(t, protection.GetEnforceAdmins().Enabled, "Admins should be enforced")
+
+		// Read it back
+		protection, _, err = client.Repositories.GetBranchProtection(ctx, owner, repo, "main")
+		require.NoError(t, err, "Get branch protection should not return error")
+		require.NotNil(t, protection.RequiredStatusChecks, "Required status checks should be stored")
+		assert.True(t, protection.RequiredStatusChecks.Strict, "Strict flag should be stored")
+		assert.Equal(t, contexts, *protection.RequiredStatusChecks.Contexts, "Required contexts should be stored")
+		require.NotNil(t, protection.RequiredStatusChecks.Checks, "Checks should mirror contexts")
+		assert.Len(t, *protection.RequiredStatusChecks.Checks, 2, "Checks should mirror contexts")
+		require.NotNil(t, protection.RequiredPullRequestReviews, "Required reviews should be stored")
+		assert.Equal(t, 2, protection.RequiredPullRequestReviews.RequiredApprovingReviewCount, "Review count should be stored")
+		assert.True(t, protection.RequiredPullRequestReviews.DismissStaleReviews, "Dismiss stale reviews should be stored")
+
+		// Remove it
+		_, err = client.Repositories.RemoveBranchProtection(ctx, owner, repo, "main")
+		require.NoError(t, err, "Remove branch protection should not return error")
+		_, resp, err = client.Repositories.GetBranchProtection(ctx, owner, repo, "main")
+		require.Error(t, err, "Removed protection should return error")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+
+	t.Run("GetBranch", func(t *testing.T) {
+		mainRef, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/main")
+		require.NoError(t, err, "Get main ref should succeed")
+		sha := mainRef.Object.GetSHA()
+
+		// Create a branch whose name contains a slash
+		_, _, err = client.Git.CreateRef(ctx, owner, repo, github.CreateRef{
+			Ref: "refs/heads/feature/get-branch",
+			SHA: sha,
+		})
+		require.NoError(t, err, "Create ref should succeed")
+
+		branch, _, err := client.Repositories.GetBranch(ctx, owner, repo, "feature/get-branch", 0)
+		require.NoError(t, err, "Get branch should not return error")
+		assert.Equal(t, "feature/get-branch", branch.GetName(), "Name should match")
+		assert.Equal(t, sha, branch.GetCommit().GetSHA(), "Commit SHA should match the branch")
+		assert.False(t, branch.GetProtected(), "New branch should not be protected")
+
+		// Protecting the branch is reflected in the protected flag
+		_, _, err = client.Repositories.UpdateBranchProtection(ctx, owner, repo, "feature/get-branch", &github.ProtectionRequest{})
+		require.NoError(t, err, "Update branch protection should succeed")
+		branch, _, err = client.Repositories.GetBranch(ctx, owner, repo, "feature/get-branch", 0)
+		require.NoError(t, err, "Get branch should not return error")
+		assert.True(t, branch.GetProtected(), "Branch should be protected")
+
+		_, resp, err := client.Repositories.GetBranch(ctx, owner, repo, "does-not-exist", 0)
+		require.Error(t, err, "Missing branch should return error")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+
+	t.Run("ListBranches", func(t *testing.T) {
+		const listRepo = "list-branches-repo"
+		_, _, err := client.Repositories.Get(ctx, owner, listRepo)
+		require.NoError(t, err, "Get repo should succeed")
+		mainRef, _, err := client.Git.GetRef(ctx, owner, listRepo, "refs/heads/main")
+		require.NoError(t, err, "Get main ref should succeed")
+
+		for _, name := range []string{"develop", "release/1.0"} {
+			_, _, err = client.Git.CreateRef(ctx, owner, listRepo, github.CreateRef{
+				Ref: "refs/heads/" + name,
+				SHA: mainRef.Object.GetSHA(),
+			})
+			require.NoError(t, err, "Create ref should succeed")
+		}
+		_, _, err = client.Repositories.UpdateBranchProtection(ctx, owner, listRepo, "release/1.0", &github.ProtectionRequest{})
+		require.NoError(t, err, "Update branch protection should succeed")
+
+		branches, _, err := client.Repositories.ListBranches(ctx, owner, listRepo, nil)
+		require.NoError(t, err, "List branches should not return error")
+		require.Len(t, branches, 3, "Should list main and both new branches")
+		assert.Equal(t, "develop", branches[0].GetName(), "Branches should be sorted by name")
+		assert.Equal(t, "main", branches[1].GetName(), "Branches should be sorted by name")
+		assert.Equal(t, "release/1.0", branches[2].GetName(), "Branches should be sorted by name")
+		assert.Equal(t, mainRef.Object.GetSHA(), branches[0].GetCommit().GetSHA(), "Commit SHA should match the branch")
+		assert.False(t, branches[0].GetProtected(), "develop should not be protected")
+		assert.True(t, branches[2].GetProtected(), "release/1.0 should be protected")
+
+		page, _, err := client.Repositories.ListBranches(ctx, owner, listRepo, &github.BranchListOptions{
+			ListOptions: github.ListOptions{PerPage: 2, Page: 2},
+		})
+		require.NoError(t, err, "List branches page should not return error")
+		require.Len(t, page, 1, "Second page should hold the remaining branch")
+		assert.Equal(t, "release/1.0", page[0].GetName(), "Second page should continue after the first")
+	})
 }
 
 func TestGithubSimulatorWorkflows(t *testing.T) {
@@ -567,6 +2151,81 @@ func TestGithubSimulatorWorkflows(t *testing.T) {
 	})
 }
 
+func TestGithubSimulatorGists(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	newClient := func(t *testing.T, sessionID string) *github.Client {
+		t.Helper()
+		customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}
+		client, err := github.NewClient(customClient).WithAuthToken("test-token").WithEnterpriseURLs(server.URL, server.URL)
+		require.NoError(t, err, "Failed to set enterprise URLs")
+		return client
+	}
+
+	ctx := context.Background()
+	client := newClient(t, "github-test-session-gists")
+
+	var gistID string
+
+	t.Run("CreateGist", func(t *testing.T) {
+		gist, resp, err := client.Gists.Create(ctx, &github.Gist{
+			Description: github.Ptr("Scratch output"),
+			Public:      github.Ptr(false),
+			Files: map[github.GistFilename]github.GistFile{
+				"report.md":   {Content: github.Ptr("# Report\nAll green")},
+				"result.json": {Content: github.Ptr(`{"ok":true}`)},
+			},
+		})
+		require.NoError(t, err, "Create should not return error")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Should return 201 Created")
+		assert.NotEmpty(t, gist.GetID(), "Gist should have an ID")
+		assert.Equal(t, "Scratch output", gist.GetDescription(), "Description should match")
+		assert.False(t, gist.GetPublic(), "Gist should be secret")
+		assert.Len(t, gist.Files, 2, "Should have 2 files")
+		gistID = gist.GetID()
+	})
+
+	t.Run("GetGist", func(t *testing.T) {
+		require.NotEmpty(t, gistID, "Gist should have been created")
+
+		gist, _, err := client.Gists.Get(ctx, gistID)
+		require.NoError(t, err, "Get should not return error")
+		assert.Equal(t, gistID, gist.GetID(), "IDs should match")
+		require.Contains(t, gist.Files, github.GistFilename("report.md"), "Should include report.md")
+		report := gist.Files["report.md"]
+		assert.Equal(t, "# Report\nAll green", report.GetContent(), "File contents should round-trip")
+		assert.Equal(t, "report.md", report.GetFilename(), "Filename should be set")
+		assert.Equal(t, len("# Report\nAll green"), report.GetSize(), "Size should match the content")
+		result := gist.Files["result.json"]
+		assert.JSONEq(t, `{"ok":true}`, result.GetContent(), "File contents should round-trip")
+	})
+
+	t.Run("ListGists", func(t *testing.T) {
+		gists, _, err := client.Gists.List(ctx, "", nil)
+		require.NoError(t, err, "List should not return error")
+		require.Len(t, gists, 1, "Should list the created gist")
+		assert.Equal(t, gistID, gists[0].GetID(), "IDs should match")
+	})
+
+	t.Run("CreateGistWithoutFiles", func(t *testing.T) {
+		_, resp, err := client.Gists.Create(ctx, &github.Gist{Description: github.Ptr("Empty")})
+		require.Error(t, err, "Create without files should fail")
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, "Should return 422")
+	})
+
+	t.Run("SessionIsolation", func(t *testing.T) {
+		_, resp, err := newClient(t, "github-test-session-gists-other").Gists.Get(ctx, gistID)
+		require.Error(t, err, "Other sessions should not see the gist")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+}
+
 func TestGithubSimulatorEndToEnd(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -641,7 +2300,7 @@ func TestGithubSimulatorEndToEnd(t *testing.T) {
 		}
 		pr, _, err := client.PullRequests.Create(ctx, owner, repo, prReq)
 		require.NoError(t, err, "Create PR should succeed")
-		assert.Equal(t, 1, pr.GetNumber())
+		assert.Equal(t, issue.GetNumber()+1, pr.GetNumber(), "The PR should take the number after the issue")
 
 		// 7. Add a comment to the PR
 		commentReq := &github.IssueComment{
@@ -664,3 +2323,109 @@ func TestGithubSimulatorEndToEnd(t *testing.T) {
 		assert.Equal(t, "closed", closedIssue.GetState())
 	})
 }
+
+func TestGithubSimulatorErrorEnvelope(t *testing.T) {
+	// Setup: A database without migrations makes every query fail
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err, "Failed to open test database")
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	handler := session.Middleware(simulatorGithub.NewHandler(database.New(db)))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{
+		Transport: &sessionHTTPTransport{sessionID: "github-test-session-errors"},
+	}
+	ctx := context.Background()
+	client := github.NewClient(customClient).WithAuthToken("test-token")
+	client, err = client.WithEnterpriseURLs(server.URL, server.URL)
+	require.NoError(t, err, "Failed to set enterprise URLs")
+
+	// A server failure should decode as a GitHub API error
+	_, resp, err := client.Repositories.Get(ctx, "test-owner", "test-repo")
+	require.Error(t, err, "Get should fail without a schema")
+	require.NotNil(t, resp, "Response should be returned with the error")
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, "Should return 500")
+
+	var apiErr *github.ErrorResponse
+	require.ErrorAs(t, err, &apiErr, "Error should decode as *github.ErrorResponse")
+	assert.Equal(t, "Internal server error", apiErr.Message, "Message should come from the envelope")
+	assert.Equal(t, "https://docs.github.com/rest", apiErr.DocumentationURL, "Documentation URL should be set")
+}
+
+func TestGithubSimulatorChildSessionNumbering(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorGithub.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	newClient := func(sessionID string) *github.Client {
+		client := github.NewClient(&http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}).WithAuthToken("test-token")
+		client, err := client.WithEnterpriseURLs(server.URL, server.URL)
+		require.NoError(t, err, "Failed to set enterprise URLs")
+		return client
+	}
+
+	const (
+		owner    = "test-owner"
+		repo     = "test-repo"
+		parentID = "github-test-session-baseline"
+		childID  = "github-test-session-child"
+	)
+	session.SetParent(childID, parentID)
+	t.Cleanup(func() {
+		session.ClearParent(childID)
+	})
+
+	// Seed the parent with an issue, a comment and a pull request
+	parent := newClient(parentID)
+	baseline, _, err := parent.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr("Baseline issue")})
+	require.NoError(t, err, "Create in parent should succeed")
+	baselineComment, _, err := parent.Issues.CreateComment(ctx, owner, repo, baseline.GetNumber(), &github.IssueComment{Body: github.Ptr("Baseline comment")})
+	require.NoError(t, err, "Comment in parent should succeed")
+	baselinePR, _, err := parent.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.Ptr("Baseline PR"),
+		Head:  github.Ptr("feature"),
+		Base:  github.Ptr("main"),
+	})
+	require.NoError(t, err, "Create PR in parent should succeed")
+
+	child := newClient(childID)
+
+	t.Run("IssueNumberedAboveParent", func(t *testing.T) {
+		issue, _, err := child.Issues.Create(ctx, owner, repo, &github.IssueRequest{Title: github.Ptr("Child issue")})
+		require.NoError(t, err, "Create in child should succeed")
+		assert.Greater(t, issue.GetNumber(), baseline.GetNumber(), "Child issue should not reuse the parent's number")
+
+		inherited, _, err := child.Issues.Get(ctx, owner, repo, baseline.GetNumber())
+		require.NoError(t, err, "Child should still read the parent's issue")
+		assert.Equal(t, "Baseline issue", inherited.GetTitle(), "Parent's issue should not be shadowed")
+	})
+
+	t.Run("CommentNumberedAboveParent", func(t *testing.T) {
+		comment, _, err := child.Issues.CreateComment(ctx, owner, repo, baseline.GetNumber(), &github.IssueComment{Body: github.Ptr("Child comment")})
+		require.NoError(t, err, "Comment in child should succeed")
+		assert.Greater(t, comment.GetID(), baselineComment.GetID(), "Child comment should not reuse the parent's ID")
+	})
+
+	t.Run("PullRequestNumberedAboveParent", func(t *testing.T) {
+		pr, _, err := child.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.Ptr("Child PR"),
+			Head:  github.Ptr("child-feature"),
+			Base:  github.Ptr("main"),
+		})
+		require.NoError(t, err, "Create PR in child should succeed")
+		assert.Greater(t, pr.GetNumber(), baselinePR.GetNumber(), "Child PR should not reuse the parent's number")
+
+		inherited, _, err := child.PullRequests.Get(ctx, owner, repo, baselinePR.GetNumber())
+		require.NoError(t, err, "Child should still read the parent's PR")
+		assert.Equal(t, "Baseline PR", inherited.GetTitle(), "Parent's PR should not be shadowed")
+	})
+}
@@ -321,9 +321,9 @@ func verifyDatabaseIsolation(t *testing.T, ctx context.Context, queries *databas
 	require.NoError(t, err, "ListGithubIssues should succeed")
 	assert.Len(t, dbIssues, len(issues), "Should have correct number of issues in database")
 
-	// Verify issue titles
+	// Verify issue titles; seeded issues share a timestamp, so the last seeded lists first
 	for i, issue := range dbIssues {
-		assert.Equal(t, issues[i].Title, issue.Title, "Issue title should match in database")
+		assert.Equal(t, issues[len(issues)-1-i].Title, issue.Title, "Issue title should match in database")
 	}
 
 	// Query pull requests from database
@@ -336,8 +336,8 @@ func verifyDatabaseIsolation(t *testing.T, ctx context.Context, queries *databas
 	require.NoError(t, err, "ListGithubPullRequests should succeed")
 	assert.Len(t, dbPRs, len(prs), "Should have correct number of pull requests in database")
 
-	// Verify PR titles
+	// Verify PR titles, newest first like the issues
 	for i := range dbPRs {
-		assert.Equal(t, prs[i].Title, dbPRs[i].Title, "PR title should match in database")
+		assert.Equal(t, prs[len(prs)-1-i].Title, dbPRs[i].Title, "PR title should match in database")
 	}
 }
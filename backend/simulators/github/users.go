@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v80/github"
+	"github.com/recreate-run/nova-simulators/internal/apierror"
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// Account types returned in the type field of users and organizations
+const (
+	userType         = "User"
+	organizationType = "Organization"
+)
+
+// User and organization handlers
+
+// handleAuthenticatedUser returns the session's authenticated user, falling back to the default
+// simulator account when none has been seeded
+func (h *Handler) handleAuthenticatedUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	dbUser, err := h.queries.GetGithubAuthenticatedUser(context.Background(), sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		dbUser = defaultAuthenticatedUser()
+		err = nil
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to get authenticated user: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toGithubUser(&dbUser))
+	log.Printf("[github] ✓ Returned authenticated user %s", dbUser.Login)
+}
+
+// handleGetUser returns a user or organization by login, as GitHub's /users/{login} does for both
+func (h *Handler) handleGetUser(w http.ResponseWriter, r *http.Request, login string) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	dbUser, ok := h.lookupAccount(w, session.FromContext(r.Context()), login)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toGithubUser(&dbUser))
+	log.Printf("[github] ✓ Returned user %s", dbUser.Login)
+}
+
+func (h *Handler) handleGetOrganization(w http.ResponseWriter, r *http.Request, org string) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.GitHub, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	dbUser, ok := h.lookupAccount(w, session.FromContext(r.Context()), org)
+	if !ok {
+		return
+	}
+	if dbUser.Type != organizationType {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&github.Organization{
+		ID:      github.Ptr(dbUser.ID),
+		Login:   github.Ptr(dbUser.Login),
+		Name:    github.Ptr(dbUser.Name),
+		Email:   github.Ptr(dbUser.Email),
+		Type:    github.Ptr(dbUser.Type),
+		URL:     github.Ptr("https://api.github.com/orgs/" + dbUser.Login),
+		HTMLURL: github.Ptr("https://github.com/" + dbUser.Login),
+	})
+	log.Printf("[github] ✓ Returned organization %s", dbUser.Login)
+}
+
+// lookupAccount finds a seeded account by login. The default authenticated user is known even
+// when it wasn't seeded. Writes a 404 or 500 and returns false when there is no account.
+func (h *Handler) lookupAccount(w http.ResponseWriter, sessionID, login string) (database.GithubUser, bool) {
+	dbUser, err := h.queries.GetGithubUser(context.Background(), database.GetGithubUserParams{
+		Login:     login,
+		SessionID: sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) && strings.EqualFold(login, authenticatedUserLogin) {
+		return defaultAuthenticatedUser(), true
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.GitHub, http.StatusNotFound, "Not Found")
+		return dbUser, false
+	}
+	if err != nil {
+		log.Printf("[github] ✗ Failed to get user: %v", err)
+		apierror.Write(w, apierror.GitHub, http.StatusInternalServerError, "Internal server error")
+		return dbUser, false
+	}
+	return dbUser, true
+}
+
+// defaultAuthenticatedUser is the account requests act as when the session seeds none
+func defaultAuthenticatedUser() database.GithubUser {
+	return database.GithubUser{
+		ID:            1,
+		Login:         authenticatedUserLogin,
+		Type:          userType,
+		Name:          "Simulator User",
+		Authenticated: 1,
+	}
+}
+
+func toGithubUser(dbUser *database.GithubUser) *github.User {
+	user := &github.User{
+		ID:      github.Ptr(dbUser.ID),
+		Login:   github.Ptr(dbUser.Login),
+		Type:    github.Ptr(dbUser.Type),
+		URL:     github.Ptr("https://api.github.com/users/" + dbUser.Login),
+		HTMLURL: github.Ptr("https://github.com/" + dbUser.Login),
+	}
+	if dbUser.Name != "" {
+		user.Name = github.Ptr(dbUser.Name)
+	}
+	if dbUser.Email != "" {
+		user.Email = github.Ptr(dbUser.Email)
+	}
+	return user
+}
@@ -3,7 +3,6 @@ package gmail
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/base64"
 	"encoding/hex"
@@ -14,15 +13,56 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
+	"net/mail"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/recreate-run/nova-simulators/internal/apierror"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
+	"github.com/recreate-run/nova-simulators/internal/pagetoken"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
+// watchDuration is how long a users.watch registration lasts before Gmail expires it
+const watchDuration = 7 * 24 * time.Hour
+
+// defaultSendAsEmail is the primary address of a session that has not configured send-as aliases
+const defaultSendAsEmail = "me@example.com"
+
+// primaryMailbox is the mailbox the "me" userId and the primary address resolve to
+const primaryMailbox = "me"
+
+// System labels the simulator gives meaning to
+const (
+	labelInbox = "INBOX"
+	labelSent  = "SENT"
+	labelTrash = "TRASH"
+)
+
+// messagesPageScope scopes messages.list page tokens so they cannot be replayed against other lists
+const messagesPageScope = "gmail.messages"
+
+// threadsPageScope scopes threads.list page tokens
+const threadsPageScope = "gmail.threads"
+
+// pagingParams are the query parameters left out of a page token's filters, so the page size
+// may change between pages
+var pagingParams = []string{"pageToken", "maxResults"}
+
+// topicNamePattern matches fully qualified Pub/Sub topic names
+var topicNamePattern = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+
+// Values of the messages.get format parameter
+const (
+	messageFormatFull     = "full"
+	messageFormatMetadata = "metadata"
+	messageFormatMinimal  = "minimal"
+)
+
 // Gmail API response structures
 type SendMessageResponse struct {
 	ID       string   `json:"id"`
@@ -36,6 +76,31 @@ type MessageListResponse struct {
 	ResultSizeEstimate int              `json:"resultSizeEstimate"`
 }
 
+// ModifyMessageRequest is the body of messages.modify
+type ModifyMessageRequest struct {
+	AddLabelIDs    []string `json:"addLabelIds"`
+	RemoveLabelIDs []string `json:"removeLabelIds"`
+}
+
+// Thread is a conversation and the messages in it
+type Thread struct {
+	ID       string    `json:"id"`
+	Messages []Message `json:"messages,omitempty"`
+}
+
+type ThreadListResponse struct {
+	Threads            []ThreadListItem `json:"threads,omitempty"`
+	NextPageToken      string           `json:"nextPageToken,omitempty"`
+	ResultSizeEstimate int              `json:"resultSizeEstimate"`
+}
+
+// ThreadListItem is a thread as threads.list returns it. MessageCount is not part of the Gmail
+// API; clients that don't know it ignore it.
+type ThreadListItem struct {
+	ID           string `json:"id"`
+	MessageCount int    `json:"messageCount"`
+}
+
 type MessageListItem struct {
 	ID       string `json:"id"`
 	ThreadID string `json:"threadId"`
@@ -80,6 +145,46 @@ type Message struct {
 	InternalDate string          `json:"internalDate,omitempty"`
 }
 
+// WatchRequest is the body of users.watch
+type WatchRequest struct {
+	TopicName           string   `json:"topicName"`
+	LabelIDs            []string `json:"labelIds,omitempty"`
+	LabelFilterBehavior string   `json:"labelFilterBehavior,omitempty"`
+}
+
+// WatchResponse is returned by users.watch; both fields are int64s encoded as strings
+type WatchResponse struct {
+	HistoryID  string `json:"historyId"`
+	Expiration string `json:"expiration"`
+}
+
+// WatchState is the watch recorded for a session, as reported by the debug endpoint
+type WatchState struct {
+	TopicName           string   `json:"topicName"`
+	LabelIDs            []string `json:"labelIds"`
+	LabelFilterBehavior string   `json:"labelFilterBehavior,omitempty"`
+	HistoryID           string   `json:"historyId"`
+	Expiration          string   `json:"expiration"`
+	Active              bool     `json:"active"`
+}
+
+// SendAs is a send-as alias as returned by users.settings.sendAs
+type SendAs struct {
+	SendAsEmail        string `json:"sendAsEmail"`
+	DisplayName        string `json:"displayName,omitempty"`
+	ReplyToAddress     string `json:"replyToAddress,omitempty"`
+	Signature          string `json:"signature,omitempty"`
+	IsPrimary          bool   `json:"isPrimary,omitempty"`
+	IsDefault          bool   `json:"isDefault,omitempty"`
+	TreatAsAlias       bool   `json:"treatAsAlias,omitempty"`
+	VerificationStatus string `json:"verificationStatus,omitempty"`
+}
+
+// SendAsListResponse is the body of users.settings.sendAs.list
+type SendAsListResponse struct {
+	SendAs []SendAs `json:"sendAs"`
+}
+
 // Handler implements the Gmail simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -92,6 +197,18 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountGmailSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"messages":    counts.Messages,
+		"attachments": counts.Attachments,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[gmail] → %s %s", r.Method, r.URL.Path)
@@ -103,60 +220,153 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleGmailAPI(w, r)
 		return
 	}
+	if r.URL.Path == "/debug/watch" || r.URL.Path == "/gmail/debug/watch" {
+		h.handleGetWatch(w, r)
+		return
+	}
 
-	http.NotFound(w, r)
+	apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
 }
 
 func (h *Handler) handleGmailAPI(w http.ResponseWriter, r *http.Request) {
-	// Extract the path after /v1/users/{userId}/ or /gmail/v1/users/{userId}/
-	path := r.URL.Path
-	path = strings.TrimPrefix(path, "/gmail/v1/users/me/")
-	if path == r.URL.Path {
-		// Didn't match /gmail prefix, try without it
-		path = strings.TrimPrefix(path, "/v1/users/me/")
+	// Extract the {userId} segment and the path after /v1/users/{userId}/ or /gmail/v1/users/{userId}/
+	path := strings.TrimPrefix(r.URL.Path, "/gmail")
+	path = strings.TrimPrefix(path, "/v1/users/")
+	userID, path, ok := strings.Cut(path, "/")
+	if !ok || userID == "" {
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	mailbox, err := h.resolveMailbox(session.FromContext(r.Context()), userID)
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to resolve mailbox: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
 	}
 
 	switch {
 	case strings.HasPrefix(path, "messages/send"):
-		h.handleSendMessage(w, r)
+		h.handleSendMessage(w, r, mailbox)
 	case strings.HasPrefix(path, "messages/import"):
-		h.handleImportMessage(w, r)
+		h.handleImportMessage(w, r, mailbox)
+	case path == "messages/batchDelete" && r.Method == http.MethodPost:
+		h.handleBatchDeleteMessages(w, r, mailbox)
+	case strings.HasPrefix(path, "messages/") && r.Method == http.MethodPost:
+		// messages/{id}/modify, messages/{id}/trash and messages/{id}/untrash
+		parts := strings.Split(path, "/")
+		if len(parts) != 3 || parts[1] == "" {
+			apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+			return
+		}
+		switch parts[2] {
+		case "modify":
+			h.handleModifyMessage(w, r, mailbox, parts[1])
+		case "trash":
+			h.handleTrashMessage(w, r, mailbox, parts[1])
+		case "untrash":
+			h.handleUntrashMessage(w, r, mailbox, parts[1])
+		default:
+			apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		}
+	case path == "threads" && r.Method == http.MethodGet:
+		h.handleListThreads(w, r, mailbox)
+	case strings.HasPrefix(path, "threads/") && r.Method == http.MethodGet:
+		parts := strings.Split(path, "/")
+		if len(parts) == 2 && parts[1] != "" {
+			h.handleGetThread(w, r, mailbox, parts[1])
+		} else {
+			apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		}
+	case strings.HasPrefix(path, "threads/") && r.Method == http.MethodPost:
+		// threads/{id}/modify
+		parts := strings.Split(path, "/")
+		if len(parts) == 3 && parts[1] != "" && parts[2] == "modify" {
+			h.handleModifyThread(w, r, mailbox, parts[1])
+		} else {
+			apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		}
+	case strings.HasPrefix(path, "messages/") && r.Method == http.MethodDelete:
+		parts := strings.Split(path, "/")
+		if len(parts) == 2 && parts[1] != "" {
+			h.handleDeleteMessage(w, r, mailbox, parts[1])
+		} else {
+			apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid message ID")
+		}
 	case strings.HasPrefix(path, "messages/") && strings.Contains(path, "/attachments/") && r.Method == http.MethodGet:
 		// Extract message ID and attachment ID from path: messages/{msgId}/attachments/{attachmentId}
 		parts := strings.Split(path, "/")
 		if len(parts) >= 4 && parts[2] == "attachments" {
 			messageID := parts[1]
 			attachmentID := parts[3]
-			h.handleGetAttachment(w, r, messageID, attachmentID)
+			h.handleGetAttachment(w, r, mailbox, messageID, attachmentID)
 		} else {
-			http.Error(w, "Invalid attachment path", http.StatusBadRequest)
+			apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid attachment path")
 		}
 	case strings.HasPrefix(path, "messages/") && r.Method == http.MethodGet:
 		// Extract message ID from path
 		parts := strings.Split(path, "/")
 		if len(parts) >= 2 {
 			messageID := parts[1]
-			h.handleGetMessage(w, r, messageID)
+			h.handleGetMessage(w, r, mailbox, messageID)
 		} else {
-			http.Error(w, "Invalid message ID", http.StatusBadRequest)
+			apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid message ID")
 		}
 	case path == "messages" && r.Method == http.MethodGet:
-		h.handleListMessages(w, r)
+		h.handleListMessages(w, r, mailbox)
+	case path == "watch" && r.Method == http.MethodPost:
+		h.handleWatch(w, r)
+	case path == "stop" && r.Method == http.MethodPost:
+		h.handleStop(w, r)
+	case path == "settings/sendAs" || strings.HasPrefix(path, "settings/sendAs/"):
+		h.handleSendAs(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "settings/sendAs"), "/"))
 	default:
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+	}
+}
+
+// resolveMailbox maps a {userId} path segment to the mailbox it names within the session. "me"
+// and the session's primary send-as address are the authenticated user; any other address is a
+// mailbox of its own.
+func (h *Handler) resolveMailbox(sessionID, userID string) (string, error) {
+	userID = strings.ToLower(userID)
+	if userID == "me" {
+		return primaryMailbox, nil
+	}
+	primary, err := h.primarySendAsEmail(sessionID)
+	if err != nil {
+		return "", err
+	}
+	if userID == strings.ToLower(primary) {
+		return primaryMailbox, nil
+	}
+	return userID, nil
+}
+
+// primarySendAsEmail returns the session's primary send-as address, or the default for sessions
+// that have not configured any
+func (h *Handler) primarySendAsEmail(sessionID string) (string, error) {
+	aliases, err := h.queries.ListGmailSendAsAliases(context.Background(), sessionID)
+	if err != nil {
+		return "", err
 	}
+	// The primary sorts first
+	if len(aliases) > 0 && aliases[0].IsPrimary == 1 {
+		return aliases[0].SendAsEmail, nil
+	}
+	return defaultSendAsEmail, nil
 }
 
-func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request, mailbox string) {
 	log.Println("[gmail] → Received send message request")
 
 	var req struct {
-		Raw string `json:"raw"`
+		Raw      string `json:"raw"`
+		ThreadID string `json:"threadId,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[gmail] ✗ Failed to decode request: %v", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
 		return
 	}
 
@@ -164,25 +374,30 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 	rawBytes, err := base64.URLEncoding.DecodeString(req.Raw)
 	if err != nil {
 		log.Printf("[gmail] ✗ Failed to decode base64: %v", err)
-		http.Error(w, "Invalid base64 encoding", http.StatusBadRequest)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid base64 encoding")
 		return
 	}
 
 	rawMessage := string(rawBytes)
 	log.Printf("[gmail]   Raw message: %s", rawMessage)
 
-	// Parse email headers and attachments
-	parsed := parseEmailWithAttachments(rawMessage)
-
-	// Generate message ID and thread ID
-	messageID := generateMessageID()
-	threadID := messageID // For new messages, thread ID equals message ID
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Parse email headers and attachments
+	parsed := parseEmailWithAttachments(sessionID, rawMessage)
+
+	// Generate message ID and resolve the thread it joins
+	messageID := generateMessageID(sessionID)
+	threadID, err := h.threadFor(r.Context(), sessionID, mailbox, req.ThreadID, messageID)
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to look up thread: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
 	// Store message in database
-	internalDate := time.Now().UnixMilli()
+	internalDate := session.Now(sessionID).UnixMilli()
 	snippet := generateSnippet(parsed.bodyPlain, parsed.bodyHTML)
 
 	err = h.queries.CreateGmailMessage(context.Background(), database.CreateGmailMessageParams{
@@ -190,6 +405,9 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 		ThreadID:     threadID,
 		FromEmail:    parsed.from,
 		ToEmail:      parsed.to,
+		CcEmail:      parsed.cc,
+		ToAddresses:  searchableAddresses(parsed.to),
+		CcAddresses:  searchableAddresses(parsed.cc),
 		Subject:      parsed.subject,
 		BodyPlain:    sql.NullString{String: parsed.bodyPlain, Valid: parsed.bodyPlain != ""},
 		BodyHtml:     sql.NullString{String: parsed.bodyHTML, Valid: parsed.bodyHTML != ""},
@@ -199,11 +417,12 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 		InternalDate: internalDate,
 		SizeEstimate: int64(len(rawMessage)),
 		SessionID:    sessionID,
+		UserID:       mailbox,
 	})
 
 	if err != nil {
 		log.Printf("[gmail] ✗ Failed to store message: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -220,7 +439,7 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 		})
 		if err != nil {
 			log.Printf("[gmail] ✗ Failed to store attachment: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 	}
@@ -239,17 +458,18 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[gmail] ✓ Message sent: %s", messageID)
 }
 
-func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request, mailbox string) {
 	log.Println("[gmail] → Received import message request")
 
 	var req struct {
 		Raw      string   `json:"raw"`
+		ThreadID string   `json:"threadId,omitempty"`
 		LabelIDs []string `json:"labelIds,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[gmail] ✗ Failed to decode request: %v", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
 		return
 	}
 
@@ -257,23 +477,28 @@ func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
 	rawBytes, err := base64.URLEncoding.DecodeString(req.Raw)
 	if err != nil {
 		log.Printf("[gmail] ✗ Failed to decode base64: %v", err)
-		http.Error(w, "Invalid base64 encoding", http.StatusBadRequest)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid base64 encoding")
 		return
 	}
 
 	rawMessage := string(rawBytes)
 	log.Printf("[gmail]   Raw message: %s", rawMessage)
 
-	// Parse email headers and attachments
-	parsed := parseEmailWithAttachments(rawMessage)
-
-	// Generate message ID and thread ID
-	messageID := generateMessageID()
-	threadID := messageID // For new messages, thread ID equals message ID
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Parse email headers and attachments
+	parsed := parseEmailWithAttachments(sessionID, rawMessage)
+
+	// Generate message ID and resolve the thread it joins
+	messageID := generateMessageID(sessionID)
+	threadID, err := h.threadFor(r.Context(), sessionID, mailbox, req.ThreadID, messageID)
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to look up thread: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
 	// Use provided labels or default to INBOX + UNREAD
 	labels := req.LabelIDs
 	if len(labels) == 0 {
@@ -282,7 +507,7 @@ func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
 	labelJSON, _ := json.Marshal(labels)
 
 	// Store message in database
-	internalDate := time.Now().UnixMilli()
+	internalDate := session.Now(sessionID).UnixMilli()
 	snippet := generateSnippet(parsed.bodyPlain, parsed.bodyHTML)
 
 	err = h.queries.CreateGmailMessage(context.Background(), database.CreateGmailMessageParams{
@@ -290,6 +515,9 @@ func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
 		ThreadID:     threadID,
 		FromEmail:    parsed.from,
 		ToEmail:      parsed.to,
+		CcEmail:      parsed.cc,
+		ToAddresses:  searchableAddresses(parsed.to),
+		CcAddresses:  searchableAddresses(parsed.cc),
 		Subject:      parsed.subject,
 		BodyPlain:    sql.NullString{String: parsed.bodyPlain, Valid: parsed.bodyPlain != ""},
 		BodyHtml:     sql.NullString{String: parsed.bodyHTML, Valid: parsed.bodyHTML != ""},
@@ -299,11 +527,12 @@ func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
 		InternalDate: internalDate,
 		SizeEstimate: int64(len(rawMessage)),
 		SessionID:    sessionID,
+		UserID:       mailbox,
 	})
 
 	if err != nil {
 		log.Printf("[gmail] ✗ Failed to store message: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -320,7 +549,7 @@ func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
 		})
 		if err != nil {
 			log.Printf("[gmail] ✗ Failed to store attachment: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 	}
@@ -339,7 +568,7 @@ func (h *Handler) handleImportMessage(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[gmail] ✓ Message imported: %s", messageID)
 }
 
-func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request, mailbox string) {
 	log.Println("[gmail] → Received list messages request")
 
 	query := r.URL.Query()
@@ -350,21 +579,28 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 			maxResults = mr
 		}
 	}
+	maxResults, clamped := listcap.Clamp(maxResults)
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
 
 	// Parse page token for offset
 	offset := 0
+	filters := pagetoken.Filters(query, pagingParams...)
 	pageToken := query.Get("pageToken")
 	if pageToken != "" {
-		if decodedOffset, err := decodePageToken(pageToken); err == nil {
-			offset = decodedOffset
+		decodedOffset, err := pagetoken.Decode(sessionID, messagesPageScope, filters, pageToken)
+		if err != nil {
+			log.Printf("[gmail] ✗ Rejected page token: %v", err)
+			apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid pageToken")
+			return
 		}
+		offset = decodedOffset
 	}
 
-	// Extract session ID from context
-	sessionID := session.FromContext(r.Context())
-
 	// Check if search query is present
 	searchQuery := query.Get("q")
+	includeTrash := query.Get("includeSpamTrash") == "true"
 
 	var messages []MessageListItem
 
@@ -386,11 +622,15 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 			Column9:   sql.NullString{String: params.body, Valid: true},
 			Column10:  params.label,
 			Column11:  sql.NullString{String: params.label, Valid: true},
+			Column12:  params.cc,
+			Column13:  sql.NullString{String: params.cc, Valid: true},
+			Column14:  boolToInt(includeTrash || params.label == labelTrash),
+			UserID:    mailbox,
 			Limit:     int64(maxResults),
 		})
 		if err != nil {
 			log.Printf("[gmail] ✗ Failed to search messages: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 
@@ -404,31 +644,23 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 	} else {
 		// List all messages with pagination
 		// Request one extra to check if there are more results
-		dbMessages, err := h.queries.ListGmailMessages(context.Background(), database.ListGmailMessagesParams{
-			SessionID: sessionID,
-			Limit:     int64(maxResults + 1),
-			Offset:    int64(offset),
-		})
+		var err error
+		messages, err = h.listMessages(r.Context(), mailbox, maxResults+1, offset, includeTrash)
 		if err != nil {
 			log.Printf("[gmail] ✗ Failed to list messages: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
 			return
 		}
-
-		messages = make([]MessageListItem, 0, len(dbMessages))
-		for i := range dbMessages {
-			messages = append(messages, MessageListItem{
-				ID:       dbMessages[i].ID,
-				ThreadID: dbMessages[i].ThreadID,
-			})
-		}
 	}
 
 	// Generate next page token if there are more results
 	var nextPageToken string
 	if len(messages) > maxResults {
 		messages = messages[:maxResults]
-		nextPageToken = encodePageToken(offset + maxResults)
+		nextPageToken = pagetoken.Encode(sessionID, messagesPageScope, filters, offset+maxResults)
+		if clamped {
+			listcap.MarkTruncated(w)
+		}
 	}
 
 	response := MessageListResponse{
@@ -442,29 +674,171 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[gmail] ✓ Listed %d messages", len(messages))
 }
 
-func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, messageID string) {
+func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, mailbox, messageID string) {
 	log.Printf("[gmail] → Received get message request for ID: %s", messageID)
 
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = messageFormatFull
+	}
+	if !validMessageFormat(format) {
+		log.Printf("[gmail] ✗ Unsupported format: %s", format)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, fmt.Sprintf("Invalid value at 'format', %q", format))
+		return
+	}
+
+	// Query message from database, falling through to the parent session. Its attachments
+	// live in whichever session holds the message.
+	dbMessage, sessionID, err := h.readMessage(r.Context(), mailbox, messageID)
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get message: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+
+	message := h.buildMessage(sessionID, dbMessage)
+	trimMessage(&message, format, r.URL.Query()["metadataHeaders"])
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(message)
+	log.Printf("[gmail] ✓ Returned message: %s (format=%s)", messageID, format)
+}
+
+// handleListThreads lists the mailbox's conversations, most recently active first
+func (h *Handler) handleListThreads(w http.ResponseWriter, r *http.Request, mailbox string) {
+	log.Println("[gmail] → Received list threads request")
+
+	query := r.URL.Query()
+	maxResults := 100
+	if mr, err := strconv.Atoi(query.Get("maxResults")); err == nil {
+		maxResults = mr
+	}
+	maxResults, clamped := listcap.Clamp(maxResults)
+
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
-	// Query message from database
-	dbMessage, err := h.queries.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
-		ID:        messageID,
+	// Parse page token for offset
+	offset := 0
+	filters := pagetoken.Filters(query, pagingParams...)
+	if pageToken := query.Get("pageToken"); pageToken != "" {
+		decodedOffset, err := pagetoken.Decode(sessionID, threadsPageScope, filters, pageToken)
+		if err != nil {
+			log.Printf("[gmail] ✗ Rejected page token: %v", err)
+			apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid pageToken")
+			return
+		}
+		offset = decodedOffset
+	}
+
+	// Request one extra to check if there are more results
+	dbThreads, err := h.queries.ListGmailThreads(context.Background(), database.ListGmailThreadsParams{
+		SessionID:    sessionID,
+		UserID:       mailbox,
+		IncludeTrash: boolToInt(query.Get("includeSpamTrash") == "true"),
+		Limit:        int64(maxResults + 1),
+		Offset:       int64(offset),
+	})
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to list threads: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	threads := make([]ThreadListItem, 0, len(dbThreads))
+	for i := range dbThreads {
+		threads = append(threads, ThreadListItem{
+			ID:           dbThreads[i].ThreadID,
+			MessageCount: int(dbThreads[i].MessageCount),
+		})
+	}
+
+	// Generate next page token if there are more results
+	var nextPageToken string
+	if len(threads) > maxResults {
+		threads = threads[:maxResults]
+		nextPageToken = pagetoken.Encode(sessionID, threadsPageScope, filters, offset+maxResults)
+		if clamped {
+			listcap.MarkTruncated(w)
+		}
+	}
+
+	response := ThreadListResponse{
+		Threads:            threads,
+		NextPageToken:      nextPageToken,
+		ResultSizeEstimate: len(threads),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[gmail] ✓ Listed %d threads", len(threads))
+}
+
+// handleGetThread returns every message of a thread, oldest first
+func (h *Handler) handleGetThread(w http.ResponseWriter, r *http.Request, mailbox, threadID string) {
+	log.Printf("[gmail] → Received get thread request for ID: %s", threadID)
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = messageFormatFull
+	}
+	if !validMessageFormat(format) {
+		log.Printf("[gmail] ✗ Unsupported format: %s", format)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, fmt.Sprintf("Invalid value at 'format', %q", format))
+		return
+	}
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	dbMessages, err := h.queries.ListGmailMessagesByThread(context.Background(), database.ListGmailMessagesByThreadParams{
+		ThreadID:  threadID,
 		SessionID: sessionID,
+		UserID:    mailbox,
 	})
 	if err != nil {
-		log.Printf("[gmail] ✗ Failed to get message: %v", err)
-		http.NotFound(w, r)
+		log.Printf("[gmail] ✗ Failed to get thread: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if len(dbMessages) == 0 {
+		log.Printf("[gmail] ✗ Thread not found: %s", threadID)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
 		return
 	}
 
-	// Parse label IDs
-	var labelIDs []string
-	if dbMessage.LabelIds.Valid && dbMessage.LabelIds.String != "" {
-		_ = json.Unmarshal([]byte(dbMessage.LabelIds.String), &labelIDs)
+	messages := make([]Message, 0, len(dbMessages))
+	for i := range dbMessages {
+		dbMessage, err := h.queries.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
+			ID:        dbMessages[i].ID,
+			SessionID: sessionID,
+			UserID:    mailbox,
+		})
+		if err != nil {
+			log.Printf("[gmail] ✗ Failed to get message: %v", err)
+			apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		message := h.buildMessage(sessionID, dbMessage)
+		trimMessage(&message, format, r.URL.Query()["metadataHeaders"])
+		messages = append(messages, message)
+	}
+
+	response := Thread{
+		ID:       threadID,
+		Messages: messages,
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[gmail] ✓ Returned thread %s with %d message(s) (format=%s)", threadID, len(messages), format)
+}
+
+// buildMessage assembles the full representation of a stored message. Attachments are looked
+// up in sessionID, the session that holds the message.
+func (h *Handler) buildMessage(sessionID string, dbMessage database.GetGmailMessageByIDRow) Message {
+	labelIDs := decodeLabelIDs(dbMessage.LabelIds)
+
 	// Build headers
 	headers := []Header{
 		{Name: "From", Value: dbMessage.FromEmail},
@@ -472,6 +846,9 @@ func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, messa
 		{Name: "Subject", Value: dbMessage.Subject},
 		{Name: "Date", Value: time.UnixMilli(dbMessage.InternalDate).Format(time.RFC1123Z)},
 	}
+	if dbMessage.CcEmail != "" {
+		headers = append(headers, Header{Name: "Cc", Value: dbMessage.CcEmail})
+	}
 
 	// Build message parts
 	var parts []MessagePart
@@ -503,11 +880,18 @@ func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, messa
 		})
 	}
 
-	// Get attachments for this message
+	// Get attachments for this message. A message a child session copied from its parent
+	// keeps its attachments in the parent.
 	attachments, err := h.queries.ListGmailAttachmentsByMessage(context.Background(), database.ListGmailAttachmentsByMessageParams{
-		MessageID: messageID,
+		MessageID: dbMessage.ID,
 		SessionID: sessionID,
 	})
+	if parentID := session.Parent(sessionID); err == nil && len(attachments) == 0 && parentID != "" {
+		attachments, err = h.queries.ListGmailAttachmentsByMessage(context.Background(), database.ListGmailAttachmentsByMessageParams{
+			MessageID: dbMessage.ID,
+			SessionID: parentID,
+		})
+	}
 	if err == nil {
 		// Add attachment parts (data not included, only metadata)
 		for i, att := range attachments {
@@ -531,7 +915,7 @@ func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, messa
 		mimeType = "multipart/mixed"
 	}
 
-	message := Message{
+	return Message{
 		ID:           dbMessage.ID,
 		ThreadID:     dbMessage.ThreadID,
 		LabelIDs:     labelIDs,
@@ -547,33 +931,75 @@ func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, messa
 			Parts:    parts,
 		},
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(message)
-	log.Printf("[gmail] ✓ Returned message: %s", messageID)
+func validMessageFormat(format string) bool {
+	return format == messageFormatFull || format == messageFormatMetadata || format == messageFormatMinimal
 }
 
-func (h *Handler) handleGetAttachment(w http.ResponseWriter, r *http.Request, messageID, attachmentID string) {
-	log.Printf("[gmail] → Received get attachment request for message: %s, attachment: %s", messageID, attachmentID)
+// trimMessage reduces a full message to what the requested format returns: minimal drops the
+// payload, metadata keeps headers (optionally filtered by metadataHeaders) but no body data
+func trimMessage(message *Message, format string, metadataHeaders []string) {
+	switch format {
+	case messageFormatMinimal:
+		message.Payload = nil
+	case messageFormatMetadata:
+		payload := message.Payload
+		if len(metadataHeaders) > 0 {
+			payload.Headers = filterHeaders(payload.Headers, metadataHeaders)
+		}
+		for i := range payload.Parts {
+			if payload.Parts[i].Body != nil {
+				payload.Parts[i].Body.Data = ""
+			}
+		}
+		if payload.Body != nil {
+			payload.Body.Data = ""
+		}
+	}
+}
 
-	// Extract session ID from context
-	sessionID := session.FromContext(r.Context())
+// filterHeaders keeps headers whose names match one of names, case-insensitively
+func filterHeaders(headers []Header, names []string) []Header {
+	filtered := make([]Header, 0, len(headers))
+	for _, header := range headers {
+		for _, name := range names {
+			if strings.EqualFold(header.Name, name) {
+				filtered = append(filtered, header)
+				break
+			}
+		}
+	}
+	return filtered
+}
 
-	// Query attachment from database
-	attachment, err := h.queries.GetGmailAttachment(context.Background(), database.GetGmailAttachmentParams{
-		ID:        attachmentID,
-		SessionID: sessionID,
+func (h *Handler) handleGetAttachment(w http.ResponseWriter, r *http.Request, mailbox, messageID, attachmentID string) {
+	log.Printf("[gmail] → Received get attachment request for message: %s, attachment: %s", messageID, attachmentID)
+
+	// Query attachment from database, falling through to the parent session
+	attachment, err := session.ReadThrough(r.Context(), "gmail_attachments", attachmentID, func(candidate string) (database.GetGmailAttachmentRow, error) {
+		return h.queries.GetGmailAttachment(context.Background(), database.GetGmailAttachmentParams{
+			ID:        attachmentID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[gmail] ✗ Failed to get attachment: %v", err)
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
 		return
 	}
 
 	// Verify attachment belongs to the requested message
 	if attachment.MessageID != messageID {
 		log.Printf("[gmail] ✗ Attachment does not belong to message")
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+
+	// Verify the parent message still exists
+	if _, _, err := h.readMessage(r.Context(), mailbox, messageID); err != nil {
+		log.Printf("[gmail] ✗ Parent message not found: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
 		return
 	}
 
@@ -593,59 +1019,827 @@ func (h *Handler) handleGetAttachment(w http.ResponseWriter, r *http.Request, me
 	log.Printf("[gmail] ✓ Returned attachment: %s", attachmentID)
 }
 
-// Helper functions
+func (h *Handler) handleDeleteMessage(w http.ResponseWriter, r *http.Request, mailbox, messageID string) {
+	log.Printf("[gmail] → Received delete message request for ID: %s", messageID)
 
-type attachment struct {
-	ID       string
-	Filename string
-	MimeType string
-	Data     []byte
-	Size     int
-}
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
 
-type searchParams struct {
-	from    string
-	to      string
-	subject string
-	body    string
-	label   string
-}
+	_, _, err := h.readMessage(r.Context(), mailbox, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[gmail] ✗ Message not found: %s", messageID)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get message: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
 
-type emailParseResult struct {
-	from        string
-	to          string
-	subject     string
-	bodyPlain   string
-	bodyHTML    string
-	attachments []attachment
+	if err := h.deleteMessages(sessionID, session.ParentFromContext(r.Context()), mailbox, []string{messageID}); err != nil {
+		log.Printf("[gmail] ✗ Failed to delete message: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("[gmail] ✓ Deleted message: %s", messageID)
 }
 
-func parseSearchQuery(q string) searchParams {
-	params := searchParams{}
-	if q == "" {
-		return params
+func (h *Handler) handleBatchDeleteMessages(w http.ResponseWriter, r *http.Request, mailbox string) {
+	log.Println("[gmail] → Received batch delete messages request")
+
+	var req struct {
+		IDs []string `json:"ids"`
 	}
 
-	// Simple parser for Gmail search syntax
-	// Supports: from:, to:, subject:, is:unread, is:read, label:
-	parts := strings.Fields(q)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[gmail] ✗ Failed to decode request: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
+		return
+	}
 
-	for _, part := range parts {
-		switch {
-		case strings.HasPrefix(part, "from:"):
-			params.from = strings.TrimPrefix(part, "from:")
-		case strings.HasPrefix(part, "to:"):
-			params.to = strings.TrimPrefix(part, "to:")
-		case strings.HasPrefix(part, "subject:"):
-			params.subject = strings.TrimPrefix(part, "subject:")
-		case part == "is:unread":
-			params.label = "UNREAD"
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	if err := h.deleteMessages(sessionID, session.ParentFromContext(r.Context()), mailbox, req.IDs); err != nil {
+		log.Printf("[gmail] ✗ Failed to batch delete messages: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("[gmail] ✓ Batch deleted %d messages", len(req.IDs))
+}
+
+func (h *Handler) handleModifyMessage(w http.ResponseWriter, r *http.Request, mailbox, messageID string) {
+	log.Printf("[gmail] → Received modify message request for ID: %s", messageID)
+
+	var req ModifyMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[gmail] ✗ Failed to decode request: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	h.updateMessageLabels(w, r, mailbox, messageID, func(labels []string) []string {
+		return modifyLabels(labels, req.AddLabelIDs, req.RemoveLabelIDs)
+	})
+}
+
+// handleTrashMessage moves a message to the trash, taking it out of the inbox
+func (h *Handler) handleTrashMessage(w http.ResponseWriter, r *http.Request, mailbox, messageID string) {
+	log.Printf("[gmail] → Received trash message request for ID: %s", messageID)
+
+	h.updateMessageLabels(w, r, mailbox, messageID, func(labels []string) []string {
+		return modifyLabels(labels, []string{labelTrash}, []string{labelInbox})
+	})
+}
+
+// handleUntrashMessage takes a message out of the trash. Received mail returns to the inbox;
+// sent mail, which never had the INBOX label, does not.
+func (h *Handler) handleUntrashMessage(w http.ResponseWriter, r *http.Request, mailbox, messageID string) {
+	log.Printf("[gmail] → Received untrash message request for ID: %s", messageID)
+
+	h.updateMessageLabels(w, r, mailbox, messageID, func(labels []string) []string {
+		labels = modifyLabels(labels, nil, []string{labelTrash})
+		if slices.Contains(labels, labelSent) {
+			return labels
+		}
+		return modifyLabels(labels, []string{labelInbox}, nil)
+	})
+}
+
+// handleModifyThread applies a label change to every message of a thread at once
+func (h *Handler) handleModifyThread(w http.ResponseWriter, r *http.Request, mailbox, threadID string) {
+	log.Printf("[gmail] → Received modify thread request for ID: %s", threadID)
+
+	// threads.modify takes the same body as messages.modify
+	var req ModifyMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[gmail] ✗ Failed to decode request: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	var messages []Message
+	err := h.queries.ExecTx(r.Context(), func(q *database.Queries) error {
+		rows, err := q.ListGmailMessagesByThread(r.Context(), database.ListGmailMessagesByThreadParams{
+			ThreadID:  threadID,
+			SessionID: sessionID,
+			UserID:    mailbox,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return sql.ErrNoRows
+		}
+
+		for i := range rows {
+			labels := modifyLabels(decodeLabelIDs(rows[i].LabelIds), req.AddLabelIDs, req.RemoveLabelIDs)
+			labelJSON, _ := json.Marshal(labels)
+			err := q.UpdateGmailMessageLabels(r.Context(), database.UpdateGmailMessageLabelsParams{
+				LabelIds:  sql.NullString{String: string(labelJSON), Valid: true},
+				ID:        rows[i].ID,
+				SessionID: sessionID,
+				UserID:    mailbox,
+			})
+			if err != nil {
+				return err
+			}
+			messages = append(messages, Message{
+				ID:       rows[i].ID,
+				ThreadID: rows[i].ThreadID,
+				LabelIDs: labels,
+			})
+		}
+		return nil
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[gmail] ✗ Thread not found: %s", threadID)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to modify thread: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := Thread{
+		ID:       threadID,
+		Messages: messages,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[gmail] ✓ Updated labels of %d message(s) in thread %s", len(messages), threadID)
+}
+
+// threadFor returns the thread a new message joins: the requested thread when it exists in the
+// mailbox, otherwise a new thread whose ID equals the message ID
+func (h *Handler) threadFor(ctx context.Context, sessionID, mailbox, requested, messageID string) (string, error) {
+	if requested == "" {
+		return messageID, nil
+	}
+	existing, err := h.queries.ListGmailMessagesByThread(ctx, database.ListGmailMessagesByThreadParams{
+		ThreadID:  requested,
+		SessionID: sessionID,
+		UserID:    mailbox,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(existing) == 0 {
+		return messageID, nil
+	}
+	return requested, nil
+}
+
+// updateMessageLabels stores the labels change computes from a message's current labels and
+// responds with the updated message
+func (h *Handler) updateMessageLabels(w http.ResponseWriter, r *http.Request, mailbox, messageID string, change func([]string) []string) {
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	dbMessage, err := h.ownMessage(r.Context(), mailbox, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[gmail] ✗ Message not found: %s", messageID)
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get message: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	labels := change(decodeLabelIDs(dbMessage.LabelIds))
+	labelJSON, _ := json.Marshal(labels)
+	err = h.queries.UpdateGmailMessageLabels(context.Background(), database.UpdateGmailMessageLabelsParams{
+		LabelIds:  sql.NullString{String: string(labelJSON), Valid: true},
+		ID:        messageID,
+		SessionID: sessionID,
+		UserID:    mailbox,
+	})
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to update labels: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := Message{
+		ID:       dbMessage.ID,
+		ThreadID: dbMessage.ThreadID,
+		LabelIDs: labels,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[gmail] ✓ Updated labels of message %s: %v", messageID, labels)
+}
+
+// readMessage looks a message up in the request's session and then in the parent session,
+// unless the child has deleted it. It also returns the session that holds the message.
+func (h *Handler) readMessage(ctx context.Context, mailbox, messageID string) (database.GetGmailMessageByIDRow, string, error) {
+	sessionID := session.FromContext(ctx)
+	dbMessage, err := h.queries.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
+		ID:        messageID,
+		SessionID: sessionID,
+		UserID:    mailbox,
+	})
+	parentID := session.ParentFromContext(ctx)
+	if !errors.Is(err, sql.ErrNoRows) || parentID == "" {
+		return dbMessage, sessionID, err
+	}
+
+	_, err = h.queries.GetGmailMessageTombstone(context.Background(), database.GetGmailMessageTombstoneParams{
+		SessionID: sessionID,
+		MessageID: messageID,
+	})
+	if err == nil {
+		return dbMessage, sessionID, sql.ErrNoRows
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return dbMessage, sessionID, err
+	}
+
+	dbMessage, err = h.queries.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
+		ID:        messageID,
+		SessionID: parentID,
+		UserID:    mailbox,
+	})
+	return dbMessage, parentID, err
+}
+
+// ownMessage returns a message of the request's session for writing. A message that is only
+// visible through the parent session is first copied into the child, so writes never reach
+// the parent.
+func (h *Handler) ownMessage(ctx context.Context, mailbox, messageID string) (database.GetGmailMessageByIDRow, error) {
+	sessionID := session.FromContext(ctx)
+	dbMessage, holder, err := h.readMessage(ctx, mailbox, messageID)
+	if err != nil || holder == sessionID {
+		return dbMessage, err
+	}
+
+	if err := h.queries.CopyGmailMessageToSession(context.Background(), database.CopyGmailMessageToSessionParams{
+		SessionID:       sessionID,
+		ID:              messageID,
+		ParentSessionID: holder,
+		UserID:          mailbox,
+	}); err != nil {
+		return dbMessage, err
+	}
+	return h.queries.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
+		ID:        messageID,
+		SessionID: sessionID,
+		UserID:    mailbox,
+	})
+}
+
+// listMessages returns a page of a mailbox's messages, newest first, including those of the
+// parent session if it has one
+func (h *Handler) listMessages(ctx context.Context, mailbox string, limit, offset int, includeTrash bool) ([]MessageListItem, error) {
+	sessionID := session.FromContext(ctx)
+
+	var messages []MessageListItem
+	if parentID := session.ParentFromContext(ctx); parentID != "" {
+		dbMessages, err := h.queries.ListGmailMessagesWithParent(context.Background(), database.ListGmailMessagesWithParentParams{
+			SessionID:       sessionID,
+			ParentSessionID: parentID,
+			UserID:          mailbox,
+			IncludeTrash:    boolToInt(includeTrash),
+			Limit:           int64(limit),
+			Offset:          int64(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = make([]MessageListItem, 0, len(dbMessages))
+		for i := range dbMessages {
+			messages = append(messages, MessageListItem{
+				ID:       dbMessages[i].ID,
+				ThreadID: dbMessages[i].ThreadID,
+			})
+		}
+		return messages, nil
+	}
+
+	dbMessages, err := h.queries.ListGmailMessages(context.Background(), database.ListGmailMessagesParams{
+		SessionID:    sessionID,
+		UserID:       mailbox,
+		IncludeTrash: boolToInt(includeTrash),
+		Limit:        int64(limit),
+		Offset:       int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	messages = make([]MessageListItem, 0, len(dbMessages))
+	for i := range dbMessages {
+		messages = append(messages, MessageListItem{
+			ID:       dbMessages[i].ID,
+			ThreadID: dbMessages[i].ThreadID,
+		})
+	}
+	return messages, nil
+}
+
+// deleteMessages removes a mailbox's messages together with their attachments in a single
+// transaction so attachment ids never outlive their parent message. Messages of the parent
+// session are left in place and hidden from the child with a tombstone.
+func (h *Handler) deleteMessages(sessionID, parentID, mailbox string, messageIDs []string) error {
+	return h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+		for _, messageID := range messageIDs {
+			if parentID != "" {
+				_, err := q.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
+					ID:        messageID,
+					SessionID: parentID,
+					UserID:    mailbox,
+				})
+				if err == nil {
+					err = q.CreateGmailMessageTombstone(context.Background(), database.CreateGmailMessageTombstoneParams{
+						SessionID: sessionID,
+						MessageID: messageID,
+					})
+				}
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+			}
+
+			// IDs from another mailbox in the session are skipped, as are unknown IDs
+			_, err := q.GetGmailMessageByID(context.Background(), database.GetGmailMessageByIDParams{
+				ID:        messageID,
+				SessionID: sessionID,
+				UserID:    mailbox,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := q.DeleteGmailAttachmentsByMessage(context.Background(), database.DeleteGmailAttachmentsByMessageParams{
+				MessageID: messageID,
+				SessionID: sessionID,
+			}); err != nil {
+				return err
+			}
+			if err := q.DeleteGmailMessage(context.Background(), database.DeleteGmailMessageParams{
+				ID:        messageID,
+				SessionID: sessionID,
+				UserID:    mailbox,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Push notification handlers. Pub/Sub delivery is not simulated; the requested watch
+// is recorded so tests can assert a client configured it.
+
+func (h *Handler) handleWatch(w http.ResponseWriter, r *http.Request) {
+	log.Println("[gmail] → Received watch request")
+
+	var req WatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[gmail] ✗ Failed to decode request: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	if !topicNamePattern.MatchString(req.TopicName) {
+		log.Printf("[gmail] ✗ Invalid topic name: %q", req.TopicName)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid topicName does not match projects/SOME-PROJECT/topics/SOME-TOPIC")
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	now := session.Now(sessionID)
+	historyID := now.UnixMilli()
+	expiration := now.Add(watchDuration).UnixMilli()
+
+	err := h.queries.UpsertGmailWatch(context.Background(), database.UpsertGmailWatchParams{
+		SessionID:           sessionID,
+		TopicName:           req.TopicName,
+		LabelIds:            strings.Join(req.LabelIDs, ","),
+		LabelFilterBehavior: req.LabelFilterBehavior,
+		HistoryID:           historyID,
+		Expiration:          expiration,
+	})
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to store watch: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(WatchResponse{
+		HistoryID:  strconv.FormatInt(historyID, 10),
+		Expiration: strconv.FormatInt(expiration, 10),
+	})
+	log.Printf("[gmail] ✓ Watching topic: %s", req.TopicName)
+}
+
+func (h *Handler) handleStop(w http.ResponseWriter, r *http.Request) {
+	log.Println("[gmail] → Received stop request")
+
+	sessionID := session.FromContext(r.Context())
+	err := h.queries.StopGmailWatch(context.Background(), database.StopGmailWatchParams{
+		StoppedAt: sql.NullInt64{Int64: session.Now(sessionID).UnixMilli(), Valid: true},
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to stop watch: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Println("[gmail] ✓ Stopped watch")
+}
+
+// ExpireWatches stops the session's watch once the session clock passes its expiration, the way
+// Gmail drops watches that are not renewed. It reports whether a watch expired.
+func ExpireWatches(ctx context.Context, queries *database.Queries, sessionID string, now time.Time) (int, error) {
+	expired, err := queries.ExpireGmailWatch(ctx, database.ExpireGmailWatchParams{
+		SessionID:  sessionID,
+		Expiration: now.UnixMilli(),
+	})
+	if err != nil || expired == 0 {
+		return 0, err
+	}
+
+	log.Println("[gmail] ✓ Watch expired")
+	return int(expired), nil
+}
+
+func (h *Handler) handleGetWatch(w http.ResponseWriter, r *http.Request) {
+	log.Println("[gmail] → Received debug watch request")
+
+	if r.Method != http.MethodGet {
+		apierror.Write(w, apierror.Google, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	watch, err := h.queries.GetGmailWatch(context.Background(), session.FromContext(r.Context()))
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("[gmail] ✗ No watch configured")
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get watch: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	labelIDs := []string{}
+	if watch.LabelIds != "" {
+		labelIDs = strings.Split(watch.LabelIds, ",")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(WatchState{
+		TopicName:           watch.TopicName,
+		LabelIDs:            labelIDs,
+		LabelFilterBehavior: watch.LabelFilterBehavior,
+		HistoryID:           strconv.FormatInt(watch.HistoryID, 10),
+		Expiration:          strconv.FormatInt(watch.Expiration, 10),
+		Active:              !watch.StoppedAt.Valid,
+	})
+}
+
+func (h *Handler) handleSendAs(w http.ResponseWriter, r *http.Request, email string) {
+	switch {
+	case email == "" && r.Method == http.MethodGet:
+		h.handleListSendAs(w, r)
+	case email == "" && r.Method == http.MethodPost:
+		h.handleCreateSendAs(w, r)
+	case email != "" && r.Method == http.MethodGet:
+		h.handleGetSendAs(w, r, email)
+	case email != "" && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+		h.handleUpdateSendAs(w, r, email)
+	case email != "" && r.Method == http.MethodDelete:
+		h.handleDeleteSendAs(w, r, email)
+	default:
+		apierror.Write(w, apierror.Google, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (h *Handler) handleListSendAs(w http.ResponseWriter, r *http.Request) {
+	log.Println("[gmail] → Received sendAs list request")
+
+	aliases, err := h.queries.ListGmailSendAsAliases(context.Background(), session.FromContext(r.Context()))
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to list send-as aliases: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := SendAsListResponse{SendAs: make([]SendAs, 0, len(aliases))}
+	for i := range aliases {
+		response.SendAs = append(response.SendAs, toSendAs(&aliases[i]))
+	}
+	// Clients pick a from-address from this list, so it is never empty
+	if len(response.SendAs) == 0 {
+		response.SendAs = append(response.SendAs, toSendAs(defaultSendAs()))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[gmail] ✓ Listed %d send-as aliases", len(response.SendAs))
+}
+
+func (h *Handler) handleGetSendAs(w http.ResponseWriter, r *http.Request, email string) {
+	log.Printf("[gmail] → Received sendAs get request for %s", email)
+
+	alias, err := h.getSendAs(session.FromContext(r.Context()), email)
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get send-as alias: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toSendAs(alias))
+}
+
+func (h *Handler) handleCreateSendAs(w http.ResponseWriter, r *http.Request) {
+	log.Println("[gmail] → Received sendAs create request")
+
+	var req SendAs
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
+		return
+	}
+	if !strings.Contains(req.SendAsEmail, "@") {
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid sendAsEmail")
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	if _, err := h.getSendAs(sessionID, req.SendAsEmail); err == nil {
+		apierror.Write(w, apierror.Google, http.StatusConflict, "Send-as alias already exists")
+		return
+	}
+
+	alias := &database.GmailSendAsAlias{
+		SessionID:      sessionID,
+		SendAsEmail:    req.SendAsEmail,
+		DisplayName:    req.DisplayName,
+		ReplyToAddress: req.ReplyToAddress,
+		Signature:      req.Signature,
+		IsDefault:      boolToInt(req.IsDefault),
+	}
+	if err := h.saveSendAs(alias); err != nil {
+		log.Printf("[gmail] ✗ Failed to create send-as alias: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toSendAs(alias))
+	log.Printf("[gmail] ✓ Created send-as alias %s", req.SendAsEmail)
+}
+
+func (h *Handler) handleUpdateSendAs(w http.ResponseWriter, r *http.Request, email string) {
+	log.Printf("[gmail] → Received sendAs update request for %s", email)
+
+	var req SendAs
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	alias, err := h.getSendAs(session.FromContext(r.Context()), email)
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get send-as alias: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Like Gmail, isDefault can only be turned on; making another alias the default turns it off
+	alias.DisplayName = req.DisplayName
+	alias.ReplyToAddress = req.ReplyToAddress
+	alias.Signature = req.Signature
+	if req.IsDefault {
+		alias.IsDefault = 1
+	}
+	if err := h.saveSendAs(alias); err != nil {
+		log.Printf("[gmail] ✗ Failed to update send-as alias: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toSendAs(alias))
+	log.Printf("[gmail] ✓ Updated send-as alias %s", email)
+}
+
+func (h *Handler) handleDeleteSendAs(w http.ResponseWriter, r *http.Request, email string) {
+	log.Printf("[gmail] → Received sendAs delete request for %s", email)
+
+	sessionID := session.FromContext(r.Context())
+	alias, err := h.getSendAs(sessionID, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		apierror.Write(w, apierror.Google, http.StatusNotFound, "Requested entity was not found.")
+		return
+	}
+	if err != nil {
+		log.Printf("[gmail] ✗ Failed to get send-as alias: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if alias.IsPrimary == 1 {
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, "Cannot delete the primary send-as alias")
+		return
+	}
+
+	if _, err := h.queries.DeleteGmailSendAsAlias(context.Background(), database.DeleteGmailSendAsAliasParams{
+		SessionID:   sessionID,
+		SendAsEmail: email,
+	}); err != nil {
+		log.Printf("[gmail] ✗ Failed to delete send-as alias: %v", err)
+		apierror.Write(w, apierror.Google, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("[gmail] ✓ Deleted send-as alias %s", email)
+}
+
+// getSendAs looks up a send-as alias, falling back to the default primary for sessions without any
+func (h *Handler) getSendAs(sessionID, email string) (*database.GmailSendAsAlias, error) {
+	ctx := context.Background()
+	alias, err := h.queries.GetGmailSendAsAlias(ctx, database.GetGmailSendAsAliasParams{
+		SessionID:   sessionID,
+		SendAsEmail: email,
+	})
+	if err == nil {
+		return &alias, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) || email != defaultSendAsEmail {
+		return nil, err
+	}
+
+	aliases, err := h.queries.ListGmailSendAsAliases(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(aliases) > 0 {
+		return nil, sql.ErrNoRows
+	}
+	return defaultSendAs(), nil
+}
+
+// saveSendAs stores an alias. The default primary is stored first so it stays listed next to
+// the new alias, and only one alias can be the default.
+func (h *Handler) saveSendAs(alias *database.GmailSendAsAlias) error {
+	return h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+		ctx := context.Background()
+		aliases, err := q.ListGmailSendAsAliases(ctx, alias.SessionID)
+		if err != nil {
+			return err
+		}
+		if len(aliases) == 0 && alias.SendAsEmail != defaultSendAsEmail {
+			primary := defaultSendAs()
+			if alias.IsDefault == 1 {
+				primary.IsDefault = 0
+			}
+			if err := upsertSendAs(ctx, q, alias.SessionID, primary); err != nil {
+				return err
+			}
+		}
+		if alias.IsDefault == 1 {
+			if err := q.ClearGmailSendAsDefault(ctx, alias.SessionID); err != nil {
+				return err
+			}
+		}
+		return upsertSendAs(ctx, q, alias.SessionID, alias)
+	})
+}
+
+func upsertSendAs(ctx context.Context, q *database.Queries, sessionID string, alias *database.GmailSendAsAlias) error {
+	return q.UpsertGmailSendAsAlias(ctx, database.UpsertGmailSendAsAliasParams{
+		SessionID:      sessionID,
+		SendAsEmail:    alias.SendAsEmail,
+		DisplayName:    alias.DisplayName,
+		ReplyToAddress: alias.ReplyToAddress,
+		Signature:      alias.Signature,
+		IsPrimary:      alias.IsPrimary,
+		IsDefault:      alias.IsDefault,
+	})
+}
+
+// defaultSendAs is the primary alias of a session that has not configured any
+func defaultSendAs() *database.GmailSendAsAlias {
+	return &database.GmailSendAsAlias{
+		SendAsEmail: defaultSendAsEmail,
+		IsPrimary:   1,
+		IsDefault:   1,
+	}
+}
+
+func toSendAs(alias *database.GmailSendAsAlias) SendAs {
+	sendAs := SendAs{
+		SendAsEmail:    alias.SendAsEmail,
+		DisplayName:    alias.DisplayName,
+		ReplyToAddress: alias.ReplyToAddress,
+		Signature:      alias.Signature,
+		IsPrimary:      alias.IsPrimary == 1,
+		IsDefault:      alias.IsDefault == 1,
+	}
+	// The primary address needs no verification; other aliases are treated as verified aliases
+	if !sendAs.IsPrimary {
+		sendAs.TreatAsAlias = true
+		sendAs.VerificationStatus = "accepted"
+	}
+	return sendAs
+}
+
+func boolToInt(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// Helper functions
+
+type attachment struct {
+	ID       string
+	Filename string
+	MimeType string
+	Data     []byte
+	Size     int
+}
+
+type searchParams struct {
+	from    string
+	to      string
+	cc      string
+	subject string
+	body    string
+	label   string
+}
+
+type emailParseResult struct {
+	from        string
+	to          string
+	cc          string
+	subject     string
+	bodyPlain   string
+	bodyHTML    string
+	attachments []attachment
+}
+
+func parseSearchQuery(q string) searchParams {
+	params := searchParams{}
+	if q == "" {
+		return params
+	}
+
+	// Simple parser for Gmail search syntax
+	// Supports: from:, to:, cc:, subject:, is:unread, is:read, label:, in:
+	parts := strings.Fields(q)
+
+	for _, part := range parts {
+		switch {
+		case strings.HasPrefix(part, "from:"):
+			params.from = strings.TrimPrefix(part, "from:")
+		case strings.HasPrefix(part, "to:"):
+			params.to = strings.TrimPrefix(part, "to:")
+		case strings.HasPrefix(part, "cc:"):
+			params.cc = strings.TrimPrefix(part, "cc:")
+		case strings.HasPrefix(part, "subject:"):
+			params.subject = strings.TrimPrefix(part, "subject:")
+		case part == "is:unread":
+			params.label = "UNREAD"
 		case part == "is:read":
 			// Messages without UNREAD label are considered read
 			// We'll handle this by searching for messages without UNREAD
 			params.label = "!UNREAD"
 		case strings.HasPrefix(part, "label:"):
 			params.label = strings.TrimPrefix(part, "label:")
+		case strings.HasPrefix(part, "in:"):
+			// in:trash, in:inbox and in:sent name system labels
+			params.label = strings.ToUpper(strings.TrimPrefix(part, "in:"))
 		default:
 			// Treat as body text search if no prefix
 			if params.body != "" {
@@ -658,13 +1852,39 @@ func parseSearchQuery(q string) searchParams {
 	return params
 }
 
-func generateMessageID() string {
+// decodeLabelIDs parses the JSON label list stored with a message
+func decodeLabelIDs(stored sql.NullString) []string {
+	var labelIDs []string
+	if stored.Valid && stored.String != "" {
+		_ = json.Unmarshal([]byte(stored.String), &labelIDs)
+	}
+	return labelIDs
+}
+
+// modifyLabels removes and then adds labels, keeping the existing order and skipping
+// labels the message already has
+func modifyLabels(labels, add, remove []string) []string {
+	result := make([]string, 0, len(labels)+len(add))
+	for _, label := range labels {
+		if !slices.Contains(remove, label) {
+			result = append(result, label)
+		}
+	}
+	for _, label := range add {
+		if !slices.Contains(result, label) {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+func generateMessageID(sessionID string) string {
 	b := make([]byte, 8)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
 
-func parseEmail(raw string) (from, to, subject, bodyPlain, bodyHTML string) {
+func parseEmail(raw string) (from, to, cc, subject, bodyPlain, bodyHTML string) {
 	lines := strings.Split(raw, "\r\n")
 	inBody := false
 	bodyLines := []string{}
@@ -682,6 +1902,8 @@ func parseEmail(raw string) (from, to, subject, bodyPlain, bodyHTML string) {
 				from = strings.TrimPrefix(line, "From: ")
 			case strings.HasPrefix(line, "To: "):
 				to = strings.TrimPrefix(line, "To: ")
+			case strings.HasPrefix(line, "Cc: "):
+				cc = strings.TrimPrefix(line, "Cc: ")
 			case strings.HasPrefix(line, "Subject: "):
 				subject = strings.TrimPrefix(line, "Subject: ")
 			}
@@ -703,6 +1925,34 @@ func parseEmail(raw string) (from, to, subject, bodyPlain, bodyHTML string) {
 	return
 }
 
+// searchableAddresses normalizes a recipient header such as "Alice <Alice@example.com>, bob@example.com"
+// into lowercase bare addresses separated by spaces, so to: and cc: match any single recipient
+func searchableAddresses(header string) string {
+	if strings.TrimSpace(header) == "" {
+		return ""
+	}
+
+	var addresses []string
+	if list, err := mail.ParseAddressList(header); err == nil {
+		for _, addr := range list {
+			addresses = append(addresses, strings.ToLower(addr.Address))
+		}
+		return strings.Join(addresses, " ")
+	}
+
+	// Not RFC 5322; fall back to splitting on commas and taking any <...> part
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if start, end := strings.Index(part, "<"), strings.LastIndex(part, ">"); start >= 0 && end > start {
+			part = part[start+1 : end]
+		}
+		if part != "" {
+			addresses = append(addresses, strings.ToLower(part))
+		}
+	}
+	return strings.Join(addresses, " ")
+}
+
 func stripHTML(html string) string {
 	// Simple HTML tag removal
 	re := regexp.MustCompile(`<[^>]*>`)
@@ -721,13 +1971,14 @@ func generateSnippet(bodyPlain, bodyHTML string) string {
 	return text
 }
 
-func parseEmailWithAttachments(raw string) emailParseResult {
+func parseEmailWithAttachments(sessionID, raw string) emailParseResult {
 	// First try simple parsing for non-MIME messages
 	if !strings.Contains(raw, "Content-Type: multipart") {
-		from, to, subject, bodyPlain, bodyHTML := parseEmail(raw)
+		from, to, cc, subject, bodyPlain, bodyHTML := parseEmail(raw)
 		return emailParseResult{
 			from:      from,
 			to:        to,
+			cc:        cc,
 			subject:   subject,
 			bodyPlain: bodyPlain,
 			bodyHTML:  bodyHTML,
@@ -738,7 +1989,7 @@ func parseEmailWithAttachments(raw string) emailParseResult {
 	lines := strings.Split(raw, "\r\n")
 	var contentType string
 	var boundary string
-	var from, to, subject, bodyPlain, bodyHTML string
+	var from, to, cc, subject, bodyPlain, bodyHTML string
 	var attachments []attachment
 
 	// Parse top-level headers
@@ -756,6 +2007,8 @@ func parseEmailWithAttachments(raw string) emailParseResult {
 			from = strings.TrimPrefix(line, "From: ")
 		case strings.HasPrefix(line, "To: "):
 			to = strings.TrimPrefix(line, "To: ")
+		case strings.HasPrefix(line, "Cc: "):
+			cc = strings.TrimPrefix(line, "Cc: ")
 		case strings.HasPrefix(line, "Subject: "):
 			subject = strings.TrimPrefix(line, "Subject: ")
 		case strings.HasPrefix(line, "Content-Type: "):
@@ -773,10 +2026,11 @@ func parseEmailWithAttachments(raw string) emailParseResult {
 
 	if boundary == "" {
 		// No boundary found, fall back to simple parsing
-		from, to, subject, bodyPlain, bodyHTML = parseEmail(raw)
+		from, to, cc, subject, bodyPlain, bodyHTML = parseEmail(raw)
 		return emailParseResult{
 			from:      from,
 			to:        to,
+			cc:        cc,
 			subject:   subject,
 			bodyPlain: bodyPlain,
 			bodyHTML:  bodyHTML,
@@ -828,7 +2082,7 @@ func parseEmailWithAttachments(raw string) emailParseResult {
 			}
 
 			att := attachment{
-				ID:       generateMessageID(),
+				ID:       generateMessageID(sessionID),
 				Filename: filename,
 				MimeType: mimeType,
 				Data:     decodedData,
@@ -845,6 +2099,7 @@ func parseEmailWithAttachments(raw string) emailParseResult {
 	return emailParseResult{
 		from:        from,
 		to:          to,
+		cc:          cc,
 		subject:     subject,
 		bodyPlain:   bodyPlain,
 		bodyHTML:    bodyHTML,
@@ -875,20 +2130,3 @@ func extractFilename(disposition, contentType string) string {
 
 	return ""
 }
-
-func encodePageToken(offset int) string {
-	token := fmt.Sprintf("%d", offset)
-	return base64.URLEncoding.EncodeToString([]byte(token))
-}
-
-func decodePageToken(token string) (int, error) {
-	decoded, err := base64.URLEncoding.DecodeString(token)
-	if err != nil {
-		return 0, err
-	}
-	offset, err := strconv.Atoi(string(decoded))
-	if err != nil {
-		return 0, err
-	}
-	return offset, nil
-}
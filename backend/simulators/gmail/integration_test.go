@@ -5,14 +5,20 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pressly/goose/v3"
 	"github.com/recreate-run/nova-simulators/internal/config"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/middleware"
 	"github.com/recreate-run/nova-simulators/internal/session"
 	"github.com/recreate-run/nova-simulators/internal/testutil"
@@ -20,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	_ "modernc.org/sqlite"
 )
@@ -241,6 +248,50 @@ func TestGmailSimulatorGetMessage(t *testing.T) {
 		}
 	})
 
+	t.Run("GetMessageMinimal", func(t *testing.T) {
+		retrieved, err := gmailService.Users.Messages.Get("me", sent.Id).Format("minimal").Do()
+
+		require.NoError(t, err, "Get should not return error")
+		assert.Equal(t, sent.Id, retrieved.Id, "Message ID should match")
+		assert.Equal(t, sent.ThreadId, retrieved.ThreadId, "Thread ID should match")
+		assert.NotEmpty(t, retrieved.LabelIds, "Labels should be kept")
+		assert.Nil(t, retrieved.Payload, "Minimal format should omit the payload")
+	})
+
+	t.Run("GetMessageMetadata", func(t *testing.T) {
+		retrieved, err := gmailService.Users.Messages.Get("me", sent.Id).Format("metadata").Do()
+
+		require.NoError(t, err, "Get should not return error")
+		require.NotNil(t, retrieved.Payload, "Metadata format should keep the payload")
+		assert.Len(t, retrieved.Payload.Headers, 4, "All headers should be kept")
+		require.NotEmpty(t, retrieved.Payload.Parts, "Parts should be described")
+		for _, part := range retrieved.Payload.Parts {
+			assert.Empty(t, part.Body.Data, "Metadata format should omit body data")
+			assert.Positive(t, part.Body.Size, "Body size should be kept")
+		}
+	})
+
+	t.Run("GetMessageMetadataHeaders", func(t *testing.T) {
+		retrieved, err := gmailService.Users.Messages.Get("me", sent.Id).
+			Format("metadata").MetadataHeaders("subject", "From").Do()
+
+		require.NoError(t, err, "Get should not return error")
+		require.NotNil(t, retrieved.Payload, "Metadata format should keep the payload")
+		names := make([]string, 0, len(retrieved.Payload.Headers))
+		for _, header := range retrieved.Payload.Headers {
+			names = append(names, header.Name)
+		}
+		assert.ElementsMatch(t, []string{"From", "Subject"}, names, "Only requested headers should be returned")
+	})
+
+	t.Run("GetMessageInvalidFormat", func(t *testing.T) {
+		_, err := gmailService.Users.Messages.Get("me", sent.Id).Format("bogus").Do()
+
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Should return a Google API error")
+		assert.Equal(t, http.StatusBadRequest, apiErr.Code, "Should return 400")
+	})
+
 	t.Run("GetNonExistentMessage", func(t *testing.T) {
 		// Try to get a non-existent message
 		_, err := gmailService.Users.Messages.Get("me", "nonexistent").Do()
@@ -384,6 +435,302 @@ func TestGmailSimulatorImportMessage(t *testing.T) {
 	})
 }
 
+func TestGmailSimulatorModifyMessage(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	newService := func(t *testing.T, sessionID string) *gmail.Service {
+		t.Helper()
+		customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}
+		gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+		require.NoError(t, err, "Failed to create Gmail service")
+		return gmailService
+	}
+	gmailService := newService(t, "gmail-test-modify")
+
+	message := "From: alice@example.com\r\nTo: me@example.com\r\nSubject: Please read\r\n\r\nUnread until modified."
+	imported, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString([]byte(message)),
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+
+	t.Run("MarkAsRead", func(t *testing.T) {
+		modified, err := gmailService.Users.Messages.Modify("me", imported.Id, &gmail.ModifyMessageRequest{
+			RemoveLabelIds: []string{"UNREAD"},
+		}).Do()
+		require.NoError(t, err, "Modify should succeed")
+		assert.Equal(t, imported.Id, modified.Id)
+		assert.Equal(t, []string{"INBOX"}, modified.LabelIds, "UNREAD should be removed")
+
+		retrieved, err := gmailService.Users.Messages.Get("me", imported.Id).Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, []string{"INBOX"}, retrieved.LabelIds, "The change should be stored")
+
+		unread, err := gmailService.Users.Messages.List("me").Q("is:unread").Do()
+		require.NoError(t, err, "Search should succeed")
+		assert.Empty(t, unread.Messages, "The message should no longer be unread")
+	})
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		modified, err := gmailService.Users.Messages.Modify("me", imported.Id, &gmail.ModifyMessageRequest{
+			AddLabelIds:    []string{"STARRED", "INBOX", "Label_1"},
+			RemoveLabelIds: []string{"INBOX"},
+		}).Do()
+		require.NoError(t, err, "Modify should succeed")
+		assert.Equal(t, []string{"STARRED", "INBOX", "Label_1"}, modified.LabelIds, "Added labels should be applied after removals")
+
+		modified, err = gmailService.Users.Messages.Modify("me", imported.Id, &gmail.ModifyMessageRequest{
+			AddLabelIds: []string{"STARRED"},
+		}).Do()
+		require.NoError(t, err, "Modify should succeed")
+		assert.Equal(t, []string{"STARRED", "INBOX", "Label_1"}, modified.LabelIds, "Existing labels should not be duplicated")
+	})
+
+	t.Run("UnknownMessage", func(t *testing.T) {
+		_, err := gmailService.Users.Messages.Modify("me", "missing", &gmail.ModifyMessageRequest{
+			RemoveLabelIds: []string{"UNREAD"},
+		}).Do()
+		require.Error(t, err, "Modifying an unknown message should fail")
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Code)
+	})
+
+	t.Run("OtherSession", func(t *testing.T) {
+		_, err := newService(t, "gmail-test-modify-other").Users.Messages.Modify("me", imported.Id, &gmail.ModifyMessageRequest{
+			AddLabelIds: []string{"TRASH"},
+		}).Do()
+		require.Error(t, err, "Messages of other sessions should not be modifiable")
+	})
+}
+
+func TestGmailSimulatorThreads(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: "gmail-test-threads"}}
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	question, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString([]byte("From: alice@example.com\r\nTo: me@example.com\r\nSubject: Deploy\r\n\r\nCan we ship today?")),
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+	answer, err := gmailService.Users.Messages.Send("me", &gmail.Message{
+		Raw:      base64.URLEncoding.EncodeToString([]byte("From: me@example.com\r\nTo: alice@example.com\r\nSubject: Re: Deploy\r\n\r\nYes, after lunch.")),
+		ThreadId: question.ThreadId,
+	}).Do()
+	require.NoError(t, err, "Send should succeed")
+	require.Equal(t, question.ThreadId, answer.ThreadId, "The reply should join the thread")
+	single, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString([]byte("From: bob@example.com\r\nTo: me@example.com\r\nSubject: Standup\r\n\r\nRunning late.")),
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+
+	t.Run("Get", func(t *testing.T) {
+		thread, err := gmailService.Users.Threads.Get("me", question.ThreadId).Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, question.ThreadId, thread.Id)
+		require.Len(t, thread.Messages, 2)
+		assert.Equal(t, question.Id, thread.Messages[0].Id, "Messages should be in internalDate order")
+		assert.Equal(t, answer.Id, thread.Messages[1].Id)
+		assert.NotNil(t, thread.Messages[0].Payload, "The full format should include payloads")
+
+		minimal, err := gmailService.Users.Threads.Get("me", question.ThreadId).Format("minimal").Do()
+		require.NoError(t, err, "Get should succeed")
+		require.Len(t, minimal.Messages, 2)
+		assert.Nil(t, minimal.Messages[0].Payload, "The minimal format should drop payloads")
+	})
+
+	t.Run("List", func(t *testing.T) {
+		var ids []string
+		call := gmailService.Users.Threads.List("me").MaxResults(1)
+		for {
+			list, err := call.Do()
+			require.NoError(t, err, "List should succeed")
+			require.LessOrEqual(t, len(list.Threads), 1)
+			for _, thread := range list.Threads {
+				ids = append(ids, thread.Id)
+			}
+			if list.NextPageToken == "" {
+				break
+			}
+			call = gmailService.Users.Threads.List("me").MaxResults(1).PageToken(list.NextPageToken)
+		}
+		assert.ElementsMatch(t, []string{question.ThreadId, single.ThreadId}, ids, "Each thread should be listed once")
+	})
+
+	t.Run("MessageCounts", func(t *testing.T) {
+		resp, err := customClient.Get(server.URL + "/gmail/v1/users/me/threads")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		var list struct {
+			Threads []struct {
+				ID           string `json:"id"`
+				MessageCount int    `json:"messageCount"`
+			} `json:"threads"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		counts := map[string]int{}
+		for _, thread := range list.Threads {
+			counts[thread.ID] = thread.MessageCount
+		}
+		assert.Equal(t, map[string]int{question.ThreadId: 2, single.ThreadId: 1}, counts)
+	})
+
+	t.Run("OtherSession", func(t *testing.T) {
+		otherClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: "gmail-test-threads-other"}}
+		otherService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(otherClient))
+		require.NoError(t, err, "Failed to create Gmail service")
+
+		_, err = otherService.Users.Threads.Get("me", question.ThreadId).Do()
+		require.Error(t, err, "Threads of other sessions should not be visible")
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Code)
+
+		list, err := otherService.Users.Threads.List("me").Do()
+		require.NoError(t, err, "List should succeed")
+		assert.Empty(t, list.Threads)
+	})
+}
+
+func TestGmailSimulatorModifyThread(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: "gmail-test-modify-thread"}}
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	first, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString([]byte("From: alice@example.com\r\nTo: me@example.com\r\nSubject: Lunch\r\n\r\nNoon?")),
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+	reply, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw:      base64.URLEncoding.EncodeToString([]byte("From: alice@example.com\r\nTo: me@example.com\r\nSubject: Re: Lunch\r\n\r\nOr one?")),
+		ThreadId: first.ThreadId,
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+	require.Equal(t, first.ThreadId, reply.ThreadId, "The reply should join the thread")
+	other, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString([]byte("From: bob@example.com\r\nTo: me@example.com\r\nSubject: Unrelated\r\n\r\nHi.")),
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+
+	t.Run("Archive", func(t *testing.T) {
+		thread, err := gmailService.Users.Threads.Modify("me", first.ThreadId, &gmail.ModifyThreadRequest{
+			RemoveLabelIds: []string{"INBOX"},
+		}).Do()
+		require.NoError(t, err, "Modify should succeed")
+		assert.Equal(t, first.ThreadId, thread.Id)
+		require.Len(t, thread.Messages, 2, "Every message of the thread should be modified")
+		for _, msg := range thread.Messages {
+			assert.Equal(t, []string{"UNREAD"}, msg.LabelIds, "INBOX should be removed")
+		}
+
+		for _, id := range []string{first.Id, reply.Id} {
+			retrieved, err := gmailService.Users.Messages.Get("me", id).Do()
+			require.NoError(t, err, "Get should succeed")
+			assert.NotContains(t, retrieved.LabelIds, "INBOX", "The change should be stored")
+		}
+
+		untouched, err := gmailService.Users.Messages.Get("me", other.Id).Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Contains(t, untouched.LabelIds, "INBOX", "Other threads should be left alone")
+	})
+
+	t.Run("UnknownThread", func(t *testing.T) {
+		_, err := gmailService.Users.Threads.Modify("me", "missing", &gmail.ModifyThreadRequest{
+			AddLabelIds: []string{"INBOX"},
+		}).Do()
+		require.Error(t, err, "Modifying an unknown thread should fail")
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Code)
+	})
+}
+
+func TestGmailSimulatorTrash(t *testing.T) {
+	queries := setupTestDB(t)
+
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: "gmail-test-trash"}}
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	message := "From: alice@example.com\r\nTo: me@example.com\r\nSubject: Old news\r\n\r\nNo longer needed."
+	imported, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString([]byte(message)),
+	}).Do()
+	require.NoError(t, err, "Import should succeed")
+
+	listedIDs := func(t *testing.T, call *gmail.UsersMessagesListCall) []string {
+		t.Helper()
+		list, err := call.Do()
+		require.NoError(t, err, "List should succeed")
+		ids := []string{}
+		for _, msg := range list.Messages {
+			ids = append(ids, msg.Id)
+		}
+		return ids
+	}
+
+	t.Run("Trash", func(t *testing.T) {
+		trashed, err := gmailService.Users.Messages.Trash("me", imported.Id).Do()
+		require.NoError(t, err, "Trash should succeed")
+		assert.Equal(t, imported.Id, trashed.Id)
+		assert.Contains(t, trashed.LabelIds, "TRASH")
+		assert.NotContains(t, trashed.LabelIds, "INBOX", "Trashed mail should leave the inbox")
+
+		assert.NotContains(t, listedIDs(t, gmailService.Users.Messages.List("me")), imported.Id, "Trashed mail should not be listed")
+		assert.NotContains(t, listedIDs(t, gmailService.Users.Messages.List("me").Q("from:alice@example.com")), imported.Id, "Trashed mail should not be found by search")
+		assert.Contains(t, listedIDs(t, gmailService.Users.Messages.List("me").Q("in:trash")), imported.Id, "in:trash should list trashed mail")
+		assert.Contains(t, listedIDs(t, gmailService.Users.Messages.List("me").IncludeSpamTrash(true)), imported.Id, "includeSpamTrash should list trashed mail")
+	})
+
+	t.Run("Untrash", func(t *testing.T) {
+		restored, err := gmailService.Users.Messages.Untrash("me", imported.Id).Do()
+		require.NoError(t, err, "Untrash should succeed")
+		assert.NotContains(t, restored.LabelIds, "TRASH")
+		assert.Contains(t, restored.LabelIds, "INBOX", "Restored mail should return to the inbox")
+
+		assert.Contains(t, listedIDs(t, gmailService.Users.Messages.List("me")), imported.Id, "Restored mail should be listed again")
+	})
+
+	t.Run("UnknownMessage", func(t *testing.T) {
+		_, err := gmailService.Users.Messages.Trash("me", "missing").Do()
+		require.Error(t, err, "Trashing an unknown message should fail")
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := gmailService.Users.Messages.Delete("me", imported.Id).Do()
+		require.NoError(t, err, "Delete should succeed")
+
+		_, err = gmailService.Users.Messages.Get("me", imported.Id).Do()
+		require.Error(t, err, "Deleted message should be gone")
+	})
+}
+
 func TestGmailSimulatorSearch(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -497,6 +844,38 @@ func TestGmailSimulatorSearch(t *testing.T) {
 		require.NoError(t, err, "Search should not return error")
 		assert.GreaterOrEqual(t, len(response.Messages), 1, "Should find at least 1 unread message from alice")
 	})
+
+	t.Run("SearchMultipleRecipients", func(t *testing.T) {
+		message := "From: dave@example.com\r\n" +
+			"To: alice@example.com, Bob Smith <Bob@Example.com>\r\n" +
+			"Cc: carol@example.com\r\n" +
+			"Subject: Offsite\r\n\r\nSee you there"
+		imported, err := gmailService.Users.Messages.Import("me", &gmail.Message{
+			Raw: base64.URLEncoding.EncodeToString([]byte(message)),
+		}).Do()
+		require.NoError(t, err, "Import should succeed")
+
+		for _, q := range []string{"to:bob@example.com", "to:alice@example.com", "to:carol@example.com", "cc:carol@example.com"} {
+			response, err := gmailService.Users.Messages.List("me").Q(q).Do()
+			require.NoError(t, err, "Search should not return error")
+			require.Len(t, response.Messages, 1, "%s should match only the offsite message", q)
+			assert.Equal(t, imported.Id, response.Messages[0].Id, "%s should match the offsite message", q)
+		}
+
+		response, err := gmailService.Users.Messages.List("me").Q("cc:bob@example.com").Do()
+		require.NoError(t, err, "Search should not return error")
+		assert.Empty(t, response.Messages, "cc: should not match To recipients")
+
+		retrieved, err := gmailService.Users.Messages.Get("me", imported.Id).Format("metadata").Do()
+		require.NoError(t, err, "Get should succeed")
+		var cc string
+		for _, header := range retrieved.Payload.Headers {
+			if header.Name == "Cc" {
+				cc = header.Value
+			}
+		}
+		assert.Equal(t, "carol@example.com", cc, "Cc header should be preserved")
+	})
 }
 
 func TestGmailSimulatorAttachments(t *testing.T) {
@@ -694,6 +1073,92 @@ func TestGmailSimulatorAttachments(t *testing.T) {
 		require.NoError(t, err, "Should decode attachment data")
 		assert.Equal(t, attachmentData, decodedData, "Attachment content should match")
 	})
+
+	t.Run("DeleteMessageRemovesAttachments", func(t *testing.T) {
+		// sendWithAttachment sends an email with one attachment and returns message and attachment IDs
+		sendWithAttachment := func(subject string) (messageID, attachmentID string) {
+			boundary := "boundaryDelete"
+			message := fmt.Sprintf("From: sender@example.com\r\n"+
+				"To: recipient@example.com\r\n"+
+				"Subject: %s\r\n"+
+				"Content-Type: multipart/mixed; boundary=\"%s\"\r\n"+
+				"\r\n"+
+				"--%s\r\n"+
+				"Content-Type: text/plain\r\n"+
+				"\r\n"+
+				"Body\r\n"+
+				"--%s\r\n"+
+				"Content-Type: text/plain; name=\"delete.txt\"\r\n"+
+				"Content-Disposition: attachment; filename=\"delete.txt\"\r\n"+
+				"Content-Transfer-Encoding: base64\r\n"+
+				"\r\n"+
+				"%s\r\n"+
+				"--%s--\r\n",
+				subject, boundary, boundary, boundary, base64.StdEncoding.EncodeToString([]byte("Delete me")), boundary)
+
+			sent, err := gmailService.Users.Messages.Send("me", &gmail.Message{
+				Raw: base64.URLEncoding.EncodeToString([]byte(message)),
+			}).Do()
+			require.NoError(t, err, "Send should succeed")
+
+			retrieved, err := gmailService.Users.Messages.Get("me", sent.Id).Format("full").Do()
+			require.NoError(t, err, "Get should succeed")
+			for _, part := range retrieved.Payload.Parts {
+				if part.Filename == "delete.txt" {
+					attachmentID = part.Body.AttachmentId
+				}
+			}
+			require.NotEmpty(t, attachmentID, "Should have attachment ID")
+
+			_, err = gmailService.Users.Messages.Attachments.Get("me", sent.Id, attachmentID).Do()
+			require.NoError(t, err, "Attachment should be downloadable before delete")
+			return sent.Id, attachmentID
+		}
+
+		assertNotFound := func(err error) {
+			var apiErr *googleapi.Error
+			require.ErrorAs(t, err, &apiErr, "Should return API error")
+			assert.Equal(t, http.StatusNotFound, apiErr.Code, "Should return 404")
+		}
+
+		// Delete a single message
+		messageID, attachmentID := sendWithAttachment("Delete Single")
+		err := gmailService.Users.Messages.Delete("me", messageID).Do()
+		require.NoError(t, err, "Delete should succeed")
+
+		_, err = gmailService.Users.Messages.Attachments.Get("me", messageID, attachmentID).Do()
+		assertNotFound(err)
+		_, err = gmailService.Users.Messages.Get("me", messageID).Do()
+		assertNotFound(err)
+
+		// Deleting again returns 404
+		err = gmailService.Users.Messages.Delete("me", messageID).Do()
+		assertNotFound(err)
+
+		// Batch delete
+		firstID, firstAttachmentID := sendWithAttachment("Batch Delete 1")
+		secondID, secondAttachmentID := sendWithAttachment("Batch Delete 2")
+		err = gmailService.Users.Messages.BatchDelete("me", &gmail.BatchDeleteMessagesRequest{
+			Ids: []string{firstID, secondID},
+		}).Do()
+		require.NoError(t, err, "Batch delete should succeed")
+
+		_, err = gmailService.Users.Messages.Attachments.Get("me", firstID, firstAttachmentID).Do()
+		assertNotFound(err)
+		_, err = gmailService.Users.Messages.Attachments.Get("me", secondID, secondAttachmentID).Do()
+		assertNotFound(err)
+
+		// Orphaned attachment rows are ignored once the parent message is gone
+		orphanID, orphanAttachmentID := sendWithAttachment("Orphan")
+		err = queries.DeleteGmailMessage(ctx, database.DeleteGmailMessageParams{
+			ID:        orphanID,
+			SessionID: sessionID,
+			UserID:    "me",
+		})
+		require.NoError(t, err, "Failed to delete message row")
+		_, err = gmailService.Users.Messages.Attachments.Get("me", orphanID, orphanAttachmentID).Do()
+		assertNotFound(err)
+	})
 }
 
 func TestGmailSimulatorPagination(t *testing.T) {
@@ -825,6 +1290,49 @@ func TestGmailSimulatorPagination(t *testing.T) {
 		// Last page should have no next token
 		assert.Empty(t, lastResponse.NextPageToken, "Last page should have no next token")
 	})
+
+	t.Run("TamperedPageTokenRejected", func(t *testing.T) {
+		page1, err := gmailService.Users.Messages.List("me").MaxResults(3).Do()
+		require.NoError(t, err, "First page should succeed")
+		require.NotEmpty(t, page1.NextPageToken, "Should have next page token")
+
+		// Swap the signed offset for another one, keeping the signature
+		_, signature, found := strings.Cut(page1.NextPageToken, ".")
+		require.True(t, found, "Page token should be signed")
+		forged := base64.RawURLEncoding.EncodeToString([]byte("6:"+strconv.FormatInt(time.Now().UnixNano(), 10))) + "." + signature
+
+		_, err = gmailService.Users.Messages.List("me").MaxResults(3).PageToken(forged).Do()
+		require.Error(t, err, "Tampered token should be rejected")
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Error should be a Google API error")
+		assert.Equal(t, http.StatusBadRequest, apiErr.Code, "Tampered token should return 400")
+	})
+
+	t.Run("ListCapTruncates", func(t *testing.T) {
+		listcap.SetMax(4)
+		t.Cleanup(func() {
+			listcap.SetMax(0)
+		})
+
+		response, err := gmailService.Users.Messages.List("me").MaxResults(100).Do()
+
+		// Assertions
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, response.Messages, 4, "Page should be cut to the cap")
+		assert.NotEmpty(t, response.NextPageToken, "Truncated page should hand out a cursor")
+		assert.Equal(t, "true", response.Header.Get(listcap.TruncatedHeader), "Truncated page should be flagged")
+
+		// Following the cursor continues after the truncated page
+		next, err := gmailService.Users.Messages.List("me").MaxResults(100).PageToken(response.NextPageToken).Do()
+		require.NoError(t, err, "Next page should succeed")
+		assert.Len(t, next.Messages, 4, "Next page should also be capped")
+		assert.NotEqual(t, response.Messages[0].Id, next.Messages[0].Id, "Next page should continue the listing")
+
+		// Pages within the cap are not flagged
+		small, err := gmailService.Users.Messages.List("me").MaxResults(2).Do()
+		require.NoError(t, err, "List should succeed")
+		assert.Empty(t, small.Header.Get(listcap.TruncatedHeader), "Pages within the cap should not be flagged")
+	})
 }
 
 func TestGmailSimulatorTimeout(t *testing.T) {
@@ -887,3 +1395,447 @@ func TestGmailSimulatorRateLimit(t *testing.T) {
 		testutil.TestMiddlewareRateLimitIsolation(t, makeHandler, makeRequest, "gmail")
 	})
 }
+
+func TestGmailSimulatorIdempotency(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+	sessionID := "gmail-test-session-idempotency"
+
+	// Setup: Start simulator server with idempotency middleware
+	handler := session.Middleware(
+		middleware.Idempotency(queries, "gmail")(
+			simulatorGmail.NewHandler(queries)))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}
+	ctx := context.Background()
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	send := func(t *testing.T, key, subject string) *gmail.Message {
+		t.Helper()
+		message := fmt.Sprintf("From: sender@example.com\r\nTo: recipient@example.com\r\nSubject: %s\r\n\r\nRetry me", subject)
+		call := gmailService.Users.Messages.Send("me", &gmail.Message{Raw: base64.URLEncoding.EncodeToString([]byte(message))})
+		call.Header().Set(middleware.IdempotencyKeyHeader, key)
+		sent, err := call.Do()
+		require.NoError(t, err, "Send should succeed")
+		return sent
+	}
+
+	t.Run("RetryReturnsOriginalResponse", func(t *testing.T) {
+		first := send(t, "retry-key-1", "First attempt")
+		second := send(t, "retry-key-1", "Second attempt")
+		assert.Equal(t, first.Id, second.Id, "Retry should return the original message")
+		assert.Equal(t, "true", second.Header.Get("Idempotent-Replayed"), "Retry should be marked as replayed")
+
+		response, err := gmailService.Users.Messages.List("me").Do()
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, response.Messages, 1, "Only one message should exist")
+	})
+
+	t.Run("NewKeyCreatesNewObject", func(t *testing.T) {
+		first := send(t, "retry-key-2", "Another message")
+		assert.Empty(t, first.Header.Get("Idempotent-Replayed"), "First use of a key should not be a replay")
+
+		response, err := gmailService.Users.Messages.List("me").Do()
+		require.NoError(t, err, "List should succeed")
+		assert.Len(t, response.Messages, 2, "A new key should create a new message")
+	})
+}
+
+func TestGmailSimulatorWatch(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+	sessionID := "gmail-test-session-watch"
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}
+	ctx := context.Background()
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	getWatch := func(t *testing.T) (int, simulatorGmail.WatchState) {
+		t.Helper()
+		resp, err := customClient.Get(server.URL + "/gmail/debug/watch")
+		require.NoError(t, err, "Debug request should succeed")
+		defer resp.Body.Close()
+		var state simulatorGmail.WatchState
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&state), "Should decode watch state")
+		}
+		return resp.StatusCode, state
+	}
+
+	t.Run("NoWatchConfigured", func(t *testing.T) {
+		status, _ := getWatch(t)
+		assert.Equal(t, http.StatusNotFound, status, "No watch should be recorded yet")
+	})
+
+	t.Run("InvalidTopicName", func(t *testing.T) {
+		_, err := gmailService.Users.Watch("me", &gmail.WatchRequest{TopicName: "my-topic"}).Do()
+		require.Error(t, err, "Unqualified topic names should be rejected")
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Should return a Google API error")
+		assert.Equal(t, http.StatusBadRequest, apiErr.Code, "Should return 400")
+	})
+
+	t.Run("WatchRecordsTopic", func(t *testing.T) {
+		resp, err := gmailService.Users.Watch("me", &gmail.WatchRequest{
+			TopicName: "projects/nova/topics/gmail-push",
+			LabelIds:  []string{"INBOX"},
+		}).Do()
+		require.NoError(t, err, "Watch should succeed")
+		assert.NotZero(t, resp.HistoryId, "Should return a history ID")
+		assert.Greater(t, resp.Expiration, time.Now().UnixMilli(), "Expiration should be in the future")
+
+		status, state := getWatch(t)
+		require.Equal(t, http.StatusOK, status, "Watch should be recorded")
+		assert.Equal(t, "projects/nova/topics/gmail-push", state.TopicName, "Should record the requested topic")
+		assert.Equal(t, []string{"INBOX"}, state.LabelIDs, "Should record the requested labels")
+		assert.True(t, state.Active, "Watch should be active")
+	})
+
+	t.Run("StopDeactivatesWatch", func(t *testing.T) {
+		err := gmailService.Users.Stop("me").Do()
+		require.NoError(t, err, "Stop should succeed")
+
+		status, state := getWatch(t)
+		require.Equal(t, http.StatusOK, status, "Watch should still be recorded")
+		assert.Equal(t, "projects/nova/topics/gmail-push", state.TopicName, "Should keep the recorded topic")
+		assert.False(t, state.Active, "Watch should no longer be active")
+	})
+}
+
+func TestGmailSimulatorSendAs(t *testing.T) {
+	queries := setupTestDB(t)
+	sessionID := "gmail-test-session-send-as"
+
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}
+	ctx := context.Background()
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	t.Run("DefaultPrimary", func(t *testing.T) {
+		resp, err := gmailService.Users.Settings.SendAs.List("me").Do()
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, resp.SendAs, 1, "A new session should have one send-as address")
+		assert.Equal(t, "me@example.com", resp.SendAs[0].SendAsEmail, "Default address should be the session email")
+		assert.True(t, resp.SendAs[0].IsPrimary, "Default address should be primary")
+		assert.True(t, resp.SendAs[0].IsDefault, "Default address should be the default")
+	})
+
+	t.Run("CreateAlias", func(t *testing.T) {
+		created, err := gmailService.Users.Settings.SendAs.Create("me", &gmail.SendAs{
+			SendAsEmail: "support@example.com",
+			DisplayName: "Support",
+			IsDefault:   true,
+		}).Do()
+		require.NoError(t, err, "Create should succeed")
+		assert.Equal(t, "support@example.com", created.SendAsEmail, "Address should match")
+		assert.Equal(t, "accepted", created.VerificationStatus, "Alias should be verified")
+
+		resp, err := gmailService.Users.Settings.SendAs.List("me").Do()
+		require.NoError(t, err, "List should succeed")
+		require.Len(t, resp.SendAs, 2, "Primary and alias should be listed")
+		assert.Equal(t, "me@example.com", resp.SendAs[0].SendAsEmail, "Primary should be listed first")
+		assert.True(t, resp.SendAs[0].IsPrimary, "Primary should stay primary")
+		assert.False(t, resp.SendAs[0].IsDefault, "Primary should no longer be the default")
+		assert.True(t, resp.SendAs[1].IsDefault, "New alias should be the default")
+
+		_, err = gmailService.Users.Settings.SendAs.Create("me", &gmail.SendAs{SendAsEmail: "support@example.com"}).Do()
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Duplicate alias should return a Google API error")
+		assert.Equal(t, http.StatusConflict, apiErr.Code, "Should return 409")
+	})
+
+	t.Run("GetAndDelete", func(t *testing.T) {
+		alias, err := gmailService.Users.Settings.SendAs.Get("me", "support@example.com").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "Support", alias.DisplayName, "Display name should match")
+
+		err = gmailService.Users.Settings.SendAs.Delete("me", "me@example.com").Do()
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Deleting the primary should fail")
+		assert.Equal(t, http.StatusBadRequest, apiErr.Code, "Should return 400")
+
+		require.NoError(t, gmailService.Users.Settings.SendAs.Delete("me", "support@example.com").Do(), "Delete should succeed")
+		_, err = gmailService.Users.Settings.SendAs.Get("me", "support@example.com").Do()
+		require.ErrorAs(t, err, &apiErr, "Deleted alias should be gone")
+		assert.Equal(t, http.StatusNotFound, apiErr.Code, "Should return 404")
+	})
+}
+
+func TestGmailSimulatorMailboxes(t *testing.T) {
+	queries := setupTestDB(t)
+	sessionID := "gmail-test-session-mailboxes"
+
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	customClient := &http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}
+	ctx := context.Background()
+	gmailService, err := gmail.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err, "Failed to create Gmail service")
+
+	send := func(t *testing.T, userID, subject string) string {
+		t.Helper()
+		message := fmt.Sprintf("From: %s\r\nTo: team@example.com\r\nSubject: %s\r\n\r\nBody", userID, subject)
+		sent, err := gmailService.Users.Messages.Send(userID, &gmail.Message{
+			Raw: base64.URLEncoding.EncodeToString([]byte(message)),
+		}).Do()
+		require.NoError(t, err, "Send should succeed")
+		return sent.Id
+	}
+	listIDs := func(t *testing.T, userID string) []string {
+		t.Helper()
+		resp, err := gmailService.Users.Messages.List(userID).Do()
+		require.NoError(t, err, "List should succeed")
+		ids := make([]string, 0, len(resp.Messages))
+		for _, m := range resp.Messages {
+			ids = append(ids, m.Id)
+		}
+		return ids
+	}
+
+	aliceID := send(t, "alice@example.com", "From Alice")
+	bobID := send(t, "bob@example.com", "From Bob")
+
+	t.Run("ListEachMailbox", func(t *testing.T) {
+		assert.Equal(t, []string{aliceID}, listIDs(t, "alice@example.com"), "Alice should only see her message")
+		assert.Equal(t, []string{bobID}, listIDs(t, "bob@example.com"), "Bob should only see his message")
+		assert.Empty(t, listIDs(t, "me"), "The primary mailbox should be untouched")
+	})
+
+	t.Run("UserIDIsCaseInsensitive", func(t *testing.T) {
+		assert.Equal(t, []string{aliceID}, listIDs(t, "Alice@Example.com"))
+	})
+
+	t.Run("MeAliasesPrimaryAddress", func(t *testing.T) {
+		meID := send(t, "me", "From me")
+		assert.Equal(t, []string{meID}, listIDs(t, "me@example.com"), "The primary address should resolve to the me mailbox")
+	})
+
+	t.Run("SessionPrimaryAliasesMe", func(t *testing.T) {
+		err := queries.UpsertGmailSendAsAlias(ctx, database.UpsertGmailSendAsAliasParams{
+			SessionID:   sessionID,
+			SendAsEmail: "owner@corp.example",
+			IsPrimary:   1,
+			IsDefault:   1,
+		})
+		require.NoError(t, err, "Failed to store primary alias")
+
+		meIDs := listIDs(t, "me")
+		require.NotEmpty(t, meIDs, "The me mailbox should hold the earlier message")
+		assert.Equal(t, meIDs, listIDs(t, "Owner@Corp.example"), "The session's primary address should resolve to the me mailbox")
+		assert.Empty(t, listIDs(t, "me@example.com"), "The default address is no longer the session's primary")
+	})
+
+	t.Run("OtherMailboxNotFound", func(t *testing.T) {
+		_, err := gmailService.Users.Messages.Get("bob@example.com", aliceID).Do()
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Reading another mailbox's message should fail")
+		assert.Equal(t, http.StatusNotFound, apiErr.Code, "Should return 404")
+
+		msg, err := gmailService.Users.Messages.Get("alice@example.com", aliceID).Do()
+		require.NoError(t, err, "Owner should read the message")
+		assert.Equal(t, aliceID, msg.Id)
+	})
+
+	t.Run("SearchScopedToMailbox", func(t *testing.T) {
+		resp, err := gmailService.Users.Messages.List("bob@example.com").Q("to:team@example.com").Do()
+		require.NoError(t, err, "Search should succeed")
+		require.Len(t, resp.Messages, 1, "Search should only match Bob's message")
+		assert.Equal(t, bobID, resp.Messages[0].Id)
+	})
+
+	t.Run("BatchDeleteSkipsOtherMailboxes", func(t *testing.T) {
+		err := gmailService.Users.Messages.BatchDelete("bob@example.com", &gmail.BatchDeleteMessagesRequest{
+			Ids: []string{aliceID, bobID},
+		}).Do()
+		require.NoError(t, err, "Batch delete should succeed")
+		assert.Empty(t, listIDs(t, "bob@example.com"), "Bob's message should be deleted")
+		assert.Equal(t, []string{aliceID}, listIDs(t, "alice@example.com"), "Alice's message should survive")
+	})
+}
+
+func TestGmailSimulatorParentSession(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+	ctx := context.Background()
+
+	// Setup: Start simulator and session servers
+	handler := session.Middleware(simulatorGmail.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	sessions := httptest.NewServer(session.NewManager(queries))
+	defer sessions.Close()
+	t.Cleanup(func() {
+		_ = os.RemoveAll("sessions")
+	})
+
+	newService := func(sessionID string) *gmail.Service {
+		service, err := gmail.NewService(ctx,
+			option.WithoutAuthentication(),
+			option.WithEndpoint(server.URL+"/"),
+			option.WithHTTPClient(&http.Client{Transport: &sessionHTTPTransport{sessionID: sessionID}}),
+		)
+		require.NoError(t, err, "Failed to create Gmail service")
+		return service
+	}
+
+	// Seed the shared baseline in the parent session
+	parentID := "gmail-test-session-baseline"
+	require.NoError(t, queries.CreateSession(ctx, parentID), "Failed to create parent session")
+	parentService := newService(parentID)
+	raw := base64.URLEncoding.EncodeToString([]byte("From: baseline@example.com\r\nTo: me@example.com\r\nSubject: Baseline\r\n\r\nShared body"))
+	baseline, err := parentService.Users.Messages.Send("me", &gmail.Message{Raw: raw}).Do()
+	require.NoError(t, err, "Send in parent should succeed")
+
+	// Create a child session layered over the parent
+	body, err := json.Marshal(map[string]string{"parent_session": parentID})
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sessions.URL+"/sessions", bytes.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Create child session should succeed")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Create child session should return 200")
+	var created map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	childID := created["session_id"]
+	assert.Equal(t, parentID, created["parent_session"], "Response should name the parent")
+	t.Cleanup(func() {
+		session.ClearParent(childID)
+	})
+	childService := newService(childID)
+
+	t.Run("ReadParentMessageFromChild", func(t *testing.T) {
+		msg, err := childService.Users.Messages.Get("me", baseline.Id).Do()
+		require.NoError(t, err, "Child should read the parent's message")
+		assert.Equal(t, baseline.Id, msg.Id, "Should return the baseline message")
+		assert.Contains(t, msg.Snippet, "Shared body", "Should return the baseline content")
+
+		// The message was not copied into the child
+		stored, err := queries.ListGmailMessages(ctx, database.ListGmailMessagesParams{
+			SessionID: childID,
+			UserID:    "me",
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, stored, "Reading should not copy the message into the child")
+	})
+
+	t.Run("WritesStayInChild", func(t *testing.T) {
+		raw := base64.URLEncoding.EncodeToString([]byte("From: me@example.com\r\nTo: someone@example.com\r\nSubject: Child\r\n\r\nChild body"))
+		own, err := childService.Users.Messages.Send("me", &gmail.Message{Raw: raw}).Do()
+		require.NoError(t, err, "Send in child should succeed")
+
+		childList, err := childService.Users.Messages.List("me").Do()
+		require.NoError(t, err)
+		childIDs := make([]string, 0, len(childList.Messages))
+		for _, m := range childList.Messages {
+			childIDs = append(childIDs, m.Id)
+		}
+		assert.ElementsMatch(t, []string{baseline.Id, own.Id}, childIDs, "Child list should include its own and the parent's messages")
+
+		parentList, err := parentService.Users.Messages.List("me").Do()
+		require.NoError(t, err)
+		require.Len(t, parentList.Messages, 1, "Parent should not see the child's message")
+		assert.Equal(t, baseline.Id, parentList.Messages[0].Id)
+
+		_, err = parentService.Users.Messages.Get("me", own.Id).Do()
+		require.Error(t, err, "Parent should not read the child's message")
+	})
+
+	t.Run("ParentSurvivesRestart", func(t *testing.T) {
+		// Forgetting the in-memory link is what a restart does; it is reloaded from the database
+		session.ClearParent(childID)
+
+		msg, err := childService.Users.Messages.Get("me", baseline.Id).Do()
+		require.NoError(t, err, "Child should still read the parent's message")
+		assert.Equal(t, baseline.Id, msg.Id)
+	})
+
+	t.Run("WriteCopiesParentMessage", func(t *testing.T) {
+		modified, err := childService.Users.Messages.Modify("me", baseline.Id, &gmail.ModifyMessageRequest{
+			AddLabelIds: []string{"STARRED"},
+		}).Do()
+		require.NoError(t, err, "Child should modify the parent's message")
+		assert.Contains(t, modified.LabelIds, "STARRED", "Child copy should carry the new label")
+
+		_, err = childService.Users.Messages.Trash("me", baseline.Id).Do()
+		require.NoError(t, err, "Child should trash the parent's message")
+
+		childMsg, err := childService.Users.Messages.Get("me", baseline.Id).Do()
+		require.NoError(t, err)
+		assert.Contains(t, childMsg.LabelIds, "TRASH", "Child should see its trashed copy")
+		assert.Contains(t, childMsg.Snippet, "Shared body", "Child copy should keep the content")
+
+		parentMsg, err := parentService.Users.Messages.Get("me", baseline.Id).Do()
+		require.NoError(t, err)
+		assert.NotContains(t, parentMsg.LabelIds, "STARRED", "Parent message should be unchanged")
+		assert.NotContains(t, parentMsg.LabelIds, "TRASH", "Parent message should be unchanged")
+
+		childList, err := childService.Users.Messages.List("me").IncludeSpamTrash(true).Do()
+		require.NoError(t, err)
+		count := 0
+		for _, m := range childList.Messages {
+			if m.Id == baseline.Id {
+				count++
+			}
+		}
+		assert.Equal(t, 1, count, "The child copy should shadow the parent's message")
+	})
+
+	t.Run("DeleteHidesParentMessage", func(t *testing.T) {
+		err := childService.Users.Messages.Delete("me", baseline.Id).Do()
+		require.NoError(t, err, "Child should delete the parent's message")
+
+		_, err = childService.Users.Messages.Get("me", baseline.Id).Do()
+		require.Error(t, err, "Child should no longer read the deleted message")
+
+		childList, err := childService.Users.Messages.List("me").IncludeSpamTrash(true).Do()
+		require.NoError(t, err)
+		for _, m := range childList.Messages {
+			assert.NotEqual(t, baseline.Id, m.Id, "Deleted message should not be listed in the child")
+		}
+
+		_, err = parentService.Users.Messages.Get("me", baseline.Id).Do()
+		require.NoError(t, err, "Parent should keep its message")
+	})
+
+	t.Run("UnknownParentRejected", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, sessions.URL+"/sessions", strings.NewReader(`{"parent_session":"missing"}`))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Unknown parent should be rejected")
+	})
+
+	t.Run("DeleteDropsParent", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, sessions.URL+"/sessions/"+childID, http.NoBody)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Delete should succeed")
+
+		_, err = queries.GetSessionParent(ctx, childID)
+		require.ErrorIs(t, err, sql.ErrNoRows, "The parent link should be deleted with the session")
+		assert.Empty(t, session.Parent(childID), "The deleted session should have no parent")
+
+		_, err = childService.Users.Messages.Get("me", baseline.Id).Do()
+		require.Error(t, err, "A deleted child should no longer read the parent's messages")
+	})
+}
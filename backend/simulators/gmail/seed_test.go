@@ -221,6 +221,7 @@ func TestGmailInitialStateSeed(t *testing.T) {
 		// Query messages from database
 		dbMessages, err := queries.ListGmailMessages(ctx, database.ListGmailMessagesParams{
 			SessionID: sessionID,
+			UserID:    "me",
 			Limit:     100,
 		})
 		require.NoError(t, err, "ListGmailMessages should succeed")
@@ -231,6 +232,7 @@ func TestGmailInitialStateSeed(t *testing.T) {
 			fullMsg, err := queries.GetGmailMessageByID(ctx, database.GetGmailMessageByIDParams{
 				ID:        m.ID,
 				SessionID: sessionID,
+				UserID:    "me",
 			})
 			require.NoError(t, err, "GetGmailMessageByID should succeed")
 			assert.Equal(t, m.ID, fullMsg.ID, "Message ID should match")
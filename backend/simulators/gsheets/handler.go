@@ -2,18 +2,23 @@ package gsheets
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 
+	"github.com/recreate-run/nova-simulators/internal/apierror"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/fieldmask"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -30,6 +35,31 @@ type SheetProperties struct {
 
 type Sheet struct {
 	Properties *SheetProperties `json:"properties"`
+	Data       []GridData       `json:"data,omitempty"`
+	Merges     []GridRange      `json:"merges,omitempty"`
+}
+
+// GridData holds cell data for one requested range, returned when includeGridData=true
+type GridData struct {
+	StartRow    int       `json:"startRow,omitempty"`
+	StartColumn int       `json:"startColumn,omitempty"`
+	RowData     []RowData `json:"rowData,omitempty"`
+}
+
+type RowData struct {
+	Values []CellData `json:"values,omitempty"`
+}
+
+type CellData struct {
+	UserEnteredValue *ExtendedValue `json:"userEnteredValue,omitempty"`
+	EffectiveValue   *ExtendedValue `json:"effectiveValue,omitempty"`
+	FormattedValue   string         `json:"formattedValue,omitempty"`
+}
+
+type ExtendedValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	NumberValue *float64 `json:"numberValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
 }
 
 type Spreadsheet struct {
@@ -54,17 +84,30 @@ type UpdateValuesResponse struct {
 
 type AppendValuesResponse struct {
 	SpreadsheetID string      `json:"spreadsheetId"`
-	TableRange    string      `json:"tableRange"`
+	TableRange    string      `json:"tableRange,omitempty"`
 	Updates       interface{} `json:"updates"`
 }
 
+// insertDataOption values accepted by values.append
+const (
+	insertDataOptionOverwrite  = "OVERWRITE"
+	insertDataOptionInsertRows = "INSERT_ROWS"
+)
+
 type BatchUpdateRequest struct {
 	Requests []Request `json:"requests"`
 }
 
 type Request struct {
-	AddSheet    *AddSheetRequest    `json:"addSheet,omitempty"`
-	DeleteSheet *DeleteSheetRequest `json:"deleteSheet,omitempty"`
+	AddSheet                    *AddSheetRequest                    `json:"addSheet,omitempty"`
+	DeleteSheet                 *DeleteSheetRequest                 `json:"deleteSheet,omitempty"`
+	CreateDeveloperMetadata     *CreateDeveloperMetadataRequest     `json:"createDeveloperMetadata,omitempty"`
+	FindReplace                 *FindReplaceRequest                 `json:"findReplace,omitempty"`
+	MergeCells                  *MergeCellsRequest                  `json:"mergeCells,omitempty"`
+	UnmergeCells                *UnmergeCellsRequest                `json:"unmergeCells,omitempty"`
+	RepeatCell                  *RepeatCellRequest                  `json:"repeatCell,omitempty"`
+	UpdateSpreadsheetProperties *UpdateSpreadsheetPropertiesRequest `json:"updateSpreadsheetProperties,omitempty"`
+	UpdateSheetProperties       *UpdateSheetPropertiesRequest       `json:"updateSheetProperties,omitempty"`
 }
 
 type AddSheetRequest struct {
@@ -75,11 +118,149 @@ type DeleteSheetRequest struct {
 	SheetID int64 `json:"sheetId"`
 }
 
+// UpdateSpreadsheetPropertiesRequest updates the spreadsheet properties named in fields; only title is stored
+type UpdateSpreadsheetPropertiesRequest struct {
+	Properties *SpreadsheetProperties `json:"properties"`
+	Fields     string                 `json:"fields"`
+}
+
+// UpdateSheetPropertiesRequest updates the properties named in fields of the sheet with
+// properties.sheetId; only title is stored
+type UpdateSheetPropertiesRequest struct {
+	Properties *SheetProperties `json:"properties"`
+	Fields     string           `json:"fields"`
+}
+
+// RepeatCellRequest writes the same cell to every cell of a range; only userEnteredValue is stored
+type RepeatCellRequest struct {
+	Range  *GridRange `json:"range"`
+	Cell   *CellData  `json:"cell"`
+	Fields string     `json:"fields"`
+}
+
+// MergeCellsRequest merges the cells of a range into one. MERGE_COLUMNS and MERGE_ROWS merge
+// each column or row of the range separately.
+type MergeCellsRequest struct {
+	Range     *GridRange `json:"range"`
+	MergeType string     `json:"mergeType"`
+}
+
+// UnmergeCellsRequest unmerges every merge within a range
+type UnmergeCellsRequest struct {
+	Range *GridRange `json:"range"`
+}
+
+// mergeType values accepted by mergeCells
+const (
+	mergeTypeAll     = "MERGE_ALL"
+	mergeTypeColumns = "MERGE_COLUMNS"
+	mergeTypeRows    = "MERGE_ROWS"
+)
+
+// FindReplaceRequest replaces text in the cells of a range, one sheet or every sheet. Formulas
+// are not modelled, so includeFormulas has no effect.
+type FindReplaceRequest struct {
+	Find            string     `json:"find"`
+	Replacement     string     `json:"replacement"`
+	MatchCase       bool       `json:"matchCase"`
+	MatchEntireCell bool       `json:"matchEntireCell"`
+	SearchByRegex   bool       `json:"searchByRegex"`
+	IncludeFormulas bool       `json:"includeFormulas"`
+	Range           *GridRange `json:"range,omitempty"`
+	SheetID         *int64     `json:"sheetId,omitempty"`
+	AllSheets       bool       `json:"allSheets"`
+}
+
+// FindReplaceResponse counts what a findReplace changed
+type FindReplaceResponse struct {
+	ValuesChanged      int `json:"valuesChanged"`
+	FormulasChanged    int `json:"formulasChanged"`
+	RowsChanged        int `json:"rowsChanged"`
+	SheetsChanged      int `json:"sheetsChanged"`
+	OccurrencesChanged int `json:"occurrencesChanged"`
+}
+
+// GridRange is a 0-based, end-exclusive range on the sheet with the given sheetId.
+// Omitted end indexes extend to the edge of the grid.
+type GridRange struct {
+	SheetID          int64 `json:"sheetId"`
+	StartRowIndex    int   `json:"startRowIndex,omitempty"`
+	EndRowIndex      int   `json:"endRowIndex,omitempty"`
+	StartColumnIndex int   `json:"startColumnIndex,omitempty"`
+	EndColumnIndex   int   `json:"endColumnIndex,omitempty"`
+}
+
+type CreateDeveloperMetadataRequest struct {
+	DeveloperMetadata *DeveloperMetadata `json:"developerMetadata"`
+}
+
+type DeveloperMetadata struct {
+	MetadataID    int64                      `json:"metadataId,omitempty"`
+	MetadataKey   string                     `json:"metadataKey,omitempty"`
+	MetadataValue string                     `json:"metadataValue,omitempty"`
+	Location      *DeveloperMetadataLocation `json:"location,omitempty"`
+	Visibility    string                     `json:"visibility,omitempty"`
+}
+
+type DeveloperMetadataLocation struct {
+	LocationType   string          `json:"locationType,omitempty"`
+	Spreadsheet    bool            `json:"spreadsheet,omitempty"`
+	SheetID        *int64          `json:"sheetId,omitempty"`
+	DimensionRange *DimensionRange `json:"dimensionRange,omitempty"`
+}
+
+type DimensionRange struct {
+	SheetID    int64  `json:"sheetId"`
+	Dimension  string `json:"dimension,omitempty"`
+	StartIndex int64  `json:"startIndex,omitempty"`
+	EndIndex   int64  `json:"endIndex,omitempty"`
+}
+
+type DataFilter struct {
+	DeveloperMetadataLookup *DeveloperMetadataLookup `json:"developerMetadataLookup,omitempty"`
+}
+
+type DeveloperMetadataLookup struct {
+	MetadataID    int64  `json:"metadataId,omitempty"`
+	MetadataKey   string `json:"metadataKey,omitempty"`
+	MetadataValue string `json:"metadataValue,omitempty"`
+	Visibility    string `json:"visibility,omitempty"`
+	LocationType  string `json:"locationType,omitempty"`
+}
+
+type SearchDeveloperMetadataRequest struct {
+	DataFilters []DataFilter `json:"dataFilters"`
+}
+
+type MatchedDeveloperMetadata struct {
+	DeveloperMetadata *DeveloperMetadata `json:"developerMetadata"`
+	DataFilters       []DataFilter       `json:"dataFilters"`
+}
+
+type SearchDeveloperMetadataResponse struct {
+	MatchedDeveloperMetadata []MatchedDeveloperMetadata `json:"matchedDeveloperMetadata,omitempty"`
+}
+
 type BatchUpdateResponse struct {
 	SpreadsheetID string        `json:"spreadsheetId"`
 	Replies       []interface{} `json:"replies"`
 }
 
+// Size of a new sheet's grid, which bounds grid ranges that omit their end indexes
+const (
+	defaultRowCount    = 1000
+	defaultColumnCount = 26
+)
+
+var (
+	// errSheetNotFound is returned when a grid range names a sheetId the spreadsheet doesn't have
+	errSheetNotFound = errors.New("sheet not found")
+	// errSheetTitleTaken is returned when renaming a sheet to the title of another sheet
+	errSheetTitleTaken = errors.New("sheet title already in use")
+	// errPartialMerge is returned when a merge or unmerge range cuts through an existing merge
+	errPartialMerge = errors.New("range partially covers a merge")
+)
+
 // Handler implements the Google Sheets simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -92,6 +273,19 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountGsheetsSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"spreadsheets": counts.Spreadsheets,
+		"sheets":       counts.Sheets,
+		"cells":        counts.Cells,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[gsheets] → %s %s", r.Method, r.URL.Path)
@@ -125,6 +319,10 @@ func (h *Handler) handleSheetsAPI(w http.ResponseWriter, r *http.Request) {
 	case regexp.MustCompile(`^/[^/]+/values/.+$`).MatchString(path) && r.Method == http.MethodPut:
 		// Update range
 		h.handleUpdateRange(w, r, path)
+	case regexp.MustCompile(`^/[^/]+/developerMetadata:search$`).MatchString(path) && r.Method == http.MethodPost:
+		// Search developer metadata
+		spreadsheetID := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/developerMetadata:search")
+		h.handleSearchDeveloperMetadata(w, r, spreadsheetID)
 	case regexp.MustCompile(`^/[^/]+:batchUpdate$`).MatchString(path):
 		// Batch update
 		spreadsheetID := strings.TrimSuffix(strings.TrimPrefix(path, "/"), ":batchUpdate")
@@ -144,12 +342,12 @@ func (h *Handler) handleCreateSpreadsheet(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Generate spreadsheet ID
-	spreadsheetID := generateID()
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Generate spreadsheet ID
+	spreadsheetID := generateID(sessionID)
+
 	// Create spreadsheet in database
 	title := "Untitled spreadsheet"
 	if req.Properties != nil && req.Properties.Title != "" {
@@ -168,9 +366,9 @@ func (h *Handler) handleCreateSpreadsheet(w http.ResponseWriter, r *http.Request
 	}
 
 	// Create default "Sheet1"
-	sheetID := generateSheetID()
+	sheetID := generateSheetID(sessionID)
 	err = h.queries.CreateSheet(context.Background(), database.CreateSheetParams{
-		ID:            generateID(),
+		ID:            generateID(sessionID),
 		SpreadsheetID: spreadsheetID,
 		Title:         "Sheet1",
 		SheetID:       sheetID,
@@ -206,12 +404,15 @@ func (h *Handler) handleCreateSpreadsheet(w http.ResponseWriter, r *http.Request
 func (h *Handler) handleGetSpreadsheet(w http.ResponseWriter, r *http.Request, spreadsheetID string) {
 	log.Printf("[gsheets] → Received get spreadsheet request for ID: %s", spreadsheetID)
 
-	sessionID := session.FromContext(r.Context())
-
-	// Get spreadsheet from database
-	dbSpreadsheet, err := h.queries.GetSpreadsheet(context.Background(), database.GetSpreadsheetParams{
-		ID:        spreadsheetID,
-		SessionID: sessionID,
+	// Get spreadsheet from database, falling through to the parent session. Its sheets and
+	// cells live in whichever session holds the spreadsheet.
+	var sessionID string
+	dbSpreadsheet, err := session.ReadThrough(r.Context(), "gsheets_spreadsheets", spreadsheetID, func(candidate string) (database.GetSpreadsheetRow, error) {
+		sessionID = candidate
+		return h.queries.GetSpreadsheet(context.Background(), database.GetSpreadsheetParams{
+			ID:        spreadsheetID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[gsheets] ✗ Failed to get spreadsheet: %v", err)
@@ -230,15 +431,57 @@ func (h *Handler) handleGetSpreadsheet(w http.ResponseWriter, r *http.Request, s
 		return
 	}
 
+	// ranges limits the response to the sheets they name, and to those cells when grid data is included
+	sheetRanges := make(map[string][]ParsedRange)
+	for _, rangeNotation := range r.URL.Query()["ranges"] {
+		parsedRange, err := parseGridRange(rangeNotation)
+		if err != nil {
+			log.Printf("[gsheets] ✗ Failed to parse range: %v", err)
+			http.Error(w, "Invalid range notation", http.StatusBadRequest)
+			return
+		}
+		sheetRanges[parsedRange.SheetTitle] = append(sheetRanges[parsedRange.SheetTitle], parsedRange)
+	}
+	includeGridData := r.URL.Query().Get("includeGridData") == "true"
+
 	sheets := make([]Sheet, 0, len(dbSheets))
 	for idx, dbSheet := range dbSheets {
-		sheets = append(sheets, Sheet{
+		ranges, requested := sheetRanges[dbSheet.Title]
+		if len(sheetRanges) > 0 && !requested {
+			continue
+		}
+
+		sheet := Sheet{
 			Properties: &SheetProperties{
 				SheetID: dbSheet.SheetID,
 				Title:   dbSheet.Title,
 				Index:   idx,
 			},
-		})
+		}
+		if includeGridData {
+			if !requested {
+				ranges = []ParsedRange{wholeSheetRange(dbSheet.Title)}
+			}
+			merges, err := h.sheetMerges(sessionID, spreadsheetID, dbSheet.SheetID)
+			if err != nil {
+				log.Printf("[gsheets] ✗ Failed to get merges: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			if len(merges) > 0 {
+				sheet.Merges = merges
+			}
+			for i := range ranges {
+				gridData, err := h.gridData(sessionID, spreadsheetID, &ranges[i], merges)
+				if err != nil {
+					log.Printf("[gsheets] ✗ Failed to get cells: %v", err)
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+					return
+				}
+				sheet.Data = append(sheet.Data, gridData)
+			}
+		}
+		sheets = append(sheets, sheet)
 	}
 
 	response := Spreadsheet{
@@ -249,11 +492,123 @@ func (h *Handler) handleGetSpreadsheet(w http.ResponseWriter, r *http.Request, s
 		Sheets: sheets,
 	}
 
+	var body interface{} = response
+	if fields := r.URL.Query().Get("fields"); fields != "" {
+		body, err = fieldmask.Apply(response, fields)
+		if err != nil {
+			log.Printf("[gsheets] ✗ Invalid fields mask: %v", err)
+			http.Error(w, "Invalid field selection", http.StatusBadRequest)
+			return
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
+	_ = json.NewEncoder(w).Encode(body)
 	log.Printf("[gsheets] ✓ Returned spreadsheet: %s", spreadsheetID)
 }
 
+// spreadsheetSession returns the session holding a spreadsheet: the request's session, or its
+// parent if only the parent has the spreadsheet
+func (h *Handler) spreadsheetSession(ctx context.Context, spreadsheetID string) string {
+	var holder string
+	if _, err := session.ReadThrough(ctx, "gsheets_spreadsheets", spreadsheetID, func(candidate string) (database.GetSpreadsheetRow, error) {
+		holder = candidate
+		return h.queries.GetSpreadsheet(context.Background(), database.GetSpreadsheetParams{
+			ID:        spreadsheetID,
+			SessionID: candidate,
+		})
+	}); err != nil {
+		return session.FromContext(ctx)
+	}
+	return holder
+}
+
+// gridData loads the cells of a range as rowData, with rows and columns relative to the range start.
+// Cells hidden by a merge are left empty.
+func (h *Handler) gridData(sessionID, spreadsheetID string, parsedRange *ParsedRange, merges []GridRange) (GridData, error) {
+	dbCells, err := h.queries.GetCellsInRange(context.Background(), database.GetCellsInRangeParams{
+		SpreadsheetID: spreadsheetID,
+		SheetTitle:    parsedRange.SheetTitle,
+		Row:           int64(parsedRange.StartRow),
+		Row_2:         int64(parsedRange.EndRow),
+		Col:           int64(parsedRange.StartCol),
+		Col_2:         int64(parsedRange.EndCol),
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		return GridData{}, err
+	}
+
+	// Grid coordinates are 0-based
+	gridData := GridData{
+		StartRow:    parsedRange.StartRow - 1,
+		StartColumn: parsedRange.StartCol - 1,
+	}
+	for _, cell := range dbCells {
+		if !cell.Value.Valid || cell.Value.String == "" || hiddenByMerge(merges, int(cell.Row), int(cell.Col)) {
+			continue
+		}
+		rowIdx := int(cell.Row) - parsedRange.StartRow
+		colIdx := int(cell.Col) - parsedRange.StartCol
+		for len(gridData.RowData) <= rowIdx {
+			gridData.RowData = append(gridData.RowData, RowData{})
+		}
+		row := &gridData.RowData[rowIdx]
+		for len(row.Values) <= colIdx {
+			row.Values = append(row.Values, CellData{})
+		}
+		row.Values[colIdx] = cellData(cell.Value.String, cell.ValueType)
+	}
+	return gridData, nil
+}
+
+// cellData converts a stored cell to CellData; without formulas the entered and effective values match
+func cellData(value, valueType string) CellData {
+	extended := &ExtendedValue{}
+	switch typed := renderCellValue(value, valueType, false).(type) {
+	case float64:
+		extended.NumberValue = &typed
+	case bool:
+		extended.BoolValue = &typed
+	default:
+		extended.StringValue = &value
+	}
+	formatted, _ := renderCellValue(value, valueType, true).(string)
+	return CellData{
+		UserEnteredValue: extended,
+		EffectiveValue:   extended,
+		FormattedValue:   formatted,
+	}
+}
+
+// parseGridRange parses a range for includeGridData, where a bare sheet title selects the whole sheet
+func parseGridRange(rangeNotation string) (ParsedRange, error) {
+	sheetTitle, cellRange, err := splitSheetRange(rangeNotation)
+	if err != nil {
+		return ParsedRange{}, err
+	}
+	if sheetTitle != "" && cellRange == "" {
+		return wholeSheetRange(sheetTitle), nil
+	}
+	if sheetTitle == "" {
+		if _, _, err := parseCell(strings.Split(rangeNotation, ":")[0]); err != nil {
+			return wholeSheetRange(rangeNotation), nil
+		}
+	}
+	return parseRange(rangeNotation)
+}
+
+// wholeSheetRange covers every cell of a sheet
+func wholeSheetRange(sheetTitle string) ParsedRange {
+	return ParsedRange{
+		SheetTitle: sheetTitle,
+		StartRow:   1,
+		StartCol:   1,
+		EndRow:     math.MaxInt32,
+		EndCol:     math.MaxInt32,
+	}
+}
+
 func (h *Handler) handleReadRange(w http.ResponseWriter, r *http.Request, path string) {
 	log.Printf("[gsheets] → Received read range request for path: %s", path)
 
@@ -267,7 +622,7 @@ func (h *Handler) handleReadRange(w http.ResponseWriter, r *http.Request, path s
 	spreadsheetID := parts[0]
 	rangeNotation := parts[1]
 
-	sessionID := session.FromContext(r.Context())
+	sessionID := h.spreadsheetSession(r.Context(), spreadsheetID)
 
 	// Parse range notation (e.g., "Sheet1!A1:B2")
 	parsedRange, err := parseRange(rangeNotation)
@@ -293,19 +648,30 @@ func (h *Handler) handleReadRange(w http.ResponseWriter, r *http.Request, path s
 		return
 	}
 
+	// Only the top-left cell of a merge shows its value
+	merges, err := h.sheetMergesByTitle(sessionID, spreadsheetID, parsedRange.SheetTitle)
+	if err != nil {
+		log.Printf("[gsheets] ✗ Failed to get merges: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Numbers and booleans keep their JSON types unless formatted values are requested
+	formatted := r.URL.Query().Get("valueRenderOption") == "FORMATTED_VALUE"
+
 	// Convert to 2D array
 	values := make([][]interface{}, 0)
-	cellMap := make(map[int]map[int]string)
+	cellMap := make(map[int]map[int]interface{})
 
 	for _, cell := range dbCells {
 		rowIdx := int(cell.Row) - parsedRange.StartRow
 		colIdx := int(cell.Col) - parsedRange.StartCol
 
 		if cellMap[rowIdx] == nil {
-			cellMap[rowIdx] = make(map[int]string)
+			cellMap[rowIdx] = make(map[int]interface{})
 		}
-		if cell.Value.Valid {
-			cellMap[rowIdx][colIdx] = cell.Value.String
+		if cell.Value.Valid && cell.Value.String != "" && !hiddenByMerge(merges, int(cell.Row), int(cell.Col)) {
+			cellMap[rowIdx][colIdx] = renderCellValue(cell.Value.String, cell.ValueType, formatted)
 		}
 	}
 
@@ -316,8 +682,8 @@ func (h *Handler) handleReadRange(w http.ResponseWriter, r *http.Request, path s
 	for r := 0; r < numRows; r++ {
 		row := make([]interface{}, 0)
 		for c := 0; c < numCols; c++ {
-			if cellMap[r] != nil && cellMap[r][c] != "" {
-				row = append(row, cellMap[r][c])
+			if value, ok := cellMap[r][c]; ok {
+				row = append(row, value)
 			} else {
 				row = append(row, "")
 			}
@@ -334,6 +700,7 @@ func (h *Handler) handleReadRange(w http.ResponseWriter, r *http.Request, path s
 			values = append(values, row)
 		}
 	}
+	values = listcap.Truncate(w, values)
 
 	response := ValueRange{
 		Range:          rangeNotation,
@@ -377,17 +744,24 @@ func (h *Handler) handleUpdateRange(w http.ResponseWriter, r *http.Request, path
 		return
 	}
 
+	if message := checkRangeBounds(rangeNotation, &parsedRange, req.Values); message != "" {
+		log.Printf("[gsheets] ✗ Values exceed range %s", rangeNotation)
+		apierror.Write(w, apierror.Google, http.StatusBadRequest, message)
+		return
+	}
+
 	// Write values to database
 	updatedCells := 0
 	for rowIdx, row := range req.Values {
 		for colIdx, val := range row {
-			value := fmt.Sprintf("%v", val)
+			value, valueType := encodeCellValue(val)
 			err := h.queries.SetCellValue(context.Background(), database.SetCellValueParams{
 				SpreadsheetID: spreadsheetID,
 				SheetTitle:    parsedRange.SheetTitle,
 				Row:           int64(parsedRange.StartRow + rowIdx),
 				Col:           int64(parsedRange.StartCol + colIdx),
 				Value:         sql.NullString{String: value, Valid: true},
+				ValueType:     valueType,
 				SessionID:     sessionID,
 			})
 			if err != nil {
@@ -448,42 +822,63 @@ func (h *Handler) handleAppendRows(w http.ResponseWriter, r *http.Request, path
 		return
 	}
 
-	// Find the next available row
-	maxRowResult, err := h.queries.GetMaxRowInSheet(context.Background(), database.GetMaxRowInSheetParams{
-		SpreadsheetID: spreadsheetID,
-		SheetTitle:    parsedRange.SheetTitle,
-		SessionID:     sessionID,
-	})
+	// OVERWRITE (the default) writes after the table; INSERT_ROWS also shifts the rows below it down
+	insertDataOption := r.URL.Query().Get("insertDataOption")
+	if insertDataOption == "" {
+		insertDataOption = insertDataOptionOverwrite
+	}
+	if insertDataOption != insertDataOptionOverwrite && insertDataOption != insertDataOptionInsertRows {
+		log.Printf("[gsheets] ✗ Invalid insertDataOption: %s", insertDataOption)
+		http.Error(w, "Invalid value at 'insert_data_option': "+insertDataOption, http.StatusBadRequest)
+		return
+	}
+
+	// Find the table within the range; values go on the row right after it
+	tableStart, tableEnd, err := h.findTable(spreadsheetID, sessionID, &parsedRange)
 	if err != nil {
-		log.Printf("[gsheets] ✗ Failed to get max row: %v", err)
+		log.Printf("[gsheets] ✗ Failed to find table: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	// Convert maxRowResult to int64
-	var maxRow int64
-	switch v := maxRowResult.(type) {
-	case int64:
-		maxRow = v
-	case int:
-		maxRow = int64(v)
-	default:
-		maxRow = 0
-	}
+	startRow := tableEnd + 1
 
-	startRow := int(maxRow) + 1
+	if insertDataOption == insertDataOptionInsertRows && len(req.Values) > 0 {
+		err = h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+			if err := q.StageRowShift(context.Background(), database.StageRowShiftParams{
+				RowCount:      int64(len(req.Values)),
+				SpreadsheetID: spreadsheetID,
+				SheetTitle:    parsedRange.SheetTitle,
+				SessionID:     sessionID,
+				FromRow:       int64(startRow),
+			}); err != nil {
+				return err
+			}
+			return q.CommitRowShift(context.Background(), database.CommitRowShiftParams{
+				SpreadsheetID: spreadsheetID,
+				SheetTitle:    parsedRange.SheetTitle,
+				SessionID:     sessionID,
+			})
+		})
+		if err != nil {
+			log.Printf("[gsheets] ✗ Failed to insert rows: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
 
 	// Append values
 	updatedCells := 0
 	for rowIdx, row := range req.Values {
 		for colIdx, val := range row {
-			value := fmt.Sprintf("%v", val)
+			value, valueType := encodeCellValue(val)
 			err := h.queries.SetCellValue(context.Background(), database.SetCellValueParams{
 				SpreadsheetID: spreadsheetID,
 				SheetTitle:    parsedRange.SheetTitle,
 				Row:           int64(startRow + rowIdx),
 				Col:           int64(parsedRange.StartCol + colIdx),
 				Value:         sql.NullString{String: value, Valid: true},
+				ValueType:     valueType,
 				SessionID:     sessionID,
 			})
 			if err != nil {
@@ -497,15 +892,25 @@ func (h *Handler) handleAppendRows(w http.ResponseWriter, r *http.Request, path
 
 	// Calculate the actual range that was updated
 	actualRange := fmt.Sprintf("%s!%s%d:%s%d",
-		parsedRange.SheetTitle,
+		quoteSheetTitle(parsedRange.SheetTitle),
 		columnToLetter(parsedRange.StartCol),
 		startRow,
 		columnToLetter(parsedRange.EndCol),
 		startRow+len(req.Values)-1)
 
+	tableRange := ""
+	if tableStart > 0 {
+		tableRange = fmt.Sprintf("%s!%s%d:%s%d",
+			quoteSheetTitle(parsedRange.SheetTitle),
+			columnToLetter(parsedRange.StartCol),
+			tableStart,
+			columnToLetter(parsedRange.EndCol),
+			tableEnd)
+	}
+
 	response := AppendValuesResponse{
 		SpreadsheetID: spreadsheetID,
-		TableRange:    actualRange,
+		TableRange:    tableRange,
 		Updates: UpdateValuesResponse{
 			SpreadsheetID:  spreadsheetID,
 			UpdatedRange:   actualRange,
@@ -517,7 +922,43 @@ func (h *Handler) handleAppendRows(w http.ResponseWriter, r *http.Request, path
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[gsheets] ✓ Appended %d rows starting at row %d", len(req.Values), startRow)
+	log.Printf("[gsheets] ✓ Appended %d rows starting at row %d (%s)", len(req.Values), startRow, insertDataOption)
+}
+
+// findTable locates the first contiguous block of non-empty rows at or below the start of the
+// range. It returns 0, startRow-1 when the range holds no data.
+func (h *Handler) findTable(spreadsheetID, sessionID string, parsedRange *ParsedRange) (start, end int, err error) {
+	// A single-cell range searches every column to its right, like the Sheets API
+	endCol := parsedRange.EndCol
+	if parsedRange.StartCol == parsedRange.EndCol {
+		endCol = math.MaxInt32
+	}
+
+	rows, err := h.queries.ListOccupiedRows(context.Background(), database.ListOccupiedRowsParams{
+		SpreadsheetID: spreadsheetID,
+		SheetTitle:    parsedRange.SheetTitle,
+		SessionID:     sessionID,
+		StartCol:      int64(parsedRange.StartCol),
+		EndCol:        int64(endCol),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end = parsedRange.StartRow - 1
+	for _, row := range rows {
+		switch {
+		case int(row) < parsedRange.StartRow:
+			continue
+		case start == 0:
+			start, end = int(row), int(row)
+		case int(row) == end+1:
+			end = int(row)
+		default:
+			return start, end, nil
+		}
+	}
+	return start, end, nil
 }
 
 func (h *Handler) handleBatchUpdate(w http.ResponseWriter, r *http.Request, spreadsheetID string) {
@@ -538,10 +979,29 @@ func (h *Handler) handleBatchUpdate(w http.ResponseWriter, r *http.Request, spre
 	// Process each request
 	for _, request := range req.Requests {
 		if request.AddSheet != nil {
-			// Add sheet
-			sheetID := generateSheetID()
+			// Add sheet, keeping sheetIds unique within the spreadsheet
+			var sheetID int64
 			if request.AddSheet.Properties != nil && request.AddSheet.Properties.SheetID != 0 {
 				sheetID = request.AddSheet.Properties.SheetID
+				taken, err := h.sheetIDTaken(sessionID, spreadsheetID, sheetID)
+				if err != nil {
+					log.Printf("[gsheets] ✗ Failed to look up sheet: %v", err)
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+					return
+				}
+				if taken {
+					log.Printf("[gsheets] ✗ Sheet ID %d already exists", sheetID)
+					http.Error(w, fmt.Sprintf("A sheet with the id %d already exists", sheetID), http.StatusBadRequest)
+					return
+				}
+			} else {
+				var err error
+				sheetID, err = h.uniqueSheetID(sessionID, spreadsheetID)
+				if err != nil {
+					log.Printf("[gsheets] ✗ Failed to generate sheet ID: %v", err)
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+					return
+				}
 			}
 
 			title := "Sheet"
@@ -550,7 +1010,7 @@ func (h *Handler) handleBatchUpdate(w http.ResponseWriter, r *http.Request, spre
 			}
 
 			err := h.queries.CreateSheet(context.Background(), database.CreateSheetParams{
-				ID:            generateID(),
+				ID:            generateID(sessionID),
 				SpreadsheetID: spreadsheetID,
 				Title:         title,
 				SheetID:       sheetID,
@@ -571,11 +1031,22 @@ func (h *Handler) handleBatchUpdate(w http.ResponseWriter, r *http.Request, spre
 				},
 			})
 		} else if request.DeleteSheet != nil {
-			// Delete sheet
-			err := h.queries.DeleteSheet(context.Background(), database.DeleteSheetParams{
-				SpreadsheetID: spreadsheetID,
-				SheetID:       request.DeleteSheet.SheetID,
-				SessionID:     sessionID,
+			// Delete sheet along with its merges, so a later sheet reusing the ID starts unmerged
+			err := h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+				ctx := context.Background()
+				err := q.DeleteSheet(ctx, database.DeleteSheetParams{
+					SpreadsheetID: spreadsheetID,
+					SheetID:       request.DeleteSheet.SheetID,
+					SessionID:     sessionID,
+				})
+				if err != nil {
+					return err
+				}
+				return q.DeleteSheetMerges(ctx, database.DeleteSheetMergesParams{
+					SpreadsheetID: spreadsheetID,
+					SheetID:       request.DeleteSheet.SheetID,
+					SessionID:     sessionID,
+				})
 			})
 			if err != nil {
 				log.Printf("[gsheets] ✗ Failed to delete sheet: %v", err)
@@ -583,6 +1054,80 @@ func (h *Handler) handleBatchUpdate(w http.ResponseWriter, r *http.Request, spre
 				return
 			}
 
+			replies = append(replies, map[string]interface{}{})
+		} else if request.CreateDeveloperMetadata != nil {
+			// Create developer metadata
+			metadata := request.CreateDeveloperMetadata.DeveloperMetadata
+			if metadata == nil || metadata.MetadataKey == "" {
+				log.Println("[gsheets] ✗ Developer metadata requires a metadataKey")
+				http.Error(w, "developerMetadata.metadataKey is required", http.StatusBadRequest)
+				return
+			}
+
+			created, err := h.createDeveloperMetadata(sessionID, spreadsheetID, metadata)
+			if err != nil {
+				log.Printf("[gsheets] ✗ Failed to create developer metadata: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			replies = append(replies, map[string]interface{}{
+				"createDeveloperMetadata": map[string]interface{}{
+					"developerMetadata": created,
+				},
+			})
+		} else if request.RepeatCell != nil {
+			// Repeat a cell across a grid range
+			if request.RepeatCell.Range == nil {
+				http.Error(w, "repeatCell.range is required", http.StatusBadRequest)
+				return
+			}
+			parsedRange, err := h.resolveGridRange(sessionID, spreadsheetID, request.RepeatCell.Range)
+			if errors.Is(err, errSheetNotFound) {
+				log.Printf("[gsheets] ✗ No sheet with ID %d", request.RepeatCell.Range.SheetID)
+				http.Error(w, fmt.Sprintf("No grid with id: %d", request.RepeatCell.Range.SheetID), http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				log.Printf("[gsheets] ✗ Failed to resolve grid range: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			if err := h.repeatCell(sessionID, spreadsheetID, &parsedRange, request.RepeatCell); err != nil {
+				log.Printf("[gsheets] ✗ Failed to repeat cell: %v", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			replies = append(replies, map[string]interface{}{})
+		} else if request.FindReplace != nil {
+			result, ok := h.applyFindReplace(w, sessionID, spreadsheetID, request.FindReplace)
+			if !ok {
+				return
+			}
+			replies = append(replies, map[string]interface{}{
+				"findReplace": result,
+			})
+		} else if request.MergeCells != nil {
+			if !h.applyMergeCells(w, sessionID, spreadsheetID, request.MergeCells) {
+				return
+			}
+			replies = append(replies, map[string]interface{}{})
+		} else if request.UnmergeCells != nil {
+			if !h.applyUnmergeCells(w, sessionID, spreadsheetID, request.UnmergeCells) {
+				return
+			}
+			replies = append(replies, map[string]interface{}{})
+		} else if request.UpdateSpreadsheetProperties != nil {
+			if !h.updateSpreadsheetProperties(w, sessionID, spreadsheetID, request.UpdateSpreadsheetProperties) {
+				return
+			}
+			replies = append(replies, map[string]interface{}{})
+		} else if request.UpdateSheetProperties != nil {
+			if !h.updateSheetProperties(w, sessionID, spreadsheetID, request.UpdateSheetProperties) {
+				return
+			}
 			replies = append(replies, map[string]interface{}{})
 		}
 	}
@@ -597,48 +1142,788 @@ func (h *Handler) handleBatchUpdate(w http.ResponseWriter, r *http.Request, spre
 	log.Printf("[gsheets] ✓ Batch update completed with %d operations", len(req.Requests))
 }
 
-// Helper functions
+func (h *Handler) handleSearchDeveloperMetadata(w http.ResponseWriter, r *http.Request, spreadsheetID string) {
+	log.Printf("[gsheets] → Received developer metadata search for spreadsheet: %s", spreadsheetID)
 
-func generateID() string {
-	b := make([]byte, 16)
-	_, _ = rand.Read(b)
-	return hex.EncodeToString(b)
-}
+	sessionID := session.FromContext(r.Context())
 
-func generateSheetID() int64 {
-	b := make([]byte, 4)
-	_, _ = rand.Read(b)
-	id := int64(b[0]) | int64(b[1])<<8 | int64(b[2])<<16 | int64(b[3])<<24
-	if id < 0 {
-		id = -id
+	var req SearchDeveloperMetadataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[gsheets] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
 	}
-	return id
-}
-
-// ParsedRange holds the parsed range information
-type ParsedRange struct {
-	SheetTitle string
-	StartRow   int
-	StartCol   int
-	EndRow     int
-	EndCol     int
-}
-
-// parseRange parses a range notation like "Sheet1!A1:B2" or "A1:B2"
-func parseRange(rangeNotation string) (ParsedRange, error) {
-	var result ParsedRange
-	var err error
-	// Default sheet title
-	result.SheetTitle = "Sheet1"
 
-	// Check if range includes sheet title
-	parts := strings.Split(rangeNotation, "!")
-	var cellRange string
-	if len(parts) == 2 {
-		result.SheetTitle = parts[0]
-		cellRange = parts[1]
-	} else {
-		cellRange = rangeNotation
+	_, err := h.queries.GetSpreadsheet(context.Background(), database.GetSpreadsheetParams{
+		ID:        spreadsheetID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[gsheets] ✗ Failed to get spreadsheet: %v", err)
+		http.NotFound(w, r)
+		return
+	}
+
+	rows, err := h.queries.ListDeveloperMetadataBySpreadsheet(context.Background(), database.ListDeveloperMetadataBySpreadsheetParams{
+		SpreadsheetID: spreadsheetID,
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		log.Printf("[gsheets] ✗ Failed to list developer metadata: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Each metadata entry is reported once, alongside every filter it matched
+	response := SearchDeveloperMetadataResponse{}
+	for _, row := range rows {
+		metadata := developerMetadataFromRow(row)
+		var matched []DataFilter
+		for _, filter := range req.DataFilters {
+			if filter.DeveloperMetadataLookup != nil && developerMetadataMatches(metadata, filter.DeveloperMetadataLookup) {
+				matched = append(matched, filter)
+			}
+		}
+		if len(matched) > 0 {
+			response.MatchedDeveloperMetadata = append(response.MatchedDeveloperMetadata, MatchedDeveloperMetadata{
+				DeveloperMetadata: metadata,
+				DataFilters:       matched,
+			})
+		}
+	}
+	response.MatchedDeveloperMetadata = listcap.Truncate(w, response.MatchedDeveloperMetadata)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[gsheets] ✓ Matched %d developer metadata entries", len(response.MatchedDeveloperMetadata))
+}
+
+// createDeveloperMetadata fills in defaults for a metadata entry and stores it
+func (h *Handler) createDeveloperMetadata(sessionID, spreadsheetID string, metadata *DeveloperMetadata) (*DeveloperMetadata, error) {
+	created := *metadata
+	if created.MetadataID == 0 {
+		created.MetadataID = generateSheetID(sessionID)
+	}
+	if created.Visibility == "" {
+		created.Visibility = "DOCUMENT"
+	}
+
+	location := DeveloperMetadataLocation{Spreadsheet: true}
+	if created.Location != nil {
+		location = *created.Location
+	}
+	if location.LocationType == "" {
+		switch {
+		case location.DimensionRange != nil && location.DimensionRange.Dimension == "COLUMNS":
+			location.LocationType = "COLUMN"
+		case location.DimensionRange != nil:
+			location.LocationType = "ROW"
+		case location.SheetID != nil:
+			location.LocationType = "SHEET"
+		default:
+			location.LocationType = "SPREADSHEET"
+			location.Spreadsheet = true
+		}
+	}
+	created.Location = &location
+
+	locationJSON, err := json.Marshal(location)
+	if err != nil {
+		return nil, err
+	}
+
+	var value sql.NullString
+	if created.MetadataValue != "" {
+		value = sql.NullString{String: created.MetadataValue, Valid: true}
+	}
+
+	err = h.queries.CreateDeveloperMetadata(context.Background(), database.CreateDeveloperMetadataParams{
+		MetadataID:    created.MetadataID,
+		SpreadsheetID: spreadsheetID,
+		MetadataKey:   created.MetadataKey,
+		MetadataValue: value,
+		Location:      string(locationJSON),
+		Visibility:    created.Visibility,
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// uniqueSheetID generates a sheetId that no sheet in the spreadsheet uses yet
+func (h *Handler) uniqueSheetID(sessionID, spreadsheetID string) (int64, error) {
+	for {
+		sheetID := generateSheetID(sessionID)
+		taken, err := h.sheetIDTaken(sessionID, spreadsheetID, sheetID)
+		if err != nil {
+			return 0, err
+		}
+		if !taken {
+			return sheetID, nil
+		}
+	}
+}
+
+// sheetIDTaken reports whether a sheet in the spreadsheet already uses sheetID
+func (h *Handler) sheetIDTaken(sessionID, spreadsheetID string, sheetID int64) (bool, error) {
+	_, err := h.queries.GetSheetBySheetID(context.Background(), database.GetSheetBySheetIDParams{
+		SpreadsheetID: spreadsheetID,
+		SheetID:       sheetID,
+		SessionID:     sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// updateSpreadsheetProperties renames the spreadsheet when the field mask selects its title. It
+// writes the error response and returns false if the request can't be applied.
+func (h *Handler) updateSpreadsheetProperties(w http.ResponseWriter, sessionID, spreadsheetID string, update *UpdateSpreadsheetPropertiesRequest) bool {
+	if update.Fields == "" {
+		http.Error(w, "updateSpreadsheetProperties.fields is required", http.StatusBadRequest)
+		return false
+	}
+	if !fieldsInclude(update.Fields, "title") {
+		return true
+	}
+	if update.Properties == nil || update.Properties.Title == "" {
+		http.Error(w, "updateSpreadsheetProperties.properties.title is required", http.StatusBadRequest)
+		return false
+	}
+
+	err := h.queries.UpdateSpreadsheetTitle(context.Background(), database.UpdateSpreadsheetTitleParams{
+		Title:     update.Properties.Title,
+		ID:        spreadsheetID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[gsheets] ✗ Failed to rename spreadsheet: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// updateSheetProperties renames a sheet when the field mask selects its title. It writes the
+// error response and returns false if the request can't be applied.
+func (h *Handler) updateSheetProperties(w http.ResponseWriter, sessionID, spreadsheetID string, update *UpdateSheetPropertiesRequest) bool {
+	if update.Fields == "" || update.Properties == nil {
+		http.Error(w, "updateSheetProperties.properties and fields are required", http.StatusBadRequest)
+		return false
+	}
+	if !fieldsInclude(update.Fields, "title") {
+		return true
+	}
+	if update.Properties.Title == "" {
+		http.Error(w, "updateSheetProperties.properties.title is required", http.StatusBadRequest)
+		return false
+	}
+
+	err := h.renameSheet(sessionID, spreadsheetID, update.Properties.SheetID, update.Properties.Title)
+	switch {
+	case errors.Is(err, errSheetNotFound):
+		log.Printf("[gsheets] ✗ No sheet with ID %d", update.Properties.SheetID)
+		http.Error(w, fmt.Sprintf("No grid with id: %d", update.Properties.SheetID), http.StatusBadRequest)
+		return false
+	case errors.Is(err, errSheetTitleTaken):
+		log.Printf("[gsheets] ✗ Sheet title already in use: %s", update.Properties.Title)
+		http.Error(w, fmt.Sprintf("A sheet with the name %q already exists. Please enter another name.", update.Properties.Title), http.StatusBadRequest)
+		return false
+	case err != nil:
+		log.Printf("[gsheets] ✗ Failed to rename sheet: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// renameSheet retitles the sheet with sheetID and moves its cells to the new title, so ranges
+// written against the new name resolve to the existing data
+func (h *Handler) renameSheet(sessionID, spreadsheetID string, sheetID int64, title string) error {
+	return h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+		ctx := context.Background()
+		sheet, err := q.GetSheetBySheetID(ctx, database.GetSheetBySheetIDParams{
+			SpreadsheetID: spreadsheetID,
+			SheetID:       sheetID,
+			SessionID:     sessionID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return errSheetNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if sheet.Title == title {
+			return nil
+		}
+
+		_, err = q.GetSheetByTitle(ctx, database.GetSheetByTitleParams{
+			SpreadsheetID: spreadsheetID,
+			Title:         title,
+			SessionID:     sessionID,
+		})
+		if err == nil {
+			return errSheetTitleTaken
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if err := q.UpdateSheetTitle(ctx, database.UpdateSheetTitleParams{
+			Title:         title,
+			SpreadsheetID: spreadsheetID,
+			SheetID:       sheetID,
+			SessionID:     sessionID,
+		}); err != nil {
+			return err
+		}
+		return q.RenameSheetCells(ctx, database.RenameSheetCellsParams{
+			NewTitle:      title,
+			SpreadsheetID: spreadsheetID,
+			OldTitle:      sheet.Title,
+			SessionID:     sessionID,
+		})
+	})
+}
+
+// applyFindReplace validates a findReplace request and runs it. It writes the error response and
+// returns false if the request can't be applied.
+func (h *Handler) applyFindReplace(w http.ResponseWriter, sessionID, spreadsheetID string, request *FindReplaceRequest) (FindReplaceResponse, bool) {
+	if request.Find == "" {
+		http.Error(w, "findReplace.find is required", http.StatusBadRequest)
+		return FindReplaceResponse{}, false
+	}
+	scopes := 0
+	for _, set := range []bool{request.Range != nil, request.SheetID != nil, request.AllSheets} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		http.Error(w, "findReplace requires exactly one of range, sheetId or allSheets", http.StatusBadRequest)
+		return FindReplaceResponse{}, false
+	}
+
+	pattern := request.Find
+	if !request.SearchByRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if request.MatchEntireCell {
+		pattern = "^(?:" + pattern + ")$"
+	}
+	if !request.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		log.Printf("[gsheets] ✗ Invalid findReplace pattern: %v", err)
+		http.Error(w, fmt.Sprintf("Invalid regular expression: %s", request.Find), http.StatusBadRequest)
+		return FindReplaceResponse{}, false
+	}
+
+	result, err := h.findReplace(sessionID, spreadsheetID, request, re)
+	if errors.Is(err, errSheetNotFound) {
+		log.Println("[gsheets] ✗ findReplace names a sheet that does not exist")
+		http.Error(w, "No grid with the given id", http.StatusBadRequest)
+		return FindReplaceResponse{}, false
+	}
+	if err != nil {
+		log.Printf("[gsheets] ✗ Failed to find and replace: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return FindReplaceResponse{}, false
+	}
+	return result, true
+}
+
+// findReplace rewrites the string and number cells in the request's scope that match pattern.
+// A number cell stays a number only if its replaced text still parses as one; boolean cells are
+// never matched since their stored text differs from what Sheets displays.
+func (h *Handler) findReplace(sessionID, spreadsheetID string, request *FindReplaceRequest, pattern *regexp.Regexp) (FindReplaceResponse, error) {
+	inScope := func(*database.ListSpreadsheetCellsRow) bool { return true }
+	switch {
+	case request.Range != nil:
+		parsedRange, err := h.resolveGridRange(sessionID, spreadsheetID, request.Range)
+		if err != nil {
+			return FindReplaceResponse{}, err
+		}
+		inScope = func(cell *database.ListSpreadsheetCellsRow) bool {
+			return cell.SheetTitle == parsedRange.SheetTitle &&
+				cell.Row >= int64(parsedRange.StartRow) && cell.Row <= int64(parsedRange.EndRow) &&
+				cell.Col >= int64(parsedRange.StartCol) && cell.Col <= int64(parsedRange.EndCol)
+		}
+	case request.SheetID != nil:
+		sheet, err := h.queries.GetSheetBySheetID(context.Background(), database.GetSheetBySheetIDParams{
+			SpreadsheetID: spreadsheetID,
+			SheetID:       *request.SheetID,
+			SessionID:     sessionID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return FindReplaceResponse{}, errSheetNotFound
+		}
+		if err != nil {
+			return FindReplaceResponse{}, err
+		}
+		inScope = func(cell *database.ListSpreadsheetCellsRow) bool { return cell.SheetTitle == sheet.Title }
+	}
+
+	cells, err := h.queries.ListSpreadsheetCells(context.Background(), database.ListSpreadsheetCellsParams{
+		SpreadsheetID: spreadsheetID,
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		return FindReplaceResponse{}, err
+	}
+
+	var result FindReplaceResponse
+	rows := make(map[string]bool)
+	sheets := make(map[string]bool)
+	err = h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+		for i := range cells {
+			cell := &cells[i]
+			if !inScope(cell) || !cell.Value.Valid || cell.Value.String == "" || cell.ValueType == "boolean" {
+				continue
+			}
+			matches := pattern.FindAllStringIndex(cell.Value.String, -1)
+			if len(matches) == 0 {
+				continue
+			}
+
+			var replaced string
+			if request.SearchByRegex {
+				replaced = pattern.ReplaceAllString(cell.Value.String, request.Replacement)
+			} else {
+				replaced = pattern.ReplaceAllLiteralString(cell.Value.String, request.Replacement)
+			}
+			valueType := "string"
+			if _, err := strconv.ParseFloat(replaced, 64); err == nil && cell.ValueType == "number" {
+				valueType = "number"
+			}
+
+			err := q.SetCellValue(context.Background(), database.SetCellValueParams{
+				SpreadsheetID: spreadsheetID,
+				SheetTitle:    cell.SheetTitle,
+				Row:           cell.Row,
+				Col:           cell.Col,
+				Value:         sql.NullString{String: replaced, Valid: true},
+				ValueType:     valueType,
+				SessionID:     sessionID,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.ValuesChanged++
+			result.OccurrencesChanged += len(matches)
+			rows[fmt.Sprintf("%s!%d", cell.SheetTitle, cell.Row)] = true
+			sheets[cell.SheetTitle] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return FindReplaceResponse{}, err
+	}
+
+	result.RowsChanged = len(rows)
+	result.SheetsChanged = len(sheets)
+	return result, nil
+}
+
+// resolveGridRange maps a grid range to the A1-style range of the sheet its sheetId refers to
+func (h *Handler) resolveGridRange(sessionID, spreadsheetID string, gridRange *GridRange) (ParsedRange, error) {
+	sheet, err := h.queries.GetSheetBySheetID(context.Background(), database.GetSheetBySheetIDParams{
+		SpreadsheetID: spreadsheetID,
+		SheetID:       gridRange.SheetID,
+		SessionID:     sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ParsedRange{}, errSheetNotFound
+	}
+	if err != nil {
+		return ParsedRange{}, err
+	}
+
+	endRow := gridRange.EndRowIndex
+	if endRow == 0 {
+		endRow = defaultRowCount
+	}
+	endCol := gridRange.EndColumnIndex
+	if endCol == 0 {
+		endCol = defaultColumnCount
+	}
+
+	// Grid indexes are 0-based and end-exclusive, while parsed ranges are 1-based and inclusive
+	return ParsedRange{
+		SheetTitle: sheet.Title,
+		StartRow:   gridRange.StartRowIndex + 1,
+		StartCol:   gridRange.StartColumnIndex + 1,
+		EndRow:     endRow,
+		EndCol:     endCol,
+	}, nil
+}
+
+// repeatCell writes the request's cell value to every cell of the range when its fields include
+// userEnteredValue; a missing value clears the range
+func (h *Handler) repeatCell(sessionID, spreadsheetID string, parsedRange *ParsedRange, request *RepeatCellRequest) error {
+	if !fieldsInclude(request.Fields, "userEnteredValue") {
+		return nil
+	}
+
+	if request.Cell == nil || request.Cell.UserEnteredValue == nil {
+		return h.queries.ClearRange(context.Background(), database.ClearRangeParams{
+			SpreadsheetID: spreadsheetID,
+			SheetTitle:    parsedRange.SheetTitle,
+			Row:           int64(parsedRange.StartRow),
+			Row_2:         int64(parsedRange.EndRow),
+			Col:           int64(parsedRange.StartCol),
+			Col_2:         int64(parsedRange.EndCol),
+			SessionID:     sessionID,
+		})
+	}
+
+	value, valueType := encodeCellValue(request.Cell.UserEnteredValue.value())
+	for row := parsedRange.StartRow; row <= parsedRange.EndRow; row++ {
+		for col := parsedRange.StartCol; col <= parsedRange.EndCol; col++ {
+			err := h.queries.SetCellValue(context.Background(), database.SetCellValueParams{
+				SpreadsheetID: spreadsheetID,
+				SheetTitle:    parsedRange.SheetTitle,
+				Row:           int64(row),
+				Col:           int64(col),
+				Value:         sql.NullString{String: value, Valid: true},
+				ValueType:     valueType,
+				SessionID:     sessionID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyMergeCells stores the merges of a mergeCells request, writing an error response and
+// returning false if it can't be applied
+func (h *Handler) applyMergeCells(w http.ResponseWriter, sessionID, spreadsheetID string, request *MergeCellsRequest) bool {
+	if request.Range == nil {
+		http.Error(w, "mergeCells.range is required", http.StatusBadRequest)
+		return false
+	}
+	mergeType := request.MergeType
+	if mergeType == "" {
+		mergeType = mergeTypeAll
+	}
+	if mergeType != mergeTypeAll && mergeType != mergeTypeColumns && mergeType != mergeTypeRows {
+		http.Error(w, fmt.Sprintf("Invalid mergeType: %s", request.MergeType), http.StatusBadRequest)
+		return false
+	}
+
+	err := h.mergeCells(sessionID, spreadsheetID, request.Range, mergeType)
+	return writeMergeError(w, request.Range, err, "merge")
+}
+
+// applyUnmergeCells removes the merges within an unmergeCells range, writing an error response
+// and returning false if it can't be applied
+func (h *Handler) applyUnmergeCells(w http.ResponseWriter, sessionID, spreadsheetID string, request *UnmergeCellsRequest) bool {
+	if request.Range == nil {
+		http.Error(w, "unmergeCells.range is required", http.StatusBadRequest)
+		return false
+	}
+
+	target, err := h.resolveMergeRange(sessionID, spreadsheetID, request.Range)
+	if err == nil {
+		err = h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+			return removeMergesWithin(q, sessionID, spreadsheetID, &target)
+		})
+	}
+	return writeMergeError(w, request.Range, err, "unmerge")
+}
+
+// writeMergeError reports a failed merge or unmerge, returning true when there was no error
+func writeMergeError(w http.ResponseWriter, gridRange *GridRange, err error, action string) bool {
+	switch {
+	case errors.Is(err, errSheetNotFound):
+		log.Printf("[gsheets] ✗ No sheet with ID %d", gridRange.SheetID)
+		http.Error(w, fmt.Sprintf("No grid with id: %d", gridRange.SheetID), http.StatusBadRequest)
+		return false
+	case errors.Is(err, errPartialMerge):
+		log.Printf("[gsheets] ✗ Cannot %s a range that partially covers a merge", action)
+		http.Error(w, fmt.Sprintf("You must select all cells in a merged range to %s them.", action), http.StatusBadRequest)
+		return false
+	case err != nil:
+		log.Printf("[gsheets] ✗ Failed to %s cells: %v", action, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// mergeCells replaces the merges inside the range with new ones of the given merge type
+func (h *Handler) mergeCells(sessionID, spreadsheetID string, gridRange *GridRange, mergeType string) error {
+	target, err := h.resolveMergeRange(sessionID, spreadsheetID, gridRange)
+	if err != nil {
+		return err
+	}
+
+	merges := []GridRange{target}
+	switch mergeType {
+	case mergeTypeColumns:
+		merges = merges[:0]
+		for col := target.StartColumnIndex; col < target.EndColumnIndex; col++ {
+			column := target
+			column.StartColumnIndex, column.EndColumnIndex = col, col+1
+			merges = append(merges, column)
+		}
+	case mergeTypeRows:
+		merges = merges[:0]
+		for row := target.StartRowIndex; row < target.EndRowIndex; row++ {
+			line := target
+			line.StartRowIndex, line.EndRowIndex = row, row+1
+			merges = append(merges, line)
+		}
+	}
+
+	return h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+		if err := removeMergesWithin(q, sessionID, spreadsheetID, &target); err != nil {
+			return err
+		}
+		for _, merge := range merges {
+			// A single cell has nothing to merge with
+			if merge.EndRowIndex-merge.StartRowIndex < 2 && merge.EndColumnIndex-merge.StartColumnIndex < 2 {
+				continue
+			}
+			err := q.CreateSheetMerge(context.Background(), database.CreateSheetMergeParams{
+				SpreadsheetID:    spreadsheetID,
+				SheetID:          merge.SheetID,
+				StartRowIndex:    int64(merge.StartRowIndex),
+				EndRowIndex:      int64(merge.EndRowIndex),
+				StartColumnIndex: int64(merge.StartColumnIndex),
+				EndColumnIndex:   int64(merge.EndColumnIndex),
+				SessionID:        sessionID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// resolveMergeRange checks the range's sheet exists and fills in omitted end indexes
+func (h *Handler) resolveMergeRange(sessionID, spreadsheetID string, gridRange *GridRange) (GridRange, error) {
+	parsedRange, err := h.resolveGridRange(sessionID, spreadsheetID, gridRange)
+	if err != nil {
+		return GridRange{}, err
+	}
+	return GridRange{
+		SheetID:          gridRange.SheetID,
+		StartRowIndex:    parsedRange.StartRow - 1,
+		EndRowIndex:      parsedRange.EndRow,
+		StartColumnIndex: parsedRange.StartCol - 1,
+		EndColumnIndex:   parsedRange.EndCol,
+	}, nil
+}
+
+// removeMergesWithin deletes the merges inside target, failing with errPartialMerge if a merge
+// only partly overlaps it
+func removeMergesWithin(q *database.Queries, sessionID, spreadsheetID string, target *GridRange) error {
+	ctx := context.Background()
+	rows, err := q.ListSheetMerges(ctx, database.ListSheetMergesParams{
+		SpreadsheetID: spreadsheetID,
+		SheetID:       target.SheetID,
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		merge := mergeFromRow(target.SheetID, &row)
+		if !gridRangesOverlap(&merge, target) {
+			continue
+		}
+		if !gridRangeContains(target, &merge) {
+			return errPartialMerge
+		}
+		if err := q.DeleteSheetMerge(ctx, database.DeleteSheetMergeParams{ID: row.ID, SessionID: sessionID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sheetMerges returns the merges of the sheet with sheetID
+func (h *Handler) sheetMerges(sessionID, spreadsheetID string, sheetID int64) ([]GridRange, error) {
+	rows, err := h.queries.ListSheetMerges(context.Background(), database.ListSheetMergesParams{
+		SpreadsheetID: spreadsheetID,
+		SheetID:       sheetID,
+		SessionID:     sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merges := make([]GridRange, 0, len(rows))
+	for i := range rows {
+		merges = append(merges, mergeFromRow(sheetID, &rows[i]))
+	}
+	return merges, nil
+}
+
+// sheetMergesByTitle returns the merges of the sheet with the given title; a sheet that doesn't
+// exist has none
+func (h *Handler) sheetMergesByTitle(sessionID, spreadsheetID, title string) ([]GridRange, error) {
+	sheet, err := h.queries.GetSheetByTitle(context.Background(), database.GetSheetByTitleParams{
+		SpreadsheetID: spreadsheetID,
+		Title:         title,
+		SessionID:     sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return []GridRange{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return h.sheetMerges(sessionID, spreadsheetID, sheet.SheetID)
+}
+
+// Helper functions
+
+func mergeFromRow(sheetID int64, row *database.ListSheetMergesRow) GridRange {
+	return GridRange{
+		SheetID:          sheetID,
+		StartRowIndex:    int(row.StartRowIndex),
+		EndRowIndex:      int(row.EndRowIndex),
+		StartColumnIndex: int(row.StartColumnIndex),
+		EndColumnIndex:   int(row.EndColumnIndex),
+	}
+}
+
+func gridRangesOverlap(a, b *GridRange) bool {
+	return a.StartRowIndex < b.EndRowIndex && b.StartRowIndex < a.EndRowIndex &&
+		a.StartColumnIndex < b.EndColumnIndex && b.StartColumnIndex < a.EndColumnIndex
+}
+
+func gridRangeContains(outer, inner *GridRange) bool {
+	return outer.StartRowIndex <= inner.StartRowIndex && inner.EndRowIndex <= outer.EndRowIndex &&
+		outer.StartColumnIndex <= inner.StartColumnIndex && inner.EndColumnIndex <= outer.EndColumnIndex
+}
+
+// hiddenByMerge reports whether the 1-based cell lies in a merge other than at its top-left,
+// where reads show nothing
+func hiddenByMerge(merges []GridRange, row, col int) bool {
+	for i := range merges {
+		merge := &merges[i]
+		if row-1 < merge.StartRowIndex || row-1 >= merge.EndRowIndex ||
+			col-1 < merge.StartColumnIndex || col-1 >= merge.EndColumnIndex {
+			continue
+		}
+		return row-1 != merge.StartRowIndex || col-1 != merge.StartColumnIndex
+	}
+	return false
+}
+
+// value returns the extended value as the JSON value encodeCellValue expects
+func (v *ExtendedValue) value() interface{} {
+	switch {
+	case v.NumberValue != nil:
+		return *v.NumberValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.StringValue != nil:
+		return *v.StringValue
+	default:
+		return nil
+	}
+}
+
+// fieldsInclude reports whether a batchUpdate field mask such as "userEnteredValue,userEnteredFormat"
+// selects the named field, either directly, through a sub-field or with "*"
+func fieldsInclude(fields, name string) bool {
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "*" || field == name || strings.HasPrefix(field, name+".") || strings.HasPrefix(field, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func developerMetadataFromRow(row database.ListDeveloperMetadataBySpreadsheetRow) *DeveloperMetadata {
+	metadata := &DeveloperMetadata{
+		MetadataID:    row.MetadataID,
+		MetadataKey:   row.MetadataKey,
+		MetadataValue: row.MetadataValue.String,
+		Visibility:    row.Visibility,
+	}
+	var location DeveloperMetadataLocation
+	if err := json.Unmarshal([]byte(row.Location), &location); err == nil {
+		metadata.Location = &location
+	}
+	return metadata
+}
+
+// developerMetadataMatches reports whether metadata satisfies every field set on the lookup
+func developerMetadataMatches(metadata *DeveloperMetadata, lookup *DeveloperMetadataLookup) bool {
+	if lookup.MetadataID != 0 && lookup.MetadataID != metadata.MetadataID {
+		return false
+	}
+	if lookup.MetadataKey != "" && lookup.MetadataKey != metadata.MetadataKey {
+		return false
+	}
+	if lookup.MetadataValue != "" && lookup.MetadataValue != metadata.MetadataValue {
+		return false
+	}
+	if lookup.Visibility != "" && lookup.Visibility != metadata.Visibility {
+		return false
+	}
+	if lookup.LocationType != "" && (metadata.Location == nil || lookup.LocationType != metadata.Location.LocationType) {
+		return false
+	}
+	return true
+}
+
+func generateID(sessionID string) string {
+	b := make([]byte, 16)
+	session.RandomBytes(sessionID, b)
+	return hex.EncodeToString(b)
+}
+
+func generateSheetID(sessionID string) int64 {
+	b := make([]byte, 4)
+	session.RandomBytes(sessionID, b)
+	id := int64(b[0]) | int64(b[1])<<8 | int64(b[2])<<16 | int64(b[3])<<24
+	if id < 0 {
+		id = -id
+	}
+	return id
+}
+
+// ParsedRange holds the parsed range information
+type ParsedRange struct {
+	SheetTitle string
+	StartRow   int
+	StartCol   int
+	EndRow     int
+	EndCol     int
+}
+
+// parseRange parses a range notation like "Sheet1!A1:B2" or "A1:B2"
+func parseRange(rangeNotation string) (ParsedRange, error) {
+	var result ParsedRange
+	var err error
+	// Default sheet title
+	result.SheetTitle = "Sheet1"
+
+	// Check if range includes sheet title
+	sheetTitle, cellRange, err := splitSheetRange(rangeNotation)
+	if err != nil {
+		return result, err
+	}
+	if sheetTitle != "" {
+		result.SheetTitle = sheetTitle
 	}
 
 	// Parse cell range (e.g., "A1:B2" or "A1")
@@ -669,6 +1954,78 @@ func parseRange(rangeNotation string) (ParsedRange, error) {
 	return result, nil
 }
 
+// checkRangeBounds rejects values that don't fit a bounded range such as A1:B2. A single-cell
+// range is only an anchor and grows to fit the values, as in the Sheets API. It returns the
+// API's error message, or "" when the values fit.
+func checkRangeBounds(rangeNotation string, parsedRange *ParsedRange, values [][]interface{}) string {
+	_, cellRange, err := splitSheetRange(rangeNotation)
+	if err != nil || !strings.Contains(cellRange, ":") {
+		return ""
+	}
+
+	if rows := len(values); rows > parsedRange.EndRow-parsedRange.StartRow+1 {
+		return fmt.Sprintf("Requested writing within range [%s], but tried writing to row [%d]", rangeNotation, parsedRange.StartRow+rows-1)
+	}
+	for _, row := range values {
+		if cols := len(row); cols > parsedRange.EndCol-parsedRange.StartCol+1 {
+			return fmt.Sprintf("Requested writing within range [%s], but tried writing to column [%s]", rangeNotation, columnToLetter(parsedRange.StartCol+cols-1))
+		}
+	}
+	return ""
+}
+
+// splitSheetRange separates the sheet title from the cells of an A1 range such as
+// 'My Sheet'!A1. A quoted title may contain spaces and "!", and a doubled single quote inside
+// it stands for a literal one. The title is empty when the range has none; the cells are empty
+// for a bare quoted title.
+func splitSheetRange(rangeNotation string) (sheetTitle, cellRange string, err error) {
+	if !strings.HasPrefix(rangeNotation, "'") {
+		if idx := strings.Index(rangeNotation, "!"); idx >= 0 {
+			return rangeNotation[:idx], rangeNotation[idx+1:], nil
+		}
+		return "", rangeNotation, nil
+	}
+
+	var title strings.Builder
+	for i := 1; i < len(rangeNotation); i++ {
+		if rangeNotation[i] != '\'' {
+			title.WriteByte(rangeNotation[i])
+			continue
+		}
+		if i+1 < len(rangeNotation) && rangeNotation[i+1] == '\'' {
+			title.WriteByte('\'')
+			i++
+			continue
+		}
+		rest := rangeNotation[i+1:]
+		switch {
+		case rest == "":
+			return title.String(), "", nil
+		case strings.HasPrefix(rest, "!"):
+			return title.String(), rest[1:], nil
+		default:
+			return "", "", fmt.Errorf("invalid range notation: %s", rangeNotation)
+		}
+	}
+	return "", "", fmt.Errorf("unterminated sheet name in range: %s", rangeNotation)
+}
+
+// quoteSheetTitle formats a sheet title for A1 notation, quoting it the way the Sheets API
+// does when it contains anything other than letters, digits and underscores
+func quoteSheetTitle(title string) string {
+	plain := title != ""
+	for _, r := range title {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return title
+	}
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'"
+}
+
 // parseCell parses a cell reference like "A1" into row and column indices (1-based)
 func parseCell(cell string) (row, col int, err error) {
 	// Extract column letters and row number
@@ -711,3 +2068,41 @@ func columnToLetter(col int) string {
 	}
 	return result
 }
+
+// encodeCellValue converts a JSON cell value to its stored string form and type tag
+func encodeCellValue(val interface{}) (value, valueType string) {
+	switch v := val.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), "number"
+	case bool:
+		return strconv.FormatBool(v), "boolean"
+	case nil:
+		return "", "string"
+	default:
+		return fmt.Sprintf("%v", v), "string"
+	}
+}
+
+// renderCellValue converts a stored cell back to a JSON value according to its type tag.
+// Formatted values are always strings, with booleans shown as TRUE/FALSE like Sheets does.
+func renderCellValue(value, valueType string, formatted bool) interface{} {
+	switch valueType {
+	case "number":
+		if formatted {
+			return value
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return value
+		}
+		if formatted {
+			return strings.ToUpper(value)
+		}
+		return b
+	}
+	return value
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pressly/goose/v3"
@@ -13,6 +14,7 @@ import (
 	simulatorGsheets "github.com/recreate-run/nova-simulators/simulators/gsheets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 	_ "modernc.org/sqlite"
@@ -138,6 +140,40 @@ func TestGsheetsSimulatorGetSpreadsheet(t *testing.T) {
 		assert.Equal(t, created.SpreadsheetId, retrieved.SpreadsheetId, "IDs should match")
 		assert.Equal(t, "My Spreadsheet", retrieved.Properties.Title, "Title should match")
 		assert.Len(t, retrieved.Sheets, 1, "Should have 1 sheet")
+		assert.Empty(t, retrieved.Sheets[0].Data, "Grid data should not be included by default")
+	})
+
+	t.Run("IncludeGridData", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!A1:B2", &sheets.ValueRange{
+			Values: [][]interface{}{{"Name", "Score"}, {"Alice", 42}},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err, "Update should succeed")
+
+		// Fetch the range inline with the spreadsheet
+		retrieved, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).
+			IncludeGridData(true).Ranges("Sheet1!A1:B2").Do()
+		require.NoError(t, err, "Get with grid data should not return error")
+		require.Len(t, retrieved.Sheets, 1, "Should have 1 sheet")
+		require.Len(t, retrieved.Sheets[0].Data, 1, "Should have grid data for the range")
+
+		rowData := retrieved.Sheets[0].Data[0].RowData
+		require.Len(t, rowData, 2, "Should have 2 rows")
+		require.Len(t, rowData[1].Values, 2, "Should have 2 columns")
+		assert.Equal(t, "Name", rowData[0].Values[0].FormattedValue, "Header should be embedded")
+		assert.Equal(t, "Alice", *rowData[1].Values[0].UserEnteredValue.StringValue, "String cell should be embedded")
+		assert.InDelta(t, 42.0, *rowData[1].Values[1].EffectiveValue.NumberValue, 0, "Number cell should keep its type")
+		assert.Equal(t, "42", rowData[1].Values[1].FormattedValue, "Formatted value should be a string")
+	})
+
+	t.Run("FieldsMask", func(t *testing.T) {
+		retrieved, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).
+			Fields("spreadsheetId,sheets.properties.title").Do()
+		require.NoError(t, err, "Get with fields should not return error")
+		assert.Equal(t, created.SpreadsheetId, retrieved.SpreadsheetId, "IDs should match")
+		assert.Nil(t, retrieved.Properties, "Unselected properties should be omitted")
+		require.Len(t, retrieved.Sheets, 1, "Should have 1 sheet")
+		assert.Equal(t, "Sheet1", retrieved.Sheets[0].Properties.Title, "Selected title should be returned")
+		assert.Zero(t, retrieved.Sheets[0].Properties.SheetId, "Unselected sheetId should be omitted")
 	})
 
 	t.Run("GetNonExistentSpreadsheet", func(t *testing.T) {
@@ -211,6 +247,48 @@ func TestGsheetsSimulatorUpdateRange(t *testing.T) {
 		assert.Equal(t, 3, int(resp.UpdatedRows), "Should update 3 rows")
 		assert.Equal(t, 9, int(resp.UpdatedCells), "Should update 9 cells")
 	})
+
+	t.Run("OversizedValuesRejected", func(t *testing.T) {
+		valueRange := &sheets.ValueRange{
+			Values: [][]interface{}{
+				{"a", "b"},
+				{"c", "d"},
+			},
+		}
+
+		_, err := sheetsService.Spreadsheets.Values.Update(
+			created.SpreadsheetId,
+			"Sheet1!E1:E1",
+			valueRange,
+		).ValueInputOption("RAW").Do()
+
+		var apiErr *googleapi.Error
+		require.ErrorAs(t, err, &apiErr, "Values larger than a bounded range should be rejected")
+		assert.Equal(t, http.StatusBadRequest, apiErr.Code)
+		assert.Contains(t, apiErr.Message, "Sheet1!E1:E1", "Error should name the range")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!E1:F2").Do()
+		require.NoError(t, err)
+		assert.Empty(t, resp.Values, "A rejected update should write nothing")
+	})
+
+	t.Run("AnchorExpands", func(t *testing.T) {
+		valueRange := &sheets.ValueRange{
+			Values: [][]interface{}{
+				{"a", "b"},
+				{"c", "d"},
+			},
+		}
+
+		resp, err := sheetsService.Spreadsheets.Values.Update(
+			created.SpreadsheetId,
+			"Sheet1!E1",
+			valueRange,
+		).ValueInputOption("RAW").Do()
+
+		require.NoError(t, err, "A single-cell anchor should grow to fit the values")
+		assert.Equal(t, 4, int(resp.UpdatedCells), "Should update 4 cells")
+	})
 }
 
 func TestGsheetsSimulatorReadRange(t *testing.T) {
@@ -266,6 +344,29 @@ func TestGsheetsSimulatorReadRange(t *testing.T) {
 		assert.Equal(t, "Value2", resp.Values[0][0], "First cell should match")
 	})
 
+	t.Run("ReadTypedValues", func(t *testing.T) {
+		// Write a number, a boolean, and a string
+		typed := &sheets.ValueRange{
+			Values: [][]interface{}{
+				{1, true, "x"},
+			},
+		}
+		_, err := sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!E1:G1", typed).ValueInputOption("RAW").Do()
+		require.NoError(t, err, "Update should not return error")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!E1:G1").Do()
+
+		// Assertions
+		require.NoError(t, err, "Get should not return error")
+		require.Len(t, resp.Values, 1, "Should have 1 row")
+		assert.Equal(t, []interface{}{float64(1), true, "x"}, resp.Values[0], "Values should keep their JSON types")
+
+		// Formatted values are rendered as strings
+		formatted, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!E1:G1").ValueRenderOption("FORMATTED_VALUE").Do()
+		require.NoError(t, err, "Get should not return error")
+		assert.Equal(t, []interface{}{"1", "TRUE", "x"}, formatted.Values[0], "Formatted values should be strings")
+	})
+
 	t.Run("ReadEmptyRange", func(t *testing.T) {
 		// Read an empty range
 		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!Z1:Z10").Do()
@@ -342,6 +443,91 @@ func TestGsheetsSimulatorAppendRows(t *testing.T) {
 	})
 }
 
+func TestGsheetsSimulatorAppendInsertDataOption(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-append-option"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	// setupSheet creates a two-row table with a footer row below a blank gap
+	setupSheet := func(t *testing.T) string {
+		t.Helper()
+		created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+			Properties: &sheets.SpreadsheetProperties{Title: "Append Option Test"},
+		}).Do()
+		require.NoError(t, err)
+
+		_, err = sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!A1:B2", &sheets.ValueRange{
+			Values: [][]interface{}{{"Name", "Score"}, {"Alice", "95"}},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err)
+
+		_, err = sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!A4:B4", &sheets.ValueRange{
+			Values: [][]interface{}{{"Total", "95"}},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err)
+
+		return created.SpreadsheetId
+	}
+
+	newRows := &sheets.ValueRange{
+		Values: [][]interface{}{{"Bob", "87"}, {"Carol", "78"}},
+	}
+
+	getRow := func(t *testing.T, spreadsheetID, cellRange string) []interface{} {
+		t.Helper()
+		resp, err := sheetsService.Spreadsheets.Values.Get(spreadsheetID, cellRange).Do()
+		require.NoError(t, err)
+		if len(resp.Values) == 0 {
+			return nil
+		}
+		return resp.Values[0]
+	}
+
+	t.Run("OverwriteWritesOverRowsBelowTable", func(t *testing.T) {
+		spreadsheetID := setupSheet(t)
+
+		resp, err := sheetsService.Spreadsheets.Values.Append(spreadsheetID, "Sheet1!A1", newRows).
+			ValueInputOption("RAW").InsertDataOption("OVERWRITE").Do()
+		require.NoError(t, err, "Append should not return error")
+		assert.Equal(t, "Sheet1!A3:A4", resp.Updates.UpdatedRange, "Rows should be written right after the table")
+
+		assert.Equal(t, []interface{}{"Bob", "87"}, getRow(t, spreadsheetID, "Sheet1!A3:B3"), "First appended row should fill the gap")
+		assert.Equal(t, []interface{}{"Carol", "78"}, getRow(t, spreadsheetID, "Sheet1!A4:B4"), "Footer should be overwritten")
+		assert.Nil(t, getRow(t, spreadsheetID, "Sheet1!A6:B6"), "Nothing should be shifted down")
+	})
+
+	t.Run("InsertRowsShiftsRowsBelowTable", func(t *testing.T) {
+		spreadsheetID := setupSheet(t)
+
+		resp, err := sheetsService.Spreadsheets.Values.Append(spreadsheetID, "Sheet1!A1", newRows).
+			ValueInputOption("RAW").InsertDataOption("INSERT_ROWS").Do()
+		require.NoError(t, err, "Append should not return error")
+		assert.Equal(t, "Sheet1!A3:A4", resp.Updates.UpdatedRange, "Rows should be inserted right after the table")
+
+		assert.Equal(t, []interface{}{"Bob", "87"}, getRow(t, spreadsheetID, "Sheet1!A3:B3"), "First inserted row should follow the table")
+		assert.Equal(t, []interface{}{"Carol", "78"}, getRow(t, spreadsheetID, "Sheet1!A4:B4"), "Second inserted row should follow the first")
+		assert.Equal(t, []interface{}{"Total", "95"}, getRow(t, spreadsheetID, "Sheet1!A6:B6"), "Footer should be shifted down by two rows")
+	})
+
+	t.Run("InvalidOption", func(t *testing.T) {
+		spreadsheetID := setupSheet(t)
+
+		_, err := sheetsService.Spreadsheets.Values.Append(spreadsheetID, "Sheet1!A1", newRows).
+			ValueInputOption("RAW").InsertDataOption("SIDEWAYS").Do()
+		require.Error(t, err, "Unknown insertDataOption should be rejected")
+	})
+}
+
 func TestGsheetsSimulatorBatchUpdate(t *testing.T) {
 	// Setup
 	queries := setupTestDB(t)
@@ -421,6 +607,506 @@ func TestGsheetsSimulatorBatchUpdate(t *testing.T) {
 	})
 }
 
+func TestGsheetsSimulatorGridRangeSheetID(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-grid-range"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{Title: "Grid Ranges"},
+	}).Do()
+	require.NoError(t, err)
+
+	_, err = sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "Data", SheetId: 4242}}},
+		},
+	}).Do()
+	require.NoError(t, err, "Adding a sheet with an explicit ID should succeed")
+
+	t.Run("RepeatCellBySheetID", func(t *testing.T) {
+		value := "filled"
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{RepeatCell: &sheets.RepeatCellRequest{
+					Range: &sheets.GridRange{
+						SheetId:          4242,
+						StartRowIndex:    0,
+						EndRowIndex:      2,
+						StartColumnIndex: 1,
+						EndColumnIndex:   3,
+					},
+					Cell:   &sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{StringValue: &value}},
+					Fields: "userEnteredValue",
+				}},
+			},
+		}).Do()
+		require.NoError(t, err, "RepeatCell should succeed")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Data!B1:C3").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, [][]interface{}{{"filled", "filled"}, {"filled", "filled"}}, resp.Values, "Only the grid range should be filled")
+
+		resp, err = sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!B1:C2").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Empty(t, resp.Values, "Other sheets should be untouched")
+	})
+
+	t.Run("UnknownSheetID", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{RepeatCell: &sheets.RepeatCellRequest{
+					Range:  &sheets.GridRange{SheetId: 99999, EndRowIndex: 1, EndColumnIndex: 1},
+					Cell:   &sheets.CellData{},
+					Fields: "userEnteredValue",
+				}},
+			},
+		}).Do()
+		require.Error(t, err, "RepeatCell on a missing sheet should fail")
+	})
+
+	t.Run("DuplicateSheetIDRejected", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "Copy", SheetId: 4242}}},
+			},
+		}).Do()
+		require.Error(t, err, "Reusing a sheet ID should fail")
+
+		spreadsheet, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).Do()
+		require.NoError(t, err)
+		seen := map[int64]bool{}
+		for _, sheet := range spreadsheet.Sheets {
+			assert.False(t, seen[sheet.Properties.SheetId], "Sheet IDs should be unique")
+			seen[sheet.Properties.SheetId] = true
+		}
+		assert.Len(t, spreadsheet.Sheets, 2, "The duplicate sheet should not be added")
+	})
+}
+
+func TestGsheetsSimulatorMergeCells(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-merges"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{Title: "Merged Header"},
+	}).Do()
+	require.NoError(t, err)
+	sheetID := created.Sheets[0].Properties.SheetId
+
+	_, err = sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!A1:C2", &sheets.ValueRange{
+		Values: [][]interface{}{{"Quarterly Report", "hidden", "Notes"}, {"Q1", "Q2", "Q3"}},
+	}).ValueInputOption("RAW").Do()
+	require.NoError(t, err, "Writing values should succeed")
+
+	merge := func(t *testing.T, gridRange *sheets.GridRange) error {
+		t.Helper()
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{MergeCells: &sheets.MergeCellsRequest{Range: gridRange, MergeType: "MERGE_ALL"}}},
+		}).Do()
+		return err
+	}
+	unmerge := func(t *testing.T, gridRange *sheets.GridRange) error {
+		t.Helper()
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{UnmergeCells: &sheets.UnmergeCellsRequest{Range: gridRange}}},
+		}).Do()
+		return err
+	}
+	header := &sheets.GridRange{SheetId: sheetID, StartRowIndex: 0, EndRowIndex: 1, StartColumnIndex: 0, EndColumnIndex: 2}
+
+	t.Run("MergeBlanksCoveredCells", func(t *testing.T) {
+		require.NoError(t, merge(t, header), "Merging A1:B1 should succeed")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!A1:C2").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, [][]interface{}{{"Quarterly Report", "", "Notes"}, {"Q1", "Q2", "Q3"}}, resp.Values,
+			"Only the top-left cell of the merge should have a value")
+	})
+
+	t.Run("GridDataIncludesMerges", func(t *testing.T) {
+		spreadsheet, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).Ranges("Sheet1!A1:C1").IncludeGridData(true).Do()
+		require.NoError(t, err, "Get should succeed")
+		require.Len(t, spreadsheet.Sheets, 1)
+
+		sheet := spreadsheet.Sheets[0]
+		require.Len(t, sheet.Merges, 1, "The merge should be listed")
+		assert.Equal(t, int64(2), sheet.Merges[0].EndColumnIndex)
+		assert.Equal(t, int64(1), sheet.Merges[0].EndRowIndex)
+
+		values := sheet.Data[0].RowData[0].Values
+		require.Len(t, values, 3)
+		assert.Equal(t, "Quarterly Report", values[0].FormattedValue)
+		assert.Nil(t, values[1].UserEnteredValue, "Cells covered by the merge should be empty")
+		assert.Equal(t, "Notes", values[2].FormattedValue)
+	})
+
+	t.Run("PartialOverlapRejected", func(t *testing.T) {
+		err := merge(t, &sheets.GridRange{SheetId: sheetID, StartRowIndex: 0, EndRowIndex: 2, StartColumnIndex: 1, EndColumnIndex: 3})
+		require.Error(t, err, "Merging across part of an existing merge should fail")
+
+		err = unmerge(t, &sheets.GridRange{SheetId: sheetID, StartRowIndex: 0, EndRowIndex: 1, StartColumnIndex: 1, EndColumnIndex: 2})
+		require.Error(t, err, "Unmerging part of a merge should fail")
+	})
+
+	t.Run("UnmergeRestoresValues", func(t *testing.T) {
+		require.NoError(t, unmerge(t, &sheets.GridRange{SheetId: sheetID, EndRowIndex: 5, EndColumnIndex: 5}), "Unmerge should succeed")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!A1:C1").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, [][]interface{}{{"Quarterly Report", "hidden", "Notes"}}, resp.Values, "Covered values should reappear")
+
+		spreadsheet, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).IncludeGridData(true).Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Empty(t, spreadsheet.Sheets[0].Merges, "No merges should remain")
+	})
+
+	t.Run("MergeColumns", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{MergeCells: &sheets.MergeCellsRequest{
+				Range:     &sheets.GridRange{SheetId: sheetID, StartRowIndex: 0, EndRowIndex: 2, StartColumnIndex: 0, EndColumnIndex: 2},
+				MergeType: "MERGE_COLUMNS",
+			}}},
+		}).Do()
+		require.NoError(t, err, "Merging columns should succeed")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!A1:C2").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, [][]interface{}{{"Quarterly Report", "hidden", "Notes"}, {"", "", "Q3"}}, resp.Values,
+			"Each column should be merged separately")
+	})
+
+	t.Run("UnknownSheetID", func(t *testing.T) {
+		err := merge(t, &sheets.GridRange{SheetId: 99999, EndRowIndex: 1, EndColumnIndex: 2})
+		require.Error(t, err, "Merging on a missing sheet should fail")
+	})
+}
+
+func TestGsheetsSimulatorFindReplace(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-find-replace"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	// newSpreadsheet creates a spreadsheet with fruit names on Sheet1 and a Data sheet with ID 4242
+	newSpreadsheet := func(t *testing.T) string {
+		t.Helper()
+		created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+			Properties: &sheets.SpreadsheetProperties{Title: "Find Replace"},
+		}).Do()
+		require.NoError(t, err)
+		_, err = sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "Data", SheetId: 4242}}},
+			},
+		}).Do()
+		require.NoError(t, err)
+
+		_, err = sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!A1:C2", &sheets.ValueRange{
+			Values: [][]interface{}{
+				{"apple pie", "Apple", "banana"},
+				{"pineapple apple", 42, true},
+			},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err)
+		_, err = sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Data!A1", &sheets.ValueRange{
+			Values: [][]interface{}{{"apple"}},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err)
+		return created.SpreadsheetId
+	}
+
+	findReplace := func(t *testing.T, spreadsheetID string, request *sheets.FindReplaceRequest) *sheets.FindReplaceResponse {
+		t.Helper()
+		resp, err := sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{FindReplace: request}},
+		}).Do()
+		require.NoError(t, err, "findReplace should succeed")
+		require.Len(t, resp.Replies, 1, "There should be one reply")
+		require.NotNil(t, resp.Replies[0].FindReplace, "Reply should hold the findReplace result")
+		return resp.Replies[0].FindReplace
+	}
+
+	values := func(t *testing.T, spreadsheetID, readRange string) [][]interface{} {
+		t.Helper()
+		resp, err := sheetsService.Spreadsheets.Values.Get(spreadsheetID, readRange).Do()
+		require.NoError(t, err, "Get should succeed")
+		return resp.Values
+	}
+
+	t.Run("AllSheetsIgnoringCase", func(t *testing.T) {
+		spreadsheetID := newSpreadsheet(t)
+		result := findReplace(t, spreadsheetID, &sheets.FindReplaceRequest{Find: "apple", Replacement: "pear", AllSheets: true})
+
+		assert.Equal(t, int64(4), result.ValuesChanged, "Four cells contain apple")
+		assert.Equal(t, int64(5), result.OccurrencesChanged, "One cell contains apple twice")
+		assert.Equal(t, int64(3), result.RowsChanged, "Two Sheet1 rows and one Data row changed")
+		assert.Equal(t, int64(2), result.SheetsChanged, "Both sheets changed")
+		assert.Equal(t, [][]interface{}{
+			{"pear pie", "pear", "banana"},
+			{"pinepear pear", float64(42), true},
+		}, values(t, spreadsheetID, "Sheet1!A1:C2"), "Matches should be replaced in place")
+		assert.Equal(t, [][]interface{}{{"pear"}}, values(t, spreadsheetID, "Data!A1"), "Other sheets should be searched")
+	})
+
+	t.Run("MatchCaseAndEntireCell", func(t *testing.T) {
+		spreadsheetID := newSpreadsheet(t)
+		result := findReplace(t, spreadsheetID, &sheets.FindReplaceRequest{
+			Find: "Apple", Replacement: "Pear", MatchCase: true, MatchEntireCell: true, AllSheets: true,
+		})
+
+		assert.Equal(t, int64(1), result.ValuesChanged, "Only the cell that is exactly Apple should change")
+		assert.Equal(t, int64(1), result.OccurrencesChanged)
+		assert.Equal(t, [][]interface{}{
+			{"apple pie", "Pear", "banana"},
+			{"pineapple apple", float64(42), true},
+		}, values(t, spreadsheetID, "Sheet1!A1:C2"), "Lowercase and partial matches should be untouched")
+	})
+
+	t.Run("SheetScope", func(t *testing.T) {
+		spreadsheetID := newSpreadsheet(t)
+		result := findReplace(t, spreadsheetID, &sheets.FindReplaceRequest{Find: "apple", Replacement: "plum", SheetId: 4242})
+
+		assert.Equal(t, int64(1), result.ValuesChanged, "Only the Data sheet should be searched")
+		assert.Equal(t, [][]interface{}{{"plum"}}, values(t, spreadsheetID, "Data!A1"))
+		assert.Equal(t, "apple pie", values(t, spreadsheetID, "Sheet1!A1")[0][0], "Sheet1 should be untouched")
+	})
+
+	t.Run("RangeScopeAndNumbers", func(t *testing.T) {
+		spreadsheetID := newSpreadsheet(t)
+		spreadsheet, err := sheetsService.Spreadsheets.Get(spreadsheetID).Do()
+		require.NoError(t, err)
+		result := findReplace(t, spreadsheetID, &sheets.FindReplaceRequest{
+			Find:        "4",
+			Replacement: "7",
+			Range: &sheets.GridRange{
+				SheetId:       spreadsheet.Sheets[0].Properties.SheetId,
+				StartRowIndex: 1, EndRowIndex: 2, EndColumnIndex: 2,
+			},
+		})
+		assert.Equal(t, int64(1), result.ValuesChanged, "The number cell in range should change")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(spreadsheetID, "Sheet1!B2").ValueRenderOption("UNFORMATTED_VALUE").Do()
+		require.NoError(t, err)
+		assert.Equal(t, [][]interface{}{{float64(72)}}, resp.Values, "Replaced numbers should stay numbers")
+	})
+
+	t.Run("RegexReplacement", func(t *testing.T) {
+		spreadsheetID := newSpreadsheet(t)
+		result := findReplace(t, spreadsheetID, &sheets.FindReplaceRequest{
+			Find: `^(\w+) pie$`, Replacement: "$1 tart", SearchByRegex: true, AllSheets: true,
+		})
+		assert.Equal(t, int64(1), result.ValuesChanged)
+		assert.Equal(t, "apple tart", values(t, spreadsheetID, "Sheet1!A1")[0][0], "Capture groups should be substituted")
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		spreadsheetID := newSpreadsheet(t)
+		for _, request := range []*sheets.FindReplaceRequest{
+			{Replacement: "x", AllSheets: true},
+			{Find: "apple", Replacement: "x"},
+			{Find: "apple", Replacement: "x", AllSheets: true, SheetId: 4242},
+			{Find: "(", Replacement: "x", SearchByRegex: true, AllSheets: true},
+			{Find: "apple", Replacement: "x", SheetId: 99999},
+		} {
+			_, err := sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+				Requests: []*sheets.Request{{FindReplace: request}},
+			}).Do()
+			require.Error(t, err, "findReplace %+v should be rejected", request)
+		}
+	})
+}
+
+func TestGsheetsSimulatorRename(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-rename"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{Title: "Before"},
+	}).Do()
+	require.NoError(t, err)
+	sheetID := created.Sheets[0].Properties.SheetId
+
+	_, err = sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "Sheet1!A1:B1", &sheets.ValueRange{
+		Values: [][]interface{}{{"kept", "data"}},
+	}).ValueInputOption("RAW").Do()
+	require.NoError(t, err)
+
+	t.Run("RenameSpreadsheet", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{UpdateSpreadsheetProperties: &sheets.UpdateSpreadsheetPropertiesRequest{
+					Properties: &sheets.SpreadsheetProperties{Title: "After"},
+					Fields:     "title",
+				}},
+			},
+		}).Do()
+		require.NoError(t, err, "UpdateSpreadsheetProperties should succeed")
+
+		spreadsheet, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).Do()
+		require.NoError(t, err)
+		assert.Equal(t, "After", spreadsheet.Properties.Title, "Spreadsheet should be renamed")
+	})
+
+	t.Run("RenameSheetThenReadByNewName", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+					Properties: &sheets.SheetProperties{SheetId: sheetID, Title: "Renamed Data"},
+					Fields:     "title",
+				}},
+			},
+		}).Do()
+		require.NoError(t, err, "UpdateSheetProperties should succeed")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "'Renamed Data'!A1:B1").Do()
+		require.NoError(t, err, "Reading by the new name should succeed")
+		assert.Equal(t, [][]interface{}{{"kept", "data"}}, resp.Values, "Cells should follow the sheet")
+
+		spreadsheet, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).Do()
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed Data", spreadsheet.Sheets[0].Properties.Title, "Sheet should be renamed")
+		assert.Equal(t, sheetID, spreadsheet.Sheets[0].Properties.SheetId, "Sheet ID should be unchanged")
+	})
+
+	t.Run("DuplicateSheetNameRejected", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "Other", SheetId: 77}}},
+			},
+		}).Do()
+		require.NoError(t, err)
+
+		_, err = sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+					Properties: &sheets.SheetProperties{SheetId: 77, Title: "Renamed Data"},
+					Fields:     "title",
+				}},
+			},
+		}).Do()
+		require.Error(t, err, "Renaming onto an existing sheet name should fail")
+	})
+
+	t.Run("UnknownSheetID", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+					Properties: &sheets.SheetProperties{SheetId: 99999, Title: "Nope"},
+					Fields:     "title",
+				}},
+			},
+		}).Do()
+		require.Error(t, err, "Renaming a missing sheet should fail")
+	})
+}
+
+func TestGsheetsSimulatorQuotedSheetNames(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-quoted"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{Title: "Quoted Names"},
+	}).Do()
+	require.NoError(t, err)
+
+	_, err = sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "My Sheet"}}},
+			{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: "Bob's Data"}}},
+		},
+	}).Do()
+	require.NoError(t, err, "Adding sheets should succeed")
+
+	t.Run("WriteAndReadSheetWithSpace", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.Values.Update(created.SpreadsheetId, "'My Sheet'!A1", &sheets.ValueRange{
+			Values: [][]interface{}{{"hello"}},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err, "Update should succeed")
+
+		resp, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "'My Sheet'!A1:A1").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, [][]interface{}{{"hello"}}, resp.Values, "Value should be read from My Sheet")
+
+		resp, err = sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "Sheet1!A1:A1").Do()
+		require.NoError(t, err, "Get should succeed")
+		assert.Empty(t, resp.Values, "Other sheets should not see the value")
+	})
+
+	t.Run("EscapedQuote", func(t *testing.T) {
+		resp, err := sheetsService.Spreadsheets.Values.Append(created.SpreadsheetId, "'Bob''s Data'!A1", &sheets.ValueRange{
+			Values: [][]interface{}{{"a", "b"}},
+		}).ValueInputOption("RAW").Do()
+		require.NoError(t, err, "Append should succeed")
+		assert.True(t, strings.HasPrefix(resp.Updates.UpdatedRange, "'Bob''s Data'!A1:"), "Updated range should quote the title: %s", resp.Updates.UpdatedRange)
+
+		spreadsheet, err := sheetsService.Spreadsheets.Get(created.SpreadsheetId).Ranges("'Bob''s Data'").IncludeGridData(true).Do()
+		require.NoError(t, err, "Get with ranges should succeed")
+		require.Len(t, spreadsheet.Sheets, 1, "Only the requested sheet should be returned")
+		assert.Equal(t, "Bob's Data", spreadsheet.Sheets[0].Properties.Title, "Title should be unescaped")
+		require.NotEmpty(t, spreadsheet.Sheets[0].Data, "Grid data should be included")
+		require.NotEmpty(t, spreadsheet.Sheets[0].Data[0].RowData, "Row data should be included")
+		assert.Equal(t, "a", *spreadsheet.Sheets[0].Data[0].RowData[0].Values[0].UserEnteredValue.StringValue, "Appended value should be in the sheet")
+	})
+
+	t.Run("UnterminatedQuote", func(t *testing.T) {
+		_, err := sheetsService.Spreadsheets.Values.Get(created.SpreadsheetId, "'My Sheet!A1").Do()
+		require.Error(t, err, "Unterminated sheet name should be rejected")
+	})
+}
+
 func TestGsheetsSimulatorEndToEnd(t *testing.T) {
 	// Setup
 	queries := setupTestDB(t)
@@ -547,3 +1233,85 @@ func TestGsheetsSimulatorSessionIsolation(t *testing.T) {
 		require.Error(t, err, "Session 2 should not access Session 1's spreadsheet")
 	})
 }
+
+func TestGsheetsSimulatorDeveloperMetadata(t *testing.T) {
+	// Setup
+	queries := setupTestDB(t)
+	sessionID := "gsheets-test-session-metadata"
+	handler := session.Middleware(simulatorGsheets.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	transport := &sessionHTTPTransport{sessionID: sessionID}
+	customClient := &http.Client{Transport: transport}
+
+	ctx := context.Background()
+	sheetsService, err := sheets.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/"), option.WithHTTPClient(customClient))
+	require.NoError(t, err)
+
+	// Create a spreadsheet
+	created, err := sheetsService.Spreadsheets.Create(&sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{Title: "Metadata Test"},
+	}).Do()
+	require.NoError(t, err)
+
+	t.Run("CreateAndSearch", func(t *testing.T) {
+		resp, err := sheetsService.Spreadsheets.BatchUpdate(created.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{
+					CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+						DeveloperMetadata: &sheets.DeveloperMetadata{
+							MetadataKey:   "source",
+							MetadataValue: "crm-export",
+							Visibility:    "DOCUMENT",
+							Location: &sheets.DeveloperMetadataLocation{
+								DimensionRange: &sheets.DimensionRange{
+									Dimension:  "ROWS",
+									StartIndex: 0,
+									EndIndex:   1,
+								},
+							},
+						},
+					},
+				},
+				{
+					CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{
+						DeveloperMetadata: &sheets.DeveloperMetadata{
+							MetadataKey: "owner",
+							Location:    &sheets.DeveloperMetadataLocation{Spreadsheet: true},
+						},
+					},
+				},
+			},
+		}).Do()
+		require.NoError(t, err, "BatchUpdate should not return error")
+		require.Len(t, resp.Replies, 2, "Should have 2 replies")
+		require.NotNil(t, resp.Replies[0].CreateDeveloperMetadata, "Reply should describe created metadata")
+		createdMetadata := resp.Replies[0].CreateDeveloperMetadata.DeveloperMetadata
+		assert.NotZero(t, createdMetadata.MetadataId, "Metadata ID should be assigned")
+		assert.Equal(t, "ROW", createdMetadata.Location.LocationType, "Location type should be inferred")
+
+		search, err := sheetsService.Spreadsheets.DeveloperMetadata.Search(created.SpreadsheetId, &sheets.SearchDeveloperMetadataRequest{
+			DataFilters: []*sheets.DataFilter{
+				{DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{MetadataKey: "source"}},
+			},
+		}).Do()
+		require.NoError(t, err, "Search should not return error")
+		require.Len(t, search.MatchedDeveloperMetadata, 1, "Only the keyed entry should match")
+		found := search.MatchedDeveloperMetadata[0].DeveloperMetadata
+		assert.Equal(t, createdMetadata.MetadataId, found.MetadataId, "Metadata ID should round-trip")
+		assert.Equal(t, "crm-export", found.MetadataValue, "Value should round-trip")
+		assert.Equal(t, "DOCUMENT", found.Visibility, "Visibility should round-trip")
+		assert.Equal(t, "ROWS", found.Location.DimensionRange.Dimension, "Dimension range should round-trip")
+	})
+
+	t.Run("SearchUnknownKey", func(t *testing.T) {
+		search, err := sheetsService.Spreadsheets.DeveloperMetadata.Search(created.SpreadsheetId, &sheets.SearchDeveloperMetadataRequest{
+			DataFilters: []*sheets.DataFilter{
+				{DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{MetadataKey: "missing"}},
+			},
+		}).Do()
+		require.NoError(t, err, "Search should not return error")
+		assert.Empty(t, search.MatchedDeveloperMetadata, "No metadata should match an unknown key")
+	})
+}
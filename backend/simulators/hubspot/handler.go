@@ -2,7 +2,6 @@ package hubspot
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
@@ -13,6 +12,7 @@ import (
 	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -57,6 +57,19 @@ type ResponseResource struct {
 	CreatedAt  string      `json:"createdAt"`
 	UpdatedAt  string      `json:"updatedAt"`
 	Archived   bool        `json:"archived"`
+	// Associations is only populated when the associations query parameter is set
+	Associations map[string]AssociationResults `json:"associations,omitempty"`
+}
+
+// AssociationResults lists the objects of one type associated with a resource
+type AssociationResults struct {
+	Results []AssociationResult `json:"results"`
+}
+
+// AssociationResult identifies a single associated object
+type AssociationResult struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
 }
 
 // SearchResponse is the search result wrapper
@@ -100,6 +113,20 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountHubspotSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"contacts":     counts.Contacts,
+		"companies":    counts.Companies,
+		"deals":        counts.Deals,
+		"associations": counts.Associations,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[hubspot] → %s %s", r.Method, r.URL.Path)
@@ -112,6 +139,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleAssociationV3(w, r)
 	case strings.HasPrefix(r.URL.Path, "/crm/v4/associations/"):
 		h.handleAssociations(w, r)
+	case strings.HasPrefix(r.URL.Path, "/crm/v3/properties/"):
+		h.handleProperties(w, r)
 	case strings.HasPrefix(r.URL.Path, "/crm/v3/objects/contacts"):
 		h.handleContacts(w, r)
 	case strings.HasPrefix(r.URL.Path, "/crm/v3/objects/deals"):
@@ -237,9 +266,9 @@ func (h *Handler) handleCreateContact(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	contactID := generateID()
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().UnixMilli()
+	contactID := generateID(sessionID)
+	now := session.Now(sessionID).UnixMilli()
 
 	// Store in database
 	dbContact, err := h.queries.CreateHubspotContact(context.Background(), database.CreateHubspotContactParams{
@@ -259,9 +288,16 @@ func (h *Handler) handleCreateContact(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	custom := customProperties("contacts", propsJSON)
+	if err := h.saveCustomProperties(sessionID, "contacts", contactID, custom); err != nil {
+		log.Printf("[hubspot] ✗ Failed to save contact properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	response := ResponseResource{
 		ID:         dbContact.ID,
-		Properties: buildContact(dbContact.Email, dbContact.FirstName, dbContact.LastName, dbContact.MobilePhone, dbContact.Website),
+		Properties: withCustomProperties(buildContact(dbContact.Email, dbContact.FirstName, dbContact.LastName, dbContact.MobilePhone, dbContact.Website), custom),
 		CreatedAt:  formatTimestamp(dbContact.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbContact.UpdatedAt),
 		Archived:   false,
@@ -275,26 +311,42 @@ func (h *Handler) handleCreateContact(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetContact(w http.ResponseWriter, r *http.Request, contactID string) {
 	log.Printf("[hubspot] → Getting contact: %s", contactID)
 
-	sessionID := session.FromContext(r.Context())
-
-	dbContact, err := h.queries.GetHubspotContactByID(context.Background(), database.GetHubspotContactByIDParams{
-		ID:        contactID,
-		SessionID: sessionID,
+	// Fall through to the parent session. Properties and associations live in whichever
+	// session holds the object.
+	var sessionID string
+	dbContact, err := session.ReadThrough(r.Context(), "hubspot_contacts", contactID, func(candidate string) (database.GetHubspotContactByIDRow, error) {
+		sessionID = candidate
+		return h.queries.GetHubspotContactByID(context.Background(), database.GetHubspotContactByIDParams{
+			ID:        contactID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[hubspot] ✗ Failed to get contact: %v", err)
 		http.NotFound(w, r)
 		return
 	}
+	custom, err := h.loadCustomProperties(sessionID, "contacts", contactID)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load contact properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	response := ResponseResource{
 		ID:         dbContact.ID,
-		Properties: buildContact(dbContact.Email, dbContact.FirstName, dbContact.LastName, dbContact.MobilePhone, dbContact.Website),
+		Properties: withCustomProperties(buildContact(dbContact.Email, dbContact.FirstName, dbContact.LastName, dbContact.MobilePhone, dbContact.Website), custom),
 		CreatedAt:  formatTimestamp(dbContact.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbContact.UpdatedAt),
 		Archived:   false,
 	}
 
+	if err := h.expandResource(r, "contacts", sessionID, &response); err != nil {
+		log.Printf("[hubspot] ✗ Failed to expand contact: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[hubspot] ✓ Contact retrieved: %s", contactID)
@@ -320,7 +372,7 @@ func (h *Handler) handleUpdateContact(w http.ResponseWriter, r *http.Request, co
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().UnixMilli()
+	now := session.Now(sessionID).UnixMilli()
 
 	// Update in database
 	err := h.queries.UpdateHubspotContact(context.Background(), database.UpdateHubspotContactParams{
@@ -337,6 +389,11 @@ func (h *Handler) handleUpdateContact(w http.ResponseWriter, r *http.Request, co
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if err := h.saveCustomProperties(sessionID, "contacts", contactID, customProperties("contacts", propsJSON)); err != nil {
+		log.Printf("[hubspot] ✗ Failed to save contact properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	// Get updated contact
 	dbContact, err := h.queries.GetHubspotContactByID(context.Background(), database.GetHubspotContactByIDParams{
@@ -348,10 +405,16 @@ func (h *Handler) handleUpdateContact(w http.ResponseWriter, r *http.Request, co
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	custom, err := h.loadCustomProperties(sessionID, "contacts", contactID)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load contact properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	response := ResponseResource{
 		ID:         dbContact.ID,
-		Properties: buildContact(dbContact.Email, dbContact.FirstName, dbContact.LastName, dbContact.MobilePhone, dbContact.Website),
+		Properties: withCustomProperties(buildContact(dbContact.Email, dbContact.FirstName, dbContact.LastName, dbContact.MobilePhone, dbContact.Website), custom),
 		CreatedAt:  formatTimestamp(dbContact.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbContact.UpdatedAt),
 		Archived:   false,
@@ -404,19 +467,28 @@ func (h *Handler) handleSearchContacts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	custom, err := h.listCustomProperties(sessionID, "contacts")
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load contact properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	results := make([]ResponseResource, 0, len(dbContacts))
 	for i := range dbContacts {
 		results = append(results, ResponseResource{
 			ID:         dbContacts[i].ID,
-			Properties: buildContact(dbContacts[i].Email, dbContacts[i].FirstName, dbContacts[i].LastName, dbContacts[i].MobilePhone, dbContacts[i].Website),
+			Properties: withCustomProperties(buildContact(dbContacts[i].Email, dbContacts[i].FirstName, dbContacts[i].LastName, dbContacts[i].MobilePhone, dbContacts[i].Website), custom[dbContacts[i].ID]),
 			CreatedAt:  formatTimestamp(dbContacts[i].CreatedAt),
 			UpdatedAt:  formatTimestamp(dbContacts[i].UpdatedAt),
 			Archived:   false,
 		})
 	}
+	total := len(results)
+	results = listcap.Truncate(w, results)
 
 	response := SearchResponse{
-		Total:   len(results),
+		Total:   total,
 		Results: results,
 	}
 
@@ -430,26 +502,47 @@ func (h *Handler) handleListContacts(w http.ResponseWriter, r *http.Request) {
 
 	sessionID := session.FromContext(r.Context())
 
-	dbContacts, err := h.queries.ListHubspotContacts(context.Background(), sessionID)
+	dbContacts, err := session.ListThrough(r.Context(), "hubspot_contacts", func(candidate string) ([]database.ListHubspotContactsRow, error) {
+		return h.queries.ListHubspotContacts(context.Background(), candidate)
+	}, nil, func(item database.ListHubspotContactsRow) string { return item.ID })
 	if err != nil {
 		log.Printf("[hubspot] ✗ Failed to list contacts: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
+	custom, err := h.listCustomProperties(sessionID, "contacts")
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load contact properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	results := make([]ResponseResource, 0, len(dbContacts))
 	for i := range dbContacts {
 		results = append(results, ResponseResource{
 			ID:         dbContacts[i].ID,
-			Properties: buildContact(dbContacts[i].Email, dbContacts[i].FirstName, dbContacts[i].LastName, dbContacts[i].MobilePhone, dbContacts[i].Website),
+			Properties: withCustomProperties(buildContact(dbContacts[i].Email, dbContacts[i].FirstName, dbContacts[i].LastName, dbContacts[i].MobilePhone, dbContacts[i].Website), custom[dbContacts[i].ID]),
 			CreatedAt:  formatTimestamp(dbContacts[i].CreatedAt),
 			UpdatedAt:  formatTimestamp(dbContacts[i].UpdatedAt),
 			Archived:   false,
 		})
 	}
 
+	// Total still counts the whole list when the cap cuts the results short
+	total := len(results)
+	results = listcap.Truncate(w, results)
+
+	for i := range results {
+		if err := h.expandResource(r, "contacts", sessionID, &results[i]); err != nil {
+			log.Printf("[hubspot] ✗ Failed to expand contact: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	response := SearchResponse{
-		Total:   len(results),
+		Total:   total,
 		Results: results,
 	}
 
@@ -479,9 +572,9 @@ func (h *Handler) handleCreateDeal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dealID := generateID()
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().UnixMilli()
+	dealID := generateID(sessionID)
+	now := session.Now(sessionID).UnixMilli()
 
 	// Store in database
 	dbDeal, err := h.queries.CreateHubspotDeal(context.Background(), database.CreateHubspotDealParams{
@@ -500,9 +593,16 @@ func (h *Handler) handleCreateDeal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	custom := customProperties("deals", propsJSON)
+	if err := h.saveCustomProperties(sessionID, "deals", dealID, custom); err != nil {
+		log.Printf("[hubspot] ✗ Failed to save deal properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	response := ResponseResource{
 		ID:         dbDeal.ID,
-		Properties: buildDeal(dbDeal.DealName, dbDeal.DealStage, dbDeal.Pipeline, dbDeal.Amount),
+		Properties: withCustomProperties(buildDeal(dbDeal.DealName, dbDeal.DealStage, dbDeal.Pipeline, dbDeal.Amount), custom),
 		CreatedAt:  formatTimestamp(dbDeal.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbDeal.UpdatedAt),
 		Archived:   false,
@@ -516,26 +616,42 @@ func (h *Handler) handleCreateDeal(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetDeal(w http.ResponseWriter, r *http.Request, dealID string) {
 	log.Printf("[hubspot] → Getting deal: %s", dealID)
 
-	sessionID := session.FromContext(r.Context())
-
-	dbDeal, err := h.queries.GetHubspotDealByID(context.Background(), database.GetHubspotDealByIDParams{
-		ID:        dealID,
-		SessionID: sessionID,
+	// Fall through to the parent session. Properties and associations live in whichever
+	// session holds the object.
+	var sessionID string
+	dbDeal, err := session.ReadThrough(r.Context(), "hubspot_deals", dealID, func(candidate string) (database.GetHubspotDealByIDRow, error) {
+		sessionID = candidate
+		return h.queries.GetHubspotDealByID(context.Background(), database.GetHubspotDealByIDParams{
+			ID:        dealID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[hubspot] ✗ Failed to get deal: %v", err)
 		http.NotFound(w, r)
 		return
 	}
+	custom, err := h.loadCustomProperties(sessionID, "deals", dealID)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load deal properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	response := ResponseResource{
 		ID:         dbDeal.ID,
-		Properties: buildDeal(dbDeal.DealName, dbDeal.DealStage, dbDeal.Pipeline, dbDeal.Amount),
+		Properties: withCustomProperties(buildDeal(dbDeal.DealName, dbDeal.DealStage, dbDeal.Pipeline, dbDeal.Amount), custom),
 		CreatedAt:  formatTimestamp(dbDeal.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbDeal.UpdatedAt),
 		Archived:   false,
 	}
 
+	if err := h.expandResource(r, "deals", sessionID, &response); err != nil {
+		log.Printf("[hubspot] ✗ Failed to expand deal: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[hubspot] ✓ Deal retrieved: %s", dealID)
@@ -561,7 +677,7 @@ func (h *Handler) handleUpdateDeal(w http.ResponseWriter, r *http.Request, dealI
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().UnixMilli()
+	now := session.Now(sessionID).UnixMilli()
 
 	// Update in database
 	err := h.queries.UpdateHubspotDeal(context.Background(), database.UpdateHubspotDealParams{
@@ -577,6 +693,11 @@ func (h *Handler) handleUpdateDeal(w http.ResponseWriter, r *http.Request, dealI
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if err := h.saveCustomProperties(sessionID, "deals", dealID, customProperties("deals", propsJSON)); err != nil {
+		log.Printf("[hubspot] ✗ Failed to save deal properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	// Get updated deal
 	dbDeal, err := h.queries.GetHubspotDealByID(context.Background(), database.GetHubspotDealByIDParams{
@@ -588,10 +709,16 @@ func (h *Handler) handleUpdateDeal(w http.ResponseWriter, r *http.Request, dealI
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	custom, err := h.loadCustomProperties(sessionID, "deals", dealID)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load deal properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	response := ResponseResource{
 		ID:         dbDeal.ID,
-		Properties: buildDeal(dbDeal.DealName, dbDeal.DealStage, dbDeal.Pipeline, dbDeal.Amount),
+		Properties: withCustomProperties(buildDeal(dbDeal.DealName, dbDeal.DealStage, dbDeal.Pipeline, dbDeal.Amount), custom),
 		CreatedAt:  formatTimestamp(dbDeal.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbDeal.UpdatedAt),
 		Archived:   false,
@@ -607,26 +734,46 @@ func (h *Handler) handleListDeals(w http.ResponseWriter, r *http.Request) {
 
 	sessionID := session.FromContext(r.Context())
 
-	dbDeals, err := h.queries.ListHubspotDeals(context.Background(), sessionID)
+	dbDeals, err := session.ListThrough(r.Context(), "hubspot_deals", func(candidate string) ([]database.ListHubspotDealsRow, error) {
+		return h.queries.ListHubspotDeals(context.Background(), candidate)
+	}, nil, func(item database.ListHubspotDealsRow) string { return item.ID })
 	if err != nil {
 		log.Printf("[hubspot] ✗ Failed to list deals: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
+	custom, err := h.listCustomProperties(sessionID, "deals")
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load deal properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	results := make([]ResponseResource, 0, len(dbDeals))
 	for i := range dbDeals {
 		results = append(results, ResponseResource{
 			ID:         dbDeals[i].ID,
-			Properties: buildDeal(dbDeals[i].DealName, dbDeals[i].DealStage, dbDeals[i].Pipeline, dbDeals[i].Amount),
+			Properties: withCustomProperties(buildDeal(dbDeals[i].DealName, dbDeals[i].DealStage, dbDeals[i].Pipeline, dbDeals[i].Amount), custom[dbDeals[i].ID]),
 			CreatedAt:  formatTimestamp(dbDeals[i].CreatedAt),
 			UpdatedAt:  formatTimestamp(dbDeals[i].UpdatedAt),
 			Archived:   false,
 		})
 	}
 
+	total := len(results)
+	results = listcap.Truncate(w, results)
+
+	for i := range results {
+		if err := h.expandResource(r, "deals", sessionID, &results[i]); err != nil {
+			log.Printf("[hubspot] ✗ Failed to expand deal: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	response := SearchResponse{
-		Total:   len(results),
+		Total:   total,
 		Results: results,
 	}
 
@@ -656,9 +803,9 @@ func (h *Handler) handleCreateCompany(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	companyID := generateID()
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().UnixMilli()
+	companyID := generateID(sessionID)
+	now := session.Now(sessionID).UnixMilli()
 
 	// Store in database
 	dbCompany, err := h.queries.CreateHubspotCompany(context.Background(), database.CreateHubspotCompanyParams{
@@ -677,9 +824,16 @@ func (h *Handler) handleCreateCompany(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	custom := customProperties("companies", propsJSON)
+	if err := h.saveCustomProperties(sessionID, "companies", companyID, custom); err != nil {
+		log.Printf("[hubspot] ✗ Failed to save company properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	response := ResponseResource{
 		ID:         dbCompany.ID,
-		Properties: buildCompany(dbCompany.Name, dbCompany.Domain, dbCompany.City, dbCompany.Industry),
+		Properties: withCustomProperties(buildCompany(dbCompany.Name, dbCompany.Domain, dbCompany.City, dbCompany.Industry), custom),
 		CreatedAt:  formatTimestamp(dbCompany.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbCompany.UpdatedAt),
 		Archived:   false,
@@ -693,26 +847,42 @@ func (h *Handler) handleCreateCompany(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetCompany(w http.ResponseWriter, r *http.Request, companyID string) {
 	log.Printf("[hubspot] → Getting company: %s", companyID)
 
-	sessionID := session.FromContext(r.Context())
-
-	dbCompany, err := h.queries.GetHubspotCompanyByID(context.Background(), database.GetHubspotCompanyByIDParams{
-		ID:        companyID,
-		SessionID: sessionID,
+	// Fall through to the parent session. Properties and associations live in whichever
+	// session holds the object.
+	var sessionID string
+	dbCompany, err := session.ReadThrough(r.Context(), "hubspot_companies", companyID, func(candidate string) (database.GetHubspotCompanyByIDRow, error) {
+		sessionID = candidate
+		return h.queries.GetHubspotCompanyByID(context.Background(), database.GetHubspotCompanyByIDParams{
+			ID:        companyID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[hubspot] ✗ Failed to get company: %v", err)
 		http.NotFound(w, r)
 		return
 	}
+	custom, err := h.loadCustomProperties(sessionID, "companies", companyID)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load company properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	response := ResponseResource{
 		ID:         dbCompany.ID,
-		Properties: buildCompany(dbCompany.Name, dbCompany.Domain, dbCompany.City, dbCompany.Industry),
+		Properties: withCustomProperties(buildCompany(dbCompany.Name, dbCompany.Domain, dbCompany.City, dbCompany.Industry), custom),
 		CreatedAt:  formatTimestamp(dbCompany.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbCompany.UpdatedAt),
 		Archived:   false,
 	}
 
+	if err := h.expandResource(r, "companies", sessionID, &response); err != nil {
+		log.Printf("[hubspot] ✗ Failed to expand company: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[hubspot] ✓ Company retrieved: %s", companyID)
@@ -738,7 +908,7 @@ func (h *Handler) handleUpdateCompany(w http.ResponseWriter, r *http.Request, co
 	}
 
 	sessionID := session.FromContext(r.Context())
-	now := time.Now().UnixMilli()
+	now := session.Now(sessionID).UnixMilli()
 
 	// Update in database
 	err := h.queries.UpdateHubspotCompany(context.Background(), database.UpdateHubspotCompanyParams{
@@ -755,6 +925,11 @@ func (h *Handler) handleUpdateCompany(w http.ResponseWriter, r *http.Request, co
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	if err := h.saveCustomProperties(sessionID, "companies", companyID, customProperties("companies", propsJSON)); err != nil {
+		log.Printf("[hubspot] ✗ Failed to save company properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	// Get updated company
 	dbCompany, err := h.queries.GetHubspotCompanyByID(context.Background(), database.GetHubspotCompanyByIDParams{
@@ -766,10 +941,16 @@ func (h *Handler) handleUpdateCompany(w http.ResponseWriter, r *http.Request, co
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	custom, err := h.loadCustomProperties(sessionID, "companies", companyID)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load company properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	response := ResponseResource{
 		ID:         dbCompany.ID,
-		Properties: buildCompany(dbCompany.Name, dbCompany.Domain, dbCompany.City, dbCompany.Industry),
+		Properties: withCustomProperties(buildCompany(dbCompany.Name, dbCompany.Domain, dbCompany.City, dbCompany.Industry), custom),
 		CreatedAt:  formatTimestamp(dbCompany.CreatedAt),
 		UpdatedAt:  formatTimestamp(dbCompany.UpdatedAt),
 		Archived:   false,
@@ -785,26 +966,46 @@ func (h *Handler) handleListCompanies(w http.ResponseWriter, r *http.Request) {
 
 	sessionID := session.FromContext(r.Context())
 
-	dbCompanies, err := h.queries.ListHubspotCompanies(context.Background(), sessionID)
+	dbCompanies, err := session.ListThrough(r.Context(), "hubspot_companies", func(candidate string) ([]database.ListHubspotCompaniesRow, error) {
+		return h.queries.ListHubspotCompanies(context.Background(), candidate)
+	}, nil, func(item database.ListHubspotCompaniesRow) string { return item.ID })
 	if err != nil {
 		log.Printf("[hubspot] ✗ Failed to list companies: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
+	custom, err := h.listCustomProperties(sessionID, "companies")
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to load company properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	results := make([]ResponseResource, 0, len(dbCompanies))
 	for i := range dbCompanies {
 		results = append(results, ResponseResource{
 			ID:         dbCompanies[i].ID,
-			Properties: buildCompany(dbCompanies[i].Name, dbCompanies[i].Domain, dbCompanies[i].City, dbCompanies[i].Industry),
+			Properties: withCustomProperties(buildCompany(dbCompanies[i].Name, dbCompanies[i].Domain, dbCompanies[i].City, dbCompanies[i].Industry), custom[dbCompanies[i].ID]),
 			CreatedAt:  formatTimestamp(dbCompanies[i].CreatedAt),
 			UpdatedAt:  formatTimestamp(dbCompanies[i].UpdatedAt),
 			Archived:   false,
 		})
 	}
 
+	total := len(results)
+	results = listcap.Truncate(w, results)
+
+	for i := range results {
+		if err := h.expandResource(r, "companies", sessionID, &results[i]); err != nil {
+			log.Printf("[hubspot] ✗ Failed to expand company: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	response := SearchResponse{
-		Total:   len(results),
+		Total:   total,
 		Results: results,
 	}
 
@@ -903,8 +1104,8 @@ func (h *Handler) handleAssociationV3(w http.ResponseWriter, r *http.Request) {
 	response := ResponseResource{
 		ID:         toObjectID,
 		Properties: map[string]string{"type": associationType},
-		CreatedAt:  formatTimestamp(time.Now().UnixMilli()),
-		UpdatedAt:  formatTimestamp(time.Now().UnixMilli()),
+		CreatedAt:  formatTimestamp(session.Now(sessionID).UnixMilli()),
+		UpdatedAt:  formatTimestamp(session.Now(sessionID).UnixMilli()),
 		Archived:   false,
 	}
 
@@ -914,14 +1115,119 @@ func (h *Handler) handleAssociationV3(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[hubspot] ✓ Created association: %s/%s -> %s/%s", fromObjectType, fromObjectID, toObjectType, toObjectID)
 }
 
+// Query expansion
+
+// expandResource applies the properties and associations query parameters of a
+// list or get request to a resource
+func (h *Handler) expandResource(r *http.Request, objectType, sessionID string, resource *ResponseResource) error {
+	if names := listParam(r, "properties"); len(names) > 0 {
+		properties, err := selectProperties(resource.Properties, names)
+		if err != nil {
+			return err
+		}
+		resource.Properties = properties
+	}
+
+	for _, related := range listParam(r, "associations") {
+		relatedType := normalizeObjectType(related)
+		rows, err := h.queries.ListHubspotAssociationsForObject(context.Background(), database.ListHubspotAssociationsForObjectParams{
+			SessionID:   sessionID,
+			ObjectType:  objectType,
+			ObjectID:    resource.ID,
+			RelatedType: relatedType,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Associations are stored in the direction they were created but read from both ends
+		seen := make(map[string]bool, len(rows))
+		results := make([]AssociationResult, 0, len(rows))
+		for i := range rows {
+			result := AssociationResult{ID: rows[i].ToObjectID, Type: rows[i].AssociationType}
+			if rows[i].FromObjectType != objectType || rows[i].FromObjectID != resource.ID {
+				result = AssociationResult{ID: rows[i].FromObjectID, Type: reverseAssociationType(rows[i].AssociationType)}
+			}
+			if seen[result.ID] {
+				continue
+			}
+			seen[result.ID] = true
+			results = append(results, result)
+		}
+
+		// HubSpot omits association types that have no results
+		if len(results) == 0 {
+			continue
+		}
+		if resource.Associations == nil {
+			resource.Associations = make(map[string]AssociationResults)
+		}
+		resource.Associations[relatedType] = AssociationResults{Results: results}
+	}
+	return nil
+}
+
+// listParam collects a query parameter given either repeated or comma-separated
+func listParam(r *http.Request, name string) []string {
+	var values []string
+	for _, raw := range r.URL.Query()[name] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// selectProperties keeps only the named properties, dropping any that are unset
+func selectProperties(properties interface{}, names []string) (map[string]interface{}, error) {
+	raw, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]interface{}
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if value, ok := all[name]; ok {
+			selected[name] = value
+		}
+	}
+	return selected, nil
+}
+
+// normalizeObjectType maps singular object names to the plural form used in paths
+func normalizeObjectType(objectType string) string {
+	switch objectType {
+	case "contact":
+		return "contacts"
+	case "deal":
+		return "deals"
+	case "company":
+		return "companies"
+	default:
+		return objectType
+	}
+}
+
+// reverseAssociationType flips a label such as deal_to_contact to contact_to_deal
+func reverseAssociationType(associationType string) string {
+	from, to, ok := strings.Cut(associationType, "_to_")
+	if !ok {
+		return associationType
+	}
+	return to + "_to_" + from
+}
+
 // Helper functions
 
-func generateID() string {
+func generateID(sessionID string) string {
 	b := make([]byte, 8)
-	if _, err := rand.Read(b); err != nil {
-		// Fallback to timestamp-based ID if random generation fails
-		return fmt.Sprintf("%d", time.Now().UnixNano())
-	}
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
 
@@ -1,9 +1,12 @@
 package hubspot_test
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/belong-inc/go-hubspot"
@@ -428,6 +431,54 @@ func TestHubSpotSimulatorAssociations(t *testing.T) {
 		assert.NotNil(t, response, "Should return response")
 	})
 
+	t.Run("ListDealsWithAssociations", func(t *testing.T) {
+		resp, err := customClient.Get("https://api.hubapi.com/crm/v3/objects/deals?associations=contacts&properties=dealname")
+		require.NoError(t, err, "List request should succeed")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "List should return 200")
+
+		var list struct {
+			Results []struct {
+				ID           string            `json:"id"`
+				Properties   map[string]string `json:"properties"`
+				Associations map[string]struct {
+					Results []hubspot.AssociationResult `json:"results"`
+				} `json:"associations"`
+			} `json:"results"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list), "Should decode list response")
+		require.Len(t, list.Results, 1, "Should list the deal")
+
+		listed := list.Results[0]
+		assert.Equal(t, dealResp.ID, listed.ID, "Should list the created deal")
+		assert.Equal(t, map[string]string{"dealname": "Jane's Deal"}, listed.Properties, "Should only return requested properties")
+		require.Len(t, listed.Associations["contacts"].Results, 1, "Should embed the associated contact")
+		assert.Equal(t, contactResp.ID, listed.Associations["contacts"].Results[0].ID, "Should embed the contact ID")
+		assert.Equal(t, "deal_to_contact", listed.Associations["contacts"].Results[0].Type, "Should embed the association type")
+	})
+
+	t.Run("GetWithAssociations", func(t *testing.T) {
+		var fetchedDeal hubspot.Deal
+		res, err := client.CRM.Deal.Get(dealResp.ID, &fetchedDeal, &hubspot.RequestQueryOption{
+			Associations: []string{"contacts"},
+		})
+		require.NoError(t, err, "Get should succeed")
+		require.NotNil(t, res.Associations, "Should embed associations")
+		require.Len(t, res.Associations.Contacts.Results, 1, "Should embed the associated contact")
+		assert.Equal(t, contactResp.ID, res.Associations.Contacts.Results[0].ID, "Should embed the contact ID")
+
+		// Associations are visible from the other side too
+		var fetchedContact hubspot.Contact
+		res, err = client.CRM.Contact.Get(contactResp.ID, &fetchedContact, &hubspot.RequestQueryOption{
+			Associations: []string{"deals"},
+		})
+		require.NoError(t, err, "Get should succeed")
+		require.NotNil(t, res.Associations, "Should embed associations")
+		require.Len(t, res.Associations.Deals.Results, 1, "Should embed the associated deal")
+		assert.Equal(t, dealResp.ID, res.Associations.Deals.Results[0].ID, "Should embed the deal ID")
+		assert.Equal(t, "contact_to_deal", res.Associations.Deals.Results[0].Type, "Should reverse the association type")
+	})
+
 	t.Run("AssociateContactToCompany", func(t *testing.T) {
 		// Associate contact to company
 		assoc := &hubspot.AssociationConfig{
@@ -519,3 +570,135 @@ func TestHubSpotSimulatorEndToEnd(t *testing.T) {
 		assert.Equal(t, "50000", dealProps.Amount.String(), "Deal amount should match")
 	})
 }
+
+func TestHubSpotSimulatorProperties(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "hubspot-test-session-properties"
+
+	// Setup: Start simulator server with session middleware (mimicking main.go setup)
+	handler := session.Middleware(simulatorHubspot.NewHandler(queries))
+	mux := http.NewServeMux()
+	mux.Handle("/hubspot/", http.StripPrefix("/hubspot", handler))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Create custom HTTP client
+	transport := &sessionHTTPTransport{
+		sessionID:  sessionID,
+		testServer: server,
+	}
+	customClient := &http.Client{
+		Transport: transport,
+	}
+
+	// Create HubSpot client
+	client, err := hubspot.NewClient(
+		hubspot.SetPrivateAppToken("test-token"),
+		hubspot.WithHTTPClient(customClient),
+	)
+	require.NoError(t, err, "Failed to create HubSpot client")
+
+	t.Run("ListBuiltinProperties", func(t *testing.T) {
+		list, err := client.CRM.Properties.List("deals")
+		require.NoError(t, err, "List should not return error")
+
+		byName := make(map[string]*hubspot.CrmProperty, len(list.Results))
+		for _, property := range list.Results {
+			byName[property.Name.String()] = property
+		}
+		require.Contains(t, byName, "dealstage", "Should list the typed dealstage property")
+		assert.Equal(t, "enumeration", byName["dealstage"].Type.String(), "dealstage should be an enumeration")
+		assert.Equal(t, "radio", byName["dealstage"].FieldType.String(), "dealstage should be a radio field")
+		assert.Equal(t, "number", byName["amount"].Type.String(), "amount should be a number")
+		assert.True(t, bool(*byName["amount"].HubspotDefined), "Typed properties should be HubSpot defined")
+	})
+
+	t.Run("DefineProperty", func(t *testing.T) {
+		property, err := client.CRM.Properties.Create("contacts", map[string]interface{}{
+			"name":      "favorite_color",
+			"label":     "Favorite Color",
+			"type":      "string",
+			"fieldType": "text",
+			"groupName": "contactinformation",
+		})
+		require.NoError(t, err, "Create should not return error")
+		assert.Equal(t, "favorite_color", property.Name.String(), "Name should match")
+		assert.Equal(t, "Favorite Color", property.Label.String(), "Label should match")
+
+		// Defining the same name again conflicts
+		_, err = client.CRM.Properties.Create("contacts", map[string]interface{}{
+			"name":      "favorite_color",
+			"label":     "Favorite Color",
+			"type":      "string",
+			"fieldType": "text",
+			"groupName": "contactinformation",
+		})
+		require.Error(t, err, "Duplicate property should be rejected")
+
+		_, err = client.CRM.Properties.Create("contacts", map[string]interface{}{
+			"name":      "shoe_size",
+			"label":     "Shoe Size",
+			"type":      "integer",
+			"fieldType": "number",
+			"groupName": "contactinformation",
+		})
+		require.Error(t, err, "Unknown property type should be rejected")
+	})
+
+	t.Run("ReadSchemaBack", func(t *testing.T) {
+		property, err := client.CRM.Properties.Get("contacts", "favorite_color")
+		require.NoError(t, err, "Get should not return error")
+		assert.Equal(t, "string", property.Type.String(), "Type should match")
+		assert.Equal(t, "text", property.FieldType.String(), "Field type should match")
+		assert.Equal(t, "Favorite Color", property.Label.String(), "Label should match")
+
+		list, err := client.CRM.Properties.List("contacts")
+		require.NoError(t, err, "List should not return error")
+		names := make([]string, 0, len(list.Results))
+		for _, property := range list.Results {
+			names = append(names, property.Name.String())
+		}
+		assert.Contains(t, names, "email", "Should list typed properties")
+		assert.Contains(t, names, "favorite_color", "Should list the custom property")
+	})
+
+	t.Run("SetCustomProperty", func(t *testing.T) {
+		body := `{"properties":{"email":"ada@example.com","favorite_color":"green"}}`
+		resp, err := customClient.Post("https://api.hubapi.com/crm/v3/objects/contacts", "application/json", strings.NewReader(body))
+		require.NoError(t, err, "Create request should succeed")
+		var created struct {
+			ID         string            `json:"id"`
+			Properties map[string]string `json:"properties"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created), "Should decode create response")
+		resp.Body.Close()
+		assert.Equal(t, "green", created.Properties["favorite_color"], "Create should echo the custom property")
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch,
+			"https://api.hubapi.com/crm/v3/objects/contacts/"+created.ID, strings.NewReader(`{"properties":{"favorite_color":"blue","nickname":"Ada"}}`))
+		require.NoError(t, err, "Should build update request")
+		req.Header.Set("Content-Type", "application/json")
+		resp, err = customClient.Do(req)
+		require.NoError(t, err, "Update request should succeed")
+		resp.Body.Close()
+
+		resp, err = customClient.Get("https://api.hubapi.com/crm/v3/objects/contacts/" + created.ID)
+		require.NoError(t, err, "Get request should succeed")
+		defer resp.Body.Close()
+		var fetched struct {
+			Properties map[string]string `json:"properties"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&fetched), "Should decode get response")
+		assert.Equal(t, "ada@example.com", fetched.Properties["email"], "Typed property should round-trip")
+		assert.Equal(t, "blue", fetched.Properties["favorite_color"], "Custom property should round-trip")
+		assert.Equal(t, "Ada", fetched.Properties["nickname"], "Undefined property should round-trip")
+
+		// Properties seen on objects show up in the schema even if never defined
+		property, err := client.CRM.Properties.Get("contacts", "nickname")
+		require.NoError(t, err, "Seen property should be listed")
+		assert.Equal(t, "string", property.Type.String(), "Seen properties default to string")
+	})
+}
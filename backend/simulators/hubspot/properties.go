@@ -0,0 +1,448 @@
+package hubspot
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
+	"github.com/recreate-run/nova-simulators/internal/session"
+)
+
+// Property describes a property in the properties API
+type Property struct {
+	Name           string           `json:"name"`
+	Label          string           `json:"label"`
+	Type           string           `json:"type"`
+	FieldType      string           `json:"fieldType"`
+	Description    string           `json:"description"`
+	GroupName      string           `json:"groupName"`
+	Options        []PropertyOption `json:"options"`
+	CreatedAt      string           `json:"createdAt,omitempty"`
+	UpdatedAt      string           `json:"updatedAt,omitempty"`
+	HubspotDefined bool             `json:"hubspotDefined,omitempty"`
+	FormField      bool             `json:"formField"`
+	Archived       bool             `json:"archived"`
+}
+
+// PropertyOption is one choice of an enumeration property
+type PropertyOption struct {
+	Label        string `json:"label"`
+	Value        string `json:"value"`
+	DisplayOrder int    `json:"displayOrder"`
+	Hidden       bool   `json:"hidden"`
+}
+
+// PropertiesResponse is the properties list wrapper
+type PropertiesResponse struct {
+	Results []Property `json:"results"`
+}
+
+// CreatePropertyRequest defines a custom property
+type CreatePropertyRequest struct {
+	Name        string           `json:"name"`
+	Label       string           `json:"label"`
+	Type        string           `json:"type"`
+	FieldType   string           `json:"fieldType"`
+	GroupName   string           `json:"groupName"`
+	Description string           `json:"description"`
+	Options     []PropertyOption `json:"options"`
+}
+
+var (
+	propertyTypes = map[string]bool{
+		"bool": true, "date": true, "datetime": true, "enumeration": true, "number": true, "string": true,
+	}
+	propertyFieldTypes = map[string]bool{
+		"booleancheckbox": true, "calculation_equation": true, "checkbox": true, "date": true, "file": true,
+		"html": true, "number": true, "phonenumber": true, "radio": true, "select": true, "text": true, "textarea": true,
+	}
+)
+
+// builtinProperties are the HubSpot-defined properties backed by typed columns, per object type
+var builtinProperties = map[string][]Property{
+	"contacts": {
+		{Name: "email", Label: "Email", Type: "string", FieldType: "text", GroupName: "contactinformation"},
+		{Name: "firstname", Label: "First Name", Type: "string", FieldType: "text", GroupName: "contactinformation"},
+		{Name: "lastname", Label: "Last Name", Type: "string", FieldType: "text", GroupName: "contactinformation"},
+		{Name: "mobilephone", Label: "Mobile Phone Number", Type: "string", FieldType: "phonenumber", GroupName: "contactinformation"},
+		{Name: "website", Label: "Website URL", Type: "string", FieldType: "text", GroupName: "contactinformation"},
+	},
+	"deals": {
+		{Name: "dealname", Label: "Deal Name", Type: "string", FieldType: "text", GroupName: "dealinformation"},
+		{Name: "dealstage", Label: "Deal Stage", Type: "enumeration", FieldType: "radio", GroupName: "dealinformation", Options: []PropertyOption{
+			{Label: "Appointment Scheduled", Value: "appointmentscheduled", DisplayOrder: 0},
+			{Label: "Qualified To Buy", Value: "qualifiedtobuy", DisplayOrder: 1},
+			{Label: "Presentation Scheduled", Value: "presentationscheduled", DisplayOrder: 2},
+			{Label: "Decision Maker Bought-In", Value: "decisionmakerboughtin", DisplayOrder: 3},
+			{Label: "Contract Sent", Value: "contractsent", DisplayOrder: 4},
+			{Label: "Closed Won", Value: "closedwon", DisplayOrder: 5},
+			{Label: "Closed Lost", Value: "closedlost", DisplayOrder: 6},
+		}},
+		{Name: "pipeline", Label: "Pipeline", Type: "enumeration", FieldType: "select", GroupName: "dealinformation", Options: []PropertyOption{
+			{Label: "Sales Pipeline", Value: "default", DisplayOrder: 0},
+		}},
+		{Name: "amount", Label: "Amount", Type: "number", FieldType: "number", GroupName: "dealinformation"},
+	},
+	"companies": {
+		{Name: "name", Label: "Company name", Type: "string", FieldType: "text", GroupName: "companyinformation"},
+		{Name: "domain", Label: "Company Domain Name", Type: "string", FieldType: "text", GroupName: "companyinformation"},
+		{Name: "city", Label: "City", Type: "string", FieldType: "text", GroupName: "companyinformation"},
+		{Name: "industry", Label: "Industry", Type: "enumeration", FieldType: "select", GroupName: "companyinformation", Options: []PropertyOption{
+			{Label: "Computer Software", Value: "COMPUTER_SOFTWARE", DisplayOrder: 0},
+			{Label: "Financial Services", Value: "FINANCIAL_SERVICES", DisplayOrder: 1},
+			{Label: "Hospital & Health Care", Value: "HOSPITAL_HEALTH_CARE", DisplayOrder: 2},
+			{Label: "Retail", Value: "RETAIL", DisplayOrder: 3},
+		}},
+	},
+}
+
+func (h *Handler) handleProperties(w http.ResponseWriter, r *http.Request) {
+	// Path format: /crm/v3/properties/{objectType}[/{propertyName}]
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/crm/v3/properties/"), "/"), "/")
+	objectType := normalizeObjectType(parts[0])
+	if _, ok := builtinProperties[objectType]; !ok || len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.handleListProperties(w, r, objectType)
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		h.handleCreateProperty(w, r, objectType)
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		h.handleGetProperty(w, r, objectType, parts[1])
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) handleListProperties(w http.ResponseWriter, r *http.Request, objectType string) {
+	log.Printf("[hubspot] → Listing %s properties", objectType)
+
+	properties, err := h.objectProperties(session.FromContext(r.Context()), objectType)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to list properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	properties = listcap.Truncate(w, properties)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(PropertiesResponse{Results: properties})
+	log.Printf("[hubspot] ✓ Listed %d %s properties", len(properties), objectType)
+}
+
+func (h *Handler) handleGetProperty(w http.ResponseWriter, r *http.Request, objectType, name string) {
+	log.Printf("[hubspot] → Getting %s property: %s", objectType, name)
+
+	properties, err := h.objectProperties(session.FromContext(r.Context()), objectType)
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to list properties: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	for i := range properties {
+		if properties[i].Name == name {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(properties[i])
+			log.Printf("[hubspot] ✓ Property retrieved: %s", name)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (h *Handler) handleCreateProperty(w http.ResponseWriter, r *http.Request, objectType string) {
+	log.Printf("[hubspot] → Creating %s property", objectType)
+
+	var req CreatePropertyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[hubspot] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case req.Name == "" || req.Label == "" || req.Type == "" || req.FieldType == "" || req.GroupName == "":
+		http.Error(w, "name, label, type, fieldType and groupName are required", http.StatusBadRequest)
+		return
+	case !propertyTypes[req.Type]:
+		http.Error(w, "Invalid property type: "+req.Type, http.StatusBadRequest)
+		return
+	case !propertyFieldTypes[req.FieldType]:
+		http.Error(w, "Invalid property fieldType: "+req.FieldType, http.StatusBadRequest)
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	if isBuiltinProperty(objectType, req.Name) {
+		http.Error(w, "Property already exists: "+req.Name, http.StatusConflict)
+		return
+	}
+	_, err := h.queries.GetHubspotPropertyDefinition(context.Background(), database.GetHubspotPropertyDefinitionParams{
+		SessionID:  sessionID,
+		ObjectType: objectType,
+		Name:       req.Name,
+	})
+	if err == nil {
+		http.Error(w, "Property already exists: "+req.Name, http.StatusConflict)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[hubspot] ✗ Failed to look up property: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if req.Options == nil {
+		req.Options = []PropertyOption{}
+	}
+	options, _ := json.Marshal(req.Options)
+	now := session.Now(sessionID).UnixMilli()
+
+	definition, err := h.queries.CreateHubspotPropertyDefinition(context.Background(), database.CreateHubspotPropertyDefinitionParams{
+		SessionID:   sessionID,
+		ObjectType:  objectType,
+		Name:        req.Name,
+		Label:       req.Label,
+		Type:        req.Type,
+		FieldType:   req.FieldType,
+		GroupName:   req.GroupName,
+		Description: req.Description,
+		Options:     string(options),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		log.Printf("[hubspot] ✗ Failed to create property: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	property := buildProperty(&definition)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(property)
+	log.Printf("[hubspot] ✓ Property created: %s", req.Name)
+}
+
+// objectProperties lists the built-in properties of an object type, then the session's custom
+// definitions, then any property names seen on objects that were never defined
+func (h *Handler) objectProperties(sessionID, objectType string) ([]Property, error) {
+	definitions, err := h.queries.ListHubspotPropertyDefinitions(context.Background(), database.ListHubspotPropertyDefinitionsParams{
+		SessionID:  sessionID,
+		ObjectType: objectType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, err := h.queries.ListHubspotObjectProperties(context.Background(), database.ListHubspotObjectPropertiesParams{
+		SessionID:  sessionID,
+		ObjectType: objectType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make([]Property, 0, len(builtinProperties[objectType])+len(definitions))
+	known := make(map[string]bool)
+	for _, property := range builtinProperties[objectType] {
+		property.HubspotDefined = true
+		if property.Options == nil {
+			property.Options = []PropertyOption{}
+		}
+		properties = append(properties, property)
+		known[property.Name] = true
+	}
+	for i := range definitions {
+		properties = append(properties, buildProperty(&definitions[i]))
+		known[definitions[i].Name] = true
+	}
+
+	var seen []string
+	for i := range values {
+		var blob map[string]string
+		if err := json.Unmarshal([]byte(values[i].Properties), &blob); err != nil {
+			return nil, err
+		}
+		for name := range blob {
+			if !known[name] {
+				known[name] = true
+				seen = append(seen, name)
+			}
+		}
+	}
+	sort.Strings(seen)
+	for _, name := range seen {
+		properties = append(properties, Property{
+			Name:      name,
+			Label:     name,
+			Type:      "string",
+			FieldType: "text",
+			Options:   []PropertyOption{},
+		})
+	}
+	return properties, nil
+}
+
+// Custom property values
+
+// customProperties returns the properties in a create or update body that have no typed column,
+// with values converted to the strings HubSpot stores
+func customProperties(objectType string, propsJSON []byte) map[string]string {
+	var all map[string]interface{}
+	if err := json.Unmarshal(propsJSON, &all); err != nil {
+		return nil
+	}
+
+	custom := make(map[string]string)
+	for name, value := range all {
+		if isBuiltinProperty(objectType, name) {
+			continue
+		}
+		switch v := value.(type) {
+		case nil:
+			custom[name] = ""
+		case string:
+			custom[name] = v
+		default:
+			raw, _ := json.Marshal(v)
+			custom[name] = string(raw)
+		}
+	}
+	return custom
+}
+
+// saveCustomProperties merges custom values into those already stored for an object
+func (h *Handler) saveCustomProperties(sessionID, objectType, objectID string, custom map[string]string) error {
+	if len(custom) == 0 {
+		return nil
+	}
+
+	stored, err := h.loadCustomProperties(sessionID, objectType, objectID)
+	if err != nil {
+		return err
+	}
+	for name, value := range custom {
+		stored[name] = value
+	}
+
+	raw, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return h.queries.UpsertHubspotObjectProperties(context.Background(), database.UpsertHubspotObjectPropertiesParams{
+		SessionID:  sessionID,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Properties: string(raw),
+	})
+}
+
+// loadCustomProperties returns the custom values stored for an object
+func (h *Handler) loadCustomProperties(sessionID, objectType, objectID string) (map[string]string, error) {
+	raw, err := h.queries.GetHubspotObjectProperties(context.Background(), database.GetHubspotObjectPropertiesParams{
+		SessionID:  sessionID,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var custom map[string]string
+	if err := json.Unmarshal([]byte(raw), &custom); err != nil {
+		return nil, err
+	}
+	return custom, nil
+}
+
+// listCustomProperties returns the custom values of every object of a type, keyed by object ID.
+// Objects inherited from the parent session keep the parent's values.
+func (h *Handler) listCustomProperties(sessionID, objectType string) (map[string]map[string]string, error) {
+	byID := make(map[string]map[string]string)
+	for _, candidate := range []string{sessionID, session.Parent(sessionID)} {
+		if candidate == "" {
+			continue
+		}
+		rows, err := h.queries.ListHubspotObjectProperties(context.Background(), database.ListHubspotObjectPropertiesParams{
+			SessionID:  candidate,
+			ObjectType: objectType,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range rows {
+			if _, ok := byID[rows[i].ObjectID]; ok {
+				continue
+			}
+			var custom map[string]string
+			if err := json.Unmarshal([]byte(rows[i].Properties), &custom); err != nil {
+				return nil, err
+			}
+			byID[rows[i].ObjectID] = custom
+		}
+	}
+	return byID, nil
+}
+
+// withCustomProperties adds custom values to an object's typed properties. The typed struct is
+// returned unchanged when there are none.
+func withCustomProperties(properties interface{}, custom map[string]string) interface{} {
+	if len(custom) == 0 {
+		return properties
+	}
+
+	raw, err := json.Marshal(properties)
+	if err != nil {
+		return properties
+	}
+	merged := make(map[string]interface{}, len(custom))
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return properties
+	}
+	for name, value := range custom {
+		merged[name] = value
+	}
+	return merged
+}
+
+func isBuiltinProperty(objectType, name string) bool {
+	for i := range builtinProperties[objectType] {
+		if builtinProperties[objectType][i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func buildProperty(definition *database.HubspotPropertyDefinition) Property {
+	options := []PropertyOption{}
+	_ = json.Unmarshal([]byte(definition.Options), &options)
+	return Property{
+		Name:        definition.Name,
+		Label:       definition.Label,
+		Type:        definition.Type,
+		FieldType:   definition.FieldType,
+		Description: definition.Description,
+		GroupName:   definition.GroupName,
+		Options:     options,
+		CreatedAt:   formatTimestamp(definition.CreatedAt),
+		UpdatedAt:   formatTimestamp(definition.UpdatedAt),
+	}
+}
@@ -2,17 +2,21 @@ package jira
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/recreate-run/nova-simulators/internal/apierror"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -33,29 +37,121 @@ type IssueType struct {
 }
 
 type Status struct {
-	Name string `json:"name"`
+	Name           string          `json:"name"`
+	StatusCategory *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory groups statuses into the To Do / In Progress / Done columns boards render
+type StatusCategory struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	ColorName string `json:"colorName"`
+	Name      string `json:"name"`
 }
 
+// The status categories every Jira instance has, with their upstream IDs
+var (
+	statusCategoryToDo       = StatusCategory{ID: 2, Key: "new", ColorName: "blue-gray", Name: "To Do"}
+	statusCategoryInProgress = StatusCategory{ID: 4, Key: "indeterminate", ColorName: "yellow", Name: "In Progress"}
+	statusCategoryDone       = StatusCategory{ID: 3, Key: "done", ColorName: "green", Name: "Done"}
+)
+
 type IssueFields struct {
-	Project     Project    `json:"project"`
-	Type        IssueType  `json:"issuetype"`
-	Summary     string     `json:"summary"`
-	Description string     `json:"description,omitempty"`
-	Assignee    *User      `json:"assignee,omitempty"`
-	Status      *Status    `json:"status,omitempty"`
+	Project     Project       `json:"project"`
+	Type        IssueType     `json:"issuetype"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description,omitempty"`
+	Assignee    *User         `json:"assignee,omitempty"`
+	Status      *Status       `json:"status,omitempty"`
+	Comment     *CommentsPage `json:"comment,omitempty"`
+
+	// custom holds fields without a typed column, such as customfield_10001
+	custom map[string]json.RawMessage
+	// only limits the fields written to JSON; nil writes them all
+	only map[string]bool
+}
+
+// builtinFields are the fields IssueFields stores in typed columns
+var builtinFields = map[string]bool{
+	"project":     true,
+	"issuetype":   true,
+	"summary":     true,
+	"description": true,
+	"assignee":    true,
+	"status":      true,
+	"comment":     true,
+}
+
+// MarshalJSON writes the issue fields merged with any custom ones, keeping only the requested
+// fields when a search asked for a subset
+func (f IssueFields) MarshalJSON() ([]byte, error) {
+	type plain IssueFields
+	data, err := json.Marshal(plain(f))
+	if err != nil || (f.only == nil && len(f.custom) == 0) {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for name, value := range f.custom {
+		if !builtinFields[name] {
+			all[name] = value
+		}
+	}
+	if f.only != nil {
+		for name := range all {
+			if !f.only[name] {
+				delete(all, name)
+			}
+		}
+	}
+	return json.Marshal(all)
+}
+
+// UnmarshalJSON reads the typed fields and keeps every other field as a custom one
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	f.custom = make(map[string]json.RawMessage)
+	for name, value := range all {
+		if !builtinFields[name] {
+			f.custom[name] = value
+		}
+	}
+	return nil
 }
 
 type Issue struct {
-	ID     string       `json:"id"`
-	Key    string       `json:"key"`
-	Self   string       `json:"self,omitempty"`
-	Fields *IssueFields `json:"fields"`
+	ID          string       `json:"id"`
+	Key         string       `json:"key"`
+	Self        string       `json:"self,omitempty"`
+	Fields      *IssueFields `json:"fields"`
+	Transitions []Transition `json:"transitions,omitempty"`
 }
 
 type Comment struct {
-	ID   string `json:"id"`
-	Body string `json:"body"`
-	Self string `json:"self,omitempty"`
+	ID      string `json:"id"`
+	Body    string `json:"body"`
+	Self    string `json:"self,omitempty"`
+	Created string `json:"created,omitempty"`
+}
+
+// CommentsPage is a page of an issue's comments, returned by the comment list endpoint and
+// embedded in issues as fields.comment
+type CommentsPage struct {
+	Comments   []Comment `json:"comments"`
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
 }
 
 type Transition struct {
@@ -68,6 +164,14 @@ type TransitionsResponse struct {
 	Transitions []Transition `json:"transitions"`
 }
 
+// SearchRequest is the body of POST /search; GET /search takes the same values as query parameters
+type SearchRequest struct {
+	JQL        string   `json:"jql"`
+	StartAt    int      `json:"startAt"`
+	MaxResults int      `json:"maxResults"`
+	Fields     []string `json:"fields"`
+}
+
 type SearchResults struct {
 	Issues     []Issue `json:"issues"`
 	StartAt    int     `json:"startAt"`
@@ -75,6 +179,63 @@ type SearchResults struct {
 	Total      int     `json:"total"`
 }
 
+// FieldSchema describes the value type of a field in create/edit metadata
+type FieldSchema struct {
+	Type   string `json:"type"`
+	System string `json:"system,omitempty"`
+}
+
+// FieldMeta describes a field that can be set when creating or editing an issue
+type FieldMeta struct {
+	Required        bool        `json:"required"`
+	Schema          FieldSchema `json:"schema"`
+	Name            string      `json:"name"`
+	Key             string      `json:"key"`
+	HasDefaultValue bool        `json:"hasDefaultValue"`
+	Operations      []string    `json:"operations"`
+}
+
+type CreateMetaIssueType struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name"`
+	Subtask bool                 `json:"subtask"`
+	Fields  map[string]FieldMeta `json:"fields"`
+}
+
+type CreateMetaProject struct {
+	ID         string                `json:"id"`
+	Key        string                `json:"key"`
+	Name       string                `json:"name"`
+	IssueTypes []CreateMetaIssueType `json:"issuetypes"`
+}
+
+type CreateMetaResponse struct {
+	Expand   string              `json:"expand"`
+	Projects []CreateMetaProject `json:"projects"`
+}
+
+type EditMetaResponse struct {
+	Fields map[string]FieldMeta `json:"fields"`
+}
+
+// jiraTimeFormat is the timestamp layout Jira uses in REST responses
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// defaultCommentPageSize is the page size for comment lists, and the cap on comments embedded
+// in a fetched issue
+const defaultCommentPageSize = 50
+
+// defaultIssueTypes are offered by every project in create metadata
+var defaultIssueTypes = []struct {
+	ID   string
+	Name string
+}{
+	{ID: "10001", Name: "Bug"},
+	{ID: "10002", Name: "Task"},
+	{ID: "10003", Name: "Story"},
+	{ID: "10004", Name: "Epic"},
+}
+
 // Handler implements the Jira simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -87,6 +248,19 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountJiraSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"projects": counts.Projects,
+		"issues":   counts.Issues,
+		"comments": counts.Comments,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[jira] → %s %s", r.Method, r.URL.Path)
@@ -97,7 +271,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.NotFound(w, r)
+	apierror.Write(w, apierror.Jira, http.StatusNotFound, "Not Found")
 }
 
 func (h *Handler) handleJiraAPI(w http.ResponseWriter, r *http.Request) {
@@ -107,10 +281,27 @@ func (h *Handler) handleJiraAPI(w http.ResponseWriter, r *http.Request) {
 	switch {
 	case path == "project" && r.Method == http.MethodGet:
 		h.handleListProjects(w, r)
+	case path == "project" && r.Method == http.MethodPost:
+		h.handleCreateProject(w, r)
+	case strings.HasPrefix(path, "project/") && r.Method == http.MethodGet:
+		projectKey := strings.TrimPrefix(path, "project/")
+		h.handleGetProject(w, r, projectKey)
+	case strings.HasPrefix(path, "project/") && r.Method == http.MethodDelete:
+		projectKey := strings.TrimPrefix(path, "project/")
+		h.handleDeleteProject(w, r, projectKey)
+	case path == "status" && r.Method == http.MethodGet:
+		h.handleListStatuses(w, r)
 	case path == "issue" && r.Method == http.MethodPost:
 		h.handleCreateIssue(w, r)
 	case path == "search" && r.Method == http.MethodGet:
 		h.handleSearchIssues(w, r)
+	case path == "search" && r.Method == http.MethodPost:
+		h.handleSearchIssuesPost(w, r)
+	case path == "issue/createmeta" && r.Method == http.MethodGet:
+		h.handleCreateMeta(w, r)
+	case strings.HasPrefix(path, "issue/") && strings.HasSuffix(path, "/editmeta") && r.Method == http.MethodGet:
+		issueKey := extractIssueKey(path)
+		h.handleEditMeta(w, r, issueKey)
 	case strings.HasPrefix(path, "issue/") && strings.HasSuffix(path, "/transitions") && r.Method == http.MethodGet:
 		issueKey := extractIssueKey(path)
 		issueKey = strings.TrimSuffix(issueKey, "/transitions")
@@ -119,6 +310,10 @@ func (h *Handler) handleJiraAPI(w http.ResponseWriter, r *http.Request) {
 		issueKey := extractIssueKey(path)
 		issueKey = strings.TrimSuffix(issueKey, "/transitions")
 		h.handleExecuteTransition(w, r, issueKey)
+	case strings.HasPrefix(path, "issue/") && strings.HasSuffix(path, "/comment") && r.Method == http.MethodGet:
+		issueKey := extractIssueKey(path)
+		issueKey = strings.TrimSuffix(issueKey, "/comment")
+		h.handleListComments(w, r, issueKey)
 	case strings.HasPrefix(path, "issue/") && strings.HasSuffix(path, "/comment") && r.Method == http.MethodPost:
 		issueKey := extractIssueKey(path)
 		issueKey = strings.TrimSuffix(issueKey, "/comment")
@@ -128,17 +323,17 @@ func (h *Handler) handleJiraAPI(w http.ResponseWriter, r *http.Request) {
 		if issueKey != "" && !strings.Contains(issueKey, "/") {
 			h.handleGetIssue(w, r, issueKey)
 		} else {
-			http.NotFound(w, r)
+			apierror.Write(w, apierror.Jira, http.StatusNotFound, "Not Found")
 		}
 	case strings.HasPrefix(path, "issue/") && r.Method == http.MethodPut:
 		issueKey := extractIssueKey(path)
 		if issueKey != "" && !strings.Contains(issueKey, "/") {
 			h.handleUpdateIssue(w, r, issueKey)
 		} else {
-			http.NotFound(w, r)
+			apierror.Write(w, apierror.Jira, http.StatusNotFound, "Not Found")
 		}
 	default:
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Not Found")
 	}
 }
 
@@ -150,10 +345,12 @@ func (h *Handler) handleListProjects(w http.ResponseWriter, r *http.Request) {
 	// Initialize default transitions for this session if not already present
 	h.initializeDefaultTransitions(sessionID)
 
-	dbProjects, err := h.queries.ListJiraProjects(context.Background(), sessionID)
+	dbProjects, err := session.ListThrough(r.Context(), "jira_projects", func(candidate string) ([]database.ListJiraProjectsRow, error) {
+		return h.queries.ListJiraProjects(context.Background(), candidate)
+	}, nil, func(item database.ListJiraProjectsRow) string { return item.Key })
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to list projects: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -171,6 +368,221 @@ func (h *Handler) handleListProjects(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[jira] ✓ Listed %d projects", len(projects))
 }
 
+// CreateProjectRequest is the body of POST /rest/api/2/project
+type CreateProjectRequest struct {
+	Key            string `json:"key"`
+	Name           string `json:"name"`
+	ProjectTypeKey string `json:"projectTypeKey,omitempty"`
+	Description    string `json:"description,omitempty"`
+	LeadAccountID  string `json:"leadAccountId,omitempty"`
+}
+
+func (h *Handler) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	log.Println("[jira] → Received create project request")
+
+	sessionID := session.FromContext(r.Context())
+
+	var req CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[jira] ✗ Failed to decode request: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if req.Key == "" || req.Name == "" {
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Project key and name are required")
+		return
+	}
+
+	_, err := h.queries.GetJiraProjectByKey(context.Background(), database.GetJiraProjectByKeyParams{
+		Key:       req.Key,
+		SessionID: sessionID,
+	})
+	if err == nil {
+		log.Printf("[jira] ✗ Project already exists: %s", req.Key)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "A project with that project key already exists.")
+		return
+	}
+
+	projectID := generateID(sessionID)
+	err = h.queries.CreateJiraProject(context.Background(), database.CreateJiraProjectParams{
+		ID:        projectID,
+		Key:       req.Key,
+		Name:      req.Name,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to create project: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(Project{
+		ID:  projectID,
+		Key: req.Key,
+	})
+	log.Printf("[jira] ✓ Project created: %s", req.Key)
+}
+
+func (h *Handler) handleGetProject(w http.ResponseWriter, r *http.Request, projectKey string) {
+	log.Printf("[jira] → Received get project request for: %s", projectKey)
+
+	project, err := session.ReadThrough(r.Context(), "jira_projects", projectKey, func(candidate string) (database.GetJiraProjectByKeyRow, error) {
+		return h.queries.GetJiraProjectByKey(context.Background(), database.GetJiraProjectByKeyParams{
+			Key:       projectKey,
+			SessionID: candidate,
+		})
+	})
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to get project: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "No project could be found with key '"+projectKey+"'.")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Project{
+		ID:   project.ID,
+		Key:  project.Key,
+		Name: project.Name,
+	})
+	log.Printf("[jira] ✓ Retrieved project: %s", projectKey)
+}
+
+// handleDeleteProject deletes a project together with its issues and their comments
+func (h *Handler) handleDeleteProject(w http.ResponseWriter, r *http.Request, projectKey string) {
+	log.Printf("[jira] → Received delete project request for: %s", projectKey)
+
+	sessionID := session.FromContext(r.Context())
+
+	var deleted int64
+	err := h.queries.ExecTx(context.Background(), func(q *database.Queries) error {
+		ctx := context.Background()
+		if err := q.DeleteJiraCommentsByProject(ctx, database.DeleteJiraCommentsByProjectParams{
+			SessionID:  sessionID,
+			ProjectKey: projectKey,
+		}); err != nil {
+			return err
+		}
+		if err := q.DeleteJiraIssueCustomFieldsByProject(ctx, database.DeleteJiraIssueCustomFieldsByProjectParams{
+			SessionID:  sessionID,
+			ProjectKey: projectKey,
+		}); err != nil {
+			return err
+		}
+		if err := q.DeleteJiraIssuesByProject(ctx, database.DeleteJiraIssuesByProjectParams{
+			ProjectKey: projectKey,
+			SessionID:  sessionID,
+		}); err != nil {
+			return err
+		}
+		var err error
+		deleted, err = q.DeleteJiraProject(ctx, database.DeleteJiraProjectParams{
+			Key:       projectKey,
+			SessionID: sessionID,
+		})
+		return err
+	})
+	if err == nil {
+		// The parent session's copy is left in place and hidden from the child
+		var inherited bool
+		inherited, err = h.hideInheritedProject(r.Context(), projectKey)
+		if inherited {
+			deleted++
+		}
+	}
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to delete project: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if deleted == 0 {
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "No project could be found with key '"+projectKey+"'.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Printf("[jira] ✓ Project deleted: %s", projectKey)
+}
+
+func (h *Handler) handleCreateMeta(w http.ResponseWriter, r *http.Request) {
+	log.Println("[jira] → Received create meta request")
+
+	sessionID := session.FromContext(r.Context())
+
+	dbProjects, err := h.queries.ListJiraProjects(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to list projects: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// Optional comma-separated projectKeys filter
+	keyFilter := make(map[string]bool)
+	if keys := r.URL.Query().Get("projectKeys"); keys != "" {
+		for _, key := range strings.Split(keys, ",") {
+			keyFilter[strings.TrimSpace(key)] = true
+		}
+	}
+
+	projects := make([]CreateMetaProject, 0, len(dbProjects))
+	for _, p := range dbProjects {
+		if len(keyFilter) > 0 && !keyFilter[p.Key] {
+			continue
+		}
+
+		issueTypes := make([]CreateMetaIssueType, 0, len(defaultIssueTypes))
+		for _, it := range defaultIssueTypes {
+			issueTypes = append(issueTypes, CreateMetaIssueType{
+				ID:     it.ID,
+				Name:   it.Name,
+				Fields: issueFieldMeta(true),
+			})
+		}
+
+		projects = append(projects, CreateMetaProject{
+			ID:         p.ID,
+			Key:        p.Key,
+			Name:       p.Name,
+			IssueTypes: issueTypes,
+		})
+	}
+
+	response := CreateMetaResponse{
+		Expand:   "projects",
+		Projects: projects,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[jira] ✓ Returned create meta for %d projects", len(projects))
+}
+
+func (h *Handler) handleEditMeta(w http.ResponseWriter, r *http.Request, issueKey string) {
+	log.Printf("[jira] → Received edit meta request for issue: %s", issueKey)
+
+	sessionID := session.FromContext(r.Context())
+
+	_, err := h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
+		Key:       issueKey,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to get issue: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+
+	response := EditMetaResponse{
+		Fields: issueFieldMeta(false),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[jira] ✓ Returned edit meta for issue: %s", issueKey)
+}
+
 func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 	log.Println("[jira] → Received create issue request")
 
@@ -179,13 +591,13 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 	var req Issue
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[jira] ✗ Failed to decode request: %v", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request")
 		return
 	}
 
 	// Validate required fields
 	if req.Fields == nil {
-		http.Error(w, "Fields are required", http.StatusBadRequest)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Fields are required")
 		return
 	}
 
@@ -195,7 +607,7 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 	description := req.Fields.Description
 
 	if projectKey == "" || issueType == "" || summary == "" {
-		http.Error(w, "Project key, issue type, and summary are required", http.StatusBadRequest)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Project key, issue type, and summary are required")
 		return
 	}
 
@@ -206,7 +618,7 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		// Project doesn't exist, create it
-		projectID := generateID()
+		projectID := generateID(sessionID)
 		projectName := req.Fields.Project.Name
 		if projectName == "" {
 			projectName = projectKey
@@ -219,13 +631,13 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 		})
 		if err != nil {
 			log.Printf("[jira] ✗ Failed to create project: %v", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 			return
 		}
 	}
 
 	// Generate issue ID and key
-	issueID := generateID()
+	issueID := generateID(sessionID)
 	issueKey := h.generateIssueKey(sessionID, projectKey)
 
 	// Extract assignee
@@ -249,7 +661,13 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to create issue: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if err := h.saveCustomFields(sessionID, issueKey, req.Fields.custom); err != nil {
+		log.Printf("[jira] ✗ Failed to save custom fields: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -267,9 +685,8 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 			Summary:     summary,
 			Description: description,
 			Assignee:    req.Fields.Assignee,
-			Status: &Status{
-				Name: "To Do",
-			},
+			Status:      newStatus("To Do"),
+			custom:      req.Fields.custom,
 		},
 	}
 
@@ -281,15 +698,19 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetIssue(w http.ResponseWriter, r *http.Request, issueKey string) {
 	log.Printf("[jira] → Received get issue request for key: %s", issueKey)
 
-	sessionID := session.FromContext(r.Context())
-
-	dbIssue, err := h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
-		Key:       issueKey,
-		SessionID: sessionID,
+	// Fall through to the parent session. Comments and custom fields live in whichever session
+	// holds the issue.
+	var sessionID string
+	dbIssue, err := session.ReadThrough(r.Context(), "jira_issues", issueKey, func(candidate string) (database.GetJiraIssueByKeyRow, error) {
+		sessionID = candidate
+		return h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
+			Key:       issueKey,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to get issue: %v", err)
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
 		return
 	}
 
@@ -306,9 +727,7 @@ func (h *Handler) handleGetIssue(w http.ResponseWriter, r *http.Request, issueKe
 			},
 			Summary:     dbIssue.Summary,
 			Description: dbIssue.Description.String,
-			Status: &Status{
-				Name: dbIssue.Status,
-			},
+			Status:      newStatus(dbIssue.Status),
 		},
 	}
 
@@ -318,6 +737,33 @@ func (h *Handler) handleGetIssue(w http.ResponseWriter, r *http.Request, issueKe
 		}
 	}
 
+	comments, _, err := h.commentsPage(sessionID, issueKey, 0, defaultCommentPageSize)
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to list comments: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	issue.Fields.Comment = comments
+
+	custom, err := h.loadCustomFields(sessionID, issueKey)
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to load custom fields: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	issue.Fields.custom = custom
+
+	if expandsTransitions(r.URL.Query().Get("expand")) {
+		h.initializeDefaultTransitions(sessionID)
+		transitions, err := h.availableTransitions(sessionID, dbIssue.Status)
+		if err != nil {
+			log.Printf("[jira] ✗ Failed to list transitions: %v", err)
+			apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+			return
+		}
+		issue.Transitions = transitions
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(issue)
 	log.Printf("[jira] ✓ Returned issue: %s", issueKey)
@@ -333,7 +779,7 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, issu
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[jira] ✗ Failed to decode request: %v", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request")
 		return
 	}
 
@@ -344,7 +790,7 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, issu
 	})
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to get issue: %v", err)
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
 		return
 	}
 
@@ -367,6 +813,19 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, issu
 		}
 	}
 
+	custom := make(map[string]json.RawMessage)
+	for name, value := range req.Fields {
+		if builtinFields[name] {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request")
+			return
+		}
+		custom[name] = raw
+	}
+
 	// Update issue
 	err = h.queries.UpdateJiraIssue(context.Background(), database.UpdateJiraIssueParams{
 		Summary:     summary,
@@ -378,7 +837,13 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, r *http.Request, issu
 
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to update issue: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	if err := h.saveCustomFields(sessionID, issueKey, custom); err != nil {
+		log.Printf("[jira] ✗ Failed to save custom fields: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -395,35 +860,24 @@ func (h *Handler) handleGetTransitions(w http.ResponseWriter, r *http.Request, i
 	h.initializeDefaultTransitions(sessionID)
 
 	// Verify issue exists
-	_, err := h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
+	dbIssue, err := h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
 		Key:       issueKey,
 		SessionID: sessionID,
 	})
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to get issue: %v", err)
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
 		return
 	}
 
-	// Get all transitions for this session
-	dbTransitions, err := h.queries.ListJiraTransitions(context.Background(), sessionID)
+	// Only transitions legal from the issue's current status are offered
+	transitions, err := h.availableTransitions(sessionID, dbIssue.Status)
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to list transitions: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	transitions := make([]Transition, 0, len(dbTransitions))
-	for _, t := range dbTransitions {
-		transitions = append(transitions, Transition{
-			ID:   t.ID,
-			Name: t.Name,
-			To: &Status{
-				Name: t.ToStatus,
-			},
-		})
-	}
-
 	response := TransitionsResponse{
 		Transitions: transitions,
 	}
@@ -445,7 +899,17 @@ func (h *Handler) handleExecuteTransition(w http.ResponseWriter, r *http.Request
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[jira] ✗ Failed to decode request: %v", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	dbIssue, err := h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
+		Key:       issueKey,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to get issue: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
 		return
 	}
 
@@ -453,20 +917,28 @@ func (h *Handler) handleExecuteTransition(w http.ResponseWriter, r *http.Request
 	dbTransitions, err := h.queries.ListJiraTransitions(context.Background(), sessionID)
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to list transitions: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
-	var targetStatus string
+	var targetStatus, transitionName string
 	for _, t := range dbTransitions {
 		if t.ID == req.Transition.ID {
 			targetStatus = t.ToStatus
+			transitionName = t.Name
 			break
 		}
 	}
 
 	if targetStatus == "" {
-		http.Error(w, "Transition not found", http.StatusNotFound)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Transition not found")
+		return
+	}
+
+	if !transitionAllowed(transitionName, dbIssue.Status) {
+		log.Printf("[jira] ✗ Transition %q is not valid from status %q", transitionName, dbIssue.Status)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest,
+			fmt.Sprintf("Transition id '%s' is not valid for this issue.", req.Transition.ID))
 		return
 	}
 
@@ -479,7 +951,7 @@ func (h *Handler) handleExecuteTransition(w http.ResponseWriter, r *http.Request
 
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to update issue status: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
@@ -495,7 +967,7 @@ func (h *Handler) handleAddComment(w http.ResponseWriter, r *http.Request, issue
 	var req Comment
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[jira] ✗ Failed to decode request: %v", err)
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request")
 		return
 	}
 
@@ -506,12 +978,12 @@ func (h *Handler) handleAddComment(w http.ResponseWriter, r *http.Request, issue
 	})
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to get issue: %v", err)
-		http.NotFound(w, r)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
 		return
 	}
 
 	// Create comment
-	commentID := generateID()
+	commentID := generateID(sessionID)
 	err = h.queries.CreateJiraComment(context.Background(), database.CreateJiraCommentParams{
 		ID:        commentID,
 		IssueKey:  issueKey,
@@ -521,13 +993,14 @@ func (h *Handler) handleAddComment(w http.ResponseWriter, r *http.Request, issue
 
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to create comment: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
 
 	response := Comment{
-		ID:   commentID,
-		Body: req.Body,
+		ID:      commentID,
+		Body:    req.Body,
+		Created: session.Now(sessionID).Format(jiraTimeFormat),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -536,31 +1009,121 @@ func (h *Handler) handleAddComment(w http.ResponseWriter, r *http.Request, issue
 	log.Printf("[jira] ✓ Comment added: %s", commentID)
 }
 
-func (h *Handler) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
-	log.Println("[jira] → Received search issues request")
+func (h *Handler) handleListComments(w http.ResponseWriter, r *http.Request, issueKey string) {
+	log.Printf("[jira] → Received list comments request for issue: %s", issueKey)
 
 	sessionID := session.FromContext(r.Context())
 
-	// Parse query parameters
+	_, err := h.queries.GetJiraIssueByKey(context.Background(), database.GetJiraIssueByKeyParams{
+		Key:       issueKey,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to get issue: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusNotFound, "Issue does not exist or you do not have permission to see it.")
+		return
+	}
+
 	query := r.URL.Query()
-	jql := query.Get("jql")
-	maxResultsStr := query.Get("maxResults")
-	startAtStr := query.Get("startAt")
+	maxResults := defaultCommentPageSize
+	if mr, err := strconv.Atoi(query.Get("maxResults")); err == nil && mr >= 0 {
+		maxResults = mr
+	}
+	startAt := 0
+	if sa, err := strconv.Atoi(query.Get("startAt")); err == nil && sa > 0 {
+		startAt = sa
+	}
 
-	maxResults := 50
-	if maxResultsStr != "" {
-		if mr, err := strconv.Atoi(maxResultsStr); err == nil {
-			maxResults = mr
-		}
+	page, clamped, err := h.commentsPage(sessionID, issueKey, startAt, maxResults)
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to list comments: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if clamped && page.StartAt+len(page.Comments) < page.Total {
+		listcap.MarkTruncated(w)
 	}
 
-	startAt := 0
-	if startAtStr != "" {
-		if sa, err := strconv.Atoi(startAtStr); err == nil {
-			startAt = sa
-		}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(page)
+	log.Printf("[jira] ✓ Returned %d of %d comments for issue: %s", len(page.Comments), page.Total, issueKey)
+}
+
+// commentsPage returns one page of an issue's comments, oldest first. The page size is held to
+// the list cap; the second result reports whether it was reduced.
+func (h *Handler) commentsPage(sessionID, issueKey string, startAt, maxResults int) (*CommentsPage, bool, error) {
+	dbComments, err := h.queries.ListJiraComments(context.Background(), database.ListJiraCommentsParams{
+		IssueKey:  issueKey,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	pageSize, clamped := listcap.Clamp(maxResults)
+	total := len(dbComments)
+	start := min(startAt, total)
+	end := min(start+pageSize, total)
+
+	comments := make([]Comment, 0, end-start)
+	for _, c := range dbComments[start:end] {
+		comments = append(comments, Comment{
+			ID:      c.ID,
+			Body:    c.Body,
+			Created: time.Unix(c.CreatedAt, 0).Format(jiraTimeFormat),
+		})
+	}
+
+	return &CommentsPage{
+		Comments:   comments,
+		StartAt:    startAt,
+		MaxResults: pageSize,
+		Total:      total,
+	}, clamped, nil
+}
+
+func (h *Handler) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
+	log.Println("[jira] → Received search issues request")
+
+	// Parse query parameters
+	query := r.URL.Query()
+	req := SearchRequest{
+		JQL:        query.Get("jql"),
+		MaxResults: 50,
+	}
+	if mr, err := strconv.Atoi(query.Get("maxResults")); err == nil {
+		req.MaxResults = mr
+	}
+	if sa, err := strconv.Atoi(query.Get("startAt")); err == nil {
+		req.StartAt = sa
+	}
+	if fields := query.Get("fields"); fields != "" {
+		req.Fields = strings.Split(fields, ",")
+	}
+
+	h.searchIssues(w, r, &req)
+}
+
+func (h *Handler) handleSearchIssuesPost(w http.ResponseWriter, r *http.Request) {
+	log.Println("[jira] → Received search issues request (POST)")
+
+	req := SearchRequest{MaxResults: 50}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[jira] ✗ Failed to decode request: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusBadRequest, "Invalid request body")
+		return
 	}
 
+	h.searchIssues(w, r, &req)
+}
+
+// searchIssues runs a JQL search for both the GET and POST forms of /search
+func (h *Handler) searchIssues(w http.ResponseWriter, r *http.Request, req *SearchRequest) {
+	sessionID := session.FromContext(r.Context())
+	jql := req.JQL
+	maxResults := req.MaxResults
+	startAt := req.StartAt
+
 	// Parse JQL (simplified - support basic filters)
 	projectKey := ""
 	issueType := ""
@@ -589,39 +1152,57 @@ func (h *Handler) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Search issues
-	dbIssues, err := h.queries.SearchJiraIssues(context.Background(), database.SearchJiraIssuesParams{
-		SessionID:  sessionID,
-		Column2:    projectKey,
-		ProjectKey: projectKey,
-		Column4:    issueType,
-		IssueType:  issueType,
-		Column6:    summary,
-		Column7:    sql.NullString{String: summary, Valid: summary != ""},
-		Column8:    assignee,
-		Assignee:   sql.NullString{String: assignee, Valid: assignee != ""},
-		Column10:   status,
-		Status:     status,
-		Limit:      int64(maxResults + startAt),
-	})
+	dbIssues, err := session.ListThrough(r.Context(), "jira_issues", func(candidate string) ([]database.SearchJiraIssuesRow, error) {
+		return h.queries.SearchJiraIssues(context.Background(), database.SearchJiraIssuesParams{
+			SessionID:  candidate,
+			Column2:    projectKey,
+			ProjectKey: projectKey,
+			Column4:    issueType,
+			IssueType:  issueType,
+			Column6:    summary,
+			Column7:    sql.NullString{String: summary, Valid: summary != ""},
+			Column8:    assignee,
+			Assignee:   sql.NullString{String: assignee, Valid: assignee != ""},
+			Column10:   status,
+			Status:     status,
+			Limit:      int64(maxResults + startAt),
+		})
+	}, func(candidate string) ([]database.SearchJiraIssuesRow, error) {
+		return h.projectIssues(candidate, "")
+	}, func(item database.SearchJiraIssuesRow) string { return item.Key })
 
 	if err != nil {
 		log.Printf("[jira] ✗ Failed to search issues: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
 		return
 	}
+	// Merge the parent's issues into the newest-first results
+	sort.SliceStable(dbIssues, func(i, j int) bool { return dbIssues[i].CreatedAt > dbIssues[j].CreatedAt })
 
-	// Apply pagination
+	// Apply pagination. A page cut short by the list cap still reports the full total, so clients
+	// page on with startAt.
+	pageSize, clamped := listcap.Clamp(maxResults)
 	total := len(dbIssues)
 	if startAt >= total {
 		dbIssues = []database.SearchJiraIssuesRow{}
 	} else {
-		end := startAt + maxResults
+		end := startAt + pageSize
 		if end > total {
 			end = total
 		}
+		if clamped && end < total {
+			listcap.MarkTruncated(w)
+		}
 		dbIssues = dbIssues[startAt:end]
 	}
 
+	customByKey, err := h.listCustomFields(sessionID)
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to list custom fields: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
 	// Build response
 	issues := make([]Issue, 0, len(dbIssues))
 	for i := range dbIssues {
@@ -637,9 +1218,7 @@ func (h *Handler) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 				},
 				Summary:     dbIssues[i].Summary,
 				Description: dbIssues[i].Description.String,
-				Status: &Status{
-					Name: dbIssues[i].Status,
-				},
+				Status:      newStatus(dbIssues[i].Status),
 			},
 		}
 		if dbIssues[i].Assignee.Valid {
@@ -647,13 +1226,15 @@ func (h *Handler) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 				Name: dbIssues[i].Assignee.String,
 			}
 		}
+		issue.Fields.custom = customByKey[dbIssues[i].Key]
+		issue.Fields.only = selectedFields(req.Fields)
 		issues = append(issues, issue)
 	}
 
 	response := SearchResults{
 		Issues:     issues,
 		StartAt:    startAt,
-		MaxResults: maxResults,
+		MaxResults: pageSize,
 		Total:      total,
 	}
 
@@ -664,15 +1245,129 @@ func (h *Handler) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 
 // Helper functions
 
-func generateID() string {
+// saveCustomFields merges custom field values into those already stored for an issue
+func (h *Handler) saveCustomFields(sessionID, issueKey string, custom map[string]json.RawMessage) error {
+	if len(custom) == 0 {
+		return nil
+	}
+
+	stored, err := h.loadCustomFields(sessionID, issueKey)
+	if err != nil {
+		return err
+	}
+	for name, value := range custom {
+		stored[name] = value
+	}
+
+	raw, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return h.queries.UpsertJiraIssueCustomFields(context.Background(), database.UpsertJiraIssueCustomFieldsParams{
+		SessionID: sessionID,
+		IssueKey:  issueKey,
+		Fields:    string(raw),
+	})
+}
+
+// loadCustomFields returns the custom field values stored for an issue
+func (h *Handler) loadCustomFields(sessionID, issueKey string) (map[string]json.RawMessage, error) {
+	raw, err := h.queries.GetJiraIssueCustomFields(context.Background(), database.GetJiraIssueCustomFieldsParams{
+		SessionID: sessionID,
+		IssueKey:  issueKey,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return map[string]json.RawMessage{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var custom map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &custom); err != nil {
+		return nil, err
+	}
+	return custom, nil
+}
+
+// listCustomFields returns the custom field values of every issue in the session, keyed by issue
+// key. Issues inherited from the parent session keep the parent's values.
+func (h *Handler) listCustomFields(sessionID string) (map[string]map[string]json.RawMessage, error) {
+	byKey := make(map[string]map[string]json.RawMessage)
+	for _, candidate := range []string{sessionID, session.Parent(sessionID)} {
+		if candidate == "" {
+			continue
+		}
+		rows, err := h.queries.ListJiraIssueCustomFields(context.Background(), candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range rows {
+			if _, ok := byKey[rows[i].IssueKey]; ok {
+				continue
+			}
+			var custom map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(rows[i].Fields), &custom); err != nil {
+				return nil, err
+			}
+			byKey[rows[i].IssueKey] = custom
+		}
+	}
+	return byKey, nil
+}
+
+// selectedFields turns a search fields list into the set of fields to return. It returns nil,
+// meaning every field, when the list is empty or asks for all of them.
+func selectedFields(fields []string) map[string]bool {
+	if len(fields) == 0 {
+		return nil
+	}
+	only := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "*all" || field == "*navigable" {
+			return nil
+		}
+		if field != "" {
+			only[field] = true
+		}
+	}
+	return only
+}
+
+func generateID(sessionID string) string {
 	b := make([]byte, 8)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
 
 func (h *Handler) generateIssueKey(sessionID, projectKey string) string {
-	// Count existing issues in project to get next number
-	dbIssues, err := h.queries.SearchJiraIssues(context.Background(), database.SearchJiraIssuesParams{
+	// Number above every key in the project, including the parent's, so a child session's
+	// new issues never shadow the ones it inherits
+	issueNum := 1
+	for _, candidate := range []string{sessionID, session.Parent(sessionID)} {
+		if candidate == "" {
+			continue
+		}
+		dbIssues, err := h.projectIssues(candidate, projectKey)
+		if err != nil {
+			continue
+		}
+		for i := range dbIssues {
+			n, err := strconv.Atoi(strings.TrimPrefix(dbIssues[i].Key, projectKey+"-"))
+			if err == nil && n >= issueNum {
+				issueNum = n + 1
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s-%d", projectKey, issueNum)
+}
+
+// projectIssues returns a session's issues in a project, or in every project for "", unfiltered
+func (h *Handler) projectIssues(sessionID, projectKey string) ([]database.SearchJiraIssuesRow, error) {
+	return h.queries.SearchJiraIssues(context.Background(), database.SearchJiraIssuesParams{
 		SessionID:  sessionID,
 		Column2:    projectKey,
 		ProjectKey: projectKey,
@@ -686,13 +1381,65 @@ func (h *Handler) generateIssueKey(sessionID, projectKey string) string {
 		Status:     "",
 		Limit:      10000,
 	})
+}
 
-	issueNum := 1
-	if err == nil {
-		issueNum = len(dbIssues) + 1
+// hideInheritedProject hides a project of the parent session, together with its issues, from
+// the child session deleting it. It reports whether the child could still see the project.
+func (h *Handler) hideInheritedProject(ctx context.Context, projectKey string) (bool, error) {
+	parentID := session.ParentFromContext(ctx)
+	if parentID == "" {
+		return false, nil
+	}
+	// The child's own copy is already gone, so anything found is the parent's
+	_, err := session.ReadThrough(ctx, "jira_projects", projectKey, func(candidate string) (database.GetJiraProjectByKeyRow, error) {
+		return h.queries.GetJiraProjectByKey(context.Background(), database.GetJiraProjectByKeyParams{
+			Key:       projectKey,
+			SessionID: candidate,
+		})
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	return fmt.Sprintf("%s-%d", projectKey, issueNum)
+	dbIssues, err := h.projectIssues(parentID, projectKey)
+	if err != nil {
+		return false, err
+	}
+	for i := range dbIssues {
+		if err := session.Hide(ctx, "jira_issues", dbIssues[i].Key); err != nil {
+			return false, err
+		}
+	}
+	return true, session.Hide(ctx, "jira_projects", projectKey)
+}
+
+// issueFieldMeta returns the minimal field set supported by the simulator.
+// Summary is only required when creating an issue.
+func issueFieldMeta(creating bool) map[string]FieldMeta {
+	return map[string]FieldMeta{
+		"summary": {
+			Required:   creating,
+			Schema:     FieldSchema{Type: "string", System: "summary"},
+			Name:       "Summary",
+			Key:        "summary",
+			Operations: []string{"set"},
+		},
+		"description": {
+			Schema:     FieldSchema{Type: "string", System: "description"},
+			Name:       "Description",
+			Key:        "description",
+			Operations: []string{"set"},
+		},
+		"assignee": {
+			Schema:     FieldSchema{Type: "user", System: "assignee"},
+			Name:       "Assignee",
+			Key:        "assignee",
+			Operations: []string{"set"},
+		},
+	}
 }
 
 func extractIssueKey(path string) string {
@@ -717,6 +1464,117 @@ func extractValue(jqlPart string) string {
 	return ""
 }
 
+// transitionSources lists the statuses each default workflow transition may start from.
+// Transitions not listed here are allowed from any status.
+var transitionSources = map[string][]string{
+	"Start Progress": {"To Do"},
+	"Stop Progress":  {"In Progress"},
+	"Done":           {"To Do", "In Progress", "In Review"},
+	"Reopen":         {"Done"},
+	"In Review":      {"In Progress"},
+}
+
+// transitionAllowed reports whether a transition may be executed from the given status
+func transitionAllowed(name, fromStatus string) bool {
+	sources, ok := transitionSources[name]
+	if !ok {
+		return true
+	}
+	for _, source := range sources {
+		if strings.EqualFold(source, fromStatus) {
+			return true
+		}
+	}
+	return false
+}
+
+// availableTransitions returns the session's transitions that are legal from the given status
+func (h *Handler) availableTransitions(sessionID, fromStatus string) ([]Transition, error) {
+	dbTransitions, err := h.queries.ListJiraTransitions(context.Background(), sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]Transition, 0, len(dbTransitions))
+	for _, t := range dbTransitions {
+		if !transitionAllowed(t.Name, fromStatus) {
+			continue
+		}
+		transitions = append(transitions, Transition{
+			ID:   t.ID,
+			Name: t.Name,
+			To:   newStatus(t.ToStatus),
+		})
+	}
+	return transitions, nil
+}
+
+// handleListStatuses lists the statuses of the session's workflow with their categories
+func (h *Handler) handleListStatuses(w http.ResponseWriter, r *http.Request) {
+	log.Println("[jira] → Received list statuses request")
+
+	sessionID := session.FromContext(r.Context())
+
+	// Initialize default transitions if needed
+	h.initializeDefaultTransitions(sessionID)
+
+	dbTransitions, err := h.queries.ListJiraTransitions(context.Background(), sessionID)
+	if err != nil {
+		log.Printf("[jira] ✗ Failed to list transitions: %v", err)
+		apierror.Write(w, apierror.Jira, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	// New issues start in "To Do"; every other status is reached through a transition
+	statuses := []*Status{newStatus("To Do")}
+	seen := map[string]bool{"To Do": true}
+	for _, t := range dbTransitions {
+		if seen[t.ToStatus] {
+			continue
+		}
+		seen[t.ToStatus] = true
+		statuses = append(statuses, newStatus(t.ToStatus))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(statuses)
+	log.Printf("[jira] ✓ Returned %d statuses", len(statuses))
+}
+
+// newStatus returns a status with the category its name implies
+func newStatus(name string) *Status {
+	return &Status{
+		Name:           name,
+		StatusCategory: statusCategoryOf(name),
+	}
+}
+
+// statusCategoryOf derives a status's category from its name. The simulator keeps no
+// per-status configuration, so unfamiliar names count as in progress.
+func statusCategoryOf(name string) *StatusCategory {
+	var category StatusCategory
+	switch strings.ToLower(name) {
+	case "to do", "open", "backlog", "reopened", "selected for development":
+		category = statusCategoryToDo
+	case "done", "closed", "resolved":
+		category = statusCategoryDone
+	default:
+		category = statusCategoryInProgress
+	}
+	return &category
+}
+
+// expandsTransitions reports whether an expand parameter such as "renderedFields,transitions"
+// asks for transitions
+func expandsTransitions(expand string) bool {
+	for _, item := range strings.Split(expand, ",") {
+		if strings.TrimSpace(item) == "transitions" {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *Handler) initializeDefaultTransitions(sessionID string) {
 	// Check if transitions already exist
 	existing, err := h.queries.ListJiraTransitions(context.Background(), sessionID)
@@ -738,7 +1596,7 @@ func (h *Handler) initializeDefaultTransitions(sessionID string) {
 
 	for _, t := range defaultTransitions {
 		_ = h.queries.CreateJiraTransition(context.Background(), database.CreateJiraTransitionParams{
-			ID:        generateID(),
+			ID:        generateID(sessionID),
 			Name:      t.name,
 			ToStatus:  t.toStatus,
 			SessionID: sessionID,
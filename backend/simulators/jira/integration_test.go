@@ -2,6 +2,7 @@ package jira_test
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -264,6 +265,90 @@ func TestJiraSimulatorUpdateIssue(t *testing.T) {
 	})
 }
 
+func TestJiraSimulatorCustomFields(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "jira-test-session-custom-fields"
+
+	// Setup: Start simulator server with session middleware
+	mux := http.NewServeMux()
+	jiraHandler := session.Middleware(simulatorJira.NewHandler(queries))
+	mux.Handle("/jira/", http.StripPrefix("/jira", jiraHandler))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Create Jira client
+	transport := jira.BasicAuthTransport{
+		Username: "test@example.com",
+		Password: "test-token",
+		Transport: &sessionHTTPTransport{
+			sessionID: sessionID,
+		},
+	}
+	client, err := jira.NewClient(transport.Client(), server.URL+"/jira")
+	require.NoError(t, err, "Failed to create Jira client")
+
+	issue := jira.Issue{
+		Fields: &jira.IssueFields{
+			Project: jira.Project{
+				Key: "CF",
+			},
+			Type: jira.IssueType{
+				Name: "Bug",
+			},
+			Summary: "Checkout fails for EU customers",
+			Unknowns: map[string]interface{}{
+				"customfield_10001": "Payments",
+				"customfield_10002": 8.0,
+				"customfield_10003": map[string]interface{}{"value": "High"},
+			},
+		},
+	}
+	created, _, err := client.Issue.Create(&issue)
+	require.NoError(t, err, "Create should succeed")
+	assert.Equal(t, "Payments", created.Fields.Unknowns["customfield_10001"], "Create should echo custom fields")
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		retrieved, _, err := client.Issue.Get(created.Key, nil)
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "Checkout fails for EU customers", retrieved.Fields.Summary)
+		assert.Equal(t, "Payments", retrieved.Fields.Unknowns["customfield_10001"], "String custom field should round-trip")
+		assert.InDelta(t, 8.0, retrieved.Fields.Unknowns["customfield_10002"], 0, "Number custom field should round-trip")
+		assert.Equal(t, map[string]interface{}{"value": "High"}, retrieved.Fields.Unknowns["customfield_10003"], "Object custom field should round-trip")
+	})
+
+	t.Run("UpdateMergesFields", func(t *testing.T) {
+		_, err := client.Issue.UpdateIssue(created.Key, map[string]interface{}{
+			"fields": map[string]interface{}{
+				"customfield_10002": 13,
+				"customfield_10004": "v2.3",
+			},
+		})
+		require.NoError(t, err, "Update should succeed")
+
+		retrieved, _, err := client.Issue.Get(created.Key, nil)
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "Payments", retrieved.Fields.Unknowns["customfield_10001"], "Untouched custom fields should be kept")
+		assert.InDelta(t, 13.0, retrieved.Fields.Unknowns["customfield_10002"], 0, "Updated custom field should change")
+		assert.Equal(t, "v2.3", retrieved.Fields.Unknowns["customfield_10004"], "New custom field should be added")
+	})
+
+	t.Run("SearchIncludesFields", func(t *testing.T) {
+		issues, _, err := client.Issue.Search("project = CF", nil)
+		require.NoError(t, err, "Search should succeed")
+		require.Len(t, issues, 1)
+		assert.Equal(t, "Payments", issues[0].Fields.Unknowns["customfield_10001"], "Search results should include custom fields")
+
+		issues, _, err = client.Issue.Search("project = CF", &jira.SearchOptions{Fields: []string{"summary", "customfield_10004"}})
+		require.NoError(t, err, "Search should succeed")
+		require.Len(t, issues, 1)
+		assert.Equal(t, "v2.3", issues[0].Fields.Unknowns["customfield_10004"], "Requested custom field should be returned")
+		assert.NotContains(t, issues[0].Fields.Unknowns, "customfield_10001", "Unrequested custom field should be left out")
+	})
+}
+
 func TestJiraSimulatorAddComment(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -316,6 +401,56 @@ func TestJiraSimulatorAddComment(t *testing.T) {
 		assert.NotEmpty(t, addedComment.ID, "Comment ID should not be empty")
 		assert.Equal(t, "This is a test comment", addedComment.Body, "Comment body should match")
 	})
+
+	t.Run("ListCommentsPaginated", func(t *testing.T) {
+		listed, _, err := client.Issue.Create(&jira.Issue{
+			Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "COM"},
+				Type:    jira.IssueType{Name: "Task"},
+				Summary: "Issue for Comment Pages",
+			},
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		for _, body := range []string{"first", "second", "third"} {
+			_, _, err := client.Issue.AddComment(listed.Key, &jira.Comment{Body: body})
+			require.NoError(t, err, "AddComment should succeed")
+		}
+
+		type commentPage struct {
+			Comments   []jira.Comment `json:"comments"`
+			StartAt    int            `json:"startAt"`
+			MaxResults int            `json:"maxResults"`
+			Total      int            `json:"total"`
+		}
+		listPage := func(t *testing.T, startAt int) commentPage {
+			t.Helper()
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/comment?startAt=%d&maxResults=2", listed.Key, startAt), nil)
+			require.NoError(t, err, "Failed to build request")
+			var page commentPage
+			_, err = client.Do(req, &page)
+			require.NoError(t, err, "List comments should not return error")
+			return page
+		}
+
+		first := listPage(t, 0)
+		assert.Equal(t, 3, first.Total, "Total should count every comment")
+		assert.Equal(t, 2, first.MaxResults, "MaxResults should echo the page size")
+		require.Len(t, first.Comments, 2, "First page should hold two comments")
+		assert.Equal(t, "first", first.Comments[0].Body, "Comments should be oldest first")
+		assert.Equal(t, "second", first.Comments[1].Body, "Comments should be oldest first")
+
+		second := listPage(t, 2)
+		assert.Equal(t, 2, second.StartAt, "StartAt should be echoed")
+		require.Len(t, second.Comments, 1, "Second page should hold the remaining comment")
+		assert.Equal(t, "third", second.Comments[0].Body, "Last comment should be on the second page")
+
+		fetched, _, err := client.Issue.Get(listed.Key, nil)
+		require.NoError(t, err, "Get should succeed")
+		require.NotNil(t, fetched.Fields.Comments, "Issue should embed its comments")
+		require.Len(t, fetched.Fields.Comments.Comments, 3, "Embedded comments should include all three")
+		assert.Equal(t, "first", fetched.Fields.Comments.Comments[0].Body, "Embedded comments should be oldest first")
+	})
 }
 
 func TestJiraSimulatorSearchIssues(t *testing.T) {
@@ -386,6 +521,46 @@ func TestJiraSimulatorSearchIssues(t *testing.T) {
 		require.NoError(t, err, "Search should not return error")
 		assert.GreaterOrEqual(t, len(issues), 3, "Should find issues with 'Test' in summary")
 	})
+
+	t.Run("SearchWithPostBody", func(t *testing.T) {
+		type searchPage struct {
+			Issues []struct {
+				Key    string                 `json:"key"`
+				Fields map[string]interface{} `json:"fields"`
+			} `json:"issues"`
+			StartAt    int `json:"startAt"`
+			MaxResults int `json:"maxResults"`
+			Total      int `json:"total"`
+		}
+		search := func(t *testing.T, startAt int) searchPage {
+			t.Helper()
+			req, err := client.NewRequest(http.MethodPost, "rest/api/2/search", map[string]interface{}{
+				"jql":        "project = SEARCH",
+				"startAt":    startAt,
+				"maxResults": 2,
+				"fields":     []string{"summary", "status"},
+			})
+			require.NoError(t, err, "Failed to build request")
+			var page searchPage
+			_, err = client.Do(req, &page)
+			require.NoError(t, err, "POST search should not return error")
+			return page
+		}
+
+		first := search(t, 0)
+		assert.Equal(t, 2, first.MaxResults)
+		require.Len(t, first.Issues, 2, "First page should be full")
+		assert.Contains(t, first.Issues[0].Fields, "summary", "Requested fields should be returned")
+		assert.Contains(t, first.Issues[0].Fields, "status", "Requested fields should be returned")
+		assert.NotContains(t, first.Issues[0].Fields, "project", "Unrequested fields should be trimmed")
+
+		second := search(t, 2)
+		assert.Equal(t, 2, second.StartAt)
+		require.Len(t, second.Issues, 1, "Second page should hold the remaining issue")
+		for _, issue := range first.Issues {
+			assert.NotEqual(t, issue.Key, second.Issues[0].Key, "Pages should not overlap")
+		}
+	})
 }
 
 func TestJiraSimulatorTransitions(t *testing.T) {
@@ -467,6 +642,90 @@ func TestJiraSimulatorTransitions(t *testing.T) {
 		require.NoError(t, err, "Get should succeed")
 		assert.Equal(t, "In Progress", retrieved.Fields.Status.Name, "Status should be 'In Progress'")
 	})
+
+	t.Run("ExpandTransitions", func(t *testing.T) {
+		// Get issue with transitions expanded inline
+		retrieved, _, err := client.Issue.Get(created.Key, &jira.GetQueryOptions{Expand: "transitions"})
+		require.NoError(t, err, "Get with expand should succeed")
+		require.NotEmpty(t, retrieved.Transitions, "Should include transitions")
+
+		// Only transitions legal from "In Progress" should be offered
+		transitionNames := make(map[string]bool)
+		for i := range retrieved.Transitions {
+			transitionNames[retrieved.Transitions[i].Name] = true
+		}
+		assert.True(t, transitionNames["Stop Progress"], "Should offer 'Stop Progress'")
+		assert.False(t, transitionNames["Start Progress"], "Should not offer 'Start Progress'")
+
+		// Without expand, transitions are omitted
+		plain, _, err := client.Issue.Get(created.Key, nil)
+		require.NoError(t, err, "Get should succeed")
+		assert.Empty(t, plain.Transitions, "Should not include transitions without expand")
+	})
+
+	t.Run("StatusCategories", func(t *testing.T) {
+		// The issue was moved to "In Progress" above
+		retrieved, _, err := client.Issue.Get(created.Key, &jira.GetQueryOptions{Expand: "transitions"})
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, jira.StatusCategoryInProgress, retrieved.Fields.Status.StatusCategory.Key, "Should be in the indeterminate category")
+		assert.Equal(t, "In Progress", retrieved.Fields.Status.StatusCategory.Name)
+
+		// Transition targets carry categories too
+		for i := range retrieved.Transitions {
+			if retrieved.Transitions[i].To.Name == "To Do" {
+				assert.Equal(t, jira.StatusCategoryToDo, retrieved.Transitions[i].To.StatusCategory.Key)
+			}
+		}
+
+		statuses, _, err := client.Status.GetAllStatuses()
+		require.NoError(t, err, "GetAllStatuses should succeed")
+		categories := make(map[string]string)
+		for i := range statuses {
+			categories[statuses[i].Name] = statuses[i].StatusCategory.Key
+		}
+		assert.Equal(t, map[string]string{
+			"To Do":       jira.StatusCategoryToDo,
+			"In Progress": jira.StatusCategoryInProgress,
+			"In Review":   jira.StatusCategoryInProgress,
+			"Done":        jira.StatusCategoryComplete,
+		}, categories, "Each workflow status should be listed once with its category")
+	})
+
+	t.Run("IllegalTransitionRejected", func(t *testing.T) {
+		// Create a fresh issue in "To Do" and look up "Start Progress"
+		fresh, _, err := client.Issue.Create(&jira.Issue{
+			Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "TRANS"},
+				Type:    jira.IssueType{Name: "Task"},
+				Summary: "Issue for illegal transition",
+			},
+		})
+		require.NoError(t, err, "Create should succeed")
+
+		transitions, _, err := client.Issue.GetTransitions(fresh.Key)
+		require.NoError(t, err, "GetTransitions should succeed")
+		var startProgressID string
+		for i := range transitions {
+			if transitions[i].Name == "Start Progress" {
+				startProgressID = transitions[i].ID
+			}
+		}
+		require.NotEmpty(t, startProgressID, "Should find 'Start Progress' transition")
+
+		_, err = client.Issue.DoTransition(fresh.Key, startProgressID)
+		require.NoError(t, err, "First DoTransition should succeed")
+
+		// "Start Progress" is not legal from "In Progress"
+		resp, err := client.Issue.DoTransition(fresh.Key, startProgressID)
+		require.Error(t, err, "Illegal transition should be rejected")
+		require.NotNil(t, resp, "Response should be returned")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Should return 400")
+
+		// Status is unchanged
+		retrieved, _, err := client.Issue.Get(fresh.Key, nil)
+		require.NoError(t, err, "Get should succeed")
+		assert.Equal(t, "In Progress", retrieved.Fields.Status.Name, "Status should remain 'In Progress'")
+	})
 }
 
 func TestJiraSimulatorListProjects(t *testing.T) {
@@ -531,6 +790,169 @@ func TestJiraSimulatorListProjects(t *testing.T) {
 	})
 }
 
+func TestJiraSimulatorProjectLifecycle(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "jira-test-session-projects"
+
+	// Setup: Start simulator server with session middleware
+	mux := http.NewServeMux()
+	jiraHandler := session.Middleware(simulatorJira.NewHandler(queries))
+	mux.Handle("/jira/", http.StripPrefix("/jira", jiraHandler))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Create Jira client
+	transport := jira.BasicAuthTransport{
+		Username: "test@example.com",
+		Password: "test-token",
+		Transport: &sessionHTTPTransport{
+			sessionID: sessionID,
+		},
+	}
+	client, err := jira.NewClient(transport.Client(), server.URL+"/jira")
+	require.NoError(t, err, "Failed to create Jira client")
+
+	createProject := func(key, name string) (*jira.Project, *jira.Response, error) {
+		req, err := client.NewRequest(http.MethodPost, "rest/api/2/project", map[string]string{
+			"key":            key,
+			"name":           name,
+			"projectTypeKey": "software",
+		})
+		require.NoError(t, err, "NewRequest should succeed")
+		project := new(jira.Project)
+		resp, err := client.Do(req, project)
+		return project, resp, err
+	}
+
+	t.Run("CreateGetDelete", func(t *testing.T) {
+		created, resp, err := createProject("LIFE", "Lifecycle")
+		require.NoError(t, err, "Create project should succeed")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Create should return 201")
+		assert.Equal(t, "LIFE", created.Key, "Key should match")
+		assert.NotEmpty(t, created.ID, "ID should be assigned")
+
+		project, _, err := client.Project.Get("LIFE")
+		require.NoError(t, err, "Get project should succeed")
+		assert.Equal(t, created.ID, project.ID, "ID should match")
+		assert.Equal(t, "Lifecycle", project.Name, "Name should match")
+
+		// Duplicate keys are rejected
+		_, resp, err = createProject("LIFE", "Another")
+		require.Error(t, err, "Duplicate create should fail")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Duplicate create should return 400")
+
+		// Add an issue with a comment to check the delete cascades
+		issue, _, err := client.Issue.Create(&jira.Issue{
+			Fields: &jira.IssueFields{
+				Project: jira.Project{Key: "LIFE"},
+				Type:    jira.IssueType{Name: "Task"},
+				Summary: "Issue in a deleted project",
+			},
+		})
+		require.NoError(t, err, "Create issue should succeed")
+		_, _, err = client.Issue.AddComment(issue.Key, &jira.Comment{Body: "Soon gone"})
+		require.NoError(t, err, "Add comment should succeed")
+
+		req, err := client.NewRequest(http.MethodDelete, "rest/api/2/project/LIFE", nil)
+		require.NoError(t, err, "NewRequest should succeed")
+		resp, err = client.Do(req, nil)
+		require.NoError(t, err, "Delete project should succeed")
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Delete should return 204")
+
+		_, resp, err = client.Project.Get("LIFE")
+		require.Error(t, err, "Get after delete should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Get after delete should return 404")
+
+		_, resp, err = client.Issue.Get(issue.Key, nil)
+		require.Error(t, err, "Issue should be deleted with its project")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Get issue after delete should return 404")
+
+		// Deleting again is a 404
+		req, err = client.NewRequest(http.MethodDelete, "rest/api/2/project/LIFE", nil)
+		require.NoError(t, err, "NewRequest should succeed")
+		resp, err = client.Do(req, nil)
+		require.Error(t, err, "Second delete should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Second delete should return 404")
+	})
+}
+
+func TestJiraSimulatorMetadata(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "jira-test-session-meta"
+
+	// Setup: Start simulator server with session middleware
+	mux := http.NewServeMux()
+	jiraHandler := session.Middleware(simulatorJira.NewHandler(queries))
+	mux.Handle("/jira/", http.StripPrefix("/jira", jiraHandler))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Create Jira client
+	transport := jira.BasicAuthTransport{
+		Username: "test@example.com",
+		Password: "test-token",
+		Transport: &sessionHTTPTransport{
+			sessionID: sessionID,
+		},
+	}
+	client, err := jira.NewClient(transport.Client(), server.URL+"/jira")
+	require.NoError(t, err, "Failed to create Jira client")
+
+	// Create an issue, which creates its project
+	created, _, err := client.Issue.Create(&jira.Issue{
+		Fields: &jira.IssueFields{
+			Project: jira.Project{
+				Key:  "META",
+				Name: "Metadata Project",
+			},
+			Type: jira.IssueType{
+				Name: "Task",
+			},
+			Summary: "Metadata test issue",
+		},
+	})
+	require.NoError(t, err, "Create should succeed")
+
+	t.Run("CreateMetaListsProject", func(t *testing.T) {
+		meta, _, err := client.Issue.GetCreateMeta("")
+		require.NoError(t, err, "GetCreateMeta should not return error")
+
+		project := meta.GetProjectWithKey("META")
+		require.NotNil(t, project, "Should list the created project")
+		assert.Equal(t, "Metadata Project", project.Name, "Project name should match")
+		require.NotEmpty(t, project.IssueTypes, "Project should have issue types")
+
+		task := project.GetIssueTypeWithName("Task")
+		require.NotNil(t, task, "Should offer the Task issue type")
+		assert.Contains(t, task.Fields, "summary", "Should describe the summary field")
+		assert.Contains(t, task.Fields, "description", "Should describe the description field")
+		assert.Contains(t, task.Fields, "assignee", "Should describe the assignee field")
+	})
+
+	t.Run("CreateMetaFiltersByProjectKey", func(t *testing.T) {
+		meta, _, err := client.Issue.GetCreateMeta("OTHER")
+		require.NoError(t, err, "GetCreateMeta should not return error")
+		assert.Empty(t, meta.Projects, "Unknown project key should match nothing")
+	})
+
+	t.Run("EditMeta", func(t *testing.T) {
+		meta, _, err := client.Issue.GetEditMeta(&jira.Issue{Key: created.Key})
+		require.NoError(t, err, "GetEditMeta should not return error")
+		assert.Contains(t, meta.Fields, "summary", "Summary should be editable")
+		assert.Contains(t, meta.Fields, "assignee", "Assignee should be editable")
+
+		_, resp, err := client.Issue.GetEditMeta(&jira.Issue{Key: "META-999"})
+		require.Error(t, err, "Unknown issue should return error")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404")
+	})
+}
+
 func TestJiraSimulatorSessionIsolation(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -619,3 +1041,88 @@ func TestJiraSimulatorSessionIsolation(t *testing.T) {
 		assert.Error(t, err, "Session 2 should not see session 1 issue")
 	})
 }
+
+func TestJiraSimulatorChildSessionDeletesInheritedProject(t *testing.T) {
+	// Setup: Create test database; the session manager stores the child's tombstones
+	queries := setupTestDB(t)
+	session.NewManager(queries)
+
+	// Setup: Start simulator server with session middleware
+	mux := http.NewServeMux()
+	jiraHandler := session.Middleware(simulatorJira.NewHandler(queries))
+	mux.Handle("/jira/", http.StripPrefix("/jira", jiraHandler))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	newClient := func(sessionID string) *jira.Client {
+		transport := jira.BasicAuthTransport{
+			Username:  "test@example.com",
+			Password:  "test-token",
+			Transport: &sessionHTTPTransport{sessionID: sessionID},
+		}
+		client, err := jira.NewClient(transport.Client(), server.URL+"/jira")
+		require.NoError(t, err, "Failed to create Jira client")
+		return client
+	}
+
+	const (
+		parentID = "jira-test-session-baseline"
+		childID  = "jira-test-session-child"
+	)
+	session.SetParent(childID, parentID)
+	t.Cleanup(func() {
+		session.ClearParent(childID)
+	})
+
+	// Seed the parent with an issue, which creates its project
+	parent := newClient(parentID)
+	baseline, _, err := parent.Issue.Create(&jira.Issue{
+		Fields: &jira.IssueFields{
+			Project: jira.Project{Key: "BASE"},
+			Type:    jira.IssueType{Name: "Task"},
+			Summary: "Baseline issue",
+		},
+	})
+	require.NoError(t, err, "Create in parent should succeed")
+
+	child := newClient(childID)
+	_, _, err = child.Project.Get("BASE")
+	require.NoError(t, err, "Child should read the parent's project")
+
+	req, err := child.NewRequest(http.MethodDelete, "rest/api/2/project/BASE", nil)
+	require.NoError(t, err, "NewRequest should succeed")
+	resp, err := child.Do(req, nil)
+	require.NoError(t, err, "Child should delete the parent's project")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Delete should return 204")
+
+	t.Run("HiddenFromChild", func(t *testing.T) {
+		_, resp, err := child.Project.Get("BASE")
+		require.Error(t, err, "Get after delete should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Get after delete should return 404")
+
+		projects, _, err := child.Project.GetList()
+		require.NoError(t, err, "List projects should succeed")
+		assert.Empty(t, *projects, "Deleted project should not be listed")
+
+		_, resp, err = child.Issue.Get(baseline.Key, nil)
+		require.Error(t, err, "The project's issues should be hidden too")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Get issue after delete should return 404")
+
+		issues, _, err := child.Issue.Search("project = BASE", nil)
+		require.NoError(t, err, "Search should succeed")
+		assert.Empty(t, issues, "Deleted project's issues should not be found")
+
+		req, err := child.NewRequest(http.MethodDelete, "rest/api/2/project/BASE", nil)
+		require.NoError(t, err, "NewRequest should succeed")
+		resp, err = child.Do(req, nil)
+		require.Error(t, err, "Second delete should fail")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Second delete should return 404")
+	})
+
+	t.Run("ParentUnchanged", func(t *testing.T) {
+		_, _, err := parent.Project.Get("BASE")
+		require.NoError(t, err, "Parent should keep its project")
+		_, _, err = parent.Issue.Get(baseline.Key, nil)
+		require.NoError(t, err, "Parent should keep its issue")
+	})
+}
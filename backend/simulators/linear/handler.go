@@ -2,7 +2,6 @@ package linear
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
@@ -13,6 +12,7 @@ import (
 	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -44,6 +44,12 @@ type User struct {
 	Email string `json:"email"`
 }
 
+type Organization struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URLKey string `json:"urlKey"`
+}
+
 type State struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -60,6 +66,20 @@ type Issue struct {
 	URL         string     `json:"url"`
 	Assignee    *User      `json:"assignee"`
 	State       *State     `json:"state"`
+	Project     *Project   `json:"project"`
+}
+
+type Project struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Teams       TeamConnection `json:"teams"`
+}
+
+type TeamConnection struct {
+	Nodes []Team `json:"nodes"`
 }
 
 // Handler implements the Linear simulator HTTP handler
@@ -74,6 +94,20 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountLinearSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"teams":    counts.Teams,
+		"users":    counts.Users,
+		"issues":   counts.Issues,
+		"projects": counts.Projects,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[linear] → %s %s", r.Method, r.URL.Path)
@@ -84,6 +118,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Simulator control: choose which seeded user the viewer query resolves to
+	if r.URL.Path == "/viewer" && r.Method == http.MethodPut {
+		h.handleSetViewer(w, r)
+		return
+	}
+
 	http.NotFound(w, r)
 }
 
@@ -109,22 +149,26 @@ func (h *Handler) handleGraphQL(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) handleQuery(w http.ResponseWriter, _ *http.Request, req GraphQLRequest, sessionID string) {
+func (h *Handler) handleQuery(w http.ResponseWriter, r *http.Request, req GraphQLRequest, sessionID string) {
 	query := strings.TrimSpace(req.Query)
 
 	switch {
 	case strings.Contains(query, "query Issue("):
-		h.handleGetIssue(w, req, sessionID)
+		h.handleGetIssue(r.Context(), w, req)
+	case strings.Contains(query, "query Project("):
+		h.handleGetProject(r.Context(), w, req)
+	case strings.Contains(query, "query Projects"):
+		h.handleListProjects(r.Context(), w, req)
 	case strings.Contains(query, "query Team("):
-		h.handleGetTeam(w, req, sessionID)
+		h.handleGetTeam(r.Context(), w, req)
 	case strings.Contains(query, "query TeamIssues("):
-		h.handleListIssuesByTeam(w, req, sessionID)
+		h.handleListIssuesByTeam(r.Context(), w, req)
 	case strings.Contains(query, "query Teams"):
-		h.handleListTeams(w, req, sessionID)
+		h.handleListTeams(r.Context(), w, req)
 	case strings.Contains(query, "query Users"):
 		h.handleListUsers(w, req, sessionID)
-	case strings.Contains(query, "query Me"):
-		h.handleGetViewer(w, req, sessionID)
+	case strings.Contains(query, "query Me"), strings.Contains(query, "viewer"), strings.Contains(query, "organization"):
+		h.handleRootFields(w, query, sessionID)
 	default:
 		log.Printf("[linear] ✗ Unknown query type")
 		h.sendError(w, "Unknown query type")
@@ -139,13 +183,15 @@ func (h *Handler) handleMutation(w http.ResponseWriter, _ *http.Request, req Gra
 		h.handleCreateIssue(w, req, sessionID)
 	case strings.Contains(query, "mutation IssueUpdate"):
 		h.handleUpdateIssue(w, req, sessionID)
+	case strings.Contains(query, "mutation ProjectCreate"):
+		h.handleCreateProject(w, req, sessionID)
 	default:
 		log.Printf("[linear] ✗ Unknown mutation type")
 		h.sendError(w, "Unknown mutation type")
 	}
 }
 
-func (h *Handler) handleGetIssue(w http.ResponseWriter, req GraphQLRequest, sessionID string) {
+func (h *Handler) handleGetIssue(ctx context.Context, w http.ResponseWriter, req GraphQLRequest) {
 	issueID, ok := req.Variables["id"].(string)
 	if !ok {
 		h.sendError(w, "Invalid or missing issue ID")
@@ -153,9 +199,15 @@ func (h *Handler) handleGetIssue(w http.ResponseWriter, req GraphQLRequest, sess
 	}
 	log.Printf("[linear] → Get issue: %s", issueID)
 
-	dbIssue, err := h.queries.GetLinearIssueByID(context.Background(), database.GetLinearIssueByIDParams{
-		ID:        issueID,
-		SessionID: sessionID,
+	// Fall through to the parent session; the issue's assignee, state and project live in
+	// whichever session holds it
+	var sessionID string
+	dbIssue, err := session.ReadThrough(ctx, "linear_issues", issueID, func(candidate string) (database.GetLinearIssueByIDRow, error) {
+		sessionID = candidate
+		return h.queries.GetLinearIssueByID(context.Background(), database.GetLinearIssueByIDParams{
+			ID:        issueID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[linear] ✗ Issue not found: %v", err)
@@ -172,7 +224,7 @@ func (h *Handler) handleGetIssue(w http.ResponseWriter, req GraphQLRequest, sess
 	log.Printf("[linear] ✓ Returned issue: %s", issueID)
 }
 
-func (h *Handler) handleGetTeam(w http.ResponseWriter, req GraphQLRequest, sessionID string) {
+func (h *Handler) handleGetTeam(ctx context.Context, w http.ResponseWriter, req GraphQLRequest) {
 	teamID, ok := req.Variables["id"].(string)
 	if !ok {
 		h.sendError(w, "Invalid or missing team ID")
@@ -180,9 +232,11 @@ func (h *Handler) handleGetTeam(w http.ResponseWriter, req GraphQLRequest, sessi
 	}
 	log.Printf("[linear] → Get team: %s", teamID)
 
-	dbTeam, err := h.queries.GetLinearTeamByID(context.Background(), database.GetLinearTeamByIDParams{
-		ID:        teamID,
-		SessionID: sessionID,
+	dbTeam, err := session.ReadThrough(ctx, "linear_teams", teamID, func(candidate string) (database.GetLinearTeamByIDRow, error) {
+		return h.queries.GetLinearTeamByID(context.Background(), database.GetLinearTeamByIDParams{
+			ID:        teamID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[linear] ✗ Team not found: %v", err)
@@ -203,7 +257,7 @@ func (h *Handler) handleGetTeam(w http.ResponseWriter, req GraphQLRequest, sessi
 	log.Printf("[linear] ✓ Returned team: %s", teamID)
 }
 
-func (h *Handler) handleListIssuesByTeam(w http.ResponseWriter, req GraphQLRequest, sessionID string) {
+func (h *Handler) handleListIssuesByTeam(ctx context.Context, w http.ResponseWriter, req GraphQLRequest) {
 	teamID, ok := req.Variables["teamId"].(string)
 	if !ok {
 		h.sendError(w, "Invalid or missing team ID")
@@ -211,36 +265,52 @@ func (h *Handler) handleListIssuesByTeam(w http.ResponseWriter, req GraphQLReque
 	}
 	log.Printf("[linear] → List issues for team: %s", teamID)
 
-	dbIssues, err := h.queries.ListLinearIssuesByTeam(context.Background(), database.ListLinearIssuesByTeamParams{
-		TeamID:    teamID,
-		SessionID: sessionID,
-	})
+	// Issues inherited from the parent session are resolved against the parent
+	issues, err := session.ListThrough(ctx, "linear_issues", func(candidate string) ([]Issue, error) {
+		dbIssues, err := h.queries.ListLinearIssuesByTeam(context.Background(), database.ListLinearIssuesByTeamParams{
+			TeamID:    teamID,
+			SessionID: candidate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		issues := make([]Issue, 0, len(dbIssues))
+		for i := range dbIssues {
+			issues = append(issues, h.convertIssueFromListByTeamRow(dbIssues[i], candidate))
+		}
+		return issues, nil
+	}, func(candidate string) ([]Issue, error) {
+		dbIssues, err := h.queries.ListLinearIssues(context.Background(), candidate)
+		if err != nil {
+			return nil, err
+		}
+		issues := make([]Issue, 0, len(dbIssues))
+		for i := range dbIssues {
+			issues = append(issues, h.convertIssueFromListByTeamRow(database.ListLinearIssuesByTeamRow(dbIssues[i]), candidate))
+		}
+		return issues, nil
+	}, func(issue Issue) string { return issue.ID })
 	if err != nil {
 		log.Printf("[linear] ✗ Failed to list issues: %v", err)
 		h.sendError(w, "Failed to list issues")
 		return
 	}
 
-	issues := make([]Issue, 0, len(dbIssues))
-	for i := range dbIssues {
-		issues = append(issues, h.convertIssueFromListByTeamRow(dbIssues[i], sessionID))
-	}
-
 	response := map[string]interface{}{
 		"team": map[string]interface{}{
-			"issues": map[string]interface{}{
-				"nodes": issues,
-			},
+			"issues": connection(w, req, issues, func(issue Issue) string { return issue.ID }),
 		},
 	}
 	h.sendSuccess(w, response)
 	log.Printf("[linear] ✓ Listed %d issues", len(issues))
 }
 
-func (h *Handler) handleListTeams(w http.ResponseWriter, _ GraphQLRequest, sessionID string) {
+func (h *Handler) handleListTeams(ctx context.Context, w http.ResponseWriter, req GraphQLRequest) {
 	log.Printf("[linear] → List teams")
 
-	dbTeams, err := h.queries.ListLinearTeams(context.Background(), sessionID)
+	dbTeams, err := session.ListThrough(ctx, "linear_teams", func(candidate string) ([]database.ListLinearTeamsRow, error) {
+		return h.queries.ListLinearTeams(context.Background(), candidate)
+	}, nil, func(team database.ListLinearTeamsRow) string { return team.ID })
 	if err != nil {
 		log.Printf("[linear] ✗ Failed to list teams: %v", err)
 		h.sendError(w, "Failed to list teams")
@@ -257,15 +327,13 @@ func (h *Handler) handleListTeams(w http.ResponseWriter, _ GraphQLRequest, sessi
 	}
 
 	response := map[string]interface{}{
-		"teams": map[string]interface{}{
-			"nodes": teams,
-		},
+		"teams": connection(w, req, teams, func(team Team) string { return team.ID }),
 	}
 	h.sendSuccess(w, response)
 	log.Printf("[linear] ✓ Listed %d teams", len(teams))
 }
 
-func (h *Handler) handleListUsers(w http.ResponseWriter, _ GraphQLRequest, sessionID string) {
+func (h *Handler) handleListUsers(w http.ResponseWriter, req GraphQLRequest, sessionID string) {
 	log.Printf("[linear] → List users")
 
 	dbUsers, err := h.queries.ListLinearUsers(context.Background(), sessionID)
@@ -285,36 +353,118 @@ func (h *Handler) handleListUsers(w http.ResponseWriter, _ GraphQLRequest, sessi
 	}
 
 	response := map[string]interface{}{
-		"users": map[string]interface{}{
-			"nodes": users,
-		},
+		"users": connection(w, req, users, func(user User) string { return user.ID }),
 	}
 	h.sendSuccess(w, response)
 	log.Printf("[linear] ✓ Listed %d users", len(users))
 }
 
-func (h *Handler) handleGetViewer(w http.ResponseWriter, _ GraphQLRequest, sessionID string) {
+// handleRootFields resolves every top-level field the query selects, so clients can fetch the
+// viewer and organization together on init
+func (h *Handler) handleRootFields(w http.ResponseWriter, query, sessionID string) {
+	data := make(map[string]interface{})
+	for _, field := range topLevelFields(query) {
+		var value interface{}
+		var err error
+		var message string
+		switch field.name {
+		case "viewer":
+			value, err = h.resolveViewer(sessionID)
+			message = "No users found"
+		case "organization":
+			value, err = h.resolveOrganization(sessionID)
+			message = "Organization not found"
+		default:
+			log.Printf("[linear] ✗ Unknown field: %s", field.name)
+			h.sendError(w, fmt.Sprintf("Cannot query field %q on type \"Query\"", field.name))
+			return
+		}
+		if err != nil {
+			log.Printf("[linear] ✗ Failed to resolve %s: %v", field.name, err)
+			h.sendError(w, message)
+			return
+		}
+		data[field.key] = value
+	}
+	h.sendSuccess(w, data)
+}
+
+func (h *Handler) resolveViewer(sessionID string) (User, error) {
 	log.Printf("[linear] → Get viewer")
 
-	// Return the first user as the viewer (simulating authenticated user)
-	dbUsers, err := h.queries.ListLinearUsers(context.Background(), sessionID)
-	if err != nil || len(dbUsers) == 0 {
-		log.Printf("[linear] ✗ No users found")
-		h.sendError(w, "No users found")
+	// Return the user marked as viewer, falling back to the first seeded user
+	dbUser, err := h.queries.GetLinearViewer(context.Background(), sessionID)
+	if err != nil {
+		return User{}, err
+	}
+
+	log.Printf("[linear] ✓ Returned viewer: %s", dbUser.ID)
+	return User{
+		ID:    dbUser.ID,
+		Name:  dbUser.Name,
+		Email: dbUser.Email,
+	}, nil
+}
+
+func (h *Handler) resolveOrganization(sessionID string) (Organization, error) {
+	log.Printf("[linear] → Get organization")
+
+	dbOrg, err := h.queries.GetLinearOrganization(context.Background(), sessionID)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	log.Printf("[linear] ✓ Returned organization: %s", dbOrg.ID)
+	return Organization{
+		ID:     dbOrg.ID,
+		Name:   dbOrg.Name,
+		URLKey: dbOrg.UrlKey,
+	}, nil
+}
+
+// SetViewerRequest is the body of PUT /viewer
+type SetViewerRequest struct {
+	ID string `json:"id"`
+}
+
+// handleSetViewer marks one of the session's users as the viewer
+func (h *Handler) handleSetViewer(w http.ResponseWriter, r *http.Request) {
+	var req SetViewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		h.sendError(w, "Invalid or missing user ID")
 		return
 	}
+	log.Printf("[linear] → Set viewer: %s", req.ID)
 
-	user := User{
-		ID:    dbUsers[0].ID,
-		Name:  dbUsers[0].Name,
-		Email: dbUsers[0].Email,
+	sessionID := session.FromContext(r.Context())
+	dbUser, err := h.queries.GetLinearUserByID(context.Background(), database.GetLinearUserByIDParams{
+		ID:        req.ID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[linear] ✗ User not found: %v", err)
+		h.sendError(w, "User not found")
+		return
 	}
 
-	response := map[string]interface{}{
-		"viewer": user,
+	err = h.queries.SetLinearViewer(context.Background(), database.SetLinearViewerParams{
+		ID:        req.ID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[linear] ✗ Failed to set viewer: %v", err)
+		h.sendError(w, "Failed to set viewer")
+		return
 	}
-	h.sendSuccess(w, response)
-	log.Printf("[linear] ✓ Returned viewer: %s", user.ID)
+
+	h.sendSuccess(w, map[string]interface{}{
+		"viewer": User{
+			ID:    dbUser.ID,
+			Name:  dbUser.Name,
+			Email: dbUser.Email,
+		},
+	})
+	log.Printf("[linear] ✓ Set viewer: %s", req.ID)
 }
 
 func (h *Handler) handleCreateIssue(w http.ResponseWriter, req GraphQLRequest, sessionID string) {
@@ -346,9 +496,22 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, req GraphQLRequest, s
 		stateID = sql.NullString{String: sid, Valid: true}
 	}
 
+	var projectID sql.NullString
+	if pid, ok := req.Variables["projectId"].(string); ok && pid != "" {
+		if _, err := h.queries.GetLinearProjectByID(context.Background(), database.GetLinearProjectByIDParams{
+			ID:        pid,
+			SessionID: sessionID,
+		}); err != nil {
+			log.Printf("[linear] ✗ Project not found: %v", err)
+			h.sendError(w, "Project not found")
+			return
+		}
+		projectID = sql.NullString{String: pid, Valid: true}
+	}
+
 	// Generate issue ID
-	issueID := generateID()
-	now := time.Now().UnixMilli()
+	issueID := generateID(sessionID)
+	now := session.Now(sessionID).UnixMilli()
 
 	// Get team to build URL
 	dbTeam, err := h.queries.GetLinearTeamByID(context.Background(), database.GetLinearTeamByIDParams{
@@ -372,6 +535,7 @@ func (h *Handler) handleCreateIssue(w http.ResponseWriter, req GraphQLRequest, s
 		Description: description,
 		AssigneeID:  assigneeID,
 		StateID:     stateID,
+		ProjectID:   projectID,
 		Url:         url,
 		SessionID:   sessionID,
 		CreatedAt:   now,
@@ -453,7 +617,20 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, req GraphQLRequest, s
 		stateID = existingIssue.StateID
 	}
 
-	now := time.Now().UnixMilli()
+	projectID := existingIssue.ProjectID
+	if p, ok := req.Variables["projectId"].(string); ok {
+		if _, err := h.queries.GetLinearProjectByID(context.Background(), database.GetLinearProjectByIDParams{
+			ID:        p,
+			SessionID: sessionID,
+		}); err != nil {
+			log.Printf("[linear] ✗ Project not found: %v", err)
+			h.sendError(w, "Project not found")
+			return
+		}
+		projectID = sql.NullString{String: p, Valid: true}
+	}
+
+	now := session.Now(sessionID).UnixMilli()
 
 	// Update issue
 	err = h.queries.UpdateLinearIssue(context.Background(), database.UpdateLinearIssueParams{
@@ -461,6 +638,7 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, req GraphQLRequest, s
 		Description: description,
 		AssigneeID:  assigneeID,
 		StateID:     stateID,
+		ProjectID:   projectID,
 		UpdatedAt:   now,
 		ID:          issueID,
 		SessionID:   sessionID,
@@ -494,6 +672,134 @@ func (h *Handler) handleUpdateIssue(w http.ResponseWriter, req GraphQLRequest, s
 	log.Printf("[linear] ✓ Updated issue: %s", issueID)
 }
 
+func (h *Handler) handleCreateProject(w http.ResponseWriter, req GraphQLRequest, sessionID string) {
+	log.Printf("[linear] → Create project")
+
+	name, ok := req.Variables["name"].(string)
+	if !ok || name == "" {
+		h.sendError(w, "Invalid or missing name")
+		return
+	}
+
+	rawTeamIDs, ok := req.Variables["teamIds"].([]interface{})
+	if !ok || len(rawTeamIDs) == 0 {
+		h.sendError(w, "Invalid or missing team IDs")
+		return
+	}
+
+	// Every team must exist in this session
+	teamIDs := make([]string, 0, len(rawTeamIDs))
+	for _, raw := range rawTeamIDs {
+		teamID, ok := raw.(string)
+		if !ok {
+			h.sendError(w, "Invalid team ID")
+			return
+		}
+		if _, err := h.queries.GetLinearTeamByID(context.Background(), database.GetLinearTeamByIDParams{
+			ID:        teamID,
+			SessionID: sessionID,
+		}); err != nil {
+			log.Printf("[linear] ✗ Team not found: %v", err)
+			h.sendError(w, "Team not found")
+			return
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+	teamIDsJSON, _ := json.Marshal(teamIDs)
+
+	var description sql.NullString
+	if desc, ok := req.Variables["description"].(string); ok && desc != "" {
+		description = sql.NullString{String: desc, Valid: true}
+	}
+
+	projectID := generateID(sessionID)
+	now := session.Now(sessionID).UnixMilli()
+
+	dbProject, err := h.queries.CreateLinearProject(context.Background(), database.CreateLinearProjectParams{
+		ID:          projectID,
+		Name:        name,
+		Description: description,
+		TeamIds:     string(teamIDsJSON),
+		SessionID:   sessionID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		log.Printf("[linear] ✗ Failed to create project: %v", err)
+		h.sendError(w, "Failed to create project")
+		return
+	}
+
+	project := h.convertProject(database.GetLinearProjectByIDRow(dbProject), sessionID)
+
+	response := map[string]interface{}{
+		"projectCreate": map[string]interface{}{
+			"success": true,
+			"project": project,
+		},
+	}
+	h.sendSuccess(w, response)
+	log.Printf("[linear] ✓ Created project: %s", projectID)
+}
+
+func (h *Handler) handleGetProject(ctx context.Context, w http.ResponseWriter, req GraphQLRequest) {
+	projectID, ok := req.Variables["id"].(string)
+	if !ok {
+		h.sendError(w, "Invalid or missing project ID")
+		return
+	}
+	log.Printf("[linear] → Get project: %s", projectID)
+
+	// Fall through to the parent session; the project's teams live in whichever session holds it
+	var sessionID string
+	dbProject, err := session.ReadThrough(ctx, "linear_projects", projectID, func(candidate string) (database.GetLinearProjectByIDRow, error) {
+		sessionID = candidate
+		return h.queries.GetLinearProjectByID(context.Background(), database.GetLinearProjectByIDParams{
+			ID:        projectID,
+			SessionID: candidate,
+		})
+	})
+	if err != nil {
+		log.Printf("[linear] ✗ Project not found: %v", err)
+		h.sendError(w, "Project not found")
+		return
+	}
+
+	response := map[string]interface{}{
+		"project": h.convertProject(dbProject, sessionID),
+	}
+	h.sendSuccess(w, response)
+	log.Printf("[linear] ✓ Returned project: %s", projectID)
+}
+
+func (h *Handler) handleListProjects(ctx context.Context, w http.ResponseWriter, req GraphQLRequest) {
+	log.Printf("[linear] → List projects")
+
+	// Projects inherited from the parent session are resolved against the parent
+	projects, err := session.ListThrough(ctx, "linear_projects", func(candidate string) ([]Project, error) {
+		dbProjects, err := h.queries.ListLinearProjects(context.Background(), candidate)
+		if err != nil {
+			return nil, err
+		}
+		projects := make([]Project, 0, len(dbProjects))
+		for _, dbProject := range dbProjects {
+			projects = append(projects, h.convertProject(database.GetLinearProjectByIDRow(dbProject), candidate))
+		}
+		return projects, nil
+	}, nil, func(project Project) string { return project.ID })
+	if err != nil {
+		log.Printf("[linear] ✗ Failed to list projects: %v", err)
+		h.sendError(w, "Failed to list projects")
+		return
+	}
+
+	response := map[string]interface{}{
+		"projects": connection(w, req, projects, func(project Project) string { return project.ID }),
+	}
+	h.sendSuccess(w, response)
+	log.Printf("[linear] ✓ Listed %d projects", len(projects))
+}
+
 // Helper functions
 
 func (h *Handler) convertIssueFromGetByIDRow(dbIssue database.GetLinearIssueByIDRow, sessionID string) Issue {
@@ -539,6 +845,8 @@ func (h *Handler) convertIssueFromGetByIDRow(dbIssue database.GetLinearIssueByID
 		}
 	}
 
+	issue.Project = h.resolveProject(dbIssue.ProjectID, sessionID)
+
 	return issue
 }
 
@@ -585,6 +893,8 @@ func (h *Handler) convertIssueFromListByTeamRow(dbIssue database.ListLinearIssue
 		}
 	}
 
+	issue.Project = h.resolveProject(dbIssue.ProjectID, sessionID)
+
 	return issue
 }
 
@@ -631,9 +941,55 @@ func (h *Handler) convertIssueFromCreateRow(dbIssue database.CreateLinearIssueRo
 		}
 	}
 
+	issue.Project = h.resolveProject(dbIssue.ProjectID, sessionID)
+
 	return issue
 }
 
+// resolveProject loads the project connection of an issue, if any
+func (h *Handler) resolveProject(projectID sql.NullString, sessionID string) *Project {
+	if !projectID.Valid {
+		return nil
+	}
+	dbProject, err := h.queries.GetLinearProjectByID(context.Background(), database.GetLinearProjectByIDParams{
+		ID:        projectID.String,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil
+	}
+	project := h.convertProject(dbProject, sessionID)
+	return &project
+}
+
+func (h *Handler) convertProject(dbProject database.GetLinearProjectByIDRow, sessionID string) Project {
+	project := Project{
+		ID:          dbProject.ID,
+		Name:        dbProject.Name,
+		Description: dbProject.Description.String,
+		CreatedAt:   time.UnixMilli(dbProject.CreatedAt),
+		UpdatedAt:   time.UnixMilli(dbProject.UpdatedAt),
+		Teams:       TeamConnection{Nodes: []Team{}},
+	}
+
+	var teamIDs []string
+	_ = json.Unmarshal([]byte(dbProject.TeamIds), &teamIDs)
+	for _, teamID := range teamIDs {
+		if dbTeam, err := h.queries.GetLinearTeamByID(context.Background(), database.GetLinearTeamByIDParams{
+			ID:        teamID,
+			SessionID: sessionID,
+		}); err == nil {
+			project.Teams.Nodes = append(project.Teams.Nodes, Team{
+				ID:   dbTeam.ID,
+				Name: dbTeam.Name,
+				Key:  dbTeam.Key,
+			})
+		}
+	}
+
+	return project
+}
+
 func (h *Handler) sendSuccess(w http.ResponseWriter, data interface{}) {
 	response := GraphQLResponse{
 		Data: data,
@@ -653,8 +1009,100 @@ func (h *Handler) sendError(w http.ResponseWriter, message string) {
 	_ = json.NewEncoder(w).Encode(response)
 }
 
-func generateID() string {
+// connection pages nodes by the first and after variables, using node IDs as cursors. Without
+// first the whole list is one page. The page size is held to the list cap, and a page it cuts
+// short is flagged while pageInfo still points clients at the rest.
+func connection[T any](w http.ResponseWriter, req GraphQLRequest, nodes []T, id func(T) string) map[string]interface{} {
+	start := 0
+	if after, ok := req.Variables["after"].(string); ok && after != "" {
+		for i := range nodes {
+			if id(nodes[i]) == after {
+				start = i + 1
+				break
+			}
+		}
+	}
+	pageSize := len(nodes) - start
+	if first, ok := req.Variables["first"].(float64); ok && first >= 0 {
+		pageSize = int(first)
+	}
+	pageSize, clamped := listcap.Clamp(pageSize)
+
+	end := min(start+pageSize, len(nodes))
+	hasNextPage := end < len(nodes)
+	if clamped && hasNextPage {
+		listcap.MarkTruncated(w)
+	}
+
+	pageInfo := map[string]interface{}{
+		"hasNextPage": hasNextPage,
+		"endCursor":   nil,
+	}
+	if end > start {
+		pageInfo["endCursor"] = id(nodes[end-1])
+	}
+	return map[string]interface{}{
+		"nodes":    nodes[start:end],
+		"pageInfo": pageInfo,
+	}
+}
+
+// rootField is a top-level field of a query, keyed in the response by its alias if it has one
+type rootField struct {
+	key  string
+	name string
+}
+
+// topLevelFields lists the fields selected directly under the operation, skipping arguments
+// and nested selections
+func topLevelFields(query string) []rootField {
+	start := strings.Index(query, "{")
+	if start < 0 {
+		return nil
+	}
+
+	var fields []rootField
+	var alias string
+	depth, parens := 0, 0
+	for i := start; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '(':
+			parens++
+		case c == ')':
+			parens--
+		case parens > 0:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case depth == 1 && isNameStart(c):
+			end := i + 1
+			for end < len(query) && (isNameStart(query[end]) || (query[end] >= '0' && query[end] <= '9')) {
+				end++
+			}
+			name := query[i:end]
+			i = end - 1
+			if strings.HasPrefix(strings.TrimLeft(query[end:], " \t\r\n"), ":") {
+				alias = name
+				continue
+			}
+			key := name
+			if alias != "" {
+				key, alias = alias, ""
+			}
+			fields = append(fields, rootField{key: key, name: name})
+		}
+	}
+	return fields
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func generateID(sessionID string) string {
 	b := make([]byte, 16)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
@@ -1,15 +1,19 @@
 package linear_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/machinebox/graphql"
 	"github.com/pressly/goose/v3"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 	"github.com/recreate-run/nova-simulators/internal/transport"
 	simulatorLinear "github.com/recreate-run/nova-simulators/simulators/linear"
@@ -406,6 +410,278 @@ func testGetUsers(t *testing.T, client *graphql.Client, userID string) {
 	assert.Equal(t, "test@example.com", response.Users.Nodes[0].Email, "User email should match")
 }
 
+func testGetViewer(t *testing.T, client *graphql.Client, queries *database.Queries, sessionID string) {
+	t.Helper()
+	ctx := context.Background()
+
+	// Seed a second user and mark it as the authenticated viewer
+	viewerID := "USER002_" + sessionID
+	err := queries.CreateLinearUser(ctx, database.CreateLinearUserParams{
+		ID:        viewerID,
+		Name:      "Viewer User",
+		Email:     "viewer@example.com",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err, "Failed to create viewer user")
+
+	// Mark it as the viewer through the simulator's control endpoint
+	setViewer, err := http.NewRequestWithContext(ctx, http.MethodPut, "https://api.linear.app/viewer",
+		strings.NewReader(`{"id":"`+viewerID+`"}`))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(setViewer)
+	require.NoError(t, err, "Failed to set viewer")
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Setting the viewer should succeed")
+
+	unknown, err := http.NewRequestWithContext(ctx, http.MethodPut, "https://api.linear.app/viewer",
+		strings.NewReader(`{"id":"missing-user"}`))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(unknown)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "An unknown user can't be the viewer")
+
+	query := `
+		query Viewer {
+			viewer {
+				id
+				name
+			}
+		}
+	`
+	req := graphql.NewRequest(query)
+
+	var response struct {
+		Viewer struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"viewer"`
+	}
+	err = client.Run(ctx, req, &response)
+
+	// Assertions
+	require.NoError(t, err, "Viewer query should not return error")
+	assert.Equal(t, viewerID, response.Viewer.ID, "Viewer ID should match configured viewer")
+	assert.Equal(t, "Viewer User", response.Viewer.Name, "Viewer name should match")
+}
+
+func testGetOrganization(t *testing.T, client *graphql.Client, queries *database.Queries, sessionID string) {
+	t.Helper()
+	ctx := context.Background()
+
+	orgID := "ORG001_" + sessionID
+	err := queries.CreateLinearOrganization(ctx, database.CreateLinearOrganizationParams{
+		ID:        orgID,
+		Name:      "Acme Inc",
+		UrlKey:    "acme",
+		SessionID: sessionID,
+	})
+	require.NoError(t, err, "Failed to create organization")
+
+	query := `
+		query Organization {
+			organization {
+				id
+				name
+				urlKey
+			}
+		}
+	`
+	req := graphql.NewRequest(query)
+
+	var response struct {
+		Organization struct {
+			ID     string `json:"id"`
+			Name   string `json:"name"`
+			URLKey string `json:"urlKey"`
+		} `json:"organization"`
+	}
+	err = client.Run(ctx, req, &response)
+
+	// Assertions
+	require.NoError(t, err, "Organization query should not return error")
+	assert.Equal(t, orgID, response.Organization.ID, "Organization ID should match")
+	assert.Equal(t, "Acme Inc", response.Organization.Name, "Organization name should match")
+	assert.Equal(t, "acme", response.Organization.URLKey, "Organization urlKey should match")
+}
+
+func testViewerAndOrganization(t *testing.T, client *graphql.Client) {
+	t.Helper()
+
+	// Clients bootstrap by selecting both root fields in one query
+	query := `
+		query Bootstrap {
+			viewer {
+				id
+				name
+			}
+			org: organization {
+				id
+				urlKey
+			}
+		}
+	`
+	req := graphql.NewRequest(query)
+
+	var response struct {
+		Viewer struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"viewer"`
+		Org struct {
+			ID     string `json:"id"`
+			URLKey string `json:"urlKey"`
+		} `json:"org"`
+	}
+	err := client.Run(context.Background(), req, &response)
+
+	// Assertions
+	require.NoError(t, err, "Combined query should not return error")
+	assert.Equal(t, "Viewer User", response.Viewer.Name, "Viewer should be resolved")
+	assert.Equal(t, "acme", response.Org.URLKey, "Aliased organization should be resolved")
+
+	err = client.Run(context.Background(), graphql.NewRequest(`query { viewer { id } teams { nodes { id } } }`), &struct{}{})
+	require.Error(t, err, "A field the simulator can't resolve alongside the viewer should fail")
+}
+
+func testProjects(t *testing.T, client *graphql.Client, teamID string) {
+	t.Helper()
+	ctx := context.Background()
+
+	// Create a project owned by the team
+	createProject := graphql.NewRequest(`
+		mutation ProjectCreate($name: String!, $description: String, $teamIds: [String!]!) {
+			projectCreate(input: { name: $name, description: $description, teamIds: $teamIds }) {
+				success
+				project { id name description teams { nodes { id } } }
+			}
+		}
+	`)
+	createProject.Var("name", "Q3 Roadmap")
+	createProject.Var("description", "Everything shipping this quarter")
+	createProject.Var("teamIds", []string{teamID})
+
+	var projectResponse struct {
+		ProjectCreate struct {
+			Success bool `json:"success"`
+			Project struct {
+				ID          string `json:"id"`
+				Name        string `json:"name"`
+				Description string `json:"description"`
+				Teams       struct {
+					Nodes []struct {
+						ID string `json:"id"`
+					} `json:"nodes"`
+				} `json:"teams"`
+			} `json:"project"`
+		} `json:"projectCreate"`
+	}
+	err := client.Run(ctx, createProject, &projectResponse)
+	require.NoError(t, err, "ProjectCreate should not return error")
+	require.True(t, projectResponse.ProjectCreate.Success, "Create should be successful")
+	projectID := projectResponse.ProjectCreate.Project.ID
+	require.NotEmpty(t, projectID, "Project ID should be set")
+	assert.Equal(t, "Q3 Roadmap", projectResponse.ProjectCreate.Project.Name, "Project name should match")
+	require.Len(t, projectResponse.ProjectCreate.Project.Teams.Nodes, 1, "Project should have 1 team")
+	assert.Equal(t, teamID, projectResponse.ProjectCreate.Project.Teams.Nodes[0].ID, "Project team should match")
+
+	// Create an issue inside the project
+	createIssue := graphql.NewRequest(`
+		mutation IssueCreate($teamId: String!, $title: String!, $projectId: String) {
+			issueCreate(input: { teamId: $teamId, title: $title, projectId: $projectId }) {
+				success
+				issue { id project { id name } }
+			}
+		}
+	`)
+	createIssue.Var("teamId", teamID)
+	createIssue.Var("title", "Launch dashboard")
+	createIssue.Var("projectId", projectID)
+
+	var issueResponse struct {
+		IssueCreate struct {
+			Issue struct {
+				ID      string `json:"id"`
+				Project *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"project"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	err = client.Run(ctx, createIssue, &issueResponse)
+	require.NoError(t, err, "IssueCreate should not return error")
+	require.NotNil(t, issueResponse.IssueCreate.Issue.Project, "Issue should resolve its project")
+	assert.Equal(t, projectID, issueResponse.IssueCreate.Issue.Project.ID, "Issue project should match")
+
+	// Query the issue back and resolve the project connection
+	getIssue := graphql.NewRequest(`
+		query Issue($id: String!) {
+			issue(id: $id) { id project { id name } }
+		}
+	`)
+	getIssue.Var("id", issueResponse.IssueCreate.Issue.ID)
+
+	var getResponse struct {
+		Issue struct {
+			Project *struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"project"`
+		} `json:"issue"`
+	}
+	err = client.Run(ctx, getIssue, &getResponse)
+	require.NoError(t, err, "GetIssue should not return error")
+	require.NotNil(t, getResponse.Issue.Project, "Queried issue should resolve its project")
+	assert.Equal(t, "Q3 Roadmap", getResponse.Issue.Project.Name, "Project name should match")
+
+	// Query the project and the project list
+	listProjects := graphql.NewRequest(`
+		query Projects {
+			projects { nodes { id name } }
+		}
+	`)
+	var listResponse struct {
+		Projects struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"nodes"`
+		} `json:"projects"`
+	}
+	err = client.Run(ctx, listProjects, &listResponse)
+	require.NoError(t, err, "Projects should not return error")
+	require.Len(t, listResponse.Projects.Nodes, 1, "Should have 1 project")
+	assert.Equal(t, projectID, listResponse.Projects.Nodes[0].ID, "Listed project should match")
+
+	getProject := graphql.NewRequest(`
+		query Project($id: String!) {
+			project(id: $id) { id name description }
+		}
+	`)
+	getProject.Var("id", projectID)
+	var getProjectResponse struct {
+		Project struct {
+			Description string `json:"description"`
+		} `json:"project"`
+	}
+	err = client.Run(ctx, getProject, &getProjectResponse)
+	require.NoError(t, err, "Project should not return error")
+	assert.Equal(t, "Everything shipping this quarter", getProjectResponse.Project.Description, "Description should match")
+
+	// Unknown projects are rejected
+	badIssue := graphql.NewRequest(`
+		mutation IssueCreate($teamId: String!, $title: String!, $projectId: String) {
+			issueCreate(input: { teamId: $teamId, title: $title, projectId: $projectId }) { success }
+		}
+	`)
+	badIssue.Var("teamId", teamID)
+	badIssue.Var("title", "Orphan")
+	badIssue.Var("projectId", "missing-project")
+	err = client.Run(ctx, badIssue, &struct{}{})
+	require.Error(t, err, "Unknown project should return error")
+}
+
 func TestLinearSimulatorIntegration(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -450,6 +726,22 @@ func TestLinearSimulatorIntegration(t *testing.T) {
 	t.Run("GetUsers", func(t *testing.T) {
 		testGetUsers(t, client, userID)
 	})
+
+	t.Run("GetViewer", func(t *testing.T) {
+		testGetViewer(t, client, queries, sessionID)
+	})
+
+	t.Run("GetOrganization", func(t *testing.T) {
+		testGetOrganization(t, client, queries, sessionID)
+	})
+
+	t.Run("ViewerAndOrganization", func(t *testing.T) {
+		testViewerAndOrganization(t, client)
+	})
+
+	t.Run("Projects", func(t *testing.T) {
+		testProjects(t, client, teamID)
+	})
 }
 
 func TestLinearSimulatorSessionIsolation(t *testing.T) {
@@ -555,3 +847,73 @@ func TestLinearSimulatorSessionIsolation(t *testing.T) {
 		assert.NotEqual(t, "Session 2 Issue", issue.Title, "Session 1 should not see session 2's issues")
 	}
 }
+
+func TestLinearSimulatorListCap(t *testing.T) {
+	// Setup: Create test database with three teams
+	queries := setupTestDB(t)
+	sessionID := "test-session-list-cap"
+	setupTestSession(t, queries, sessionID)
+	for _, key := range []string{"OPS", "WEB"} {
+		err := queries.CreateLinearTeam(context.Background(), database.CreateLinearTeamParams{
+			ID:        "TEAM_" + key + "_" + sessionID,
+			Name:      key,
+			Key:       key,
+			SessionID: sessionID,
+		})
+		require.NoError(t, err, "Failed to create team")
+	}
+
+	handler := session.Middleware(simulatorLinear.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	listcap.SetMax(2)
+	t.Cleanup(func() {
+		listcap.SetMax(0)
+	})
+
+	type teamsPage struct {
+		Data struct {
+			Teams struct {
+				Nodes []struct {
+					ID string `json:"id"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"teams"`
+		} `json:"data"`
+	}
+	list := func(variables map[string]interface{}) (*http.Response, teamsPage) {
+		body, err := json.Marshal(map[string]interface{}{
+			"query":     `query Teams($after: String) { teams(after: $after) { nodes { id } pageInfo { hasNextPage endCursor } } }`,
+			"variables": variables,
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/graphql", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-Session-ID", sessionID)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Teams query should succeed")
+		defer func() { _ = resp.Body.Close() }()
+		var page teamsPage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		return resp, page
+	}
+
+	resp, page := list(map[string]interface{}{})
+
+	// Assertions
+	teams := page.Data.Teams
+	assert.Len(t, teams.Nodes, 2, "Page should be cut to the cap")
+	assert.True(t, teams.PageInfo.HasNextPage, "Truncated page should report a next page")
+	assert.Equal(t, "true", resp.Header.Get(listcap.TruncatedHeader), "Truncated page should be flagged")
+
+	// Following the cursor continues after the truncated page
+	resp, next := list(map[string]interface{}{"after": teams.PageInfo.EndCursor})
+	assert.Len(t, next.Data.Teams.Nodes, 1, "Last page should hold the remaining team")
+	assert.NotEqual(t, teams.Nodes[0].ID, next.Data.Teams.Nodes[0].ID, "Next page should continue the listing")
+	assert.False(t, next.Data.Teams.PageInfo.HasNextPage, "Last page should not report a next page")
+	assert.Empty(t, resp.Header.Get(listcap.TruncatedHeader), "A page that reaches the end should not be flagged")
+}
@@ -1,18 +1,25 @@
 package outlook
 
 import (
+	"bytes"
 	"context"
-	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -42,15 +49,19 @@ type Message struct {
 	Body             *ItemBody    `json:"body,omitempty"`
 	From             *Recipient   `json:"from,omitempty"`
 	ToRecipients     []*Recipient `json:"toRecipients,omitempty"`
+	CcRecipients     []*Recipient `json:"ccRecipients,omitempty"`
 	IsRead           bool         `json:"isRead"`
 	ReceivedDateTime string       `json:"receivedDateTime,omitempty"`
+	ConversationID   string       `json:"conversationId,omitempty"`
 }
 
-// MessageListResponse represents the response from listing messages
+// MessageListResponse represents the response from listing messages. Delta queries set
+// DeltaLink on their last page.
 type MessageListResponse struct {
-	Value         []*Message `json:"value"`
-	NextLink      string     `json:"@odata.nextLink,omitempty"`
-	ODataContext  string     `json:"@odata.context"`
+	Value        []*Message `json:"value"`
+	NextLink     string     `json:"@odata.nextLink,omitempty"`
+	DeltaLink    string     `json:"@odata.deltaLink,omitempty"`
+	ODataContext string     `json:"@odata.context"`
 }
 
 // SendMailRequest represents the request body for sending email
@@ -59,6 +70,46 @@ type SendMailRequest struct {
 	SaveToSentItems bool     `json:"saveToSentItems,omitempty"`
 }
 
+// ReplyRequest represents the request body for reply, replyAll, and forward
+type ReplyRequest struct {
+	Comment      string       `json:"comment,omitempty"`
+	Message      *Message     `json:"message,omitempty"`
+	ToRecipients []*Recipient `json:"toRecipients,omitempty"`
+}
+
+// BatchRequestItem is one sub-request of a JSON batch
+type BatchRequestItem struct {
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// BatchRequest represents the request body for POST /$batch
+type BatchRequest struct {
+	Requests []BatchRequestItem `json:"requests"`
+}
+
+// BatchResponseItem is the result of one sub-request
+type BatchResponseItem struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// BatchResponse represents the response from POST /$batch
+type BatchResponse struct {
+	Responses []BatchResponseItem `json:"responses"`
+}
+
+// maxBatchRequests is Graph's limit on sub-requests per batch
+const maxBatchRequests = 20
+
+// defaultDeltaPageSize is how many changes a delta page holds without $top or odata.maxpagesize
+const defaultDeltaPageSize = 10
+
 // Handler implements the Outlook simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -71,10 +122,25 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	count, err := queries.CountOutlookSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"messages": count}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[outlook] → %s %s", r.Method, r.URL.Path)
 
+	// JSON batching: POST /v1.0/$batch
+	if (r.URL.Path == "/v1.0/$batch" || r.URL.Path == "/$batch") && r.Method == http.MethodPost {
+		h.handleBatch(w, r)
+		return
+	}
+
 	// Route Microsoft Graph API requests
 	if strings.HasPrefix(r.URL.Path, "/v1.0/me/") || strings.HasPrefix(r.URL.Path, "/me/") {
 		h.handleGraphAPI(w, r)
@@ -93,6 +159,14 @@ func (h *Handler) handleGraphAPI(w http.ResponseWriter, r *http.Request) {
 	switch {
 	case path == "sendMail" && r.Method == http.MethodPost:
 		h.handleSendMail(w, r)
+	case strings.HasPrefix(path, "messages/") && r.Method == http.MethodPost:
+		// Extract message ID and action from path: messages/{id}/{reply|replyAll|forward}
+		parts := strings.Split(path, "/")
+		if len(parts) == 3 && (parts[2] == "reply" || parts[2] == "replyAll" || parts[2] == "forward") {
+			h.handleRespondToMessage(w, r, parts[1], parts[2])
+		} else {
+			http.NotFound(w, r)
+		}
 	case strings.HasPrefix(path, "messages/") && r.Method == http.MethodGet:
 		// Extract message ID from path
 		parts := strings.Split(path, "/")
@@ -113,6 +187,14 @@ func (h *Handler) handleGraphAPI(w http.ResponseWriter, r *http.Request) {
 		}
 	case path == "messages" && r.Method == http.MethodGet:
 		h.handleListMessages(w, r)
+	case strings.HasPrefix(path, "mailFolders/") && r.Method == http.MethodGet:
+		// mailFolders/{folder}/messages/delta; every stored message is in the Inbox
+		parts := strings.Split(path, "/")
+		if len(parts) == 4 && strings.EqualFold(parts[1], "inbox") && parts[2] == "messages" && parts[3] == "delta" {
+			h.handleMessagesDelta(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
 	default:
 		http.NotFound(w, r)
 	}
@@ -145,10 +227,8 @@ func (h *Handler) handleSendMail(w http.ResponseWriter, r *http.Request) {
 		fromEmail = "me@example.com" // Default sender
 	}
 
-	toEmail := ""
-	if len(msg.ToRecipients) > 0 && msg.ToRecipients[0] != nil && msg.ToRecipients[0].EmailAddress != nil {
-		toEmail = msg.ToRecipients[0].EmailAddress.Address
-	}
+	toEmail := joinAddresses(addressesOf(msg.ToRecipients))
+	ccEmail := joinAddresses(addressesOf(msg.CcRecipients))
 
 	subject := msg.Subject
 	bodyContent := ""
@@ -161,24 +241,26 @@ func (h *Handler) handleSendMail(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Generate message ID
-	messageID := generateMessageID()
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Generate message ID; a new message starts its own conversation
+	messageID := generateMessageID(sessionID)
+
 	// Store message in database
-	receivedDateTime := time.Now().UTC().Format(time.RFC3339)
+	receivedDateTime := session.Now(sessionID).UTC().Format(time.RFC3339)
 
 	err := h.queries.CreateOutlookMessage(context.Background(), database.CreateOutlookMessageParams{
 		ID:               messageID,
 		FromEmail:        fromEmail,
 		ToEmail:          toEmail,
+		CcEmail:          ccEmail,
 		Subject:          subject,
 		BodyContent:      sql.NullString{String: bodyContent, Valid: bodyContent != ""},
 		BodyType:         bodyType,
 		IsRead:           0, // New sent messages are unread by default
 		ReceivedDatetime: receivedDateTime,
+		ConversationID:   messageID,
 		SessionID:        sessionID,
 	})
 
@@ -193,6 +275,100 @@ func (h *Handler) handleSendMail(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[outlook] ✓ Message sent: %s", messageID)
 }
 
+func (h *Handler) handleRespondToMessage(w http.ResponseWriter, r *http.Request, messageID, action string) {
+	log.Printf("[outlook] → Received %s request for ID: %s", action, messageID)
+
+	// Body is optional for reply and replyAll
+	var req ReplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("[outlook] ✗ Failed to decode request: %v", err)
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	original, err := h.queries.GetOutlookMessageByID(context.Background(), database.GetOutlookMessageByIDParams{
+		ID:        messageID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[outlook] ✗ Message not found: %v", err)
+		http.NotFound(w, r)
+		return
+	}
+
+	// The responding mailbox is whoever received the original
+	fromEmail := "me@example.com" // Default sender
+	if originalTo := splitAddresses(original.ToEmail); len(originalTo) > 0 {
+		fromEmail = originalTo[0]
+	}
+
+	var to, cc []string
+	var subject string
+	switch action {
+	case "forward":
+		recipients := req.ToRecipients
+		if len(recipients) == 0 && req.Message != nil {
+			recipients = req.Message.ToRecipients
+		}
+		to = addressesOf(recipients)
+		if len(to) == 0 {
+			log.Println("[outlook] ✗ Missing toRecipients for forward")
+			http.Error(w, "toRecipients is required", http.StatusBadRequest)
+			return
+		}
+		subject = prefixSubject("FW: ", original.Subject)
+	case "replyAll":
+		// Everyone on the original but the responding mailbox itself
+		to = without(append([]string{original.FromEmail}, splitAddresses(original.ToEmail)...), fromEmail)
+		cc = without(splitAddresses(original.CcEmail), fromEmail)
+		subject = prefixSubject("RE: ", original.Subject)
+	default:
+		to = []string{original.FromEmail}
+		subject = prefixSubject("RE: ", original.Subject)
+	}
+
+	// Quote the original body below the comment
+	bodyContent := req.Comment
+	if req.Message != nil && req.Message.Body != nil && req.Message.Body.Content != "" {
+		bodyContent = req.Message.Body.Content
+	}
+	if original.BodyContent.Valid && original.BodyContent.String != "" {
+		bodyContent = strings.TrimSpace(bodyContent + "\n\n" + original.BodyContent.String)
+	}
+
+	// Keep the response in the original's conversation
+	conversationID := conversationOrSelf(original.ConversationID, original.ID)
+
+	newMessageID := generateMessageID(sessionID)
+	receivedDateTime := session.Now(sessionID).UTC().Format(time.RFC3339)
+
+	err = h.queries.CreateOutlookMessage(context.Background(), database.CreateOutlookMessageParams{
+		ID:               newMessageID,
+		FromEmail:        fromEmail,
+		ToEmail:          joinAddresses(to),
+		CcEmail:          joinAddresses(cc),
+		Subject:          subject,
+		BodyContent:      sql.NullString{String: bodyContent, Valid: bodyContent != ""},
+		BodyType:         original.BodyType,
+		IsRead:           0,
+		ReceivedDatetime: receivedDateTime,
+		ConversationID:   conversationID,
+		SessionID:        sessionID,
+	})
+	if err != nil {
+		log.Printf("[outlook] ✗ Failed to store message: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Microsoft Graph reply/forward endpoints return 202 Accepted with no body
+	w.WriteHeader(http.StatusAccepted)
+	log.Printf("[outlook] ✓ Sent %s %s for message %s", action, newMessageID, messageID)
+}
+
 func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 	log.Println("[outlook] → Received list messages request")
 
@@ -244,6 +420,7 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 		for i := range searchResults {
 			messageList = append(messageList, searchRowToGraphMessage(searchResults[i]))
 		}
+		messageList = listcap.Truncate(w, messageList)
 
 		response := MessageListResponse{
 			Value:        messageList,
@@ -252,27 +429,37 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(response)
-		log.Printf("[outlook] ✓ Listed %d messages", len(searchResults))
+		log.Printf("[outlook] ✓ Listed %d messages", len(messageList))
 		return
 	}
 
 	// List all messages
-	listResults, err := h.queries.ListOutlookMessages(context.Background(), database.ListOutlookMessagesParams{
-		SessionID: sessionID,
-		Limit:     int64(top),
-	})
+	listResults, err := session.ListThrough(r.Context(), "outlook_messages", func(candidate string) ([]database.ListOutlookMessagesRow, error) {
+		return h.queries.ListOutlookMessages(context.Background(), database.ListOutlookMessagesParams{
+			SessionID: candidate,
+			Limit:     int64(top),
+		})
+	}, nil, func(item database.ListOutlookMessagesRow) string { return item.ID })
 
 	if err != nil {
 		log.Printf("[outlook] ✗ Failed to list messages: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	// Merge the parent's messages into the newest-first page
+	sort.SliceStable(listResults, func(i, j int) bool {
+		return listResults[i].ReceivedDatetime > listResults[j].ReceivedDatetime
+	})
+	if len(listResults) > top {
+		listResults = listResults[:top]
+	}
 
 	// Build response
 	messageList := make([]*Message, 0, len(listResults))
 	for i := range listResults {
 		messageList = append(messageList, listRowToGraphMessage(listResults[i]))
 	}
+	messageList = listcap.Truncate(w, messageList)
 
 	response := MessageListResponse{
 		Value:        messageList,
@@ -281,19 +468,87 @@ func (h *Handler) handleListMessages(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[outlook] ✓ Listed %d messages", len(listResults))
+	log.Printf("[outlook] ✓ Listed %d messages", len(messageList))
+}
+
+// handleMessagesDelta returns the messages changed since a delta or skip token, or every message
+// when there is neither. The last page carries a deltaLink for the next sync; earlier pages carry
+// a nextLink. Both tokens hold the change sequence number of the last message returned.
+func (h *Handler) handleMessagesDelta(w http.ResponseWriter, r *http.Request) {
+	log.Println("[outlook] → Received messages delta request")
+
+	query := r.URL.Query()
+	token := query.Get("$deltatoken")
+	if token == "" {
+		token = query.Get("$skiptoken")
+	}
+	var since int64
+	if token != "" {
+		var ok bool
+		if since, ok = parseDeltaToken(token); !ok {
+			log.Printf("[outlook] ✗ Invalid delta token: %s", token)
+			http.Error(w, "Invalid delta token", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pageSize := defaultDeltaPageSize
+	if topStr := query.Get("$top"); topStr != "" {
+		if top, err := strconv.Atoi(topStr); err == nil && top > 0 {
+			pageSize = top
+		}
+	} else if maxPageSize, ok := preferredPageSize(r); ok {
+		pageSize = maxPageSize
+		w.Header().Set("Preference-Applied", fmt.Sprintf("odata.maxpagesize=%d", maxPageSize))
+	}
+
+	changes, err := h.queries.ListOutlookMessageChanges(context.Background(), database.ListOutlookMessageChangesParams{
+		SessionID: session.FromContext(r.Context()),
+		ChangeSeq: since,
+		Limit:     int64(pageSize + 1),
+	})
+	if err != nil {
+		log.Printf("[outlook] ✗ Failed to list message changes: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	more := len(changes) > pageSize
+	if more {
+		changes = changes[:pageSize]
+	}
+
+	messageList := make([]*Message, 0, len(changes))
+	last := since
+	for i := range changes {
+		messageList = append(messageList, changeRowToGraphMessage(changes[i]))
+		last = changes[i].ChangeSeq
+	}
+
+	response := MessageListResponse{
+		Value:        messageList,
+		ODataContext: "https://graph.microsoft.com/v1.0/$metadata#Collection(message)",
+	}
+	if more {
+		response.NextLink = deltaURL(r) + "?$skiptoken=" + encodeDeltaToken(last)
+	} else {
+		response.DeltaLink = deltaURL(r) + "?$deltatoken=" + encodeDeltaToken(last)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[outlook] ✓ Returned %d changed messages", len(messageList))
 }
 
 func (h *Handler) handleGetMessage(w http.ResponseWriter, r *http.Request, messageID string) {
 	log.Printf("[outlook] → Received get message request for ID: %s", messageID)
 
-	// Extract session ID from context
-	sessionID := session.FromContext(r.Context())
-
 	// Query message from database
-	dbMessage, err := h.queries.GetOutlookMessageByID(context.Background(), database.GetOutlookMessageByIDParams{
-		ID:        messageID,
-		SessionID: sessionID,
+	dbMessage, err := session.ReadThrough(r.Context(), "outlook_messages", messageID, func(candidate string) (database.GetOutlookMessageByIDRow, error) {
+		return h.queries.GetOutlookMessageByID(context.Background(), database.GetOutlookMessageByIDParams{
+			ID:        messageID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[outlook] ✗ Failed to get message: %v", err)
@@ -370,12 +625,154 @@ func (h *Handler) handleUpdateMessage(w http.ResponseWriter, r *http.Request, me
 
 // Helper functions
 
-func generateMessageID() string {
+func (h *Handler) handleBatch(w http.ResponseWriter, r *http.Request) {
+	log.Println("[outlook] → Received $batch request")
+
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("[outlook] ✗ Failed to decode batch: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Requests) == 0 || len(req.Requests) > maxBatchRequests {
+		log.Printf("[outlook] ✗ Batch has %d requests", len(req.Requests))
+		http.Error(w, fmt.Sprintf("Batch must contain between 1 and %d requests", maxBatchRequests), http.StatusBadRequest)
+		return
+	}
+
+	seen := make(map[string]bool, len(req.Requests))
+	for i := range req.Requests {
+		id := req.Requests[i].ID
+		if id == "" || seen[id] {
+			log.Printf("[outlook] ✗ Missing or duplicate batch request id: %q", id)
+			http.Error(w, "Each batch request needs a unique id", http.StatusBadRequest)
+			return
+		}
+		seen[id] = true
+	}
+
+	// Sub-requests run in order so writes are visible to later reads in the same batch
+	responses := make([]BatchResponseItem, 0, len(req.Requests))
+	for i := range req.Requests {
+		responses = append(responses, h.runBatchItem(r, &req.Requests[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(BatchResponse{Responses: responses})
+	log.Printf("[outlook] ✓ Processed batch of %d requests", len(responses))
+}
+
+// runBatchItem dispatches one sub-request through the normal Graph routing
+func (h *Handler) runBatchItem(r *http.Request, item *BatchRequestItem) BatchResponseItem {
+	// Sub-request URLs are relative to the version root, e.g. /me/messages?$top=5
+	target := "/v1.0/" + strings.TrimPrefix(item.URL, "/")
+
+	var body io.Reader = http.NoBody
+	if len(item.Body) > 0 {
+		body = bytes.NewReader(item.Body)
+	}
+
+	// The parent context carries the session, so sub-requests see the same data
+	sub, err := http.NewRequestWithContext(r.Context(), strings.ToUpper(item.Method), target, body)
+	if err != nil {
+		log.Printf("[outlook] ✗ Invalid batch request %s: %v", item.ID, err)
+		return BatchResponseItem{ID: item.ID, Status: http.StatusBadRequest}
+	}
+	for name, value := range item.Headers {
+		sub.Header.Set(name, value)
+	}
+	if len(item.Body) > 0 && sub.Header.Get("Content-Type") == "" {
+		sub.Header.Set("Content-Type", "application/json")
+	}
+
+	rec := httptest.NewRecorder()
+	h.handleGraphAPI(rec, sub)
+
+	result := BatchResponseItem{
+		ID:     item.ID,
+		Status: rec.Code,
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "" {
+		result.Headers = map[string]string{"Content-Type": contentType}
+	}
+	if responseBody := bytes.TrimSpace(rec.Body.Bytes()); len(responseBody) > 0 {
+		if json.Valid(responseBody) {
+			result.Body = responseBody
+		} else {
+			// Non-JSON bodies such as plain-text errors are embedded as strings
+			result.Body, _ = json.Marshal(string(responseBody))
+		}
+	}
+	return result
+}
+
+// encodeDeltaToken wraps a change sequence number in an opaque token
+func encodeDeltaToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("seq:" + strconv.FormatInt(seq, 10)))
+}
+
+func parseDeltaToken(token string) (int64, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), "seq:") {
+		return 0, false
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(raw), "seq:"), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false
+	}
+	return seq, true
+}
+
+// preferredPageSize reads odata.maxpagesize from the Prefer header, which Graph honors on delta queries
+func preferredPageSize(r *http.Request) (int, bool) {
+	for _, preference := range strings.Split(r.Header.Get("Prefer"), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(preference), "=")
+		if !found || !strings.EqualFold(name, "odata.maxpagesize") {
+			continue
+		}
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+// deltaURL is the absolute URL of the delta request without its query, so links lead back to
+// the simulator under whatever prefix it is mounted at
+func deltaURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	path := r.URL.Path
+	if r.RequestURI != "" {
+		path, _, _ = strings.Cut(r.RequestURI, "?")
+	}
+	return scheme + "://" + r.Host + path
+}
+
+func generateMessageID(sessionID string) string {
 	b := make([]byte, 16)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return "AAMkAD" + hex.EncodeToString(b) // Microsoft Graph message IDs start with AAMkAD
 }
 
+// prefixSubject adds a RE:/FW: prefix unless the subject already carries it
+func prefixSubject(prefix, subject string) string {
+	if strings.HasPrefix(strings.ToUpper(subject), strings.ToUpper(prefix)) {
+		return subject
+	}
+	return prefix + subject
+}
+
+// conversationOrSelf returns the stored conversation ID, treating legacy messages as their own conversation
+func conversationOrSelf(conversationID, messageID string) string {
+	if conversationID == "" {
+		return messageID
+	}
+	return conversationID
+}
+
 func getRowToGraphMessage(msg database.GetOutlookMessageByIDRow) *Message {
 	bodyContent := ""
 	if msg.BodyContent.Valid {
@@ -394,15 +791,11 @@ func getRowToGraphMessage(msg database.GetOutlookMessageByIDRow) *Message {
 				Address: msg.FromEmail,
 			},
 		},
-		ToRecipients: []*Recipient{
-			{
-				EmailAddress: &EmailAddress{
-					Address: msg.ToEmail,
-				},
-			},
-		},
+		ToRecipients:     toRecipients(msg.ToEmail),
+		CcRecipients:     toRecipients(msg.CcEmail),
 		IsRead:           msg.IsRead != 0,
 		ReceivedDateTime: msg.ReceivedDatetime,
+		ConversationID:   conversationOrSelf(msg.ConversationID, msg.ID),
 	}
 }
 
@@ -424,18 +817,29 @@ func listRowToGraphMessage(msg database.ListOutlookMessagesRow) *Message {
 				Address: msg.FromEmail,
 			},
 		},
-		ToRecipients: []*Recipient{
-			{
-				EmailAddress: &EmailAddress{
-					Address: msg.ToEmail,
-				},
-			},
-		},
+		ToRecipients:     toRecipients(msg.ToEmail),
+		CcRecipients:     toRecipients(msg.CcEmail),
 		IsRead:           msg.IsRead != 0,
 		ReceivedDateTime: msg.ReceivedDatetime,
+		ConversationID:   conversationOrSelf(msg.ConversationID, msg.ID),
 	}
 }
 
+func changeRowToGraphMessage(msg database.ListOutlookMessageChangesRow) *Message {
+	return listRowToGraphMessage(database.ListOutlookMessagesRow{
+		ID:               msg.ID,
+		FromEmail:        msg.FromEmail,
+		ToEmail:          msg.ToEmail,
+		CcEmail:          msg.CcEmail,
+		Subject:          msg.Subject,
+		BodyContent:      msg.BodyContent,
+		BodyType:         msg.BodyType,
+		IsRead:           msg.IsRead,
+		ReceivedDatetime: msg.ReceivedDatetime,
+		ConversationID:   msg.ConversationID,
+	})
+}
+
 func searchRowToGraphMessage(msg database.SearchOutlookMessagesRow) *Message {
 	bodyContent := ""
 	if msg.BodyContent.Valid {
@@ -454,16 +858,69 @@ func searchRowToGraphMessage(msg database.SearchOutlookMessagesRow) *Message {
 				Address: msg.FromEmail,
 			},
 		},
-		ToRecipients: []*Recipient{
-			{
-				EmailAddress: &EmailAddress{
-					Address: msg.ToEmail,
-				},
-			},
-		},
+		ToRecipients:     toRecipients(msg.ToEmail),
+		CcRecipients:     toRecipients(msg.CcEmail),
 		IsRead:           msg.IsRead != 0,
 		ReceivedDateTime: msg.ReceivedDatetime,
+		ConversationID:   conversationOrSelf(msg.ConversationID, msg.ID),
+	}
+}
+
+// addressesOf returns the addresses of recipients, skipping blanks and repeats
+func addressesOf(recipients []*Recipient) []string {
+	addresses := []string{}
+	for _, recipient := range recipients {
+		if recipient == nil || recipient.EmailAddress == nil {
+			continue
+		}
+		addresses = append(addresses, recipient.EmailAddress.Address)
+	}
+	return splitAddresses(joinAddresses(addresses))
+}
+
+// joinAddresses stores addresses as a comma-separated list
+func joinAddresses(addresses []string) string {
+	return strings.Join(addresses, ",")
+}
+
+// splitAddresses reads a stored address list, dropping blanks and repeats
+func splitAddresses(list string) []string {
+	addresses := []string{}
+	seen := make(map[string]bool)
+	for _, address := range strings.Split(list, ",") {
+		address = strings.TrimSpace(address)
+		key := strings.ToLower(address)
+		if address == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// without returns addresses other than the excluded one
+func without(addresses []string, excluded string) []string {
+	kept := []string{}
+	for _, address := range addresses {
+		if !strings.EqualFold(address, excluded) {
+			kept = append(kept, address)
+		}
+	}
+	return kept
+}
+
+// toRecipients renders a stored address list as Graph recipients
+func toRecipients(list string) []*Recipient {
+	addresses := splitAddresses(list)
+	if len(addresses) == 0 {
+		return nil
+	}
+	recipients := make([]*Recipient, 0, len(addresses))
+	for _, address := range addresses {
+		recipients = append(recipients, &Recipient{EmailAddress: &EmailAddress{Address: address}})
 	}
+	return recipients
 }
 
 func parseFilter(filter string) (fromEmail, toEmail, subject, bodySearch string) {
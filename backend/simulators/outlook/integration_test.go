@@ -39,8 +39,10 @@ type Message struct {
 	Body             *ItemBody    `json:"body,omitempty"`
 	From             *Recipient   `json:"from,omitempty"`
 	ToRecipients     []*Recipient `json:"toRecipients,omitempty"`
+	CcRecipients     []*Recipient `json:"ccRecipients,omitempty"`
 	IsRead           bool         `json:"isRead"`
 	ReceivedDateTime string       `json:"receivedDateTime,omitempty"`
+	ConversationID   string       `json:"conversationId,omitempty"`
 }
 
 type SendMailRequest struct {
@@ -447,6 +449,184 @@ func TestOutlookSimulatorMarkAsRead(t *testing.T) {
 	})
 }
 
+func TestOutlookSimulatorReplyForward(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "outlook-test-session-reply"
+
+	// Setup: Seed an inbound message
+	err := queries.CreateOutlookMessage(context.Background(), database.CreateOutlookMessageParams{
+		ID:               "seeded-msg-1",
+		FromEmail:        "customer@example.com",
+		ToEmail:          "support@example.com",
+		Subject:          "Printer is broken",
+		BodyContent:      sql.NullString{String: "It prints nothing.", Valid: true},
+		BodyType:         "text",
+		IsRead:           0,
+		ReceivedDatetime: "2025-01-01T00:00:00Z",
+		ConversationID:   "seeded-conv-1",
+		SessionID:        sessionID,
+	})
+	require.NoError(t, err, "Failed to seed message")
+	err = queries.CreateOutlookMessage(context.Background(), database.CreateOutlookMessageParams{
+		ID:               "seeded-msg-2",
+		FromEmail:        "customer@example.com",
+		ToEmail:          "support@example.com,bob@example.com",
+		CcEmail:          "carol@example.com,support@example.com",
+		Subject:          "Scanner is broken too",
+		BodyType:         "text",
+		ReceivedDatetime: "2025-01-01T01:00:00Z",
+		ConversationID:   "seeded-conv-2",
+		SessionID:        sessionID,
+	})
+	require.NoError(t, err, "Failed to seed message")
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorOutlook.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	postActionOn := func(t *testing.T, messageID, action string, body interface{}) *http.Response {
+		t.Helper()
+		jsonBody, _ := json.Marshal(body)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/v1.0/me/messages/"+messageID+"/"+action, bytes.NewBuffer(jsonBody))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Session-ID", sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	postAction := func(t *testing.T, action string, body interface{}) *http.Response {
+		t.Helper()
+		return postActionOn(t, "seeded-msg-1", action, body)
+	}
+	addresses := func(recipients []*Recipient) []string {
+		result := []string{}
+		for _, recipient := range recipients {
+			result = append(result, recipient.EmailAddress.Address)
+		}
+		return result
+	}
+
+	findBySubject := func(t *testing.T, subject string) *Message {
+		t.Helper()
+		listReq, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/v1.0/me/messages", http.NoBody)
+		require.NoError(t, err)
+		listReq.Header.Set("X-Session-ID", sessionID)
+
+		listResp, err := http.DefaultClient.Do(listReq)
+		require.NoError(t, err)
+		defer listResp.Body.Close()
+
+		var listResponse MessageListResponse
+		err = json.NewDecoder(listResp.Body).Decode(&listResponse)
+		require.NoError(t, err)
+
+		for _, msg := range listResponse.Value {
+			if msg.Subject == subject {
+				return msg
+			}
+		}
+		return nil
+	}
+
+	t.Run("ReplyKeepsConversation", func(t *testing.T) {
+		resp := postAction(t, "reply", map[string]string{"comment": "We are on it."})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode, "Reply should return 202")
+
+		reply := findBySubject(t, "RE: Printer is broken")
+		require.NotNil(t, reply, "Reply should be stored")
+		assert.Equal(t, "seeded-conv-1", reply.ConversationID, "Reply should share the original conversation")
+		assert.Equal(t, "support@example.com", reply.From.EmailAddress.Address, "Reply should come from the original recipient")
+		assert.Equal(t, "customer@example.com", reply.ToRecipients[0].EmailAddress.Address, "Reply should go to the original sender")
+		assert.Contains(t, reply.Body.Content, "We are on it.", "Reply should include the comment")
+		assert.Contains(t, reply.Body.Content, "It prints nothing.", "Reply should quote the original body")
+
+		// Get the reply directly and verify linkage
+		getReq, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/v1.0/me/messages/"+reply.ID, http.NoBody)
+		require.NoError(t, err)
+		getReq.Header.Set("X-Session-ID", sessionID)
+
+		getResp, err := http.DefaultClient.Do(getReq)
+		require.NoError(t, err)
+		defer getResp.Body.Close()
+
+		var retrieved Message
+		err = json.NewDecoder(getResp.Body).Decode(&retrieved)
+		require.NoError(t, err)
+		assert.Equal(t, "seeded-conv-1", retrieved.ConversationID, "Conversation ID should match the original")
+	})
+
+	t.Run("ForwardToNewRecipient", func(t *testing.T) {
+		resp := postAction(t, "forward", map[string]interface{}{
+			"comment": "FYI",
+			"toRecipients": []*Recipient{
+				{EmailAddress: &EmailAddress{Address: "hardware@example.com"}},
+			},
+		})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode, "Forward should return 202")
+
+		forwarded := findBySubject(t, "FW: Printer is broken")
+		require.NotNil(t, forwarded, "Forward should be stored")
+		assert.Equal(t, "seeded-conv-1", forwarded.ConversationID, "Forward should share the original conversation")
+		assert.Equal(t, "hardware@example.com", forwarded.ToRecipients[0].EmailAddress.Address, "Forward should go to the new recipient")
+	})
+
+	t.Run("ReplyAllToEveryoneButSelf", func(t *testing.T) {
+		resp := postActionOn(t, "seeded-msg-2", "replyAll", map[string]string{"comment": "Looking into both."})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode, "ReplyAll should return 202")
+
+		reply := findBySubject(t, "RE: Scanner is broken too")
+		require.NotNil(t, reply, "ReplyAll should be stored")
+		assert.Equal(t, "support@example.com", reply.From.EmailAddress.Address, "ReplyAll should come from the original recipient")
+		assert.Equal(t, []string{"customer@example.com", "bob@example.com"}, addresses(reply.ToRecipients),
+			"ReplyAll should go to the sender and the other original recipients")
+		assert.Equal(t, []string{"carol@example.com"}, addresses(reply.CcRecipients),
+			"ReplyAll should copy the original Cc without the responding mailbox")
+	})
+
+	t.Run("ForwardToSeveralRecipients", func(t *testing.T) {
+		resp := postActionOn(t, "seeded-msg-2", "forward", map[string]interface{}{
+			"toRecipients": []*Recipient{
+				{EmailAddress: &EmailAddress{Address: "hardware@example.com"}},
+				{EmailAddress: &EmailAddress{Address: "facilities@example.com"}},
+			},
+		})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode, "Forward should return 202")
+
+		forwarded := findBySubject(t, "FW: Scanner is broken too")
+		require.NotNil(t, forwarded, "Forward should be stored")
+		assert.Equal(t, []string{"hardware@example.com", "facilities@example.com"}, addresses(forwarded.ToRecipients),
+			"Forward should go to every recipient")
+		assert.Empty(t, forwarded.CcRecipients, "Forward should not copy the original Cc")
+	})
+
+	t.Run("ForwardWithoutRecipients", func(t *testing.T) {
+		resp := postAction(t, "forward", map[string]string{"comment": "FYI"})
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Forward without recipients should return 400")
+	})
+
+	t.Run("ReplyToNonExistentMessage", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/v1.0/me/messages/nonexistent/replyAll", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-ID", sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Should return 404 for non-existent message")
+	})
+}
+
 func TestOutlookSimulatorEndToEnd(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -561,3 +741,237 @@ func TestOutlookSimulatorEndToEnd(t *testing.T) {
 		assert.True(t, updated.IsRead, "Message should be marked as read")
 	})
 }
+
+func TestOutlookSimulatorBatch(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "outlook-test-session-batch"
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorOutlook.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	postBatch := func(t *testing.T, body interface{}) (int, simulatorOutlook.BatchResponse) {
+		t.Helper()
+		jsonBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/v1.0/$batch", bytes.NewBuffer(jsonBody))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Session-ID", sessionID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result simulatorOutlook.BatchResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+
+	t.Run("SendMailAndListMessages", func(t *testing.T) {
+		sendBody := &SendMailRequest{
+			Message: &Message{
+				Subject: "Batched Email",
+				Body: &ItemBody{
+					ContentType: "text",
+					Content:     "Sent inside a batch.",
+				},
+				ToRecipients: []*Recipient{
+					{EmailAddress: &EmailAddress{Address: "recipient@example.com"}},
+				},
+			},
+		}
+
+		status, result := postBatch(t, map[string]interface{}{
+			"requests": []map[string]interface{}{
+				{
+					"id":      "1",
+					"method":  "POST",
+					"url":     "/me/sendMail",
+					"headers": map[string]string{"Content-Type": "application/json"},
+					"body":    sendBody,
+				},
+				{
+					"id":     "2",
+					"method": "GET",
+					"url":    "/me/messages",
+				},
+			},
+		})
+
+		// Assertions
+		require.Equal(t, http.StatusOK, status, "Batch should return 200 OK")
+		require.Len(t, result.Responses, 2, "Should return one response per request")
+
+		assert.Equal(t, "1", result.Responses[0].ID, "First response should match its request")
+		assert.Equal(t, http.StatusAccepted, result.Responses[0].Status, "sendMail should return 202 Accepted")
+
+		assert.Equal(t, "2", result.Responses[1].ID, "Second response should match its request")
+		assert.Equal(t, http.StatusOK, result.Responses[1].Status, "List should return 200 OK")
+		var list MessageListResponse
+		require.NoError(t, json.Unmarshal(result.Responses[1].Body, &list), "List body should be embedded JSON")
+		require.Len(t, list.Value, 1, "List should see the message sent earlier in the batch")
+		assert.Equal(t, "Batched Email", list.Value[0].Subject, "Subject should match")
+	})
+
+	t.Run("SubRequestErrors", func(t *testing.T) {
+		status, result := postBatch(t, map[string]interface{}{
+			"requests": []map[string]interface{}{
+				{"id": "missing", "method": "GET", "url": "/me/messages/does-not-exist"},
+			},
+		})
+
+		require.Equal(t, http.StatusOK, status, "Batch should succeed even if a sub-request fails")
+		require.Len(t, result.Responses, 1, "Should return one response")
+		assert.Equal(t, http.StatusNotFound, result.Responses[0].Status, "Sub-request status should be preserved")
+	})
+
+	t.Run("InvalidBatch", func(t *testing.T) {
+		status, _ := postBatch(t, map[string]interface{}{
+			"requests": []map[string]interface{}{
+				{"id": "1", "method": "GET", "url": "/me/messages"},
+				{"id": "1", "method": "GET", "url": "/me/messages"},
+			},
+		})
+		assert.Equal(t, http.StatusBadRequest, status, "Duplicate ids should be rejected")
+	})
+}
+
+type DeltaResponse struct {
+	Value     []*Message `json:"value"`
+	NextLink  string     `json:"@odata.nextLink"`
+	DeltaLink string     `json:"@odata.deltaLink"`
+}
+
+func TestOutlookSimulatorMessagesDelta(t *testing.T) {
+	queries := setupTestDB(t)
+	sessionID := "outlook-test-session-delta"
+
+	handler := session.Middleware(simulatorOutlook.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	do := func(t *testing.T, method, url string, body interface{}, header http.Header) *http.Response {
+		t.Helper()
+		var reader *bytes.Buffer
+		if body != nil {
+			jsonBody, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewBuffer(jsonBody)
+		} else {
+			reader = &bytes.Buffer{}
+		}
+		req, err := http.NewRequestWithContext(context.Background(), method, url, reader)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Session-ID", sessionID)
+		for name := range header {
+			req.Header.Set(name, header.Get(name))
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	send := func(t *testing.T, subject string) {
+		t.Helper()
+		resp := do(t, http.MethodPost, server.URL+"/v1.0/me/sendMail", &SendMailRequest{
+			Message: &Message{
+				Subject:      subject,
+				Body:         &ItemBody{ContentType: "text", Content: subject},
+				ToRecipients: []*Recipient{{EmailAddress: &EmailAddress{Address: "recipient@example.com"}}},
+			},
+		}, nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusAccepted, resp.StatusCode, "sendMail should succeed")
+	}
+
+	delta := func(t *testing.T, url string, header http.Header) DeltaResponse {
+		t.Helper()
+		resp := do(t, http.MethodGet, url, nil, header)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Delta should succeed")
+		var result DeltaResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	subjects := func(messages []*Message) []string {
+		result := make([]string, 0, len(messages))
+		for _, message := range messages {
+			result = append(result, message.Subject)
+		}
+		return result
+	}
+
+	deltaURL := server.URL + "/v1.0/me/mailFolders/Inbox/messages/delta"
+
+	send(t, "First")
+	initial := delta(t, deltaURL, nil)
+	assert.Equal(t, []string{"First"}, subjects(initial.Value), "The initial sync should return every message")
+	assert.Empty(t, initial.NextLink, "A single page should not have a nextLink")
+	require.NotEmpty(t, initial.DeltaLink, "The last page should carry a deltaLink")
+
+	t.Run("OnlyNewMessages", func(t *testing.T) {
+		send(t, "Second")
+
+		changes := delta(t, initial.DeltaLink, nil)
+		assert.Equal(t, []string{"Second"}, subjects(changes.Value), "Only the message sent after the token should be returned")
+
+		unchanged := delta(t, changes.DeltaLink, nil)
+		assert.Empty(t, unchanged.Value, "No changes should be returned once caught up")
+		assert.NotEmpty(t, unchanged.DeltaLink, "An empty round should still return a deltaLink")
+	})
+
+	t.Run("UpdatesAreChanges", func(t *testing.T) {
+		caughtUp := delta(t, deltaURL, nil)
+
+		messageID := initial.Value[0].ID
+		resp := do(t, http.MethodPatch, server.URL+"/v1.0/me/messages/"+messageID, map[string]bool{"isRead": true}, nil)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Update should succeed")
+
+		changes := delta(t, caughtUp.DeltaLink, nil)
+		require.Len(t, changes.Value, 1, "The updated message should be returned")
+		assert.Equal(t, messageID, changes.Value[0].ID)
+		assert.True(t, changes.Value[0].IsRead, "The change should carry the new state")
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		first := delta(t, deltaURL, http.Header{"Prefer": []string{"odata.maxpagesize=1"}})
+		require.Len(t, first.Value, 1, "odata.maxpagesize should limit the page")
+		require.NotEmpty(t, first.NextLink, "More changes should be linked with a nextLink")
+		assert.Empty(t, first.DeltaLink)
+
+		second := delta(t, first.NextLink, http.Header{"Prefer": []string{"odata.maxpagesize=1"}})
+		require.Len(t, second.Value, 1)
+		assert.NotEqual(t, first.Value[0].ID, second.Value[0].ID, "Pages should not repeat messages")
+		assert.NotEmpty(t, second.DeltaLink, "The last page should carry a deltaLink")
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		resp := do(t, http.MethodGet, deltaURL+"?$deltatoken=bogus", nil, nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Malformed tokens should be rejected")
+	})
+
+	t.Run("SessionIsolation", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, deltaURL, http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("X-Session-ID", "outlook-test-session-delta-other")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var result DeltaResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Empty(t, result.Value, "Other sessions should not see these messages")
+	})
+}
@@ -2,10 +2,10 @@ package pagerduty
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
@@ -31,17 +32,18 @@ type APIDetails struct {
 }
 
 type Incident struct {
-	ID          string     `json:"id"`
-	Type        string     `json:"type"`
-	Title       string     `json:"title"`
-	Service     APIObject  `json:"service"`
-	Urgency     string     `json:"urgency"`
-	Status      string     `json:"status"`
-	Body        *APIDetails `json:"body,omitempty"`
-	CreatedAt   string     `json:"created_at"`
-	UpdatedAt   string     `json:"updated_at,omitempty"`
-	HTMLURL     string     `json:"html_url,omitempty"`
-	Self        string     `json:"self,omitempty"`
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Service   APIObject   `json:"service"`
+	Urgency   string      `json:"urgency"`
+	Status    string      `json:"status"`
+	Body      *APIDetails `json:"body,omitempty"`
+	Priority  *Priority   `json:"priority,omitempty"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at,omitempty"`
+	HTMLURL   string      `json:"html_url,omitempty"`
+	Self      string      `json:"self,omitempty"`
 }
 
 type CreateIncidentRequest struct {
@@ -49,11 +51,12 @@ type CreateIncidentRequest struct {
 }
 
 type CreateIncidentOptions struct {
-	Type    string     `json:"type"`
-	Title   string     `json:"title"`
-	Service APIObject  `json:"service"`
-	Urgency string     `json:"urgency,omitempty"`
-	Body    *APIDetails `json:"body,omitempty"`
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Service  APIObject   `json:"service"`
+	Urgency  string      `json:"urgency,omitempty"`
+	Body     *APIDetails `json:"body,omitempty"`
+	Priority *APIObject  `json:"priority,omitempty"`
 }
 
 type CreateIncidentResponse struct {
@@ -77,15 +80,42 @@ type ManageIncidentsRequest struct {
 }
 
 type ManageIncidentOptions struct {
-	ID     string `json:"id"`
-	Type   string `json:"type"`
-	Status string `json:"status"`
+	ID       string     `json:"id"`
+	Type     string     `json:"type"`
+	Status   string     `json:"status"`
+	Priority *APIObject `json:"priority,omitempty"`
 }
 
 type ManageIncidentsResponse struct {
 	Incidents []Incident `json:"incidents"`
 }
 
+type Priority struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description"`
+	Self        string `json:"self,omitempty"`
+}
+
+type ListPrioritiesResponse struct {
+	Priorities []Priority `json:"priorities"`
+	Limit      int        `json:"limit"`
+	Offset     int        `json:"offset"`
+	More       bool       `json:"more"`
+	Total      int        `json:"total,omitempty"`
+}
+
+// defaultPriorities are seeded into each session the first time priorities are used
+var defaultPriorities = []database.CreatePagerDutyPriorityParams{
+	{ID: "P1", Name: "P1", Description: "Critical: customer-facing outage", OrderIndex: 1},
+	{ID: "P2", Name: "P2", Description: "High: major functionality degraded", OrderIndex: 2},
+	{ID: "P3", Name: "P3", Description: "Moderate: partial degradation", OrderIndex: 3},
+	{ID: "P4", Name: "P4", Description: "Low: minor issue", OrderIndex: 4},
+	{ID: "P5", Name: "P5", Description: "Informational", OrderIndex: 5},
+}
+
 type Service struct {
 	ID      string `json:"id"`
 	Type    string `json:"type"`
@@ -155,6 +185,19 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountPagerDutySessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"services":            counts.Services,
+		"incidents":           counts.Incidents,
+		"escalation_policies": counts.EscalationPolicies,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[pagerduty] → %s %s", r.Method, r.URL.Path)
@@ -194,6 +237,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleListEscalationPolicies(w, r)
 	case path == "oncalls" && r.Method == http.MethodGet:
 		h.handleListOnCalls(w, r)
+	case path == "priorities" && r.Method == http.MethodGet:
+		h.handleListPriorities(w, r)
 	default:
 		log.Printf("[pagerduty] ✗ Unhandled route: %s %s", r.Method, r.URL.Path)
 		http.NotFound(w, r)
@@ -214,8 +259,8 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 	sessionID := session.FromContext(r.Context())
 
 	// Generate incident ID
-	incidentID := generateID()
-	now := time.Now()
+	incidentID := generateID(sessionID)
+	now := session.Now(sessionID)
 
 	// Default values
 	status := "triggered"
@@ -229,6 +274,13 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 		bodyDetails = req.Incident.Body.Details
 	}
 
+	priorityID, err := h.resolvePriority(sessionID, req.Incident.Priority)
+	if err != nil {
+		log.Printf("[pagerduty] ✗ %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Store incident in database
 	dbIncident, err := h.queries.CreatePagerDutyIncident(context.Background(), database.CreatePagerDutyIncidentParams{
 		ID:          incidentID,
@@ -237,6 +289,7 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 		Urgency:     urgency,
 		Status:      status,
 		BodyDetails: sql.NullString{String: bodyDetails, Valid: bodyDetails != ""},
+		PriorityID:  priorityID,
 		SessionID:   sessionID,
 		CreatedAt:   now.Unix(),
 		UpdatedAt:   now.Unix(),
@@ -261,6 +314,7 @@ func (h *Handler) handleCreateIncident(w http.ResponseWriter, r *http.Request) {
 		Status:    dbIncident.Status,
 		CreatedAt: time.Unix(dbIncident.CreatedAt, 0).Format(time.RFC3339),
 		UpdatedAt: time.Unix(dbIncident.UpdatedAt, 0).Format(time.RFC3339),
+		Priority:  h.priorityReference(sessionID, dbIncident.PriorityID),
 		HTMLURL:   "https://example.pagerduty.com/incidents/" + incidentID,
 		Self:      "https://api.pagerduty.com/incidents/" + incidentID,
 	}
@@ -289,9 +343,11 @@ func (h *Handler) handleGetIncident(w http.ResponseWriter, r *http.Request, inci
 	sessionID := session.FromContext(r.Context())
 
 	// Query incident from database
-	dbIncident, err := h.queries.GetPagerDutyIncidentByID(context.Background(), database.GetPagerDutyIncidentByIDParams{
-		ID:        incidentID,
-		SessionID: sessionID,
+	dbIncident, err := session.ReadThrough(r.Context(), "pagerduty_incidents", incidentID, func(candidate string) (database.GetPagerDutyIncidentByIDRow, error) {
+		return h.queries.GetPagerDutyIncidentByID(context.Background(), database.GetPagerDutyIncidentByIDParams{
+			ID:        incidentID,
+			SessionID: candidate,
+		})
 	})
 	if err != nil {
 		log.Printf("[pagerduty] ✗ Failed to get incident: %v", err)
@@ -312,6 +368,7 @@ func (h *Handler) handleGetIncident(w http.ResponseWriter, r *http.Request, inci
 		Status:    dbIncident.Status,
 		CreatedAt: time.Unix(dbIncident.CreatedAt, 0).Format(time.RFC3339),
 		UpdatedAt: time.Unix(dbIncident.UpdatedAt, 0).Format(time.RFC3339),
+		Priority:  h.priorityReference(sessionID, dbIncident.PriorityID),
 		HTMLURL:   "https://example.pagerduty.com/incidents/" + incidentID,
 		Self:      "https://api.pagerduty.com/incidents/" + incidentID,
 	}
@@ -337,7 +394,8 @@ func (h *Handler) handleUpdateIncidentStatus(w http.ResponseWriter, r *http.Requ
 
 	var req struct {
 		Incident struct {
-			Status string `json:"status"`
+			Status   string     `json:"status"`
+			Priority *APIObject `json:"priority,omitempty"`
 		} `json:"incident"`
 	}
 
@@ -350,21 +408,45 @@ func (h *Handler) handleUpdateIncidentStatus(w http.ResponseWriter, r *http.Requ
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
-	// Update incident status
-	now := time.Now()
-	err := h.queries.UpdatePagerDutyIncidentStatus(context.Background(), database.UpdatePagerDutyIncidentStatusParams{
-		Status:    req.Incident.Status,
-		UpdatedAt: now.Unix(),
-		ID:        incidentID,
-		SessionID: sessionID,
-	})
-
+	// Validate priority before applying any changes
+	priorityID, err := h.resolvePriority(sessionID, req.Incident.Priority)
 	if err != nil {
-		log.Printf("[pagerduty] ✗ Failed to update incident: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		log.Printf("[pagerduty] ✗ %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	// Update incident status
+	now := session.Now(sessionID)
+	if req.Incident.Status != "" {
+		err = h.queries.UpdatePagerDutyIncidentStatus(context.Background(), database.UpdatePagerDutyIncidentStatusParams{
+			Status:    req.Incident.Status,
+			UpdatedAt: now.Unix(),
+			ID:        incidentID,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			log.Printf("[pagerduty] ✗ Failed to update incident: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Update incident priority
+	if priorityID.Valid {
+		err = h.queries.UpdatePagerDutyIncidentPriority(context.Background(), database.UpdatePagerDutyIncidentPriorityParams{
+			PriorityID: priorityID,
+			UpdatedAt:  now.Unix(),
+			ID:         incidentID,
+			SessionID:  sessionID,
+		})
+		if err != nil {
+			log.Printf("[pagerduty] ✗ Failed to update incident priority: %v", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Return updated incident
 	h.handleGetIncident(w, r, incidentID)
 	log.Printf("[pagerduty] ✓ Updated incident status: %s -> %s", incidentID, req.Incident.Status)
@@ -385,19 +467,47 @@ func (h *Handler) handleManageIncidents(w http.ResponseWriter, r *http.Request)
 
 	// Update each incident
 	updatedIncidents := make([]Incident, 0, len(req.Incidents))
-	now := time.Now()
-
-	for _, incidentUpdate := range req.Incidents {
-		err := h.queries.UpdatePagerDutyIncidentStatus(context.Background(), database.UpdatePagerDutyIncidentStatusParams{
-			Status:    incidentUpdate.Status,
-			UpdatedAt: now.Unix(),
-			ID:        incidentUpdate.ID,
-			SessionID: sessionID,
-		})
+	now := session.Now(sessionID)
 
+	// Validate priorities before applying any changes
+	priorityIDs := make([]sql.NullString, len(req.Incidents))
+	for i, incidentUpdate := range req.Incidents {
+		priorityID, err := h.resolvePriority(sessionID, incidentUpdate.Priority)
 		if err != nil {
-			log.Printf("[pagerduty] ✗ Failed to update incident %s: %v", incidentUpdate.ID, err)
-			continue
+			log.Printf("[pagerduty] ✗ %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		priorityIDs[i] = priorityID
+	}
+
+	for i, incidentUpdate := range req.Incidents {
+		if incidentUpdate.Status != "" {
+			err := h.queries.UpdatePagerDutyIncidentStatus(context.Background(), database.UpdatePagerDutyIncidentStatusParams{
+				Status:    incidentUpdate.Status,
+				UpdatedAt: now.Unix(),
+				ID:        incidentUpdate.ID,
+				SessionID: sessionID,
+			})
+
+			if err != nil {
+				log.Printf("[pagerduty] ✗ Failed to update incident %s: %v", incidentUpdate.ID, err)
+				continue
+			}
+		}
+
+		if priorityIDs[i].Valid {
+			err := h.queries.UpdatePagerDutyIncidentPriority(context.Background(), database.UpdatePagerDutyIncidentPriorityParams{
+				PriorityID: priorityIDs[i],
+				UpdatedAt:  now.Unix(),
+				ID:         incidentUpdate.ID,
+				SessionID:  sessionID,
+			})
+
+			if err != nil {
+				log.Printf("[pagerduty] ✗ Failed to update incident priority %s: %v", incidentUpdate.ID, err)
+				continue
+			}
 		}
 
 		// Get updated incident
@@ -420,6 +530,7 @@ func (h *Handler) handleManageIncidents(w http.ResponseWriter, r *http.Request)
 			},
 			Urgency:   dbIncident.Urgency,
 			Status:    dbIncident.Status,
+			Priority:  h.priorityReference(sessionID, dbIncident.PriorityID),
 			CreatedAt: time.Unix(dbIncident.CreatedAt, 0).Format(time.RFC3339),
 			UpdatedAt: time.Unix(dbIncident.UpdatedAt, 0).Format(time.RFC3339),
 		}
@@ -450,7 +561,9 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 	sessionID := session.FromContext(r.Context())
 
 	// Query incidents from database
-	dbIncidents, err := h.queries.ListPagerDutyIncidents(context.Background(), sessionID)
+	dbIncidents, err := session.ListThrough(r.Context(), "pagerduty_incidents", func(candidate string) ([]database.ListPagerDutyIncidentsRow, error) {
+		return h.queries.ListPagerDutyIncidents(context.Background(), candidate)
+	}, nil, func(item database.ListPagerDutyIncidentsRow) string { return item.ID })
 	if err != nil {
 		log.Printf("[pagerduty] ✗ Failed to list incidents: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -469,6 +582,7 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 			},
 			Urgency:   dbIncident.Urgency,
 			Status:    dbIncident.Status,
+			Priority:  h.priorityReference(sessionID, dbIncident.PriorityID),
 			CreatedAt: time.Unix(dbIncident.CreatedAt, 0).Format(time.RFC3339),
 			UpdatedAt: time.Unix(dbIncident.UpdatedAt, 0).Format(time.RFC3339),
 		}
@@ -483,11 +597,12 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 		incidents = append(incidents, incident)
 	}
 
+	page, info := paginate(w, r, incidents)
 	response := ListIncidentsResponse{
-		Incidents: incidents,
-		Limit:     100,
-		Offset:    0,
-		More:      false,
+		Incidents: page,
+		Limit:     info.limit,
+		Offset:    info.offset,
+		More:      info.more,
 		Total:     len(incidents),
 	}
 
@@ -499,11 +614,10 @@ func (h *Handler) handleListIncidents(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleListServices(w http.ResponseWriter, r *http.Request) {
 	log.Println("[pagerduty] → Received list services request")
 
-	// Extract session ID from context
-	sessionID := session.FromContext(r.Context())
-
 	// Query services from database
-	dbServices, err := h.queries.ListPagerDutyServices(context.Background(), sessionID)
+	dbServices, err := session.ListThrough(r.Context(), "pagerduty_services", func(candidate string) ([]database.ListPagerDutyServicesRow, error) {
+		return h.queries.ListPagerDutyServices(context.Background(), candidate)
+	}, nil, func(item database.ListPagerDutyServicesRow) string { return item.ID })
 	if err != nil {
 		log.Printf("[pagerduty] ✗ Failed to list services: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
@@ -522,11 +636,12 @@ func (h *Handler) handleListServices(w http.ResponseWriter, r *http.Request) {
 		services = append(services, service)
 	}
 
+	page, info := paginate(w, r, services)
 	response := ListServicesResponse{
-		Services: services,
-		Limit:    100,
-		Offset:   0,
-		More:     false,
+		Services: page,
+		Limit:    info.limit,
+		Offset:   info.offset,
+		More:     info.more,
 		Total:    len(services),
 	}
 
@@ -561,11 +676,12 @@ func (h *Handler) handleListEscalationPolicies(w http.ResponseWriter, r *http.Re
 		policies = append(policies, policy)
 	}
 
+	page, info := paginate(w, r, policies)
 	response := ListEscalationPoliciesResponse{
-		EscalationPolicies: policies,
-		Limit:              100,
-		Offset:             0,
-		More:               false,
+		EscalationPolicies: page,
+		Limit:              info.limit,
+		Offset:             info.offset,
+		More:               info.more,
 		Total:              len(policies),
 	}
 
@@ -619,11 +735,12 @@ func (h *Handler) handleListOnCalls(w http.ResponseWriter, r *http.Request) {
 		oncalls = append(oncalls, oncall)
 	}
 
+	page, info := paginate(w, r, oncalls)
 	response := ListOnCallsResponse{
-		OnCalls: oncalls,
-		Limit:   100,
-		Offset:  0,
-		More:    false,
+		OnCalls: page,
+		Limit:   info.limit,
+		Offset:  info.offset,
+		More:    info.more,
 		Total:   len(oncalls),
 	}
 
@@ -632,10 +749,145 @@ func (h *Handler) handleListOnCalls(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[pagerduty] ✓ Listed %d oncalls", len(oncalls))
 }
 
+func (h *Handler) handleListPriorities(w http.ResponseWriter, r *http.Request) {
+	log.Println("[pagerduty] → Received list priorities request")
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	// Query priorities from database
+	dbPriorities, err := h.listPriorities(sessionID)
+	if err != nil {
+		log.Printf("[pagerduty] ✗ Failed to list priorities: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	priorities := make([]Priority, 0, len(dbPriorities))
+	for _, dbPriority := range dbPriorities {
+		priority := Priority{
+			ID:          dbPriority.ID,
+			Type:        "priority",
+			Name:        dbPriority.Name,
+			Summary:     dbPriority.Name,
+			Description: dbPriority.Description,
+			Self:        "https://api.pagerduty.com/priorities/" + dbPriority.ID,
+		}
+		priorities = append(priorities, priority)
+	}
+
+	page, info := paginate(w, r, priorities)
+	response := ListPrioritiesResponse{
+		Priorities: page,
+		Limit:      info.limit,
+		Offset:     info.offset,
+		More:       info.more,
+		Total:      len(priorities),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[pagerduty] ✓ Listed %d priorities", len(priorities))
+}
+
+// listPriorities returns the session's priorities, seeding the defaults if none exist yet
+func (h *Handler) listPriorities(sessionID string) ([]database.ListPagerDutyPrioritiesRow, error) {
+	dbPriorities, err := h.queries.ListPagerDutyPriorities(context.Background(), sessionID)
+	if err != nil || len(dbPriorities) > 0 {
+		return dbPriorities, err
+	}
+
+	for _, p := range defaultPriorities {
+		p.SessionID = sessionID
+		if err := h.queries.CreatePagerDutyPriority(context.Background(), p); err != nil {
+			return nil, err
+		}
+	}
+
+	return h.queries.ListPagerDutyPriorities(context.Background(), sessionID)
+}
+
+// resolvePriority validates a priority reference; a nil reference means no priority
+func (h *Handler) resolvePriority(sessionID string, ref *APIObject) (sql.NullString, error) {
+	if ref == nil || ref.ID == "" {
+		return sql.NullString{}, nil
+	}
+
+	if _, err := h.listPriorities(sessionID); err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to load priorities: %w", err)
+	}
+
+	_, err := h.queries.GetPagerDutyPriorityByID(context.Background(), database.GetPagerDutyPriorityByIDParams{
+		ID:        ref.ID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("unknown priority id: %s", ref.ID)
+	}
+
+	return sql.NullString{String: ref.ID, Valid: true}, nil
+}
+
+// priorityReference builds the priority object returned on incidents
+func (h *Handler) priorityReference(sessionID string, priorityID sql.NullString) *Priority {
+	if !priorityID.Valid || priorityID.String == "" {
+		return nil
+	}
+
+	priority := &Priority{
+		ID:   priorityID.String,
+		Type: "priority",
+		Self: "https://api.pagerduty.com/priorities/" + priorityID.String,
+	}
+	if p, err := h.queries.GetPagerDutyPriorityByID(context.Background(), database.GetPagerDutyPriorityByIDParams{
+		ID:        priorityID.String,
+		SessionID: sessionID,
+	}); err == nil {
+		priority.Name = p.Name
+		priority.Summary = p.Name
+		priority.Description = p.Description
+	}
+
+	return priority
+}
+
 // Helper functions
 
-func generateID() string {
+// defaultPageLimit is the page size PagerDuty uses when a request doesn't set limit
+const defaultPageLimit = 100
+
+// pageInfo describes the page paginate returned, in PagerDuty's offset pagination terms
+type pageInfo struct {
+	limit  int
+	offset int
+	more   bool
+}
+
+// paginate cuts a list to the page selected by the limit and offset query parameters. The limit
+// is held to the list cap, and a page it cuts short is flagged while more still points clients
+// at the rest.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) ([]T, pageInfo) {
+	limit := defaultPageLimit
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = n
+	}
+	offset := 0
+	if n, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && n > 0 {
+		offset = n
+	}
+	limit, clamped := listcap.Clamp(limit)
+
+	start := min(offset, len(items))
+	end := min(start+limit, len(items))
+	more := end < len(items)
+	if clamped && more {
+		listcap.MarkTruncated(w)
+	}
+	return items[start:end], pageInfo{limit: limit, offset: offset, more: more}
+}
+
+func generateID(sessionID string) string {
 	b := make([]byte, 8)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
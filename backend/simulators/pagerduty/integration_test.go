@@ -3,6 +3,7 @@ package pagerduty_test
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,7 @@ import (
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/pressly/goose/v3"
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
 	"github.com/recreate-run/nova-simulators/internal/session"
 	simulatorPagerDuty "github.com/recreate-run/nova-simulators/simulators/pagerduty"
 	"github.com/stretchr/testify/assert"
@@ -341,6 +343,40 @@ func TestPagerDutySimulatorListServices(t *testing.T) {
 		assert.NotNil(t, response, "Should return response")
 		assert.GreaterOrEqual(t, len(response.Services), 3, "Should have at least 3 services")
 	})
+
+	t.Run("ListCapTruncates", func(t *testing.T) {
+		listcap.SetMax(2)
+		t.Cleanup(func() {
+			listcap.SetMax(0)
+		})
+
+		list := func(query string) (*http.Response, simulatorPagerDuty.ListServicesResponse) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/services"+query, http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("X-Session-ID", sessionID)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err, "ListServices should succeed")
+			defer func() { _ = resp.Body.Close() }()
+			var page simulatorPagerDuty.ListServicesResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+			return resp, page
+		}
+
+		resp, page := list("?limit=100")
+
+		// Assertions
+		assert.Len(t, page.Services, 2, "Page should be cut to the cap")
+		assert.Equal(t, 2, page.Limit, "Limit should report the capped page size")
+		assert.True(t, page.More, "Truncated page should report more")
+		assert.Equal(t, "true", resp.Header.Get(listcap.TruncatedHeader), "Truncated page should be flagged")
+
+		// Following the offset continues after the truncated page
+		resp, next := list("?limit=100&offset=2")
+		assert.Len(t, next.Services, 1, "Last page should hold the remaining service")
+		assert.NotEqual(t, page.Services[0].ID, next.Services[0].ID, "Next page should continue the listing")
+		assert.False(t, next.More, "Last page should not report more")
+		assert.Empty(t, resp.Header.Get(listcap.TruncatedHeader), "A page that reaches the end should not be flagged")
+	})
 }
 
 func TestPagerDutySimulatorListEscalationPolicies(t *testing.T) {
@@ -418,6 +454,83 @@ func TestPagerDutySimulatorListOnCalls(t *testing.T) {
 	})
 }
 
+func TestPagerDutySimulatorPriorities(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "pagerduty-test-session-priorities"
+
+	// Create test service
+	serviceID := createTestService(t, queries, sessionID)
+
+	// Setup test server and client
+	server, pdClient := setupTestServer(t, queries, sessionID)
+	defer server.Close()
+
+	t.Run("ListPriorities", func(t *testing.T) {
+		resp, err := pdClient.ListPrioritiesWithContext(context.Background(), pagerduty.ListPrioritiesOptions{})
+
+		// Assertions
+		require.NoError(t, err, "ListPriorities should not return error")
+		require.Len(t, resp.Priorities, 5, "Should return seeded P1-P5 priorities")
+		assert.Equal(t, "P1", resp.Priorities[0].Name, "First priority should be P1")
+		assert.Equal(t, "P5", resp.Priorities[4].Name, "Last priority should be P5")
+	})
+
+	t.Run("CreateIncidentWithPriority", func(t *testing.T) {
+		incident := pagerduty.CreateIncidentOptions{
+			Title:    "Checkout is down",
+			Service:  &pagerduty.APIReference{ID: serviceID, Type: "service_reference"},
+			Priority: &pagerduty.APIReference{ID: "P1", Type: "priority_reference"},
+		}
+
+		created, err := pdClient.CreateIncidentWithContext(context.Background(), "", &incident)
+		require.NoError(t, err, "CreateIncident should not return error")
+		require.NotNil(t, created.Priority, "Created incident should carry priority")
+		assert.Equal(t, "P1", created.Priority.ID, "Priority ID should match")
+
+		// Read it back
+		retrieved, err := pdClient.GetIncidentWithContext(context.Background(), created.ID)
+		require.NoError(t, err, "GetIncident should not return error")
+		require.NotNil(t, retrieved.Priority, "Retrieved incident should carry priority")
+		assert.Equal(t, "P1", retrieved.Priority.ID, "Priority ID should match")
+		assert.Equal(t, "P1", retrieved.Priority.Name, "Priority name should match")
+
+		// Downgrade the priority
+		_, err = pdClient.ManageIncidentsWithContext(context.Background(), "test@example.com", []pagerduty.ManageIncidentsOptions{
+			{
+				ID:       created.ID,
+				Type:     "incident_reference",
+				Priority: &pagerduty.APIReference{ID: "P3", Type: "priority_reference"},
+			},
+		})
+		require.NoError(t, err, "ManageIncidents should not return error")
+
+		updated, err := pdClient.GetIncidentWithContext(context.Background(), created.ID)
+		require.NoError(t, err, "GetIncident should not return error")
+		require.NotNil(t, updated.Priority, "Updated incident should carry priority")
+		assert.Equal(t, "P3", updated.Priority.ID, "Priority should be updated")
+		assert.Equal(t, "triggered", updated.Status, "Status should be unchanged")
+	})
+
+	t.Run("CreateIncidentWithUnknownPriority", func(t *testing.T) {
+		incident := pagerduty.CreateIncidentOptions{
+			Title:    "Unknown priority",
+			Service:  &pagerduty.APIReference{ID: serviceID, Type: "service_reference"},
+			Priority: &pagerduty.APIReference{ID: "P9", Type: "priority_reference"},
+		}
+
+		_, err := pdClient.CreateIncidentWithContext(context.Background(), "", &incident)
+
+		// Assertions
+		require.Error(t, err, "Should return error for unknown priority")
+		var apiErr pagerduty.APIError
+		require.ErrorAs(t, err, &apiErr, "Error should be an API error")
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode, "Should return 400 for unknown priority")
+	})
+}
+
 func TestPagerDutySimulatorEndToEnd(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -2,16 +2,17 @@ package resend
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
 	"github.com/recreate-run/nova-simulators/internal/session"
+	"github.com/recreate-run/nova-simulators/internal/webhook"
 )
 
 // Resend API request/response structures
@@ -41,6 +42,15 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	count, err := queries.CountResendSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"emails": count}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[resend] → %s %s", r.Method, r.URL.Path)
@@ -95,12 +105,12 @@ func (h *Handler) handleSendEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Generate email ID
-	emailID := generateEmailID()
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Generate email ID
+	emailID := generateEmailID(sessionID)
+
 	// Convert arrays to JSON strings for storage
 	toJSON, _ := json.Marshal(req.To)
 	var ccJSON, bccJSON sql.NullString
@@ -136,6 +146,16 @@ func (h *Handler) handleSendEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := webhook.Emit(r.Context(), "resend", "email.sent", EmailEvent{
+		EmailID:   emailID,
+		From:      req.From,
+		To:        req.To,
+		Subject:   req.Subject,
+		CreatedAt: session.Now(sessionID).UTC().Format(time.RFC3339),
+	}); err != nil {
+		log.Printf("[resend] ✗ Failed to queue email.sent webhook: %v", err)
+	}
+
 	response := SendEmailResponse{
 		ID: emailID,
 	}
@@ -148,8 +168,8 @@ func (h *Handler) handleSendEmail(w http.ResponseWriter, r *http.Request) {
 
 // Helper functions
 
-func generateEmailID() string {
+func generateEmailID(sessionID string) string {
 	b := make([]byte, 16)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return hex.EncodeToString(b)
 }
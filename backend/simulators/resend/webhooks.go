@@ -0,0 +1,43 @@
+package resend
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/recreate-run/nova-simulators/internal/signing"
+	"github.com/recreate-run/nova-simulators/internal/webhook"
+)
+
+// EmailEvent is the data of an email.* webhook
+type EmailEvent struct {
+	EmailID   string   `json:"email_id"`
+	From      string   `json:"from"`
+	To        []string `json:"to"`
+	Subject   string   `json:"subject"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// WebhookProvider wraps events in Resend's {type, created_at, data} envelope and signs them
+// with Svix, as Resend does
+func WebhookProvider() webhook.Provider {
+	return webhook.Provider{
+		Payload: func(event string, data interface{}) (interface{}, error) {
+			// Email events carry the session clock's time; the envelope reuses it
+			createdAt := time.Now().UTC().Format(time.RFC3339)
+			if email, ok := data.(EmailEvent); ok {
+				createdAt = email.CreatedAt
+			}
+			return map[string]interface{}{
+				"type":       event,
+				"created_at": createdAt,
+				"data":       data,
+			}, nil
+		},
+		Headers: func(req *webhook.Request) (http.Header, error) {
+			if req.Secret == "" {
+				return nil, nil
+			}
+			return signing.SignSvix(req.Secret, "msg_"+req.ID, req.Timestamp, req.Payload)
+		},
+	}
+}
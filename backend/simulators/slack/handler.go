@@ -2,25 +2,41 @@ package slack
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/recreate-run/nova-simulators/internal/database"
+	"github.com/recreate-run/nova-simulators/internal/listcap"
+	"github.com/recreate-run/nova-simulators/internal/pagetoken"
 	"github.com/recreate-run/nova-simulators/internal/session"
 )
 
 type Message struct {
-	Type      string `json:"type"`
-	User      string `json:"user"`
-	Text      string `json:"text"`
-	Timestamp string `json:"ts"`
+	Type            string          `json:"type"`
+	User            string          `json:"user"`
+	Text            string          `json:"text"`
+	Timestamp       string          `json:"ts"`
+	ThreadTimestamp string          `json:"thread_ts,omitempty"`
+	ReplyCount      int64           `json:"reply_count,omitempty"`
+	Blocks          json.RawMessage `json:"blocks,omitempty"`
+	Reactions       []Reaction      `json:"reactions,omitempty"`
+}
+
+// Reaction is one emoji on a message with the users who left it
+type Reaction struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Users []string `json:"users"`
 }
 
 type Channel struct {
@@ -29,42 +45,167 @@ type Channel struct {
 	Created int64  `json:"created"`
 }
 
+// ChannelTopic is a channel's topic or purpose
+type ChannelTopic struct {
+	Value   string `json:"value"`
+	Creator string `json:"creator"`
+	LastSet int64  `json:"last_set"`
+}
+
+// ChannelInfo is the full channel object returned by conversations.info, join and setTopic
+type ChannelInfo struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Created    int64        `json:"created"`
+	IsChannel  bool         `json:"is_channel"`
+	IsMember   bool         `json:"is_member"`
+	NumMembers int64        `json:"num_members"`
+	Topic      ChannelTopic `json:"topic"`
+	Purpose    ChannelTopic `json:"purpose"`
+}
+
+// ResponseMetadata carries pagination cursors and warnings. List methods always include it.
+type ResponseMetadata struct {
+	NextCursor string   `json:"next_cursor,omitempty"`
+	Warnings   []string `json:"warnings,omitempty"`
+}
+
+// WarningsRequest configures the warnings attached to a session's responses
+type WarningsRequest struct {
+	Warnings []string `json:"warnings"`
+}
+
+type WarningsResponse struct {
+	OK       bool     `json:"ok"`
+	Warnings []string `json:"warnings"`
+}
+
 // Slack API response structures
 type SlackResponse struct {
-	OK      bool   `json:"ok"`
-	Error   string `json:"error,omitempty"`
-	Channel string `json:"channel,omitempty"`
-	TS      string `json:"ts,omitempty"`
+	OK               bool              `json:"ok"`
+	Error            string            `json:"error,omitempty"`
+	Channel          string            `json:"channel,omitempty"`
+	TS               string            `json:"ts,omitempty"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+type ConversationResponse struct {
+	OK               bool              `json:"ok"`
+	Error            string            `json:"error,omitempty"`
+	Channel          *ChannelInfo      `json:"channel,omitempty"`
+	AlreadyInChannel bool              `json:"already_in_channel,omitempty"`
+	NotInChannel     bool              `json:"not_in_channel,omitempty"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+// maxScheduleAhead is how far in the future chat.scheduleMessage accepts a post_at
+const maxScheduleAhead = 120 * 24 * time.Hour
+
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	Channel     string `json:"channel_id"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text"`
+}
+
+type ScheduleMessageResponse struct {
+	OK                 bool              `json:"ok"`
+	Error              string            `json:"error,omitempty"`
+	Channel            string            `json:"channel,omitempty"`
+	ScheduledMessageID string            `json:"scheduled_message_id,omitempty"`
+	PostAt             int64             `json:"post_at,omitempty"`
+	Warning            string            `json:"warning,omitempty"`
+	ResponseMetadata   *ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+type ScheduledMessagesListResponse struct {
+	OK                bool               `json:"ok"`
+	ScheduledMessages []ScheduledMessage `json:"scheduled_messages"`
+	Warning           string             `json:"warning,omitempty"`
+	ResponseMetadata  ResponseMetadata   `json:"response_metadata"`
+}
+
+type PostEphemeralResponse struct {
+	OK               bool              `json:"ok"`
+	MessageTS        string            `json:"message_ts"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+type EphemeralMessage struct {
+	Channel   string `json:"channel"`
+	User      string `json:"user"`
+	Text      string `json:"text"`
+	Timestamp string `json:"ts"`
+}
+
+type EphemeralListResponse struct {
+	OK               bool               `json:"ok"`
+	Messages         []EphemeralMessage `json:"messages"`
+	Warning          string             `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata   `json:"response_metadata"`
 }
 
 type AuthTestResponse struct {
-	OK     bool   `json:"ok"`
-	URL    string `json:"url"`
-	Team   string `json:"team"`
-	User   string `json:"user"`
-	TeamID string `json:"team_id"`
-	UserID string `json:"user_id"`
+	OK               bool              `json:"ok"`
+	URL              string            `json:"url"`
+	Team             string            `json:"team"`
+	User             string            `json:"user"`
+	TeamID           string            `json:"team_id"`
+	UserID           string            `json:"user_id"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
 }
 
 type ConversationsListResponse struct {
-	OK       bool      `json:"ok"`
-	Channels []Channel `json:"channels"`
+	OK               bool             `json:"ok"`
+	Channels         []Channel        `json:"channels"`
+	Warning          string           `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 type ConversationHistoryResponse struct {
-	OK       bool      `json:"ok"`
-	Messages []Message `json:"messages"`
+	OK               bool             `json:"ok"`
+	Messages         []Message        `json:"messages"`
+	HasMore          bool             `json:"has_more"`
+	Warning          string           `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
+}
+
+type ReactionsGetResponse struct {
+	OK               bool              `json:"ok"`
+	Type             string            `json:"type"`
+	Channel          string            `json:"channel"`
+	Message          Message           `json:"message"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+// ConversationRepliesResponse is a thread's parent message followed by its replies
+type ConversationRepliesResponse struct {
+	OK               bool             `json:"ok"`
+	Messages         []Message        `json:"messages"`
+	HasMore          bool             `json:"has_more"`
+	Warning          string           `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 type GetUploadURLResponse struct {
-	OK        bool   `json:"ok"`
-	UploadURL string `json:"upload_url"`
-	FileID    string `json:"file_id"`
+	OK               bool              `json:"ok"`
+	UploadURL        string            `json:"upload_url"`
+	FileID           string            `json:"file_id"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
 }
 
 type CompleteUploadResponse struct {
-	OK    bool          `json:"ok"`
-	Files []UploadedFile `json:"files"`
+	OK               bool              `json:"ok"`
+	Files            []UploadedFile    `json:"files"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
 }
 
 type UploadedFile struct {
@@ -103,10 +244,52 @@ type User struct {
 }
 
 type UserInfoResponse struct {
-	OK   bool `json:"ok"`
-	User User `json:"user"`
+	OK               bool              `json:"ok"`
+	User             User              `json:"user"`
+	Warning          string            `json:"warning,omitempty"`
+	ResponseMetadata *ResponseMetadata `json:"response_metadata,omitempty"`
+}
+
+type UsersListResponse struct {
+	OK               bool             `json:"ok"`
+	Members          []User           `json:"members"`
+	Warning          string           `json:"warning,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
+// botUserID is the user the simulator authenticates every token as
+const botUserID = "U123456"
+
+// maxTopicLength is the longest topic or purpose Slack accepts
+const maxTopicLength = 250
+
+// usersListLimit and maxUsersListLimit are the default and largest page sizes users.list accepts
+const (
+	usersListLimit    = 200
+	maxUsersListLimit = 1000
+)
+
+// usersPageScope scopes users.list cursors so they cannot be replayed against other lists
+const usersPageScope = "slack.users"
+
+// conversationsLimit and maxConversationsLimit are the default and largest page sizes of
+// conversations.list and conversations.history
+const (
+	conversationsLimit    = 100
+	maxConversationsLimit = 1000
+)
+
+// conversationsPageScope and historyPageScope scope the cursors of conversations.list and
+// conversations.history
+const (
+	conversationsPageScope = "slack.conversations"
+	historyPageScope       = "slack.history"
+)
+
+// pagingParams are the form fields left out of a cursor's filters: the cursor itself, the page
+// size, which may change between pages, and the token, which the SDK may send as a field
+var pagingParams = []string{"cursor", "limit", "token"}
+
 // Handler implements the Slack simulator HTTP handler
 type Handler struct {
 	queries *database.Queries
@@ -119,30 +302,62 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	counts, err := queries.CountSlackSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"channels": counts.Channels,
+		"users":    counts.Users,
+		"messages": counts.Messages,
+		"files":    counts.Files,
+	}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/auth.test", h.handleAuthTest)
 	mux.HandleFunc("/api/chat.postMessage", h.handlePostMessage)
+	mux.HandleFunc("/api/chat.postEphemeral", h.handlePostEphemeral)
+	mux.HandleFunc("/api/chat.scheduleMessage", h.handleScheduleMessage)
+	mux.HandleFunc("/api/chat.scheduledMessages.list", h.handleListScheduledMessages)
+	mux.HandleFunc("/api/chat.deleteScheduledMessage", h.handleDeleteScheduledMessage)
 	mux.HandleFunc("/api/conversations.list", h.handleConversationsList)
 	mux.HandleFunc("/api/conversations.history", h.handleConversationHistory)
+	mux.HandleFunc("/api/conversations.replies", h.handleConversationReplies)
+	mux.HandleFunc("/api/conversations.info", h.handleConversationInfo)
+	mux.HandleFunc("/api/conversations.join", h.handleConversationJoin)
+	mux.HandleFunc("/api/conversations.leave", h.handleConversationLeave)
+	mux.HandleFunc("/api/conversations.setTopic", h.handleSetTopic)
+	mux.HandleFunc("/api/conversations.setPurpose", h.handleSetPurpose)
+	mux.HandleFunc("/api/reactions.add", h.handleReactionsAdd)
+	mux.HandleFunc("/api/reactions.get", h.handleReactionsGet)
 	mux.HandleFunc("/api/files.getUploadURLExternal", h.handleGetUploadURL)
 	mux.HandleFunc("/api/files.completeUploadExternal", h.handleCompleteUpload)
 	mux.HandleFunc("/api/users.info", h.handleUserInfo)
+	mux.HandleFunc("/api/users.list", h.handleUsersList)
 	mux.HandleFunc("/upload/", h.handleFileUpload)
+	mux.HandleFunc("/debug/ephemerals", h.handleListEphemerals)
+	mux.HandleFunc("/debug/warnings", h.handleWarnings)
 	mux.ServeHTTP(w, r)
 }
 
 func (h *Handler) handleAuthTest(w http.ResponseWriter, r *http.Request) {
 	log.Println("[slack] → Received auth.test request")
 
+	warning, warnings := h.responseWarnings(session.FromContext(r.Context()))
 	response := AuthTestResponse{
-		OK:     true,
-		URL:    "https://test-workspace.slack.com/",
-		Team:   "Test Workspace",
-		User:   "test-user",
-		TeamID: "T123456",
-		UserID: "U123456",
+		OK:               true,
+		URL:              "https://test-workspace.slack.com/",
+		Team:             "Test Workspace",
+		User:             "test-user",
+		TeamID:           "T123456",
+		UserID:           "U123456",
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -150,6 +365,26 @@ func (h *Handler) handleAuthTest(w http.ResponseWriter, r *http.Request) {
 	log.Println("[slack] ✓ Auth test successful")
 }
 
+var (
+	// lastTimestamps holds the most recent message ts handed out in each session, in microseconds
+	lastTimestamps  = make(map[string]int64)
+	lastTimestampMu sync.Mutex
+)
+
+// messageTimestamp returns a new message ts from the session's clock. A frozen test clock would
+// hand out the same ts twice, so each ts is at least a microsecond after the previous one.
+func messageTimestamp(sessionID string) string {
+	lastTimestampMu.Lock()
+	defer lastTimestampMu.Unlock()
+
+	micros := session.Now(sessionID).UnixMicro()
+	if last := lastTimestamps[sessionID]; micros <= last {
+		micros = last + 1
+	}
+	lastTimestamps[sessionID] = micros
+	return fmt.Sprintf("%d.%06d", micros/1e6, micros%1e6)
+}
+
 func (h *Handler) handlePostMessage(w http.ResponseWriter, r *http.Request) {
 	log.Println("[slack] → Received chat.postMessage request")
 
@@ -165,19 +400,40 @@ func (h *Handler) handlePostMessage(w http.ResponseWriter, r *http.Request) {
 	channel := r.FormValue("channel")
 	text := r.FormValue("text")
 	attachments := r.FormValue("attachments")
+	blocks := r.FormValue("blocks")
+	threadTS := r.FormValue("thread_ts")
 
 	log.Printf("[slack]   Token: %s", token)
 	log.Printf("[slack]   Channel: %s", channel)
 	log.Printf("[slack]   Text: %s", text)
+	if threadTS != "" {
+		log.Printf("[slack]   Thread: %s", threadTS)
+	}
 	if attachments != "" {
 		log.Printf("[slack]   Attachments: %s", attachments)
 	}
+	if blocks != "" {
+		log.Printf("[slack]   Blocks: %s", blocks)
+	}
+
+	// Blocks arrive as a JSON-encoded array of Block Kit blocks
+	var blocksJSON sql.NullString
+	if blocks != "" {
+		var parsed []map[string]interface{}
+		if err := json.Unmarshal([]byte(blocks), &parsed); err != nil {
+			log.Printf("[slack] ✗ Invalid blocks: %v", err)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_blocks"})
+			return
+		}
+		blocksJSON = sql.NullString{String: blocks, Valid: true}
+	}
 
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
 	// Store message in database
-	timestamp := fmt.Sprintf("%d.%06d", time.Now().Unix(), time.Now().Nanosecond()/1000)
+	timestamp := messageTimestamp(sessionID)
 
 	// Convert attachments to database-compatible format
 	var attachmentsJSON sql.NullString
@@ -192,6 +448,8 @@ func (h *Handler) handlePostMessage(w http.ResponseWriter, r *http.Request) {
 		Text:        text,
 		Timestamp:   timestamp,
 		Attachments: attachmentsJSON,
+		Blocks:      blocksJSON,
+		ThreadTs:    sql.NullString{String: threadTS, Valid: threadTS != ""},
 		SessionID:   sessionID,
 	})
 
@@ -202,10 +460,13 @@ func (h *Handler) handlePostMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	warning, warnings := h.responseWarnings(sessionID)
 	response := SlackResponse{
-		OK:      true,
-		Channel: channel,
-		TS:      timestamp,
+		OK:               true,
+		Channel:          channel,
+		TS:               timestamp,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -213,10 +474,9 @@ func (h *Handler) handlePostMessage(w http.ResponseWriter, r *http.Request) {
 	log.Println("[slack] ✓ Message posted successfully")
 }
 
-func (h *Handler) handleConversationsList(w http.ResponseWriter, r *http.Request) {
-	log.Println("[slack] → Received conversations.list request")
+func (h *Handler) handlePostEphemeral(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received chat.postEphemeral request")
 
-	// Parse form data
 	if err := r.ParseForm(); err != nil {
 		log.Printf("[slack] ✗ Failed to parse form: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
@@ -224,45 +484,64 @@ func (h *Handler) handleConversationsList(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	token := r.FormValue("token")
-	log.Printf("[slack]   Token: %s", token)
+	channel := r.FormValue("channel")
+	user := r.FormValue("user")
+	text := r.FormValue("text")
 
-	// Extract session ID from context
-	sessionID := session.FromContext(r.Context())
+	log.Printf("[slack]   Channel: %s", channel)
+	log.Printf("[slack]   User: %s", user)
+	log.Printf("[slack]   Text: %s", text)
 
-	// Query channels from database
-	dbChannels, err := h.queries.ListChannels(context.Background(), sessionID)
-	if err != nil {
-		log.Printf("[slack] ✗ Failed to query channels: %v", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+	w.Header().Set("Content-Type", "application/json")
+
+	if channel == "" {
+		log.Println("[slack] ✗ Missing channel")
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "channel_not_found"})
 		return
 	}
-
-	// Convert to response format
-	channels := make([]Channel, 0, len(dbChannels))
-	for _, ch := range dbChannels {
-		channels = append(channels, Channel{
-			ID:      ch.ID,
-			Name:    ch.Name,
-			Created: ch.CreatedAt,
-		})
+	if user == "" {
+		log.Println("[slack] ✗ Missing user")
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "user_not_found"})
+		return
+	}
+	if text == "" {
+		log.Println("[slack] ✗ Missing text")
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "no_text"})
+		return
 	}
 
-	response := ConversationsListResponse{
-		OK:       true,
-		Channels: channels,
+	sessionID := session.FromContext(r.Context())
+	timestamp := messageTimestamp(sessionID)
+
+	// Ephemerals live in their own table so they never show up in conversations.history
+	err := h.queries.CreateEphemeralMessage(context.Background(), database.CreateEphemeralMessageParams{
+		ChannelID: channel,
+		UserID:    user,
+		Text:      text,
+		Timestamp: timestamp,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to insert ephemeral message: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[slack] ✓ Returned %d channels", len(channels))
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(PostEphemeralResponse{
+		OK:               true,
+		MessageTS:        timestamp,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	})
+	log.Println("[slack] ✓ Ephemeral message posted successfully")
 }
 
-func (h *Handler) handleConversationHistory(w http.ResponseWriter, r *http.Request) {
-	log.Println("[slack] → Received conversations.history request")
+// handleListEphemerals is a debug endpoint returning the ephemeral messages sent to a user
+func (h *Handler) handleScheduleMessage(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received chat.scheduleMessage request")
 
-	// Parse form data
 	if err := r.ParseForm(); err != nil {
 		log.Printf("[slack] ✗ Failed to parse form: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
@@ -270,51 +549,67 @@ func (h *Handler) handleConversationHistory(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	token := r.FormValue("token")
-	channelID := r.FormValue("channel")
-	log.Printf("[slack]   Token: %s", token)
-	log.Printf("[slack]   Channel: %s", channelID)
+	channel := r.FormValue("channel")
+	text := r.FormValue("text")
+	log.Printf("[slack]   Channel: %s", channel)
+	log.Printf("[slack]   Post at: %s", r.FormValue("post_at"))
 
-	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
 
-	// Query messages from database
-	dbMessages, err := h.queries.GetMessagesByChannel(context.Background(), database.GetMessagesByChannelParams{
-		ChannelID: channelID,
+	// post_at is checked against the session clock, which tests can advance through /api/tick
+	postAt, err := strconv.ParseInt(r.FormValue("post_at"), 10, 64)
+	now := session.Now(sessionID)
+	var slackErr string
+	switch {
+	case channel == "":
+		slackErr = "channel_not_found"
+	case text == "":
+		slackErr = "no_text"
+	case err != nil:
+		slackErr = "invalid_time"
+	case postAt <= now.Unix():
+		slackErr = "time_in_past"
+	case postAt > now.Add(maxScheduleAhead).Unix():
+		slackErr = "time_too_far"
+	}
+	if slackErr != "" {
+		log.Printf("[slack] ✗ Cannot schedule message: %s", slackErr)
+		_ = json.NewEncoder(w).Encode(ScheduleMessageResponse{OK: false, Error: slackErr})
+		return
+	}
+
+	id := generateScheduledMessageID(sessionID)
+	err = h.queries.CreateSlackScheduledMessage(context.Background(), database.CreateSlackScheduledMessageParams{
+		ID:        id,
+		ChannelID: channel,
+		UserID:    botUserID,
+		Text:      text,
+		PostAt:    postAt,
 		SessionID: sessionID,
 	})
 	if err != nil {
-		log.Printf("[slack] ✗ Failed to query messages: %v", err)
+		log.Printf("[slack] ✗ Failed to store scheduled message: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
 		return
 	}
 
-	// Convert to response format
-	messages := make([]Message, 0, len(dbMessages))
-	for _, msg := range dbMessages {
-		messages = append(messages, Message{
-			Type:      msg.Type,
-			User:      msg.UserID,
-			Text:      msg.Text,
-			Timestamp: msg.Timestamp,
-		})
-	}
-
-	response := ConversationHistoryResponse{
-		OK:       true,
-		Messages: messages,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[slack] ✓ Returned %d messages", len(messages))
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(ScheduleMessageResponse{
+		OK:                 true,
+		Channel:            channel,
+		ScheduledMessageID: id,
+		PostAt:             postAt,
+		Warning:            warning,
+		ResponseMetadata:   warningMetadata(warnings),
+	})
+	log.Printf("[slack] ✓ Scheduled message %s for %d", id, postAt)
 }
 
-func (h *Handler) handleGetUploadURL(w http.ResponseWriter, r *http.Request) {
-	log.Println("[slack] → Received files.getUploadURLExternal request")
+func (h *Handler) handleListScheduledMessages(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received chat.scheduledMessages.list request")
 
-	// Parse form data
 	if err := r.ParseForm(); err != nil {
 		log.Printf("[slack] ✗ Failed to parse form: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
@@ -322,54 +617,45 @@ func (h *Handler) handleGetUploadURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	filename := r.FormValue("filename")
-	lengthStr := r.FormValue("length")
-	length, _ := strconv.ParseInt(lengthStr, 10, 64)
-
-	log.Printf("[slack]   Filename: %s", filename)
-	log.Printf("[slack]   Length: %d", length)
-
-	// Extract session ID from context
+	channel := r.FormValue("channel")
 	sessionID := session.FromContext(r.Context())
 
-	// Generate file ID
-	fileID := generateFileID()
-	// Use files.slack.com so the HTTP interceptor can route it properly in tests
-	uploadURL := fmt.Sprintf("http://files.slack.com/upload/%s", fileID)
-
-	// Store file metadata in database
-	err := h.queries.CreateFile(context.Background(), database.CreateFileParams{
-		ID:        fileID,
-		Filename:  filename,
-		Title:     sql.NullString{String: filename, Valid: true},
-		Size:      length,
-		UploadUrl: sql.NullString{String: uploadURL, Valid: true},
-		UserID:    "U123456",
-		SessionID: sessionID,
-	})
-
+	rows, err := h.queries.ListSlackScheduledMessages(context.Background(), sessionID)
 	if err != nil {
-		log.Printf("[slack] ✗ Failed to store file metadata: %v", err)
+		log.Printf("[slack] ✗ Failed to list scheduled messages: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
 		return
 	}
 
-	response := GetUploadURLResponse{
-		OK:        true,
-		UploadURL: uploadURL,
-		FileID:    fileID,
+	messages := make([]ScheduledMessage, 0, len(rows))
+	for i := range rows {
+		if channel != "" && rows[i].ChannelID != channel {
+			continue
+		}
+		messages = append(messages, ScheduledMessage{
+			ID:          rows[i].ID,
+			Channel:     rows[i].ChannelID,
+			PostAt:      rows[i].PostAt,
+			DateCreated: rows[i].CreatedAt,
+			Text:        rows[i].Text,
+		})
 	}
 
+	warning, warnings := h.responseWarnings(sessionID)
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[slack] ✓ Generated upload URL for file ID: %s", fileID)
+	_ = json.NewEncoder(w).Encode(ScheduledMessagesListResponse{
+		OK:                true,
+		ScheduledMessages: messages,
+		Warning:           warning,
+		ResponseMetadata:  ResponseMetadata{Warnings: warnings},
+	})
+	log.Printf("[slack] ✓ Returned %d scheduled messages", len(messages))
 }
 
-func (h *Handler) handleCompleteUpload(w http.ResponseWriter, r *http.Request) {
-	log.Println("[slack] → Received files.completeUploadExternal request")
+func (h *Handler) handleDeleteScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received chat.deleteScheduledMessage request")
 
-	// Parse form data
 	if err := r.ParseForm(); err != nil {
 		log.Printf("[slack] ✗ Failed to parse form: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
@@ -377,46 +663,119 @@ func (h *Handler) handleCompleteUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	filesJSON := r.FormValue("files")
-	channelID := r.FormValue("channel_id")
-
-	log.Printf("[slack]   Files JSON: %s", filesJSON)
-	log.Printf("[slack]   Channel ID: %s", channelID)
+	id := r.FormValue("scheduled_message_id")
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
 
-	// Parse files array
-	var files []map[string]string
-	if err := json.Unmarshal([]byte(filesJSON), &files); err != nil {
-		log.Printf("[slack] ✗ Failed to parse files JSON: %v", err)
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_json"})
+	deleted, err := h.queries.DeleteSlackScheduledMessage(context.Background(), database.DeleteSlackScheduledMessageParams{
+		ID:        id,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to delete scheduled message: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+	if deleted == 0 {
+		log.Printf("[slack] ✗ Scheduled message not found: %s", id)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_scheduled_message_id"})
 		return
 	}
 
-	uploadedFiles := make([]UploadedFile, 0, len(files))
-	for _, file := range files {
-		fileID := file["id"]
-		title := file["title"]
-
-		uploadedFiles = append(uploadedFiles, UploadedFile{
-			ID:    fileID,
-			Title: title,
-		})
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(SlackResponse{OK: true, Warning: warning, ResponseMetadata: warningMetadata(warnings)})
+	log.Printf("[slack] ✓ Deleted scheduled message %s", id)
+}
 
-		log.Printf("[slack]   Completed upload for file: %s (%s)", fileID, title)
+// FireScheduledMessages posts the session's scheduled messages that are due at now, stamped
+// with their post_at time. It is registered with the session manager's /api/tick fan-out.
+func FireScheduledMessages(ctx context.Context, queries *database.Queries, sessionID string, now time.Time) (int, error) {
+	due, err := queries.ListDueSlackScheduledMessages(ctx, database.ListDueSlackScheduledMessagesParams{
+		SessionID: sessionID,
+		PostAt:    now.Unix(),
+	})
+	if err != nil || len(due) == 0 {
+		return 0, err
 	}
 
-	response := CompleteUploadResponse{
-		OK:    true,
-		Files: uploadedFiles,
+	err = queries.ExecTx(ctx, func(q *database.Queries) error {
+		for i := range due {
+			msg := &due[i]
+			err := q.CreateMessage(ctx, database.CreateMessageParams{
+				ChannelID: msg.ChannelID,
+				Type:      "message",
+				UserID:    msg.UserID,
+				Text:      msg.Text,
+				Timestamp: fmt.Sprintf("%d.%06d", msg.PostAt, i),
+				SessionID: sessionID,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := q.DeleteSlackScheduledMessage(ctx, database.DeleteSlackScheduledMessageParams{
+				ID:        msg.ID,
+				SessionID: sessionID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
+	log.Printf("[slack] ✓ Posted %d scheduled messages", len(due))
+	return len(due), nil
+}
+
+func (h *Handler) handleListEphemerals(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received debug ephemerals request")
+
+	user := r.URL.Query().Get("user")
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(response)
-	log.Printf("[slack] ✓ Completed upload for %d files", len(uploadedFiles))
+	if user == "" {
+		log.Println("[slack] ✗ Missing user")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "user_not_found"})
+		return
+	}
+
+	sessionID := session.FromContext(r.Context())
+	rows, err := h.queries.ListEphemeralMessagesByUser(context.Background(), database.ListEphemeralMessagesByUserParams{
+		UserID:    user,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query ephemeral messages: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	messages := make([]EphemeralMessage, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, EphemeralMessage{
+			Channel:   row.ChannelID,
+			User:      row.UserID,
+			Text:      row.Text,
+			Timestamp: row.Timestamp,
+		})
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(EphemeralListResponse{
+		OK:               true,
+		Messages:         messages,
+		Warning:          warning,
+		ResponseMetadata: ResponseMetadata{Warnings: warnings},
+	})
+	log.Printf("[slack] ✓ Returned %d ephemeral messages", len(messages))
 }
 
-func (h *Handler) handleUserInfo(w http.ResponseWriter, r *http.Request) {
-	log.Println("[slack] → Received users.info request")
+func (h *Handler) handleConversationsList(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received conversations.list request")
 
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
@@ -426,63 +785,904 @@ func (h *Handler) handleUserInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := r.FormValue("user")
-	log.Printf("[slack]   User ID: %s", userID)
+	token := r.FormValue("token")
+	log.Printf("[slack]   Token: %s", token)
 
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
-	// Query user from database
-	dbUser, err := h.queries.GetUserByID(context.Background(), database.GetUserByIDParams{
-		ID:        userID,
-		SessionID: sessionID,
-	})
+	// Query channels from database, including those of the parent session
+	dbChannels, err := session.ListThrough(r.Context(), "slack_channels", func(candidate string) ([]database.ListChannelsRow, error) {
+		return h.queries.ListChannels(context.Background(), candidate)
+	}, nil, func(ch database.ListChannelsRow) string { return ch.ID })
 	if err != nil {
-		log.Printf("[slack] ✗ Failed to query user: %v", err)
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "user_not_found"})
+		log.Printf("[slack] ✗ Failed to query channels: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
 		return
 	}
 
 	// Convert to response format
-	user := User{
-		ID:       dbUser.ID,
-		TeamID:   dbUser.TeamID,
-		Name:     dbUser.Name,
-		Deleted:  false,
-		RealName: dbUser.RealName,
-		TZ:       dbUser.Timezone.String,
-		TZLabel:  dbUser.TimezoneLabel.String,
-		TZOffset: int(dbUser.TimezoneOffset.Int64),
-		Profile: UserProfile{
-			FirstName:   dbUser.FirstName.String,
-			LastName:    dbUser.LastName.String,
-			RealName:    dbUser.RealName,
-			DisplayName: dbUser.DisplayName.String,
-			Email:       dbUser.Email.String,
-			Image24:     dbUser.Image24.String,
-			Image32:     dbUser.Image32.String,
-			Image48:     dbUser.Image48.String,
-			Image72:     dbUser.Image72.String,
-			Image192:    dbUser.Image192.String,
-			Image512:    dbUser.Image512.String,
-		},
-		IsAdmin: dbUser.IsAdmin != 0,
-		IsOwner: dbUser.IsOwner != 0,
-		IsBot:   dbUser.IsBot != 0,
-		Updated: dbUser.CreatedAt,
-	}
-
-	response := UserInfoResponse{
-		OK:   true,
-		User: user,
-	}
-
+	channels := make([]Channel, 0, len(dbChannels))
+	for _, ch := range dbChannels {
+		channels = append(channels, Channel{
+			ID:      ch.ID,
+			Name:    ch.Name,
+			Created: ch.CreatedAt,
+		})
+	}
+
+	channels, nextCursor, err := pageOf(w, r, sessionID, conversationsPageScope, channels)
+	if err != nil {
+		log.Printf("[slack] ✗ Rejected cursor: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_cursor"})
+		return
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	response := ConversationsListResponse{
+		OK:               true,
+		Channels:         channels,
+		Warning:          warning,
+		ResponseMetadata: ResponseMetadata{NextCursor: nextCursor, Warnings: warnings},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[slack] ✓ Returned %d channels", len(channels))
+}
+
+func (h *Handler) handleConversationHistory(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received conversations.history request")
+
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	token := r.FormValue("token")
+	channelID := r.FormValue("channel")
+	log.Printf("[slack]   Token: %s", token)
+	log.Printf("[slack]   Channel: %s", channelID)
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	// Deliver scheduled messages that came due since the last read
+	if _, err := FireScheduledMessages(context.Background(), h.queries, sessionID, session.Now(sessionID)); err != nil {
+		log.Printf("[slack] ✗ Failed to deliver scheduled messages: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	// Query messages from database, including those of the parent session, newest first
+	dbMessages, err := session.ListThrough(r.Context(), "slack_messages:"+channelID, func(candidate string) ([]database.GetMessagesByChannelRow, error) {
+		return h.queries.GetMessagesByChannel(context.Background(), database.GetMessagesByChannelParams{
+			ChannelID: channelID,
+			SessionID: candidate,
+		})
+	}, nil, func(msg database.GetMessagesByChannelRow) string { return msg.Timestamp })
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query messages: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+	sort.SliceStable(dbMessages, func(i, j int) bool { return dbMessages[i].Timestamp > dbMessages[j].Timestamp })
+
+	// Convert to response format
+	messages := make([]Message, 0, len(dbMessages))
+	for _, msg := range dbMessages {
+		message := Message{
+			Type:       msg.Type,
+			User:       msg.UserID,
+			Text:       msg.Text,
+			Timestamp:  msg.Timestamp,
+			ReplyCount: msg.ReplyCount,
+		}
+		// Slack marks a parent with its own timestamp once it has replies
+		if msg.ReplyCount > 0 {
+			message.ThreadTimestamp = msg.Timestamp
+		}
+		if msg.Blocks.Valid {
+			message.Blocks = json.RawMessage(msg.Blocks.String)
+		}
+		messages = append(messages, message)
+	}
+
+	messages, nextCursor, err := pageOf(w, r, sessionID, historyPageScope, messages)
+	if err != nil {
+		log.Printf("[slack] ✗ Rejected cursor: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_cursor"})
+		return
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	response := ConversationHistoryResponse{
+		OK:               true,
+		Messages:         messages,
+		HasMore:          nextCursor != "",
+		Warning:          warning,
+		ResponseMetadata: ResponseMetadata{NextCursor: nextCursor, Warnings: warnings},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[slack] ✓ Returned %d messages", len(messages))
+}
+
+// pageOf returns the page of items selected by the request's limit and cursor, and the cursor
+// of the next page, or "" on the last page
+func pageOf[T any](w http.ResponseWriter, r *http.Request, sessionID, scope string, items []T) ([]T, string, error) {
+	limit := conversationsLimit
+	if raw := r.FormValue("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = min(n, maxConversationsLimit)
+		}
+	}
+	limit, clamped := listcap.Clamp(limit)
+
+	offset := 0
+	filters := pagetoken.Filters(r.Form, pagingParams...)
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		decoded, err := pagetoken.Decode(sessionID, scope, filters, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		offset = min(decoded, len(items))
+	}
+
+	end := min(offset+limit, len(items))
+	var nextCursor string
+	if end < len(items) {
+		nextCursor = pagetoken.Encode(sessionID, scope, filters, end)
+		if clamped {
+			listcap.MarkTruncated(w)
+		}
+	}
+	return items[offset:end], nextCursor, nil
+}
+
+func (h *Handler) handleConversationReplies(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received conversations.replies request")
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	ts := r.FormValue("ts")
+	log.Printf("[slack]   Channel: %s", channelID)
+	log.Printf("[slack]   Thread: %s", ts)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	// A reply's timestamp resolves to the thread it belongs to
+	message, err := h.queries.GetSlackMessage(context.Background(), database.GetSlackMessageParams{
+		ChannelID: channelID,
+		Timestamp: ts,
+		SessionID: sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[slack] ✗ Thread not found: %s", ts)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "thread_not_found"})
+		return
+	}
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query message: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+	if message.ThreadTs.Valid {
+		ts = message.ThreadTs.String
+	}
+
+	rows, err := h.queries.ListSlackThreadMessages(context.Background(), database.ListSlackThreadMessagesParams{
+		ChannelID: channelID,
+		SessionID: sessionID,
+		ThreadTs:  ts,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query thread: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	messages := make([]Message, 0, len(rows))
+	for _, row := range rows {
+		message := Message{
+			Type:            row.Type,
+			User:            row.UserID,
+			Text:            row.Text,
+			Timestamp:       row.Timestamp,
+			ThreadTimestamp: ts,
+		}
+		if row.Timestamp == ts {
+			message.ReplyCount = int64(len(rows) - 1)
+		}
+		if row.Blocks.Valid {
+			message.Blocks = json.RawMessage(row.Blocks.String)
+		}
+		messages = append(messages, message)
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(ConversationRepliesResponse{
+		OK:               true,
+		Messages:         messages,
+		Warning:          warning,
+		ResponseMetadata: ResponseMetadata{Warnings: warnings},
+	})
+	log.Printf("[slack] ✓ Returned %d messages in thread %s", len(messages), ts)
+}
+
+func (h *Handler) handleConversationInfo(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received conversations.info request")
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	log.Printf("[slack]   Channel: %s", channelID)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	channel, err := session.ReadThrough(r.Context(), "slack_channels", channelID, func(candidate string) (*ChannelInfo, error) {
+		return h.channelInfo(candidate, channelID)
+	})
+	if err != nil {
+		writeChannelError(w, err)
+		return
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(ConversationResponse{
+		OK:               true,
+		Channel:          channel,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	})
+	log.Printf("[slack] ✓ Returned info for channel: %s", channelID)
+}
+
+func (h *Handler) handleConversationJoin(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received conversations.join request")
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	log.Printf("[slack]   Channel: %s", channelID)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	if _, err := h.channelInfo(sessionID, channelID); err != nil {
+		writeChannelError(w, err)
+		return
+	}
+
+	joined, err := h.queries.JoinSlackChannel(context.Background(), database.JoinSlackChannelParams{
+		ChannelID: channelID,
+		UserID:    botUserID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to join channel: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	channel, err := h.channelInfo(sessionID, channelID)
+	if err != nil {
+		writeChannelError(w, err)
+		return
+	}
+
+	// Joining a channel the bot is already in succeeds with an already_in_channel warning
+	warning, warnings := h.responseWarnings(sessionID)
+	if joined == 0 {
+		warnings = append([]string{"already_in_channel"}, warnings...)
+		warning = strings.Join(warnings, ",")
+	}
+	_ = json.NewEncoder(w).Encode(ConversationResponse{
+		OK:               true,
+		Channel:          channel,
+		AlreadyInChannel: joined == 0,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	})
+	log.Printf("[slack] ✓ Joined channel: %s", channelID)
+}
+
+func (h *Handler) handleConversationLeave(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received conversations.leave request")
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	log.Printf("[slack]   Channel: %s", channelID)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	if _, err := h.channelInfo(sessionID, channelID); err != nil {
+		writeChannelError(w, err)
+		return
+	}
+
+	left, err := h.queries.LeaveSlackChannel(context.Background(), database.LeaveSlackChannelParams{
+		ChannelID: channelID,
+		UserID:    botUserID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to leave channel: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	// Leaving a channel the bot is not in still succeeds, flagged with not_in_channel
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(ConversationResponse{
+		OK:               true,
+		NotInChannel:     left == 0,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	})
+	log.Printf("[slack] ✓ Left channel: %s", channelID)
+}
+
+func (h *Handler) handleSetTopic(w http.ResponseWriter, r *http.Request) {
+	h.handleSetChannelText(w, r, "setTopic", "topic", func(sessionID, channelID, value string) error {
+		return h.queries.UpdateSlackChannelTopic(context.Background(), database.UpdateSlackChannelTopicParams{
+			Topic:        value,
+			TopicCreator: botUserID,
+			ID:           channelID,
+			SessionID:    sessionID,
+		})
+	})
+}
+
+func (h *Handler) handleSetPurpose(w http.ResponseWriter, r *http.Request) {
+	h.handleSetChannelText(w, r, "setPurpose", "purpose", func(sessionID, channelID, value string) error {
+		return h.queries.UpdateSlackChannelPurpose(context.Background(), database.UpdateSlackChannelPurposeParams{
+			Purpose:        value,
+			PurposeCreator: botUserID,
+			ID:             channelID,
+			SessionID:      sessionID,
+		})
+	})
+}
+
+// handleSetChannelText implements conversations.setTopic and conversations.setPurpose, which
+// only members of the channel may call. field is the form value holding the new text.
+func (h *Handler) handleSetChannelText(w http.ResponseWriter, r *http.Request, method, field string, update func(sessionID, channelID, value string) error) {
+	log.Printf("[slack] → Received conversations.%s request", method)
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	value := r.FormValue(field)
+	log.Printf("[slack]   Channel: %s", channelID)
+	log.Printf("[slack]   %s: %s", field, value)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	if len([]rune(value)) > maxTopicLength {
+		log.Printf("[slack] ✗ %s exceeds %d characters", field, maxTopicLength)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "too_long"})
+		return
+	}
+
+	channel, err := h.channelInfo(sessionID, channelID)
+	if err != nil {
+		writeChannelError(w, err)
+		return
+	}
+	if !channel.IsMember {
+		log.Printf("[slack] ✗ Not a member of channel: %s", channelID)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "not_in_channel"})
+		return
+	}
+
+	if err := update(sessionID, channelID, value); err != nil {
+		log.Printf("[slack] ✗ Failed to update %s: %v", field, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	channel, err = h.channelInfo(sessionID, channelID)
+	if err != nil {
+		writeChannelError(w, err)
+		return
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(ConversationResponse{
+		OK:               true,
+		Channel:          channel,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	})
+	log.Printf("[slack] ✓ Set %s for channel: %s", field, channelID)
+}
+
+// channelInfo loads a channel along with the bot user's membership of it
+func (h *Handler) channelInfo(sessionID, channelID string) (*ChannelInfo, error) {
+	ctx := context.Background()
+	row, err := h.queries.GetChannelByID(ctx, database.GetChannelByIDParams{
+		ID:        channelID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := h.queries.IsSlackChannelMember(ctx, database.IsSlackChannelMemberParams{
+		ChannelID: channelID,
+		UserID:    botUserID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	numMembers, err := h.queries.CountSlackChannelMembers(ctx, database.CountSlackChannelMembersParams{
+		ChannelID: channelID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelInfo{
+		ID:         row.ID,
+		Name:       row.Name,
+		Created:    row.CreatedAt,
+		IsChannel:  true,
+		IsMember:   isMember != 0,
+		NumMembers: numMembers,
+		Topic:      ChannelTopic{Value: row.Topic, Creator: row.TopicCreator, LastSet: row.TopicLastSet},
+		Purpose:    ChannelTopic{Value: row.Purpose, Creator: row.PurposeCreator, LastSet: row.PurposeLastSet},
+	}, nil
+}
+
+// writeChannelError reports a failed channel lookup; like Slack, a missing channel is a 200 with ok=false
+func writeChannelError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("[slack] ✗ Channel not found")
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "channel_not_found"})
+		return
+	}
+	log.Printf("[slack] ✗ Failed to query channel: %v", err)
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+}
+
+func (h *Handler) handleReactionsAdd(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received reactions.add request")
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	timestamp := r.FormValue("timestamp")
+	name := strings.Trim(r.FormValue("name"), ":")
+	log.Printf("[slack]   Channel: %s", channelID)
+	log.Printf("[slack]   Timestamp: %s", timestamp)
+	log.Printf("[slack]   Name: %s", name)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	if name == "" {
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_name"})
+		return
+	}
+	if _, ok := h.reactedMessage(w, sessionID, channelID, timestamp); !ok {
+		return
+	}
+
+	added, err := h.queries.CreateSlackReaction(context.Background(), database.CreateSlackReactionParams{
+		ChannelID: channelID,
+		MessageTs: timestamp,
+		Name:      name,
+		UserID:    botUserID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to store reaction: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+	if added == 0 {
+		log.Printf("[slack] ✗ Already reacted with %s", name)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "already_reacted"})
+		return
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	_ = json.NewEncoder(w).Encode(SlackResponse{
+		OK:               true,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	})
+	log.Printf("[slack] ✓ Reaction %s added to %s", name, timestamp)
+}
+
+func (h *Handler) handleReactionsGet(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received reactions.get request")
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	channelID := r.FormValue("channel")
+	timestamp := r.FormValue("timestamp")
+	log.Printf("[slack]   Channel: %s", channelID)
+	log.Printf("[slack]   Timestamp: %s", timestamp)
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	message, ok := h.reactedMessage(w, sessionID, channelID, timestamp)
+	if !ok {
+		return
+	}
+
+	rows, err := h.queries.ListSlackReactions(context.Background(), database.ListSlackReactionsParams{
+		ChannelID: channelID,
+		MessageTs: timestamp,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query reactions: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	// Group by emoji in the order each was first added
+	var reactions []Reaction
+	index := make(map[string]int)
+	for _, row := range rows {
+		i, ok := index[row.Name]
+		if !ok {
+			i = len(reactions)
+			index[row.Name] = i
+			reactions = append(reactions, Reaction{Name: row.Name, Users: []string{}})
+		}
+		reactions[i].Count++
+		reactions[i].Users = append(reactions[i].Users, row.UserID)
+	}
+
+	response := ReactionsGetResponse{
+		OK:      true,
+		Type:    "message",
+		Channel: channelID,
+		Message: Message{
+			Type:            message.Type,
+			User:            message.UserID,
+			Text:            message.Text,
+			Timestamp:       message.Timestamp,
+			ThreadTimestamp: message.ThreadTs.String,
+			Reactions:       reactions,
+		},
+	}
+	if message.Blocks.Valid {
+		response.Message.Blocks = json.RawMessage(message.Blocks.String)
+	}
+	warning, warnings := h.responseWarnings(sessionID)
+	response.Warning = warning
+	response.ResponseMetadata = warningMetadata(warnings)
+
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[slack] ✓ Returned %d reactions for %s", len(reactions), timestamp)
+}
+
+// reactedMessage looks up the message a reactions call targets, writing the Slack error when
+// it is not specified or does not exist
+func (h *Handler) reactedMessage(w http.ResponseWriter, sessionID, channelID, timestamp string) (database.GetSlackMessageRow, bool) {
+	if channelID == "" || timestamp == "" {
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "no_item_specified"})
+		return database.GetSlackMessageRow{}, false
+	}
+
+	message, err := h.queries.GetSlackMessage(context.Background(), database.GetSlackMessageParams{
+		ChannelID: channelID,
+		Timestamp: timestamp,
+		SessionID: sessionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[slack] ✗ Message not found: %s", timestamp)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "message_not_found"})
+		return database.GetSlackMessageRow{}, false
+	}
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query message: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return database.GetSlackMessageRow{}, false
+	}
+	return message, true
+}
+
+func (h *Handler) handleGetUploadURL(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received files.getUploadURLExternal request")
+
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	filename := r.FormValue("filename")
+	lengthStr := r.FormValue("length")
+	length, _ := strconv.ParseInt(lengthStr, 10, 64)
+
+	log.Printf("[slack]   Filename: %s", filename)
+	log.Printf("[slack]   Length: %d", length)
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	// Generate file ID
+	fileID := generateFileID(sessionID)
+	// Use files.slack.com so the HTTP interceptor can route it properly in tests
+	uploadURL := fmt.Sprintf("http://files.slack.com/upload/%s", fileID)
+
+	// Store file metadata in database
+	err := h.queries.CreateFile(context.Background(), database.CreateFileParams{
+		ID:        fileID,
+		Filename:  filename,
+		Title:     sql.NullString{String: filename, Valid: true},
+		Size:      length,
+		UploadUrl: sql.NullString{String: uploadURL, Valid: true},
+		UserID:    "U123456",
+		SessionID: sessionID,
+	})
+
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to store file metadata: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	response := GetUploadURLResponse{
+		OK:               true,
+		UploadURL:        uploadURL,
+		FileID:           fileID,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[slack] ✓ Generated upload URL for file ID: %s", fileID)
+}
+
+func (h *Handler) handleCompleteUpload(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received files.completeUploadExternal request")
+
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	filesJSON := r.FormValue("files")
+	channelID := r.FormValue("channel_id")
+
+	log.Printf("[slack]   Files JSON: %s", filesJSON)
+	log.Printf("[slack]   Channel ID: %s", channelID)
+
+	// Parse files array
+	var files []map[string]string
+	if err := json.Unmarshal([]byte(filesJSON), &files); err != nil {
+		log.Printf("[slack] ✗ Failed to parse files JSON: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_json"})
+		return
+	}
+
+	uploadedFiles := make([]UploadedFile, 0, len(files))
+	for _, file := range files {
+		fileID := file["id"]
+		title := file["title"]
+
+		uploadedFiles = append(uploadedFiles, UploadedFile{
+			ID:    fileID,
+			Title: title,
+		})
+
+		log.Printf("[slack]   Completed upload for file: %s (%s)", fileID, title)
+	}
+
+	warning, warnings := h.responseWarnings(session.FromContext(r.Context()))
+	response := CompleteUploadResponse{
+		OK:               true,
+		Files:            uploadedFiles,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[slack] ✓ Completed upload for %d files", len(uploadedFiles))
+}
+
+func (h *Handler) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received users.info request")
+
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	userID := r.FormValue("user")
+	log.Printf("[slack]   User ID: %s", userID)
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	// Query user from database, falling through to the parent session
+	dbUser, err := session.ReadThrough(r.Context(), "slack_users", userID, func(candidate string) (database.GetUserByIDRow, error) {
+		return h.queries.GetUserByID(context.Background(), database.GetUserByIDParams{
+			ID:        userID,
+			SessionID: candidate,
+		})
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query user: %v", err)
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "user_not_found"})
+		return
+	}
+
+	// Convert to response format
+	user := buildUser(&dbUser)
+
+	warning, warnings := h.responseWarnings(sessionID)
+	response := UserInfoResponse{
+		OK:               true,
+		User:             user,
+		Warning:          warning,
+		ResponseMetadata: warningMetadata(warnings),
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 	log.Printf("[slack] ✓ Returned user info for: %s", userID)
 }
 
+func (h *Handler) handleUsersList(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received users.list request")
+
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		log.Printf("[slack] ✗ Failed to parse form: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_form_data"})
+		return
+	}
+
+	limit := usersListLimit
+	if raw := r.FormValue("limit"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			limit = min(n, maxUsersListLimit)
+		}
+	}
+	limit, clamped := listcap.Clamp(limit)
+
+	// Extract session ID from context
+	sessionID := session.FromContext(r.Context())
+
+	offset := 0
+	filters := pagetoken.Filters(r.Form, pagingParams...)
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		decoded, err := pagetoken.Decode(sessionID, usersPageScope, filters, cursor)
+		if err != nil {
+			log.Printf("[slack] ✗ Rejected cursor: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_cursor"})
+			return
+		}
+		offset = decoded
+	}
+
+	// Request one extra to check if there are more results
+	dbUsers, err := h.queries.ListSlackUsers(context.Background(), database.ListSlackUsersParams{
+		SessionID: sessionID,
+		Limit:     int64(limit + 1),
+		Offset:    int64(offset),
+	})
+	if err != nil {
+		log.Printf("[slack] ✗ Failed to query users: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+		return
+	}
+
+	var nextCursor string
+	if len(dbUsers) > limit {
+		dbUsers = dbUsers[:limit]
+		nextCursor = pagetoken.Encode(sessionID, usersPageScope, filters, offset+limit)
+		if clamped {
+			listcap.MarkTruncated(w)
+		}
+	}
+
+	members := make([]User, 0, len(dbUsers))
+	for i := range dbUsers {
+		row := database.GetUserByIDRow(dbUsers[i])
+		members = append(members, buildUser(&row))
+	}
+
+	warning, warnings := h.responseWarnings(sessionID)
+	response := UsersListResponse{
+		OK:               true,
+		Members:          members,
+		Warning:          warning,
+		ResponseMetadata: ResponseMetadata{NextCursor: nextCursor, Warnings: warnings},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+	log.Printf("[slack] ✓ Returned %d users", len(members))
+}
+
 func (h *Handler) handleFileUpload(w http.ResponseWriter, r *http.Request) {
 	log.Println("[slack] → Received file upload request")
 
@@ -492,9 +1692,115 @@ func (h *Handler) handleFileUpload(w http.ResponseWriter, r *http.Request) {
 	log.Println("[slack] ✓ File upload successful")
 }
 
+// handleWarnings is a debug endpoint for reading and configuring the warnings attached to
+// every successful response in the session, e.g. missing_charset
+func (h *Handler) handleWarnings(w http.ResponseWriter, r *http.Request) {
+	log.Println("[slack] → Received debug warnings request")
+
+	sessionID := session.FromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var req WarningsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("[slack] ✗ Failed to parse warnings: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_json"})
+			return
+		}
+		for _, warning := range req.Warnings {
+			if warning == "" || strings.Contains(warning, ",") {
+				log.Printf("[slack] ✗ Invalid warning: %q", warning)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "invalid_arguments"})
+				return
+			}
+		}
+		err := h.queries.UpsertSlackResponseWarnings(context.Background(), database.UpsertSlackResponseWarningsParams{
+			SessionID: sessionID,
+			Warnings:  strings.Join(req.Warnings, ","),
+		})
+		if err != nil {
+			log.Printf("[slack] ✗ Failed to store warnings: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "internal_error"})
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(SlackResponse{OK: false, Error: "unknown_method"})
+		return
+	}
+
+	_, warnings := h.responseWarnings(sessionID)
+	if warnings == nil {
+		warnings = []string{}
+	}
+	_ = json.NewEncoder(w).Encode(WarningsResponse{OK: true, Warnings: warnings})
+	log.Printf("[slack] ✓ Session has %d response warnings", len(warnings))
+}
+
+// responseWarnings returns the session's configured warnings in both forms Slack reports them:
+// the comma-separated top-level warning and the response_metadata.warnings list
+func (h *Handler) responseWarnings(sessionID string) (string, []string) {
+	stored, err := h.queries.GetSlackResponseWarnings(context.Background(), sessionID)
+	if err != nil || stored == "" {
+		return "", nil
+	}
+	return stored, strings.Split(stored, ",")
+}
+
+// warningMetadata builds response_metadata for non-list methods, which only include it for warnings
+func warningMetadata(warnings []string) *ResponseMetadata {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return &ResponseMetadata{Warnings: warnings}
+}
+
+// generateScheduledMessageID generates a random scheduled message ID
+func generateScheduledMessageID(sessionID string) string {
+	b := make([]byte, 8)
+	session.RandomBytes(sessionID, b)
+	return "Q" + strings.ToUpper(hex.EncodeToString(b))
+}
+
 // generateFileID generates a random file ID
-func generateFileID() string {
+func generateFileID(sessionID string) string {
 	b := make([]byte, 8)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return "F" + hex.EncodeToString(b)
 }
+
+// buildUser converts a stored user to the API user object
+func buildUser(u *database.GetUserByIDRow) User {
+	return User{
+		ID:       u.ID,
+		TeamID:   u.TeamID,
+		Name:     u.Name,
+		Deleted:  false,
+		RealName: u.RealName,
+		TZ:       u.Timezone.String,
+		TZLabel:  u.TimezoneLabel.String,
+		TZOffset: int(u.TimezoneOffset.Int64),
+		Profile: UserProfile{
+			FirstName:   u.FirstName.String,
+			LastName:    u.LastName.String,
+			RealName:    u.RealName,
+			DisplayName: u.DisplayName.String,
+			Email:       u.Email.String,
+			Image24:     u.Image24.String,
+			Image32:     u.Image32.String,
+			Image48:     u.Image48.String,
+			Image72:     u.Image72.String,
+			Image192:    u.Image192.String,
+			Image512:    u.Image512.String,
+		},
+		IsAdmin: u.IsAdmin != 0,
+		IsOwner: u.IsOwner != 0,
+		IsBot:   u.IsBot != 0,
+		Updated: u.CreatedAt,
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/pressly/goose/v3"
@@ -219,6 +223,52 @@ func TestSlackSimulatorIntegration(t *testing.T) {
 		assert.True(t, found, "Posted message should appear in history")
 	})
 
+	t.Run("PageThroughConversations", func(t *testing.T) {
+		first, cursor, err := client.GetConversations(&slack.GetConversationsParameters{Limit: 1})
+		require.NoError(t, err, "GetConversations should not return error")
+		require.Len(t, first, 1, "Page should respect the limit")
+		require.NotEmpty(t, cursor, "A partial page should carry a next cursor")
+
+		rest, cursor, err := client.GetConversations(&slack.GetConversationsParameters{Limit: 1, Cursor: cursor})
+		require.NoError(t, err, "GetConversations should not return error")
+		require.Len(t, rest, 1, "Second page should hold the other channel")
+		assert.Empty(t, cursor, "The last page should not carry a cursor")
+		assert.NotEqual(t, first[0].ID, rest[0].ID, "Channels should not repeat across pages")
+	})
+
+	t.Run("PageThroughHistory", func(t *testing.T) {
+		for _, text := range []string{"page one", "page two", "page three"} {
+			_, _, err := client.PostMessage(channelID2, slack.MsgOptionText(text, false))
+			require.NoError(t, err, "PostMessage should succeed")
+		}
+
+		seen := make(map[string]bool)
+		params := &slack.GetConversationHistoryParameters{ChannelID: channelID2, Limit: 2}
+		for {
+			history, err := client.GetConversationHistory(params)
+			require.NoError(t, err, "GetConversationHistory should not return error")
+			assert.LessOrEqual(t, len(history.Messages), 2, "Page should respect the limit")
+			for _, msg := range history.Messages {
+				assert.False(t, seen[msg.Timestamp], "Messages should not repeat across pages")
+				seen[msg.Timestamp] = true
+			}
+			assert.Equal(t, history.ResponseMetaData.NextCursor != "", history.HasMore, "has_more should match the cursor")
+			if !history.HasMore {
+				break
+			}
+			params.Cursor = history.ResponseMetaData.NextCursor
+		}
+		assert.GreaterOrEqual(t, len(seen), 3, "Should page through every message")
+
+		resp, err := http.PostForm("https://slack.com/api/conversations.history", url.Values{
+			"channel": {channelID1},
+			"cursor":  {params.Cursor},
+		})
+		require.NoError(t, err, "Request should not fail")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Cursors should not carry over to another channel")
+	})
+
 	t.Run("AuthTest", func(t *testing.T) {
 		// Test authentication endpoint
 		response, err := client.AuthTest()
@@ -375,6 +425,83 @@ func TestSlackSimulatorUserInfo(t *testing.T) {
 	})
 }
 
+func TestSlackSimulatorUsersList(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Seed 30 users
+	sessionID := "test-session-users-list"
+	for i := range 30 {
+		err := queries.CreateUser(context.Background(), database.CreateUserParams{
+			ID:        fmt.Sprintf("U%03d_%s", i, sessionID),
+			TeamID:    "T021F9ZE2",
+			Name:      fmt.Sprintf("user-%02d", i),
+			RealName:  fmt.Sprintf("User %02d", i),
+			Email:     database.StringToNullString(fmt.Sprintf("user%02d@example.com", i)),
+			SessionID: sessionID,
+		})
+		require.NoError(t, err, "Failed to create user")
+	}
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Install HTTP interceptor to route slack.com to test server with session ID
+	http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+		"slack.com": server.URL[7:], // Strip "http://" prefix
+	}).WithSessionID(sessionID)
+
+	client := slack.New("fake-token-12345")
+
+	t.Run("PageThroughUsers", func(t *testing.T) {
+		page := client.GetUsersPaginated(slack.GetUsersOptionLimit(7))
+		var pages int
+		seen := make(map[string]bool)
+		for {
+			var err error
+			page, err = page.Next(context.Background())
+			if page.Done(err) {
+				break
+			}
+			require.NoError(t, err, "Next should not return error")
+			pages++
+			assert.LessOrEqual(t, len(page.Users), 7, "Page should respect the limit")
+			for i := range page.Users {
+				assert.False(t, seen[page.Users[i].ID], "Users should not repeat across pages")
+				seen[page.Users[i].ID] = true
+			}
+		}
+		assert.Equal(t, 5, pages, "30 users at 7 per page should take 5 pages")
+		assert.Len(t, seen, 30, "Should page through every user")
+	})
+
+	t.Run("GetUsers", func(t *testing.T) {
+		users, err := client.GetUsersContext(context.Background(), slack.GetUsersOptionLimit(10))
+
+		// Assertions
+		require.NoError(t, err, "GetUsersContext should not return error")
+		require.Len(t, users, 30, "Should return every seeded user")
+		assert.Equal(t, "user-00", users[0].Name, "Users should be listed in creation order")
+		assert.Equal(t, "user29@example.com", users[29].Profile.Email, "Profile should be populated")
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		resp, err := http.PostForm("https://slack.com/api/users.list", url.Values{"cursor": {"bogus"}})
+		require.NoError(t, err, "Request should not fail")
+		defer resp.Body.Close()
+
+		var body struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body), "Should decode response")
+		assert.False(t, body.OK, "Invalid cursor should fail")
+		assert.Equal(t, "invalid_cursor", body.Error, "Should report invalid_cursor")
+	})
+}
+
 func TestSlackSimulatorAttachments(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -438,6 +565,383 @@ func TestSlackSimulatorAttachments(t *testing.T) {
 		assert.True(t, found, "Message with attachment should appear in history")
 	})
 }
+
+func TestSlackSimulatorBlocks(t *testing.T) {
+	queries := setupTestDB(t)
+
+	sessionID := "test-session-blocks"
+	setupTestSession(t, queries, sessionID)
+	channelID1 := "C001_" + sessionID
+
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+		"slack.com": server.URL[7:], // Strip "http://" prefix
+	}).WithSessionID(sessionID)
+
+	client := slack.New("fake-token-12345")
+
+	t.Run("PostMessageWithSectionBlock", func(t *testing.T) {
+		section := slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, "*Deploy* finished", false, false),
+			nil,
+			nil,
+			slack.SectionBlockOptionBlockID("deploy-status"),
+		)
+
+		_, timestamp, err := client.PostMessage(
+			channelID1,
+			slack.MsgOptionText("Deploy finished", false),
+			slack.MsgOptionBlocks(section),
+		)
+		require.NoError(t, err, "PostMessage with blocks should not return error")
+
+		history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+			ChannelID: channelID1,
+		})
+		require.NoError(t, err, "GetConversationHistory should succeed")
+
+		var found *slack.Message
+		for i := range history.Messages {
+			if history.Messages[i].Timestamp == timestamp {
+				found = &history.Messages[i]
+			}
+		}
+		require.NotNil(t, found, "Message with blocks should appear in history")
+		require.Len(t, found.Blocks.BlockSet, 1, "One block should be returned")
+
+		block, ok := found.Blocks.BlockSet[0].(*slack.SectionBlock)
+		require.True(t, ok, "Block should be a section")
+		assert.Equal(t, "deploy-status", block.BlockID, "Block ID should round-trip")
+		assert.Equal(t, slack.MarkdownType, block.Text.Type, "Text type should round-trip")
+		assert.Equal(t, "*Deploy* finished", block.Text.Text, "Text should round-trip")
+	})
+
+	t.Run("MessageWithoutBlocksOmitsThem", func(t *testing.T) {
+		_, timestamp, err := client.PostMessage(channelID1, slack.MsgOptionText("Plain", false))
+		require.NoError(t, err, "PostMessage should succeed")
+
+		history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+			ChannelID: channelID1,
+		})
+		require.NoError(t, err, "GetConversationHistory should succeed")
+		for i := range history.Messages {
+			if history.Messages[i].Timestamp == timestamp {
+				assert.Empty(t, history.Messages[i].Blocks.BlockSet, "Plain message should have no blocks")
+			}
+		}
+	})
+
+	t.Run("InvalidBlocksRejected", func(t *testing.T) {
+		form := url.Values{"channel": {channelID1}, "text": {"Broken"}, "blocks": {"not json"}}
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			server.URL+"/api/chat.postMessage", strings.NewReader(form.Encode()))
+		require.NoError(t, err, "Failed to create request")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(session.SessionHeaderName, sessionID)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err, "Request should succeed")
+		defer resp.Body.Close()
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result), "Failed to decode response")
+		assert.Equal(t, false, result["ok"], "Invalid blocks should fail")
+		assert.Equal(t, "invalid_blocks", result["error"], "Error should be invalid_blocks")
+	})
+}
+func TestSlackSimulatorEphemeral(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "test-session-ephemeral"
+	setupTestSession(t, queries, sessionID)
+	channelID1, _, userID1, userID2 := getTestSessionIDs(sessionID)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Install HTTP interceptor to route slack.com to test server with session ID
+	http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+		"slack.com": server.URL[7:], // Strip "http://" prefix
+	}).WithSessionID(sessionID)
+
+	client := slack.New("fake-token-12345")
+
+	// listEphemerals reads the debug store for a user
+	listEphemerals := func(t *testing.T, userID string) simulatorSlack.EphemeralListResponse {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			"https://slack.com/debug/ephemerals?user="+userID, http.NoBody)
+		require.NoError(t, err, "Failed to create debug request")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "Debug request should succeed")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Expected 200 OK")
+
+		var result simulatorSlack.EphemeralListResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result), "Failed to decode response")
+		return result
+	}
+
+	t.Run("PostAndRetrieve", func(t *testing.T) {
+		timestamp, err := client.PostEphemeral(channelID1, userID1, slack.MsgOptionText("Only you can see this", false))
+		require.NoError(t, err, "PostEphemeral should not return error")
+		assert.NotEmpty(t, timestamp, "message_ts should be returned")
+
+		result := listEphemerals(t, userID1)
+		assert.True(t, result.OK, "Debug response should be ok")
+		require.Len(t, result.Messages, 1, "User should have one ephemeral message")
+		assert.Equal(t, "Only you can see this", result.Messages[0].Text, "Text should match")
+		assert.Equal(t, channelID1, result.Messages[0].Channel, "Channel should match")
+		assert.Equal(t, timestamp, result.Messages[0].Timestamp, "Timestamp should match")
+
+		assert.Empty(t, listEphemerals(t, userID2).Messages, "Other users should not see the ephemeral")
+	})
+
+	t.Run("NotInHistory", func(t *testing.T) {
+		history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+			ChannelID: channelID1,
+			Limit:     10,
+		})
+		require.NoError(t, err, "GetConversationHistory should succeed")
+		for _, msg := range history.Messages {
+			assert.NotEqual(t, "Only you can see this", msg.Text, "Ephemeral should not appear in history")
+		}
+	})
+
+	t.Run("MissingUser", func(t *testing.T) {
+		_, err := client.PostEphemeral(channelID1, "", slack.MsgOptionText("Nobody", false))
+		require.Error(t, err, "PostEphemeral without user should fail")
+		assert.Contains(t, err.Error(), "user_not_found", "Error should name the missing user")
+	})
+}
+
+func TestSlackSimulatorConversationMembership(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "test-session-membership"
+	setupTestSession(t, queries, sessionID)
+	channelID1, channelID2, _, _ := getTestSessionIDs(sessionID)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Install HTTP interceptor to route slack.com to test server with session ID
+	http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+		"slack.com": server.URL[7:], // Strip "http://" prefix
+	}).WithSessionID(sessionID)
+
+	client := slack.New("fake-token-12345")
+
+	t.Run("JoinSetTopicInfo", func(t *testing.T) {
+		channel, warning, _, err := client.JoinConversation(channelID1)
+		require.NoError(t, err, "JoinConversation should succeed")
+		assert.Empty(t, warning, "First join should not warn")
+		assert.True(t, channel.IsMember, "Bot should be a member after joining")
+		assert.Equal(t, 1, channel.NumMembers, "Channel should have one member")
+
+		channel, err = client.SetTopicOfConversation(channelID1, "Release planning")
+		require.NoError(t, err, "SetTopicOfConversation should succeed")
+		assert.Equal(t, "Release planning", channel.Topic.Value, "Topic should be returned")
+
+		_, err = client.SetPurposeOfConversation(channelID1, "Coordinate the next release")
+		require.NoError(t, err, "SetPurposeOfConversation should succeed")
+
+		info, err := client.GetConversationInfo(&slack.GetConversationInfoInput{ChannelID: channelID1})
+		require.NoError(t, err, "GetConversationInfo should succeed")
+		assert.Equal(t, "general", info.Name, "Name should match")
+		assert.True(t, info.IsMember, "Bot should still be a member")
+		assert.Equal(t, "Release planning", info.Topic.Value, "Topic should be stored")
+		assert.Equal(t, "U123456", info.Topic.Creator, "Topic creator should be the bot")
+		assert.NotZero(t, info.Topic.LastSet, "Topic last_set should be recorded")
+		assert.Equal(t, "Coordinate the next release", info.Purpose.Value, "Purpose should be stored")
+	})
+
+	t.Run("JoinTwiceWarns", func(t *testing.T) {
+		_, warning, _, err := client.JoinConversation(channelID1)
+		require.NoError(t, err, "Joining again should succeed")
+		assert.Equal(t, "already_in_channel", warning, "Second join should warn")
+	})
+
+	t.Run("Leave", func(t *testing.T) {
+		notInChannel, err := client.LeaveConversation(channelID1)
+		require.NoError(t, err, "LeaveConversation should succeed")
+		assert.False(t, notInChannel, "Bot was in the channel")
+
+		info, err := client.GetConversationInfo(&slack.GetConversationInfoInput{ChannelID: channelID1})
+		require.NoError(t, err, "GetConversationInfo should succeed")
+		assert.False(t, info.IsMember, "Bot should no longer be a member")
+		assert.Zero(t, info.NumMembers, "Channel should have no members")
+
+		notInChannel, err = client.LeaveConversation(channelID1)
+		require.NoError(t, err, "Leaving again should succeed")
+		assert.True(t, notInChannel, "Second leave should report not_in_channel")
+	})
+
+	t.Run("SetTopicRequiresMembership", func(t *testing.T) {
+		_, err := client.SetTopicOfConversation(channelID2, "Not a member")
+		require.Error(t, err, "SetTopicOfConversation should fail outside the channel")
+		assert.Contains(t, err.Error(), "not_in_channel", "Error should be not_in_channel")
+	})
+
+	t.Run("JoinNonexistentChannel", func(t *testing.T) {
+		_, _, _, err := client.JoinConversation("C_DOES_NOT_EXIST")
+		require.Error(t, err, "JoinConversation should fail")
+		assert.Contains(t, err.Error(), "channel_not_found", "Error should be channel_not_found")
+	})
+}
+
+func TestSlackSimulatorReactions(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "test-session-reactions"
+	setupTestSession(t, queries, sessionID)
+	channelID1, channelID2, _, _ := getTestSessionIDs(sessionID)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Install HTTP interceptor to route slack.com to test server with session ID
+	http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+		"slack.com": server.URL[7:], // Strip "http://" prefix
+	}).WithSessionID(sessionID)
+
+	client := slack.New("fake-token-12345")
+
+	_, timestamp, err := client.PostMessage(channelID1, slack.MsgOptionText("Deploy finished", false))
+	require.NoError(t, err, "PostMessage should succeed")
+	item := slack.NewRefToMessage(channelID1, timestamp)
+
+	t.Run("AddAndGet", func(t *testing.T) {
+		require.NoError(t, client.AddReaction("white_check_mark", item), "AddReaction should succeed")
+		require.NoError(t, client.AddReaction(":tada:", item), "AddReaction should accept colon-wrapped names")
+
+		reactions, err := client.GetReactions(item, slack.NewGetReactionsParameters())
+		require.NoError(t, err, "GetReactions should succeed")
+		require.Len(t, reactions, 2, "Message should have two reactions")
+		assert.Equal(t, "white_check_mark", reactions[0].Name, "Reactions should be in the order added")
+		assert.Equal(t, 1, reactions[0].Count, "Reaction count should be 1")
+		assert.Equal(t, []string{"U123456"}, reactions[0].Users, "Reaction should be left by the bot")
+		assert.Equal(t, "tada", reactions[1].Name, "Colons should be stripped from the name")
+	})
+
+	t.Run("AddTwice", func(t *testing.T) {
+		err := client.AddReaction("white_check_mark", item)
+		require.Error(t, err, "Repeating a reaction should fail")
+		assert.Contains(t, err.Error(), "already_reacted", "Error should be already_reacted")
+	})
+
+	t.Run("UnknownMessage", func(t *testing.T) {
+		err := client.AddReaction("eyes", slack.NewRefToMessage(channelID1, "1.000000"))
+		require.Error(t, err, "AddReaction should fail for an unknown timestamp")
+		assert.Contains(t, err.Error(), "message_not_found", "Error should be message_not_found")
+
+		err = client.AddReaction("eyes", slack.NewRefToMessage(channelID2, timestamp))
+		require.Error(t, err, "AddReaction should fail when the timestamp is in another channel")
+		assert.Contains(t, err.Error(), "message_not_found", "Error should be message_not_found")
+
+		_, err = client.GetReactions(slack.NewRefToMessage(channelID1, "1.000000"), slack.NewGetReactionsParameters())
+		require.Error(t, err, "GetReactions should fail for an unknown timestamp")
+		assert.Contains(t, err.Error(), "message_not_found", "Error should be message_not_found")
+	})
+
+	t.Run("NoReactions", func(t *testing.T) {
+		_, other, err := client.PostMessage(channelID1, slack.MsgOptionText("No reactions here", false))
+		require.NoError(t, err, "PostMessage should succeed")
+
+		reactions, err := client.GetReactions(slack.NewRefToMessage(channelID1, other), slack.NewGetReactionsParameters())
+		require.NoError(t, err, "GetReactions should succeed")
+		assert.Empty(t, reactions, "Message should have no reactions")
+	})
+
+	t.Run("SessionIsolation", func(t *testing.T) {
+		http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+			"slack.com": server.URL[7:],
+		}).WithSessionID("test-session-reactions-other")
+
+		_, err := client.GetReactions(item, slack.NewGetReactionsParameters())
+		require.Error(t, err, "Another session should not see the message")
+		assert.Contains(t, err.Error(), "message_not_found", "Error should be message_not_found")
+	})
+}
+
+func TestSlackSimulatorResponseWarnings(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "test-session-warnings"
+	setupTestSession(t, queries, sessionID)
+	channelID1, channelID2, _, _ := getTestSessionIDs(sessionID)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// callMethod posts a form to a Slack method and decodes the raw JSON response
+	callMethod := func(t *testing.T, method string, form url.Values) map[string]interface{} {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			server.URL+"/api/"+method, strings.NewReader(form.Encode()))
+		require.NoError(t, err, "Failed to create request")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(session.SessionHeaderName, sessionID)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err, "Request should succeed")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Expected 200 OK")
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result), "Failed to decode response")
+		return result
+	}
+
+	t.Run("ListsIncludeEmptyMetadata", func(t *testing.T) {
+		result := callMethod(t, "conversations.list", url.Values{})
+		assert.Equal(t, map[string]interface{}{}, result["response_metadata"], "Metadata should be an empty object")
+
+		result = callMethod(t, "chat.postMessage", url.Values{"channel": {channelID2}, "text": {"No warnings"}})
+		assert.NotContains(t, result, "response_metadata", "Non-list responses should omit empty metadata")
+		assert.NotContains(t, result, "warning", "No warning should be reported")
+	})
+
+	t.Run("PostMessageWarning", func(t *testing.T) {
+		body := bytes.NewReader([]byte(`{"warnings": ["missing_charset"]}`))
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, server.URL+"/debug/warnings", body)
+		require.NoError(t, err, "Failed to create warnings request")
+		req.Header.Set(session.SessionHeaderName, sessionID)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err, "Warnings request should succeed")
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode, "Expected 200 OK")
+
+		result := callMethod(t, "chat.postMessage", url.Values{"channel": {channelID1}, "text": {"With warnings"}})
+		assert.Equal(t, "missing_charset", result["warning"], "Top-level warning should be set")
+		metadata, ok := result["response_metadata"].(map[string]interface{})
+		require.True(t, ok, "response_metadata should be an object")
+		assert.Equal(t, []interface{}{"missing_charset"}, metadata["warnings"], "Warning should appear in response_metadata")
+
+		result = callMethod(t, "conversations.history", url.Values{"channel": {channelID1}})
+		metadata, ok = result["response_metadata"].(map[string]interface{})
+		require.True(t, ok, "response_metadata should be an object")
+		assert.Equal(t, []interface{}{"missing_charset"}, metadata["warnings"], "Lists should report warnings too")
+	})
+}
+
 func TestSlackSimulatorTimeout(t *testing.T) {
 	// Setup: Create test database
 	queries := setupTestDB(t)
@@ -492,3 +996,88 @@ func TestSlackSimulatorRateLimit(t *testing.T) {
 		testutil.TestMiddlewareRateLimitIsolation(t, makeHandler, makeRequest, "slack")
 	})
 }
+
+func TestSlackSimulatorThreads(t *testing.T) {
+	// Setup: Create test database
+	queries := setupTestDB(t)
+
+	// Setup: Create test session
+	sessionID := "test-session-threads"
+	setupTestSession(t, queries, sessionID)
+	channelID1, _, _, _ := getTestSessionIDs(sessionID)
+
+	// Setup: Start simulator server with session middleware
+	handler := session.Middleware(simulatorSlack.NewHandler(queries))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Install HTTP interceptor to route slack.com to test server with session ID
+	http.DefaultTransport = transport.NewSimulatorTransport(map[string]string{
+		"slack.com": server.URL[7:], // Strip "http://" prefix
+	}).WithSessionID(sessionID)
+
+	client := slack.New("fake-token-12345")
+
+	_, parentTS, err := client.PostMessage(channelID1, slack.MsgOptionText("Release checklist", false))
+	require.NoError(t, err, "PostMessage should succeed")
+	_, firstReplyTS, err := client.PostMessage(channelID1, slack.MsgOptionText("Tests passed", false), slack.MsgOptionTS(parentTS))
+	require.NoError(t, err, "Reply should succeed")
+	_, _, err = client.PostMessage(channelID1, slack.MsgOptionText("Tagged v1.2.0", false), slack.MsgOptionTS(parentTS))
+	require.NoError(t, err, "Reply should succeed")
+	_, _, err = client.PostMessage(channelID1, slack.MsgOptionText("Unrelated update", false))
+	require.NoError(t, err, "PostMessage should succeed")
+
+	t.Run("Replies", func(t *testing.T) {
+		messages, hasMore, _, err := client.GetConversationReplies(&slack.GetConversationRepliesParameters{
+			ChannelID: channelID1,
+			Timestamp: parentTS,
+		})
+		require.NoError(t, err, "GetConversationReplies should succeed")
+		assert.False(t, hasMore, "Thread should fit in one page")
+		require.Len(t, messages, 3, "Thread should hold the parent and two replies")
+		assert.Equal(t, "Release checklist", messages[0].Text, "Parent should come first")
+		assert.Equal(t, 2, messages[0].ReplyCount, "Parent should count its replies")
+		assert.Equal(t, "Tests passed", messages[1].Text, "Replies should be oldest first")
+		assert.Equal(t, "Tagged v1.2.0", messages[2].Text, "Replies should be oldest first")
+		for _, msg := range messages {
+			assert.Equal(t, parentTS, msg.ThreadTimestamp, "Every message should carry the thread timestamp")
+		}
+	})
+
+	t.Run("RepliesFromReplyTimestamp", func(t *testing.T) {
+		messages, _, _, err := client.GetConversationReplies(&slack.GetConversationRepliesParameters{
+			ChannelID: channelID1,
+			Timestamp: firstReplyTS,
+		})
+		require.NoError(t, err, "GetConversationReplies should succeed for a reply timestamp")
+		require.Len(t, messages, 3, "A reply should resolve to its whole thread")
+		assert.Equal(t, parentTS, messages[0].Timestamp, "Parent should come first")
+	})
+
+	t.Run("HistoryHidesReplies", func(t *testing.T) {
+		history, err := client.GetConversationHistory(&slack.GetConversationHistoryParameters{ChannelID: channelID1})
+		require.NoError(t, err, "GetConversationHistory should succeed")
+
+		var parent *slack.Message
+		for i := range history.Messages {
+			msg := &history.Messages[i]
+			assert.NotEqual(t, "Tests passed", msg.Text, "Replies should not appear in history")
+			assert.NotEqual(t, "Tagged v1.2.0", msg.Text, "Replies should not appear in history")
+			if msg.Timestamp == parentTS {
+				parent = msg
+			}
+		}
+		require.NotNil(t, parent, "Parent should appear in history")
+		assert.Equal(t, 2, parent.ReplyCount, "Parent should report its reply count")
+		assert.Equal(t, parentTS, parent.ThreadTimestamp, "Parent should carry its own thread timestamp")
+	})
+
+	t.Run("UnknownThread", func(t *testing.T) {
+		_, _, _, err := client.GetConversationReplies(&slack.GetConversationRepliesParameters{
+			ChannelID: channelID1,
+			Timestamp: "1.000000",
+		})
+		require.Error(t, err, "GetConversationReplies should fail for an unknown timestamp")
+		assert.Contains(t, err.Error(), "thread_not_found", "Error should be thread_not_found")
+	})
+}
@@ -2,7 +2,6 @@ package whatsapp
 
 import (
 	"context"
-	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
@@ -88,6 +87,15 @@ func NewHandler(queries *database.Queries) *Handler {
 	}
 }
 
+// CountObjects reports how many objects of each kind the session holds
+func CountObjects(ctx context.Context, queries *database.Queries, sessionID string) (map[string]int64, error) {
+	count, err := queries.CountWhatsAppSessionObjects(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"messages": count}, nil
+}
+
 // ServeHTTP implements http.Handler interface
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[whatsapp] → %s %s", r.Method, r.URL.Path)
@@ -142,12 +150,12 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request, phon
 		return
 	}
 
-	// Generate message ID
-	messageID := generateMessageID()
-
 	// Extract session ID from context
 	sessionID := session.FromContext(r.Context())
 
+	// Generate message ID
+	messageID := generateMessageID(sessionID)
+
 	// Parse message type and extract content
 	var textBody, mediaURL, caption, templateName, languageCode sql.NullString
 
@@ -232,8 +240,8 @@ func (h *Handler) handleSendMessage(w http.ResponseWriter, r *http.Request, phon
 
 // Helper functions
 
-func generateMessageID() string {
+func generateMessageID(sessionID string) string {
 	b := make([]byte, 16)
-	_, _ = rand.Read(b)
+	session.RandomBytes(sessionID, b)
 	return "wamid." + hex.EncodeToString(b)
 }